@@ -8,42 +8,72 @@ package main
 import (
 	"cmp"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"maps"
+	"math/rand/v2"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/VuteTech/Bor/agent/internal/compliancecheck"
 	"github.com/VuteTech/Bor/agent/internal/config"
+	"github.com/VuteTech/Bor/agent/internal/contactloss"
 	"github.com/VuteTech/Bor/agent/internal/filewatcher"
+	"github.com/VuteTech/Bor/agent/internal/logbuffer"
+	"github.com/VuteTech/Bor/agent/internal/metrics"
 	"github.com/VuteTech/Bor/agent/internal/notify"
 	"github.com/VuteTech/Bor/agent/internal/policy"
 	"github.com/VuteTech/Bor/agent/internal/policyclient"
+	"github.com/VuteTech/Bor/agent/internal/precondition"
 	"github.com/VuteTech/Bor/agent/internal/procinfo"
+	"github.com/VuteTech/Bor/agent/internal/remediation"
 	"github.com/VuteTech/Bor/agent/internal/sysinfo"
+	"github.com/VuteTech/Bor/agent/internal/transform"
 	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
 	"google.golang.org/protobuf/proto"
 )
 
 const defaultConfigPath = "/etc/bor/config.yaml"
 
+// exitNeedsEnrollment is the exit status used when the server has rejected
+// the agent's credentials and they have been discarded. It is EX_CONFIG from
+// sysexits(3), so the service manager records the unit as failed.
+const exitNeedsEnrollment = 78
+
+// errEnrollmentRejected is returned by runStreamingLoop when the server has
+// rejected the agent's identity too many times in a row.
+var errEnrollmentRejected = errors.New("server rejected agent credentials")
+
 // Version is set at build time via -ldflags "-X main.Version=x.y.z".
 var Version = "dev"
 
-// kconfigCache maps policy ID → typed KConfig policy for all active Kconfig policies.
+// kconfigCacheEntry holds a KConfig policy alongside its binding priority
+// and, for policies bound to user groups, the logged-in users it applies to.
+type kconfigCacheEntry struct {
+	priority   int32
+	policy     *pb.KConfigPolicy
+	scopeUsers []string
+}
+
+// kconfigCache maps policy ID → typed KConfig policy + priority for all active Kconfig policies.
 // It is maintained across streaming events so that a full re-merge and
 // sync can be performed whenever any single policy changes or is deleted.
-var kconfigCache = make(map[string]*pb.KConfigPolicy)
+var kconfigCache = make(map[string]kconfigCacheEntry)
 
 // kconfigSnapshotStaging accumulates KConfig policies during a SNAPSHOT.
 // It is nil when not inside a snapshot sequence.
-var kconfigSnapshotStaging map[string]*pb.KConfigPolicy
+var kconfigSnapshotStaging map[string]kconfigCacheEntry
 
 // kdeNotifier handles desktop notifications and app reconfigure via D-Bus.
 var kdeNotifier = notify.New()
@@ -56,11 +86,17 @@ var notifyConfig = notify.Config{
 	Message:  "Desktop policies have been updated. Please log out and log back in for all changes to take effect.",
 }
 
-// firefoxCache maps policy ID → proto policy for all active Firefox policies.
-var firefoxCache = make(map[string]*pb.FirefoxPolicy)
+// firefoxCacheEntry holds a Firefox policy alongside its binding priority.
+type firefoxCacheEntry struct {
+	priority int32
+	policy   *pb.FirefoxPolicy
+}
+
+// firefoxCache maps policy ID → proto policy + priority for all active Firefox policies.
+var firefoxCache = make(map[string]firefoxCacheEntry)
 
 // firefoxSnapshotStaging accumulates Firefox proto policies during a SNAPSHOT.
-var firefoxSnapshotStaging map[string]*pb.FirefoxPolicy
+var firefoxSnapshotStaging map[string]firefoxCacheEntry
 
 // firefoxNotifier handles desktop notifications for Firefox policy changes.
 var firefoxNotifier = notify.New()
@@ -72,12 +108,18 @@ var firefoxNotifyConfig = notify.Config{
 	Message:  "Firefox policies have been updated. Please restart Firefox for all changes to take effect.",
 }
 
-// chromeCache maps policy ID → proto policy for all active Chrome policies.
-var chromeCache = make(map[string]*pb.ChromePolicy)
+// chromeCacheEntry holds a Chrome policy alongside its binding priority.
+type chromeCacheEntry struct {
+	priority int32
+	policy   *pb.ChromePolicy
+}
+
+// chromeCache maps policy ID → proto policy + priority for all active Chrome policies.
+var chromeCache = make(map[string]chromeCacheEntry)
 
 // chromeSnapshotStaging accumulates Chrome proto policies during a SNAPSHOT.
 // It is nil when not inside a snapshot sequence.
-var chromeSnapshotStaging map[string]*pb.ChromePolicy
+var chromeSnapshotStaging map[string]chromeCacheEntry
 
 // chromeNotifier handles desktop notifications for Chrome policy changes.
 var chromeNotifier = notify.New()
@@ -121,13 +163,69 @@ var polkitCache = make(map[string]polkitCacheEntry)
 // polkitSnapshotStaging accumulates Polkit policies during a SNAPSHOT.
 var polkitSnapshotStaging map[string]polkitCacheEntry
 
+// sysctlCacheEntry holds a Sysctl policy alongside its binding priority.
+type sysctlCacheEntry struct {
+	priority int32
+	policy   *pb.SysctlPolicy
+}
+
+// sysctlCache maps policy ID → Sysctl policy + priority for all active Sysctl policies.
+var sysctlCache = make(map[string]sysctlCacheEntry)
+
+// sysctlSnapshotStaging accumulates Sysctl policies during a SNAPSHOT.
+var sysctlSnapshotStaging map[string]sysctlCacheEntry
+
 // polkitActionsReported tracks whether the polkit action catalogue has been
 // reported to the server in this agent session.
 var polkitActionsReported bool
 
+// contactRules tracks each policy's dead-man's-switch rule and the time
+// since the server was last reachable.
+var contactRules = contactloss.New()
+
+// preconditions holds the agent-evaluated preconditions of all cached
+// policies.
+var preconditions = precondition.New()
+
+// applyMu serialises policy application between the policy stream and the
+// periodic precondition re-check. Remediation actions also hold it.
+var applyMu sync.Mutex
+
+// appliedPolicies holds every policy currently applied, by ID, as it is
+// persisted to the offline policy cache. appliedPoliciesStaging collects a
+// snapshot until it is complete.
+var (
+	appliedPolicies        = make(map[string]*policyclient.PolicyInfo)
+	appliedPoliciesStaging map[string]*policyclient.PolicyInfo
+)
+
+// complianceChecks holds the check commands of all cached policies.
+var complianceChecks = compliancecheck.NewSet()
+
+// complianceCheckTrigger requests an immediate run of all compliance checks.
+var complianceCheckTrigger = make(chan struct{}, 1)
+
 // fileWatcher monitors Bor-managed files and restores them when modified externally.
 var fileWatcher *filewatcher.FileWatcher
 
+// agentMetrics tracks sync and stream state for /healthz and /metrics.
+var agentMetrics = metrics.New()
+
+// recentLogs keeps the most recent agent log output for on-demand upload
+// to the server (POST /api/v1/nodes/{id}/collect-logs).
+var recentLogs = logbuffer.New(512 << 10)
+
+// serverInventoryOnly is set from the latest policy snapshot when the node
+// belongs to an inventory-only node group.
+var serverInventoryOnly bool
+
+// inventoryOnly reports whether policies are only monitored: the agent
+// evaluates compliance but never writes policy targets or notifies users.
+// Files written before the mode was enabled are left as they are.
+func inventoryOnly(cfg *config.Config) bool {
+	return cfg.Agent.InventoryOnly || serverInventoryOnly
+}
+
 // resolveEnrollToken returns the enrollment token from the most secure
 // available source: --token-file > BOR_ENROLLMENT_TOKEN > --token.
 func resolveEnrollToken(cliToken, tokenFilePath string) string {
@@ -151,6 +249,22 @@ func resolveEnrollToken(cliToken, tokenFilePath string) string {
 	return cliToken
 }
 
+// readReenrollToken returns the token from the pre-placed re-enrollment
+// token file, or "" if the file is not configured, absent or empty.
+func readReenrollToken(path string) string {
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path from trusted config
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Warning: failed to read re-enrollment token file %s: %v", path, err)
+		}
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
 func main() {
 	configPath := flag.String("config", defaultConfigPath, "path to configuration file")
 	enrollToken := flag.String("token", "", "one-time enrollment token (deprecated: use --token-file or BOR_ENROLLMENT_TOKEN)")
@@ -161,6 +275,7 @@ func main() {
 	resolvedToken := resolveEnrollToken(*enrollToken, *enrollTokenFile)
 
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	log.SetOutput(io.MultiWriter(os.Stderr, recentLogs))
 	log.Println("Bor Agent starting")
 
 	cfg, err := config.Load(*configPath)
@@ -171,6 +286,9 @@ func main() {
 	log.Printf("Server enrollment: %s  policy: %s", cfg.Server.EnrollmentAddr(), cfg.Server.PolicyAddr())
 	log.Printf("Client ID: %s", cfg.Agent.ClientID)
 
+	checkPolicyTargets(cfg)
+	configureNotifiers(cfg)
+
 	// ─── Enrollment / mTLS bootstrap ──────────────────────────────────
 	paths := policyclient.DefaultPaths(cfg.Enrollment.DataDir)
 
@@ -185,6 +303,18 @@ func main() {
 		}
 	}
 
+	// A token pre-placed in the re-enrollment file is used when no token
+	// was supplied explicitly. Unlike interactive enrollment, the agent keeps
+	// running afterwards so that a service restart completes the recovery.
+	reenrolling := false
+	if resolvedToken == "" && !policyclient.IsEnrolled(paths) {
+		if token := readReenrollToken(cfg.Enrollment.ReenrollTokenFile); token != "" {
+			log.Printf("Using re-enrollment token from %s", cfg.Enrollment.ReenrollTokenFile)
+			resolvedToken = token
+			reenrolling = true
+		}
+	}
+
 	if !policyclient.IsEnrolled(paths) {
 		enrolled := false
 
@@ -232,9 +362,17 @@ func main() {
 			); enrollErr != nil {
 				log.Fatalf("Enrollment failed: %v", enrollErr)
 			}
+			if reenrolling {
+				if removeErr := os.Remove(cfg.Enrollment.ReenrollTokenFile); removeErr != nil {
+					log.Printf("Warning: failed to remove used re-enrollment token %s: %v", cfg.Enrollment.ReenrollTokenFile, removeErr)
+				}
+			}
 		}
 
-		fmt.Printf(`
+		if reenrolling {
+			log.Printf("Re-enrollment successful. Certificates stored in %s", cfg.Enrollment.DataDir)
+		} else {
+			fmt.Printf(`
 Enrollment successful. Certificates stored in %s
 
 To enable and start the Bor agent service, run:
@@ -250,23 +388,28 @@ To follow the agent logs:
     sudo journalctl -u bor-agent -f
 
 `, cfg.Enrollment.DataDir)
-		os.Exit(0)
+			os.Exit(0)
+		}
 	}
 	log.Println("Agent is enrolled – using mTLS credentials")
+	agentMetrics.SetEnrolled(true)
 
 	agentAddr := cfg.Server.PolicyAddr()
 
 	// ─── Certificate renewal check ────────────────────────────────────
-	// Renew the agent certificate if it expires within 30 days.
-	const renewThreshold = 30 * 24 * time.Hour
-	expiring, expiryErr := policyclient.CertExpiringSoon(paths.CertFile, renewThreshold)
-	if expiryErr != nil {
-		log.Printf("Warning: could not check cert expiry: %v", expiryErr)
-	} else if expiring {
-		log.Println("Certificate expires within 30 days, renewing...")
-		if renewErr := policyclient.RenewCertificate(agentAddr, paths.CACert, paths.CertFile, paths.KeyFile); renewErr != nil {
-			log.Printf("Warning: certificate renewal failed: %v — will retry next cycle", renewErr)
-		}
+	// Renew the agent certificate once less than a third of its lifetime
+	// remains. The check is repeated periodically below so that short-lived
+	// certificates are rotated without restarting the agent.
+	renewCertIfDue(agentAddr, paths)
+
+	// The server only accepts the node name in the certificate as client
+	// ID, which differs from the configured one if e.g. the hostname
+	// changed after enrollment.
+	if cn, err := policyclient.CertCommonName(paths.CertFile); err != nil {
+		log.Printf("Failed to read the node name from the agent certificate: %v", err)
+	} else if cn != cfg.Agent.ClientID {
+		log.Printf("Using client ID %q from the agent certificate instead of %q", cn, cfg.Agent.ClientID)
+		cfg.Agent.ClientID = cn
 	}
 
 	// ─── Connect with mTLS credentials ────────────────────────────────
@@ -277,11 +420,19 @@ To follow the agent logs:
 		paths.CertFile, // agent client cert signed by CA
 		paths.KeyFile,  // agent private key
 		false,          // never skip verify after enrollment – we have the CA cert
+		policyclient.Keepalive{
+			Interval: time.Duration(cfg.Server.KeepaliveIntervalSeconds) * time.Second,
+			Timeout:  time.Duration(cfg.Server.KeepaliveTimeoutSeconds) * time.Second,
+		},
 	)
 	if err != nil {
 		log.Fatalf("Failed to create policy client: %v", err)
 	}
 	defer func() { _ = client.Close() }()
+	client.SetAgentVersion(Version)
+	if err := client.SetComplianceQueue(policyclient.ComplianceQueueFile(cfg.Enrollment.DataDir), cfg.Agent.ComplianceQueueSize); err != nil {
+		log.Printf("Warning: discarding compliance queue: %v", err)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -296,6 +447,36 @@ To follow the agent logs:
 		cancel()
 	}()
 
+	client.SetLogRequestHandler(func(requestID string) {
+		go uploadRecentLogs(ctx, client, requestID)
+	})
+	remediator := remediation.NewRunner(cfg.Remediation)
+	client.SetRemediationHandler(func(requestID, action string) {
+		go runRemediation(ctx, client, remediator, requestID, action)
+	})
+
+	if hook := transform.New(cfg.ContentTransform); hook != nil {
+		policy.SetContentTransform(hook.Transform)
+		log.Printf("Content transform hook enabled: %s", cfg.ContentTransform.Command)
+	}
+
+	go runCertRenewalLoop(ctx, agentAddr, paths)
+	go runComplianceCheckLoop(ctx, client, cfg)
+	go runHeartbeatLoop(ctx, client, cfg)
+	go runPreconditionLoop(ctx, client, cfg)
+	go runSessionWatchLoop(ctx, client)
+
+	if cfg.Agent.MetricsAddr != "" {
+		metricsServer := metrics.NewServer(cfg.Agent.MetricsAddr, agentMetrics)
+		go func() {
+			log.Printf("Metrics and health checks listening on http://%s/metrics and /healthz", cfg.Agent.MetricsAddr)
+			if err := metricsServer.ListenAndServe(); err != http.ErrServerClosed {
+				log.Printf("Metrics server error: %v", err)
+			}
+		}()
+		defer func() { _ = metricsServer.Close() }()
+	}
+
 	// Start the file watcher to restore managed files if tampered externally.
 	var watcherErr error
 	fileWatcher, watcherErr = filewatcher.New(func(path string) {
@@ -309,51 +490,166 @@ To follow the agent logs:
 		log.Println("File watcher started")
 	}
 
+	// Enforce the last-known policies until the server can be reached.
+	lastRevision := applyOfflineCache(ctx, client, cfg)
+
 	// Run the policy enforcement loop — prefer streaming, fall back to polling.
-	runStreamingLoop(ctx, client, cfg)
+	if loopErr := runStreamingLoop(ctx, client, cfg, lastRevision); errors.Is(loopErr, errEnrollmentRejected) {
+		log.Printf("The server rejected this agent %d times in a row (certificate revoked or node deleted).",
+			cfg.Enrollment.RejectionThreshold)
+		if removeErr := policyclient.RemoveEnrollmentCerts(paths); removeErr != nil {
+			log.Printf("Failed to remove stale enrollment certificates: %v", removeErr)
+		} else {
+			log.Printf("Stale credentials removed from %s – this agent needs to be enrolled again.", cfg.Enrollment.DataDir)
+		}
+		if cfg.Enrollment.ReenrollTokenFile != "" {
+			log.Printf("To re-enroll, generate a token from the Node Groups page in the Bor web UI and write it to %s; "+
+				"the agent enrolls with it on the next start.", cfg.Enrollment.ReenrollTokenFile)
+		} else {
+			log.Println("To re-enroll, run: bor-agent --token-file <PATH>")
+		}
+		_ = client.Close()
+		os.Exit(exitNeedsEnrollment)
+	}
 
 	log.Println("Bor Agent stopped")
 }
 
+// certRenewalInterval is how often the agent re-checks whether its
+// certificate is due for renewal.
+const certRenewalInterval = time.Hour
+
+// renewCertIfDue renews the agent certificate when it is due. Failures are
+// logged and retried on the next check while the current cert is valid.
+func renewCertIfDue(agentAddr string, paths policyclient.EnrollmentPaths) {
+	due, err := policyclient.CertRenewalDue(paths.CertFile)
+	if err != nil {
+		log.Printf("Warning: could not check cert expiry: %v", err)
+		return
+	}
+	if !due {
+		return
+	}
+	log.Println("Certificate is due for renewal, renewing...")
+	if err := policyclient.RenewCertificate(agentAddr, paths.CACert, paths.CertFile, paths.KeyFile); err != nil {
+		log.Printf("Warning: certificate renewal failed: %v — will retry next cycle", err)
+	}
+}
+
+// runCertRenewalLoop calls renewCertIfDue every certRenewalInterval until
+// ctx is cancelled.
+func runCertRenewalLoop(ctx context.Context, agentAddr string, paths policyclient.EnrollmentPaths) {
+	ticker := time.NewTicker(certRenewalInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			renewCertIfDue(agentAddr, paths)
+		}
+	}
+}
+
+// triggerComplianceChecks asks the check loop to run all checks now.
+func triggerComplianceChecks() {
+	select {
+	case complianceCheckTrigger <- struct{}{}:
+	default:
+	}
+}
+
+// runComplianceCheckLoop runs the policies' compliance check commands after
+// policy changes and every compliance_checks.interval_seconds, reporting
+// each result to the server. Agents that have not opted in only report
+// the checks as inapplicable after policy changes.
+func runComplianceCheckLoop(ctx context.Context, client *policyclient.Client, cfg *config.Config) {
+	runner := compliancecheck.NewRunner(cfg.ComplianceChecks)
+	ticker := time.NewTicker(time.Duration(cfg.ComplianceChecks.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-complianceCheckTrigger:
+		case <-ticker.C:
+			if !runner.Enabled() {
+				continue
+			}
+		}
+		for id, chk := range complianceChecks.All() {
+			if !contactRules.Enforced(id) {
+				continue
+			}
+			if met, _ := preconditions.Met(id); !met {
+				continue
+			}
+			res := runner.Run(ctx, chk)
+			if err := client.ReportCheckResult(ctx, id, res.Status, res.ExitCode, res.Message); err != nil {
+				log.Printf("Failed to report compliance check for policy %s: %v", id, err)
+			}
+		}
+	}
+}
+
 // runStreamingLoop connects to the server's SubscribePolicyUpdates
 // stream and applies policies as they arrive. On stream failure it
-// reconnects with exponential backoff. The last known revision is
-// sent on each reconnect so the server can send a delta or snapshot.
-func runStreamingLoop(ctx context.Context, client *policyclient.Client, cfg *config.Config) {
-	var lastRevision int64
+// reconnects with exponential backoff. The last known revision, starting
+// at lastRevision, is sent on each reconnect so the server can send a
+// delta or snapshot.
+//
+// After cfg.Server.StreamFailuresBeforePolling consecutive attempts that
+// fail because the stream is unimplemented or unavailable, each further
+// attempt is preceded by a poll (see pollPolicies) and spaced by the poll
+// interval, so the agent keeps enforcing its policies against servers or
+// proxies without working streaming and returns to streaming as soon as
+// the stream succeeds again.
+//
+// It returns nil when ctx is cancelled, or errEnrollmentRejected once the
+// server has rejected the agent's identity on
+// cfg.Enrollment.RejectionThreshold consecutive connection attempts.
+func runStreamingLoop(ctx context.Context, client *policyclient.Client, cfg *config.Config, lastRevision int64) error {
 	backoff := time.Second
+	rejections := 0
+	streamFailures := 0
+	var pollInitialSync bool
 
-	for {
+	for attempt := 0; ; attempt++ {
 		select {
 		case <-ctx.Done():
-			return
+			return nil
 		default:
 		}
+		if attempt > 0 {
+			agentMetrics.Reconnected()
+		}
 
-		log.Printf("Connecting to policy stream (last_known_revision=%d)...", lastRevision)
-
-		// Fetch notification settings from the server on each connect.
-		if agentCfg, err := client.GetAgentConfig(ctx); err != nil {
-			log.Printf("Failed to fetch agent config (using defaults): %v", err)
-		} else {
-			notifyConfig = notify.Config{
-				Enabled:  agentCfg.NotifyUsers,
-				Cooldown: time.Duration(agentCfg.NotifyCooldown) * time.Second,
-				Message:  agentCfg.NotifyMessage,
+		if streamFailures >= cfg.Server.StreamFailuresBeforePolling {
+			agentMetrics.SetStreamState(metrics.StreamPolling)
+			if streamFailures == cfg.Server.StreamFailuresBeforePolling {
+				log.Printf("Policy stream unavailable %d times in a row — polling every %ds until it recovers",
+					streamFailures, cfg.Server.PollIntervalSeconds)
 			}
-			log.Printf("Agent notification config: enabled=%v cooldown=%v", notifyConfig.Enabled, notifyConfig.Cooldown)
-			firefoxNotifyConfig = notify.Config{
-				Enabled:  agentCfg.NotifyUsers,
-				Cooldown: time.Duration(agentCfg.NotifyCooldown) * time.Second,
-				Message:  agentCfg.NotifyMessageFirefox,
+			if err := pollPolicies(ctx, client, cfg, &pollInitialSync); err != nil {
+				log.Printf("Policy poll failed: %v", err)
 			}
-			chromeNotifyConfig = notify.Config{
-				Enabled:  agentCfg.NotifyUsers,
-				Cooldown: time.Duration(agentCfg.NotifyCooldown) * time.Second,
-				Message:  agentCfg.NotifyMessageChrome,
+			// The polled state has no revision; take a full snapshot once
+			// the stream works again.
+			lastRevision = 0
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Duration(cfg.Server.PollIntervalSeconds) * time.Second):
 			}
 		}
 
+		log.Printf("Connecting to policy stream (last_known_revision=%d)...", lastRevision)
+		agentMetrics.SetStreamState(metrics.StreamConnecting)
+
+		// Fetch notification settings from the server on each connect.
+		refreshAgentConfig(ctx, client, cfg)
+
 		// Send heartbeat on connect to report current metadata.
 		go sendHeartbeat(ctx, client)
 
@@ -401,26 +697,98 @@ func runStreamingLoop(ctx context.Context, client *policyclient.Client, cfg *con
 		}
 
 		var postInitialSync bool
+		var received bool
 		err := client.SubscribePolicyUpdates(ctx, lastRevision,
-			func(updateType string, pi *policyclient.PolicyInfo, revision int64, snapshotComplete bool) {
+			func(updateType string, pi *policyclient.PolicyInfo, revision int64, snapshotComplete, inventoryOnly bool) {
+				if !received {
+					agentMetrics.SetStreamState(metrics.StreamConnected)
+					go flushComplianceQueue(ctx, client)
+				}
+				received = true
+				contactRules.Connected()
 				// Don't let METADATA_REQUEST overwrite the last known revision.
 				if updateType != "METADATA_REQUEST" {
 					lastRevision = revision
 				}
+				if updateType == "SNAPSHOT" && snapshotComplete {
+					setServerInventoryOnly(inventoryOnly)
+				}
+				applyMu.Lock()
 				handlePolicyUpdate(ctx, client, cfg, updateType, pi, snapshotComplete, &postInitialSync)
+				if updateType != "METADATA_REQUEST" && (updateType != "SNAPSHOT" || snapshotComplete) {
+					saveOfflineCache(cfg, revision)
+				}
+				if updateType == "SNAPSHOT" && snapshotComplete {
+					agentMetrics.Synced()
+				}
+				applyMu.Unlock()
 			},
 		)
 
 		if ctx.Err() != nil {
-			return // parent context cancelled — shutting down
+			return nil // parent context cancelled — shutting down
+		}
+		agentMetrics.SetStreamState(metrics.StreamDisconnected)
+
+		// Apply dead-man's-switch transitions while the server is unreachable.
+		// Checked once per reconnect attempt, i.e. at least once a minute.
+		contactRules.Disconnected()
+		resyncOnStateChange(ctx, client, cfg)
+
+		// Count consecutive failures of an unusable stream; any update
+		// received means streaming works.
+		switch {
+		case received:
+			if streamFailures >= cfg.Server.StreamFailuresBeforePolling {
+				log.Println("Policy stream recovered — stopped polling")
+			}
+			streamFailures = 0
+			pollInitialSync = false
+		case policyclient.IsStreamUnavailable(err):
+			streamFailures++
+		default:
+			streamFailures = 0
+		}
+		if streamFailures >= cfg.Server.StreamFailuresBeforePolling {
+			// The poll interval spaces the next attempt.
+			log.Printf("Policy stream unavailable: %v", err)
+			rejections = 0
+			continue
+		}
+
+		// Count consecutive identity rejections. A session that received
+		// any update proves the credentials were accepted.
+		switch {
+		case errors.Is(err, policyclient.ErrServerGoingAway):
+			// A planned server restart: start over with the shortest
+			// back-off once the server's reconnect delay has passed.
+			rejections = 0
+			backoff = time.Second
+		case received:
+			rejections = 0
+		case policyclient.IsRejected(err):
+			rejections++
+			if rejections >= cfg.Enrollment.RejectionThreshold {
+				return errEnrollmentRejected
+			}
+		default:
+			rejections = 0
+		}
+
+		// Honour the server's backpressure hint, with jitter so that the
+		// fleet does not reconnect in lockstep.
+		wait := backoff
+		if hint := client.BackoffHint(); hint > wait {
+			wait = hint + rand.N(hint/4+1) //nolint:gosec // jitter does not need a CSPRNG
+			log.Printf("Server requested back-off of %v", hint)
 		}
 
-		log.Printf("Policy stream disconnected: %v — reconnecting in %v", err, backoff)
+		log.Printf("Policy stream disconnected: %v — reconnecting in %v", err, wait)
 
 		select {
 		case <-ctx.Done():
-			return
-		case <-time.After(backoff):
+			return nil
+		case <-time.After(wait):
 		}
 
 		// Exponential backoff capped at 60 s.
@@ -431,6 +799,240 @@ func runStreamingLoop(ctx context.Context, client *policyclient.Client, cfg *con
 	}
 }
 
+// refreshAgentConfig fetches the notification settings from the server.
+// On failure the previous settings stay in use.
+func refreshAgentConfig(ctx context.Context, client *policyclient.Client, cfg *config.Config) {
+	agentCfg, err := client.GetAgentConfig(ctx)
+	if err != nil {
+		log.Printf("Failed to fetch agent config (using defaults): %v", err)
+		return
+	}
+	contactRules.Connected()
+	resyncOnStateChange(ctx, client, cfg)
+	notifyConfig = notify.Config{
+		Enabled:  agentCfg.NotifyUsers,
+		Cooldown: time.Duration(agentCfg.NotifyCooldown) * time.Second,
+		Message:  agentCfg.NotifyMessage,
+	}
+	log.Printf("Agent notification config: enabled=%v cooldown=%v", notifyConfig.Enabled, notifyConfig.Cooldown)
+	firefoxNotifyConfig = notify.Config{
+		Enabled:  agentCfg.NotifyUsers,
+		Cooldown: time.Duration(agentCfg.NotifyCooldown) * time.Second,
+		Message:  agentCfg.NotifyMessageFirefox,
+	}
+	chromeNotifyConfig = notify.Config{
+		Enabled:  agentCfg.NotifyUsers,
+		Cooldown: time.Duration(agentCfg.NotifyCooldown) * time.Second,
+		Message:  agentCfg.NotifyMessageChrome,
+	}
+}
+
+// flushComplianceQueue delivers the compliance reports queued while the
+// server was unreachable.
+func flushComplianceQueue(ctx context.Context, client *policyclient.Client) {
+	if err := client.FlushComplianceQueue(ctx); err != nil {
+		log.Printf("Failed to deliver queued compliance reports: %v", err)
+	}
+}
+
+// pollPolicies is the polling fallback for one round: it refreshes the
+// agent config, sends a heartbeat, and applies the policies returned by
+// ListPolicies as if they had arrived as a full snapshot on the stream.
+// ListPolicies does not report inventory-only mode, so the server setting
+// last received on the stream stays in effect.
+func pollPolicies(ctx context.Context, client *policyclient.Client, cfg *config.Config, postInitialSync *bool) error {
+	refreshAgentConfig(ctx, client, cfg)
+	go sendHeartbeat(ctx, client)
+
+	policies, err := client.ListPolicies(ctx)
+	if err != nil {
+		contactRules.Disconnected()
+		resyncOnStateChange(ctx, client, cfg)
+		return err
+	}
+	contactRules.Connected()
+	go flushComplianceQueue(ctx, client)
+
+	applyMu.Lock()
+	defer applyMu.Unlock()
+	replaySnapshot(ctx, client, cfg, policies, postInitialSync)
+	// Polled state has no revision.
+	saveOfflineCache(cfg, 0)
+	agentMetrics.Synced()
+	return nil
+}
+
+// replaySnapshot applies policies as if they had arrived as a complete
+// snapshot on the stream. The caller must hold applyMu.
+func replaySnapshot(ctx context.Context, client *policyclient.Client, cfg *config.Config, policies []*policyclient.PolicyInfo, postInitialSync *bool) {
+	if len(policies) == 0 {
+		handlePolicyUpdate(ctx, client, cfg, "SNAPSHOT", nil, true, postInitialSync)
+		return
+	}
+	for i, pi := range policies {
+		handlePolicyUpdate(ctx, client, cfg, "SNAPSHOT", pi, i == len(policies)-1, postInitialSync)
+	}
+}
+
+// applyOfflineCache applies the policies saved by the previous run so the
+// node is enforced from boot, before the server is reachable. It returns
+// the revision of the cached policy set, 0 when there is none. A cache
+// written with another schema version is ignored rather than applied.
+func applyOfflineCache(ctx context.Context, client *policyclient.Client, cfg *config.Config) int64 {
+	snap, err := policyclient.LoadOfflineSnapshot(policyclient.OfflineCacheFile(cfg.Enrollment.DataDir))
+	if err != nil {
+		log.Printf("Ignoring offline policy cache: %v", err)
+		return 0
+	}
+	if snap == nil {
+		return 0
+	}
+	log.Printf("Applying %d cached policies (revision %d) until the server is reachable",
+		len(snap.Policies), snap.Revision)
+
+	applyMu.Lock()
+	defer applyMu.Unlock()
+	setServerInventoryOnly(snap.InventoryOnly)
+	// Cached state is not a live admin change: never notify users for it.
+	var postInitialSync bool
+	replaySnapshot(ctx, client, cfg, snap.Policies, &postInitialSync)
+	return snap.Revision
+}
+
+// saveOfflineCache persists the applied policies and their revision to the
+// offline policy cache. The caller must hold applyMu.
+func saveOfflineCache(cfg *config.Config, revision int64) {
+	snap := &policyclient.OfflineSnapshot{
+		Revision:      revision,
+		InventoryOnly: serverInventoryOnly,
+		Policies:      make([]*policyclient.PolicyInfo, 0, len(appliedPolicies)),
+	}
+	for _, id := range slices.Sorted(maps.Keys(appliedPolicies)) {
+		snap.Policies = append(snap.Policies, appliedPolicies[id])
+	}
+	if err := policyclient.SaveOfflineSnapshot(policyclient.OfflineCacheFile(cfg.Enrollment.DataDir), snap); err != nil {
+		log.Printf("Failed to save offline policy cache: %v", err)
+	}
+}
+
+// resyncOnStateChange re-applies all policies when the dead-man's switch
+// has flipped any policy between enforced and dormant, or when a policy's
+// preconditions have started or stopped holding.
+func resyncOnStateChange(ctx context.Context, client *policyclient.Client, cfg *config.Config) {
+	applyMu.Lock()
+	defer applyMu.Unlock()
+
+	contactChanged := contactRules.Changed()
+	preconditionsChanged := preconditions.Changed()
+	switch {
+	case contactChanged:
+		log.Println("Contact-loss state changed — re-applying policies")
+	case preconditionsChanged:
+		log.Println("Policy preconditions changed — re-applying policies")
+	default:
+		return
+	}
+	if changed := syncAllKConfig(ctx, client, cfg); len(changed) > 0 {
+		kdeNotifier.ScheduleNotification(notifyConfig, changed)
+	}
+	if syncAllFirefox(ctx, client, cfg) {
+		firefoxNotifier.ScheduleNotification(firefoxNotifyConfig, map[string]bool{"policies.json": true})
+	}
+	if syncAllChrome(ctx, client, cfg) {
+		chromeNotifier.ScheduleNotification(chromeNotifyConfig, map[string]bool{"bor_managed.json": true})
+	}
+	syncAllDConf(ctx, client, cfg)
+	syncAllPolkit(ctx, client, cfg)
+	syncAllSysctl(ctx, client, cfg)
+}
+
+// preconditionCheckInterval is how often policy preconditions are
+// re-evaluated between policy updates.
+const preconditionCheckInterval = time.Minute
+
+// runPreconditionLoop re-evaluates policy preconditions periodically so
+// that policies follow local state changes (a desktop session starting, a
+// package being installed) without waiting for a server update.
+func runPreconditionLoop(ctx context.Context, client *policyclient.Client, cfg *config.Config) {
+	ticker := time.NewTicker(preconditionCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			resyncOnStateChange(ctx, client, cfg)
+		}
+	}
+}
+
+// setServerInventoryOnly records the server's inventory-only flag and logs
+// transitions.
+func setServerInventoryOnly(v bool) {
+	if v == serverInventoryOnly {
+		return
+	}
+	serverInventoryOnly = v
+	if v {
+		log.Println("Server enabled inventory-only mode: policies are monitored but not enforced")
+	} else {
+		log.Println("Server disabled inventory-only mode: enforcing policies")
+	}
+}
+
+// applicable reports whether a cached policy should be applied now: its
+// contact-loss rule must enforce it and its preconditions must hold on this
+// node. Policies with unmet preconditions are reported as inapplicable.
+func applicable(ctx context.Context, client *policyclient.Client, id string) bool {
+	if !contactRules.Enforced(id) {
+		return false
+	}
+	met, reason := preconditions.Met(id)
+	if met {
+		return true
+	}
+	log.Printf("Policy %s skipped: precondition unmet: %s", id, reason)
+	if err := client.ReportComplianceWithStatus(ctx, id, pb.ComplianceStatus_COMPLIANCE_STATUS_INAPPLICABLE,
+		"skipped: precondition unmet: "+reason, nil); err != nil {
+		log.Printf("Failed to report skipped policy %s: %v", id, err)
+	}
+	return false
+}
+
+// contactRule extracts the dead-man's-switch rule from a received policy.
+func contactRule(pi *policyclient.PolicyInfo) contactloss.Rule {
+	return contactloss.Rule{Action: pi.ContactLossAction, TTL: pi.ContactLossTTL}
+}
+
+// uploadRecentLogs answers a server log request with the buffered agent
+// log output.
+func uploadRecentLogs(ctx context.Context, client *policyclient.Client, requestID string) {
+	log.Printf("Server requested agent logs (request %s)", requestID)
+	content, truncated := recentLogs.Snapshot()
+	if err := client.UploadLogs(ctx, requestID, content, truncated); err != nil {
+		log.Printf("Failed to upload agent logs: %v", err)
+	}
+}
+
+// runRemediation runs a server-requested remediation action and reports its
+// result. Actions are serialised with policy application so that, for
+// example, "dconf-update" never races a dconf policy write.
+func runRemediation(ctx context.Context, client *policyclient.Client, runner *remediation.Runner, requestID, action string) {
+	log.Printf("Server requested remediation action %q (request %s)", action, requestID)
+	applyMu.Lock()
+	res := runner.Run(ctx, action)
+	applyMu.Unlock()
+
+	if res.Error != "" {
+		log.Printf("Remediation action %q failed: %s", action, res.Error)
+	} else {
+		log.Printf("Remediation action %q finished: exit code %d", action, res.ExitCode)
+	}
+	if err := client.ReportRemediationResult(ctx, requestID, action, res.Success, res.ExitCode, res.Output, res.Error); err != nil {
+		log.Printf("Failed to report remediation result: %v", err)
+	}
+}
+
 // handlePolicyUpdate processes a single event from the streaming RPC.
 // postInitialSync tracks whether the first SNAPSHOT for this connection has
 // already completed; subsequent SNAPSHOTs are server-side resyncs triggered
@@ -446,25 +1048,33 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 		if pi == nil {
 			if snapshotComplete {
 				log.Println("Received empty snapshot (no policies assigned)")
+				appliedPolicies = make(map[string]*policyclient.PolicyInfo)
+				appliedPoliciesStaging = nil
 				firefoxChanged := len(firefoxCache) > 0
 				hadKconfigPolicies := len(kconfigCache) > 0
 				chromeChanged := len(chromeCache) > 0
-				kconfigCache = make(map[string]*pb.KConfigPolicy)
+				kconfigCache = make(map[string]kconfigCacheEntry)
 				kconfigSnapshotStaging = nil
-				firefoxCache = make(map[string]*pb.FirefoxPolicy)
+				firefoxCache = make(map[string]firefoxCacheEntry)
 				firefoxSnapshotStaging = nil
-				chromeCache = make(map[string]*pb.ChromePolicy)
+				chromeCache = make(map[string]chromeCacheEntry)
 				chromeSnapshotStaging = nil
 				dconfCache = make(map[string]dconfCacheEntry)
 				dconfSnapshotStaging = nil
 				polkitCache = make(map[string]polkitCacheEntry)
 				polkitSnapshotStaging = nil
+				sysctlCache = make(map[string]sysctlCacheEntry)
+				sysctlSnapshotStaging = nil
+				contactRules.CommitSnapshot()
+				preconditions.CommitSnapshot()
+				complianceChecks.CommitSnapshot()
 				syncAllKConfig(ctx, client, cfg)
 				syncAllFirefox(ctx, client, cfg)
 				syncAllChrome(ctx, client, cfg)
 				syncAllDConf(ctx, client, cfg)
 				syncAllPolkit(ctx, client, cfg)
-				if *postInitialSync {
+				syncAllSysctl(ctx, client, cfg)
+				if *postInitialSync && !inventoryOnly(cfg) {
 					if hadKconfigPolicies {
 						kdeNotifier.ScheduleNotification(notifyConfig, map[string]bool{"kwinrc": true, "kdeglobals": true})
 					}
@@ -482,23 +1092,30 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 
 		log.Printf("Policy update: type=%s id=%s name=%s version=%d",
 			updateType, pi.ID, pi.Name, pi.Version)
+		contactRules.Stage(pi.ID, contactRule(pi))
+		preconditions.Stage(pi.ID, pi.Preconditions)
+		complianceChecks.Stage(pi.ID, pi.ComplianceCheck)
+		if appliedPoliciesStaging == nil {
+			appliedPoliciesStaging = make(map[string]*policyclient.PolicyInfo)
+		}
+		appliedPoliciesStaging[pi.ID] = pi
 
 		switch pi.Type {
 		case "Firefox":
 			if firefoxSnapshotStaging == nil {
-				firefoxSnapshotStaging = make(map[string]*pb.FirefoxPolicy)
+				firefoxSnapshotStaging = make(map[string]firefoxCacheEntry)
 			}
-			firefoxSnapshotStaging[pi.ID] = pi.FirefoxPolicy
+			firefoxSnapshotStaging[pi.ID] = firefoxCacheEntry{priority: pi.Priority, policy: pi.FirefoxPolicy}
 		case "Chrome":
 			if chromeSnapshotStaging == nil {
-				chromeSnapshotStaging = make(map[string]*pb.ChromePolicy)
+				chromeSnapshotStaging = make(map[string]chromeCacheEntry)
 			}
-			chromeSnapshotStaging[pi.ID] = pi.ChromePolicy
+			chromeSnapshotStaging[pi.ID] = chromeCacheEntry{priority: pi.Priority, policy: pi.ChromePolicy}
 		case "Kconfig":
 			if kconfigSnapshotStaging == nil {
-				kconfigSnapshotStaging = make(map[string]*pb.KConfigPolicy)
+				kconfigSnapshotStaging = make(map[string]kconfigCacheEntry)
 			}
-			kconfigSnapshotStaging[pi.ID] = pi.KConfigPolicy
+			kconfigSnapshotStaging[pi.ID] = kconfigCacheEntry{priority: pi.Priority, policy: pi.KConfigPolicy, scopeUsers: pi.ScopeUsers}
 		case "Dconf":
 			if dconfSnapshotStaging == nil {
 				dconfSnapshotStaging = make(map[string]dconfCacheEntry)
@@ -509,6 +1126,11 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 				polkitSnapshotStaging = make(map[string]polkitCacheEntry)
 			}
 			polkitSnapshotStaging[pi.ID] = polkitCacheEntry{id: pi.ID, name: pi.Name, priority: pi.Priority, policy: pi.PolkitPolicy}
+		case "Sysctl":
+			if sysctlSnapshotStaging == nil {
+				sysctlSnapshotStaging = make(map[string]sysctlCacheEntry)
+			}
+			sysctlSnapshotStaging[pi.ID] = sysctlCacheEntry{priority: pi.Priority, policy: pi.SysctlPolicy}
 		default:
 			log.Printf("Unknown policy type %q for policy %s, skipping", pi.Type, pi.Name)
 			_ = client.ReportCompliance(ctx, pi.ID, false,
@@ -524,7 +1146,7 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 			if kconfigSnapshotStaging != nil {
 				kconfigCache = kconfigSnapshotStaging
 			} else {
-				kconfigCache = make(map[string]*pb.KConfigPolicy)
+				kconfigCache = make(map[string]kconfigCacheEntry)
 			}
 			kconfigSnapshotStaging = nil
 
@@ -532,7 +1154,7 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 			if firefoxSnapshotStaging != nil {
 				firefoxCache = firefoxSnapshotStaging
 			} else {
-				firefoxCache = make(map[string]*pb.FirefoxPolicy)
+				firefoxCache = make(map[string]firefoxCacheEntry)
 			}
 			firefoxSnapshotStaging = nil
 
@@ -540,7 +1162,7 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 			if chromeSnapshotStaging != nil {
 				chromeCache = chromeSnapshotStaging
 			} else {
-				chromeCache = make(map[string]*pb.ChromePolicy)
+				chromeCache = make(map[string]chromeCacheEntry)
 			}
 			chromeSnapshotStaging = nil
 
@@ -560,13 +1182,34 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 			}
 			polkitSnapshotStaging = nil
 
+			// Swap Sysctl staging into cache.
+			if sysctlSnapshotStaging != nil {
+				sysctlCache = sysctlSnapshotStaging
+			} else {
+				sysctlCache = make(map[string]sysctlCacheEntry)
+			}
+			sysctlSnapshotStaging = nil
+
+			if appliedPoliciesStaging != nil {
+				appliedPolicies = appliedPoliciesStaging
+			} else {
+				appliedPolicies = make(map[string]*policyclient.PolicyInfo)
+			}
+			appliedPoliciesStaging = nil
+
+			contactRules.CommitSnapshot()
+			preconditions.CommitSnapshot()
+			complianceChecks.CommitSnapshot()
+			triggerComplianceChecks()
+
 			kconfigChanged := syncAllKConfig(ctx, client, cfg)
 			syncAllFirefox(ctx, client, cfg)
 			syncAllChrome(ctx, client, cfg)
 			syncAllDConf(ctx, client, cfg)
 			syncAllPolkit(ctx, client, cfg)
+			syncAllSysctl(ctx, client, cfg)
 
-			if *postInitialSync {
+			if *postInitialSync && !inventoryOnly(cfg) {
 				// Resync from a live admin change — notify if content changed.
 				if len(kconfigChanged) > 0 {
 					kdeNotifier.ScheduleNotification(notifyConfig, kconfigChanged)
@@ -587,20 +1230,30 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 		}
 		log.Printf("Policy update: type=%s id=%s name=%s version=%d",
 			updateType, pi.ID, pi.Name, pi.Version)
+		contactRules.Set(pi.ID, contactRule(pi))
+		preconditions.Set(pi.ID, pi.Preconditions)
+		complianceChecks.Put(pi.ID, pi.ComplianceCheck)
+		triggerComplianceChecks()
+		unchanged := pi.SameContent(appliedPolicies[pi.ID])
+		appliedPolicies[pi.ID] = pi
+		if unchanged {
+			log.Printf("Policy %s content unchanged, not re-applying", pi.Name)
+			return
+		}
 
 		switch pi.Type {
 		case "Firefox":
-			firefoxCache[pi.ID] = pi.FirefoxPolicy
+			firefoxCache[pi.ID] = firefoxCacheEntry{priority: pi.Priority, policy: pi.FirefoxPolicy}
 			if syncAllFirefox(ctx, client, cfg) {
 				firefoxNotifier.ScheduleNotification(firefoxNotifyConfig, map[string]bool{"policies.json": true})
 			}
 		case "Chrome":
-			chromeCache[pi.ID] = pi.ChromePolicy
+			chromeCache[pi.ID] = chromeCacheEntry{priority: pi.Priority, policy: pi.ChromePolicy}
 			if syncAllChrome(ctx, client, cfg) {
 				chromeNotifier.ScheduleNotification(chromeNotifyConfig, map[string]bool{"bor_managed.json": true})
 			}
 		case "Kconfig":
-			kconfigCache[pi.ID] = pi.KConfigPolicy
+			kconfigCache[pi.ID] = kconfigCacheEntry{priority: pi.Priority, policy: pi.KConfigPolicy, scopeUsers: pi.ScopeUsers}
 			if changed := syncAllKConfig(ctx, client, cfg); len(changed) > 0 {
 				kdeNotifier.ScheduleNotification(notifyConfig, changed)
 			}
@@ -610,6 +1263,9 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 		case "Polkit":
 			polkitCache[pi.ID] = polkitCacheEntry{id: pi.ID, name: pi.Name, priority: pi.Priority, policy: pi.PolkitPolicy}
 			syncAllPolkit(ctx, client, cfg)
+		case "Sysctl":
+			sysctlCache[pi.ID] = sysctlCacheEntry{priority: pi.Priority, policy: pi.SysctlPolicy}
+			syncAllSysctl(ctx, client, cfg)
 		default:
 			log.Printf("Unknown policy type %q for policy %s, skipping", pi.Type, pi.Name)
 			_ = client.ReportCompliance(ctx, pi.ID, false,
@@ -622,6 +1278,10 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 		}
 		log.Printf("Policy update: type=%s id=%s name=%s version=%d",
 			updateType, pi.ID, pi.Name, pi.Version)
+		contactRules.Delete(pi.ID)
+		preconditions.Delete(pi.ID)
+		complianceChecks.Delete(pi.ID)
+		delete(appliedPolicies, pi.ID)
 
 		if _, ok := kconfigCache[pi.ID]; ok {
 			delete(kconfigCache, pi.ID)
@@ -644,6 +1304,9 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 		} else if _, ok := polkitCache[pi.ID]; ok {
 			delete(polkitCache, pi.ID)
 			syncAllPolkit(ctx, client, cfg)
+		} else if _, ok := sysctlCache[pi.ID]; ok {
+			delete(sysctlCache, pi.ID)
+			syncAllSysctl(ctx, client, cfg)
 		} else {
 			log.Printf("Policy %s deleted (not in any policy cache)", pi.ID)
 		}
@@ -651,9 +1314,9 @@ func handlePolicyUpdate(ctx context.Context, client *policyclient.Client, cfg *c
 }
 
 // firefoxCachesEqual returns true when two Firefox policy caches contain
-// identical policy IDs and proto content. Used to detect whether a
-// SNAPSHOT resync actually changed the Firefox policy set.
-func firefoxCachesEqual(a, b map[string]*pb.FirefoxPolicy) bool {
+// identical policy IDs, priorities and proto content. Used to detect
+// whether a SNAPSHOT resync actually changed the Firefox policy set.
+func firefoxCachesEqual(a, b map[string]firefoxCacheEntry) bool {
 	if len(a) != len(b) {
 		return false
 	}
@@ -662,7 +1325,7 @@ func firefoxCachesEqual(a, b map[string]*pb.FirefoxPolicy) bool {
 		if !ok {
 			return false
 		}
-		if !proto.Equal(va, vb) {
+		if va.priority != vb.priority || !proto.Equal(va.policy, vb.policy) {
 			return false
 		}
 	}
@@ -670,9 +1333,9 @@ func firefoxCachesEqual(a, b map[string]*pb.FirefoxPolicy) bool {
 }
 
 // chromeCachesEqual returns true when two Chrome policy caches contain
-// identical policy IDs and proto content. Used to detect whether a
-// SNAPSHOT resync actually changed the Chrome policy set.
-func chromeCachesEqual(a, b map[string]*pb.ChromePolicy) bool {
+// identical policy IDs, priorities and proto content. Used to detect
+// whether a SNAPSHOT resync actually changed the Chrome policy set.
+func chromeCachesEqual(a, b map[string]chromeCacheEntry) bool {
 	if len(a) != len(b) {
 		return false
 	}
@@ -681,7 +1344,7 @@ func chromeCachesEqual(a, b map[string]*pb.ChromePolicy) bool {
 		if !ok {
 			return false
 		}
-		if !proto.Equal(va, vb) {
+		if va.priority != vb.priority || !proto.Equal(va.policy, vb.policy) {
 			return false
 		}
 	}
@@ -689,31 +1352,91 @@ func chromeCachesEqual(a, b map[string]*pb.ChromePolicy) bool {
 }
 
 // syncAllKConfig re-merges all cached KConfig policies and syncs the
-// resulting files to disk. KCM (Control Module) restriction entries are
-// split out and written directly to /etc/kde5rc and /etc/kde6rc; all
-// other entries go to the XDG overlay. When the cache is empty,
-// SyncKConfigFiles restores all previously managed files from backups.
+// resulting files to disk. Entries are routed by scope: system overlay
+// entries go to the XDG overlay, system immutable KCM restrictions are
+// written directly to /etc/kde5rc and /etc/kde6rc, and per-user entries
+// become seed files under cfg.KConfig.UserSeedPath. When the cache is
+// empty, the sync functions restore all previously managed files from
+// backups.
 //
-// Returns the set of written file basenames (nil when nothing was
-// written). The caller decides whether to schedule a notification.
+// Policies bound to user groups are applied per logged-in user by
+// syncUserKConfig.
+//
+// Returns the set of basenames whose content was written or restored (nil
+// when every file was already up to date). The caller decides whether to
+// schedule a notification; user seeds only affect new accounts and are not
+// included.
 func syncAllKConfig(ctx context.Context, client *policyclient.Client, cfg *config.Config) map[string]bool {
+	batch := client.NewComplianceBatch()
+	defer func() { _ = batch.Send(ctx) }()
+
 	var allEntries []*pb.KConfigEntry
-	var ids []string
-	for id, pol := range kconfigCache {
-		allEntries = append(allEntries, policy.KConfigPolicyToEntries(pol)...)
+	var ids, userScopedIDs []string
+	for _, id := range policy.SortedIDs(kconfigCache) {
+		e := kconfigCache[id]
+		if !applicable(ctx, client, id) {
+			continue
+		}
+		if len(e.scopeUsers) > 0 {
+			userScopedIDs = append(userScopedIDs, id)
+			continue
+		}
+		// The priority orders URL restriction rules across policies and
+		// picks the winner when policies set the same key.
+		polEntries := policy.KConfigPolicyToEntries(e.policy)
+		for _, pe := range polEntries {
+			pe.Priority = e.priority
+			pe.PolicyId = id
+		}
+		allEntries = append(allEntries, polEntries...)
 		ids = append(ids, id)
 	}
 
-	// Split KCM restriction entries from other KConfig entries.
-	// KCM restrictions go to /etc/kde5rc and /etc/kde6rc directly.
-	kcmEntries, otherEntries := policy.SplitKCMRestrictions(allEntries)
+	scoped := policy.SplitKConfigByScope(allEntries)
+	kcmEntries := scoped.KCM
 
-	files, err := policy.MergeKConfigEntries(otherEntries)
+	files, conflicts, err := policy.MergeKConfigEntriesWithConflicts(scoped.Overlay)
 	if err != nil {
 		log.Printf("Error merging KConfig policies: %v", err)
 		for _, id := range ids {
-			_ = client.ReportCompliance(ctx, id, false, "failed to merge policies: "+err.Error())
+			batch.ReportCompliance(id, false, "failed to merge policies: "+err.Error())
+		}
+		return nil
+	}
+
+	userFiles, userConflicts, err := policy.MergeKConfigEntriesWithConflicts(scoped.User)
+	if err != nil {
+		log.Printf("Error merging KConfig user seeds: %v", err)
+		for _, id := range ids {
+			batch.ReportCompliance(id, false, "failed to merge user seeds: "+err.Error())
+		}
+		return nil
+	}
+	overridden := kconfigOverriddenItems(append(conflicts, userConflicts...))
+	if len(userFiles) > 0 && cfg.KConfig.UserSeedPath == "" {
+		log.Printf("KConfig: per-user seeding is disabled; %d user seed files not written", len(userFiles))
+		for _, id := range ids {
+			batch.ReportCompliance(id, false, "per-user KConfig settings require kconfig.user_seed_path")
+		}
+		return nil
+	}
+
+	if inventoryOnly(cfg) {
+		want := make(map[string][]byte, len(files)+len(userFiles)+2)
+		for name, data := range files {
+			want[filepath.Join(cfg.KConfig.ConfigPath, name)] = data
+		}
+		for name, data := range userFiles {
+			want[filepath.Join(cfg.KConfig.UserSeedPath, name)] = data
 		}
+		if len(kcmEntries) > 0 {
+			if kcmFiles, err := policy.MergeKConfigEntries(kcmEntries); err == nil {
+				want["/etc/kde5rc"] = kcmFiles["kde5rc"]
+				want["/etc/kde6rc"] = kcmFiles["kde5rc"]
+			}
+		}
+		reportInventoryOnly(ctx, client, ids, "Kconfig", "KConfig", want)
+		syncUserKConfig(ctx, client, cfg, scoped.Overlay, userScopedIDs)
 		return nil
 	}
 
@@ -726,18 +1449,34 @@ func syncAllKConfig(ctx context.Context, client *policyclient.Client, cfg *confi
 	for name := range files {
 		incomingPaths = append(incomingPaths, filepath.Join(cfg.KConfig.ConfigPath, name))
 	}
+	for name := range userFiles {
+		incomingPaths = append(incomingPaths, filepath.Join(cfg.KConfig.UserSeedPath, name))
+	}
 	incomingPaths = append(incomingPaths, "/etc/kde5rc", "/etc/kde6rc")
 	suppressManagedWrites(cfg, incomingPaths...)
 	defer updateWatcher(cfg)
 
-	if err := policy.SyncKConfigFiles(cfg.KConfig.ConfigPath, files); err != nil {
+	changed, err := policy.SyncKConfigFiles(cfg.KConfig.ConfigPath, files)
+	if err != nil {
 		log.Printf("Error syncing KConfig files: %v", err)
+		agentMetrics.SyncFailed("KConfig")
 		for _, id := range ids {
-			_ = client.ReportCompliance(ctx, id, false, "failed to sync KConfig files: "+err.Error())
+			batch.ReportCompliance(id, false, policy.ComplianceMessage("failed to sync KConfig files", err))
 		}
 		return nil
 	}
 
+	if cfg.KConfig.UserSeedPath != "" {
+		if _, err := policy.SyncKConfigFiles(cfg.KConfig.UserSeedPath, userFiles); err != nil {
+			log.Printf("Error syncing KConfig user seeds: %v", err)
+			agentMetrics.SyncFailed("KConfig")
+			for _, id := range ids {
+				batch.ReportCompliance(id, false, policy.ComplianceMessage("failed to sync KConfig user seeds", err))
+			}
+			return nil
+		}
+	}
+
 	// Sync KCM restrictions to /etc/kde5rc and /etc/kde6rc.
 	var kcmContent []byte
 	if len(kcmEntries) > 0 {
@@ -745,67 +1484,262 @@ func syncAllKConfig(ctx context.Context, client *policyclient.Client, cfg *confi
 		if err != nil {
 			log.Printf("Error merging KCM restriction entries: %v", err)
 			for _, id := range ids {
-				_ = client.ReportCompliance(ctx, id, false, "failed to merge KCM restrictions: "+err.Error())
+				batch.ReportCompliance(id, false, "failed to merge KCM restrictions: "+err.Error())
 			}
 			return nil
 		}
 		kcmContent = kcmFiles["kde5rc"]
 	}
 
-	if err := policy.SyncKCMRestrictions(kcmContent); err != nil {
+	kcmChanged, err := policy.SyncKCMRestrictions(kcmContent)
+	if err != nil {
 		log.Printf("Error syncing KCM restrictions: %v", err)
+		agentMetrics.SyncFailed("KConfig")
 		for _, id := range ids {
-			_ = client.ReportCompliance(ctx, id, false, "failed to sync KCM restrictions: "+err.Error())
+			batch.ReportCompliance(id, false, policy.ComplianceMessage("failed to sync KCM restrictions", err))
 		}
 		return nil
 	}
 
 	log.Printf("KConfig policies synced to %s (%d policies, %d files)", cfg.KConfig.ConfigPath, len(ids), len(files))
+	agentMetrics.SyncApplied("KConfig")
 	if len(kcmEntries) > 0 {
 		log.Printf("KCM restrictions synced to /etc/kde5rc and /etc/kde6rc")
 	}
+	if len(userFiles) > 0 {
+		log.Printf("KConfig user seeds synced to %s (%d files)", cfg.KConfig.UserSeedPath, len(userFiles))
+	}
+	// The merged files are shared by all KConfig policies, so each policy
+	// reports the full set.
+	appliedPaths := make([]string, 0, len(files)+len(userFiles)+2)
+	for name := range files {
+		appliedPaths = append(appliedPaths, filepath.Join(cfg.KConfig.ConfigPath, name))
+	}
+	for name := range userFiles {
+		appliedPaths = append(appliedPaths, filepath.Join(cfg.KConfig.UserSeedPath, name))
+	}
+	if len(kcmEntries) > 0 {
+		appliedPaths = append(appliedPaths, "/etc/kde5rc", "/etc/kde6rc")
+	}
+	slices.Sort(appliedPaths)
+	applied := policy.HashAppliedFiles(appliedPaths...)
 	for _, id := range ids {
-		_ = client.ReportCompliance(ctx, id, true, "Deployed")
+		// Overridden keys do not make a policy non-compliant: the merged
+		// files are exactly what the bindings ask for.
+		msg := "Deployed"
+		if items := overridden[id]; len(items) > 0 {
+			msg = fmt.Sprintf("Deployed; %d keys overridden by higher-priority policies", len(items))
+		}
+		batch.ReportComplianceWithFiles(id, pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT, msg, overridden[id], applied)
 	}
 
-	if len(files) == 0 && len(kcmEntries) == 0 {
+	userChanged := syncUserKConfig(ctx, client, cfg, scoped.Overlay, userScopedIDs)
+
+	if len(changed) == 0 && !kcmChanged && len(userChanged) == 0 {
 		return nil
 	}
-	changedFiles := make(map[string]bool, len(files)+2)
-	for name := range files {
+	changedFiles := make(map[string]bool, len(changed)+len(userChanged)+2)
+	for _, name := range changed {
 		changedFiles[name] = true
 	}
-	if len(kcmEntries) > 0 {
+	for name := range userChanged {
+		changedFiles[name] = true
+	}
+	if kcmChanged {
 		changedFiles["kde5rc"] = true
 		changedFiles["kde6rc"] = true
 	}
 	return changedFiles
 }
 
+// syncUserKConfig applies the KConfig policies bound to user groups. Each
+// logged-in user they apply to gets a directory with the machine-wide
+// overlay entries merged with those of their policies, so priorities work
+// across both; directories of users without such policies are cleaned up.
+// Only overlay settings can be applied per user: KCM restrictions and user
+// seeds are machine-wide, so policies containing them are reported
+// non-compliant. Returns the basenames written or restored.
+func syncUserKConfig(ctx context.Context, client *policyclient.Client, cfg *config.Config, machine []*pb.KConfigEntry, ids []string) map[string]bool {
+	perUser := make(map[string][]*pb.KConfigEntry)
+	policyUsers := make(map[string][]string) // policy ID → users it applies to
+	for _, id := range ids {
+		e := kconfigCache[id]
+		entries := policy.KConfigPolicyToEntries(e.policy)
+		for _, pe := range entries {
+			pe.Priority = e.priority
+			pe.PolicyId = id
+		}
+		scoped := policy.SplitKConfigByScope(entries)
+		if len(scoped.KCM) > 0 || len(scoped.User) > 0 {
+			_ = client.ReportCompliance(ctx, id, false, "KCM restrictions and per-user seeds cannot be applied per logged-in user; bind the policy to a node group")
+			continue
+		}
+		for _, u := range e.scopeUsers {
+			if !policy.ValidUserKConfigName(u) {
+				log.Printf("KConfig: skipping policy %s for invalid user name %q", id, u)
+				continue
+			}
+			perUser[u] = append(perUser[u], scoped.Overlay...)
+			policyUsers[id] = append(policyUsers[id], u)
+		}
+	}
+
+	files := make(map[string]map[string][]byte, len(perUser))
+	failed := make(map[string]string) // user → error
+	for u, entries := range perUser {
+		merged, _, err := policy.MergeKConfigEntriesWithConflicts(slices.Concat(machine, entries))
+		if err != nil {
+			log.Printf("Error merging KConfig policies for user %s: %v", u, err)
+			failed[u] = "failed to merge policies: " + err.Error()
+			continue
+		}
+		files[u] = merged
+	}
+
+	if inventoryOnly(cfg) {
+		want := make(map[string][]byte)
+		for u, merged := range files {
+			for name, data := range merged {
+				want[filepath.Join(policy.UserKConfigDir(cfg.KConfig.ConfigPath, u), name)] = data
+			}
+		}
+		reportInventoryOnly(ctx, client, slices.Sorted(maps.Keys(policyUsers)), "Kconfig", "KConfig", want)
+		return nil
+	}
+
+	// Clean up after users whose policies no longer apply.
+	changed := make(map[string]bool)
+	existing, err := policy.UserKConfigUsers(cfg.KConfig.ConfigPath)
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	for _, u := range existing {
+		if _, ok := perUser[u]; ok {
+			continue
+		}
+		names, err := policy.SyncKConfigFiles(policy.UserKConfigDir(cfg.KConfig.ConfigPath, u), nil)
+		if err != nil {
+			log.Printf("Error cleaning up KConfig files of user %s: %v", u, err)
+		}
+		for _, name := range names {
+			changed[name] = true
+		}
+	}
+
+	applied := make(map[string][]string) // user → written paths
+	for _, u := range slices.Sorted(maps.Keys(files)) {
+		dir := policy.UserKConfigDir(cfg.KConfig.ConfigPath, u)
+		for name := range files[u] {
+			applied[u] = append(applied[u], filepath.Join(dir, name))
+		}
+		suppressManagedWrites(cfg, applied[u]...)
+		names, err := policy.SyncKConfigFiles(dir, files[u])
+		if err != nil {
+			log.Printf("Error syncing KConfig files of user %s: %v", u, err)
+			agentMetrics.SyncFailed("KConfig")
+			failed[u] = policy.ComplianceMessage("failed to sync KConfig files", err)
+			continue
+		}
+		for _, name := range names {
+			changed[name] = true
+		}
+	}
+	if len(files) > 0 {
+		log.Printf("KConfig: user-scoped policies synced for %d users", len(files))
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(policyUsers)) {
+		var paths []string
+		var errs []string
+		for _, u := range policyUsers[id] {
+			if msg, ok := failed[u]; ok {
+				errs = append(errs, fmt.Sprintf("user %s: %s", u, msg))
+				continue
+			}
+			paths = append(paths, applied[u]...)
+		}
+		if len(errs) > 0 {
+			_ = client.ReportCompliance(ctx, id, false, strings.Join(errs, "; "))
+			continue
+		}
+		slices.Sort(paths)
+		msg := fmt.Sprintf("Deployed for %s", strings.Join(policyUsers[id], ", "))
+		_ = client.ReportComplianceWithFiles(ctx, id, pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT, msg, nil, policy.HashAppliedFiles(paths...))
+	}
+	return changed
+}
+
+// kconfigOverriddenItems turns merge conflicts into compliance items,
+// keyed by the ID of each policy whose value lost. Each item names the
+// key, the winning policy and the value written.
+func kconfigOverriddenItems(conflicts []policy.KConfigConflict) map[string][]*pb.ComplianceItemResult {
+	items := make(map[string][]*pb.ComplianceItemResult)
+	for _, c := range conflicts {
+		winner := c.PolicyIDs[0]
+		log.Printf("KConfig conflict: %s [%s] %s set by %d policies; using %q from policy %s",
+			c.File, c.Group, c.Key, len(c.PolicyIDs), c.Values[0], winner)
+		for i, id := range c.PolicyIDs[1:] {
+			if id == winner || c.Values[i+1] == c.Values[0] {
+				continue
+			}
+			items[id] = append(items[id], &pb.ComplianceItemResult{
+				SchemaId: c.File + "/" + c.Group,
+				Key:      c.Key,
+				Status:   pb.ComplianceStatus_COMPLIANCE_STATUS_INAPPLICABLE,
+				Message:  fmt.Sprintf("Key %s overridden by higher-priority policy %s; applied value: %s", c.Key, winner, c.Values[0]),
+			})
+		}
+	}
+	return items
+}
+
 // syncAllFirefox re-merges all cached Firefox proto policies and syncs
 // the resulting policies.json to disk. When the cache is empty,
 // SyncFirefoxPoliciesFromProto restores the original file from backup.
 //
 // Returns true when the sync succeeded (for notification scheduling).
 func syncAllFirefox(ctx context.Context, client *policyclient.Client, cfg *config.Config) bool {
+	batch := client.NewComplianceBatch()
+	defer func() { _ = batch.Send(ctx) }()
+
 	var policies []*pb.FirefoxPolicy
 	var ids []string
-	for id, pol := range firefoxCache {
+	// Merge in priority order so the highest-priority policy wins.
+	for _, id := range policy.PriorityOrder(firefoxCache, func(e firefoxCacheEntry) int32 { return e.priority }) {
+		pol := firefoxCache[id].policy
+		if !applicable(ctx, client, id) {
+			continue
+		}
 		policies = append(policies, pol)
 		ids = append(ids, id)
 	}
 
+	if inventoryOnly(cfg) {
+		data, err := policy.FirefoxPoliciesContent(policies)
+		if err != nil {
+			for _, id := range ids {
+				batch.ReportCompliance(id, false, "failed to render Firefox policies: "+err.Error())
+			}
+			return false
+		}
+		reportInventoryOnly(ctx, client, ids, "Firefox", "Firefox", map[string][]byte{cfg.Firefox.PoliciesPath: data})
+		return false
+	}
+
 	suppressManagedWrites(cfg, cfg.Firefox.PoliciesPath, cfg.Firefox.FlatpakPoliciesPath)
 	defer updateWatcher(cfg)
 
 	if err := policy.SyncFirefoxPoliciesFromProto(cfg.Firefox.PoliciesPath, policies); err != nil {
 		log.Printf("Error syncing Firefox policies: %v", err)
+		agentMetrics.SyncFailed("Firefox")
 		for _, id := range ids {
-			_ = client.ReportCompliance(ctx, id, false, "failed to sync Firefox policies: "+err.Error())
+			batch.ReportCompliance(id, false, policy.ComplianceMessage("failed to sync Firefox policies", err))
 		}
 		return false
 	}
 
+	appliedPaths := []string{cfg.Firefox.PoliciesPath}
+
 	// Flatpak Firefox: write to the system-wide extension directory.
 	// This is best-effort — Flatpak Firefox may not be installed.
 	if cfg.Firefox.FlatpakPoliciesPath != "" {
@@ -813,18 +1747,149 @@ func syncAllFirefox(ctx context.Context, client *policyclient.Client, cfg *confi
 			log.Printf("Warning: failed to sync Flatpak Firefox policies: %v", err)
 		} else {
 			log.Printf("Flatpak Firefox policies synced to %s", cfg.Firefox.FlatpakPoliciesPath)
+			appliedPaths = append(appliedPaths, cfg.Firefox.FlatpakPoliciesPath)
 		}
 	}
 
 	log.Printf("Firefox policies synced to %s (%d policies)", cfg.Firefox.PoliciesPath, len(ids))
+	agentMetrics.SyncApplied("Firefox")
+	_, conflicts := policy.MergeFirefoxProtosWithReport(policies)
+	notes := firefoxConflictNotes(ids, conflicts)
+	applied := policy.HashAppliedFiles(appliedPaths...)
 	for _, id := range ids {
-		_ = client.ReportCompliance(ctx, id, true, "Deployed")
+		msg := "Deployed"
+		if len(notes[id]) > 0 {
+			msg += "; " + strings.Join(notes[id], "; ")
+		}
+		batch.ReportComplianceWithFiles(id, pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT, msg, nil, applied)
 	}
 	return true
 }
 
+// firefoxConflictNotes turns Firefox merge conflicts into compliance
+// notes per policy ID, so admins can see which policy's setting won,
+// e.g. "Homepage.URL overridden by policy <id>". ids are the policy IDs
+// in merge order.
+func firefoxConflictNotes(ids []string, conflicts []policy.FirefoxMergeConflict) map[string][]string {
+	notes := make(map[string][]string)
+	for _, c := range conflicts {
+		winner, loser := ids[c.Winner], ids[c.Loser]
+		log.Printf("Firefox policy %s: %s overrides policy %s", winner, c.Key, loser)
+		notes[winner] = append(notes[winner], fmt.Sprintf("%s overrides policy %s", c.Key, loser))
+		notes[loser] = append(notes[loser], fmt.Sprintf("%s overridden by policy %s", c.Key, winner))
+	}
+	return notes
+}
+
+// configureNotifiers applies the local notify settings to all notifiers.
+// Without configured reconfigure rules they keep
+// notify.DefaultReconfigureRules.
+func configureNotifiers(cfg *config.Config) {
+	// The desktop notification asks users to log out, so offer a button.
+	kdeNotifier.SetLogoutAction(true)
+	notifiers := []*notify.Notifier{kdeNotifier, firefoxNotifier, chromeNotifier}
+	for _, n := range notifiers {
+		n.SetPerSession(cfg.Notify.PerSession)
+		n.SetSuppressWhenLocked(cfg.Notify.SuppressWhenLocked)
+	}
+	if cfg.Notify.ReconfigureRules == nil {
+		return
+	}
+	rules := make([]notify.ReconfigureRule, 0, len(cfg.Notify.ReconfigureRules))
+	for _, r := range cfg.Notify.ReconfigureRules {
+		rules = append(rules, notify.ReconfigureRule{FilePattern: r.FilePattern, Dest: r.Dest, Path: r.Path, Method: r.Method})
+	}
+	for _, n := range notifiers {
+		n.SetReconfigureRules(rules)
+	}
+	log.Printf("Using %d configured reconfigure rules", len(rules))
+}
+
+// checkPolicyTargets warns at startup about policy targets that live on a
+// read-only filesystem (common on immutable distributions), so the cause of
+// the resulting sync failures is visible before the first policy arrives.
+func checkPolicyTargets(cfg *config.Config) {
+	targets := []string{
+		cfg.Firefox.PoliciesPath,
+		cfg.Firefox.FlatpakPoliciesPath,
+		cfg.Chrome.ChromePoliciesPath,
+		cfg.Chrome.ChromiumPoliciesPath,
+		cfg.Chrome.ChromiumBrowserPoliciesPath,
+		cfg.Chrome.FlatpakChromiumPoliciesPath,
+		cfg.Chrome.EdgePoliciesPath,
+		cfg.Chrome.FlatpakEdgePoliciesPath,
+		cfg.KConfig.ConfigPath,
+		policy.DConfDBDir,
+		policy.PolkitRulesDir,
+	}
+	for _, target := range targets {
+		if target == "" {
+			continue
+		}
+		if err := policy.CheckWritable(target); err != nil {
+			log.Printf("Warning: policy target not writable: %v", err)
+		}
+	}
+}
+
+// runHeartbeatLoop sends a heartbeat every heartbeat interval, so the
+// server can tell a live node from one whose stream died unnoticed. Only
+// failures are logged.
+func runHeartbeatLoop(ctx context.Context, client *policyclient.Client, cfg *config.Config) {
+	ticker := time.NewTicker(time.Duration(cfg.Server.HeartbeatIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := heartbeat(ctx, client); err != nil && ctx.Err() == nil {
+				log.Printf("Heartbeat failed: %v", err)
+			}
+		}
+	}
+}
+
+// sessionCheckInterval is how often the agent looks for users logging in
+// or out.
+const sessionCheckInterval = 15 * time.Second
+
+// runSessionWatchLoop sends a heartbeat as soon as the set of logged-in
+// users changes, so the server delivers the policies bound to their user
+// groups without waiting for the next regular heartbeat.
+func runSessionWatchLoop(ctx context.Context, client *policyclient.Client) {
+	last, _ := notify.SessionUsers()
+	ticker := time.NewTicker(sessionCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			users, err := notify.SessionUsers()
+			if err != nil || slices.Equal(users, last) {
+				continue
+			}
+			last = users
+			log.Printf("Logged-in users changed: %v", users)
+			sendHeartbeat(ctx, client)
+		}
+	}
+}
+
 // sendHeartbeat collects current system metadata and sends it to the server.
 func sendHeartbeat(ctx context.Context, client *policyclient.Client) {
+	info, err := heartbeat(ctx, client)
+	if err != nil {
+		log.Printf("Heartbeat failed: %v", err)
+	} else {
+		log.Printf("Heartbeat sent (os=%s %s, de=%v)", info.OSName, info.OSVersion, info.DesktopEnvs)
+	}
+}
+
+// heartbeat collects current system metadata, sends it to the server and
+// returns it.
+func heartbeat(ctx context.Context, client *policyclient.Client) (*policyclient.NodeInfo, error) {
 	si := sysinfo.Collect()
 
 	desktopEnvs := make([]string, 0, len(si.DesktopEnvs))
@@ -833,40 +1898,71 @@ func sendHeartbeat(ctx context.Context, client *policyclient.Client) {
 	}
 
 	info := &policyclient.NodeInfo{
-		FQDN:         si.FQDN,
-		IPAddress:    si.IPAddress,
-		OSName:       si.OS.Name,
-		OSVersion:    si.OS.Version,
-		DesktopEnvs:  desktopEnvs,
-		AgentVersion: Version,
-		MachineID:    si.MachineID,
-	}
+		FQDN:           si.FQDN,
+		IPAddress:      si.IPAddress,
+		OSName:         si.OS.Name,
+		OSVersion:      si.OS.Version,
+		DesktopEnvs:    desktopEnvs,
+		AgentVersion:   Version,
+		MachineID:      si.MachineID,
+		FirefoxVersion: si.FirefoxVersion,
+		ChromeVersion:  si.ChromeVersion,
+		KernelVersion:  si.KernelVersion,
+		Hardware:       nodeHardware(si.Hardware),
+	}
+	if users, err := notify.SessionUsers(); err == nil {
+		info.SessionUsers = users
+	}
+	return info, client.Heartbeat(ctx, info)
+}
 
-	if err := client.Heartbeat(ctx, info); err != nil {
-		log.Printf("Heartbeat failed: %v", err)
-	} else {
-		log.Printf("Heartbeat sent (os=%s %s, de=%v)", info.OSName, info.OSVersion, info.DesktopEnvs)
+// nodeHardware converts collected hardware facts for the heartbeat,
+// returning nil when none were collected.
+func nodeHardware(hw sysinfo.HardwareInfo) *pb.NodeHardware {
+	if hw == (sysinfo.HardwareInfo{}) {
+		return nil
+	}
+	out := &pb.NodeHardware{
+		MemoryTotalBytes:     hw.MemoryTotal,
+		MemoryAvailableBytes: hw.MemoryAvailable,
+		CpuModel:             hw.CPUModel,
+		CpuCores:             uint32(hw.CPUCores), //nolint:gosec // G115: CPU count fits
+		RootDiskTotalBytes:   hw.RootDiskTotal,
+		RootDiskFreeBytes:    hw.RootDiskFree,
+	}
+	if !hw.BootTime.IsZero() {
+		out.BootTime = hw.BootTime.Unix()
 	}
+	return out
 }
 
 // syncAllChrome re-merges all cached Chrome proto policies and syncs
 // bor_managed.json to each configured Chrome/Chromium policy directory.
 // Returns true when the sync succeeded (for notification scheduling).
 func syncAllChrome(ctx context.Context, client *policyclient.Client, cfg *config.Config) bool {
+	batch := client.NewComplianceBatch()
+	defer func() { _ = batch.Send(ctx) }()
+
 	var policies []*pb.ChromePolicy
 	var ids []string
-	for id, pol := range chromeCache {
+	// Merge in priority order so the highest-priority policy wins.
+	for _, id := range policy.PriorityOrder(chromeCache, func(e chromeCacheEntry) int32 { return e.priority }) {
+		pol := chromeCache[id].policy
+		if !applicable(ctx, client, id) {
+			continue
+		}
 		if pol != nil {
 			policies = append(policies, pol)
 		}
 		ids = append(ids, id)
 	}
 
-	// Collect active (non-empty) Chrome/Chromium paths.
+	// Collect active (non-empty) Chrome/Chromium/Edge paths.
 	chromePaths := []string{
 		cfg.Chrome.ChromePoliciesPath,
 		cfg.Chrome.ChromiumPoliciesPath,
 		cfg.Chrome.ChromiumBrowserPoliciesPath,
+		cfg.Chrome.EdgePoliciesPath,
 	}
 	var activePaths []string
 	for _, p := range chromePaths {
@@ -875,11 +1971,36 @@ func syncAllChrome(ctx context.Context, client *policyclient.Client, cfg *config
 		}
 	}
 
+	if inventoryOnly(cfg) {
+		data, err := policy.ChromePoliciesContent(policies)
+		var recommended []byte
+		if err == nil {
+			recommended, err = policy.ChromeRecommendedContent(policies)
+		}
+		if err != nil {
+			for _, id := range ids {
+				batch.ReportCompliance(id, false, "failed to render Chrome policies: "+err.Error())
+			}
+			return false
+		}
+		want := make(map[string][]byte, 2*len(activePaths))
+		for _, dir := range activePaths {
+			if data != nil {
+				want[filepath.Join(dir, policy.ChromeManagedFilename)] = data
+			}
+			if recDir := policy.ChromeRecommendedDir(dir); recDir != "" && recommended != nil {
+				want[filepath.Join(recDir, policy.ChromeManagedFilename)] = recommended
+			}
+		}
+		reportInventoryOnly(ctx, client, ids, "Chrome", "Chrome", want)
+		return false
+	}
+
 	// Suppress watcher events for all Chrome managed files about to be written.
 	var chromeManagedFiles []string
-	for _, dir := range append(activePaths, cfg.Chrome.FlatpakChromiumPoliciesPath) {
+	for _, dir := range append(activePaths, cfg.Chrome.FlatpakChromiumPoliciesPath, cfg.Chrome.FlatpakEdgePoliciesPath) {
 		if dir != "" {
-			chromeManagedFiles = append(chromeManagedFiles, filepath.Join(dir, policy.ChromeManagedFilename))
+			chromeManagedFiles = append(chromeManagedFiles, policy.ChromePolicyFiles(dir)...)
 		}
 	}
 	suppressManagedWrites(cfg, chromeManagedFiles...)
@@ -887,24 +2008,39 @@ func syncAllChrome(ctx context.Context, client *policyclient.Client, cfg *config
 
 	if err := policy.SyncChromeFromProto(policies, activePaths); err != nil {
 		log.Printf("Error syncing Chrome policies: %v", err)
+		agentMetrics.SyncFailed("Chrome")
 		for _, id := range ids {
-			_ = client.ReportCompliance(ctx, id, false, "failed to sync Chrome policies: "+err.Error())
+			batch.ReportCompliance(id, false, policy.ComplianceMessage("failed to sync Chrome policies", err))
 		}
 		return false
 	}
 
-	// Flatpak Chromium is best-effort — log warning but don't fail.
-	if cfg.Chrome.FlatpakChromiumPoliciesPath != "" {
-		if err := policy.SyncChromeFromProto(policies, []string{cfg.Chrome.FlatpakChromiumPoliciesPath}); err != nil {
-			log.Printf("Warning: failed to sync Flatpak Chromium policies: %v", err)
+	var appliedPaths []string
+	for _, dir := range activePaths {
+		appliedPaths = append(appliedPaths, policy.ChromePolicyFiles(dir)...)
+	}
+
+	// Flatpak browsers are best-effort — log warning but don't fail.
+	for _, fp := range []struct{ name, dir string }{
+		{"Chromium", cfg.Chrome.FlatpakChromiumPoliciesPath},
+		{"Edge", cfg.Chrome.FlatpakEdgePoliciesPath},
+	} {
+		if fp.dir == "" {
+			continue
+		}
+		if err := policy.SyncChromeFromProto(policies, []string{fp.dir}); err != nil {
+			log.Printf("Warning: failed to sync Flatpak %s policies: %v", fp.name, err)
 		} else if len(policies) > 0 {
-			log.Printf("Flatpak Chromium policies synced to %s", cfg.Chrome.FlatpakChromiumPoliciesPath)
+			log.Printf("Flatpak %s policies synced to %s", fp.name, fp.dir)
+			appliedPaths = append(appliedPaths, policy.ChromePolicyFiles(fp.dir)...)
 		}
 	}
 
 	log.Printf("Chrome policies synced (%d policies)", len(ids))
+	agentMetrics.SyncApplied("Chrome")
+	applied := policy.HashAppliedFiles(appliedPaths...)
 	for _, id := range ids {
-		_ = client.ReportCompliance(ctx, id, true, "Deployed")
+		batch.ReportComplianceWithFiles(id, pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT, "Deployed", nil, applied)
 	}
 	return true
 }
@@ -914,11 +2050,16 @@ func syncAllChrome(ctx context.Context, client *policyclient.Client, cfg *config
 // Reports compliance back to the server for each affected policy ID.
 func syncAllDConf(ctx context.Context, client *policyclient.Client, cfg *config.Config) {
 	entries := make([]dconfCacheEntry, 0, len(dconfCache))
-	for _, e := range dconfCache {
+	for _, id := range policy.SortedIDs(dconfCache) {
+		e := dconfCache[id]
+		if !applicable(ctx, client, e.id) {
+			continue
+		}
 		entries = append(entries, e)
 	}
 	// Sort ascending by priority so higher-priority policies are processed last
-	// and win in the last-writer-wins merge.
+	// and win in the last-writer-wins merge. Policies of equal priority keep
+	// their policy ID order.
 	slices.SortStableFunc(entries, func(a, b dconfCacheEntry) int {
 		return cmp.Compare(a.priority, b.priority)
 	})
@@ -939,20 +2080,27 @@ func syncAllDConf(ctx context.Context, client *policyclient.Client, cfg *config.
 	dbDir := filepath.Join(policy.DConfDBDir, dbName+".d")
 	keyfilePath := filepath.Join(dbDir, "00-bor")
 	locksPath := filepath.Join(dbDir, "locks", "bor")
-	suppressManagedWrites(cfg, keyfilePath, locksPath)
-	defer updateWatcher(cfg)
 
-	if err := policy.SyncDConfFiles(dbName, keyfile, locksfile); err != nil {
-		log.Printf("Error syncing dconf files: %v", err)
-		for _, e := range entries {
-			_ = client.ReportComplianceWithStatus(ctx, e.id,
-				pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR,
-				"failed to sync dconf files: "+err.Error(), nil)
+	// In inventory-only mode nothing is written; the compliance check below
+	// reports the values currently in effect.
+	if !inventoryOnly(cfg) {
+		suppressManagedWrites(cfg, keyfilePath, locksPath)
+		defer updateWatcher(cfg)
+
+		if err := policy.SyncDConfFiles(dbName, keyfile, locksfile); err != nil {
+			log.Printf("Error syncing dconf files: %v", err)
+			agentMetrics.SyncFailed("Dconf")
+			for _, e := range entries {
+				_ = client.ReportComplianceWithStatus(ctx, e.id,
+					pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR,
+					policy.ComplianceMessage("failed to sync dconf files", err), nil)
+			}
+			return
 		}
-		return
-	}
 
-	log.Printf("dconf policies synced (db=%s, %d policies)", dbName, len(entries))
+		log.Printf("dconf policies synced (db=%s, %d policies)", dbName, len(entries))
+		agentMetrics.SyncApplied("Dconf")
+	}
 
 	// Compliance check uses the locally cached schema index.
 	idx := dconfSchemaIndex
@@ -983,6 +2131,8 @@ func syncAllDConf(ctx context.Context, client *policyclient.Client, cfg *config.
 		})
 	}
 
+	applied := policy.HashAppliedFiles(keyfilePath, locksPath)
+
 	// Report per-policy: annotate items whose key was overridden by a
 	// higher-priority policy, then compute a per-policy rollup status.
 	for _, e := range entries {
@@ -1013,7 +2163,7 @@ func syncAllDConf(ctx context.Context, client *policyclient.Client, cfg *config.
 		// using the merged-policy rollup (which is always COMPLIANT when the
 		// highest-priority policy is correctly enforced).
 		policyStatus, policyMsg := rollupProtoItems(items, overallStatus, msg)
-		_ = client.ReportComplianceWithStatus(ctx, e.id, policyStatus, policyMsg, items)
+		_ = client.ReportComplianceWithFiles(ctx, e.id, policyStatus, policyMsg, items, applied)
 	}
 }
 
@@ -1028,6 +2178,9 @@ func syncAllDConf(ctx context.Context, client *policyclient.Client, cfg *config.
 func syncAllPolkit(ctx context.Context, client *policyclient.Client, cfg *config.Config) {
 	entries := make([]polkitCacheEntry, 0, len(polkitCache))
 	for _, e := range polkitCache {
+		if !applicable(ctx, client, e.id) {
+			continue
+		}
 		entries = append(entries, e)
 	}
 
@@ -1037,6 +2190,11 @@ func syncAllPolkit(ctx context.Context, client *policyclient.Client, cfg *config
 		desiredPaths[policy.PolkitRulesPath(e.priority, e.id)] = true
 	}
 
+	if inventoryOnly(cfg) {
+		reportPolkitCompliance(ctx, client, entries)
+		return
+	}
+
 	// Discover existing bor-managed polkit files.
 	existingFiles, err := policy.ListBorManagedPolkitFiles()
 	if err != nil {
@@ -1074,6 +2232,7 @@ func syncAllPolkit(ctx context.Context, client *policyclient.Client, cfg *config
 		js, err := policy.PolkitPoliciesToJS(e.policy)
 		if err != nil {
 			log.Printf("polkit: failed to generate JS for policy %q: %v", e.name, err)
+			agentMetrics.SyncFailed("Polkit")
 			_ = client.ReportComplianceWithStatus(ctx, e.id,
 				pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR,
 				"failed to generate rules file: "+err.Error(), nil)
@@ -1082,14 +2241,99 @@ func syncAllPolkit(ctx context.Context, client *policyclient.Client, cfg *config
 
 		if err := policy.SyncPolkitRules(rulesPath, js); err != nil {
 			log.Printf("polkit: failed to sync %s: %v", rulesPath, err)
+			agentMetrics.SyncFailed("Polkit")
 			_ = client.ReportComplianceWithStatus(ctx, e.id,
 				pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR,
-				"failed to write rules file: "+err.Error(), nil)
+				policy.ComplianceMessage("failed to write rules file", err), nil)
 			continue
 		}
 
 		log.Printf("polkit: wrote %s for policy %q (%d rules)", rulesPath, e.name, len(e.policy.GetRules()))
+		agentMetrics.SyncApplied("Polkit")
+
+		reportPolkitCompliance(ctx, client, []polkitCacheEntry{e})
+	}
+}
+
+// syncAllSysctl merges all active Sysctl policies into
+// /etc/sysctl.d/99-bor.conf, applies it with `sysctl --system` and reports
+// per-policy compliance against the values the kernel reports. With no
+// active policies the original file is restored.
+func syncAllSysctl(ctx context.Context, client *policyclient.Client, cfg *config.Config) {
+	var allEntries []*pb.SysctlEntry
+	var ids []string
+	for _, id := range policy.SortedIDs(sysctlCache) {
+		e := sysctlCache[id]
+		if !applicable(ctx, client, id) {
+			continue
+		}
+		for _, se := range e.policy.GetEntries() {
+			allEntries = append(allEntries, &pb.SysctlEntry{Key: se.GetKey(), Value: se.GetValue(), Priority: e.priority})
+		}
+		ids = append(ids, id)
+	}
+
+	// In inventory-only mode nothing is written; the compliance check below
+	// reports the values currently in effect.
+	if !inventoryOnly(cfg) {
+		suppressManagedWrites(cfg, policy.SysctlConfPath)
+		defer updateWatcher(cfg)
+
+		if err := policy.SyncSysctl(allEntries); err != nil {
+			log.Printf("Error syncing sysctl settings: %v", err)
+			agentMetrics.SyncFailed("Sysctl")
+			for _, id := range ids {
+				_ = client.ReportComplianceWithStatus(ctx, id,
+					pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR,
+					policy.ComplianceMessage("failed to apply sysctl settings", err), nil)
+			}
+			return
+		}
+		if len(ids) > 0 {
+			log.Printf("sysctl policies synced (%d policies, %d entries)", len(ids), len(allEntries))
+			agentMetrics.SyncApplied("Sysctl")
+		}
+	}
+
+	merged := policy.MergeSysctlEntries(allEntries)
+	mergedValue := make(map[string]string, len(merged))
+	for _, e := range merged {
+		mergedValue[policy.SysctlPath(e.GetKey())] = e.GetValue()
+	}
+	results := policy.CheckSysctlCompliance(merged)
+	applied := policy.HashAppliedFiles(policy.SysctlConfPath)
+
+	// Report per-policy: keys another policy overrides are inapplicable to
+	// this one; the rest carry the live-value check of the merged entry.
+	for _, id := range ids {
+		own := make(map[string]string)
+		for _, se := range sysctlCache[id].policy.GetEntries() {
+			own[policy.SysctlPath(se.GetKey())] = se.GetValue()
+		}
+		items := make([]*pb.ComplianceItemResult, 0, len(own))
+		for _, r := range results {
+			path := policy.SysctlPath(r.Key)
+			v, ok := own[path]
+			if !ok {
+				continue
+			}
+			item := &pb.ComplianceItemResult{SchemaId: "sysctl", Key: r.Key, Status: r.Status, Message: r.Message}
+			if v != mergedValue[path] {
+				item.Status = pb.ComplianceStatus_COMPLIANCE_STATUS_INAPPLICABLE
+				item.Message = fmt.Sprintf("Overridden by a higher-priority policy; applied value: %s", mergedValue[path])
+			}
+			items = append(items, item)
+		}
+		status, msg := rollupProtoItems(items, pb.ComplianceStatus_COMPLIANCE_STATUS_INAPPLICABLE, "all entries overridden by other policies")
+		_ = client.ReportComplianceWithFiles(ctx, id, status, msg, items, applied)
+	}
+}
 
+// reportPolkitCompliance checks each policy's rules file on disk and reports
+// the per-rule results.
+func reportPolkitCompliance(ctx context.Context, client *policyclient.Client, entries []polkitCacheEntry) {
+	for _, e := range entries {
+		rulesPath := policy.PolkitRulesPath(e.priority, e.id)
 		results := policy.CheckPolkitCompliance(e.policy, rulesPath)
 		policyStatus, policyMsg := policy.RollupPolkitCompliance(results)
 
@@ -1103,7 +2347,36 @@ func syncAllPolkit(ctx context.Context, client *policyclient.Client, cfg *config
 			})
 		}
 
-		_ = client.ReportComplianceWithStatus(ctx, e.id, policyStatus, policyMsg, items)
+		_ = client.ReportComplianceWithFiles(ctx, e.id, policyStatus, policyMsg, items, policy.HashAppliedFiles(rulesPath))
+	}
+}
+
+// reportInventoryOnly reports monitor-only compliance for the given policies:
+// they are compliant when every target in want already holds the content the
+// agent would write, and non-compliant otherwise. Nothing is written.
+// policyType selects the content transform; kind names the targets in
+// messages.
+func reportInventoryOnly(ctx context.Context, client *policyclient.Client, ids []string, policyType, kind string, want map[string][]byte) {
+	if len(ids) == 0 {
+		return
+	}
+	drifted := policy.DriftedFiles(policyType, want)
+	status := pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT
+	msg := "Inventory-only mode: " + kind + " targets match the policy"
+	if len(drifted) > 0 {
+		status = pb.ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT
+		msg = fmt.Sprintf("Inventory-only mode: %s targets differ from the policy: %s", kind, strings.Join(drifted, ", "))
+	}
+	log.Printf("%s policies not enforced (inventory-only mode, %d policies, %d differing targets)", kind, len(ids), len(drifted))
+
+	paths := make([]string, 0, len(want))
+	for path := range want {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	applied := policy.HashAppliedFiles(paths...)
+	for _, id := range ids {
+		_ = client.ReportComplianceWithFiles(ctx, id, status, msg, nil, applied)
 	}
 }
 
@@ -1221,9 +2494,11 @@ func getManagedPaths(cfg *config.Config) []string {
 			cfg.Chrome.ChromiumPoliciesPath,
 			cfg.Chrome.ChromiumBrowserPoliciesPath,
 			cfg.Chrome.FlatpakChromiumPoliciesPath,
+			cfg.Chrome.EdgePoliciesPath,
+			cfg.Chrome.FlatpakEdgePoliciesPath,
 		} {
 			if dir != "" {
-				paths = append(paths, filepath.Join(dir, policy.ChromeManagedFilename))
+				paths = append(paths, policy.ChromePolicyFiles(dir)...)
 			}
 		}
 	}
@@ -1234,9 +2509,27 @@ func getManagedPaths(cfg *config.Config) []string {
 			paths = append(paths, filepath.Join(cfg.KConfig.ConfigPath, name))
 		}
 	}
+	if cfg.KConfig.UserSeedPath != "" {
+		if managed, err := policy.ManagedFiles(cfg.KConfig.UserSeedPath); err == nil {
+			for _, name := range managed {
+				paths = append(paths, filepath.Join(cfg.KConfig.UserSeedPath, name))
+			}
+		}
+	}
+	// KConfig: per-user directories of policies bound to user groups.
+	if users, err := policy.UserKConfigUsers(cfg.KConfig.ConfigPath); err == nil {
+		for _, u := range users {
+			dir := policy.UserKConfigDir(cfg.KConfig.ConfigPath, u)
+			if managed, err := policy.ManagedFiles(dir); err == nil {
+				for _, name := range managed {
+					paths = append(paths, filepath.Join(dir, name))
+				}
+			}
+		}
+	}
 	// KCM restriction files in /etc.
-	for _, pol := range kconfigCache {
-		if len(pol.GetKcmRestrictions()) > 0 {
+	for _, e := range kconfigCache {
+		if len(policy.SplitKConfigByScope(policy.KConfigPolicyToEntries(e.policy)).KCM) > 0 {
 			paths = append(paths, "/etc/kde5rc", "/etc/kde6rc")
 			break
 		}
@@ -1265,6 +2558,11 @@ func getManagedPaths(cfg *config.Config) []string {
 		paths = append(paths, polkitFiles...)
 	}
 
+	// Sysctl: the single drop-in, while any Sysctl policy is active.
+	if len(sysctlCache) > 0 {
+		paths = append(paths, policy.SysctlConfPath)
+	}
+
 	return paths
 }
 
@@ -1292,7 +2590,11 @@ func suppressManagedWrites(cfg *config.Config, extra ...string) {
 // or removed externally. It re-applies the appropriate policy to restore the
 // file to the Bor-managed state and reports the event to the server.
 func onTamperedFile(ctx context.Context, client *policyclient.Client, cfg *config.Config, path string) {
-	log.Printf("Tamper protection: restoring %s", path)
+	if inventoryOnly(cfg) {
+		log.Printf("Tamper protection: %s changed; not restoring in inventory-only mode", path)
+	} else {
+		log.Printf("Tamper protection: restoring %s", path)
+	}
 
 	// Collect process info before restoring — the modifying process may still
 	// hold the file open (e.g. an editor), giving us user/comm attribution.
@@ -1305,6 +2607,7 @@ func onTamperedFile(ctx context.Context, client *policyclient.Client, cfg *confi
 
 	switch {
 	case strings.HasPrefix(path, cfg.KConfig.ConfigPath+string(filepath.Separator)) ||
+		(cfg.KConfig.UserSeedPath != "" && strings.HasPrefix(path, cfg.KConfig.UserSeedPath+string(filepath.Separator))) ||
 		path == "/etc/kde5rc" || path == "/etc/kde6rc":
 		syncAllKConfig(ctx, client, cfg)
 	case path == cfg.Firefox.PoliciesPath || path == cfg.Firefox.FlatpakPoliciesPath:
@@ -1315,6 +2618,8 @@ func onTamperedFile(ctx context.Context, client *policyclient.Client, cfg *confi
 		syncAllPolkit(ctx, client, cfg)
 	case filepath.Base(path) == policy.ChromeManagedFilename:
 		syncAllChrome(ctx, client, cfg)
+	case path == policy.SysctlConfPath:
+		syncAllSysctl(ctx, client, cfg)
 	default:
 		log.Printf("Tamper protection: unrecognised managed path %s — no restore action taken", path)
 	}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package cmdoutput collects the output of the commands the agent runs
+// (compliance checks, remediation actions and content transform hooks),
+// keeping only a bounded prefix of it.
+package cmdoutput
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Buffer keeps the first max bytes written to it and discards the rest.
+type Buffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+// New returns a Buffer that keeps the first max bytes written to it.
+func New(maxBytes int) *Buffer {
+	return &Buffer{max: maxBytes}
+}
+
+// Write keeps as much of p as still fits. It never fails, so a command
+// producing too much output is not interrupted.
+func (b *Buffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := b.max - b.buf.Len(); n > room {
+		b.truncated = true
+		p = p[:max(room, 0)]
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+// Truncated reports whether output was discarded.
+func (b *Buffer) Truncated() bool {
+	return b.truncated
+}
+
+// Bytes returns the kept output unchanged.
+func (b *Buffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// Text returns the kept output trimmed of surrounding white space and
+// with invalid UTF-8 removed, so it can be reported in a proto string
+// field. Invalid sequences come from binary output or from the limit
+// splitting a multibyte character.
+func (b *Buffer) Text() string {
+	return strings.ToValidUTF8(strings.TrimSpace(b.buf.String()), "")
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package cmdoutput
+
+import "testing"
+
+func TestBufferLimit(t *testing.T) {
+	b := New(4)
+	n, err := b.Write([]byte("abcdef"))
+	if err != nil || n != 6 {
+		t.Fatalf("Write = %d, %v; want 6, nil", n, err)
+	}
+	_, _ = b.Write([]byte("gh"))
+	if string(b.Bytes()) != "abcd" || !b.Truncated() {
+		t.Errorf("buffer = %q truncated=%v, want abcd truncated", b.Bytes(), b.Truncated())
+	}
+
+	b = New(4)
+	_, _ = b.Write([]byte("abcd"))
+	if b.Truncated() {
+		t.Error("Truncated() = true for output that fits exactly")
+	}
+}
+
+func TestBufferText(t *testing.T) {
+	b := New(5)
+	// The limit keeps only the first byte of the two-byte "é".
+	_, _ = b.Write([]byte(" ok\xff\xc3\xa9"))
+	if got := b.Text(); got != "ok" {
+		t.Errorf("Text() = %q, want %q", got, "ok")
+	}
+}
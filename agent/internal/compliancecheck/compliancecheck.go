@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package compliancecheck runs the optional per-policy compliance check
+// commands supplied by the server and turns their exit status into a
+// compliance result.
+//
+// Because the commands come from the server, execution is guarded: the
+// agent must opt in, the executable must be on a local allowlist, and the
+// command runs without a shell, with an empty environment, as an
+// unprivileged user by default, and is killed (with its whole process
+// group) when it exceeds its timeout.
+package compliancecheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/VuteTech/Bor/agent/internal/cmdoutput"
+	"github.com/VuteTech/Bor/agent/internal/config"
+	"github.com/VuteTech/Bor/agent/internal/sandboxexec"
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+// maxOutput is the number of output bytes kept and reported to the server.
+const maxOutput = 4096
+
+// Result is the outcome of a single check.
+type Result struct {
+	Status   pb.ComplianceStatus
+	ExitCode int32 // -1 when the command did not exit normally
+	Message  string
+}
+
+// Runner executes compliance checks according to the agent configuration.
+type Runner struct {
+	enabled        bool
+	allowed        map[string]struct{}
+	runAsUser      string
+	defaultTimeout time.Duration
+	maxTimeout     time.Duration
+}
+
+// NewRunner creates a Runner from the agent's compliance_checks settings.
+func NewRunner(cfg config.ComplianceChecksConfig) *Runner {
+	allowed := make(map[string]struct{}, len(cfg.AllowedCommands))
+	for _, c := range cfg.AllowedCommands {
+		allowed[filepath.Clean(c)] = struct{}{}
+	}
+	return &Runner{
+		enabled:        cfg.Enabled,
+		allowed:        allowed,
+		runAsUser:      cfg.RunAsUser,
+		defaultTimeout: time.Duration(cfg.DefaultTimeoutSeconds) * time.Second,
+		maxTimeout:     time.Duration(cfg.MaxTimeoutSeconds) * time.Second,
+	}
+}
+
+// Enabled reports whether this agent has opted in to running checks.
+func (r *Runner) Enabled() bool {
+	return r.enabled
+}
+
+// Run executes chk and maps its exit status to a compliance result.
+func (r *Runner) Run(ctx context.Context, chk *pb.ComplianceCheck) Result {
+	if !r.enabled {
+		return inapplicable("compliance checks are disabled on this agent")
+	}
+	command := chk.GetCommand()
+	if !filepath.IsAbs(command) {
+		return inapplicable(fmt.Sprintf("check command %q is not an absolute path", command))
+	}
+	command = filepath.Clean(command)
+	if _, ok := r.allowed[command]; !ok {
+		return inapplicable(fmt.Sprintf("check command %s is not in the agent allowlist", command))
+	}
+
+	cred, err := lookupCredential(r.runAsUser)
+	if err != nil {
+		return errorResult(err.Error())
+	}
+
+	timeout := r.defaultTimeout
+	if t := chk.GetTimeoutSeconds(); t > 0 {
+		timeout = time.Duration(t) * time.Second
+	}
+	if r.maxTimeout > 0 && timeout > r.maxTimeout {
+		timeout = r.maxTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	out := cmdoutput.New(maxOutput)
+	cmd := sandboxexec.Command(ctx, command, chk.GetArgs()...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.SysProcAttr.Credential = cred
+
+	err = cmd.Run()
+	output := out.Text()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return errorResult(fmt.Sprintf("check timed out after %v", timeout))
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return Result{Status: pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT, ExitCode: 0, Message: output}
+	case errors.As(err, &exitErr) && exitErr.ExitCode() >= 0:
+		return Result{
+			Status:   pb.ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT,
+			ExitCode: int32(exitErr.ExitCode()), //nolint:gosec // exit codes fit in int32
+			Message:  output,
+		}
+	default:
+		return errorResult(fmt.Sprintf("failed to run check: %v", err))
+	}
+}
+
+// lookupCredential resolves the account a check runs as. It returns nil
+// (no switch) when that account is the one the agent already runs as.
+func lookupCredential(name string) (*syscall.Credential, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		return nil, fmt.Errorf("check user %q: %w", name, err)
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("check user %q: invalid uid %q", name, u.Uid)
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("check user %q: invalid gid %q", name, u.Gid)
+	}
+	if int(uid) == os.Getuid() {
+		return nil, nil
+	}
+	// Groups is empty so root's supplementary groups are dropped.
+	return &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid), Groups: []uint32{}}, nil
+}
+
+func inapplicable(msg string) Result {
+	return Result{Status: pb.ComplianceStatus_COMPLIANCE_STATUS_INAPPLICABLE, ExitCode: -1, Message: msg}
+}
+
+func errorResult(msg string) Result {
+	return Result{Status: pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR, ExitCode: -1, Message: msg}
+}
+
+// Set holds the check commands of all cached policies. It is safe for
+// concurrent use by the policy stream and the check loop.
+type Set struct {
+	mu      sync.Mutex
+	checks  map[string]*pb.ComplianceCheck
+	staging map[string]*pb.ComplianceCheck
+}
+
+// NewSet returns an empty Set.
+func NewSet() *Set {
+	return &Set{checks: make(map[string]*pb.ComplianceCheck)}
+}
+
+// Put records the check of a policy; a nil or empty check removes it.
+func (s *Set) Put(id string, chk *pb.ComplianceCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if chk.GetCommand() == "" {
+		delete(s.checks, id)
+		return
+	}
+	s.checks[id] = chk
+}
+
+// Delete removes the check of a policy.
+func (s *Set) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.checks, id)
+}
+
+// Stage records a check received as part of a SNAPSHOT. Staged checks
+// replace the current set when CommitSnapshot is called.
+func (s *Set) Stage(id string, chk *pb.ComplianceCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.staging == nil {
+		s.staging = make(map[string]*pb.ComplianceCheck)
+	}
+	if chk.GetCommand() != "" {
+		s.staging[id] = chk
+	}
+}
+
+// CommitSnapshot replaces the current checks with the staged ones.
+func (s *Set) CommitSnapshot() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checks = s.staging
+	if s.checks == nil {
+		s.checks = make(map[string]*pb.ComplianceCheck)
+	}
+	s.staging = nil
+}
+
+// All returns a copy of the current checks keyed by policy ID.
+func (s *Set) All() map[string]*pb.ComplianceCheck {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]*pb.ComplianceCheck, len(s.checks))
+	for id, chk := range s.checks {
+		out[id] = chk
+	}
+	return out
+}
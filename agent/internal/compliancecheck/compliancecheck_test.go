@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package compliancecheck
+
+import (
+	"context"
+	"os/user"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/VuteTech/Bor/agent/internal/config"
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+func newTestRunner(t *testing.T, enabled bool, allowed ...string) *Runner {
+	t.Helper()
+	u, err := user.Current()
+	if err != nil {
+		t.Skipf("cannot determine current user: %v", err)
+	}
+	return NewRunner(config.ComplianceChecksConfig{
+		Enabled:               enabled,
+		AllowedCommands:       allowed,
+		RunAsUser:             u.Username,
+		DefaultTimeoutSeconds: 5,
+	})
+}
+
+func TestRunDisabled(t *testing.T) {
+	r := newTestRunner(t, false, "/bin/sh")
+	res := r.Run(context.Background(), &pb.ComplianceCheck{Command: "/bin/sh", Args: []string{"-c", "exit 0"}})
+	if res.Status != pb.ComplianceStatus_COMPLIANCE_STATUS_INAPPLICABLE {
+		t.Errorf("status = %v, want INAPPLICABLE", res.Status)
+	}
+}
+
+func TestRunNotAllowed(t *testing.T) {
+	r := newTestRunner(t, true, "/usr/bin/firewall-cmd")
+	res := r.Run(context.Background(), &pb.ComplianceCheck{Command: "/bin/sh", Args: []string{"-c", "exit 0"}})
+	if res.Status != pb.ComplianceStatus_COMPLIANCE_STATUS_INAPPLICABLE {
+		t.Errorf("status = %v, want INAPPLICABLE", res.Status)
+	}
+	if !strings.Contains(res.Message, "allowlist") {
+		t.Errorf("message = %q, want allowlist reason", res.Message)
+	}
+
+	res = r.Run(context.Background(), &pb.ComplianceCheck{Command: "sh"})
+	if res.Status != pb.ComplianceStatus_COMPLIANCE_STATUS_INAPPLICABLE {
+		t.Errorf("relative command: status = %v, want INAPPLICABLE", res.Status)
+	}
+}
+
+func TestRunExitStatus(t *testing.T) {
+	r := newTestRunner(t, true, "/bin/sh")
+
+	res := r.Run(context.Background(), &pb.ComplianceCheck{Command: "/bin/sh", Args: []string{"-c", "echo ok"}})
+	if res.Status != pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT || res.ExitCode != 0 || res.Message != "ok" {
+		t.Errorf("exit 0: got %+v", res)
+	}
+
+	res = r.Run(context.Background(), &pb.ComplianceCheck{Command: "/bin/sh", Args: []string{"-c", "echo off >&2; exit 3"}})
+	if res.Status != pb.ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT || res.ExitCode != 3 || res.Message != "off" {
+		t.Errorf("exit 3: got %+v", res)
+	}
+}
+
+func TestRunEmptyEnvironment(t *testing.T) {
+	t.Setenv("BOR_CHECK_SECRET", "leak")
+	r := newTestRunner(t, true, "/bin/sh")
+	res := r.Run(context.Background(), &pb.ComplianceCheck{Command: "/bin/sh", Args: []string{"-c", "echo \"x${BOR_CHECK_SECRET}x\""}})
+	if res.Message != "xx" {
+		t.Errorf("agent environment leaked into check: %q", res.Message)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	r := newTestRunner(t, true, "/bin/sh")
+	res := r.Run(context.Background(), &pb.ComplianceCheck{Command: "/bin/sh", Args: []string{"-c", "sleep 10"}, TimeoutSeconds: 1})
+	if res.Status != pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR {
+		t.Errorf("status = %v, want ERROR", res.Status)
+	}
+	if !strings.Contains(res.Message, "timed out") {
+		t.Errorf("message = %q, want timeout reason", res.Message)
+	}
+}
+
+func TestRunTimeoutCapped(t *testing.T) {
+	u, err := user.Current()
+	if err != nil {
+		t.Skipf("cannot determine current user: %v", err)
+	}
+	r := NewRunner(config.ComplianceChecksConfig{
+		Enabled:               true,
+		AllowedCommands:       []string{"/bin/sh"},
+		RunAsUser:             u.Username,
+		DefaultTimeoutSeconds: 1,
+		MaxTimeoutSeconds:     1,
+	})
+	res := r.Run(context.Background(), &pb.ComplianceCheck{Command: "/bin/sh", Args: []string{"-c", "sleep 10"}, TimeoutSeconds: 3600})
+	if res.Status != pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR {
+		t.Errorf("status = %v, want ERROR", res.Status)
+	}
+	if res.Message != "check timed out after 1s" {
+		t.Errorf("message = %q, want timeout capped at 1s", res.Message)
+	}
+}
+
+func TestRunInvalidUTF8Output(t *testing.T) {
+	r := newTestRunner(t, true, "/bin/sh")
+	res := r.Run(context.Background(), &pb.ComplianceCheck{Command: "/bin/sh", Args: []string{"-c", `printf 'ok\377\376'`}})
+	if res.Message != "ok" {
+		t.Errorf("message = %q, want invalid bytes dropped", res.Message)
+	}
+}
+
+func TestRunOutputTruncatedInsideRune(t *testing.T) {
+	r := newTestRunner(t, true, "/bin/sh")
+	// 4095 ASCII bytes followed by a two-byte "é": the limit splits the rune.
+	script := `head -c 4095 /dev/zero | tr '\0' a; printf '\303\251'`
+	res := r.Run(context.Background(), &pb.ComplianceCheck{Command: "/bin/sh", Args: []string{"-c", script}})
+	if !utf8.ValidString(res.Message) {
+		t.Fatalf("message is not valid UTF-8: %q", res.Message[len(res.Message)-4:])
+	}
+	if want := strings.Repeat("a", maxOutput-1); res.Message != want {
+		t.Errorf("len(message) = %d, want %d ASCII bytes", len(res.Message), len(want))
+	}
+}
+
+func TestSetSnapshot(t *testing.T) {
+	s := NewSet()
+	s.Put("old", &pb.ComplianceCheck{Command: "/bin/true"})
+	s.Put("empty", &pb.ComplianceCheck{})
+	if got := len(s.All()); got != 1 {
+		t.Fatalf("len(All()) = %d, want 1 (empty check must be ignored)", got)
+	}
+
+	s.Stage("new", &pb.ComplianceCheck{Command: "/bin/false"})
+	s.CommitSnapshot()
+	all := s.All()
+	if _, ok := all["old"]; ok {
+		t.Error("check dropped by the snapshot is still present")
+	}
+	if _, ok := all["new"]; !ok {
+		t.Error("staged check missing after CommitSnapshot")
+	}
+}
@@ -8,6 +8,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 
 	"gopkg.in/yaml.v3"
@@ -20,8 +21,13 @@ type Config struct {
 	Firefox    FirefoxConfig    `yaml:"firefox"`
 	Chrome     ChromeConfig     `yaml:"chrome"`
 	KConfig    KConfigConfig    `yaml:"kconfig"`
+	Notify     NotifyConfig     `yaml:"notify"`
 	Enrollment EnrollmentConfig `yaml:"enrollment"`
 	Kerberos   KerberosConfig   `yaml:"kerberos"`
+
+	ComplianceChecks ComplianceChecksConfig `yaml:"compliance_checks"`
+	Remediation      RemediationConfig      `yaml:"remediation"`
+	ContentTransform ContentTransformConfig `yaml:"content_transform"`
 }
 
 // ServerConfig holds server connection settings.
@@ -34,6 +40,23 @@ type ServerConfig struct {
 	PolicyPort         int    `yaml:"policy_port"`          // port for mTLS policy streaming and cert renewal (default 8444)
 	CACert             string `yaml:"ca_cert"`              // optional path to CA cert for TLS verification
 	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"` // skip TLS verification during enrollment
+	// StreamFailuresBeforePolling is the number of consecutive policy
+	// stream attempts failing as unimplemented or unavailable after which
+	// the agent polls for policies instead (default 3).
+	StreamFailuresBeforePolling int `yaml:"stream_failures_before_polling"`
+	// PollIntervalSeconds is how often policies are fetched while polling;
+	// the stream is retried after each poll (default 300, minimum 30).
+	PollIntervalSeconds int `yaml:"poll_interval_seconds"`
+	// HeartbeatIntervalSeconds is how often a heartbeat is sent; the
+	// server marks nodes degraded, then offline, when heartbeats stop
+	// (default 60, minimum 10).
+	HeartbeatIntervalSeconds int `yaml:"heartbeat_interval_seconds"`
+	// KeepaliveIntervalSeconds is how long the policy connection may be
+	// idle before the agent pings the server, and KeepaliveTimeoutSeconds
+	// how long it waits for the answer before treating the connection as
+	// dead and reconnecting (defaults 30 and 10, minimums 10 and 1).
+	KeepaliveIntervalSeconds int `yaml:"keepalive_interval_seconds"`
+	KeepaliveTimeoutSeconds  int `yaml:"keepalive_timeout_seconds"`
 }
 
 // EnrollmentAddr returns the host:port for the enrollment / UI server.
@@ -49,6 +72,18 @@ func (s ServerConfig) PolicyAddr() string {
 // AgentConfig holds agent identification settings.
 type AgentConfig struct {
 	ClientID string `yaml:"client_id"`
+	// InventoryOnly keeps the agent from enforcing any policy: it still
+	// heartbeats and reports compliance, but never writes policy targets
+	// or notifies users. The server can also enable this per node group.
+	InventoryOnly bool `yaml:"inventory_only"`
+	// MetricsAddr is the host:port of an optional plain-HTTP listener
+	// serving /healthz and Prometheus /metrics, e.g. "127.0.0.1:9101".
+	// Empty disables it (default).
+	MetricsAddr string `yaml:"metrics_addr"`
+	// ComplianceQueueSize caps the compliance reports kept on disk while
+	// the server is unreachable; the oldest are dropped beyond it. 0
+	// disables the queue.
+	ComplianceQueueSize int `yaml:"compliance_queue_size"`
 }
 
 // FirefoxConfig holds Firefox policy file settings.
@@ -57,8 +92,9 @@ type FirefoxConfig struct {
 	FlatpakPoliciesPath string `yaml:"flatpak_policies_path"`
 }
 
-// ChromeConfig holds Chrome/Chromium policy directory settings.
-// The agent writes bor_managed.json into each configured directory.
+// ChromeConfig holds Chrome/Chromium/Edge policy directory settings.
+// The agent writes bor_managed.json into each configured directory, and
+// recommended policies into the sibling "recommended" directory.
 type ChromeConfig struct {
 	// Google Chrome (all channels: stable, beta, dev, unstable)
 	ChromePoliciesPath string `yaml:"chrome_policies_path"`
@@ -68,16 +104,117 @@ type ChromeConfig struct {
 	ChromiumBrowserPoliciesPath string `yaml:"chromium_browser_policies_path"`
 	// Flatpak Chromium (org.chromium.Chromium) — set empty to disable
 	FlatpakChromiumPoliciesPath string `yaml:"flatpak_chromium_policies_path"`
+	// Microsoft Edge (all channels share this path)
+	EdgePoliciesPath string `yaml:"edge_policies_path"`
+	// Flatpak Microsoft Edge (com.microsoft.Edge) — set empty to disable
+	FlatpakEdgePoliciesPath string `yaml:"flatpak_edge_policies_path"`
 }
 
 // KConfigConfig holds KDE Kiosk (KConfig) policy settings.
 type KConfigConfig struct {
 	ConfigPath string `yaml:"config_path"` // base directory for KDE config files (default /etc/bor/xdg)
+	// UserSeedPath receives per-user scoped settings as seed files that new
+	// accounts start with (default /etc/skel/.config). Empty disables
+	// per-user seeding; such settings are then reported as not applied.
+	UserSeedPath string `yaml:"user_seed_path"`
+}
+
+// NotifyConfig holds local desktop notification settings. Whether and what
+// to notify is configured on the server.
+type NotifyConfig struct {
+	// ReconfigureRules are the D-Bus calls made in each active session when
+	// a matching policy file changes, so running applications reload it.
+	// When unset, the built-in rules reloading KWin, Plasma shell and the
+	// KDE screen locker are used; set an empty list to disable reloads.
+	// Listing rules replaces the built-in ones.
+	ReconfigureRules []ReconfigureRule `yaml:"reconfigure_rules"`
+	// PerSession notifies and reconfigures every active graphical session
+	// rather than one session per user, e.g. for a user logged in on two
+	// seats of a multi-seat machine (default false).
+	PerSession bool `yaml:"notify_per_session"`
+	// SuppressWhenLocked skips notifying sessions whose screen is locked;
+	// reloads still happen, and the user is notified of the next change
+	// after unlocking (default true).
+	SuppressWhenLocked bool `yaml:"suppress_when_locked"`
+}
+
+// ReconfigureRule is a D-Bus method call triggered by a changed file.
+type ReconfigureRule struct {
+	// FilePattern is a filepath.Match pattern for the changed file's
+	// basename, e.g. "kwinrc" or "policies.json".
+	FilePattern string `yaml:"file_pattern"`
+	Dest        string `yaml:"dest"`   // D-Bus destination, e.g. "org.kde.KWin"
+	Path        string `yaml:"path"`   // object path, e.g. "/KWin"
+	Method      string `yaml:"method"` // interface-qualified method, e.g. "org.kde.KWin.reconfigure"
 }
 
 // EnrollmentConfig holds enrollment and mTLS settings.
 type EnrollmentConfig struct {
 	DataDir string `yaml:"data_dir"` // directory for persisted certs/keys (default /var/lib/bor/agent)
+	// ReenrollTokenFile is an optional pre-placed enrollment token. When the
+	// agent is not enrolled and no token was given on the command line, it
+	// enrolls with this token and deletes the file afterwards. This lets an
+	// administrator recover a node whose certificate was revoked by dropping
+	// a fresh token here. Set empty to disable (default /etc/bor/enrollment.token).
+	ReenrollTokenFile string `yaml:"reenroll_token_file"`
+	// RejectionThreshold is the number of consecutive connection attempts
+	// rejected by the server (Unauthenticated / NotFound) after which the
+	// agent discards its credentials and exits so that it can be re-enrolled
+	// (default 3).
+	RejectionThreshold int `yaml:"rejection_threshold"`
+}
+
+// ComplianceChecksConfig controls execution of server-supplied compliance
+// check commands. Running commands on behalf of the server is security
+// sensitive, so it is disabled by default and limited to an allowlist.
+type ComplianceChecksConfig struct {
+	// Enabled opts this agent in to running compliance checks (default false).
+	Enabled bool `yaml:"enabled"`
+	// AllowedCommands lists the absolute executable paths a check may use.
+	// Checks with any other command are reported as inapplicable.
+	AllowedCommands []string `yaml:"allowed_commands"`
+	// RunAsUser is the account checks run as (default "nobody"). Set to
+	// "root" only if a check genuinely needs root privileges.
+	RunAsUser string `yaml:"run_as_user"`
+	// IntervalSeconds is how often all checks are re-run (default 900).
+	IntervalSeconds int `yaml:"interval_seconds"`
+	// DefaultTimeoutSeconds applies to checks without their own timeout
+	// (default 30).
+	DefaultTimeoutSeconds int `yaml:"default_timeout_seconds"`
+	// MaxTimeoutSeconds caps the timeout a check may ask for, so the
+	// server cannot keep a check running indefinitely (default 300).
+	MaxTimeoutSeconds int `yaml:"max_timeout_seconds"`
+}
+
+// RemediationConfig controls the one-time remediation actions an
+// administrator can trigger from the server. Only the predefined actions
+// compiled into the agent can run, and only when enabled here.
+type RemediationConfig struct {
+	// Enabled opts this agent in to running remediation actions (default
+	// false).
+	Enabled bool `yaml:"enabled"`
+	// AllowedActions optionally narrows the predefined actions this agent
+	// accepts. When empty, all predefined actions are allowed.
+	AllowedActions []string `yaml:"allowed_actions"`
+	// TimeoutSeconds bounds a single action (default 120).
+	TimeoutSeconds int `yaml:"timeout_seconds"`
+}
+
+// ContentTransformConfig configures an optional local executable that
+// merged policy content is piped through before it is written, for
+// site-specific last-mile changes. The hook reads the content on stdin and
+// writes the content to use on stdout; a failing hook leaves the target
+// untouched and the affected policies non-compliant.
+type ContentTransformConfig struct {
+	// Command is the absolute path of the hook executable. Empty disables
+	// the hook (default).
+	Command string `yaml:"command"`
+	// Types optionally limits the hook to these policy types ("Firefox",
+	// "Chrome", "Kconfig", "Dconf", "Sysctl"). When empty, all of them are piped
+	// through the hook.
+	Types []string `yaml:"types"`
+	// TimeoutSeconds bounds a single hook run (default 10).
+	TimeoutSeconds int `yaml:"timeout_seconds"`
 }
 
 // KerberosConfig holds agent-side Kerberos configuration for token-free enrollment.
@@ -121,11 +258,18 @@ type KerberosConfig struct {
 func DefaultConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Address:        "localhost",
-			EnrollmentPort: 8443,
-			PolicyPort:     8444,
+			Address:                     "localhost",
+			EnrollmentPort:              8443,
+			PolicyPort:                  8444,
+			StreamFailuresBeforePolling: 3,
+			PollIntervalSeconds:         300,
+			HeartbeatIntervalSeconds:    60,
+			KeepaliveIntervalSeconds:    30,
+			KeepaliveTimeoutSeconds:     10,
+		},
+		Agent: AgentConfig{
+			ComplianceQueueSize: 500,
 		},
-		Agent: AgentConfig{},
 		Firefox: FirefoxConfig{
 			PoliciesPath:        "/etc/firefox/policies/policies.json",
 			FlatpakPoliciesPath: "/var/lib/flatpak/extension/org.mozilla.firefox.systemconfig/" + flatpakArch() + "/stable/policies/policies.json",
@@ -135,16 +279,36 @@ func DefaultConfig() *Config {
 			ChromiumPoliciesPath:        "/etc/chromium/policies/managed",
 			ChromiumBrowserPoliciesPath: "/etc/chromium-browser/policies/managed",
 			FlatpakChromiumPoliciesPath: "/var/lib/flatpak/extension/org.chromium.Chromium.Extension.system-policies/" + flatpakArch() + "/1/policies/managed",
+			EdgePoliciesPath:            "/etc/opt/edge/policies/managed",
+			FlatpakEdgePoliciesPath:     "/var/lib/flatpak/extension/com.microsoft.Edge.Extension.system-policies/" + flatpakArch() + "/1/policies/managed",
 		},
 		KConfig: KConfigConfig{
-			ConfigPath: "/etc/bor/xdg",
+			ConfigPath:   "/etc/bor/xdg",
+			UserSeedPath: "/etc/skel/.config",
+		},
+		Notify: NotifyConfig{
+			SuppressWhenLocked: true,
 		},
 		Enrollment: EnrollmentConfig{
-			DataDir: "/var/lib/bor/agent",
+			DataDir:            "/var/lib/bor/agent",
+			ReenrollTokenFile:  "/etc/bor/enrollment.token",
+			RejectionThreshold: 3,
 		},
 		Kerberos: KerberosConfig{
 			KeytabFile: "/etc/krb5.keytab",
 		},
+		ComplianceChecks: ComplianceChecksConfig{
+			RunAsUser:             "nobody",
+			IntervalSeconds:       900,
+			DefaultTimeoutSeconds: 30,
+			MaxTimeoutSeconds:     300,
+		},
+		Remediation: RemediationConfig{
+			TimeoutSeconds: 120,
+		},
+		ContentTransform: ContentTransformConfig{
+			TimeoutSeconds: 10,
+		},
 	}
 }
 
@@ -169,6 +333,58 @@ func Load(path string) (*Config, error) {
 		cfg.Agent.ClientID = hostname
 	}
 
+	if cfg.Enrollment.RejectionThreshold < 1 {
+		cfg.Enrollment.RejectionThreshold = 1
+	}
+	if cfg.Server.StreamFailuresBeforePolling < 1 {
+		cfg.Server.StreamFailuresBeforePolling = 1
+	}
+	if cfg.Server.PollIntervalSeconds < 30 {
+		cfg.Server.PollIntervalSeconds = 30
+	}
+	if cfg.Server.HeartbeatIntervalSeconds < 10 {
+		cfg.Server.HeartbeatIntervalSeconds = 10
+	}
+	// gRPC does not ping more often than every 10 seconds.
+	if cfg.Server.KeepaliveIntervalSeconds < 10 {
+		cfg.Server.KeepaliveIntervalSeconds = 10
+	}
+	if cfg.Server.KeepaliveTimeoutSeconds < 1 {
+		cfg.Server.KeepaliveTimeoutSeconds = 1
+	}
+	if cfg.ComplianceChecks.RunAsUser == "" {
+		cfg.ComplianceChecks.RunAsUser = "nobody"
+	}
+	if cfg.ComplianceChecks.IntervalSeconds < 60 {
+		cfg.ComplianceChecks.IntervalSeconds = 60
+	}
+	if cfg.ComplianceChecks.DefaultTimeoutSeconds < 1 {
+		cfg.ComplianceChecks.DefaultTimeoutSeconds = 30
+	}
+	if cfg.ComplianceChecks.MaxTimeoutSeconds < 1 {
+		cfg.ComplianceChecks.MaxTimeoutSeconds = 300
+	}
+	if cfg.ComplianceChecks.DefaultTimeoutSeconds > cfg.ComplianceChecks.MaxTimeoutSeconds {
+		cfg.ComplianceChecks.DefaultTimeoutSeconds = cfg.ComplianceChecks.MaxTimeoutSeconds
+	}
+	if cfg.Remediation.TimeoutSeconds < 1 {
+		cfg.Remediation.TimeoutSeconds = 120
+	}
+	if cfg.ContentTransform.TimeoutSeconds < 1 {
+		cfg.ContentTransform.TimeoutSeconds = 10
+	}
+	if cmd := cfg.ContentTransform.Command; cmd != "" && !filepath.IsAbs(cmd) {
+		return nil, fmt.Errorf("content_transform.command must be an absolute path: %s", cmd)
+	}
+	for i, r := range cfg.Notify.ReconfigureRules {
+		if r.FilePattern == "" || r.Dest == "" || r.Path == "" || r.Method == "" {
+			return nil, fmt.Errorf("notify.reconfigure_rules[%d]: file_pattern, dest, path and method are required", i)
+		}
+		if _, err := filepath.Match(r.FilePattern, ""); err != nil {
+			return nil, fmt.Errorf("notify.reconfigure_rules[%d]: invalid file_pattern %q: %w", i, r.FilePattern, err)
+		}
+	}
+
 	return cfg, nil
 }
 
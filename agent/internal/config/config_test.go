@@ -20,6 +20,7 @@ server:
   enrollment_port: 9090
 agent:
   client_id: "test-agent"
+  inventory_only: true
 firefox:
   policies_path: "/tmp/test/policies.json"
 `
@@ -38,6 +39,9 @@ firefox:
 	if cfg.Agent.ClientID != "test-agent" {
 		t.Errorf("expected client_id test-agent, got %s", cfg.Agent.ClientID)
 	}
+	if !cfg.Agent.InventoryOnly {
+		t.Error("expected inventory_only to be true")
+	}
 	if cfg.Firefox.PoliciesPath != "/tmp/test/policies.json" {
 		t.Errorf("expected policies_path /tmp/test/policies.json, got %s", cfg.Firefox.PoliciesPath)
 	}
@@ -67,6 +71,94 @@ func TestLoadDefaults(t *testing.T) {
 	if cfg.Agent.ClientID == "" {
 		t.Error("expected client_id to default to hostname")
 	}
+	if cfg.Enrollment.ReenrollTokenFile != "/etc/bor/enrollment.token" {
+		t.Errorf("expected default reenroll_token_file, got %s", cfg.Enrollment.ReenrollTokenFile)
+	}
+	if cfg.Enrollment.RejectionThreshold != 3 {
+		t.Errorf("expected default rejection_threshold 3, got %d", cfg.Enrollment.RejectionThreshold)
+	}
+	if cfg.Server.StreamFailuresBeforePolling != 3 || cfg.Server.PollIntervalSeconds != 300 {
+		t.Errorf("expected polling fallback after 3 failures every 300s, got %d/%ds",
+			cfg.Server.StreamFailuresBeforePolling, cfg.Server.PollIntervalSeconds)
+	}
+	if cfg.Server.HeartbeatIntervalSeconds != 60 {
+		t.Errorf("expected default heartbeat_interval_seconds 60, got %d", cfg.Server.HeartbeatIntervalSeconds)
+	}
+	if cfg.Server.KeepaliveIntervalSeconds != 30 || cfg.Server.KeepaliveTimeoutSeconds != 10 {
+		t.Errorf("expected keepalive pings every 30s with a 10s timeout, got %d/%ds",
+			cfg.Server.KeepaliveIntervalSeconds, cfg.Server.KeepaliveTimeoutSeconds)
+	}
+	if cfg.Chrome.EdgePoliciesPath != "/etc/opt/edge/policies/managed" {
+		t.Errorf("expected default edge_policies_path, got %s", cfg.Chrome.EdgePoliciesPath)
+	}
+	if !cfg.Notify.SuppressWhenLocked || cfg.Notify.PerSession {
+		t.Errorf("expected notifications suppressed when locked and one per user, got %+v", cfg.Notify)
+	}
+	if cfg.Agent.InventoryOnly {
+		t.Error("expected inventory_only to be disabled by default")
+	}
+	if cfg.ComplianceChecks.Enabled {
+		t.Error("expected compliance checks to be disabled by default")
+	}
+	if cfg.ComplianceChecks.RunAsUser != "nobody" {
+		t.Errorf("expected default run_as_user nobody, got %s", cfg.ComplianceChecks.RunAsUser)
+	}
+	if cfg.ComplianceChecks.MaxTimeoutSeconds != 300 {
+		t.Errorf("expected default compliance check max timeout 300, got %d", cfg.ComplianceChecks.MaxTimeoutSeconds)
+	}
+	if cfg.Remediation.Enabled {
+		t.Error("expected remediation to be disabled by default")
+	}
+	if cfg.Remediation.TimeoutSeconds != 120 {
+		t.Errorf("expected default remediation timeout 120, got %d", cfg.Remediation.TimeoutSeconds)
+	}
+	if cfg.ContentTransform.Command != "" || cfg.ContentTransform.TimeoutSeconds != 10 {
+		t.Errorf("expected content transform disabled with timeout 10, got %+v", cfg.ContentTransform)
+	}
+}
+
+func TestLoadRejectsRelativeTransformCommand(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(cfgPath, []byte("content_transform:\n  command: transform.sh\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(cfgPath); err == nil {
+		t.Error("expected error for relative content_transform.command")
+	}
+}
+
+func TestLoadReconfigureRules(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+
+	for _, tc := range []struct {
+		yaml    string
+		want    int
+		wantErr bool
+	}{
+		{yaml: "{}", want: 0},
+		{yaml: "notify:\n  reconfigure_rules: []\n", want: 0},
+		{yaml: "notify:\n  reconfigure_rules:\n    - {file_pattern: \"*.json\", dest: org.example.App, path: /App, method: org.example.App.Reload}\n", want: 1},
+		{yaml: "notify:\n  reconfigure_rules:\n    - {file_pattern: kwinrc, dest: org.kde.KWin}\n", wantErr: true},
+		{yaml: "notify:\n  reconfigure_rules:\n    - {file_pattern: \"[\", dest: org.example.App, path: /App, method: org.example.App.Reload}\n", wantErr: true},
+	} {
+		if err := os.WriteFile(cfgPath, []byte(tc.yaml), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := Load(cfgPath)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("Load(%q) = nil error", tc.yaml)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Load(%q) error = %v", tc.yaml, err)
+		}
+		if got := len(cfg.Notify.ReconfigureRules); got != tc.want {
+			t.Errorf("Load(%q): %d rules, want %d", tc.yaml, got, tc.want)
+		}
+	}
 }
 
 func TestLoadMissingFile(t *testing.T) {
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package contactloss implements the agent side of the per-policy
+// dead-man's switch. It tracks how long the agent has been out of contact
+// with the server and decides, per policy, whether the policy should
+// currently be enforced:
+//
+//   - KEEP policies are always enforced.
+//   - REVERT policies stop being enforced (originals are restored by the
+//     next sync) once contact has been lost for longer than their TTL.
+//   - ACTIVATE policies are dormant while in contact and only enforced
+//     once contact has been lost for longer than their TTL.
+package contactloss
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+// Rule is a policy's contact-loss behaviour.
+type Rule struct {
+	Action pb.ContactLossAction
+	TTL    time.Duration
+}
+
+// Tracker holds the contact-loss rules of all cached policies together with
+// the agent's connection state. It is safe for concurrent use.
+type Tracker struct {
+	mu          sync.Mutex
+	now         func() time.Time
+	rules       map[string]Rule
+	staging     map[string]Rule
+	connected   bool
+	lastContact time.Time
+	// applied records the enforcement state last reported by Changed.
+	applied map[string]bool
+}
+
+// New returns a Tracker that starts out disconnected, with the contact-loss
+// clock starting now.
+func New() *Tracker {
+	return newTracker(time.Now)
+}
+
+func newTracker(now func() time.Time) *Tracker {
+	return &Tracker{
+		now:         now,
+		rules:       make(map[string]Rule),
+		lastContact: now(),
+		applied:     make(map[string]bool),
+	}
+}
+
+// Set records the rule for a policy. KEEP rules are not stored since they
+// never affect enforcement.
+func (t *Tracker) Set(id string, r Rule) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if r.Action == pb.ContactLossAction_CONTACT_LOSS_ACTION_KEEP {
+		delete(t.rules, id)
+		return
+	}
+	t.rules[id] = r
+}
+
+// Delete forgets the rule for a policy.
+func (t *Tracker) Delete(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.rules, id)
+	delete(t.applied, id)
+}
+
+// Stage records a rule received as part of a SNAPSHOT. Staged rules replace
+// the current set when CommitSnapshot is called.
+func (t *Tracker) Stage(id string, r Rule) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.staging == nil {
+		t.staging = make(map[string]Rule)
+	}
+	if r.Action != pb.ContactLossAction_CONTACT_LOSS_ACTION_KEEP {
+		t.staging[id] = r
+	}
+}
+
+// CommitSnapshot replaces the current rules with the staged ones.
+func (t *Tracker) CommitSnapshot() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules = t.staging
+	if t.rules == nil {
+		t.rules = make(map[string]Rule)
+	}
+	t.staging = nil
+	for id := range t.applied {
+		if _, ok := t.rules[id]; !ok {
+			delete(t.applied, id)
+		}
+	}
+}
+
+// Connected marks the server as reachable.
+func (t *Tracker) Connected() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.connected = true
+	t.lastContact = t.now()
+}
+
+// Disconnected marks the server as unreachable. The contact-loss clock
+// starts at the first call after a connection; repeated calls while already
+// disconnected do not reset it.
+func (t *Tracker) Disconnected() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.connected {
+		t.connected = false
+		t.lastContact = t.now()
+	}
+}
+
+// Enforced reports whether the policy with the given ID should currently
+// be applied.
+func (t *Tracker) Enforced(id string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.enforcedLocked(id)
+}
+
+func (t *Tracker) enforcedLocked(id string) bool {
+	r, ok := t.rules[id]
+	if !ok {
+		return true
+	}
+	lost := !t.connected && t.now().Sub(t.lastContact) >= r.TTL
+	switch r.Action {
+	case pb.ContactLossAction_CONTACT_LOSS_ACTION_REVERT:
+		return !lost
+	case pb.ContactLossAction_CONTACT_LOSS_ACTION_ACTIVATE:
+		return lost
+	default:
+		return true
+	}
+}
+
+// Changed reports whether any policy has flipped between enforced and
+// dormant since the previous call, in which case the caller must re-sync.
+// Policies seen for the first time do not count as a change: they were
+// synced with their current state when they arrived.
+func (t *Tracker) Changed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	changed := false
+	for id := range t.rules {
+		enforced := t.enforcedLocked(id)
+		if prev, ok := t.applied[id]; ok && prev != enforced {
+			changed = true
+		}
+		t.applied[id] = enforced
+	}
+	return changed
+}
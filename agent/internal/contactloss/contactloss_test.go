@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package contactloss
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+func TestTrackerEnforced(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := newTracker(func() time.Time { return now })
+
+	tr.Set("keep", Rule{Action: pb.ContactLossAction_CONTACT_LOSS_ACTION_KEEP, TTL: time.Minute})
+	tr.Set("revert", Rule{Action: pb.ContactLossAction_CONTACT_LOSS_ACTION_REVERT, TTL: time.Hour})
+	tr.Set("activate", Rule{Action: pb.ContactLossAction_CONTACT_LOSS_ACTION_ACTIVATE, TTL: time.Hour})
+
+	check := func(stage string, want map[string]bool) {
+		t.Helper()
+		for id, w := range want {
+			if got := tr.Enforced(id); got != w {
+				t.Errorf("%s: Enforced(%q) = %v, want %v", stage, id, got, w)
+			}
+		}
+	}
+
+	tr.Connected()
+	check("connected", map[string]bool{"keep": true, "revert": true, "activate": false, "unknown": true})
+
+	tr.Disconnected()
+	now = now.Add(30 * time.Minute)
+	check("within ttl", map[string]bool{"keep": true, "revert": true, "activate": false})
+
+	// A second Disconnected must not restart the clock.
+	tr.Disconnected()
+	now = now.Add(31 * time.Minute)
+	check("past ttl", map[string]bool{"keep": true, "revert": false, "activate": true})
+
+	tr.Connected()
+	check("reconnected", map[string]bool{"keep": true, "revert": true, "activate": false})
+}
+
+func TestTrackerChanged(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := newTracker(func() time.Time { return now })
+	tr.Connected()
+
+	tr.Set("p1", Rule{Action: pb.ContactLossAction_CONTACT_LOSS_ACTION_REVERT, TTL: time.Hour})
+	if tr.Changed() {
+		t.Fatal("a newly added rule must not count as a change")
+	}
+
+	tr.Disconnected()
+	now = now.Add(2 * time.Hour)
+	if !tr.Changed() {
+		t.Fatal("expected change once the TTL has passed")
+	}
+	if tr.Changed() {
+		t.Fatal("change must only be reported once")
+	}
+
+	tr.Connected()
+	if !tr.Changed() {
+		t.Fatal("expected change after contact is restored")
+	}
+}
+
+func TestTrackerSnapshot(t *testing.T) {
+	tr := New()
+	tr.Set("old", Rule{Action: pb.ContactLossAction_CONTACT_LOSS_ACTION_ACTIVATE, TTL: time.Hour})
+	tr.Stage("new", Rule{Action: pb.ContactLossAction_CONTACT_LOSS_ACTION_ACTIVATE, TTL: time.Hour})
+	tr.CommitSnapshot()
+
+	tr.Connected()
+	if !tr.Enforced("old") {
+		t.Error("rule dropped by the snapshot must no longer apply")
+	}
+	if tr.Enforced("new") {
+		t.Error("staged ACTIVATE rule must be dormant while connected")
+	}
+}
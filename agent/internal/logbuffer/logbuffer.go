@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package logbuffer keeps the agent's most recent log output in memory so
+// it can be uploaded to the server on demand, without journald or shell
+// access to the node.
+package logbuffer
+
+import "sync"
+
+// Buffer is a fixed-size ring of the most recently written bytes. It is
+// safe for concurrent use and is meant to be installed as an additional
+// output of the standard logger.
+type Buffer struct {
+	mu        sync.Mutex
+	buf       []byte
+	start     int // index of the oldest byte once the ring has wrapped
+	full      bool
+	truncated bool
+}
+
+// New returns a Buffer that retains the last size bytes written to it.
+func New(size int) *Buffer {
+	return &Buffer{buf: make([]byte, 0, size)}
+}
+
+// Write appends p, overwriting the oldest output when the buffer is full.
+// It never fails.
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := len(p)
+	size := cap(b.buf)
+	if size == 0 {
+		return n, nil
+	}
+	if len(p) >= size {
+		b.buf = append(b.buf[:0], p[len(p)-size:]...)
+		b.start = 0
+		b.full = true
+		b.truncated = true
+		return n, nil
+	}
+	if !b.full {
+		free := size - len(b.buf)
+		if len(p) <= free {
+			b.buf = append(b.buf, p...)
+			return n, nil
+		}
+		b.buf = append(b.buf, p[:free]...)
+		p = p[free:]
+		b.full = true
+	}
+	// The ring is full: overwrite starting at the oldest byte.
+	b.truncated = true
+	for len(p) > 0 {
+		c := copy(b.buf[b.start:], p)
+		p = p[c:]
+		b.start = (b.start + c) % size
+	}
+	return n, nil
+}
+
+// Snapshot returns a copy of the buffered output, oldest first, and whether
+// earlier output was discarded. When output was discarded the partial first
+// line is dropped.
+func (b *Buffer) Snapshot() (content []byte, truncated bool) {
+	b.mu.Lock()
+	out := make([]byte, 0, len(b.buf))
+	out = append(out, b.buf[b.start:]...)
+	out = append(out, b.buf[:b.start]...)
+	truncated = b.truncated
+	b.mu.Unlock()
+
+	if truncated {
+		for i, c := range out {
+			if c == '\n' {
+				return out[i+1:], true
+			}
+		}
+	}
+	return out, truncated
+}
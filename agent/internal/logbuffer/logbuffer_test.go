@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package logbuffer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBuffer_KeepsEverythingBelowCapacity(t *testing.T) {
+	b := New(64)
+	fmt.Fprintln(b, "first")
+	fmt.Fprintln(b, "second")
+
+	got, truncated := b.Snapshot()
+	if truncated {
+		t.Error("Snapshot() truncated = true, want false")
+	}
+	if string(got) != "first\nsecond\n" {
+		t.Errorf("Snapshot() = %q, want %q", got, "first\nsecond\n")
+	}
+}
+
+func TestBuffer_KeepsMostRecentLines(t *testing.T) {
+	b := New(32)
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(b, "line %02d\n", i)
+	}
+
+	got, truncated := b.Snapshot()
+	if !truncated {
+		t.Error("Snapshot() truncated = false, want true")
+	}
+	if !strings.HasSuffix(string(got), "line 19\n") {
+		t.Errorf("Snapshot() = %q, want it to end with the last line", got)
+	}
+	if !strings.HasPrefix(string(got), "line ") {
+		t.Errorf("Snapshot() = %q, want it to start at a line boundary", got)
+	}
+	if len(got) > 32 {
+		t.Errorf("len(Snapshot()) = %d, want <= 32", len(got))
+	}
+}
+
+func TestBuffer_OversizedWrite(t *testing.T) {
+	b := New(8)
+	fmt.Fprint(b, "0123456789abcdef")
+
+	got, truncated := b.Snapshot()
+	if !truncated {
+		t.Error("Snapshot() truncated = false, want true")
+	}
+	if len(got) > 8 {
+		t.Errorf("len(Snapshot()) = %d, want <= 8", len(got))
+	}
+}
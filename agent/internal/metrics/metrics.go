@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package metrics tracks agent health and exposes it over an optional
+// local HTTP listener: /healthz for liveness checks and /metrics in the
+// Prometheus text format.
+package metrics
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Policy stream states reported by the bor_agent_stream_state metric.
+const (
+	StreamConnecting   = "connecting"
+	StreamConnected    = "connected"
+	StreamPolling      = "polling"
+	StreamDisconnected = "disconnected"
+)
+
+var streamStates = []string{StreamConnecting, StreamConnected, StreamPolling, StreamDisconnected}
+
+// Agent records the agent's sync and connection state. It is safe for
+// concurrent use and implements prometheus.Collector.
+type Agent struct {
+	mu           sync.Mutex
+	enrolled     bool
+	syncsApplied map[string]uint64 // policy type → successful syncs
+	syncErrors   map[string]uint64 // policy type → failed syncs
+	lastSync     time.Time
+	streamState  string
+	reconnects   uint64
+
+	syncsAppliedDesc *prometheus.Desc
+	syncErrorsDesc   *prometheus.Desc
+	lastSyncDesc     *prometheus.Desc
+	streamStateDesc  *prometheus.Desc
+	reconnectsDesc   *prometheus.Desc
+	enrolledDesc     *prometheus.Desc
+}
+
+// New creates an Agent in the connecting state.
+func New() *Agent {
+	return &Agent{
+		syncsApplied: make(map[string]uint64),
+		syncErrors:   make(map[string]uint64),
+		streamState:  StreamConnecting,
+
+		syncsAppliedDesc: prometheus.NewDesc("bor_agent_syncs_applied_total",
+			"Policy syncs applied successfully, by policy type.", []string{"type"}, nil),
+		syncErrorsDesc: prometheus.NewDesc("bor_agent_sync_errors_total",
+			"Policy syncs that failed, by policy type.", []string{"type"}, nil),
+		lastSyncDesc: prometheus.NewDesc("bor_agent_last_successful_sync_timestamp_seconds",
+			"Unix time of the last successful sync, 0 if none yet.", nil, nil),
+		streamStateDesc: prometheus.NewDesc("bor_agent_stream_state",
+			"Current policy stream state (1 for the current state, 0 otherwise).", []string{"state"}, nil),
+		reconnectsDesc: prometheus.NewDesc("bor_agent_stream_reconnects_total",
+			"Policy stream reconnection attempts.", nil, nil),
+		enrolledDesc: prometheus.NewDesc("bor_agent_enrolled",
+			"Whether the agent is enrolled (1) or not (0).", nil, nil),
+	}
+}
+
+// SetEnrolled records whether the agent holds enrollment credentials.
+func (a *Agent) SetEnrolled(enrolled bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.enrolled = enrolled
+}
+
+// SyncApplied records a successful sync of policies of policyType.
+func (a *Agent) SyncApplied(policyType string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.syncsApplied[policyType]++
+	a.lastSync = time.Now()
+}
+
+// SyncFailed records a failed sync of policies of policyType.
+func (a *Agent) SyncFailed(policyType string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.syncErrors[policyType]++
+}
+
+// Synced records that the full policy set from the server was applied,
+// even if it contained no policies to sync.
+func (a *Agent) Synced() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.lastSync = time.Now()
+}
+
+// SetStreamState records the policy stream state, one of the Stream*
+// constants.
+func (a *Agent) SetStreamState(state string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.streamState = state
+}
+
+// Reconnected records a policy stream reconnection attempt.
+func (a *Agent) Reconnected() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.reconnects++
+}
+
+// Healthy reports whether the agent is enrolled and has synced at least
+// once.
+func (a *Agent) Healthy() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.enrolled && !a.lastSync.IsZero()
+}
+
+// Describe implements prometheus.Collector.
+func (a *Agent) Describe(ch chan<- *prometheus.Desc) {
+	ch <- a.syncsAppliedDesc
+	ch <- a.syncErrorsDesc
+	ch <- a.lastSyncDesc
+	ch <- a.streamStateDesc
+	ch <- a.reconnectsDesc
+	ch <- a.enrolledDesc
+}
+
+// Collect implements prometheus.Collector.
+func (a *Agent) Collect(ch chan<- prometheus.Metric) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for typ, n := range a.syncsApplied {
+		ch <- prometheus.MustNewConstMetric(a.syncsAppliedDesc, prometheus.CounterValue, float64(n), typ)
+	}
+	for typ, n := range a.syncErrors {
+		ch <- prometheus.MustNewConstMetric(a.syncErrorsDesc, prometheus.CounterValue, float64(n), typ)
+	}
+	var lastSync float64
+	if !a.lastSync.IsZero() {
+		lastSync = float64(a.lastSync.Unix())
+	}
+	ch <- prometheus.MustNewConstMetric(a.lastSyncDesc, prometheus.GaugeValue, lastSync)
+	for _, state := range streamStates {
+		v := 0.0
+		if state == a.streamState {
+			v = 1
+		}
+		ch <- prometheus.MustNewConstMetric(a.streamStateDesc, prometheus.GaugeValue, v, state)
+	}
+	ch <- prometheus.MustNewConstMetric(a.reconnectsDesc, prometheus.CounterValue, float64(a.reconnects))
+	enrolled := 0.0
+	if a.enrolled {
+		enrolled = 1
+	}
+	ch <- prometheus.MustNewConstMetric(a.enrolledDesc, prometheus.GaugeValue, enrolled)
+}
+
+// Handler serves /healthz, which returns 200 once the agent is healthy
+// (see Healthy) and 503 before, and /metrics.
+func (a *Agent) Handler() http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(a)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if !a.Healthy() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("not ready\n"))
+			return
+		}
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	return mux
+}
+
+// NewServer builds a plain-HTTP server on addr (host:port) serving
+// a.Handler. The returned *http.Server is not started — call
+// ListenAndServe in a goroutine.
+func NewServer(addr string, a *Agent) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           a.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+}
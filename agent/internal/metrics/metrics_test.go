@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func get(t *testing.T, h http.Handler, path string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	body, err := io.ReadAll(rec.Result().Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rec.Code, string(body)
+}
+
+func TestHealthz(t *testing.T) {
+	a := New()
+	h := a.Handler()
+
+	if code, _ := get(t, h, "/healthz"); code != http.StatusServiceUnavailable {
+		t.Errorf("before enrollment: /healthz = %d, want 503", code)
+	}
+	a.SetEnrolled(true)
+	if code, _ := get(t, h, "/healthz"); code != http.StatusServiceUnavailable {
+		t.Errorf("before the first sync: /healthz = %d, want 503", code)
+	}
+	a.SyncFailed("Firefox")
+	if code, _ := get(t, h, "/healthz"); code != http.StatusServiceUnavailable {
+		t.Errorf("after a failed sync: /healthz = %d, want 503", code)
+	}
+	a.Synced()
+	if code, _ := get(t, h, "/healthz"); code != http.StatusOK {
+		t.Errorf("after a sync: /healthz = %d, want 200", code)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	a := New()
+	a.SetEnrolled(true)
+	a.SyncApplied("Firefox")
+	a.SyncApplied("Firefox")
+	a.SyncFailed("Chrome")
+	a.SetStreamState(StreamConnected)
+	a.Reconnected()
+
+	code, body := get(t, a.Handler(), "/metrics")
+	if code != http.StatusOK {
+		t.Fatalf("/metrics = %d, want 200", code)
+	}
+	for _, want := range []string{
+		`bor_agent_syncs_applied_total{type="Firefox"} 2`,
+		`bor_agent_sync_errors_total{type="Chrome"} 1`,
+		`bor_agent_stream_state{state="connected"} 1`,
+		`bor_agent_stream_state{state="polling"} 0`,
+		`bor_agent_stream_reconnects_total 1`,
+		`bor_agent_enrolled 1`,
+		`bor_agent_last_successful_sync_timestamp_seconds `,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics does not contain %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "bor_agent_last_successful_sync_timestamp_seconds 0\n") {
+		t.Error("last successful sync timestamp should be set")
+	}
+}
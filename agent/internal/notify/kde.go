@@ -2,7 +2,7 @@
 // Copyright (C) 2026 Vute Tech LTD
 // Copyright (C) 2026 Bor contributors
 
-// Package notify provides desktop notification and KDE application
+// Package notify provides desktop notification and application
 // reconfigure support via D-Bus. It is used by the agent to inform
 // logged-in users when KDE policies have been updated.
 //
@@ -13,12 +13,15 @@
 package notify
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,17 +36,46 @@ type Config struct {
 	Message  string
 }
 
+// ReconfigureRule is a D-Bus method call sent to every active session
+// when a changed file matches FilePattern, so the application owning the
+// file reloads it.
+type ReconfigureRule struct {
+	// FilePattern is matched against changed file basenames with
+	// filepath.Match, e.g. "kwinrc" or "*.json".
+	FilePattern string
+	// Dest is the D-Bus destination, e.g. "org.kde.KWin".
+	Dest string
+	// Path is the object path, e.g. "/KWin".
+	Path string
+	// Method is the interface-qualified method, e.g. "org.kde.KWin.reconfigure".
+	Method string
+}
+
+// DefaultReconfigureRules reload KWin, Plasma shell and the KDE screen
+// locker when their configuration changes.
+var DefaultReconfigureRules = []ReconfigureRule{
+	{FilePattern: "kwinrc", Dest: "org.kde.KWin", Path: "/KWin", Method: "org.kde.KWin.reconfigure"},
+	{FilePattern: "plasma-org.kde.plasma.desktop-appletsrc", Dest: "org.kde.plasmashell", Path: "/PlasmaShell", Method: "org.kde.PlasmaShell.refreshCurrentDesktop"},
+	{FilePattern: "plasmarc", Dest: "org.kde.plasmashell", Path: "/PlasmaShell", Method: "org.kde.PlasmaShell.refreshCurrentDesktop"},
+	{FilePattern: "kdeglobals", Dest: "org.kde.plasmashell", Path: "/PlasmaShell", Method: "org.kde.PlasmaShell.refreshCurrentDesktop"},
+	{FilePattern: "kscreenlockerrc", Dest: "org.kde.screensaver", Path: "/ScreenSaver", Method: "org.kde.screensaver.configure"},
+}
+
 // NotifyDebounce is the delay before sending a notification after the
 // last change. Rapid successive changes reset the timer so that a
 // single notification covers an entire batch of admin edits.
 const NotifyDebounce = 10 * time.Second
 
-// Notifier sends desktop notifications and KDE reconfigure signals
-// to active user sessions via D-Bus.
+// Notifier sends desktop notifications and application reconfigure
+// signals to active user sessions via D-Bus.
 type Notifier struct {
 	mu           sync.Mutex
-	lastSent     map[uint32]time.Time // UID → last notification time
-	lastNotifyID map[uint32]uint32    // UID → last notify-send notification ID
+	lastSent     map[sessionKey]time.Time // session → last notification time
+	lastNotifyID map[sessionKey]uint32    // session → last notify-send notification ID
+	rules        []ReconfigureRule
+	perSession   bool
+	skipLocked   bool
+	logoutAction bool
 
 	debounceMu    sync.Mutex
 	debounceTimer *time.Timer
@@ -54,11 +86,50 @@ type Notifier struct {
 // New creates a Notifier.
 func New() *Notifier {
 	return &Notifier{
-		lastSent:     make(map[uint32]time.Time),
-		lastNotifyID: make(map[uint32]uint32),
+		lastSent:     make(map[sessionKey]time.Time),
+		lastNotifyID: make(map[sessionKey]uint32),
+		rules:        DefaultReconfigureRules,
+		skipLocked:   true,
 	}
 }
 
+// SetSuppressWhenLocked controls whether notifications to a session with
+// a locked screen are skipped (the default). Reconfigure calls are still
+// made, and the cooldown is not reset, so the user is notified of the
+// next change after unlocking.
+func (n *Notifier) SetSuppressWhenLocked(suppress bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.skipLocked = suppress
+}
+
+// SetReconfigureRules replaces the reconfigure rules, which default to
+// DefaultReconfigureRules. An empty list disables reconfiguring.
+func (n *Notifier) SetReconfigureRules(rules []ReconfigureRule) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.rules = rules
+}
+
+// SetPerSession controls whether every active graphical session is
+// notified and reconfigured. By default a user with several sessions, e.g.
+// on two seats of a multi-seat machine, is handled once; per session, each
+// of their sessions is, with its own cooldown.
+func (n *Notifier) SetPerSession(perSession bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.perSession = perSession
+}
+
+// SetLogoutAction controls whether notifications offer a "Log out now"
+// button that logs the user out of their session. It suits notifications
+// asking users to log out, and is off by default.
+func (n *Notifier) SetLogoutAction(enabled bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.logoutAction = enabled
+}
+
 // ScheduleNotification accumulates changed files and resets the
 // debounce timer. When the timer fires (after NotifyDebounce of
 // inactivity), a single NotifyAndReconfigure call is made covering
@@ -97,16 +168,51 @@ func (n *Notifier) flush() {
 
 // session holds information about an active graphical login session.
 type session struct {
+	ID   string // logind session ID, e.g. "2" or "c1"
 	UID  uint32
 	GID  uint32
 	User string
+	// Seat and Display are the session's seat (e.g. "seat0") and X11
+	// display (e.g. ":0"); either may be empty.
+	Seat    string
+	Display string
+}
+
+// sessionKey identifies a notification target: a user, or one of their
+// sessions when notifying per session.
+type sessionKey struct {
+	UID     uint32
+	Seat    string
+	Display string
+}
+
+// targetSessions returns the sessions to notify: all of them per session,
+// otherwise the first session of each user. The key of each target is
+// returned alongside it.
+func targetSessions(sessions []session, perSession bool) ([]session, []sessionKey) {
+	var targets []session
+	var keys []sessionKey
+	seen := make(map[sessionKey]bool)
+	for _, s := range sessions {
+		key := sessionKey{UID: s.UID}
+		if perSession {
+			key.Seat, key.Display = s.Seat, s.Display
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		targets = append(targets, s)
+		keys = append(keys, key)
+	}
+	return targets, keys
 }
 
 // NotifyAndReconfigure sends desktop notifications to all active
-// graphical sessions and triggers reconfigure signals for KDE apps
-// whose config files have changed.
+// graphical sessions and triggers the reconfigure rules matching the
+// config files that have changed.
 //
-// changedFiles is the set of KConfig file basenames that were written
+// changedFiles is the set of file basenames that were written
 // (e.g. "kwinrc", "kdeglobals"). cfg controls whether notifications
 // are sent, the cooldown, and the message text.
 //
@@ -128,27 +234,28 @@ func (n *Notifier) NotifyAndReconfigure(cfg Config, changedFiles map[string]bool
 		return
 	}
 
-	// Deduplicate sessions by UID (a user may have multiple sessions).
-	seen := make(map[uint32]bool)
-	var unique []session
-	for _, s := range sessions {
-		if !seen[s.UID] {
-			seen[s.UID] = true
-			unique = append(unique, s)
-		}
-	}
-
 	now := time.Now()
+	n.mu.Lock()
+	calls := matchingRules(n.rules, changedFiles)
+	perSession := n.perSession
+	skipLocked := n.skipLocked
+	n.mu.Unlock()
 
-	for _, s := range unique {
+	// Unless notifying per session, a user with several sessions is
+	// handled once.
+	targets, keys := targetSessions(sessions, perSession)
+	for i, s := range targets {
+		key := keys[i]
 		// Send desktop notification (with cooldown).
 		if cfg.Enabled {
-			if n.shouldNotify(s.UID, now, cfg.Cooldown) {
-				if err := n.sendNotification(s, cfg.Message); err != nil {
+			if skipLocked && sessionLocked(s) {
+				log.Printf("notify: skipping notification for UID %d (screen locked)", s.UID)
+			} else if n.shouldNotify(key, now, cfg.Cooldown) {
+				if err := n.sendNotification(s, key, cfg.Message); err != nil {
 					log.Printf("notify: failed to send notification to UID %d: %v", s.UID, err)
 				} else {
 					log.Printf("notify: sent desktop notification to user %s (UID %d)", s.User, s.UID)
-					n.recordNotification(s.UID, now)
+					n.recordNotification(key, now)
 				}
 			} else {
 				log.Printf("notify: skipping notification for UID %d (cooldown)", s.UID)
@@ -156,24 +263,24 @@ func (n *Notifier) NotifyAndReconfigure(cfg Config, changedFiles map[string]bool
 		}
 
 		// Trigger app-specific reconfigure signals.
-		reconfigureApps(s, changedFiles)
+		reconfigureApps(s, calls)
 	}
 }
 
 // shouldNotify checks whether enough time has elapsed since the last
-// notification to this UID.
-func (n *Notifier) shouldNotify(uid uint32, now time.Time, cooldown time.Duration) bool {
+// notification to this target.
+func (n *Notifier) shouldNotify(key sessionKey, now time.Time, cooldown time.Duration) bool {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	last, ok := n.lastSent[uid]
+	last, ok := n.lastSent[key]
 	return !ok || now.Sub(last) >= cooldown
 }
 
 // recordNotification records the timestamp of the last notification.
-func (n *Notifier) recordNotification(uid uint32, t time.Time) {
+func (n *Notifier) recordNotification(key sessionKey, t time.Time) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	n.lastSent[uid] = t
+	n.lastSent[key] = t
 }
 
 // sendNotification sends a freedesktop desktop notification using
@@ -181,9 +288,14 @@ func (n *Notifier) recordNotification(uid uint32, t time.Time) {
 // capture the notification ID and --replace-id on subsequent calls so
 // that repeated notifications replace the previous one rather than
 // creating new ones (which triggers ExcessNotificationGeneration).
-func (n *Notifier) sendNotification(s session, message string) error {
+//
+// With the logout action enabled the notification carries a "Log out now"
+// button. Should that fail, e.g. with a notify-send too old for actions,
+// the notification is sent again without it.
+func (n *Notifier) sendNotification(s session, key sessionKey, message string) error {
 	n.mu.Lock()
-	replaceID := n.lastNotifyID[s.UID]
+	replaceID := n.lastNotifyID[key]
+	withLogout := n.logoutAction
 	n.mu.Unlock()
 
 	args := []string{
@@ -197,69 +309,158 @@ func (n *Notifier) sendNotification(s session, message string) error {
 	}
 	args = append(args, "Desktop Policies Updated", message)
 
+	if withLogout {
+		err := n.sendNotificationWithLogout(s, key, args)
+		if err == nil {
+			return nil
+		}
+		log.Printf("notify: logout action unavailable for UID %d, notifying without it: %v", s.UID, err)
+	}
+	return n.sendPlainNotification(s, key, args)
+}
+
+// sendPlainNotification runs notify-send with args and records the
+// notification ID it prints.
+func (n *Notifier) sendPlainNotification(s session, key sessionKey, args []string) error {
 	output, err := runAsUserOutput(s, "notify-send", args...)
 	if err != nil {
 		return err
 	}
+	n.recordNotifyID(key, output)
+	return nil
+}
 
-	// Store the returned ID for replacement on the next notification.
+// recordNotifyID stores the ID printed by notify-send --print-id for
+// replacement on the next notification.
+func (n *Notifier) recordNotifyID(key sessionKey, output string) {
 	if id, err := strconv.ParseUint(strings.TrimSpace(output), 10, 32); err == nil && id > 0 {
 		n.mu.Lock()
-		n.lastNotifyID[s.UID] = uint32(id)
+		n.lastNotifyID[key] = uint32(id)
 		n.mu.Unlock()
 	}
-	return nil
 }
 
-// reconfigureApps sends D-Bus reconfigure calls to KDE applications
-// based on which config files changed.
-func reconfigureApps(s session, changedFiles map[string]bool) {
-	if changedFiles["kwinrc"] {
-		if err := reconfigureKWin(s); err != nil {
-			log.Printf("notify: KWin reconfigure failed for UID %d: %v", s.UID, err)
-		} else {
-			log.Printf("notify: KWin reconfigure sent to UID %d", s.UID)
-		}
+// logoutActionKey is the notify-send action key of the "Log out now"
+// button; notify-send prints it when the button is clicked.
+const logoutActionKey = "logout"
+
+// sendNotificationWithLogout sends a notification with a "Log out now"
+// button. notify-send prints the notification ID and then waits until the
+// notification is closed, printing the key of a clicked action, so it is
+// left running in the background and the session is logged out when the
+// button is clicked. Replacing the notification closes it, which ends the
+// previous notify-send. If notify-send fails without printing anything,
+// the notification is sent again without the button.
+func (n *Notifier) sendNotificationWithLogout(s session, key sessionKey, args []string) error {
+	cmd := userCommand(s, "notify-send", append([]string{"--action=" + logoutActionKey + "=Log out now"}, args...)...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("notify-send: %w", err)
 	}
-
-	if changedFiles["plasma-org.kde.plasma.desktop-appletsrc"] {
-		if err := reconfigurePlasmaShell(s); err != nil {
-			log.Printf("notify: Plasma shell reconfigure failed for UID %d: %v", s.UID, err)
-		} else {
-			log.Printf("notify: Plasma shell reconfigure sent to UID %d", s.UID)
-		}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("notify-send: %w", err)
 	}
 
-	if changedFiles["plasmarc"] || changedFiles["kdeglobals"] {
-		if err := reconfigurePlasmaShell(s); err != nil {
-			log.Printf("notify: Plasma shell reconfigure failed for UID %d: %v", s.UID, err)
-		} else {
-			log.Printf("notify: Plasma shell reconfigure sent to UID %d", s.UID)
+	go func() {
+		printed := readNotifyOutput(bufio.NewScanner(stdout), func(id string) {
+			n.recordNotifyID(key, id)
+		}, func(action string) {
+			if action != logoutActionKey {
+				return
+			}
+			log.Printf("notify: user %s (UID %d) chose to log out", s.User, s.UID)
+			if err := logoutSession(s); err != nil {
+				log.Printf("notify: logout failed for UID %d: %v", s.UID, err)
+			}
+		})
+		err := cmd.Wait()
+		if err == nil {
+			return
 		}
-	}
+		if printed {
+			log.Printf("notify: notify-send for UID %d: %v: %s", s.UID, err, strings.TrimSpace(stderr.String()))
+			return
+		}
+		log.Printf("notify: logout action unavailable for UID %d, notifying without it: %v: %s", s.UID, err, strings.TrimSpace(stderr.String()))
+		if err := n.sendPlainNotification(s, key, args); err != nil {
+			log.Printf("notify: failed to send notification to UID %d: %v", s.UID, err)
+		}
+	}()
+	return nil
+}
 
-	if changedFiles["kscreenlockerrc"] {
-		if err := reconfigureScreenLocker(s); err != nil {
-			log.Printf("notify: screen locker reconfigure failed for UID %d: %v", s.UID, err)
-		} else {
-			log.Printf("notify: screen locker reconfigure sent to UID %d", s.UID)
+// readNotifyOutput reads the output of notify-send --print-id with
+// actions until the notification is closed: the notification ID, then the
+// key of each clicked action. It reports whether anything was printed.
+func readNotifyOutput(lines *bufio.Scanner, onID, onAction func(string)) bool {
+	printed := false
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if !printed {
+			printed = true
+			onID(line)
+			continue
 		}
+		onAction(line)
 	}
+	return printed
+}
+
+// logoutCalls are the D-Bus calls asking a desktop to log the user out,
+// tried in order until one is accepted: Plasma 6, Plasma 5 and GNOME.
+// Each lets the user confirm or cancel.
+var logoutCalls = [][]string{
+	{"--dest=org.kde.LogoutPrompt", "/LogoutPrompt", "org.kde.LogoutPrompt.promptLogout"},
+	{"--dest=org.kde.ksmserver", "/KSMServer", "org.kde.KSMServerInterface.logout", "int32:1", "int32:0", "int32:0"},
+	{"--dest=org.gnome.SessionManager", "/org/gnome/SessionManager", "org.gnome.SessionManager.Logout", "uint32:0"},
 }
 
-// reconfigureKWin tells KWin to reload its configuration.
-func reconfigureKWin(s session) error {
-	return dbusCall(s, "org.kde.KWin", "/KWin", "org.kde.KWin.reconfigure")
+// logoutSession asks the desktop of session s to log the user out.
+func logoutSession(s session) error {
+	var errs []error
+	for _, call := range logoutCalls {
+		args := append([]string{"--session", "--print-reply", "--type=method_call"}, call...)
+		err := runAsUser(s, "dbus-send", args...)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
 }
 
-// reconfigurePlasmaShell tells Plasma shell to refresh.
-func reconfigurePlasmaShell(s session) error {
-	return dbusCall(s, "org.kde.plasmashell", "/PlasmaShell", "org.kde.PlasmaShell.refreshCurrentDesktop")
+// matchingRules returns the rules matching any of changedFiles, in rule
+// order. Rules making the same call are returned once, so an application
+// is reloaded once however many of its files changed.
+func matchingRules(rules []ReconfigureRule, changedFiles map[string]bool) []ReconfigureRule {
+	var calls []ReconfigureRule
+	for _, r := range rules {
+		if slices.ContainsFunc(calls, func(c ReconfigureRule) bool {
+			return c.Dest == r.Dest && c.Path == r.Path && c.Method == r.Method
+		}) {
+			continue
+		}
+		for file := range changedFiles {
+			if ok, _ := filepath.Match(r.FilePattern, file); ok {
+				calls = append(calls, r)
+				break
+			}
+		}
+	}
+	return calls
 }
 
-// reconfigureScreenLocker tells the KDE screen locker to reload config.
-func reconfigureScreenLocker(s session) error {
-	return dbusCall(s, "org.kde.screensaver", "/ScreenSaver", "org.kde.screensaver.configure")
+// reconfigureApps sends the given reconfigure calls to a session.
+func reconfigureApps(s session, calls []ReconfigureRule) {
+	for _, r := range calls {
+		if err := dbusCall(s, r.Dest, r.Path, r.Method); err != nil {
+			log.Printf("notify: %s reconfigure failed for UID %d: %v", r.Dest, s.UID, err)
+		} else {
+			log.Printf("notify: %s reconfigure sent to UID %d", r.Dest, s.UID)
+		}
+	}
 }
 
 // dbusCall sends a D-Bus method call via dbus-send running as the target user.
@@ -277,19 +478,7 @@ func dbusCall(s session, dest, objectPath, method string) error {
 // environment. The subprocess inherits the target UID/GID so that
 // SO_PEERCRED matches the bus owner and dbus-broker accepts the connection.
 func runAsUser(s session, name string, args ...string) error {
-	cmd := exec.Command(name, args...) //nolint:gosec // G204: all call sites pass hardcoded binary names ("dbus-send", etc.)
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Credential: &syscall.Credential{
-			Uid: s.UID,
-			Gid: s.GID,
-		},
-	}
-	cmd.Env = []string{
-		fmt.Sprintf("DBUS_SESSION_BUS_ADDRESS=unix:path=/run/user/%d/bus", s.UID),
-		fmt.Sprintf("XDG_RUNTIME_DIR=/run/user/%d", s.UID),
-		"HOME=/home/" + s.User,
-	}
-	out, err := cmd.CombinedOutput()
+	out, err := userCommand(s, name, args...).CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(out)))
 	}
@@ -298,32 +487,104 @@ func runAsUser(s session, name string, args ...string) error {
 
 // runAsUserOutput is like runAsUser but returns the command's stdout.
 func runAsUserOutput(s session, name string, args ...string) (string, error) {
-	cmd := exec.Command(name, args...) //nolint:gosec // G204: all call sites pass hardcoded binary names ("notify-send", etc.)
+	out, err := userCommand(s, name, args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// sessionLocked reports whether the screen of session s is locked, as
+// published by the desktop in logind's LockedHint. When the state cannot
+// be read the session is assumed to be unlocked.
+func sessionLocked(s session) bool {
+	if s.ID == "" {
+		return false
+	}
+	out, err := runAsUserOutput(s, "dbus-send",
+		"--system",
+		"--print-reply",
+		"--dest=org.freedesktop.login1",
+		login1SessionPath(s.ID),
+		"org.freedesktop.DBus.Properties.Get",
+		"string:org.freedesktop.login1.Session",
+		"string:LockedHint",
+	)
+	if err != nil {
+		log.Printf("notify: failed to read lock state of session %s: %v", s.ID, err)
+		return false
+	}
+	return parseLockedHint(out)
+}
+
+// parseLockedHint parses the dbus-send --print-reply output of a
+// LockedHint property read, e.g. "variant       boolean true".
+func parseLockedHint(out string) bool {
+	fields := strings.Fields(out)
+	for i := 0; i+1 < len(fields); i++ {
+		if fields[i] == "boolean" {
+			return fields[i+1] == "true"
+		}
+	}
+	return false
+}
+
+// login1SessionPath returns the logind object path of a session, escaping
+// the ID like sd_bus_path_encode: every byte other than a letter, or a
+// digit after the first byte, becomes "_" and two hex digits.
+func login1SessionPath(id string) string {
+	var b strings.Builder
+	b.WriteString("/org/freedesktop/login1/session/")
+	if id == "" {
+		b.WriteByte('_')
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		isAlpha := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		isDigit := c >= '0' && c <= '9'
+		if isAlpha || (isDigit && i > 0) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "_%02x", c)
+		}
+	}
+	return b.String()
+}
+
+// userCommand prepares a command running as the user of session s, with
+// the session's bus and runtime directory.
+func userCommand(s session, name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...) //nolint:gosec // G204: all call sites pass hardcoded binary names ("dbus-send", "notify-send")
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Credential: &syscall.Credential{
 			Uid: s.UID,
 			Gid: s.GID,
 		},
 	}
-	cmd.Env = []string{
+	cmd.Env = sessionEnv(s)
+	return cmd
+}
+
+// sessionEnv returns the environment for commands run in session s.
+func sessionEnv(s session) []string {
+	env := []string{
 		fmt.Sprintf("DBUS_SESSION_BUS_ADDRESS=unix:path=/run/user/%d/bus", s.UID),
 		fmt.Sprintf("XDG_RUNTIME_DIR=/run/user/%d", s.UID),
 		"HOME=/home/" + s.User,
 	}
-	out, err := cmd.Output()
-	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return "", fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(exitErr.Stderr)))
-		}
-		return "", fmt.Errorf("%s: %w", name, err)
+	if s.Display != "" {
+		env = append(env, "DISPLAY="+s.Display)
 	}
-	return strings.TrimSpace(string(out)), nil
+	return env
 }
 
 // activeGraphicalSessions enumerates active X11/Wayland login sessions
 // by reading systemd session files from /run/systemd/sessions/.
 func activeGraphicalSessions() ([]session, error) {
-	sessDir := "/run/systemd/sessions"
+	sessDir := sessionDir
 	entries, err := os.ReadDir(sessDir)
 	if err != nil {
 		return nil, fmt.Errorf("read %s: %w", sessDir, err)
@@ -370,15 +631,55 @@ func activeGraphicalSessions() ([]session, error) {
 		}
 
 		sessions = append(sessions, session{
-			UID:  uint32(uid),
-			GID:  gid,
-			User: props["USER"],
+			ID:      entry.Name(),
+			UID:     uint32(uid),
+			GID:     gid,
+			User:    props["USER"],
+			Seat:    props["SEAT"],
+			Display: props["DISPLAY"],
 		})
 	}
 
 	return sessions, nil
 }
 
+// sessionDir is where systemd-logind publishes login sessions.
+const sessionDir = "/run/systemd/sessions"
+
+// SessionUsers returns the names of the users with an open login session,
+// graphical or not, sorted and without duplicates. The server delivers
+// policies bound to their user groups.
+func SessionUsers() ([]string, error) {
+	return sessionUsersIn(sessionDir)
+}
+
+// sessionUsersIn reads the user sessions in the systemd session directory
+// dir. Sessions that are closing are skipped.
+func sessionUsersIn(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", dir, err)
+	}
+
+	var users []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		props := parseSessionFile(string(data))
+		if props["CLASS"] != "user" || props["STATE"] == "closing" || props["USER"] == "" {
+			continue
+		}
+		users = append(users, props["USER"])
+	}
+	slices.Sort(users)
+	return slices.Compact(users), nil
+}
+
 // parseSessionFile parses a systemd session file (KEY=VALUE per line).
 func parseSessionFile(content string) map[string]string {
 	props := make(map[string]string)
@@ -5,6 +5,11 @@
 package notify
 
 import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -61,20 +66,20 @@ func TestNotifierCooldown(t *testing.T) {
 	cooldown := 5 * time.Minute
 
 	// First notification should be allowed.
-	if !n.shouldNotify(1000, now, cooldown) {
+	if !n.shouldNotify(sessionKey{UID: 1000}, now, cooldown) {
 		t.Error("first notification should be allowed")
 	}
 
 	// Record it.
-	n.recordNotification(1000, now)
+	n.recordNotification(sessionKey{UID: 1000}, now)
 
 	// Immediate retry should be blocked by cooldown.
-	if n.shouldNotify(1000, now.Add(1*time.Second), cooldown) {
+	if n.shouldNotify(sessionKey{UID: 1000}, now.Add(1*time.Second), cooldown) {
 		t.Error("notification within cooldown should be blocked")
 	}
 
 	// After cooldown expires, should be allowed again.
-	if !n.shouldNotify(1000, now.Add(6*time.Minute), cooldown) {
+	if !n.shouldNotify(sessionKey{UID: 1000}, now.Add(6*time.Minute), cooldown) {
 		t.Error("notification after cooldown should be allowed")
 	}
 }
@@ -84,14 +89,46 @@ func TestNotifierCooldownDifferentUIDs(t *testing.T) {
 	now := time.Now()
 	cooldown := 5 * time.Minute
 
-	n.recordNotification(1000, now)
+	n.recordNotification(sessionKey{UID: 1000}, now)
 
 	// Different UID should not be affected by the first UID's cooldown.
-	if !n.shouldNotify(1001, now, cooldown) {
+	if !n.shouldNotify(sessionKey{UID: 1001}, now, cooldown) {
 		t.Error("different UID should not be affected by cooldown")
 	}
 }
 
+func TestTargetSessions(t *testing.T) {
+	sessions := []session{
+		{UID: 1000, User: "alice", Seat: "seat0", Display: ":0"},
+		{UID: 1000, User: "alice", Seat: "seat1", Display: ":1"},
+		{UID: 1001, User: "bob", Seat: "seat2", Display: ":2"},
+	}
+
+	targets, keys := targetSessions(sessions, false)
+	if len(targets) != 2 || targets[0].Seat != "seat0" || targets[1].UID != 1001 {
+		t.Errorf("per user: targets = %+v, want alice on seat0 and bob", targets)
+	}
+	if keys[0] != (sessionKey{UID: 1000}) {
+		t.Errorf("per user: key = %+v, want the UID alone", keys[0])
+	}
+
+	targets, keys = targetSessions(sessions, true)
+	if len(targets) != 3 {
+		t.Fatalf("per session: %d targets, want 3", len(targets))
+	}
+	if keys[1] != (sessionKey{UID: 1000, Seat: "seat1", Display: ":1"}) {
+		t.Errorf("per session: key = %+v, want alice on seat1", keys[1])
+	}
+
+	// Each session has its own cooldown.
+	n := New()
+	now := time.Now()
+	n.recordNotification(keys[0], now)
+	if !n.shouldNotify(keys[1], now, time.Minute) {
+		t.Error("second session of the same user should not be affected by cooldown")
+	}
+}
+
 func TestActiveGraphicalSessionsParsing(t *testing.T) {
 	// Test the session file parsing logic with various session types.
 	tests := []struct {
@@ -136,3 +173,106 @@ func TestActiveGraphicalSessionsParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionUsersIn(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"1":  "TYPE=wayland\nCLASS=user\nSTATE=active\nUSER=bob",
+		"2":  "TYPE=tty\nCLASS=user\nSTATE=online\nUSER=alice",
+		"3":  "TYPE=x11\nCLASS=greeter\nSTATE=online\nUSER=sddm",
+		"4":  "TYPE=tty\nCLASS=user\nSTATE=closing\nUSER=carol",
+		"5":  "TYPE=x11\nCLASS=user\nSTATE=online\nUSER=bob",
+		".#": "CLASS=user\nUSER=tmp",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := sessionUsersIn(dir)
+	if err != nil {
+		t.Fatalf("sessionUsersIn() error = %v", err)
+	}
+	want := []string{"alice", "bob"}
+	if !slices.Equal(got, want) {
+		t.Errorf("sessionUsersIn() = %v, want %v", got, want)
+	}
+}
+
+func TestMatchingRules(t *testing.T) {
+	calls := matchingRules(DefaultReconfigureRules, map[string]bool{
+		"kwinrc":     true,
+		"plasmarc":   true,
+		"kdeglobals": true,
+		"dolphinrc":  true,
+	})
+	var dests []string
+	for _, c := range calls {
+		dests = append(dests, c.Dest)
+	}
+	// Plasma shell is matched by two files but reloaded once.
+	if want := []string{"org.kde.KWin", "org.kde.plasmashell"}; !slices.Equal(dests, want) {
+		t.Errorf("matchingRules() = %v, want %v", dests, want)
+	}
+
+	rules := []ReconfigureRule{{FilePattern: "*.json", Dest: "org.example.App", Path: "/App", Method: "org.example.App.Reload"}}
+	if got := matchingRules(rules, map[string]bool{"policies.json": true}); len(got) != 1 {
+		t.Errorf("matchingRules(*.json) = %v, want one call", got)
+	}
+	if got := matchingRules(rules, map[string]bool{"kwinrc": true}); len(got) != 0 {
+		t.Errorf("matchingRules(*.json) for kwinrc = %v, want none", got)
+	}
+	if got := matchingRules(nil, map[string]bool{"kwinrc": true}); len(got) != 0 {
+		t.Errorf("matchingRules(nil) = %v, want none", got)
+	}
+}
+
+func TestLogin1SessionPath(t *testing.T) {
+	for _, tc := range []struct{ id, want string }{
+		{"c1", "/org/freedesktop/login1/session/c1"},
+		{"2", "/org/freedesktop/login1/session/_32"},
+		{"12", "/org/freedesktop/login1/session/_312"},
+		{"a-b", "/org/freedesktop/login1/session/a_2db"},
+	} {
+		if got := login1SessionPath(tc.id); got != tc.want {
+			t.Errorf("login1SessionPath(%q) = %q, want %q", tc.id, got, tc.want)
+		}
+	}
+}
+
+func TestParseLockedHint(t *testing.T) {
+	locked := "method return time=1700000000.000000 sender=:1.3 -> destination=:1.99 serial=42 reply_serial=2\n   variant       boolean true\n"
+	if !parseLockedHint(locked) {
+		t.Error("expected locked session")
+	}
+	if parseLockedHint(strings.Replace(locked, "true", "false", 1)) {
+		t.Error("expected unlocked session")
+	}
+	if parseLockedHint("") {
+		t.Error("expected empty output to read as unlocked")
+	}
+}
+
+func TestReadNotifyOutput(t *testing.T) {
+	for _, tc := range []struct {
+		output      string
+		wantID      string
+		wantActions []string
+		wantPrinted bool
+	}{
+		{"42\nlogout\n", "42", []string{"logout"}, true},
+		{"42\n", "42", nil, true},
+		{"", "", nil, false},
+	} {
+		var id string
+		var actions []string
+		printed := readNotifyOutput(bufio.NewScanner(strings.NewReader(tc.output)),
+			func(s string) { id = s },
+			func(a string) { actions = append(actions, a) })
+		if printed != tc.wantPrinted || id != tc.wantID || !slices.Equal(actions, tc.wantActions) {
+			t.Errorf("readNotifyOutput(%q) = %v, id %q, actions %v; want %v, %q, %v",
+				tc.output, printed, id, actions, tc.wantPrinted, tc.wantID, tc.wantActions)
+		}
+	}
+}
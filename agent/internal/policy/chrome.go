@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -22,11 +23,85 @@ const ChromeManagedFilename = "bor_managed.json"
 
 // SyncChromeFromProto merges multiple ChromePolicy protos and syncs the result
 // to each Chrome managed-policy directory. It uses protojson to convert each
-// proto to Chrome-compatible JSON (respecting json_name options), deep-merges
-// them, then writes bor_managed.json to each directory.
-// When policies is empty or all nil, bor_managed.json is removed from every dir.
+// proto to Chrome-compatible JSON (respecting json_name options), merges
+// them (see mergeChromePolicy), then writes bor_managed.json to each directory. Policies listed in
+// BorRecommendedPolicies are written to bor_managed.json in the sibling
+// recommended directory instead (see ChromeRecommendedDir).
+// When a tier has no policies, its bor_managed.json is removed.
 func SyncChromeFromProto(policies []*pb.ChromePolicy, dirPaths []string) error {
-	merged := make(map[string]interface{})
+	managed, recommended, err := chromeTiers(policies)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirPaths {
+		if dir == "" {
+			continue
+		}
+		if err := syncChromeFile(dir, managed); err != nil {
+			return err
+		}
+		if recDir := ChromeRecommendedDir(dir); recDir != "" {
+			if err := syncChromeFile(recDir, recommended); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// syncChromeFile writes data as bor_managed.json in dir, or removes the
+// file when data is nil.
+func syncChromeFile(dir string, data []byte) error {
+	if data == nil {
+		_ = removeChromeManaged(dir)
+		return nil
+	}
+	return writeChromeManaged(dir, data)
+}
+
+// ChromeRecommendedDir returns the recommended-policy directory that pairs
+// with a Chrome managed-policy directory ("…/policies/managed" →
+// "…/policies/recommended"), or "" when dir is not named "managed".
+func ChromeRecommendedDir(dir string) string {
+	if filepath.Base(dir) != "managed" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(dir), "recommended")
+}
+
+// ChromePolicyFiles returns the Bor-managed files for a Chrome
+// managed-policy directory: its bor_managed.json and, when there is a
+// recommended directory, the recommended tier's bor_managed.json.
+func ChromePolicyFiles(dir string) []string {
+	files := []string{filepath.Join(dir, ChromeManagedFilename)}
+	if recDir := ChromeRecommendedDir(dir); recDir != "" {
+		files = append(files, filepath.Join(recDir, ChromeManagedFilename))
+	}
+	return files
+}
+
+// ChromePoliciesContent deep-merges the given policies and returns the
+// bor_managed.json content of the managed (mandatory) tier, or nil when the
+// merge yields no policy keys.
+func ChromePoliciesContent(policies []*pb.ChromePolicy) ([]byte, error) {
+	managed, _, err := chromeTiers(policies)
+	return managed, err
+}
+
+// ChromeRecommendedContent is ChromePoliciesContent for the recommended
+// tier.
+func ChromeRecommendedContent(policies []*pb.ChromePolicy) ([]byte, error) {
+	_, recommended, err := chromeTiers(policies)
+	return recommended, err
+}
+
+// chromeTiers splits each policy into its managed and recommended keys,
+// merges each tier across policies and returns the bor_managed.json
+// content for both. A tier without policy keys yields nil.
+func chromeTiers(policies []*pb.ChromePolicy) (managed, recommended []byte, err error) {
+	mergedManaged := make(map[string]interface{})
+	mergedRecommended := make(map[string]interface{})
 
 	opts := protojson.MarshalOptions{EmitUnpopulated: false}
 	for _, pol := range policies {
@@ -35,41 +110,123 @@ func SyncChromeFromProto(policies []*pb.ChromePolicy, dirPaths []string) error {
 		}
 		jsonBytes, err := opts.Marshal(pol)
 		if err != nil {
-			return fmt.Errorf("failed to marshal Chrome policy proto: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal Chrome policy proto: %w", err)
 		}
 		var partial map[string]interface{}
 		if err := json.Unmarshal(jsonBytes, &partial); err != nil {
-			return fmt.Errorf("failed to parse marshalled Chrome policy: %w", err)
+			return nil, nil, fmt.Errorf("failed to parse marshalled Chrome policy: %w", err)
 		}
-		deepMerge(merged, partial)
-	}
+		delete(partial, chromeRecommendedKey)
 
-	if len(merged) == 0 {
-		// No policies — remove managed file from all dirs.
-		for _, dir := range dirPaths {
-			if dir == "" {
-				continue
+		rec := make(map[string]interface{})
+		for _, name := range pol.GetBorRecommendedPolicies() {
+			if v, ok := partial[name]; ok {
+				rec[name] = v
+				delete(partial, name)
 			}
-			_ = removeChromeManaged(dir)
 		}
-		return nil
+		mergeChromePolicy(mergedManaged, partial)
+		mergeChromePolicy(mergedRecommended, rec)
 	}
 
-	data, err := json.MarshalIndent(merged, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal merged Chrome policies: %w", err)
+	if managed, err = chromeFileContent(mergedManaged); err != nil {
+		return nil, nil, err
 	}
-	data = append(data, '\n')
+	if recommended, err = chromeFileContent(mergedRecommended); err != nil {
+		return nil, nil, err
+	}
+	return managed, recommended, nil
+}
 
-	for _, dir := range dirPaths {
-		if dir == "" {
-			continue
+// mergeChromePolicy merges the keys of one Chrome policy into dst, which
+// holds the merged keys of the policies before it in merge order. Keys are
+// deep-merged like Firefox policies, with two rules that keep the result
+// free of duplicates when several policies manage extensions:
+//
+//   - ExtensionSettings is a union by extension ID; when two policies
+//     configure the same extension, the later policy's entry replaces the
+//     earlier one as a whole rather than mixing their settings.
+//   - List policies drop duplicate entries. ExtensionInstallForcelist
+//     entries ("<id>;<update URL>") are matched by extension ID, and the
+//     later policy's entry wins.
+func mergeChromePolicy(dst, src map[string]interface{}) {
+	if settings, ok := src[chromeExtensionSettingsKey].(map[string]interface{}); ok {
+		if merged, ok := dst[chromeExtensionSettingsKey].(map[string]interface{}); ok {
+			for id, v := range settings {
+				merged[id] = v
+			}
+			rest := make(map[string]interface{}, len(src)-1)
+			for k, v := range src {
+				if k != chromeExtensionSettingsKey {
+					rest[k] = v
+				}
+			}
+			src = rest
 		}
-		if err := writeChromeManaged(dir, data); err != nil {
-			return err
+	}
+	deepMerge(dst, src)
+	for key := range src {
+		if list, ok := dst[key].([]interface{}); ok {
+			dst[key] = dedupChromeList(list, key == chromeForcelistKey)
 		}
 	}
-	return nil
+}
+
+// dedupChromeList removes duplicate entries from a merged list policy,
+// keeping the position of the first occurrence. With byExtensionID,
+// entries are forcelist entries matched by the extension ID before ";",
+// and the last entry for an ID replaces the earlier ones.
+func dedupChromeList(list []interface{}, byExtensionID bool) []interface{} {
+	out := make([]interface{}, 0, len(list))
+	seen := make(map[string]int, len(list))
+	for _, v := range list {
+		key := chromeListKey(v)
+		if s, ok := v.(string); ok && byExtensionID {
+			key, _, _ = strings.Cut(s, ";")
+		}
+		if i, dup := seen[key]; dup {
+			out[i] = v
+			continue
+		}
+		seen[key] = len(out)
+		out = append(out, v)
+	}
+	return out
+}
+
+// chromeListKey identifies a list entry by its JSON encoding, so that equal
+// objects (e.g. ManagedBookmarks entries) compare equal as well as strings.
+func chromeListKey(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+const (
+	chromeExtensionSettingsKey = "ExtensionSettings"
+	chromeForcelistKey         = "ExtensionInstallForcelist"
+)
+
+// chromeRecommendedKey is the JSON name of the Bor-only field listing
+// recommended policies; it is never written to Chrome policy files.
+const chromeRecommendedKey = "BorRecommendedPolicies"
+
+// chromeFileContent renders merged policy keys as bor_managed.json
+// content, or nil when there are none.
+func chromeFileContent(merged map[string]interface{}) ([]byte, error) {
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	data, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merged Chrome policies: %w", err)
+	}
+	return append(data, '\n'), nil
 }
 
 // writeChromeManaged creates dir (mode 0755) if needed and atomically writes
@@ -79,6 +236,10 @@ func writeChromeManaged(dirPath string, data []byte) error {
 		return fmt.Errorf("failed to create Chrome policy directory %s: %w", dirPath, err)
 	}
 	target := filepath.Join(dirPath, ChromeManagedFilename)
+	data, err := transformContent("Chrome", target, data)
+	if err != nil {
+		return err
+	}
 	if err := WriteFileAtomically(target, data); err != nil {
 		return fmt.Errorf("failed to write Chrome policies to %s: %w", target, err)
 	}
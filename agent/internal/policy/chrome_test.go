@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
@@ -109,6 +110,73 @@ func TestSyncChromeFromProto_MultiplePolicies(t *testing.T) {
 	}
 }
 
+func TestChromePoliciesContent_DedupsExtensions(t *testing.T) {
+	const (
+		extA = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		extB = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	)
+	settings := func(m map[string]interface{}) *structpb.Value {
+		v, err := structpb.NewValue(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	low := &pb.ChromePolicy{
+		ExtensionInstallForcelist: []string{extA + ";https://old.example.com/update.xml", extB},
+		ExtensionInstallBlocklist: []string{"*"},
+		URLBlocklist:              []string{"example.org", "example.net"},
+		ExtensionSettings: settings(map[string]interface{}{
+			extA: map[string]interface{}{"installation_mode": "allowed", "blocked_permissions": []interface{}{"usb"}},
+			extB: map[string]interface{}{"installation_mode": "blocked"},
+		}),
+	}
+	high := &pb.ChromePolicy{
+		ExtensionInstallForcelist: []string{extB, extA + ";https://new.example.com/update.xml"},
+		ExtensionInstallBlocklist: []string{"*"},
+		URLBlocklist:              []string{"example.net", "example.com"},
+		ExtensionSettings: settings(map[string]interface{}{
+			extA: map[string]interface{}{"installation_mode": "force_installed"},
+		}),
+	}
+
+	data, err := ChromePoliciesContent([]*pb.ChromePolicy{low, high})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		ExtensionInstallForcelist []string
+		ExtensionInstallBlocklist []string
+		URLBlocklist              []string
+		ExtensionSettings         map[string]map[string]interface{}
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	wantForcelist := []string{extA + ";https://new.example.com/update.xml", extB}
+	if !slices.Equal(result.ExtensionInstallForcelist, wantForcelist) {
+		t.Errorf("ExtensionInstallForcelist = %v, want %v", result.ExtensionInstallForcelist, wantForcelist)
+	}
+	if !slices.Equal(result.ExtensionInstallBlocklist, []string{"*"}) {
+		t.Errorf("ExtensionInstallBlocklist = %v, want [*]", result.ExtensionInstallBlocklist)
+	}
+	wantURLs := []string{"example.org", "example.net", "example.com"}
+	if !slices.Equal(result.URLBlocklist, wantURLs) {
+		t.Errorf("URLBlocklist = %v, want %v", result.URLBlocklist, wantURLs)
+	}
+
+	// The later policy's entry for extA replaces the earlier one entirely;
+	// extB, configured only by the earlier policy, is kept.
+	a := result.ExtensionSettings[extA]
+	if a["installation_mode"] != "force_installed" || a["blocked_permissions"] != nil {
+		t.Errorf("ExtensionSettings[%s] = %v, want only the later policy's entry", extA, a)
+	}
+	if result.ExtensionSettings[extB]["installation_mode"] != "blocked" {
+		t.Errorf("ExtensionSettings[%s] = %v, want it kept", extB, result.ExtensionSettings[extB])
+	}
+}
+
 func TestSyncChromeFromProto_EmptyInput(t *testing.T) {
 	dir := t.TempDir()
 	managedDir := filepath.Join(dir, "policies", "managed")
@@ -277,6 +345,35 @@ func TestSyncChromeFromProto_MultipleDirs(t *testing.T) {
 	}
 }
 
+func TestSyncChromeFromProto_SameContentInAllDirs(t *testing.T) {
+	dir := t.TempDir()
+	dirs := []string{
+		filepath.Join(dir, "opt", "chrome", "policies", "managed"),
+		filepath.Join(dir, "chromium", "policies", "managed"),
+		filepath.Join(dir, "opt", "edge", "policies", "managed"),
+	}
+
+	homepageLoc := "https://example.com"
+	pol := &pb.ChromePolicy{HomepageLocation: &homepageLoc}
+	if err := SyncChromeFromProto([]*pb.ChromePolicy{pol}, dirs); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := ChromePoliciesContent([]*pb.ChromePolicy{pol})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range dirs {
+		got, err := os.ReadFile(filepath.Join(d, ChromeManagedFilename))
+		if err != nil {
+			t.Fatalf("expected bor_managed.json in %s: %v", d, err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("dir %s: content = %s, want %s", d, got, want)
+		}
+	}
+}
+
 func TestSyncChromeFromProto_NilPoliciesAreSkipped(t *testing.T) {
 	dir := t.TempDir()
 	managedDir := filepath.Join(dir, "policies", "managed")
@@ -303,3 +400,96 @@ func TestSyncChromeFromProto_NilPoliciesAreSkipped(t *testing.T) {
 		t.Errorf("expected HomepageLocation, got %v", result["HomepageLocation"])
 	}
 }
+
+func TestChromePoliciesContent_MatchesWrittenFile(t *testing.T) {
+	dir := t.TempDir()
+
+	homepageLoc := "https://example.com"
+	policies := []*pb.ChromePolicy{{HomepageLocation: &homepageLoc}}
+
+	content, err := ChromePoliciesContent(policies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if syncErr := SyncChromeFromProto(policies, []string{dir}); syncErr != nil {
+		t.Fatal(syncErr)
+	}
+	written, err := os.ReadFile(filepath.Join(dir, ChromeManagedFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != string(written) {
+		t.Errorf("content = %q, written file = %q", content, written)
+	}
+
+	empty, err := ChromePoliciesContent([]*pb.ChromePolicy{nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty != nil {
+		t.Errorf("expected nil content for no policies, got %q", empty)
+	}
+}
+
+func TestSyncChromeFromProto_RecommendedTier(t *testing.T) {
+	dir := t.TempDir()
+	managedDir := filepath.Join(dir, "policies", "managed")
+	recommendedDir := filepath.Join(dir, "policies", "recommended")
+
+	homepageLoc := "https://intranet.example.com"
+	bookmarkBar := true
+	pol := &pb.ChromePolicy{
+		HomepageLocation:       &homepageLoc,
+		BookmarkBarEnabled:     &bookmarkBar,
+		BorRecommendedPolicies: []string{"HomepageLocation"},
+	}
+	if err := SyncChromeFromProto([]*pb.ChromePolicy{pol}, []string{managedDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(d string) map[string]interface{} {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(d, ChromeManagedFilename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	managed := read(managedDir)
+	if _, ok := managed["HomepageLocation"]; ok {
+		t.Error("recommended HomepageLocation must not be in the managed file")
+	}
+	if _, ok := managed["BorRecommendedPolicies"]; ok {
+		t.Error("BorRecommendedPolicies must not be written to Chrome policy files")
+	}
+	if managed["BookmarkBarEnabled"] != true {
+		t.Errorf("managed BookmarkBarEnabled = %v, want true", managed["BookmarkBarEnabled"])
+	}
+	recommended := read(recommendedDir)
+	if recommended["HomepageLocation"] != homepageLoc || len(recommended) != 1 {
+		t.Errorf("recommended = %v, want only HomepageLocation", recommended)
+	}
+
+	// Dropping the recommended tier removes its file.
+	pol.BorRecommendedPolicies = nil
+	if err := SyncChromeFromProto([]*pb.ChromePolicy{pol}, []string{managedDir}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(recommendedDir, ChromeManagedFilename)); !os.IsNotExist(err) {
+		t.Errorf("expected recommended file removed, stat err = %v", err)
+	}
+}
+
+func TestChromeRecommendedDir(t *testing.T) {
+	if got := ChromeRecommendedDir("/etc/opt/chrome/policies/managed"); got != "/etc/opt/chrome/policies/recommended" {
+		t.Errorf("got %q", got)
+	}
+	if got := ChromeRecommendedDir("/etc/custom"); got != "" {
+		t.Errorf("got %q, want empty", got)
+	}
+}
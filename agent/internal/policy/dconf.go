@@ -137,6 +137,14 @@ func SyncDConfFiles(dbName string, keyfile, locksfile []byte) error {
 	keyfilePath := filepath.Join(dbDir, "00-bor")
 	locksPath := filepath.Join(locksDir, "bor")
 
+	keyfile, err := transformContent("Dconf", keyfilePath, keyfile)
+	if err != nil {
+		return err
+	}
+	if locksfile, err = transformContent("Dconf", locksPath, locksfile); err != nil {
+		return err
+	}
+
 	if err := BackupOriginal(keyfilePath); err != nil {
 		return fmt.Errorf("dconf: backup keyfile: %w", err)
 	}
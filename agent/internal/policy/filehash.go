@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"slices"
+	"strings"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+// ContentHash returns the hex SHA-256 of a managed file's policy content.
+// The Bor-managed header is stripped first: it carries no policy and, for
+// polkit rules, includes a generation timestamp that differs on every write.
+func ContentHash(data []byte) string {
+	sum := sha256.Sum256(stripManagedHeader(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// HashAppliedFiles reads each path and returns its content hash. Paths that
+// cannot be read, typically because the sync removed the file, are skipped.
+func HashAppliedFiles(paths ...string) []*pb.AppliedFile {
+	files := make([]*pb.AppliedFile, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path) //nolint:gosec // G304: paths are the agent's own managed files
+		if err != nil {
+			continue
+		}
+		files = append(files, &pb.AppliedFile{Path: path, Sha256: ContentHash(data)})
+	}
+	return files
+}
+
+// DriftedFiles compares each path's policy content with the desired content
+// of policyType, after the content transform hook, and returns the sorted
+// paths that differ or cannot be read. It is used in inventory-only mode,
+// where targets are checked but never written.
+func DriftedFiles(policyType string, want map[string][]byte) []string {
+	var drifted []string
+	for path, data := range want {
+		data, err := transformContent(policyType, path, data)
+		if err != nil {
+			drifted = append(drifted, path)
+			continue
+		}
+		have, err := os.ReadFile(path) //nolint:gosec // G304: paths are the agent's own managed files
+		if err != nil || ContentHash(have) != ContentHash(data) {
+			drifted = append(drifted, path)
+		}
+	}
+	slices.Sort(drifted)
+	return drifted
+}
+
+func stripManagedHeader(data []byte) []byte {
+	if bytes.HasPrefix(data, []byte(ManagedFileHeader)) {
+		return data[len(ManagedFileHeader):]
+	}
+	if bytes.HasPrefix(data, polkitManagedPrefix) {
+		rest := data
+		for i := 0; i < strings.Count(polkitManagedHeader, "\n"); i++ {
+			nl := bytes.IndexByte(rest, '\n')
+			if nl < 0 {
+				return nil
+			}
+			rest = rest[nl+1:]
+		}
+		return rest
+	}
+	return data
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestContentHash_ExcludesHeader(t *testing.T) {
+	body := "[General]\nfoo=bar\n"
+	if ContentHash([]byte(ManagedFileHeader+body)) != ContentHash([]byte(body)) {
+		t.Error("KConfig managed header should not affect the content hash")
+	}
+
+	rules := "polkit.addRule(function(action, subject) {});\n"
+	a := fmt.Sprintf(polkitManagedHeader, "2026-01-01T00:00:00Z") + rules
+	b := fmt.Sprintf(polkitManagedHeader, "2026-06-01T12:00:00Z") + rules
+	if ContentHash([]byte(a)) != ContentHash([]byte(b)) {
+		t.Error("polkit generation timestamp should not affect the content hash")
+	}
+
+	if ContentHash([]byte(body)) == ContentHash([]byte("[General]\nfoo=baz\n")) {
+		t.Error("different content should hash differently")
+	}
+}
+
+func TestHashAppliedFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kdeglobals")
+	if err := os.WriteFile(path, []byte(ManagedFileHeader+"[General]\nfoo=bar\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	files := HashAppliedFiles(path, filepath.Join(dir, "missing"))
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+	if files[0].GetPath() != path {
+		t.Errorf("Path = %q, want %q", files[0].GetPath(), path)
+	}
+	if files[0].GetSha256() != ContentHash([]byte("[General]\nfoo=bar\n")) {
+		t.Errorf("Sha256 = %q, want hash of content without header", files[0].GetSha256())
+	}
+}
+
+func TestDriftedFiles(t *testing.T) {
+	dir := t.TempDir()
+	same := filepath.Join(dir, "kdeglobals")
+	changed := filepath.Join(dir, "kwinrc")
+	if err := os.WriteFile(same, []byte(ManagedFileHeader+"[General]\nfoo=bar\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(changed, []byte("[General]\nfoo=local\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	got := DriftedFiles("Kconfig", map[string][]byte{
+		same:    []byte("[General]\nfoo=bar\n"),
+		changed: []byte("[General]\nfoo=bar\n"),
+		missing: []byte("{}\n"),
+	})
+	want := []string{changed, missing}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("DriftedFiles = %v, want %v", got, want)
+	}
+}
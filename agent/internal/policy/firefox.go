@@ -13,6 +13,7 @@ import (
 	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // FirefoxManagedComment is the comment written into policies.json when the
@@ -51,15 +52,112 @@ func deepMerge(dst, src map[string]interface{}) {
 
 // MergeFirefoxProtos merges multiple FirefoxPolicy proto messages into one.
 // Uses proto.Merge semantics: singular optional fields from later policies
-// overwrite earlier ones; repeated fields are appended.
+// overwrite earlier ones; repeated fields are appended, then deduplicated
+// (see MergeFirefoxProtosWithReport).
 func MergeFirefoxProtos(policies []*pb.FirefoxPolicy) *pb.FirefoxPolicy {
+	merged, _ := MergeFirefoxProtosWithReport(policies)
+	return merged
+}
+
+// FirefoxMergeConflict records a policy key set by two policies to
+// different values: the value of the policy at index Winner overrode that
+// of the policy at index Loser. Indexes refer to the slice passed to
+// MergeFirefoxProtosWithReport.
+type FirefoxMergeConflict struct {
+	// Key is the policies.json path of the key, e.g. "Homepage.URL".
+	Key    string
+	Winner int
+	Loser  int
+}
+
+// MergeFirefoxProtosWithReport merges policies like MergeFirefoxProtos and
+// also reports every key a later policy overrode. Repeated fields are
+// deduplicated after merging: string lists (e.g. Extensions.Install) by
+// value, and Bookmarks by placement, folder and URL, with the later
+// policy's bookmark replacing an earlier one with the same identity.
+func MergeFirefoxProtosWithReport(policies []*pb.FirefoxPolicy) (*pb.FirefoxPolicy, []FirefoxMergeConflict) {
 	merged := &pb.FirefoxPolicy{}
-	for _, p := range policies {
-		if p != nil {
-			proto.Merge(merged, p)
+	setBy := make(map[string]int)
+	var conflicts []FirefoxMergeConflict
+	for i, p := range policies {
+		if p == nil {
+			continue
 		}
+		firefoxConflicts(merged.ProtoReflect(), p.ProtoReflect(), "", i, setBy, &conflicts)
+		proto.Merge(merged, p)
 	}
-	return merged
+	dedupFirefoxLists(merged.ProtoReflect())
+	merged.Bookmarks = dedupFirefoxBookmarks(merged.Bookmarks)
+	return merged, conflicts
+}
+
+// firefoxConflicts appends to conflicts the singular fields that src, the
+// policy at index idx, sets to a value other than the one already merged
+// into dst. setBy tracks which policy set each key.
+func firefoxConflicts(dst, src protoreflect.Message, prefix string, idx int, setBy map[string]int, conflicts *[]FirefoxMergeConflict) {
+	src.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		key := prefix + fd.JSONName()
+		switch {
+		case fd.IsList() || fd.IsMap():
+			// Appended rather than overwritten.
+		case fd.Message() != nil:
+			firefoxConflicts(dst.Get(fd).Message(), v.Message(), key+".", idx, setBy, conflicts)
+		default:
+			if dst.Has(fd) && !dst.Get(fd).Equal(v) {
+				*conflicts = append(*conflicts, FirefoxMergeConflict{Key: key, Winner: idx, Loser: setBy[key]})
+			}
+			setBy[key] = idx
+		}
+		return true
+	})
+}
+
+// dedupFirefoxLists removes duplicate values from every repeated string
+// field of m and its sub-messages, keeping the first occurrence.
+func dedupFirefoxLists(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Kind() == protoreflect.StringKind:
+			list := v.List()
+			seen := make(map[string]bool, list.Len())
+			n := 0
+			for i := 0; i < list.Len(); i++ {
+				s := list.Get(i).String()
+				if seen[s] {
+					continue
+				}
+				seen[s] = true
+				list.Set(n, list.Get(i))
+				n++
+			}
+			list.Truncate(n)
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			dedupFirefoxLists(v.Message())
+		}
+		return true
+	})
+}
+
+// dedupFirefoxBookmarks removes bookmarks with the same placement, folder
+// and URL as an earlier one. The later bookmark takes the earlier one's
+// position, so a higher-priority policy can retitle a bookmark.
+func dedupFirefoxBookmarks(bookmarks []*pb.FirefoxBookmark) []*pb.FirefoxBookmark {
+	if len(bookmarks) == 0 {
+		return bookmarks
+	}
+	type identity struct{ placement, folder, url string }
+	seen := make(map[identity]int, len(bookmarks))
+	out := make([]*pb.FirefoxBookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		id := identity{b.GetPlacement(), b.GetFolder(), b.GetURL()}
+		if i, dup := seen[id]; dup {
+			out[i] = b
+			continue
+		}
+		seen[id] = len(out)
+		out = append(out, b)
+	}
+	return out
 }
 
 // SyncFirefoxPoliciesFromProto merges the given proto policies and writes
@@ -75,6 +173,9 @@ func SyncFirefoxPoliciesFromProto(targetPath string, policies []*pb.FirefoxPolic
 	if err != nil {
 		return err
 	}
+	if data, err = transformContent("Firefox", targetPath, data); err != nil {
+		return err
+	}
 	return WriteFileAtomically(targetPath, data)
 }
 
@@ -91,9 +192,18 @@ func SyncFirefoxFlatpakPoliciesFromProto(targetPath string, policies []*pb.Firef
 	if err != nil {
 		return err
 	}
+	if data, err = transformContent("Firefox", targetPath, data); err != nil {
+		return err
+	}
 	return WriteFileAtomically(targetPath, data)
 }
 
+// FirefoxPoliciesContent returns the policies.json content the agent would
+// write for the given policies, without touching the filesystem.
+func FirefoxPoliciesContent(policies []*pb.FirefoxPolicy) ([]byte, error) {
+	return marshalFirefoxPolicies(policies)
+}
+
 // marshalFirefoxPolicies merges the given policies and marshals them into
 // the policies.json format Firefox expects: {"_comment": "...", "policies": {...}}.
 func marshalFirefoxPolicies(policies []*pb.FirefoxPolicy) ([]byte, error) {
@@ -127,8 +237,13 @@ func marshalFirefoxPolicies(policies []*pb.FirefoxPolicy) ([]byte, error) {
 
 // WriteFileAtomically writes data to a temporary file and then renames it
 // to the target path for an atomic update. Parent directories are created
-// if they do not exist. The file is written with mode 0644.
+// if they do not exist. The file is written with mode 0644. Failures caused
+// by a read-only filesystem are returned as a *ReadOnlyError.
 func WriteFileAtomically(targetPath string, data []byte) error {
+	return asReadOnly(targetPath, writeFileAtomically(targetPath, data))
+}
+
+func writeFileAtomically(targetPath string, data []byte) error {
 	dir := filepath.Dir(targetPath)
 	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec // G301: policy directories must be world-readable
 		return fmt.Errorf("failed to create directory %s: %w", dir, err)
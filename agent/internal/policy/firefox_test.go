@@ -9,6 +9,8 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
@@ -52,6 +54,63 @@ func TestMergeFirefoxProtos_RepeatedFieldsAppended(t *testing.T) {
 	}
 }
 
+func TestMergeFirefoxProtos_DedupsLists(t *testing.T) {
+	policies := []*pb.FirefoxPolicy{
+		{
+			Extensions: &pb.FirefoxExtensions{Install: []string{"ext1@example.com", "ext2@example.com"}},
+			Bookmarks: []*pb.FirefoxBookmark{
+				{Title: "Intranet", URL: "https://intranet.example.com", Placement: "toolbar"},
+				{Title: "Wiki", URL: "https://wiki.example.com", Placement: "toolbar"},
+			},
+		},
+		{
+			Extensions: &pb.FirefoxExtensions{Install: []string{"ext2@example.com", "ext3@example.com"}},
+			Bookmarks: []*pb.FirefoxBookmark{
+				{Title: "Company intranet", URL: "https://intranet.example.com", Placement: "toolbar"},
+				{Title: "Intranet", URL: "https://intranet.example.com", Placement: "menu"},
+			},
+		},
+	}
+	merged := MergeFirefoxProtos(policies)
+
+	wantInstall := []string{"ext1@example.com", "ext2@example.com", "ext3@example.com"}
+	if got := merged.GetExtensions().GetInstall(); !slices.Equal(got, wantInstall) {
+		t.Errorf("Install = %v, want %v", got, wantInstall)
+	}
+	var titles []string
+	for _, b := range merged.GetBookmarks() {
+		titles = append(titles, b.GetPlacement()+":"+b.GetTitle())
+	}
+	wantTitles := []string{"toolbar:Company intranet", "toolbar:Wiki", "menu:Intranet"}
+	if !slices.Equal(titles, wantTitles) {
+		t.Errorf("bookmarks = %v, want %v", titles, wantTitles)
+	}
+}
+
+func TestMergeFirefoxProtosWithReport_Conflicts(t *testing.T) {
+	url := func(s string) *pb.FirefoxHomepage { return &pb.FirefoxHomepage{URL: s} }
+	policies := []*pb.FirefoxPolicy{
+		{DisableTelemetry: boolPtr(true), Homepage: url("https://a.example.com")},
+		nil,
+		{DisableTelemetry: boolPtr(true), DisablePocket: boolPtr(true)},
+		{Homepage: url("https://b.example.com"), DisablePocket: boolPtr(false)},
+	}
+	merged, conflicts := MergeFirefoxProtosWithReport(policies)
+	if merged.GetHomepage().GetURL() != "https://b.example.com" {
+		t.Errorf("Homepage.URL = %q, want the last policy's", merged.GetHomepage().GetURL())
+	}
+
+	// Setting a key to the value it already has is not a conflict.
+	want := []FirefoxMergeConflict{
+		{Key: "Homepage.URL", Winner: 3, Loser: 0},
+		{Key: "DisablePocket", Winner: 3, Loser: 2},
+	}
+	slices.SortFunc(conflicts, func(a, b FirefoxMergeConflict) int { return strings.Compare(b.Key, a.Key) })
+	if !slices.Equal(conflicts, want) {
+		t.Errorf("conflicts = %+v, want %+v", conflicts, want)
+	}
+}
+
 func TestMergeFirefoxProtos_NilSkipped(t *testing.T) {
 	policies := []*pb.FirefoxPolicy{nil, {DisableTelemetry: boolPtr(true)}, nil}
 	merged := MergeFirefoxProtos(policies)
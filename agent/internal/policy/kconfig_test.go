@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -333,7 +334,7 @@ func TestSyncKConfigFiles_BackupAndHeader(t *testing.T) {
 		"kdeglobals": []byte("[KDE Action Restrictions][$i]\nshell_access=false\n"),
 	}
 
-	if err := SyncKConfigFiles(dir, files); err != nil {
+	if _, err := SyncKConfigFiles(dir, files); err != nil {
 		t.Fatal(err)
 	}
 
@@ -366,12 +367,12 @@ func TestSyncKConfigFiles_CleanupRestoresOriginal(t *testing.T) {
 	files := map[string][]byte{
 		"kdeglobals": []byte("[KDE Action Restrictions][$i]\nshell_access=false\n"),
 	}
-	if err := SyncKConfigFiles(dir, files); err != nil {
+	if _, err := SyncKConfigFiles(dir, files); err != nil {
 		t.Fatal(err)
 	}
 
 	// Second sync: empty map — should restore original.
-	if err := SyncKConfigFiles(dir, nil); err != nil {
+	if _, err := SyncKConfigFiles(dir, nil); err != nil {
 		t.Fatal(err)
 	}
 
@@ -397,7 +398,7 @@ func TestSyncKConfigFiles_CleanupDeletesWhenNoOriginal(t *testing.T) {
 	files := map[string][]byte{
 		"brandnew": []byte("[Section]\nkey=value\n"),
 	}
-	if err := SyncKConfigFiles(dir, files); err != nil {
+	if _, err := SyncKConfigFiles(dir, files); err != nil {
 		t.Fatal(err)
 	}
 
@@ -407,7 +408,7 @@ func TestSyncKConfigFiles_CleanupDeletesWhenNoOriginal(t *testing.T) {
 	}
 
 	// Second sync: empty map — should delete (no original).
-	if err := SyncKConfigFiles(dir, nil); err != nil {
+	if _, err := SyncKConfigFiles(dir, nil); err != nil {
 		t.Fatal(err)
 	}
 
@@ -419,6 +420,63 @@ func TestSyncKConfigFiles_CleanupDeletesWhenNoOriginal(t *testing.T) {
 	}
 }
 
+func TestSyncKConfigFiles_ReportsOnlyChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "kwinrc"), []byte("orig-kwin"))
+
+	files := map[string][]byte{
+		"kdeglobals": []byte("[G1]\nk1=v1\n"),
+		"kwinrc":     []byte("[G2]\nk2=v2\n"),
+	}
+	changed, err := SyncKConfigFiles(dir, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(changed, []string{"kdeglobals", "kwinrc"}) {
+		t.Errorf("first sync changed = %v", changed)
+	}
+
+	info, _ := os.Stat(filepath.Join(dir, "kdeglobals"))
+	changed, err = SyncKConfigFiles(dir, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("identical resync changed = %v, want none", changed)
+	}
+	if again, _ := os.Stat(filepath.Join(dir, "kdeglobals")); !again.ModTime().Equal(info.ModTime()) {
+		t.Error("identical resync rewrote kdeglobals")
+	}
+
+	// A changed file and a restored one are both reported.
+	changed, err = SyncKConfigFiles(dir, map[string][]byte{"kdeglobals": []byte("[G1]\nk1=v2\n")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(changed, []string{"kdeglobals", "kwinrc"}) {
+		t.Errorf("update changed = %v", changed)
+	}
+}
+
+func TestSyncKConfigFiles_RewritesEditedFile(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "kdeglobals")
+	files := map[string][]byte{"kdeglobals": []byte("[G1]\nk1=v1\n")}
+	if _, err := SyncKConfigFiles(dir, files); err != nil {
+		t.Fatal(err)
+	}
+
+	// Same content without the managed header is not considered current.
+	writeFile(t, target, files["kdeglobals"])
+	changed, err := SyncKConfigFiles(dir, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(changed, []string{"kdeglobals"}) {
+		t.Errorf("changed = %v, want kdeglobals rewritten", changed)
+	}
+}
+
 func TestSyncKConfigFiles_PartialCleanup(t *testing.T) {
 	dir := t.TempDir()
 
@@ -431,7 +489,7 @@ func TestSyncKConfigFiles_PartialCleanup(t *testing.T) {
 		"kdeglobals": []byte("[G1]\nk1=v1\n"),
 		"kwinrc":     []byte("[G2]\nk2=v2\n"),
 	}
-	if err := SyncKConfigFiles(dir, files); err != nil {
+	if _, err := SyncKConfigFiles(dir, files); err != nil {
 		t.Fatal(err)
 	}
 
@@ -439,7 +497,7 @@ func TestSyncKConfigFiles_PartialCleanup(t *testing.T) {
 	files2 := map[string][]byte{
 		"kdeglobals": []byte("[G1]\nk1=v1-updated\n"),
 	}
-	if err := SyncKConfigFiles(dir, files2); err != nil {
+	if _, err := SyncKConfigFiles(dir, files2); err != nil {
 		t.Fatal(err)
 	}
 
@@ -537,6 +595,135 @@ func TestMergeKConfigEntries_URLRestrictionsMultiplePolicies(t *testing.T) {
 	}
 }
 
+func TestMergeKConfigEntries_URLRestrictionsPriorityAndDedup(t *testing.T) {
+	entries := []*pb.KConfigEntry{
+		// Policy A, priority 10
+		{File: "kdeglobals", Group: "KDE URL Restrictions", Key: "rule_count", Value: "2", Enforced: true, Priority: 10},
+		{File: "kdeglobals", Group: "KDE URL Restrictions", Key: "rule_1", Value: "open,,,,http,shared.com,,false", Enforced: true, Priority: 10},
+		{File: "kdeglobals", Group: "KDE URL Restrictions", Key: "rule_2", Value: "open,,,,http,a.com,,true", Enforced: true, Priority: 10},
+		// Policy B, priority 50
+		{File: "kdeglobals", Group: "KDE URL Restrictions", Key: "rule_count", Value: "2", Enforced: true, Priority: 50},
+		{File: "kdeglobals", Group: "KDE URL Restrictions", Key: "rule_1", Value: "list,,,,file,b.com,,false", Enforced: true, Priority: 50},
+		{File: "kdeglobals", Group: "KDE URL Restrictions", Key: "rule_2", Value: "open,,,,http,shared.com,,false", Enforced: true, Priority: 50},
+	}
+
+	files, err := MergeKConfigEntries(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := string(files["kdeglobals"])
+	for _, want := range []string{
+		"rule_1=list,,,,file,b.com,,false",
+		"rule_2=open,,,,http,shared.com,,false",
+		"rule_3=open,,,,http,a.com,,true",
+		"rule_count=3",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q, got:\n%s", want, content)
+		}
+	}
+	if n := strings.Count(content, "shared.com"); n != 1 {
+		t.Errorf("shared rule written %d times, want once:\n%s", n, content)
+	}
+}
+
+func TestMergeKConfigEntriesWithConflicts_PriorityWins(t *testing.T) {
+	entries := []*pb.KConfigEntry{
+		{File: "kscreenlockerrc", Group: "Daemon", Key: "Timeout", Value: "5", Type: "int", Priority: 10, PolicyId: "high"},
+		{File: "kscreenlockerrc", Group: "Daemon", Key: "Timeout", Value: "15", Type: "int", Priority: 1, PolicyId: "low"},
+		{File: "kscreenlockerrc", Group: "Daemon", Key: "AutoLock", Value: "true", Type: "bool", Enforced: true, Priority: 1, PolicyId: "low"},
+		{File: "kscreenlockerrc", Group: "Daemon", Key: "AutoLock", Value: "true", Type: "bool", Priority: 10, PolicyId: "high"},
+	}
+
+	files, conflicts, err := MergeKConfigEntriesWithConflicts(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := string(files["kscreenlockerrc"])
+	if strings.Count(content, "Timeout") != 1 || !strings.Contains(content, "Timeout=5") {
+		t.Errorf("expected a single Timeout=5 from the higher-priority policy, got:\n%s", content)
+	}
+	// Same value from both policies: written once, enforced because one
+	// of them enforces it, and not a conflict.
+	if !strings.Contains(content, "AutoLock[$i]=true") {
+		t.Errorf("expected enforced AutoLock, got:\n%s", content)
+	}
+
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %+v, want one", conflicts)
+	}
+	c := conflicts[0]
+	if c.Key != "Timeout" || !slices.Equal(c.Values, []string{"5", "15"}) || !slices.Equal(c.PolicyIDs, []string{"high", "low"}) {
+		t.Errorf("conflict = %+v, want Timeout 5/15 from high/low", c)
+	}
+}
+
+func TestMergeKConfigEntriesWithConflicts_EqualPriorityLastWins(t *testing.T) {
+	entries := []*pb.KConfigEntry{
+		{File: "kdeglobals", Group: "Icons", Key: "Theme", Value: "breeze", PolicyId: "a"},
+		{File: "kdeglobals", Group: "Icons", Key: "Theme", Value: "oxygen", PolicyId: "b"},
+	}
+
+	files, conflicts, err := MergeKConfigEntriesWithConflicts(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content := string(files["kdeglobals"]); !strings.Contains(content, "Theme=oxygen") || strings.Contains(content, "breeze") {
+		t.Errorf("expected the later policy to win, got:\n%s", content)
+	}
+	if len(conflicts) != 1 || conflicts[0].PolicyIDs[0] != "b" {
+		t.Errorf("conflicts = %+v, want b as winner", conflicts)
+	}
+}
+
+func TestMergeKConfigEntries_Delete(t *testing.T) {
+	del := pb.KConfigOperation_KCONFIG_OPERATION_DELETE
+	entries := []*pb.KConfigEntry{
+		{File: "kscreenlockerrc", Group: "Daemon", Key: "Timeout", Operation: del, Enforced: true},
+		{File: "kscreenlockerrc", Group: "Daemon", Key: "AutoLock", Value: "true", Type: "bool"},
+		{File: "kdeglobals", Group: "Icons", Key: "Theme", Operation: del, Enforced: true},
+	}
+
+	files, err := MergeKConfigEntries(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only some keys enforced: key-level flags, combined with $d.
+	if got, want := string(files["kscreenlockerrc"]), "[Daemon]\nAutoLock=true\nTimeout[$di]\n"; got != want {
+		t.Errorf("kscreenlockerrc = %q, want %q", got, want)
+	}
+	// Every key enforced: group-level [$i].
+	if got, want := string(files["kdeglobals"]), "[Icons][$i]\nTheme[$d]\n"; got != want {
+		t.Errorf("kdeglobals = %q, want %q", got, want)
+	}
+}
+
+func TestMergeKConfigEntriesWithConflicts_DeleteVersusSet(t *testing.T) {
+	del := pb.KConfigOperation_KCONFIG_OPERATION_DELETE
+	entries := []*pb.KConfigEntry{
+		{File: "kdeglobals", Group: "Icons", Key: "Theme", Value: "breeze", Priority: 1, PolicyId: "low"},
+		{File: "kdeglobals", Group: "Icons", Key: "Theme", Operation: del, Priority: 10, PolicyId: "high"},
+		{File: "kdeglobals", Group: "Icons", Key: "Theme", Operation: del, Enforced: true, Priority: 10, PolicyId: "other"},
+	}
+
+	files, conflicts, err := MergeKConfigEntriesWithConflicts(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The delete wins and is enforced because another delete is.
+	if got, want := string(files["kdeglobals"]), "[Icons][$i]\nTheme[$d]\n"; got != want {
+		t.Errorf("kdeglobals = %q, want %q", got, want)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %+v, want one", conflicts)
+	}
+	if c := conflicts[0]; !slices.Equal(c.Values, []string{"(deleted)", "(deleted)", "breeze"}) {
+		t.Errorf("conflict values = %q", c.Values)
+	}
+}
+
 func TestMergeKConfigEntries_URLRestrictionsWithOtherGroups(t *testing.T) {
 	entries := []*pb.KConfigEntry{
 		// URL restriction rules
@@ -617,6 +804,65 @@ func TestSplitKCMRestrictions_OnlyKCM(t *testing.T) {
 	}
 }
 
+func TestSplitKConfigByScope(t *testing.T) {
+	overlay := pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_OVERLAY
+	user := pb.KConfigScope_KCONFIG_SCOPE_USER
+	immutable := pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_IMMUTABLE
+
+	entries := []*pb.KConfigEntry{
+		{File: "kde5rc", Group: "KDE Control Module Restrictions", Key: "kcm_access", Value: "false", Enforced: true},
+		{File: "kdeglobals", Group: "Icons", Key: "Theme", Value: "breeze"},
+		{File: "kdeglobals", Group: "General", Key: "ColorScheme", Value: "BreezeDark", Enforced: true, Scope: user},
+		{File: "kscreenlockerrc", Group: "Daemon", Key: "AutoLock", Value: "true", Scope: immutable},
+		{File: "kde5rc", Group: "KDE Control Module Restrictions", Key: "kcm_clock", Value: "false", Enforced: true, Scope: overlay},
+	}
+
+	scoped := SplitKConfigByScope(entries)
+
+	if len(scoped.KCM) != 1 || scoped.KCM[0].Key != "kcm_access" {
+		t.Errorf("KCM = %v, want only kcm_access", scoped.KCM)
+	}
+	if len(scoped.User) != 1 || scoped.User[0].Key != "ColorScheme" || scoped.User[0].Enforced {
+		t.Errorf("User = %v, want unenforced ColorScheme", scoped.User)
+	}
+	keys := make(map[string]bool)
+	for _, e := range scoped.Overlay {
+		keys[e.Key] = e.Enforced
+	}
+	if len(keys) != 3 || keys["Theme"] || !keys["AutoLock"] || !keys["kcm_clock"] {
+		t.Errorf("Overlay enforcement = %v, want Theme=false AutoLock=true kcm_clock=true", keys)
+	}
+	if !entries[2].Enforced || entries[3].Enforced {
+		t.Error("SplitKConfigByScope modified its input")
+	}
+}
+
+func TestKConfigPolicyToEntries_Scopes(t *testing.T) {
+	theme := "breeze"
+	autoLock := true
+	pol := &pb.KConfigPolicy{
+		IconTheme:       &theme,
+		AutoLock:        &autoLock,
+		KcmRestrictions: []string{"kcm_clock"},
+		Scope:           pb.KConfigScope_KCONFIG_SCOPE_USER,
+		FieldScopes: map[string]pb.KConfigScope{
+			"autoLock":        pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_OVERLAY,
+			"kcmRestrictions": pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_IMMUTABLE,
+		},
+	}
+
+	want := map[string]pb.KConfigScope{
+		"Theme":     pb.KConfigScope_KCONFIG_SCOPE_USER,
+		"AutoLock":  pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_OVERLAY,
+		"kcm_clock": pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_IMMUTABLE,
+	}
+	for _, e := range KConfigPolicyToEntries(pol) {
+		if e.Scope != want[e.Key] {
+			t.Errorf("%s: scope = %v, want %v", e.Key, e.Scope, want[e.Key])
+		}
+	}
+}
+
 func TestSyncKCMRestrictions_WriteAndRestore(t *testing.T) {
 	dir := t.TempDir()
 
@@ -631,7 +877,7 @@ func TestSyncKCMRestrictions_WriteAndRestore(t *testing.T) {
 	content := []byte("[KDE Control Module Restrictions][$i]\nkcm_access=false\n")
 
 	// Write KCM restrictions.
-	if err := SyncKCMRestrictions(content); err != nil {
+	if _, err := SyncKCMRestrictions(content); err != nil {
 		t.Fatal(err)
 	}
 
@@ -649,9 +895,17 @@ func TestSyncKCMRestrictions_WriteAndRestore(t *testing.T) {
 		}
 	}
 
+	// Same content again: nothing to write.
+	if changed, err := SyncKCMRestrictions(content); err != nil || changed {
+		t.Errorf("identical resync = %v, %v; want no change", changed, err)
+	}
+
 	// Restore (nil content).
-	if err := SyncKCMRestrictions(nil); err != nil {
-		t.Fatal(err)
+	if changed, err := SyncKCMRestrictions(nil); err != nil || !changed {
+		t.Fatalf("restore = %v, %v", changed, err)
+	}
+	if changed, err := SyncKCMRestrictions(nil); err != nil || changed {
+		t.Errorf("second restore = %v, %v; want no-op", changed, err)
 	}
 
 	// Files should be removed (no originals existed).
@@ -675,12 +929,12 @@ func TestSyncKCMRestrictions_PreservesOriginal(t *testing.T) {
 	content := []byte("[KDE Control Module Restrictions][$i]\nkcm_access=false\n")
 
 	// Write KCM restrictions.
-	if err := SyncKCMRestrictions(content); err != nil {
+	if _, err := SyncKCMRestrictions(content); err != nil {
 		t.Fatal(err)
 	}
 
 	// Restore.
-	if err := SyncKCMRestrictions(nil); err != nil {
+	if _, err := SyncKCMRestrictions(nil); err != nil {
 		t.Fatal(err)
 	}
 
@@ -696,8 +950,36 @@ func TestSyncKCMRestrictions_PreservesOriginal(t *testing.T) {
 
 func TestProfileScriptContent(t *testing.T) {
 	got := profileScriptContent("/etc/bor/xdg")
-	want := "export XDG_CONFIG_DIRS=/etc/bor/xdg:${XDG_CONFIG_DIRS:-/etc/xdg}\nreadonly XDG_CONFIG_DIRS\n"
+	want := "export XDG_CONFIG_DIRS=/etc/bor/xdg-users/$(id -un):/etc/bor/xdg:${XDG_CONFIG_DIRS:-/etc/xdg}\nreadonly XDG_CONFIG_DIRS\n"
 	if got != want {
 		t.Errorf("profileScriptContent mismatch:\ngot:  %q\nwant: %q", got, want)
 	}
 }
+
+func TestUserKConfigDirs(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "xdg")
+	if got, want := UserKConfigDir(base+"/", "alice"), base+"-users/alice"; got != want {
+		t.Errorf("UserKConfigDir() = %q, want %q", got, want)
+	}
+
+	users, err := UserKConfigUsers(base)
+	if err != nil || users != nil {
+		t.Fatalf("UserKConfigUsers() with no directory = %v, %v; want nil, nil", users, err)
+	}
+	if _, err := SyncKConfigFiles(UserKConfigDir(base, "alice"), map[string][]byte{"kdeglobals": []byte("[General]\n")}); err != nil {
+		t.Fatalf("SyncKConfigFiles() error = %v", err)
+	}
+	users, err = UserKConfigUsers(base)
+	if err != nil {
+		t.Fatalf("UserKConfigUsers() error = %v", err)
+	}
+	if !slices.Equal(users, []string{"alice"}) {
+		t.Errorf("UserKConfigUsers() = %v, want [alice]", users)
+	}
+
+	for name, want := range map[string]bool{"alice": true, "": false, "..": false, "a/b": false} {
+		if got := ValidUserKConfigName(name); got != want {
+			t.Errorf("ValidUserKConfigName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policy
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// SortedIDs returns the policy IDs of a policy cache in ascending order.
+// Merges such as MergeFirefoxProtos are order-sensitive (repeated fields
+// are appended, later scalars win), so callers must merge cached policies
+// in this order rather than Go's randomised map order; otherwise identical
+// inputs can render different files and trigger needless rewrites.
+func SortedIDs[V any](cache map[string]V) []string {
+	return slices.Sorted(maps.Keys(cache))
+}
+
+// PriorityOrder returns the policy IDs of a policy cache in merge order:
+// ascending by binding priority, then by ID. Last-writer-wins merges fed
+// in this order let the highest-priority policy win a conflict and stay
+// deterministic between policies of equal priority.
+func PriorityOrder[V any](cache map[string]V, priority func(V) int32) []string {
+	ids := SortedIDs(cache)
+	slices.SortStableFunc(ids, func(a, b string) int {
+		return cmp.Compare(priority(cache[a]), priority(cache[b]))
+	})
+	return ids
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policy
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+func TestSortedIDs_FirefoxOutputIsStable(t *testing.T) {
+	newCache := func() map[string]*pb.FirefoxPolicy {
+		return map[string]*pb.FirefoxPolicy{
+			"c": {DisableTelemetry: boolPtr(true), Extensions: &pb.FirefoxExtensions{Install: []string{"c@example.com"}}},
+			"a": {DisableTelemetry: boolPtr(false), Extensions: &pb.FirefoxExtensions{Install: []string{"a@example.com"}}},
+			"b": {Extensions: &pb.FirefoxExtensions{Install: []string{"b@example.com"}}},
+		}
+	}
+	render := func(cache map[string]*pb.FirefoxPolicy) []byte {
+		var policies []*pb.FirefoxPolicy
+		for _, id := range SortedIDs(cache) {
+			policies = append(policies, cache[id])
+		}
+		data, err := FirefoxPoliciesContent(policies)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	want := render(newCache())
+	for i := 0; i < 50; i++ {
+		if got := render(newCache()); !bytes.Equal(got, want) {
+			t.Fatalf("render %d differs:\n%s\nwant:\n%s", i, got, want)
+		}
+	}
+	if !bytes.Contains(want, []byte(`"DisableTelemetry": true`)) {
+		t.Errorf("expected the policy with the highest ID to win, got:\n%s", want)
+	}
+}
+
+func TestSortedIDs_ChromeOutputIsStable(t *testing.T) {
+	newCache := func() map[string]*pb.ChromePolicy {
+		first, second, third := "https://a.example.com", "https://b.example.com", "https://c.example.com"
+		return map[string]*pb.ChromePolicy{
+			"p2": {HomepageLocation: &second},
+			"p3": {HomepageLocation: &third},
+			"p1": {HomepageLocation: &first},
+		}
+	}
+	render := func(cache map[string]*pb.ChromePolicy) []byte {
+		var policies []*pb.ChromePolicy
+		for _, id := range SortedIDs(cache) {
+			policies = append(policies, cache[id])
+		}
+		data, err := ChromePoliciesContent(policies)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	want := render(newCache())
+	for i := 0; i < 50; i++ {
+		if got := render(newCache()); !bytes.Equal(got, want) {
+			t.Fatalf("render %d differs:\n%s\nwant:\n%s", i, got, want)
+		}
+	}
+	if !bytes.Contains(want, []byte("https://c.example.com")) {
+		t.Errorf("expected the policy with the highest ID to win, got:\n%s", want)
+	}
+}
+
+func TestPriorityOrder_FirefoxHigherPriorityWins(t *testing.T) {
+	type entry struct {
+		priority int32
+		policy   *pb.FirefoxPolicy
+	}
+	// "a" sorts first by ID, so without priority ordering "b" would win.
+	cache := map[string]entry{
+		"a": {priority: 10, policy: &pb.FirefoxPolicy{Homepage: &pb.FirefoxHomepage{URL: "https://high.example.com", Locked: true}}},
+		"b": {priority: 1, policy: &pb.FirefoxPolicy{Homepage: &pb.FirefoxHomepage{URL: "https://low.example.com"}}},
+	}
+
+	ids := PriorityOrder(cache, func(e entry) int32 { return e.priority })
+	if len(ids) != 2 || ids[0] != "b" || ids[1] != "a" {
+		t.Fatalf("PriorityOrder = %v, want [b a]", ids)
+	}
+	var policies []*pb.FirefoxPolicy
+	for _, id := range ids {
+		policies = append(policies, cache[id].policy)
+	}
+	data, err := FirefoxPoliciesContent(policies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte("https://high.example.com")) || bytes.Contains(data, []byte("https://low.example.com")) {
+		t.Errorf("expected the priority-10 homepage to win, got:\n%s", data)
+	}
+}
+
+func TestPriorityOrder_EqualPriorityByID(t *testing.T) {
+	cache := map[string]int32{"c": 5, "a": 5, "b": 1}
+	ids := PriorityOrder(cache, func(p int32) int32 { return p })
+	if want := []string{"b", "a", "c"}; !slices.Equal(ids, want) {
+		t.Errorf("PriorityOrder = %v, want %v", ids, want)
+	}
+}
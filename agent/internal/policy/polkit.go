@@ -181,14 +181,14 @@ func PolkitRulesPath(priority int32, policyID string) string {
 func SyncPolkitRules(rulesPath string, js []byte) error {
 	// Ensure the directory exists.
 	if err := os.MkdirAll(filepath.Dir(rulesPath), 0o755); err != nil { //nolint:gosec // G301: polkit rules directory must be world-readable
-		return fmt.Errorf("polkit: create rules dir: %w", err)
+		return asReadOnly(rulesPath, fmt.Errorf("polkit: create rules dir: %w", err))
 	}
 
 	// Write atomically: write to a temp file then rename.
 	dir := filepath.Dir(rulesPath)
 	tmp, err := os.CreateTemp(dir, ".bor-polkit-*.rules")
 	if err != nil {
-		return fmt.Errorf("polkit: create temp file: %w", err)
+		return asReadOnly(rulesPath, fmt.Errorf("polkit: create temp file: %w", err))
 	}
 	tmpPath := tmp.Name()
 
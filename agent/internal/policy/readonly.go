@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// stRdonly is the ST_RDONLY bit of statfs(2) f_flags.
+const stRdonly = 0x1
+
+// writableAlternatives maps read-only locations commonly found on
+// immutable (ostree, composefs, transactional-update) systems to the
+// writable location that the corresponding software also reads. An
+// alternative ending in "/" replaces the matched prefix.
+var writableAlternatives = []struct {
+	prefix      string
+	alternative string
+}{
+	{"/usr/lib64/firefox/", "/etc/firefox/policies/policies.json"},
+	{"/usr/lib/firefox/", "/etc/firefox/policies/policies.json"},
+	{"/usr/share/polkit-1/rules.d/", PolkitRulesDir + "/"},
+	{"/usr/etc/", "/etc/"},
+}
+
+// ReadOnlyError reports that a policy target lives on a read-only
+// filesystem, as is the case for parts of the tree on immutable systems.
+type ReadOnlyError struct {
+	Path string
+	Err  error
+}
+
+func (e *ReadOnlyError) Error() string {
+	msg := "target filesystem is read-only: " + e.Path
+	if alt := WritableAlternative(e.Path); alt != "" {
+		return msg + " (use " + alt + " instead)"
+	}
+	return msg + " (configure a writable target path in the agent configuration)"
+}
+
+func (e *ReadOnlyError) Unwrap() error {
+	return e.Err
+}
+
+// WritableAlternative returns the writable location to use instead of the
+// read-only path, or "" if none is known.
+func WritableAlternative(path string) string {
+	for _, a := range writableAlternatives {
+		if !strings.HasPrefix(path, a.prefix) {
+			continue
+		}
+		if strings.HasSuffix(a.alternative, "/") {
+			return a.alternative + strings.TrimPrefix(path, a.prefix)
+		}
+		return a.alternative
+	}
+	return ""
+}
+
+// asReadOnly wraps err in a *ReadOnlyError for path when it was caused by
+// EROFS, and returns it unchanged otherwise.
+func asReadOnly(path string, err error) error {
+	if err == nil || !errors.Is(err, syscall.EROFS) {
+		return err
+	}
+	var roErr *ReadOnlyError
+	if errors.As(err, &roErr) {
+		return err
+	}
+	return &ReadOnlyError{Path: path, Err: err}
+}
+
+// ComplianceMessage formats a sync failure for a compliance report. Errors
+// caused by a read-only filesystem are reported with a clear, actionable
+// message instead of the raw error chain.
+func ComplianceMessage(prefix string, err error) string {
+	var roErr *ReadOnlyError
+	if errors.As(err, &roErr) {
+		return roErr.Error()
+	}
+	if errors.Is(err, syscall.EROFS) {
+		path := ""
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			path = pathErr.Path
+		}
+		return (&ReadOnlyError{Path: path, Err: err}).Error()
+	}
+	return prefix + ": " + err.Error()
+}
+
+// CheckWritable reports whether path could be written by the agent. It
+// inspects the nearest existing ancestor, since targets and their parent
+// directories are created on demand. A *ReadOnlyError is returned when that
+// ancestor is on a read-only mount.
+func CheckWritable(path string) error {
+	dir := filepath.Clean(path)
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return fmt.Errorf("statfs %s: %w", dir, err)
+	}
+	if st.Flags&stRdonly != 0 {
+		return &ReadOnlyError{Path: path, Err: syscall.EROFS}
+	}
+	return nil
+}
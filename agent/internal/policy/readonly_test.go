@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestWritableAlternative(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/usr/lib64/firefox/distribution/policies.json", "/etc/firefox/policies/policies.json"},
+		{"/usr/share/polkit-1/rules.d/50-bor.rules", "/etc/polkit-1/rules.d/50-bor.rules"},
+		{"/usr/etc/dconf/db/local.d/00-bor", "/etc/dconf/db/local.d/00-bor"},
+		{"/etc/firefox/policies/policies.json", ""},
+	}
+	for _, tt := range tests {
+		if got := WritableAlternative(tt.path); got != tt.want {
+			t.Errorf("WritableAlternative(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestComplianceMessage(t *testing.T) {
+	rofs := fmt.Errorf("failed to create temp file: %w",
+		&fs.PathError{Op: "open", Path: "/usr/lib64/firefox/distribution/.bor-tmp-1", Err: syscall.EROFS})
+
+	wrapped := asReadOnly("/usr/lib64/firefox/distribution/policies.json", rofs)
+	if !errors.Is(wrapped, syscall.EROFS) {
+		t.Error("ReadOnlyError must unwrap to EROFS")
+	}
+	msg := ComplianceMessage("failed to sync Firefox policies", fmt.Errorf("sync: %w", wrapped))
+	want := "target filesystem is read-only: /usr/lib64/firefox/distribution/policies.json (use /etc/firefox/policies/policies.json instead)"
+	if msg != want {
+		t.Errorf("ComplianceMessage() = %q, want %q", msg, want)
+	}
+
+	// Unwrapped EROFS errors still get the clear message.
+	msg = ComplianceMessage("failed to write rules file", rofs)
+	if !strings.HasPrefix(msg, "target filesystem is read-only: ") {
+		t.Errorf("ComplianceMessage(raw EROFS) = %q", msg)
+	}
+
+	other := errors.New("disk full")
+	if got := ComplianceMessage("failed to sync dconf files", other); got != "failed to sync dconf files: disk full" {
+		t.Errorf("ComplianceMessage(other) = %q", got)
+	}
+	if asReadOnly("/x", other) != other {
+		t.Error("asReadOnly must not wrap unrelated errors")
+	}
+}
+
+func TestCheckWritable(t *testing.T) {
+	dir := t.TempDir()
+	if err := CheckWritable(filepath.Join(dir, "missing", "policies.json")); err != nil {
+		t.Errorf("CheckWritable(temp dir) = %v, want nil", err)
+	}
+}
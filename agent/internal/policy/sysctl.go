@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policy
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+// SysctlConfPath is the sysctl.d drop-in written by Bor. The 99- prefix
+// makes it load after the distribution defaults so its values win.
+const SysctlConfPath = "/etc/sysctl.d/99-bor.conf"
+
+// sysctlProcDir is where the kernel exposes the current parameter values.
+// A variable so tests can point it at a fixture tree.
+var sysctlProcDir = "/proc/sys"
+
+// sysctlReload applies the sysctl.d configuration to the running kernel.
+// A variable so tests can observe the reload without running sysctl.
+var sysctlReload = func() ([]byte, error) {
+	return exec.Command("sysctl", "--system").CombinedOutput()
+}
+
+// SysctlItemResult is the compliance result for a single sysctl entry.
+type SysctlItemResult struct {
+	Key     string
+	Status  pb.ComplianceStatus
+	Message string
+}
+
+// SysctlPath converts a sysctl key to its path below /proc/sys. As in
+// sysctl(8), a key containing "/" uses it as the separator and keeps dots
+// literal (e.g. "net/ipv4/conf/eth0.100/rp_filter"); otherwise dots
+// separate the components.
+func SysctlPath(key string) string {
+	if strings.Contains(key, "/") {
+		return strings.Trim(key, "/")
+	}
+	return strings.ReplaceAll(key, ".", "/")
+}
+
+// MergeSysctlEntries merges the entries of all Sysctl policies into one
+// entry per kernel parameter. Entries are applied in ascending priority
+// order, so for a key set by several policies the highest priority wins;
+// at equal priority the entry that comes last in the input wins. Callers
+// pass entries in SortedIDs order so the result is deterministic. The
+// result is sorted by key.
+func MergeSysctlEntries(entries []*pb.SysctlEntry) []*pb.SysctlEntry {
+	ordered := slices.Clone(entries)
+	slices.SortStableFunc(ordered, func(a, b *pb.SysctlEntry) int {
+		return cmp.Compare(a.GetPriority(), b.GetPriority())
+	})
+
+	byPath := make(map[string]*pb.SysctlEntry, len(ordered))
+	for _, e := range ordered {
+		if e.GetKey() == "" {
+			continue
+		}
+		byPath[SysctlPath(e.GetKey())] = e
+	}
+
+	merged := make([]*pb.SysctlEntry, 0, len(byPath))
+	for _, e := range byPath {
+		merged = append(merged, e)
+	}
+	slices.SortFunc(merged, func(a, b *pb.SysctlEntry) int {
+		return cmp.Compare(a.GetKey(), b.GetKey())
+	})
+	return merged
+}
+
+// SysctlEntriesToConf renders entries as sysctl.d "key = value" lines.
+func SysctlEntriesToConf(entries []*pb.SysctlEntry) []byte {
+	var buf bytes.Buffer
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "%s = %s\n", e.GetKey(), strings.TrimSpace(e.GetValue()))
+	}
+	return buf.Bytes()
+}
+
+// SyncSysctl merges entries, writes them to SysctlConfPath and runs
+// `sysctl --system` so the new values take effect immediately. The
+// original file, if any, is backed up on the first write. When the file
+// already holds the merged entries nothing is written or reloaded.
+// Passing no entries restores the original and reloads sysctl settings;
+// when Bor never wrote the file this is a no-op.
+func SyncSysctl(entries []*pb.SysctlEntry) error {
+	return syncSysctl(SysctlConfPath, entries)
+}
+
+func syncSysctl(path string, entries []*pb.SysctlEntry) error {
+	changed, err := writeSysctlConf(path, MergeSysctlEntries(entries))
+	if err != nil || !changed {
+		return err
+	}
+	if out, err := sysctlReload(); err != nil {
+		return fmt.Errorf("sysctl --system failed: %w\noutput: %s", err, out)
+	}
+	return nil
+}
+
+// writeSysctlConf writes the merged entries to path, or restores the
+// original when entries is empty. It reports whether path was touched.
+func writeSysctlConf(path string, entries []*pb.SysctlEntry) (bool, error) {
+	if len(entries) == 0 {
+		if _, err := os.Stat(path + BackupSuffix); os.IsNotExist(err) {
+			return false, nil
+		}
+		if err := RestoreOriginal(path); err != nil {
+			return false, fmt.Errorf("sysctl: restore %s: %w", path, err)
+		}
+		return true, nil
+	}
+
+	data, err := transformContent("Sysctl", path, SysctlEntriesToConf(entries))
+	if err != nil {
+		return false, err
+	}
+	if managedContentEqual(path, data) {
+		return false, nil
+	}
+	if err := BackupOriginal(path); err != nil {
+		return false, fmt.Errorf("sysctl: backup %s: %w", path, err)
+	}
+	if err := WriteFileAtomically(path, append([]byte(ManagedFileHeader), data...)); err != nil {
+		return false, fmt.Errorf("sysctl: write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// CheckSysctlCompliance compares each merged entry with the value the
+// kernel currently reports. Multi-field values such as
+// net.ipv4.tcp_rmem are compared field by field, ignoring whitespace.
+func CheckSysctlCompliance(entries []*pb.SysctlEntry) []SysctlItemResult {
+	results := make([]SysctlItemResult, 0, len(entries))
+	for _, e := range entries {
+		r := SysctlItemResult{Key: e.GetKey()}
+		if strings.Contains(e.GetKey(), "..") {
+			r.Status = pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR
+			r.Message = "invalid kernel parameter name"
+			results = append(results, r)
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(sysctlProcDir, SysctlPath(e.GetKey()))) //nolint:gosec // G304: path below /proc/sys built from policy key
+		switch {
+		case os.IsNotExist(err):
+			r.Status = pb.ComplianceStatus_COMPLIANCE_STATUS_INAPPLICABLE
+			r.Message = "kernel parameter not available on this node"
+		case err != nil:
+			r.Status = pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR
+			r.Message = err.Error()
+		case normalizeSysctlValue(string(data)) != normalizeSysctlValue(e.GetValue()):
+			r.Status = pb.ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT
+			r.Message = fmt.Sprintf("expected %s, got %s", strings.TrimSpace(e.GetValue()), normalizeSysctlValue(string(data)))
+		default:
+			r.Status = pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func normalizeSysctlValue(v string) string {
+	return strings.Join(strings.Fields(v), " ")
+}
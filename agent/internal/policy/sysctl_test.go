@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+func TestMergeSysctlEntries_PriorityWins(t *testing.T) {
+	entries := []*pb.SysctlEntry{
+		{Key: "vm.swappiness", Value: "10", Priority: 50},
+		{Key: "net.ipv4.ip_forward", Value: "0", Priority: 50},
+		{Key: "vm/swappiness", Value: "60", Priority: 10},
+		{Key: "net.ipv4.ip_forward", Value: "1", Priority: 50},
+	}
+
+	got := string(SysctlEntriesToConf(MergeSysctlEntries(entries)))
+	want := "net.ipv4.ip_forward = 1\nvm.swappiness = 10\n"
+	if got != want {
+		t.Errorf("merged conf = %q, want %q", got, want)
+	}
+}
+
+func TestMergeSysctlEntries_SlashKeysKeepDots(t *testing.T) {
+	entries := []*pb.SysctlEntry{
+		{Key: "net/ipv4/conf/eth0.100/rp_filter", Value: "1"},
+		{Key: "net.ipv4.conf.eth0.rp_filter", Value: "2"},
+	}
+	if got := MergeSysctlEntries(entries); len(got) != 2 {
+		t.Errorf("merged %d entries, want 2 distinct parameters", len(got))
+	}
+}
+
+func TestWriteSysctlConf_BackupAndRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "99-bor.conf")
+	writeFile(t, path, []byte("kernel.sysrq = 1\n"))
+
+	changed, err := writeSysctlConf(path, []*pb.SysctlEntry{{Key: "kernel.sysrq", Value: "0"}})
+	if err != nil || !changed {
+		t.Fatalf("writeSysctlConf = %v, %v", changed, err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), ManagedFileHeader) || !strings.HasSuffix(string(data), "kernel.sysrq = 0\n") {
+		t.Errorf("managed file = %q", data)
+	}
+
+	changed, err = writeSysctlConf(path, nil)
+	if err != nil || !changed {
+		t.Fatalf("restore = %v, %v", changed, err)
+	}
+	data, _ = os.ReadFile(path)
+	if string(data) != "kernel.sysrq = 1\n" {
+		t.Errorf("restored file = %q, want original", data)
+	}
+
+	// Nothing left to restore: no write, so no reload either.
+	if changed, err = writeSysctlConf(path, nil); err != nil || changed {
+		t.Errorf("second restore = %v, %v; want no-op", changed, err)
+	}
+}
+
+func TestSyncSysctl_SkipsReloadWhenUnchanged(t *testing.T) {
+	reloads := 0
+	orig := sysctlReload
+	sysctlReload = func() ([]byte, error) {
+		reloads++
+		return nil, nil
+	}
+	t.Cleanup(func() { sysctlReload = orig })
+
+	path := filepath.Join(t.TempDir(), "99-bor.conf")
+	entries := []*pb.SysctlEntry{{Key: "kernel.sysrq", Value: "0"}}
+	for range 2 {
+		if err := syncSysctl(path, entries); err != nil {
+			t.Fatalf("syncSysctl() error = %v", err)
+		}
+	}
+	if reloads != 1 {
+		t.Errorf("reloads = %d after syncing the same entries twice, want 1", reloads)
+	}
+
+	if err := syncSysctl(path, []*pb.SysctlEntry{{Key: "kernel.sysrq", Value: "1"}}); err != nil {
+		t.Fatalf("syncSysctl() error = %v", err)
+	}
+	if reloads != 2 {
+		t.Errorf("reloads = %d after changing a value, want 2", reloads)
+	}
+}
+
+func TestCheckSysctlCompliance(t *testing.T) {
+	sysctlProcDir = t.TempDir()
+	t.Cleanup(func() { sysctlProcDir = "/proc/sys" })
+	for path, v := range map[string]string{
+		"vm/swappiness":       "10\n",
+		"net/ipv4/tcp_rmem":   "4096\t131072\t6291456\n",
+		"net/ipv4/ip_forward": "1\n",
+	} {
+		full := filepath.Join(sysctlProcDir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, full, []byte(v))
+	}
+
+	results := CheckSysctlCompliance([]*pb.SysctlEntry{
+		{Key: "vm.swappiness", Value: "10"},
+		{Key: "net.ipv4.tcp_rmem", Value: "4096 131072  6291456"},
+		{Key: "net.ipv4.ip_forward", Value: "0"},
+		{Key: "kernel.missing", Value: "1"},
+	})
+	want := []pb.ComplianceStatus{
+		pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT,
+		pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT,
+		pb.ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT,
+		pb.ComplianceStatus_COMPLIANCE_STATUS_INAPPLICABLE,
+	}
+	for i, r := range results {
+		if r.Status != want[i] {
+			t.Errorf("%s: status = %v (%s), want %v", r.Key, r.Status, r.Message, want[i])
+		}
+	}
+}
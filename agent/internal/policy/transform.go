@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policy
+
+import "fmt"
+
+// ContentTransform rewrites the merged content of a policy type before it
+// is written to target. See package transform.
+type ContentTransform func(policyType, target string, data []byte) ([]byte, error)
+
+// contentTransform is applied to Firefox, Chrome, KConfig, dconf and
+// sysctl content (the latter by writeSysctlConf); nil writes content as
+// merged.
+var contentTransform ContentTransform
+
+// SetContentTransform installs the site content transform hook. It must be
+// called before the first sync.
+func SetContentTransform(t ContentTransform) {
+	contentTransform = t
+}
+
+// transformContent returns data as it should be written to target. Hook
+// failures are returned as errors so that the target is left untouched and
+// the sync reports the affected policies as non-compliant.
+func transformContent(policyType, target string, data []byte) ([]byte, error) {
+	if contentTransform == nil || len(data) == 0 {
+		return data, nil
+	}
+	out, err := contentTransform(policyType, target, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", target, err)
+	}
+	return out, nil
+}
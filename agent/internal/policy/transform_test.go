@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+func TestContentTransform(t *testing.T) {
+	t.Cleanup(func() { SetContentTransform(nil) })
+	target := filepath.Join(t.TempDir(), "policies.json")
+	policies := []*pb.FirefoxPolicy{{}}
+
+	SetContentTransform(func(policyType, path string, _ []byte) ([]byte, error) {
+		if policyType != "Firefox" || path != target {
+			t.Errorf("transform called with %s %s", policyType, path)
+		}
+		return []byte("{\"transformed\": true}\n"), nil
+	})
+	if err := SyncFirefoxFlatpakPoliciesFromProto(target, policies); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(target); string(got) != "{\"transformed\": true}\n" { //nolint:gosec // G304: test path
+		t.Errorf("written content = %q, want transformed output", got)
+	}
+
+	// A failing hook leaves the previously written file in place.
+	SetContentTransform(func(string, string, []byte) ([]byte, error) {
+		return nil, errors.New("hook failed")
+	})
+	if err := SyncFirefoxFlatpakPoliciesFromProto(target, policies); err == nil {
+		t.Fatal("expected sync to fail when the hook fails")
+	}
+	if got, _ := os.ReadFile(target); string(got) != "{\"transformed\": true}\n" { //nolint:gosec // G304: test path
+		t.Errorf("content after failed hook = %q, want previous content", got)
+	}
+}
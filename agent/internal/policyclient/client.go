@@ -9,15 +9,23 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"log"
 	"math"
 	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -26,6 +34,35 @@ type Client struct {
 	conn     *grpc.ClientConn
 	client   pb.PolicyServiceClient
 	clientID string
+	// agentVersion is reported when subscribing, see SetAgentVersion.
+	agentVersion string
+
+	// backoffSeconds is the server's latest backpressure hint.
+	backoffSeconds atomic.Int32
+	// batchUnsupported is set once the server turned out not to implement
+	// ReportComplianceBatch.
+	batchUnsupported atomic.Bool
+
+	// onLogRequest handles LOG_REQUEST events, see SetLogRequestHandler.
+	onLogRequest func(requestID string)
+	// onRemediation handles REMEDIATION_REQUEST events, see
+	// SetRemediationHandler.
+	onRemediation func(requestID, action string)
+
+	// complianceMu serialises compliance reports, so a queued report is
+	// never delivered after a newer one.
+	complianceMu    sync.Mutex
+	complianceQueue *complianceQueue // nil: undeliverable reports are lost
+}
+
+// Keepalive configures the pings that detect a dead connection to the
+// server. After Interval without activity the client pings the server and
+// closes the connection when no answer arrives within Timeout, failing
+// open streams so the caller reconnects. A zero Interval keeps the gRPC
+// defaults.
+type Keepalive struct {
+	Interval time.Duration
+	Timeout  time.Duration
 }
 
 // New creates a gRPC client connection to the given server address.
@@ -33,7 +70,7 @@ type Client struct {
 // clientCertPath and clientKeyPath specify the mTLS client certificate
 // and private key. If insecureSkipVerify is true, server cert verification
 // is skipped.
-func New(serverAddr, clientID, caCertPath, clientCertPath, clientKeyPath string, insecureSkipVerify bool) (*Client, error) {
+func New(serverAddr, clientID, caCertPath, clientCertPath, clientKeyPath string, insecureSkipVerify bool, ka Keepalive) (*Client, error) {
 	// After enrollment the agent has a client cert — refuse to operate
 	// without proper CA verification in that case.
 	enrolled := clientCertPath != "" && clientKeyPath != ""
@@ -66,18 +103,33 @@ func New(serverAddr, clientID, caCertPath, clientCertPath, clientKeyPath string,
 		return nil, fmt.Errorf("no CA certificate configured and insecure_skip_verify is false – cannot connect securely; enroll first or set insecure_skip_verify")
 	}
 
-	// Load client certificate for mTLS if present
+	// Load client certificate for mTLS if present. The key pair is re-read
+	// from disk on every handshake so that a certificate renewed while the
+	// agent is running is used as soon as the connection is re-established.
 	if clientCertPath != "" && clientKeyPath != "" {
-		cert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
-		if err != nil {
+		if _, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath); err != nil {
 			return nil, fmt.Errorf("failed to load client certificate: %w", err)
 		}
-		tlsCfg.Certificates = []tls.Certificate{cert}
+		tlsCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load client certificate: %w", err)
+			}
+			return &cert, nil
+		}
 	}
 
-	conn, err := grpc.NewClient(serverAddr,
-		grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)),
-	)
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg))}
+	if ka.Interval > 0 {
+		// Pings are also sent without an active RPC, so a connection that
+		// died between stream reconnects is noticed too.
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                ka.Interval,
+			Timeout:             ka.Timeout,
+			PermitWithoutStream: true,
+		}))
+	}
+	conn, err := grpc.NewClient(serverAddr, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to gRPC server %s: %w", serverAddr, err)
 	}
@@ -110,28 +162,217 @@ type PolicyInfo struct {
 	ChromePolicy  *pb.ChromePolicy  // populated from typed_content for Chrome type
 	DConfPolicy   *pb.DConfPolicy   // populated from typed_content for Dconf type
 	PolkitPolicy  *pb.PolkitPolicy  // populated from typed_content for Polkit type
+	SysctlPolicy  *pb.SysctlPolicy  // populated from typed_content for Sysctl type
+
+	// ContactLossAction and ContactLossTTL describe the policy's dead-man's
+	// switch: what to do once the server has been unreachable for ContactLossTTL.
+	ContactLossAction pb.ContactLossAction
+	ContactLossTTL    time.Duration
+
+	// ComplianceCheck is the policy's optional check command (nil if none).
+	ComplianceCheck *pb.ComplianceCheck
+
+	// Preconditions must all hold on this node for the policy to be applied.
+	Preconditions []*pb.Precondition
+
+	// ScopeUsers, when set, are the logged-in users the policy applies to;
+	// it is bound to their user groups rather than to this node.
+	ScopeUsers []string
+
+	// ContentHash is the hex SHA-256 of Content computed by the server.
+	// Empty when the server does not send it.
+	ContentHash string
+
+	// proto is the message the policy was received as, kept for the
+	// offline policy cache.
+	proto *pb.Policy
+}
+
+// SameContent reports whether p applies exactly like prev: the content
+// hashes are known and equal, and type, priority, scope and preconditions
+// are unchanged. Such an update needs no re-applying.
+func (p *PolicyInfo) SameContent(prev *PolicyInfo) bool {
+	if prev == nil || p.ContentHash == "" || p.ContentHash != prev.ContentHash {
+		return false
+	}
+	return p.Type == prev.Type &&
+		p.Priority == prev.Priority &&
+		slices.Equal(p.ScopeUsers, prev.ScopeUsers) &&
+		slices.EqualFunc(p.Preconditions, prev.Preconditions, func(a, b *pb.Precondition) bool { return proto.Equal(a, b) })
 }
 
 // ReportCompliance sends a compliance report for a policy back to the server.
 func (c *Client) ReportCompliance(ctx context.Context, policyID string, compliant bool, message string) error {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
-	resp, err := c.client.ReportCompliance(ctx, &pb.ReportComplianceRequest{
+	return c.reportCompliance(ctx, &pb.ReportComplianceRequest{
 		ClientId:   c.clientID,
 		PolicyId:   policyID,
 		Compliant:  compliant,
 		Message:    message,
 		ReportedAt: timestamppb.Now(),
 	})
+}
+
+// SetComplianceQueue persists compliance reports that cannot be delivered
+// because the server is unreachable in the file at path, keeping at most
+// maxReports, and delivers them once the server can be reached again, see
+// FlushComplianceQueue. Reports already queued at path are loaded. A
+// maxReports of 0 or less disables the queue.
+func (c *Client) SetComplianceQueue(path string, maxReports int) error {
+	c.complianceMu.Lock()
+	defer c.complianceMu.Unlock()
+	if maxReports <= 0 {
+		c.complianceQueue = nil
+		return nil
+	}
+	q, err := loadComplianceQueue(path, maxReports)
+	c.complianceQueue = q
 	if err != nil {
-		return fmt.Errorf("ReportCompliance RPC failed: %w", err)
+		return err
+	}
+	if n := len(q.reports); n > 0 {
+		log.Printf("%d queued compliance reports pending delivery", n)
+	}
+	return nil
+}
+
+// FlushComplianceQueue delivers the queued compliance reports, oldest
+// first. It stops at the first report that still cannot be delivered.
+func (c *Client) FlushComplianceQueue(ctx context.Context) error {
+	c.complianceMu.Lock()
+	defer c.complianceMu.Unlock()
+	return c.flushComplianceQueueLocked(ctx)
+}
+
+func (c *Client) flushComplianceQueueLocked(ctx context.Context) error {
+	q := c.complianceQueue
+	if q == nil || len(q.reports) == 0 {
+		return nil
+	}
+	var err error
+	sent := 0
+	for len(q.reports) > 0 {
+		req := q.reports[0]
+		if err = c.sendCompliance(ctx, req); err != nil && queueableComplianceError(err) {
+			break
+		}
+		if err != nil {
+			log.Printf("Dropping queued compliance report for policy %s: %v", req.GetPolicyId(), err)
+		} else {
+			sent++
+		}
+		q.reports = q.reports[1:]
+	}
+	if sent > 0 {
+		log.Printf("Delivered %d queued compliance reports", sent)
+	}
+	if saveErr := q.save(); saveErr != nil {
+		log.Printf("Failed to save compliance queue: %v", saveErr)
+	}
+	if err != nil && queueableComplianceError(err) {
+		return err
+	}
+	return nil
+}
+
+// reportCompliance delivers req, queueing it when the server cannot be
+// reached and a compliance queue is set.
+func (c *Client) reportCompliance(ctx context.Context, req *pb.ReportComplianceRequest) error {
+	c.complianceMu.Lock()
+	defer c.complianceMu.Unlock()
+
+	err := c.sendCompliance(ctx, req)
+	c.settleComplianceLocked(ctx, []*pb.ReportComplianceRequest{req}, err)
+	return err
+}
+
+// reportComplianceBatch delivers reqs in one call, queueing them when the
+// server cannot be reached and a compliance queue is set. Servers without
+// the batch RPC get the reports one by one.
+func (c *Client) reportComplianceBatch(ctx context.Context, reqs []*pb.ReportComplianceRequest) error {
+	if !c.batchUnsupported.Load() {
+		c.complianceMu.Lock()
+		err := c.sendComplianceBatch(ctx, reqs)
+		if status.Code(err) != codes.Unimplemented {
+			c.settleComplianceLocked(ctx, reqs, err)
+			c.complianceMu.Unlock()
+			return err
+		}
+		c.complianceMu.Unlock()
+		c.batchUnsupported.Store(true)
+		log.Printf("Server does not support batched compliance reports; sending them one by one")
 	}
 
+	var errs []error
+	for _, req := range reqs {
+		if err := c.reportCompliance(ctx, req); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// settleComplianceLocked updates the compliance queue after sending reqs
+// failed with err, or succeeded when err is nil.
+func (c *Client) settleComplianceLocked(ctx context.Context, reqs []*pb.ReportComplianceRequest, err error) {
+	q := c.complianceQueue
+	if q == nil {
+		return
+	}
+	switch {
+	case err == nil:
+		// Older queued reports for the policies are stale now; deliver
+		// the rest while the server is reachable.
+		for _, req := range reqs {
+			q.supersede(req.GetPolicyId())
+		}
+		_ = c.flushComplianceQueueLocked(ctx)
+	case queueableComplianceError(err):
+		dropped := 0
+		for _, req := range reqs {
+			dropped += q.add(req)
+		}
+		if dropped > 0 {
+			log.Printf("Warning: compliance queue full, dropped %d oldest reports", dropped)
+		}
+	default:
+		return
+	}
+	if saveErr := q.save(); saveErr != nil {
+		log.Printf("Failed to save compliance queue: %v", saveErr)
+	}
+}
+
+// sendCompliance sends one compliance report to the server.
+func (c *Client) sendCompliance(ctx context.Context, req *pb.ReportComplianceRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ReportCompliance(ctx, req)
+	if err != nil {
+		return fmt.Errorf("ReportCompliance RPC failed: %w", err)
+	}
 	if !resp.GetSuccess() {
-		return fmt.Errorf("server rejected compliance report for policy %s", policyID)
+		return fmt.Errorf("server rejected compliance report for policy %s", req.GetPolicyId())
 	}
+	return nil
+}
+
+// sendComplianceBatch sends several compliance reports to the server in one
+// call.
+func (c *Client) sendComplianceBatch(ctx context.Context, reqs []*pb.ReportComplianceRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
 
+	resp, err := c.client.ReportComplianceBatch(ctx, &pb.ReportComplianceBatchRequest{
+		ClientId: c.clientID,
+		Reports:  reqs,
+	})
+	if err != nil {
+		return fmt.Errorf("ReportComplianceBatch RPC failed: %w", err)
+	}
+	if !resp.GetSuccess() {
+		return fmt.Errorf("server rejected %d compliance reports", len(reqs))
+	}
 	return nil
 }
 
@@ -154,6 +395,7 @@ func (c *Client) GetAgentConfig(ctx context.Context) (*AgentConfig, error) {
 	if err != nil {
 		return nil, fmt.Errorf("GetAgentConfig RPC failed: %w", err)
 	}
+	c.backoffSeconds.Store(resp.GetBackoffSeconds())
 
 	cfg := resp.GetConfig()
 	if cfg == nil {
@@ -169,6 +411,12 @@ func (c *Client) GetAgentConfig(ctx context.Context) (*AgentConfig, error) {
 	}, nil
 }
 
+// BackoffHint returns how long the server last asked agents to wait before
+// reconnecting because it is overloaded, or 0 when no back-off is requested.
+func (c *Client) BackoffHint() time.Duration {
+	return time.Duration(c.backoffSeconds.Load()) * time.Second
+}
+
 // NodeInfo holds system metadata sent with each heartbeat.
 type NodeInfo struct {
 	FQDN         string
@@ -178,6 +426,14 @@ type NodeInfo struct {
 	DesktopEnvs  []string
 	AgentVersion string
 	MachineID    string
+	SessionUsers []string
+	// FirefoxVersion, ChromeVersion and KernelVersion are empty when not
+	// detected.
+	FirefoxVersion string
+	ChromeVersion  string
+	KernelVersion  string
+	// Hardware is nil when no hardware facts could be collected.
+	Hardware *pb.NodeHardware
 }
 
 // Heartbeat sends a heartbeat with node metadata to the server.
@@ -188,13 +444,18 @@ func (c *Client) Heartbeat(ctx context.Context, info *NodeInfo) error {
 	req := &pb.HeartbeatRequest{
 		ClientId: c.clientID,
 		Info: &pb.NodeInfo{
-			Fqdn:         info.FQDN,
-			IpAddress:    info.IPAddress,
-			OsName:       info.OSName,
-			OsVersion:    info.OSVersion,
-			DesktopEnvs:  info.DesktopEnvs,
-			AgentVersion: info.AgentVersion,
-			MachineId:    info.MachineID,
+			Fqdn:           info.FQDN,
+			IpAddress:      info.IPAddress,
+			OsName:         info.OSName,
+			OsVersion:      info.OSVersion,
+			DesktopEnvs:    info.DesktopEnvs,
+			AgentVersion:   info.AgentVersion,
+			MachineId:      info.MachineID,
+			SessionUsers:   info.SessionUsers,
+			FirefoxVersion: info.FirefoxVersion,
+			ChromeVersion:  info.ChromeVersion,
+			KernelVersion:  info.KernelVersion,
+			Hardware:       info.Hardware,
 		},
 	}
 
@@ -202,6 +463,7 @@ func (c *Client) Heartbeat(ctx context.Context, info *NodeInfo) error {
 	if err != nil {
 		return fmt.Errorf("Heartbeat RPC failed: %w", err)
 	}
+	c.backoffSeconds.Store(resp.GetBackoffSeconds())
 	if !resp.GetAccepted() {
 		return fmt.Errorf("server rejected heartbeat for client %s", c.clientID)
 	}
@@ -246,11 +508,17 @@ func (c *Client) ReportTamperEvent(ctx context.Context, filePath string, process
 	return nil
 }
 
+// ErrServerGoingAway is returned by SubscribePolicyUpdates when the server
+// closed the stream because it is shutting down. It is not a failure: the
+// caller should reconnect after BackoffHint.
+var ErrServerGoingAway = errors.New("server is going away")
+
 // PolicyUpdateCallback is called for each policy update received from
 // the server streaming RPC. The revision parameter is the server-side
 // revision after the update; the caller should persist this value to
-// resume from on reconnect.
-type PolicyUpdateCallback func(updateType string, policy *PolicyInfo, revision int64, snapshotComplete bool)
+// resume from on reconnect. inventoryOnly is only meaningful on SNAPSHOT
+// updates and reports whether the node must not enforce its policies.
+type PolicyUpdateCallback func(updateType string, policy *PolicyInfo, revision int64, snapshotComplete, inventoryOnly bool)
 
 // SubscribePolicyUpdates opens a server-side streaming RPC and invokes
 // cb for every policy update. It blocks until the context is cancelled
@@ -262,6 +530,7 @@ func (c *Client) SubscribePolicyUpdates(ctx context.Context, lastKnownRevision i
 	stream, err := c.client.SubscribePolicyUpdates(ctx, &pb.SubscribePolicyUpdatesRequest{
 		ClientId:          c.clientID,
 		LastKnownRevision: lastKnownRevision,
+		AgentVersion:      c.agentVersion,
 	})
 	if err != nil {
 		return fmt.Errorf("SubscribePolicyUpdates RPC failed: %w", err)
@@ -273,60 +542,149 @@ func (c *Client) SubscribePolicyUpdates(ctx context.Context, lastKnownRevision i
 			return fmt.Errorf("stream recv error: %w", err)
 		}
 
-		var pi *PolicyInfo
-		if p := update.GetPolicy(); p != nil {
-			pi = &PolicyInfo{
-				ID:       p.GetId(),
-				Name:     p.GetName(),
-				Type:     p.GetType(),
-				Content:  p.GetContent(),
-				Version:  p.GetVersion(),
-				Priority: p.GetPriority(),
-			}
-			if kcp := p.GetKconfigPolicy(); kcp != nil {
-				pi.KConfigPolicy = kcp
-			}
-			if ffp := p.GetFirefoxPolicy(); ffp != nil {
-				pi.FirefoxPolicy = ffp
+		// Log requests are commands, not policy updates: they do not
+		// advance the revision and are handled outside the callback.
+		if update.GetType() == pb.PolicyUpdate_LOG_REQUEST {
+			if c.onLogRequest != nil {
+				c.onLogRequest(update.GetLogRequestId())
 			}
-			if chrp := p.GetChromePolicy(); chrp != nil {
-				pi.ChromePolicy = chrp
-			}
-			if dp := p.GetDconfPolicy(); dp != nil {
-				pi.DConfPolicy = dp
-			}
-			if pkp := p.GetPolkitPolicy(); pkp != nil {
-				pi.PolkitPolicy = pkp
+			continue
+		}
+
+		if update.GetType() == pb.PolicyUpdate_GOING_AWAY {
+			c.backoffSeconds.Store(update.GetReconnectAfterSeconds())
+			return ErrServerGoingAway
+		}
+
+		if update.GetType() == pb.PolicyUpdate_REMEDIATION_REQUEST {
+			if c.onRemediation != nil {
+				c.onRemediation(update.GetRemediationRequestId(), update.GetRemediationAction())
 			}
+			continue
 		}
 
-		cb(update.GetType().String(), pi, update.GetRevision(), update.GetSnapshotComplete())
+		var pi *PolicyInfo
+		if p := update.GetPolicy(); p != nil {
+			pi = policyInfoFromProto(p)
+		}
+
+		cb(update.GetType().String(), pi, update.GetRevision(), update.GetSnapshotComplete(), update.GetInventoryOnly())
+	}
+}
+
+// ListPolicies fetches every policy that currently applies to this agent
+// in one unary call. It is the polling counterpart of a full snapshot on
+// SubscribePolicyUpdates, used when streaming is not available.
+func (c *Client) ListPolicies(ctx context.Context) ([]*PolicyInfo, error) {
+	resp, err := c.client.ListPolicies(ctx, &pb.ListPoliciesRequest{ClientId: c.clientID})
+	if err != nil {
+		return nil, fmt.Errorf("ListPolicies RPC failed: %w", err)
+	}
+	policies := make([]*PolicyInfo, 0, len(resp.GetPolicies()))
+	for _, p := range resp.GetPolicies() {
+		policies = append(policies, policyInfoFromProto(p))
+	}
+	return policies, nil
+}
+
+// policyInfoFromProto converts a policy received from the server.
+func policyInfoFromProto(p *pb.Policy) *PolicyInfo {
+	return &PolicyInfo{
+		ID:       p.GetId(),
+		Name:     p.GetName(),
+		Type:     p.GetType(),
+		Content:  p.GetContent(),
+		Version:  p.GetVersion(),
+		Priority: p.GetPriority(),
+
+		KConfigPolicy: p.GetKconfigPolicy(),
+		FirefoxPolicy: p.GetFirefoxPolicy(),
+		ChromePolicy:  p.GetChromePolicy(),
+		DConfPolicy:   p.GetDconfPolicy(),
+		PolkitPolicy:  p.GetPolkitPolicy(),
+		SysctlPolicy:  p.GetSysctlPolicy(),
+
+		ContactLossAction: p.GetContactLossAction(),
+		ContactLossTTL:    time.Duration(p.GetContactLossTtlSeconds()) * time.Second,
+		ComplianceCheck:   p.GetComplianceCheck(),
+		Preconditions:     p.GetPreconditions(),
+		ScopeUsers:        p.GetScopeUsers(),
+		ContentHash:       p.GetContentHash(),
+
+		proto: p,
 	}
 }
 
 // ReportComplianceWithStatus sends a four-state compliance report to the server.
 // items may be nil for policy types that do not produce per-entry results.
 func (c *Client) ReportComplianceWithStatus(ctx context.Context, policyID string, status pb.ComplianceStatus, message string, items []*pb.ComplianceItemResult) error {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
+	return c.ReportComplianceWithFiles(ctx, policyID, status, message, items, nil)
+}
 
-	compliant := status == pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT
-	resp, err := c.client.ReportCompliance(ctx, &pb.ReportComplianceRequest{
-		ClientId:   c.clientID,
+// ReportComplianceWithFiles is like ReportComplianceWithStatus and also
+// reports the content hashes of the files written for the policy, so the
+// server can verify what was actually applied.
+func (c *Client) ReportComplianceWithFiles(ctx context.Context, policyID string, status pb.ComplianceStatus, message string, items []*pb.ComplianceItemResult, files []*pb.AppliedFile) error {
+	return c.reportCompliance(ctx, &pb.ReportComplianceRequest{
+		ClientId:     c.clientID,
+		PolicyId:     policyID,
+		Compliant:    status == pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT,
+		Message:      message,
+		ReportedAt:   timestamppb.Now(),
+		Status:       status,
+		Items:        items,
+		AppliedFiles: files,
+	})
+}
+
+// ComplianceBatch collects compliance reports to be sent to the server in
+// a single call, see NewComplianceBatch. It is not safe for concurrent use.
+type ComplianceBatch struct {
+	c       *Client
+	reports []*pb.ReportComplianceRequest
+}
+
+// NewComplianceBatch returns an empty compliance batch. Its report methods
+// mirror the Client ones but only record the report; Send delivers them.
+func (c *Client) NewComplianceBatch() *ComplianceBatch {
+	return &ComplianceBatch{c: c}
+}
+
+// ReportCompliance adds a compliance report to the batch.
+func (b *ComplianceBatch) ReportCompliance(policyID string, compliant bool, message string) {
+	b.reports = append(b.reports, &pb.ReportComplianceRequest{
+		ClientId:   b.c.clientID,
 		PolicyId:   policyID,
 		Compliant:  compliant,
 		Message:    message,
 		ReportedAt: timestamppb.Now(),
-		Status:     status,
-		Items:      items,
 	})
-	if err != nil {
-		return fmt.Errorf("ReportCompliance RPC failed: %w", err)
-	}
-	if !resp.GetSuccess() {
-		return fmt.Errorf("server rejected compliance report for policy %s", policyID)
+}
+
+// ReportComplianceWithFiles adds a four-state compliance report with the
+// policy's applied files to the batch.
+func (b *ComplianceBatch) ReportComplianceWithFiles(policyID string, status pb.ComplianceStatus, message string, items []*pb.ComplianceItemResult, files []*pb.AppliedFile) {
+	b.reports = append(b.reports, &pb.ReportComplianceRequest{
+		ClientId:     b.c.clientID,
+		PolicyId:     policyID,
+		Compliant:    status == pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT,
+		Message:      message,
+		ReportedAt:   timestamppb.Now(),
+		Status:       status,
+		Items:        items,
+		AppliedFiles: files,
+	})
+}
+
+// Send delivers the collected reports and empties the batch. Sending an
+// empty batch does nothing.
+func (b *ComplianceBatch) Send(ctx context.Context) error {
+	if len(b.reports) == 0 {
+		return nil
 	}
-	return nil
+	reports := b.reports
+	b.reports = nil
+	return b.c.reportComplianceBatch(ctx, reports)
 }
 
 // ReportSchemaCatalogue sends the GSettings schema catalogue to the server.
@@ -360,6 +718,120 @@ func (c *Client) ReportPolkitCatalogue(ctx context.Context, actions []*pb.Polkit
 	return nil
 }
 
+// ReportCheckResult sends the result of a policy's compliance check command
+// to the server.
+func (c *Client) ReportCheckResult(ctx context.Context, policyID string, status pb.ComplianceStatus, exitCode int32, message string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := c.client.ReportCheckResult(ctx, &pb.ReportCheckResultRequest{
+		ClientId:  c.clientID,
+		PolicyId:  policyID,
+		Status:    status,
+		ExitCode:  exitCode,
+		Message:   message,
+		CheckedAt: timestamppb.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("ReportCheckResult RPC failed: %w", err)
+	}
+	return nil
+}
+
+// SetAgentVersion sets the agent version reported by
+// SubscribePolicyUpdates, so the server can refuse outdated agents before
+// their first heartbeat.
+func (c *Client) SetAgentVersion(version string) {
+	c.agentVersion = version
+}
+
+// SetLogRequestHandler registers fn to be called with the request ID of
+// each LOG_REQUEST event received by SubscribePolicyUpdates. Without a
+// handler log requests are ignored.
+func (c *Client) SetLogRequestHandler(fn func(requestID string)) {
+	c.onLogRequest = fn
+}
+
+// UploadLogs sends the agent's recent log output to the server in answer to
+// the log request with the given ID.
+func (c *Client) UploadLogs(ctx context.Context, requestID string, content []byte, truncated bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := c.client.UploadLogs(ctx, &pb.UploadLogsRequest{
+		ClientId:   c.clientID,
+		RequestId:  requestID,
+		Content:    content,
+		Truncated:  truncated,
+		CapturedAt: timestamppb.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("UploadLogs RPC failed: %w", err)
+	}
+	return nil
+}
+
+// SetRemediationHandler registers fn to be called for each
+// REMEDIATION_REQUEST event received by SubscribePolicyUpdates. Without a
+// handler remediation requests are ignored.
+func (c *Client) SetRemediationHandler(fn func(requestID, action string)) {
+	c.onRemediation = fn
+}
+
+// ReportRemediationResult sends the outcome of a remediation action to the
+// server.
+func (c *Client) ReportRemediationResult(ctx context.Context, requestID, action string, success bool, exitCode int32, output, errMsg string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := c.client.ReportRemediationResult(ctx, &pb.ReportRemediationResultRequest{
+		ClientId:   c.clientID,
+		RequestId:  requestID,
+		Action:     action,
+		Success:    success,
+		ExitCode:   exitCode,
+		Output:     output,
+		Error:      errMsg,
+		FinishedAt: timestamppb.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("ReportRemediationResult RPC failed: %w", err)
+	}
+	return nil
+}
+
+// IsRejected reports whether err means the server refused this agent's
+// identity: its certificate was revoked (Unauthenticated) or its node
+// record no longer exists (NotFound). Transport errors are not rejections.
+func IsRejected(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch st.Code() {
+	case codes.Unauthenticated, codes.NotFound:
+		return true
+	}
+	return false
+}
+
+// IsStreamUnavailable reports whether err means the policy stream cannot
+// be used right now: the server does not implement it (Unimplemented, e.g.
+// an older server) or it could not be reached (Unavailable, e.g. a proxy
+// that breaks HTTP/2 streaming). The agent falls back to polling after
+// repeated such failures.
+func IsStreamUnavailable(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch st.Code() {
+	case codes.Unimplemented, codes.Unavailable:
+		return true
+	}
+	return false
+}
+
 // clampInt32 safely converts an int to int32, clamping to [0, MaxInt32].
 func clampInt32(v int) int32 {
 	if v > math.MaxInt32 {
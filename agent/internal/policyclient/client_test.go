@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policyclient
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("boom"), false},
+		{"unavailable", status.Error(codes.Unavailable, "connection refused"), false},
+		{"unauthenticated", status.Error(codes.Unauthenticated, "certificate has been revoked"), true},
+		{"not found", status.Error(codes.NotFound, "node not found"), true},
+		{"wrapped", fmt.Errorf("SubscribePolicyUpdates RPC failed: %w", status.Error(codes.Unauthenticated, "revoked")), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRejected(tt.err); got != tt.want {
+				t.Errorf("IsRejected(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsStreamUnavailable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("boom"), false},
+		{"unimplemented", status.Error(codes.Unimplemented, "unknown method SubscribePolicyUpdates"), true},
+		{"wrapped unavailable", fmt.Errorf("stream recv error: %w", status.Error(codes.Unavailable, "connection reset")), true},
+		{"unauthenticated", status.Error(codes.Unauthenticated, "revoked"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsStreamUnavailable(tt.err); got != tt.want {
+				t.Errorf("IsStreamUnavailable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoffHint(t *testing.T) {
+	c := &Client{}
+	if got := c.BackoffHint(); got != 0 {
+		t.Errorf("initial BackoffHint() = %v, want 0", got)
+	}
+	c.backoffSeconds.Store(90)
+	if got := c.BackoffHint(); got != 90*time.Second {
+		t.Errorf("BackoffHint() = %v, want 90s", got)
+	}
+}
+
+func TestPolicyInfo_SameContent(t *testing.T) {
+	base := func() *PolicyInfo {
+		return &PolicyInfo{
+			ID: "p1", Type: "Firefox", Priority: 10, ContentHash: "abc", Version: 1,
+			ScopeUsers:    []string{"alice"},
+			Preconditions: []*pb.Precondition{{Type: pb.PreconditionType_PRECONDITION_TYPE_FILE_EXISTS, Value: "/usr/bin/firefox"}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *PolicyInfo)
+		prev   func() *PolicyInfo
+		want   bool
+	}{
+		{"identical but new version", func(p *PolicyInfo) { p.Version = 2 }, base, true},
+		{"no previous policy", func(*PolicyInfo) {}, func() *PolicyInfo { return nil }, false},
+		{"content changed", func(p *PolicyInfo) { p.ContentHash = "def" }, base, false},
+		{"hash not sent", func(p *PolicyInfo) { p.ContentHash = "" }, func() *PolicyInfo { p := base(); p.ContentHash = ""; return p }, false},
+		{"priority changed", func(p *PolicyInfo) { p.Priority = 20 }, base, false},
+		{"scope changed", func(p *PolicyInfo) { p.ScopeUsers = nil }, base, false},
+		{"precondition changed", func(p *PolicyInfo) { p.Preconditions[0].Negate = true }, base, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := base()
+			tt.modify(p)
+			if got := p.SameContent(tt.prev()); got != tt.want {
+				t.Errorf("SameContent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policyclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// complianceQueueVersion is the schema version of the compliance queue
+// file. Queues written with another version are discarded.
+const complianceQueueVersion = 1
+
+// ComplianceQueueFile returns the path of the compliance report queue in
+// dataDir.
+func ComplianceQueueFile(dataDir string) string {
+	return filepath.Join(dataDir, "compliance-queue.json")
+}
+
+// complianceQueue holds compliance reports that could not be delivered
+// because the server was unreachable, persisted so they survive a restart.
+// Reports keep their original reported_at time.
+type complianceQueue struct {
+	path    string
+	max     int
+	reports []*pb.ReportComplianceRequest
+}
+
+// complianceQueueFile is the on-disk form of a complianceQueue. Reports are
+// stored in their protobuf JSON form.
+type complianceQueueFile struct {
+	Version int               `json:"version"`
+	Reports []json.RawMessage `json:"reports"`
+}
+
+// loadComplianceQueue reads the queue persisted at path, or returns an
+// empty queue when there is none. At most maxReports are kept.
+func loadComplianceQueue(path string, maxReports int) (*complianceQueue, error) {
+	q := &complianceQueue{path: path, max: maxReports}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is inside the agent's data directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return q, nil
+		}
+		return q, fmt.Errorf("failed to read compliance queue: %w", err)
+	}
+
+	var f complianceQueueFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return q, fmt.Errorf("failed to parse compliance queue: %w", err)
+	}
+	if f.Version != complianceQueueVersion {
+		return q, fmt.Errorf("compliance queue has unsupported schema version %d", f.Version)
+	}
+	for _, raw := range f.Reports {
+		req := &pb.ReportComplianceRequest{}
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(raw, req); err != nil {
+			return q, fmt.Errorf("failed to parse queued compliance report: %w", err)
+		}
+		q.reports = append(q.reports, req)
+	}
+	q.trim()
+	return q, nil
+}
+
+// add queues req and returns how many of the oldest reports were dropped
+// to stay within the cap. An already queued report with the same policy,
+// compliance and message is replaced, so a flapping policy occupies at
+// most one entry per distinct state.
+func (q *complianceQueue) add(req *pb.ReportComplianceRequest) int {
+	q.reports = append(q.withoutReport(func(r *pb.ReportComplianceRequest) bool {
+		return r.GetPolicyId() == req.GetPolicyId() &&
+			r.GetCompliant() == req.GetCompliant() &&
+			r.GetMessage() == req.GetMessage()
+	}), req)
+	return q.trim()
+}
+
+// supersede drops the queued reports for policyID, e.g. after a newer
+// report for it was delivered.
+func (q *complianceQueue) supersede(policyID string) {
+	q.reports = q.withoutReport(func(r *pb.ReportComplianceRequest) bool {
+		return r.GetPolicyId() == policyID
+	})
+}
+
+func (q *complianceQueue) withoutReport(match func(*pb.ReportComplianceRequest) bool) []*pb.ReportComplianceRequest {
+	kept := q.reports[:0]
+	for _, r := range q.reports {
+		if !match(r) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// trim drops the oldest reports beyond the cap and returns their number.
+func (q *complianceQueue) trim() int {
+	n := len(q.reports) - q.max
+	if n <= 0 {
+		return 0
+	}
+	q.reports = append([]*pb.ReportComplianceRequest(nil), q.reports[n:]...)
+	return n
+}
+
+// save atomically writes the queue to disk, readable by root only, or
+// removes the file when the queue is empty.
+func (q *complianceQueue) save() error {
+	if len(q.reports) == 0 {
+		if err := os.Remove(q.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove compliance queue: %w", err)
+		}
+		return nil
+	}
+
+	f := complianceQueueFile{
+		Version: complianceQueueVersion,
+		Reports: make([]json.RawMessage, 0, len(q.reports)),
+	}
+	for _, r := range q.reports {
+		data, err := protojson.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("failed to encode compliance report for policy %s: %w", r.GetPolicyId(), err)
+		}
+		f.Reports = append(f.Reports, data)
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("failed to encode compliance queue: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(q.path), ".compliance-queue-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write compliance queue: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write compliance queue: %w", err)
+	}
+	if err := os.Rename(tmpName, q.path); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to replace compliance queue: %w", err)
+	}
+	return nil
+}
+
+// queueableComplianceError reports whether a failed compliance report
+// should be queued for later: the server could not be reached or did not
+// answer in time, as opposed to rejecting the report.
+func queueableComplianceError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
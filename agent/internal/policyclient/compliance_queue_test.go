@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policyclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func queuedPolicies(q *complianceQueue) []string {
+	ids := make([]string, 0, len(q.reports))
+	for _, r := range q.reports {
+		ids = append(ids, fmt.Sprintf("%s:%v", r.GetPolicyId(), r.GetCompliant()))
+	}
+	return ids
+}
+
+func TestComplianceQueue_AddDedup(t *testing.T) {
+	q := &complianceQueue{max: 10}
+	q.add(&pb.ReportComplianceRequest{PolicyId: "p1", Compliant: false, Message: "drift"})
+	q.add(&pb.ReportComplianceRequest{PolicyId: "p1", Compliant: true})
+	q.add(&pb.ReportComplianceRequest{PolicyId: "p1", Compliant: false, Message: "drift"})
+
+	// The repeated report replaces the first one and moves to the end.
+	if got := fmt.Sprint(queuedPolicies(q)); got != "[p1:true p1:false]" {
+		t.Errorf("queue = %s, want [p1:true p1:false]", got)
+	}
+}
+
+func TestComplianceQueue_DropsOldest(t *testing.T) {
+	q := &complianceQueue{max: 2}
+	for _, id := range []string{"p1", "p2"} {
+		if dropped := q.add(&pb.ReportComplianceRequest{PolicyId: id}); dropped != 0 {
+			t.Fatalf("add(%s) dropped %d", id, dropped)
+		}
+	}
+	if dropped := q.add(&pb.ReportComplianceRequest{PolicyId: "p3"}); dropped != 1 {
+		t.Errorf("add(p3) dropped %d, want 1", dropped)
+	}
+	if got := fmt.Sprint(queuedPolicies(q)); got != "[p2:false p3:false]" {
+		t.Errorf("queue = %s, want [p2:false p3:false]", got)
+	}
+}
+
+func TestComplianceQueue_Supersede(t *testing.T) {
+	q := &complianceQueue{max: 10}
+	q.add(&pb.ReportComplianceRequest{PolicyId: "p1"})
+	q.add(&pb.ReportComplianceRequest{PolicyId: "p2"})
+	q.add(&pb.ReportComplianceRequest{PolicyId: "p1", Compliant: true})
+	q.supersede("p1")
+	if got := fmt.Sprint(queuedPolicies(q)); got != "[p2:false]" {
+		t.Errorf("queue = %s, want [p2:false]", got)
+	}
+}
+
+func TestComplianceQueue_SaveLoad(t *testing.T) {
+	path := ComplianceQueueFile(t.TempDir())
+	q := &complianceQueue{path: path, max: 10}
+	q.add(&pb.ReportComplianceRequest{
+		ClientId: "node-1",
+		PolicyId: "p1",
+		Message:  "drift",
+		Status:   pb.ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT,
+	})
+	q.add(&pb.ReportComplianceRequest{ClientId: "node-1", PolicyId: "p2", Compliant: true})
+	if err := q.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	// Loading with a smaller cap keeps the newest reports.
+	loaded, err := loadComplianceQueue(path, 1)
+	if err != nil {
+		t.Fatalf("loadComplianceQueue: %v", err)
+	}
+	if got := fmt.Sprint(queuedPolicies(loaded)); got != "[p2:true]" {
+		t.Errorf("loaded = %s, want [p2:true]", got)
+	}
+
+	loaded, err = loadComplianceQueue(path, 10)
+	if err != nil {
+		t.Fatalf("loadComplianceQueue: %v", err)
+	}
+	if len(loaded.reports) != 2 {
+		t.Fatalf("loaded %d reports, want 2", len(loaded.reports))
+	}
+	r := loaded.reports[0]
+	if r.GetClientId() != "node-1" || r.GetMessage() != "drift" || r.GetStatus() != pb.ComplianceStatus_COMPLIANCE_STATUS_NON_COMPLIANT {
+		t.Errorf("loaded report = %v", r)
+	}
+
+	// An empty queue removes the file.
+	loaded.reports = nil
+	if err := loaded.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("queue file still present: %v", err)
+	}
+	if q, err := loadComplianceQueue(path, 10); err != nil || len(q.reports) != 0 {
+		t.Errorf("loadComplianceQueue() = %d reports, %v; want empty", len(q.reports), err)
+	}
+}
+
+func TestComplianceQueue_LoadUnsupportedVersion(t *testing.T) {
+	path := ComplianceQueueFile(t.TempDir())
+	if err := os.WriteFile(path, []byte(`{"version":99,"reports":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	q, err := loadComplianceQueue(path, 10)
+	if err == nil {
+		t.Error("loadComplianceQueue() = nil error for unknown version")
+	}
+	if q == nil || len(q.reports) != 0 {
+		t.Errorf("want an empty queue alongside the error, got %v", q)
+	}
+}
+
+func TestQueueableComplianceError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"unavailable", status.Error(codes.Unavailable, "connection refused"), true},
+		{"deadline", status.Error(codes.DeadlineExceeded, "timeout"), true},
+		{"wrapped", fmt.Errorf("ReportCompliance RPC failed: %w", status.Error(codes.Unavailable, "down")), true},
+		{"invalid argument", status.Error(codes.InvalidArgument, "bad policy"), false},
+		{"rejected", errors.New("server rejected compliance report for policy p1"), false},
+	}
+	for _, tt := range tests {
+		if got := queueableComplianceError(tt.err); got != tt.want {
+			t.Errorf("%s: queueableComplianceError() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// fakeComplianceServer records the compliance RPCs it receives.
+type fakeComplianceServer struct {
+	pb.PolicyServiceClient
+	batchErr error // returned by ReportComplianceBatch
+	single   []string
+	batches  [][]string
+}
+
+func (f *fakeComplianceServer) ReportCompliance(_ context.Context, req *pb.ReportComplianceRequest, _ ...grpc.CallOption) (*pb.ReportComplianceResponse, error) {
+	f.single = append(f.single, req.GetPolicyId())
+	return &pb.ReportComplianceResponse{Success: true}, nil
+}
+
+func (f *fakeComplianceServer) ReportComplianceBatch(_ context.Context, req *pb.ReportComplianceBatchRequest, _ ...grpc.CallOption) (*pb.ReportComplianceBatchResponse, error) {
+	if f.batchErr != nil {
+		return nil, f.batchErr
+	}
+	var ids []string
+	for _, r := range req.GetReports() {
+		ids = append(ids, r.GetPolicyId())
+	}
+	f.batches = append(f.batches, ids)
+	return &pb.ReportComplianceBatchResponse{Success: true}, nil
+}
+
+func TestComplianceBatch_Send(t *testing.T) {
+	srv := &fakeComplianceServer{}
+	c := &Client{client: srv, clientID: "node-1"}
+	ctx := context.Background()
+
+	b := c.NewComplianceBatch()
+	if err := b.Send(ctx); err != nil || len(srv.batches) != 0 {
+		t.Fatalf("empty Send() = %v, %d calls; want nil, none", err, len(srv.batches))
+	}
+	b.ReportCompliance("p1", true, "Deployed")
+	b.ReportComplianceWithFiles("p2", pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR, "failed", nil, nil)
+	if err := b.Send(ctx); err != nil {
+		t.Fatalf("Send() = %v", err)
+	}
+	if got := fmt.Sprint(srv.batches); got != "[[p1 p2]]" || len(srv.single) != 0 {
+		t.Errorf("batches = %s, single = %v; want one batch of p1, p2", got, srv.single)
+	}
+	if err := b.Send(ctx); err != nil || len(srv.batches) != 1 {
+		t.Errorf("Send() after Send() = %v, %d batches; want the batch emptied", err, len(srv.batches))
+	}
+}
+
+func TestComplianceBatch_FallsBackToSingleReports(t *testing.T) {
+	srv := &fakeComplianceServer{batchErr: status.Error(codes.Unimplemented, "unknown method")}
+	c := &Client{client: srv, clientID: "node-1"}
+
+	b := c.NewComplianceBatch()
+	b.ReportCompliance("p1", true, "")
+	b.ReportCompliance("p2", true, "")
+	if err := b.Send(context.Background()); err != nil {
+		t.Fatalf("Send() = %v", err)
+	}
+	if got := fmt.Sprint(srv.single); got != "[p1 p2]" {
+		t.Errorf("single reports = %s, want [p1 p2]", got)
+	}
+	if !c.batchUnsupported.Load() {
+		t.Error("batch RPC should be remembered as unsupported")
+	}
+}
+
+func TestComplianceBatch_QueuesWhenUnavailable(t *testing.T) {
+	srv := &fakeComplianceServer{batchErr: status.Error(codes.Unavailable, "connection refused")}
+	c := &Client{client: srv, clientID: "node-1"}
+	if err := c.SetComplianceQueue(ComplianceQueueFile(t.TempDir()), 10); err != nil {
+		t.Fatal(err)
+	}
+
+	b := c.NewComplianceBatch()
+	b.ReportCompliance("p1", false, "drift")
+	b.ReportCompliance("p2", true, "")
+	if err := b.Send(context.Background()); err == nil {
+		t.Fatal("Send() = nil error while the server is unavailable")
+	}
+	if got := fmt.Sprint(queuedPolicies(c.complianceQueue)); got != "[p1:false p2:true]" {
+		t.Fatalf("queue = %s, want both reports", got)
+	}
+
+	srv.batchErr = nil
+	if err := c.FlushComplianceQueue(context.Background()); err != nil {
+		t.Fatalf("FlushComplianceQueue() = %v", err)
+	}
+	if len(c.complianceQueue.reports) != 0 || fmt.Sprint(srv.single) != "[p1 p2]" {
+		t.Errorf("after flush: queue = %v, delivered = %v", queuedPolicies(c.complianceQueue), srv.single)
+	}
+}
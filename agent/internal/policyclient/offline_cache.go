@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policyclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// offlineCacheVersion is the schema version of the offline policy cache.
+// Bump it whenever the file layout or the meaning of its content changes;
+// caches written with another version are ignored.
+const offlineCacheVersion = 1
+
+// OfflineCacheFile returns the path of the offline policy cache in dataDir.
+func OfflineCacheFile(dataDir string) string {
+	return filepath.Join(dataDir, "policy-cache.json")
+}
+
+// ErrOfflineCacheVersion is returned by LoadOfflineSnapshot for a cache
+// written with an unsupported schema version.
+var ErrOfflineCacheVersion = errors.New("offline policy cache has an unsupported schema version")
+
+// OfflineSnapshot is the last policy set the agent applied, persisted so
+// that it can be enforced on boot before the server is reachable.
+type OfflineSnapshot struct {
+	// Revision is the server revision of the policy set; 0 when unknown,
+	// e.g. after a poll.
+	Revision      int64
+	InventoryOnly bool
+	Policies      []*PolicyInfo
+}
+
+// offlineCacheFile is the on-disk form of an OfflineSnapshot. Policies are
+// stored in their protobuf JSON form so that every typed content survives.
+type offlineCacheFile struct {
+	Version       int               `json:"version"`
+	Revision      int64             `json:"revision"`
+	InventoryOnly bool              `json:"inventory_only"`
+	Policies      []json.RawMessage `json:"policies"`
+}
+
+// SaveOfflineSnapshot atomically writes snap to path, readable by root only.
+// Policies not received from the server (without their protobuf form) are
+// skipped.
+func SaveOfflineSnapshot(path string, snap *OfflineSnapshot) error {
+	f := offlineCacheFile{
+		Version:       offlineCacheVersion,
+		Revision:      snap.Revision,
+		InventoryOnly: snap.InventoryOnly,
+		Policies:      make([]json.RawMessage, 0, len(snap.Policies)),
+	}
+	for _, pi := range snap.Policies {
+		if pi.proto == nil {
+			continue
+		}
+		data, err := protojson.Marshal(pi.proto)
+		if err != nil {
+			return fmt.Errorf("failed to encode policy %s: %w", pi.ID, err)
+		}
+		f.Policies = append(f.Policies, data)
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("failed to encode offline policy cache: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".policy-cache-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write offline policy cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write offline policy cache: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to replace offline policy cache: %w", err)
+	}
+	return nil
+}
+
+// LoadOfflineSnapshot reads the snapshot saved at path. It returns nil
+// without an error when no cache exists, and ErrOfflineCacheVersion when
+// the cache was written with another schema version.
+func LoadOfflineSnapshot(path string) (*OfflineSnapshot, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is inside the agent's data directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read offline policy cache: %w", err)
+	}
+
+	var f offlineCacheFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse offline policy cache: %w", err)
+	}
+	if f.Version != offlineCacheVersion {
+		return nil, fmt.Errorf("%w: %d", ErrOfflineCacheVersion, f.Version)
+	}
+
+	snap := &OfflineSnapshot{
+		Revision:      f.Revision,
+		InventoryOnly: f.InventoryOnly,
+		Policies:      make([]*PolicyInfo, 0, len(f.Policies)),
+	}
+	for _, raw := range f.Policies {
+		var p pb.Policy
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(raw, &p); err != nil {
+			return nil, fmt.Errorf("failed to parse cached policy: %w", err)
+		}
+		snap.Policies = append(snap.Policies, policyInfoFromProto(&p))
+	}
+	return snap, nil
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policyclient
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+func TestOfflineSnapshot_RoundTrip(t *testing.T) {
+	path := OfflineCacheFile(t.TempDir())
+	policies := []*PolicyInfo{
+		policyInfoFromProto(&pb.Policy{
+			Id: "p1", Name: "swappiness", Type: "Sysctl", Version: 3, Priority: 20,
+			TypedContent: &pb.Policy_SysctlPolicy{SysctlPolicy: &pb.SysctlPolicy{
+				Entries: []*pb.SysctlEntry{{Key: "vm.swappiness", Value: "10"}},
+			}},
+		}),
+		// Built locally, not received from the server: skipped.
+		{ID: "local", Type: "Sysctl"},
+	}
+
+	if err := SaveOfflineSnapshot(path, &OfflineSnapshot{Revision: 42, InventoryOnly: true, Policies: policies}); err != nil {
+		t.Fatalf("SaveOfflineSnapshot: %v", err)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Errorf("cache mode = %v, %v; want 0600", fi.Mode().Perm(), err)
+	}
+
+	snap, err := LoadOfflineSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadOfflineSnapshot: %v", err)
+	}
+	if snap.Revision != 42 || !snap.InventoryOnly || len(snap.Policies) != 1 {
+		t.Fatalf("snapshot = rev %d, inventory-only %v, %d policies", snap.Revision, snap.InventoryOnly, len(snap.Policies))
+	}
+	got := snap.Policies[0]
+	if got.ID != "p1" || got.Version != 3 || got.Priority != 20 || got.SysctlPolicy.GetEntries()[0].GetValue() != "10" {
+		t.Errorf("cached policy = %+v", got)
+	}
+}
+
+func TestLoadOfflineSnapshot_Missing(t *testing.T) {
+	snap, err := LoadOfflineSnapshot(OfflineCacheFile(t.TempDir()))
+	if snap != nil || err != nil {
+		t.Errorf("LoadOfflineSnapshot = %v, %v; want nil, nil", snap, err)
+	}
+}
+
+func TestLoadOfflineSnapshot_SchemaVersionMismatch(t *testing.T) {
+	path := OfflineCacheFile(t.TempDir())
+	if err := os.WriteFile(path, []byte(`{"version":999,"revision":7,"policies":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadOfflineSnapshot(path); !errors.Is(err, ErrOfflineCacheVersion) {
+		t.Errorf("LoadOfflineSnapshot error = %v, want ErrOfflineCacheVersion", err)
+	}
+}
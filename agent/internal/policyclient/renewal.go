@@ -41,6 +41,45 @@ func CertExpiringSoon(certPath string, threshold time.Duration) (bool, error) {
 	return time.Until(cert.NotAfter) < threshold, nil
 }
 
+// CertCommonName returns the CN of the certificate at certPath, which is
+// the node name the agent was enrolled as.
+func CertCommonName(certPath string) (string, error) {
+	certPEM, err := os.ReadFile(certPath) //nolint:gosec // G304: path comes from trusted config
+	if err != nil {
+		return "", fmt.Errorf("failed to read cert %s: %w", certPath, err)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return "", fmt.Errorf("failed to decode cert PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse cert: %w", err)
+	}
+	return cert.Subject.CommonName, nil
+}
+
+// CertRenewalDue returns true once less than a third of the lifetime of the
+// certificate at certPath remains. Because the threshold scales with the
+// lifetime, it suits both the default 90-day certificates (renewed with 30
+// days left) and the short-lived certificates some deployments configure.
+func CertRenewalDue(certPath string) (bool, error) {
+	certPEM, err := os.ReadFile(certPath) //nolint:gosec // G304: path comes from trusted config
+	if err != nil {
+		return false, fmt.Errorf("failed to read cert %s: %w", certPath, err)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return false, fmt.Errorf("failed to decode cert PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse cert: %w", err)
+	}
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return time.Until(cert.NotAfter) < lifetime/3, nil
+}
+
 // RenewCertificate performs in-place certificate renewal:
 //  1. Generates a new ECDSA P-256 key pair (FIPS 140-3 / BSI TR-02102-1 approved).
 //  2. Creates a CSR with the same CN as the existing cert.
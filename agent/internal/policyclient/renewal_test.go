@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package policyclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestCert writes a self-signed certificate valid from notBefore to
+// notAfter and returns its path.
+func writeTestCert(t *testing.T, notBefore, notAfter time.Time) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-agent"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatalf("failed to create cert: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "agent.crt")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCertRenewalDue(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		want      bool
+	}{
+		{"fresh 90-day cert", now.Add(-day), now.Add(89 * day), false},
+		{"90-day cert with 29 days left", now.Add(-61 * day), now.Add(29 * day), true},
+		{"fresh 7-day cert", now.Add(-time.Hour), now.Add(7*day - time.Hour), false},
+		{"7-day cert with 2 days left", now.Add(-5 * day), now.Add(2 * day), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CertRenewalDue(writeTestCert(t, tt.notBefore, tt.notAfter))
+			if err != nil {
+				t.Fatalf("CertRenewalDue() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CertRenewalDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCertCommonName(t *testing.T) {
+	now := time.Now()
+	cn, err := CertCommonName(writeTestCert(t, now.Add(-time.Hour), now.Add(time.Hour)))
+	if err != nil || cn != "test-agent" {
+		t.Errorf("CertCommonName = %q, %v; want test-agent", cn, err)
+	}
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package precondition evaluates policy preconditions against the node's
+// live local state. Unlike server-side node filtering, preconditions are
+// checked on the agent every time a policy is applied, so a policy such as
+// "only if Plasma is the active session" follows the machine's current
+// state rather than the last reported inventory.
+package precondition
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+// desktopCommands maps lower-cased desktop names to the shell binary that
+// indicates the desktop is installed.
+var desktopCommands = map[string]string{
+	"kde":        "plasmashell",
+	"plasma":     "plasmashell",
+	"kde plasma": "plasmashell",
+	"gnome":      "gnome-shell",
+}
+
+// env is the local state preconditions are evaluated against.
+type env struct {
+	stat        func(name string) (os.FileInfo, error)
+	lookPath    func(file string) (string, error)
+	sessionsDir string
+}
+
+var defaultEnv = env{
+	stat:        os.Stat,
+	lookPath:    exec.LookPath,
+	sessionsDir: "/run/systemd/sessions",
+}
+
+// eval reports whether a single precondition holds, ignoring Negate, along
+// with a short description of the condition for messages.
+func (e env) eval(pc *pb.Precondition) (bool, string) {
+	v := pc.GetValue()
+	switch pc.GetType() {
+	case pb.PreconditionType_PRECONDITION_TYPE_FILE_EXISTS:
+		_, err := e.stat(v)
+		return err == nil, fmt.Sprintf("file %s exists", v)
+	case pb.PreconditionType_PRECONDITION_TYPE_COMMAND_EXISTS:
+		_, err := e.lookPath(v)
+		return err == nil, fmt.Sprintf("command %s exists", v)
+	case pb.PreconditionType_PRECONDITION_TYPE_DESKTOP_INSTALLED:
+		cmd, ok := desktopCommands[strings.ToLower(v)]
+		if !ok {
+			return false, fmt.Sprintf("desktop %s installed (unknown desktop)", v)
+		}
+		_, err := e.lookPath(cmd)
+		return err == nil, fmt.Sprintf("desktop %s installed", v)
+	case pb.PreconditionType_PRECONDITION_TYPE_DESKTOP_SESSION:
+		return e.desktopSessionActive(v), fmt.Sprintf("%s session active", v)
+	default:
+		return false, fmt.Sprintf("unsupported precondition type %s", pc.GetType())
+	}
+}
+
+// desktopSessionActive reports whether an active graphical logind session
+// runs the named desktop. The session's DESKTOP value may be a
+// colon-separated list such as "ubuntu:GNOME".
+func (e env) desktopSessionActive(name string) bool {
+	entries, err := os.ReadDir(e.sessionsDir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(e.sessionsDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		props := parseSessionFile(string(data))
+		if (props["TYPE"] != "x11" && props["TYPE"] != "wayland") || props["ACTIVE"] != "1" {
+			continue
+		}
+		for _, d := range strings.Split(props["DESKTOP"], ":") {
+			if strings.EqualFold(d, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseSessionFile parses KEY=VALUE lines of a logind session file.
+func parseSessionFile(data string) map[string]string {
+	props := make(map[string]string)
+	for _, line := range strings.Split(data, "\n") {
+		if k, v, ok := strings.Cut(line, "="); ok {
+			props[k] = v
+		}
+	}
+	return props
+}
+
+// check evaluates all preconditions and returns whether they hold; when
+// they do not, reason describes the first unmet one.
+func (e env) check(pcs []*pb.Precondition) (met bool, reason string) {
+	for _, pc := range pcs {
+		ok, desc := e.eval(pc)
+		if pc.GetNegate() {
+			ok = !ok
+			desc = "not " + desc
+		}
+		if !ok {
+			return false, desc
+		}
+	}
+	return true, ""
+}
+
+// Tracker holds the preconditions of all cached policies. It is safe for
+// concurrent use.
+type Tracker struct {
+	mu      sync.Mutex
+	env     env
+	conds   map[string][]*pb.Precondition
+	staging map[string][]*pb.Precondition
+	// applied records the result last reported by Met or Changed.
+	applied map[string]bool
+}
+
+// New returns an empty Tracker that evaluates against the local system.
+func New() *Tracker {
+	return newTracker(defaultEnv)
+}
+
+func newTracker(e env) *Tracker {
+	return &Tracker{
+		env:     e,
+		conds:   make(map[string][]*pb.Precondition),
+		applied: make(map[string]bool),
+	}
+}
+
+// Set records the preconditions of a policy. Policies without
+// preconditions are not stored since they are always met.
+func (t *Tracker) Set(id string, pcs []*pb.Precondition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(pcs) == 0 {
+		delete(t.conds, id)
+		delete(t.applied, id)
+		return
+	}
+	t.conds[id] = pcs
+}
+
+// Delete forgets the preconditions of a policy.
+func (t *Tracker) Delete(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.conds, id)
+	delete(t.applied, id)
+}
+
+// Stage records preconditions received as part of a SNAPSHOT. Staged
+// preconditions replace the current set when CommitSnapshot is called.
+func (t *Tracker) Stage(id string, pcs []*pb.Precondition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.staging == nil {
+		t.staging = make(map[string][]*pb.Precondition)
+	}
+	if len(pcs) > 0 {
+		t.staging[id] = pcs
+	}
+}
+
+// CommitSnapshot replaces the current preconditions with the staged ones.
+func (t *Tracker) CommitSnapshot() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.conds = t.staging
+	if t.conds == nil {
+		t.conds = make(map[string][]*pb.Precondition)
+	}
+	t.staging = nil
+	for id := range t.applied {
+		if _, ok := t.conds[id]; !ok {
+			delete(t.applied, id)
+		}
+	}
+}
+
+// Met evaluates the policy's preconditions now. When they are unmet,
+// reason names the first failing condition.
+func (t *Tracker) Met(id string) (met bool, reason string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pcs, ok := t.conds[id]
+	if !ok {
+		return true, ""
+	}
+	met, reason = t.env.check(pcs)
+	t.applied[id] = met
+	return met, reason
+}
+
+// Changed re-evaluates all preconditions and reports whether any policy's
+// result differs from the one last seen by Met or Changed, in which case
+// the caller must re-sync.
+func (t *Tracker) Changed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	changed := false
+	for id, pcs := range t.conds {
+		met, _ := t.env.check(pcs)
+		if prev, ok := t.applied[id]; ok && prev != met {
+			changed = true
+		}
+		t.applied[id] = met
+	}
+	return changed
+}
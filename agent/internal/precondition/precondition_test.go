@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package precondition
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+func testEnv(t *testing.T, files, commands map[string]bool) env {
+	t.Helper()
+	return env{
+		stat: func(name string) (os.FileInfo, error) {
+			if files[name] {
+				return nil, nil
+			}
+			return nil, os.ErrNotExist
+		},
+		lookPath: func(file string) (string, error) {
+			if commands[file] {
+				return "/usr/bin/" + file, nil
+			}
+			return "", errors.New("not found")
+		},
+		sessionsDir: t.TempDir(),
+	}
+}
+
+func writeSession(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnvCheck(t *testing.T) {
+	e := testEnv(t, map[string]bool{"/etc/kdeglobals": true}, map[string]bool{"plasmashell": true})
+	writeSession(t, e.sessionsDir, "2", "UID=1000\nTYPE=wayland\nACTIVE=1\nDESKTOP=KDE\n")
+	writeSession(t, e.sessionsDir, "3", "UID=1001\nTYPE=x11\nACTIVE=0\nDESKTOP=ubuntu:GNOME\n")
+
+	tests := []struct {
+		name       string
+		pc         *pb.Precondition
+		want       bool
+		wantReason string
+	}{
+		{"file exists", &pb.Precondition{Type: pb.PreconditionType_PRECONDITION_TYPE_FILE_EXISTS, Value: "/etc/kdeglobals"}, true, ""},
+		{"file missing", &pb.Precondition{Type: pb.PreconditionType_PRECONDITION_TYPE_FILE_EXISTS, Value: "/etc/missing"}, false, "file /etc/missing exists"},
+		{"negated file", &pb.Precondition{Type: pb.PreconditionType_PRECONDITION_TYPE_FILE_EXISTS, Value: "/etc/kdeglobals", Negate: true}, false, "not file /etc/kdeglobals exists"},
+		{"command exists", &pb.Precondition{Type: pb.PreconditionType_PRECONDITION_TYPE_COMMAND_EXISTS, Value: "plasmashell"}, true, ""},
+		{"desktop installed", &pb.Precondition{Type: pb.PreconditionType_PRECONDITION_TYPE_DESKTOP_INSTALLED, Value: "KDE"}, true, ""},
+		{"desktop not installed", &pb.Precondition{Type: pb.PreconditionType_PRECONDITION_TYPE_DESKTOP_INSTALLED, Value: "GNOME"}, false, "desktop GNOME installed"},
+		{"active session", &pb.Precondition{Type: pb.PreconditionType_PRECONDITION_TYPE_DESKTOP_SESSION, Value: "kde"}, true, ""},
+		{"inactive session", &pb.Precondition{Type: pb.PreconditionType_PRECONDITION_TYPE_DESKTOP_SESSION, Value: "GNOME"}, false, "GNOME session active"},
+		{"unspecified", &pb.Precondition{Value: "x"}, false, "unsupported precondition type PRECONDITION_TYPE_UNSPECIFIED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := e.check([]*pb.Precondition{tt.pc})
+			if got != tt.want || reason != tt.wantReason {
+				t.Errorf("check = (%v, %q), want (%v, %q)", got, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestTrackerMetAndChanged(t *testing.T) {
+	files := map[string]bool{}
+	tr := newTracker(testEnv(t, files, nil))
+	pcs := []*pb.Precondition{{Type: pb.PreconditionType_PRECONDITION_TYPE_FILE_EXISTS, Value: "/etc/flag"}}
+
+	if met, _ := tr.Met("none"); !met {
+		t.Fatal("a policy without preconditions must be met")
+	}
+
+	tr.Set("p1", pcs)
+	if met, _ := tr.Met("p1"); met {
+		t.Fatal("expected p1 unmet")
+	}
+	if tr.Changed() {
+		t.Fatal("no local change yet")
+	}
+
+	files["/etc/flag"] = true
+	if !tr.Changed() {
+		t.Fatal("expected change once the file appears")
+	}
+	if tr.Changed() {
+		t.Fatal("change must only be reported once")
+	}
+
+	tr.Stage("p2", pcs)
+	tr.CommitSnapshot()
+	if met, _ := tr.Met("p1"); !met {
+		t.Fatal("p1 dropped by the snapshot must be met")
+	}
+	if met, _ := tr.Met("p2"); !met {
+		t.Fatal("expected p2 met")
+	}
+}
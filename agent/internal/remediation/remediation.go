@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package remediation runs the one-time remediation actions requested by
+// the server. The server only sends an action name; the command it maps to
+// is compiled into the agent (see server/pkg/remediation), so no command
+// line ever travels over the wire. Actions run without a shell, with a
+// fixed environment and a timeout, and only when the agent has opted in.
+package remediation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/VuteTech/Bor/agent/internal/cmdoutput"
+	"github.com/VuteTech/Bor/agent/internal/config"
+	"github.com/VuteTech/Bor/agent/internal/sandboxexec"
+	"github.com/VuteTech/Bor/server/pkg/remediation"
+)
+
+// maxOutput bounds the action output reported back to the server.
+const maxOutput = 8192
+
+// Result is the outcome of a single action.
+type Result struct {
+	Success  bool
+	ExitCode int32 // -1 when the command did not exit normally
+	Output   string
+	Error    string
+}
+
+// Runner executes remediation actions according to the agent configuration.
+type Runner struct {
+	enabled bool
+	allowed map[string]struct{} // empty allows every predefined action
+	timeout time.Duration
+	lookup  func(name string) (remediation.Action, bool)
+}
+
+// NewRunner creates a Runner from the agent's remediation settings.
+func NewRunner(cfg config.RemediationConfig) *Runner {
+	allowed := make(map[string]struct{}, len(cfg.AllowedActions))
+	for _, a := range cfg.AllowedActions {
+		allowed[a] = struct{}{}
+	}
+	return &Runner{
+		enabled: cfg.Enabled,
+		allowed: allowed,
+		timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+		lookup:  remediation.Lookup,
+	}
+}
+
+// Run executes the named action.
+func (r *Runner) Run(ctx context.Context, name string) Result {
+	if !r.enabled {
+		return refused("remediation is disabled on this agent")
+	}
+	action, ok := r.lookup(name)
+	if !ok {
+		return refused(fmt.Sprintf("unknown remediation action %q", name))
+	}
+	if len(r.allowed) > 0 {
+		if _, ok := r.allowed[name]; !ok {
+			return refused(fmt.Sprintf("remediation action %s is not allowed on this agent", name))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	out := cmdoutput.New(maxOutput)
+	cmd := sandboxexec.Command(ctx, action.Argv[0], action.Argv[1:]...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	err := cmd.Run()
+	output := out.Text()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return Result{ExitCode: -1, Output: output, Error: fmt.Sprintf("action timed out after %v", r.timeout)}
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return Result{Success: true, Output: output}
+	case errors.As(err, &exitErr) && exitErr.ExitCode() >= 0:
+		return Result{
+			ExitCode: int32(exitErr.ExitCode()), //nolint:gosec // exit codes fit in int32
+			Output:   output,
+		}
+	default:
+		return Result{ExitCode: -1, Output: output, Error: fmt.Sprintf("failed to run action: %v", err)}
+	}
+}
+
+func refused(msg string) Result {
+	return Result{ExitCode: -1, Error: msg}
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package remediation
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/VuteTech/Bor/agent/internal/config"
+	"github.com/VuteTech/Bor/server/pkg/remediation"
+)
+
+func testRunner(t *testing.T, cfg config.RemediationConfig, actions map[string][]string) *Runner {
+	t.Helper()
+	if cfg.TimeoutSeconds == 0 {
+		cfg.TimeoutSeconds = 5
+	}
+	r := NewRunner(cfg)
+	r.lookup = func(name string) (remediation.Action, bool) {
+		argv, ok := actions[name]
+		if !ok {
+			return remediation.Action{}, false
+		}
+		path, err := exec.LookPath(argv[0])
+		if err != nil {
+			t.Skipf("%s not available: %v", argv[0], err)
+		}
+		return remediation.Action{Name: name, Argv: append([]string{path}, argv[1:]...)}, true
+	}
+	return r
+}
+
+func TestRunRefused(t *testing.T) {
+	actions := map[string][]string{"ok": {"true"}, "other": {"true"}}
+
+	tests := []struct {
+		name    string
+		cfg     config.RemediationConfig
+		action  string
+		wantErr string
+	}{
+		{"disabled", config.RemediationConfig{}, "ok", "disabled"},
+		{"unknown", config.RemediationConfig{Enabled: true}, "dconf update; reboot", "unknown remediation action"},
+		{"not allowed", config.RemediationConfig{Enabled: true, AllowedActions: []string{"other"}}, "ok", "not allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := testRunner(t, tt.cfg, actions).Run(context.Background(), tt.action)
+			if res.Success || res.ExitCode != -1 || !strings.Contains(res.Error, tt.wantErr) {
+				t.Errorf("Run = %+v, want refusal containing %q", res, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunExitStatus(t *testing.T) {
+	r := testRunner(t, config.RemediationConfig{Enabled: true}, map[string][]string{
+		"ok":   {"echo", "done"},
+		"fail": {"false"},
+	})
+
+	res := r.Run(context.Background(), "ok")
+	if !res.Success || res.ExitCode != 0 || res.Output != "done" || res.Error != "" {
+		t.Errorf("ok: got %+v", res)
+	}
+
+	res = r.Run(context.Background(), "fail")
+	if res.Success || res.ExitCode != 1 || res.Error != "" {
+		t.Errorf("fail: got %+v", res)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	r := testRunner(t, config.RemediationConfig{Enabled: true, TimeoutSeconds: 1}, map[string][]string{
+		"slow": {"sleep", "10"},
+	})
+
+	res := r.Run(context.Background(), "slow")
+	if res.Success || !strings.Contains(res.Error, "timed out") {
+		t.Errorf("slow: got %+v", res)
+	}
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package sandboxexec builds the commands the agent runs on behalf of
+// policies and administrators (compliance checks, remediation actions and
+// content transform hooks). They run without a shell, from /, with a fixed
+// environment, and in their own process group, which is killed as a whole
+// when the command's context is done.
+package sandboxexec
+
+import (
+	"context"
+	"os/exec"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// env is the environment every command starts with.
+var env = []string{"PATH=/usr/sbin:/usr/bin:/sbin:/bin", "LANG=C"}
+
+// waitDelay bounds how long Wait waits for output after the process group
+// is killed, e.g. when a grandchild holds the pipes open.
+const waitDelay = time.Second
+
+// Command returns the command running name with args under ctx. Callers
+// may append to its Env and set further SysProcAttr fields, such as a
+// Credential, before starting it.
+func Command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...) //nolint:gosec // G204: callers restrict name to configured or compiled-in commands
+	cmd.Env = slices.Clone(env)
+	cmd.Dir = "/"
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		// Kill the whole process group so children cannot outlive the command.
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = waitDelay
+	return cmd
+}
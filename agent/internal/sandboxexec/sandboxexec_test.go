@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package sandboxexec
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommandEnvironment(t *testing.T) {
+	t.Setenv("BOR_SANDBOX_SECRET", "leak")
+	out, err := Command(context.Background(), "/bin/sh", "-c", `echo "x${BOR_SANDBOX_SECRET}x $PATH $(pwd)"`).Output()
+	if err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+	if got, want := strings.TrimSpace(string(out)), "xx /usr/sbin:/usr/bin:/sbin:/bin /"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestCommandKillsProcessGroup(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The background sleep keeps stdout open; only killing the process
+	// group lets Output return before it exits.
+	start := time.Now()
+	_, err := Command(ctx, "/bin/sh", "-c", "sleep 10 & wait").Output()
+	if err == nil {
+		t.Fatal("Output() error = nil, want the command to be killed")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("command ran for %v after its context expired", elapsed)
+	}
+}
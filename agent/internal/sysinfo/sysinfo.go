@@ -7,12 +7,21 @@ package sysinfo
 
 import (
 	"bufio"
+	"context"
+	"io"
 	"net"
 	"os"
 	"os/exec"
+	"runtime"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 )
 
+// probeTimeout bounds each "--version" probe of an installed application.
+const probeTimeout = 5 * time.Second
+
 // OSInfo holds detected operating system information.
 type OSInfo struct {
 	// Name is the distribution name.
@@ -45,18 +54,124 @@ type Info struct {
 	OS          OSInfo
 	DesktopEnvs []DesktopInfo
 	MachineID   string
+	// FirefoxVersion and ChromeVersion are the installed browser versions,
+	// e.g. "128.0.3"; empty when the browser is not installed.
+	FirefoxVersion string
+	ChromeVersion  string
+	// KernelVersion is the running kernel release, e.g. "6.9.7-200.fc40.x86_64".
+	KernelVersion string
+	Hardware      HardwareInfo
+}
+
+// HardwareInfo holds hardware and resource facts. Each is collected
+// independently; a zero value means it could not be determined.
+type HardwareInfo struct {
+	MemoryTotal     uint64 // bytes
+	MemoryAvailable uint64 // bytes
+	CPUModel        string
+	CPUCores        int    // logical CPUs
+	RootDiskTotal   uint64 // bytes
+	RootDiskFree    uint64 // bytes available to unprivileged users
+	BootTime        time.Time
 }
 
 // Collect gathers system metadata. Individual failures are silently skipped;
 // callers always receive a partial (potentially empty) result.
 func Collect() *Info {
 	return &Info{
-		FQDN:        collectFQDN(),
-		IPAddress:   collectIPAddress(),
-		OS:          collectOS(),
-		DesktopEnvs: collectDesktopEnvs(),
-		MachineID:   collectMachineID(),
+		FQDN:           collectFQDN(),
+		IPAddress:      collectIPAddress(),
+		OS:             collectOS(),
+		DesktopEnvs:    collectDesktopEnvs(),
+		MachineID:      collectMachineID(),
+		FirefoxVersion: probeVersion("firefox", "firefox-esr"),
+		ChromeVersion:  probeVersion("google-chrome", "google-chrome-stable", "chromium", "chromium-browser"),
+		KernelVersion:  collectKernelVersion(),
+		Hardware:       collectHardware(),
+	}
+}
+
+func collectHardware() HardwareInfo {
+	var hw HardwareInfo
+	if f, err := os.Open("/proc/meminfo"); err == nil {
+		hw.MemoryTotal, hw.MemoryAvailable = parseMeminfo(f)
+		_ = f.Close()
+	}
+	if f, err := os.Open("/proc/cpuinfo"); err == nil {
+		hw.CPUModel, hw.CPUCores = parseCPUInfo(f)
+		_ = f.Close()
+	}
+	if hw.CPUCores == 0 {
+		hw.CPUCores = runtime.NumCPU()
+	}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs("/", &st); err == nil {
+		hw.RootDiskTotal = st.Blocks * uint64(st.Bsize) //nolint:gosec // G115: block size is positive
+		hw.RootDiskFree = st.Bavail * uint64(st.Bsize)  //nolint:gosec // G115: block size is positive
+	}
+	if f, err := os.Open("/proc/stat"); err == nil {
+		hw.BootTime = parseBootTime(f)
+		_ = f.Close()
+	}
+	return hw
+}
+
+// parseMeminfo returns MemTotal and MemAvailable from /proc/meminfo, in
+// bytes.
+func parseMeminfo(r io.Reader) (total, available uint64) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// "MemTotal:       16314916 kB"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "MemTotal:":
+			total = kb * 1024
+		case "MemAvailable:":
+			available = kb * 1024
+		}
+	}
+	return total, available
+}
+
+// parseCPUInfo returns the CPU model name and the number of logical CPUs
+// listed in /proc/cpuinfo.
+func parseCPUInfo(r io.Reader) (model string, cores int) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "processor":
+			cores++
+		case "model name":
+			if model == "" {
+				model = strings.TrimSpace(value)
+			}
+		}
 	}
+	return model, cores
+}
+
+// parseBootTime returns the boot time from the btime line of /proc/stat.
+func parseBootTime(r io.Reader) time.Time {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if v, ok := strings.CutPrefix(scanner.Text(), "btime "); ok {
+			if sec, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				return time.Unix(sec, 0)
+			}
+		}
+	}
+	return time.Time{}
 }
 
 func collectFQDN() string {
@@ -112,6 +227,48 @@ func collectMachineID() string {
 	return strings.TrimSpace(string(data))
 }
 
+func collectKernelVersion() string {
+	data, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// probeVersion runs "<name> --version" for the first of names found in
+// PATH and returns the version it prints, or "" if none is installed.
+func probeVersion(names ...string) string {
+	for _, name := range names {
+		path, err := exec.LookPath(name)
+		if err != nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+		out, err := exec.CommandContext(ctx, path, "--version").Output() //nolint:gosec // G204: path is one of the fixed names above
+		cancel()
+		if err != nil {
+			continue
+		}
+		if ver := parseVersionOutput(string(out)); ver != "" {
+			return ver
+		}
+	}
+	return ""
+}
+
+// parseVersionOutput extracts the version from "--version" output such as
+// "Mozilla Firefox 128.0.3" or "Chromium 126.0.6478.126 Fedora Project":
+// the first field of the first line that starts with a digit.
+func parseVersionOutput(out string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	for _, f := range strings.Fields(line) {
+		if f[0] >= '0' && f[0] <= '9' {
+			return strings.TrimSuffix(f, ",")
+		}
+	}
+	return ""
+}
+
 func collectOS() OSInfo {
 	fields, err := parseOSRelease("/etc/os-release")
 	if err != nil {
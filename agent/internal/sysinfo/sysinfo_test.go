@@ -7,7 +7,9 @@ package sysinfo
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestClassifyOS_Fedora(t *testing.T) {
@@ -187,3 +189,55 @@ func TestCollectIPAddress_NoPanic(_ *testing.T) {
 	ip := collectIPAddress()
 	_ = ip
 }
+
+func TestParseVersionOutput(t *testing.T) {
+	tests := []struct {
+		out  string
+		want string
+	}{
+		{"Mozilla Firefox 128.0.3\n", "128.0.3"},
+		{"Mozilla Firefox 115.12.0esr", "115.12.0esr"},
+		{"Google Chrome 126.0.6478.126 \n", "126.0.6478.126"},
+		{"Chromium 126.0.6478.126 Fedora Project", "126.0.6478.126"},
+		{"Chromium 125.0.6422.141 built on Debian 12.5, running on Debian 12.5", "125.0.6422.141"},
+		{"no version here", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := parseVersionOutput(tt.out); got != tt.want {
+			t.Errorf("parseVersionOutput(%q) = %q, want %q", tt.out, got, tt.want)
+		}
+	}
+}
+
+func TestParseMeminfo(t *testing.T) {
+	in := "MemTotal:       16314916 kB\nMemFree:         1056360 kB\nMemAvailable:    9281232 kB\nBuffers:          123 kB\n"
+	total, available := parseMeminfo(strings.NewReader(in))
+	if total != 16314916*1024 || available != 9281232*1024 {
+		t.Errorf("parseMeminfo = %d, %d", total, available)
+	}
+}
+
+func TestParseCPUInfo(t *testing.T) {
+	in := `processor	: 0
+vendor_id	: GenuineIntel
+model name	: Intel(R) Core(TM) i7-1165G7 @ 2.80GHz
+
+processor	: 1
+model name	: Intel(R) Core(TM) i7-1165G7 @ 2.80GHz
+`
+	model, cores := parseCPUInfo(strings.NewReader(in))
+	if model != "Intel(R) Core(TM) i7-1165G7 @ 2.80GHz" || cores != 2 {
+		t.Errorf("parseCPUInfo = %q, %d", model, cores)
+	}
+}
+
+func TestParseBootTime(t *testing.T) {
+	in := "cpu  1 2 3 4\nintr 12345\nbtime 1760600000\nprocesses 42\n"
+	if got := parseBootTime(strings.NewReader(in)); !got.Equal(time.Unix(1760600000, 0)) {
+		t.Errorf("parseBootTime = %v", got)
+	}
+	if got := parseBootTime(strings.NewReader("cpu 1 2 3\n")); !got.IsZero() {
+		t.Errorf("parseBootTime without btime = %v, want zero", got)
+	}
+}
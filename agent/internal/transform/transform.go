@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package transform runs the optional site-specific content transform hook.
+// The hook is a local executable configured on the agent; merged policy
+// content is written to its stdin and whatever it prints on stdout is
+// written to the target instead. The policy type and target path are passed
+// in BOR_POLICY_TYPE and BOR_TARGET_PATH. The hook runs without a shell,
+// with a fixed environment and a timeout. A non-zero exit status, a timeout
+// or empty output is a failure, and the caller must then leave the target
+// untouched.
+package transform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/VuteTech/Bor/agent/internal/cmdoutput"
+	"github.com/VuteTech/Bor/agent/internal/config"
+	"github.com/VuteTech/Bor/agent/internal/sandboxexec"
+)
+
+// maxOutput bounds the transformed content a hook may produce.
+const maxOutput = 16 << 20
+
+// maxStderr is the number of stderr bytes kept for the error message.
+const maxStderr = 1024
+
+// Hook pipes policy content through the configured executable.
+type Hook struct {
+	command string
+	types   map[string]struct{} // empty applies to every type
+	timeout time.Duration
+}
+
+// New returns the hook described by cfg, or nil when no command is
+// configured.
+func New(cfg config.ContentTransformConfig) *Hook {
+	if cfg.Command == "" {
+		return nil
+	}
+	types := make(map[string]struct{}, len(cfg.Types))
+	for _, t := range cfg.Types {
+		types[t] = struct{}{}
+	}
+	return &Hook{
+		command: cfg.Command,
+		types:   types,
+		timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+	}
+}
+
+// Applies reports whether content of policyType is piped through the hook.
+func (h *Hook) Applies(policyType string) bool {
+	if len(h.types) == 0 {
+		return true
+	}
+	_, ok := h.types[policyType]
+	return ok
+}
+
+// Transform returns data as rewritten by the hook for the given policy
+// type and target path. Types the hook does not apply to are returned
+// unchanged.
+func (h *Hook) Transform(policyType, target string, data []byte) ([]byte, error) {
+	if !h.Applies(policyType) {
+		return data, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	stdout := cmdoutput.New(maxOutput)
+	stderr := cmdoutput.New(maxStderr)
+	cmd := sandboxexec.Command(ctx, h.command)
+	cmd.Env = append(cmd.Env,
+		"BOR_POLICY_TYPE="+policyType,
+		"BOR_TARGET_PATH="+target)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("content transform timed out after %v", h.timeout)
+	}
+	if err != nil {
+		msg := stderr.Text()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && msg != "" {
+			return nil, fmt.Errorf("content transform failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("content transform failed: %w", err)
+	}
+	if stdout.Truncated() {
+		return nil, fmt.Errorf("content transform output exceeds %d bytes", maxOutput)
+	}
+	if len(stdout.Bytes()) == 0 {
+		return nil, errors.New("content transform produced no output")
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package transform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/VuteTech/Bor/agent/internal/config"
+)
+
+// writeHook creates an executable shell script with the given body.
+func writeHook(t *testing.T, body string) string {
+	t.Helper()
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("/bin/sh not available")
+	}
+	path := filepath.Join(t.TempDir(), "hook.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o700); err != nil { //nolint:gosec // G306: test hook must be executable
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewDisabled(t *testing.T) {
+	if h := New(config.ContentTransformConfig{TimeoutSeconds: 10}); h != nil {
+		t.Errorf("New without command = %+v, want nil", h)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	hook := writeHook(t, `sed "s/proxy.old/proxy.$BOR_POLICY_TYPE/"; echo "# $BOR_TARGET_PATH"`)
+	h := New(config.ContentTransformConfig{Command: hook, TimeoutSeconds: 5})
+
+	got, err := h.Transform("Firefox", "/etc/firefox/policies/policies.json", []byte("http://proxy.old\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "http://proxy.Firefox\n# /etc/firefox/policies/policies.json\n"; string(got) != want {
+		t.Errorf("Transform = %q, want %q", got, want)
+	}
+}
+
+func TestTransformSkipsOtherTypes(t *testing.T) {
+	hook := writeHook(t, "exit 1")
+	h := New(config.ContentTransformConfig{Command: hook, Types: []string{"Chrome"}, TimeoutSeconds: 5})
+
+	got, err := h.Transform("Kconfig", "/etc/bor/xdg/kdeglobals", []byte("[General]\n"))
+	if err != nil || string(got) != "[General]\n" {
+		t.Errorf("Transform of unlisted type = %q, %v; want content unchanged", got, err)
+	}
+	if _, err := h.Transform("Chrome", "/etc/opt/chrome/policies/managed/bor_managed.json", []byte("{}")); err == nil {
+		t.Error("expected failure for listed type")
+	}
+}
+
+func TestTransformFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		timeout int
+		wantErr string
+	}{
+		{"non-zero exit", "echo 'bad proxy' >&2; exit 3", 5, "bad proxy"},
+		{"empty output", "cat >/dev/null", 5, "no output"},
+		{"timeout", "sleep 5", 1, "timed out"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New(config.ContentTransformConfig{Command: writeHook(t, tt.body), TimeoutSeconds: tt.timeout})
+			_, err := h.Transform("Dconf", "/etc/dconf/db/local.d/00-bor", []byte("[org/gnome]\n"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Transform error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/VuteTech/Bor/server/internal/config"
+)
+
+// dataEncryptionKeyFile is the name of the generated data encryption key in
+// the CA autogen dir.
+const dataEncryptionKeyFile = "data-encryption.key"
+
+// loadDataEncryptionKeys returns the key that encrypts secrets at rest and
+// the earlier keys still tried when decrypting them: the JWT secrets, which
+// encrypted them before a dedicated key was configured. Without a
+// configured key a generated one is kept in the CA autogen dir, so a
+// generated JWT secret does not make the secrets unreadable on restart.
+func loadDataEncryptionKeys(cfg *config.Config) (key string, previous []string, err error) {
+	key = cfg.Security.DataEncryptionKey
+	if key == "" {
+		key, err = loadOrCreateDataEncryptionKey(filepath.Join(cfg.CA.AutogenDir, dataEncryptionKeyFile))
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	for _, secret := range append([]string{cfg.Security.JWTSecret}, cfg.Security.JWTPreviousSecrets...) {
+		if secret != "" && secret != key {
+			previous = append(previous, secret)
+		}
+	}
+	return key, previous, nil
+}
+
+func loadOrCreateDataEncryptionKey(path string) (string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path from server configuration
+	if err == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read data encryption key: %w", err)
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate data encryption key: %w", err)
+	}
+	key := hex.EncodeToString(b)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("failed to create data encryption key dir: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(key+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write data encryption key: %w", err)
+	}
+	log.Printf("WARNING: BOR_DATA_ENCRYPTION_KEY not set — generated one in %s; back it up with the database", path)
+	return key, nil
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/VuteTech/Bor/server/internal/config"
+	"github.com/VuteTech/Bor/server/internal/services"
+)
+
+// loadJWTKeys builds the JWT signing and verification keys from the security
+// configuration, reading RS256 key files from disk.
+func loadJWTKeys(cfg config.SecurityConfig) (*services.JWTKeys, error) {
+	if cfg.JWTAlgorithm != services.JWTAlgorithmRS256 {
+		return services.NewHMACJWTKeys(cfg.JWTSecret, cfg.JWTPreviousSecrets), nil
+	}
+
+	privPEM, err := os.ReadFile(cfg.JWTPrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT private key: %w", err)
+	}
+	previous := make([][]byte, 0, len(cfg.JWTPreviousPublicKeyFiles))
+	for _, path := range cfg.JWTPreviousPublicKeyFiles {
+		pubPEM, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read previous JWT public key %s: %w", path, err)
+		}
+		previous = append(previous, pubPEM)
+	}
+	return services.NewRSAJWTKeys(privPEM, previous)
+}
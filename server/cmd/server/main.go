@@ -59,6 +59,11 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// ─── Certificate lifetimes and key type for auto-generated and issued certs
+	certOpts := cfg.CertOptions()
+	log.Printf("Certificate validity: CA %dd, UI %dd, agent %dd",
+		cfg.CA.ValidityDays, cfg.TLS.CertValidityDays, cfg.CA.AgentCertValidityDays)
+
 	// ─── Internal CA for agent mTLS (must be created first so it can
 	//     sign the UI cert when auto-generating) ──────────────────────────
 	var caCert *x509.Certificate
@@ -79,6 +84,7 @@ func main() {
 			caCertFile,
 			cfg.CA.PKCS11.Lib, cfg.CA.PKCS11.TokenLabel,
 			cfg.CA.PKCS11.KeyLabel, cfg.CA.PKCS11.PIN,
+			certOpts,
 		)
 		if err != nil {
 			log.Fatalf("Failed to initialise CA with PKCS#11 HSM: %v", err)
@@ -90,7 +96,7 @@ func main() {
 		caKeyFile := cfg.CA.KeyFile
 		if caCertFile == "" && caKeyFile == "" {
 			log.Println("BOR_CA_CERT_FILE/KEY not set – generating internal CA")
-			caCertFile, caKeyFile, err = pki.EnsureCA(cfg.CA.AutogenDir)
+			caCertFile, caKeyFile, err = pki.EnsureCA(cfg.CA.AutogenDir, certOpts)
 			if err != nil {
 				log.Fatalf("Failed to generate internal CA: %v", err)
 			}
@@ -112,22 +118,18 @@ func main() {
 	tlsKeyFile := cfg.TLS.KeyFile
 	if tlsCertFile == "" && tlsKeyFile == "" {
 		log.Println("BOR_TLS_CERT_FILE/KEY not set – ensuring server certificate (signed by internal CA)")
-		tlsCertFile, tlsKeyFile, err = pki.EnsureServerCert(cfg.TLS.AutogenDir, caCert, caKey, cfg.Server.Hostnames)
+		tlsCertFile, tlsKeyFile, err = pki.EnsureServerCert(cfg.TLS.AutogenDir, caCert, caKey, cfg.Server.Hostnames, certOpts)
 		if err != nil {
 			log.Fatalf("Failed to ensure server certificate: %v", err)
 		}
 		log.Printf("Server certificate ready in %s", cfg.TLS.AutogenDir)
 	}
 
-	// Initialize database connection
-	db, err := database.New(&database.Config{
-		Host:     cfg.Database.Host,
-		Port:     cfg.Database.Port,
-		User:     cfg.Database.User,
-		Password: cfg.Database.Password,
-		Database: cfg.Database.Database,
-		SSLMode:  cfg.Database.SSLMode,
-	})
+	// Initialize database connection, waiting for the database to come up.
+	// A shutdown signal stops the wait.
+	connectCtx, stopConnect := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	db, err := database.New(connectCtx, databaseConfig(cfg.Database))
+	stopConnect()
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -146,9 +148,15 @@ func main() {
 	nodeGroupRepo := database.NewNodeGroupRepository(db)
 	userGroupRepo := database.NewUserGroupRepository(db)
 	policyBindingRepo := database.NewPolicyBindingRepository(db)
+	nodeFilterRepo := database.NewNodeFilterRepository(db)
+	enrollmentCampaignRepo := database.NewEnrollmentCampaignRepository(db)
+	profileRepo := database.NewProfileRepository(db)
+	nodeLogCaptureRepo := database.NewNodeLogCaptureRepository(db)
+	nodeRemediationRepo := database.NewNodeRemediationRepository(db)
 	roleRepo := database.NewRoleRepository(db)
 	permRepo := database.NewPermissionRepository(db)
 	userRoleBindingRepo := database.NewUserRoleBindingRepository(db)
+	revokedTokenRepo := database.NewRevokedTokenRepository(db)
 	userGroupMemberRepo := database.NewUserGroupMemberRepository(db)
 	userGroupRoleBindingRepo := database.NewUserGroupRoleBindingRepository(db)
 	auditLogRepo := database.NewAuditLogRepository(db)
@@ -161,52 +169,20 @@ func main() {
 	mfaRepo := database.NewMFARepository(db)
 	webauthnRepo := database.NewWebAuthnRepository(db)
 
-	// Initialize LDAP service
-	var ldapSvc *services.LDAPService
-	if cfg.LDAP.Enabled {
-		ldapSvc = services.NewLDAPService(&services.LDAPConfig{
-			Enabled:         cfg.LDAP.Enabled,
-			Host:            cfg.LDAP.Host,
-			Port:            cfg.LDAP.Port,
-			UseTLS:          cfg.LDAP.UseTLS,
-			StartTLS:        cfg.LDAP.StartTLS,
-			TLSCAFile:       cfg.LDAP.TLSCAFile,
-			TLSSkipVerify:   cfg.LDAP.TLSSkipVerify,
-			BindDN:          cfg.LDAP.BindDN,
-			BindPassword:    cfg.LDAP.BindPassword,
-			BaseDN:          cfg.LDAP.BaseDN,
-			UserFilter:      cfg.LDAP.UserFilter,
-			UPNSuffix:       cfg.LDAP.UPNSuffix,
-			AttrUsername:    cfg.LDAP.AttrUsername,
-			AttrEmail:       cfg.LDAP.AttrEmail,
-			AttrFullName:    cfg.LDAP.AttrFullName,
-			GroupBaseDN:     cfg.LDAP.GroupBaseDN,
-			GroupFilter:     cfg.LDAP.GroupFilter,
-			GroupMemberAttr: cfg.LDAP.GroupMemberAttr,
-			AttrMemberOf:    cfg.LDAP.AttrMemberOf,
-			PageSize:        cfg.LDAP.PageSize,
-			GroupRoleMap:    cfg.LDAP.GroupRoleMap,
-		})
-		log.Printf("LDAP authentication enabled (host=%s port=%d tls=%v startTLS=%v)",
-			cfg.LDAP.Host, cfg.LDAP.Port, cfg.LDAP.UseTLS, cfg.LDAP.StartTLS)
-		if len(cfg.LDAP.GroupRoleMap) > 0 {
-			log.Printf("LDAP group→role mapping: %d mapping(s) active (set BOR_LDAP_GROUP_ROLE_MAP to configure)", len(cfg.LDAP.GroupRoleMap))
-			for grp, role := range cfg.LDAP.GroupRoleMap {
-				log.Printf("  LDAP group %q → Bor role %q", grp, role)
-			}
-		}
-		if cfg.LDAP.TLSSkipVerify {
-			devMode := strings.EqualFold(os.Getenv("BOR_DEV_MODE"), "true")
-			allowInsecure := strings.EqualFold(os.Getenv("BOR_ALLOW_INSECURE_LDAP"), "true")
-			if !devMode && !allowInsecure {
-				log.Fatalf("LDAP_TLS_SKIP_VERIFY=true requires BOR_DEV_MODE=true or BOR_ALLOW_INSECURE_LDAP=true") //nolint:gocritic // exitAfterDefer: intentional fatal on misconfiguration at startup
-			}
-			log.Printf("WARNING: LDAP TLS certificate verification is disabled (LDAP_TLS_SKIP_VERIFY=true)")
-		}
+	// Initialize LDAP service. It is created even when LDAP is disabled so
+	// a configuration reload can enable it.
+	if err := checkLDAPConfig(cfg.LDAP); err != nil {
+		log.Fatal(err) //nolint:gocritic // exitAfterDefer: intentional fatal on misconfiguration at startup
 	}
+	ldapSvc := services.NewLDAPService(ldapServiceConfig(cfg.LDAP))
 
-	// Initialize MFA service
-	mfaSvc := services.NewMFAService(mfaRepo, settingsRepo, cfg.Security.JWTSecret)
+	// Initialize MFA service. TOTP and webhook secrets are encrypted at rest
+	// with keys derived from the data encryption key.
+	dataKey, previousDataKeys, err := loadDataEncryptionKeys(cfg)
+	if err != nil {
+		log.Fatalf("Failed to load data encryption key: %v", err)
+	}
+	mfaSvc := services.NewMFAService(mfaRepo, settingsRepo, dataKey, previousDataKeys)
 
 	// Initialize WebAuthn service (optional — only if RPID is configured)
 	var webauthnSvc *services.WebAuthnService
@@ -224,26 +200,94 @@ func main() {
 	}
 
 	// Initialize auth service
+	jwtKeys, err := loadJWTKeys(cfg.Security)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err) //nolint:gocritic // exitAfterDefer: intentional fatal on misconfiguration at startup
+	}
+	log.Printf("JWT signing algorithm: %s", jwtKeys.Algorithm())
 	authSvc := services.NewAuthServiceWithMFAAndWebAuthn(userRepo, roleRepo, userRoleBindingRepo, cfg.Security.JWTSecret, cfg.Security.JWTLifetime, cfg.Security.RefreshLifetime, ldapSvc, mfaSvc, webauthnSvc).
-		WithAdminPassword(cfg.Security.AdminPassword)
+		WithAdminPassword(cfg.Security.AdminPassword).
+		WithJWTKeys(jwtKeys).
+		WithTokenIssuer(cfg.Security.JWTIssuer, cfg.Security.JWTAudience).
+		WithTokenRevocation(revokedTokenRepo).
+		WithLockout(cfg.Security.LockoutThreshold, cfg.Security.LockoutDuration).
+		WithUserGroupBindings(userGroupRoleBindingRepo)
+	stopRevokedTokenPurge := authSvc.StartRevokedTokenPurge(time.Hour)
+
+	// REST API clients may authenticate with a TLS client certificate issued
+	// by a dedicated CA. The UI listener must request such certificates too,
+	// so its client CA pool is the agent CA plus this one.
+	uiClientCAs := caCertPool
+	if cfg.Security.ClientCertCAFile != "" {
+		clientCertPool, err := pki.LoadCACertPool(cfg.Security.ClientCertCAFile)
+		if err != nil {
+			log.Fatalf("Failed to load client certificate CA: %v", err)
+		}
+		if clientCertPool.Equal(caCertPool) {
+			log.Fatal("BOR_CLIENT_CERT_CA_FILE must not be the agent CA")
+		}
+		clientCAPEM, err := os.ReadFile(cfg.Security.ClientCertCAFile)
+		if err != nil {
+			log.Fatalf("Failed to read client certificate CA: %v", err)
+		}
+		uiClientCAs = caCertPool.Clone()
+		uiClientCAs.AppendCertsFromPEM(clientCAPEM)
+		authSvc.WithClientCertAuth(services.NewClientCertAuth(clientCertPool, cfg.Security.ClientCertUsers))
+		log.Printf("REST API client certificate authentication enabled for %d certificate(s)", len(cfg.Security.ClientCertUsers))
+	}
 
 	// Initialize policy service
 	policySvc := services.NewPolicyService(policyRepo, policyBindingRepo)
+	policyBundleSvc := services.NewPolicyBundleService(policySvc)
 
 	// Initialize node service
 	nodeSvc := services.NewNodeService(nodeRepo)
+	if err := nodeSvc.SetMinAgentVersion(cfg.Server.MinAgentVersion); err != nil {
+		log.Fatalf("Invalid BOR_MIN_AGENT_VERSION: %v", err)
+	}
+	stopHeartbeats := func() {}
+	if cfg.Server.HeartbeatFlushInterval > 0 {
+		stopHeartbeats = nodeSvc.StartHeartbeatCoalescing(cfg.Server.HeartbeatFlushInterval)
+	}
+	stopStatusReaper := func() {}
+	if cfg.Server.NodeDegradedAfter > 0 {
+		stopStatusReaper = nodeSvc.StartStatusReaper(cfg.Server.NodeDegradedAfter, cfg.Server.NodeOfflineAfter)
+	}
 
 	// Initialize node group service
-	nodeGroupSvc := services.NewNodeGroupService(nodeGroupRepo)
+	nodeGroupSvc := services.NewNodeGroupService(nodeGroupRepo).
+		WithBindings(policyBindingRepo).
+		WithMembers(nodeRepo)
 
 	// Initialize user group service (identity domain — separate from node groups)
 	userGroupSvc := services.NewUserGroupService(userGroupRepo)
 
+	// Initialize RBAC export/import service
+	rbacSvc := services.NewRBACService(roleRepo, permRepo, userRepo, userGroupRepo, nodeGroupRepo, userRoleBindingRepo, userGroupRoleBindingRepo)
+
+	// Initialize on-demand agent log capture service
+	nodeLogCaptureSvc := services.NewNodeLogCaptureService(nodeLogCaptureRepo)
+	nodeRemediationSvc := services.NewNodeRemediationService(nodeRemediationRepo)
+
+	// Initialize saved node filter service
+	nodeFilterSvc := services.NewNodeFilterService(nodeFilterRepo, nodeRepo)
+
 	// Initialize policy binding service
-	policyBindingSvc := services.NewPolicyBindingService(policyBindingRepo, policyRepo, nodeGroupRepo)
+	policyBindingSvc := services.NewPolicyBindingService(policyBindingRepo, policyRepo, nodeGroupRepo, nodeRepo, nodeFilterSvc).
+		WithUserGroups(userGroupRepo)
+
+	// Initialize policy profile service
+	profileSvc := services.NewProfileService(profileRepo, policyRepo, nodeGroupRepo)
 
 	// Initialize enrollment service
 	enrollSvc := services.NewEnrollmentService(caCert, caKey, nodeGroupSvc, nodeSvc, revocationRepo)
+	// Revoked serials are checked on every agent RPC; keep them in memory.
+	// Revocations made by other server instances apply within a minute.
+	revocations := grpcserver.NewRevocationCache(revocationRepo, time.Minute)
+	nodeSvc.SetRevocationListener(revocations)
+	enrollSvc.SetCertOptions(certOpts)
+	enrollSvc.SetCampaignRepository(enrollmentCampaignRepo)
+	enrollmentCampaignSvc := services.NewEnrollmentCampaignService(enrollmentCampaignRepo, nodeGroupSvc, enrollSvc)
 
 	// Initialize audit service
 	auditSvc := services.NewAuditService(auditLogRepo)
@@ -258,6 +302,8 @@ func main() {
 			ResourceID:   entry.ResourceID,
 			Details:      entry.Details,
 			IPAddress:    entry.IPAddress,
+
+			CorrelationID: entry.CorrelationID,
 		})
 	})
 	auditSvc.AddSink(dbSink)
@@ -278,28 +324,18 @@ func main() {
 	}
 
 	// Start audit log retention purge if configured.
+	stopAuditRetention := func() {}
 	if cfg.Audit.RetentionDays > 0 {
-		go func() {
-			ticker := time.NewTicker(24 * time.Hour)
-			defer ticker.Stop()
-			for {
-				cutoff := time.Now().AddDate(0, 0, -cfg.Audit.RetentionDays)
-				if n, purgeErr := auditLogRepo.DeleteOlderThan(context.Background(), cutoff); purgeErr != nil {
-					log.Printf("Audit log retention purge failed: %v", purgeErr)
-				} else if n > 0 {
-					log.Printf("Audit log retention: purged %d records older than %d days", n, cfg.Audit.RetentionDays)
-				}
-				<-ticker.C
-			}
-		}()
+		stopAuditRetention = auditSvc.StartRetention(time.Duration(cfg.Audit.RetentionDays)*24*time.Hour, 24*time.Hour)
 		log.Printf("Audit log retention enabled: %d days", cfg.Audit.RetentionDays)
 	}
 
 	// Initialize settings service
 	settingsSvc := services.NewSettingsService(settingsRepo)
+	webhookSvc := services.NewWebhookService(database.NewWebhookRepository(db), dataKey, previousDataKeys)
 
 	// Initialize authorizer
-	az := authz.New(userRoleBindingRepo, roleRepo)
+	az := authz.New(userRoleBindingRepo, userGroupRoleBindingRepo, roleRepo)
 
 	// Create default admin if no users exist
 	if adminErr := authSvc.EnsureDefaultAdmin(context.Background()); adminErr != nil {
@@ -307,40 +343,92 @@ func main() {
 	}
 
 	// PolicyHub provides in-process pub/sub for streaming policy updates.
-	policyHub := grpcserver.NewPolicyHub()
+	policyHub := grpcserver.NewPolicyHubWithSize(cfg.Server.PolicyLogSize)
 
 	// Initialize API handlers
 	authHandler := api.NewAuthHandler(authSvc, mfaSvc, webauthnSvc).
-		WithPrivacyPolicyURL(cfg.UI.PrivacyPolicyURL)
+		WithPrivacyPolicyURL(cfg.UI.PrivacyPolicyURL).
+		WithLoginLimit(cfg.Security.LoginMaxAttempts, cfg.Security.LoginAttemptWindow)
 	userHandler := api.NewUserHandler(authSvc)
 	roleHandler := api.NewRoleHandler(roleRepo, permRepo, userRoleBindingRepo)
 	bindingHandler := api.NewUserRoleBindingHandler(userRoleBindingRepo)
-	policyHandler := api.NewPolicyHandler(policySvc)
-	nodeHandler := api.NewNodeHandler(nodeSvc, enrollSvc, policyHub)
+	rbacHandler := api.NewRBACHandler(rbacSvc)
+	policyHandler := api.NewPolicyHandler(policySvc).WithBindings(policyBindingSvc)
+	policyBundleHandler := api.NewPolicyBundleHandler(policyBundleSvc)
+	nodeHandler := api.NewNodeHandler(nodeSvc, enrollSvc, policyHub, services.NewPolicyResolver(policySvc, nodeFilterSvc).WithQuarantine(nodeGroupSvc)).
+		WithLogCapture(nodeLogCaptureSvc, policyHub).
+		WithRemediation(nodeRemediationSvc, policyHub).
+		WithBulkDelete(nodeFilterSvc, policyHub).
+		WithQuarantine(policyHub)
 	nodeGroupHandler := api.NewNodeGroupHandler(nodeGroupSvc, enrollSvc)
+	nodeFilterHandler := api.NewNodeFilterHandler(nodeFilterSvc)
+	enrollmentCampaignHandler := api.NewEnrollmentCampaignHandler(enrollmentCampaignSvc)
 	userGroupHandler := api.NewUserGroupHandler(userGroupSvc, userGroupMemberRepo, userGroupRoleBindingRepo)
 	policyBindingHandler := api.NewPolicyBindingHandler(policyBindingSvc)
-	auditLogHandler := api.NewAuditLogHandler(auditSvc)
+	profileHandler := api.NewProfileHandler(profileSvc)
+	auditLogHandler := api.NewAuditLogHandler(auditSvc).WithAnonymizeIPs(cfg.Audit.AnonymizeIPs)
 	settingsHandler := api.NewSettingsHandler(settingsSvc, mfaSvc)
+	webhookHandler := api.NewWebhookHandler(webhookSvc)
 	dconfHandler := api.NewDConfHandler(dconfRepo)
 	complianceHandler := api.NewComplianceHandler(dconfRepo)
 	polkitHandler := api.NewPolkitHandler(polkitRepo)
 
-	// Wire policy and binding change notifications to the hub.
+	// Wire policy and binding change notifications to the hub and to the
+	// configured webhooks.
 	// Only agents whose node groups are affected by the change are signalled.
-	policyHandler.OnPolicyChange = func(policyID string) {
+	// Policies bound to saved filters or user groups may reach any node, so
+	// changes to them signal all agents.
+	policyHandler.OnPolicyChange = func(ctx context.Context, policy *models.Policy) {
+		webhookSvc.PolicyChanged(policy, actorFromContext(ctx))
+		policyID := policy.ID
+		dynamic, lookupErr := policyBindingSvc.HasEnabledDynamicBinding(context.Background(), policyID)
+		if lookupErr != nil {
+			log.Printf("Warning: failed to check filter bindings for policy %s: %v", policyID, lookupErr)
+			return
+		}
+		if dynamic {
+			policyHub.PublishResync()
+			return
+		}
 		groupIDs, lookupErr := policyBindingSvc.GetEnabledGroupIDsForPolicy(context.Background(), policyID)
 		if lookupErr != nil {
 			log.Printf("Warning: failed to get enabled group IDs for policy %s: %v", policyID, lookupErr)
 			return
 		}
-		if len(groupIDs) == 0 {
-			return // no active bindings, no agents to notify
+		// No active bindings: no agents to notify.
+		policyHub.PublishResyncForGroups(groupIDs)
+	}
+	policyBindingHandler.OnBindingChange = func(ctx context.Context, b *models.PolicyBinding) {
+		webhookSvc.BindingChanged(b, actorFromContext(ctx))
+		if b.FilterID != nil || b.UserGroupID != nil {
+			policyHub.PublishResync()
+			return
 		}
-		policyHub.PublishResync(groupIDs...)
+		policyHub.PublishResyncForGroups([]string{b.GroupID})
+	}
+	// Bindings entering or leaving their activity window change what
+	// agents receive just like enabling or disabling them.
+	stopWindowScheduler := policyBindingSvc.StartWindowScheduler(15*time.Second, func(b *models.PolicyBinding) {
+		policyBindingHandler.OnBindingChange(context.Background(), b)
+	})
+	nodeFilterHandler.OnFilterChange = func(string) {
+		policyHub.PublishResync()
+	}
+	userGroupHandler.OnMembershipChange = func(string) {
+		policyHub.PublishResync()
 	}
-	policyBindingHandler.OnBindingChange = func(b *models.PolicyBinding) {
-		policyHub.PublishResync(b.GroupID)
+	nodeGroupHandler.OnInventoryOnlyChange = func(groupID string) {
+		policyHub.PublishResyncForGroups([]string{groupID})
+	}
+	nodeGroupHandler.OnQuarantineChange = func(groupID string) {
+		policyHub.PublishResyncForGroups([]string{groupID})
+	}
+	// Removed nodes are no longer group members, so signal all agents.
+	nodeGroupHandler.OnMembershipChange = func(string) {
+		policyHub.PublishResync()
+	}
+	profileHandler.OnProfileChange = func(groupIDs []string) {
+		policyHub.PublishResyncForGroups(groupIDs)
 	}
 
 	// Setup HTTP routes
@@ -355,6 +443,7 @@ func main() {
 	// Public routes (no auth required)
 	mux.HandleFunc("/api/v1/version", api.NewVersionHandler(Version))
 	mux.HandleFunc("/api/v1/config", authHandler.PublicConfig)
+	mux.HandleFunc("/api/v1/pki/crl", api.NewCRLHandler(enrollSvc))
 	mux.Handle("/api/v1/auth/login", authRateLimit(http.HandlerFunc(authHandler.Login)))
 	mux.Handle("/api/v1/auth/begin", authRateLimit(http.HandlerFunc(authHandler.Begin)))
 	mux.Handle("/api/v1/auth/step", authRateLimit(http.HandlerFunc(authHandler.Step)))
@@ -362,6 +451,7 @@ func main() {
 	mux.Handle("/api/v1/auth/webauthn/finish", authRateLimit(http.HandlerFunc(authHandler.WebAuthnAuthFinish)))
 	mux.HandleFunc("/api/v1/auth/logout", authHandler.Logout)
 	mux.HandleFunc("/api/v1/auth/refresh", authHandler.Refresh)
+	mux.HandleFunc("/api/v1/auth/jwks", authHandler.JWKS)
 
 	// Protected routes — all require authentication AND specific permissions.
 	// Deny-by-default: routes without explicit permission middleware are not accessible.
@@ -369,6 +459,7 @@ func main() {
 
 	// Auth routes (no additional permission needed — user just needs to be authenticated)
 	mux.Handle("/api/v1/auth/me", authMiddleware(http.HandlerFunc(authHandler.Me)))
+	mux.Handle("/api/v1/auth/change-password", authRateLimit(authMiddleware(auditMw(http.HandlerFunc(authHandler.ChangePassword)))))
 
 	// GDPR data export for the current user
 	mux.Handle("/api/v1/users/me/export", authMiddleware(http.HandlerFunc(authHandler.DataExport)))
@@ -394,6 +485,9 @@ func main() {
 		{Method: http.MethodDelete, Resource: "policy", Action: "delete"},
 	})
 	mux.Handle("/api/v1/policies", authMiddleware(api.RequirePermission(az, "policy", "view")(http.HandlerFunc(policyHandler.List))))
+	mux.Handle("/api/v1/policies/export", authMiddleware(api.RequirePermission(az, "policy", "view")(http.HandlerFunc(policyBundleHandler.Export))))
+	mux.Handle("/api/v1/policies/import", authMiddleware(api.RequirePermission(az, "policy", "create")(auditMw(http.HandlerFunc(policyBundleHandler.Import)))))
+	mux.Handle("/api/v1/policies/validate", authMiddleware(api.RequirePermission(az, "policy", "view")(http.HandlerFunc(policyHandler.Validate))))
 	mux.Handle("/api/v1/policies/all", authMiddleware(policyPerms(auditMw(http.HandlerFunc(policyHandler.ServeHTTP)))))
 	mux.Handle("/api/v1/policies/all/", authMiddleware(policyPerms(auditMw(http.HandlerFunc(policyHandler.ServeHTTP)))))
 
@@ -406,7 +500,27 @@ func main() {
 	})
 	mux.Handle("/api/v1/nodes", authMiddleware(api.RequirePermission(az, "node", "view")(http.HandlerFunc(nodeHandler.List))))
 	mux.Handle("/api/v1/nodes/status-counts", authMiddleware(api.RequirePermission(az, "node", "view")(http.HandlerFunc(nodeHandler.CountByStatus))))
+	mux.Handle("/api/v1/nodes/stale-policies", authMiddleware(api.RequirePermission(az, "node", "view")(http.HandlerFunc(nodeHandler.StalePolicies))))
 	mux.Handle("/api/v1/nodes/", authMiddleware(nodePerms(auditMw(http.HandlerFunc(nodeHandler.ServeHTTP)))))
+	mux.Handle("/api/v1/nodes:bulk-delete", authMiddleware(api.RequirePermission(az, "node", "delete")(auditMw(http.HandlerFunc(nodeHandler.BulkDelete)))))
+	mux.Handle("/api/v1/remediation-actions", authMiddleware(api.RequirePermission(az, "node", "view")(http.HandlerFunc(nodeHandler.RemediationActions))))
+
+	// Remediation actions run as root on the agent, so they require their own
+	// permission rather than the generic node ones.
+	mux.Handle("/api/v1/nodes/{id}/run-command", authMiddleware(api.RequirePermission(az, "node", "remediate")(auditMw(http.HandlerFunc(nodeHandler.ServeRunCommand)))))
+
+	// Captured agent logs may contain hostnames, user names and policy
+	// content, so collecting and reading them requires node:collect_logs.
+	nodeCollectLogs := api.RequirePermission(az, "node", "collect_logs")
+	mux.Handle("/api/v1/nodes/{id}/collect-logs", authMiddleware(nodeCollectLogs(auditMw(http.HandlerFunc(nodeHandler.ServeCollectLogs)))))
+	mux.Handle("/api/v1/nodes/{id}/logs", authMiddleware(nodeCollectLogs(http.HandlerFunc(nodeHandler.ServeLogCaptures))))
+	mux.Handle("/api/v1/nodes/{id}/logs/{captureID}", authMiddleware(nodeCollectLogs(http.HandlerFunc(nodeHandler.ServeLogCaptures))))
+
+	// Quarantine replaces a node's whole policy set during incident response,
+	// so placing and lifting it requires node:quarantine.
+	nodeQuarantine := api.RequirePermission(az, "node", "quarantine")
+	mux.Handle("/api/v1/nodes/{id}/quarantine", authMiddleware(nodeQuarantine(auditMw(http.HandlerFunc(nodeHandler.ServeQuarantine)))))
+	mux.Handle("/api/v1/nodes/{id}/unquarantine", authMiddleware(nodeQuarantine(auditMw(http.HandlerFunc(nodeHandler.ServeUnquarantine)))))
 
 	// Node group routes — method-based permission checking
 	groupPerms := api.RequireMethodPermission(az, []api.MethodPermission{
@@ -418,6 +532,12 @@ func main() {
 	mux.Handle("/api/v1/node-groups", authMiddleware(groupPerms(auditMw(http.HandlerFunc(nodeGroupHandler.ServeHTTP)))))
 	mux.Handle("/api/v1/node-groups/", authMiddleware(groupPerms(auditMw(http.HandlerFunc(nodeGroupHandler.ServeHTTP)))))
 
+	// Saved node filter routes — dynamic node selections share the node group permissions
+	mux.Handle("/api/v1/node-filters", authMiddleware(groupPerms(auditMw(http.HandlerFunc(nodeFilterHandler.ServeHTTP)))))
+	mux.Handle("/api/v1/node-filters/", authMiddleware(groupPerms(auditMw(http.HandlerFunc(nodeFilterHandler.ServeHTTP)))))
+	mux.Handle("/api/v1/enrollment-campaigns", authMiddleware(groupPerms(auditMw(http.HandlerFunc(enrollmentCampaignHandler.ServeHTTP)))))
+	mux.Handle("/api/v1/enrollment-campaigns/", authMiddleware(groupPerms(auditMw(http.HandlerFunc(enrollmentCampaignHandler.ServeHTTP)))))
+
 	// User group routes — identity domain (separate from node groups)
 	userGroupPerms := api.RequireMethodPermission(az, []api.MethodPermission{
 		{Method: http.MethodGet, Resource: "user_group", Action: "view"},
@@ -438,6 +558,8 @@ func main() {
 	})
 	mux.Handle("/api/v1/policy-bindings", authMiddleware(bindingPerms(auditMw(http.HandlerFunc(policyBindingHandler.ServeHTTP)))))
 	mux.Handle("/api/v1/policy-bindings/", authMiddleware(bindingPerms(auditMw(http.HandlerFunc(policyBindingHandler.ServeHTTP)))))
+	mux.Handle("/api/v1/profiles", authMiddleware(bindingPerms(auditMw(http.HandlerFunc(profileHandler.ServeHTTP)))))
+	mux.Handle("/api/v1/profiles/", authMiddleware(bindingPerms(auditMw(http.HandlerFunc(profileHandler.ServeHTTP)))))
 
 	// Admin-only routes (requires "user:manage" permission)
 	adminMiddleware := api.AdminOnly(az)
@@ -450,16 +572,28 @@ func main() {
 	mux.Handle("/api/v1/roles/", authMiddleware(roleMiddleware(auditMw(roleHandler))))
 	mux.Handle("/api/v1/permissions", authMiddleware(roleMiddleware(http.HandlerFunc(roleHandler.ListAllPermissions))))
 
+	// RBAC export/import (requires "role:manage" and, since bindings are
+	// included, "user:manage")
+	mux.Handle("/api/v1/rbac/export", authMiddleware(roleMiddleware(adminMiddleware(http.HandlerFunc(rbacHandler.Export)))))
+	mux.Handle("/api/v1/rbac/import", authMiddleware(roleMiddleware(adminMiddleware(auditMw(http.HandlerFunc(rbacHandler.Import))))))
+
 	// User role binding routes (requires "user:manage" permission)
 	mux.Handle("/api/v1/user-role-bindings", authMiddleware(adminMiddleware(auditMw(bindingHandler))))
 	mux.Handle("/api/v1/user-role-bindings/", authMiddleware(adminMiddleware(auditMw(bindingHandler))))
 
 	// Audit log routes
-	mux.Handle("/api/v1/audit-logs", authMiddleware(api.RequirePermission(az, "audit_log", "view")(http.HandlerFunc(auditLogHandler.List))))
+	// Purges record their own audit entry, so they bypass auditMw.
+	auditLogPerms := api.RequireMethodPermission(az, []api.MethodPermission{
+		{Method: http.MethodGet, Resource: "audit_log", Action: "view"},
+		{Method: http.MethodDelete, Resource: "audit_log", Action: "purge"},
+	})
+	mux.Handle("/api/v1/audit-logs", authMiddleware(auditLogPerms(auditLogHandler)))
 	mux.Handle("/api/v1/audit-logs/export", authMiddleware(api.RequirePermission(az, "audit_log", "export")(http.HandlerFunc(auditLogHandler.Export))))
 
 	// Settings routes
 	mux.Handle("/api/v1/settings/agent-notifications", authMiddleware(api.RequirePermission(az, "settings", "manage")(auditMw(http.HandlerFunc(settingsHandler.AgentNotifications)))))
+	mux.Handle("/api/v1/settings/webhooks", authMiddleware(api.RequirePermission(az, "settings", "manage")(auditMw(webhookHandler))))
+	mux.Handle("/api/v1/settings/webhooks/", authMiddleware(api.RequirePermission(az, "settings", "manage")(auditMw(webhookHandler))))
 	mux.Handle("/api/v1/settings/mfa", authMiddleware(api.RequirePermission(az, "settings", "manage")(http.HandlerFunc(settingsHandler.MFASettings))))
 
 	// DConf schema catalogue — readable by anyone with policy:view
@@ -467,6 +601,9 @@ func main() {
 
 	// Compliance results — readable by anyone with compliance:view
 	mux.Handle("/api/v1/compliance", authMiddleware(api.RequirePermission(az, "compliance", "view")(http.HandlerFunc(complianceHandler.List))))
+	mux.Handle("/api/v1/compliance/applied-files", authMiddleware(api.RequirePermission(az, "compliance", "view")(http.HandlerFunc(complianceHandler.ListAppliedFiles))))
+	mux.Handle("/api/v1/nodes/{id}/compliance", authMiddleware(api.RequirePermission(az, "compliance", "view")(http.HandlerFunc(complianceHandler.NodeCompliance))))
+	mux.Handle("/api/v1/compliance/export", authMiddleware(api.RequirePermission(az, "compliance", "export")(http.HandlerFunc(complianceHandler.Export))))
 
 	// Polkit action catalogue — readable by anyone with policy:view
 	mux.Handle("/api/v1/polkit/actions", authMiddleware(api.RequirePermission(az, "policy", "view")(http.HandlerFunc(polkitHandler.ListActions))))
@@ -480,6 +617,14 @@ func main() {
 		log.Fatalf("Failed to load UI TLS certificate: %v", err) //nolint:gocritic // process is exiting, deferred cleanup not needed
 	}
 
+	// Health endpoints (no auth): liveness, and readiness of the database
+	// and the CA/TLS certificates.
+	mux.HandleFunc("/healthz", api.NewHealthzHandler())
+	mux.HandleFunc("/readyz", api.NewReadyzHandler(
+		api.ReadinessCheck{Name: "database", Check: db.Check},
+		api.ReadinessCheck{Name: "tls", Check: api.CertificatesValid(caCert, uiTLSCert.Leaf)},
+	))
+
 	// ─── Kerberos enrollment service (optional) ───────────────────────────────
 	var kerberosvc *services.KerberosService
 	if cfg.Kerberos.Enabled {
@@ -501,10 +646,15 @@ func main() {
 	// ─── Enrollment gRPC server (no mandatory client cert at TLS layer) ──
 	// Require a verified client certificate for all RPCs except Enroll and
 	// KerberosEnroll (both are bootstrapping calls that exchange credentials
-	// for a signed certificate).
-	exemptMethods := map[string]bool{
-		"/bor.enrollment.v1.EnrollmentService/Enroll":         true,
-		"/bor.enrollment.v1.EnrollmentService/KerberosEnroll": true,
+	// for a signed certificate) and any configured exemptions.
+	enrollAuth := grpcserver.NewAuthPolicy(grpcserver.RequireClientCert(revocations)).
+		Exempt(
+			enrollpb.EnrollmentService_Enroll_FullMethodName,
+			enrollpb.EnrollmentService_KerberosEnroll_FullMethodName,
+		)
+	for _, m := range cfg.Server.GRPCExemptMethods {
+		log.Printf("gRPC method %s is exempt from client certificate authentication", m)
+		enrollAuth.Exempt(m)
 	}
 
 	enrollSrvImpl := grpcserver.NewEnrollmentServer(enrollSvc, cfg.Security.AdminToken)
@@ -513,17 +663,28 @@ func main() {
 	}
 
 	enrollGrpcSrv := grpc.NewServer(
-		grpc.UnaryInterceptor(grpcserver.RequireClientCertInterceptor(exemptMethods, revocationRepo)),
-		grpc.StreamInterceptor(grpcserver.RequireClientCertStreamInterceptor(exemptMethods, revocationRepo)),
+		grpc.ChainUnaryInterceptor(grpcserver.AuthPolicyInterceptor(enrollAuth), grpcserver.AgentAuditInterceptor(auditSvc)),
+		grpc.StreamInterceptor(grpcserver.AuthPolicyStreamInterceptor(enrollAuth)),
 	)
 	enrollpb.RegisterEnrollmentServiceServer(enrollGrpcSrv, enrollSrvImpl)
 
 	// ─── Policy gRPC server (mandatory client cert — agents only) ────────
+	// Certificate renewal additionally requires the presented certificate to
+	// be far enough into its lifetime to be due for renewal.
+	policyAuth := grpcserver.NewAuthPolicy(grpcserver.RequireClientCert(revocations)).
+		Set(pb.PolicyService_RenewCertificate_FullMethodName, grpcserver.RequireRenewableClientCert(revocations))
 	policyGrpcSrv := grpc.NewServer(
-		grpc.UnaryInterceptor(grpcserver.RequireClientCertInterceptor(map[string]bool{}, revocationRepo)),
-		grpc.StreamInterceptor(grpcserver.RequireClientCertStreamInterceptor(map[string]bool{}, revocationRepo)),
+		grpc.ChainUnaryInterceptor(grpcserver.AuthPolicyInterceptor(policyAuth), grpcserver.AgentAuditInterceptor(auditSvc)),
+		grpc.ChainStreamInterceptor(grpcserver.AuthPolicyStreamInterceptor(policyAuth), grpcserver.AgentAuditStreamInterceptor(auditSvc)),
 	)
-	pb.RegisterPolicyServiceServer(policyGrpcSrv, grpcserver.NewPolicyServer(policySvc, nodeSvc, settingsSvc, auditSvc, enrollSvc, dconfRepo, polkitRepo, policyHub))
+	policyGrpcSvc := grpcserver.NewPolicyServer(policySvc, nodeSvc, settingsSvc, auditSvc, enrollSvc, dconfRepo, polkitRepo, policyHub)
+	policyGrpcSvc.SetNodeFilterService(nodeFilterSvc)
+	policyGrpcSvc.SetNodeGroupService(nodeGroupSvc)
+	policyGrpcSvc.SetNodeLogCaptureService(nodeLogCaptureSvc)
+	policyGrpcSvc.SetNodeRemediationService(nodeRemediationSvc)
+	policyGrpcSvc.SetBackpressure(cfg.Server.BackpressureSubscribers, time.Duration(cfg.Server.BackpressureSeconds)*time.Second)
+	policyGrpcSvc.SetUnhealthyThreshold(cfg.Compliance.UnhealthyThreshold, cfg.Compliance.UnhealthyWindow)
+	pb.RegisterPolicyServiceServer(policyGrpcSrv, policyGrpcSvc)
 
 	// ─── UI + Enrollment server (:8443) — VerifyClientCertIfGiven ────────
 	// Explicit cipher suites per BSI TR-02102-2 (2024): ECDHE+AEAD only.
@@ -534,7 +695,7 @@ func main() {
 	// GODEBUG=fips140=on will automatically remove it at runtime.
 	uiTLSConfig := &tls.Config{
 		Certificates: []tls.Certificate{uiTLSCert},
-		ClientCAs:    caCertPool,
+		ClientCAs:    uiClientCAs,
 		ClientAuth:   tls.VerifyClientCertIfGiven,
 		MinVersion:   tls.VersionTLS12,
 		NextProtos:   []string{"h2", "http/1.1"},
@@ -551,13 +712,18 @@ func main() {
 		},
 	}
 
+	securityHeaders := api.NewSecurityHeadersMiddleware(cfg.Security.ContentSecurityPolicy)
+	bodyLimit := api.NewMaxBodyBytesMiddleware(api.DefaultMaxBodyBytes,
+		api.BodyLimit{Prefix: "/api/v1/policies", Limit: api.MaxPolicyBodyBytes})
 	uiGrpcRouter := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") && !api.IsHealthPath(r.URL.Path) {
 			enrollGrpcSrv.ServeHTTP(w, r)
 		} else {
 			// Security headers + CSRF applied to HTTP only, not gRPC.
-			api.SecurityHeadersMiddleware(
-				api.CSRFMiddleware(mux),
+			api.RequestIDMiddleware(
+				securityHeaders(
+					api.CSRFMiddleware(bodyLimit(mux)),
+				),
 			).ServeHTTP(w, r)
 		}
 	})
@@ -588,13 +754,20 @@ func main() {
 		Handler:           agentGrpcRouter,
 		TLSConfig:         agentTLSConfig,
 		ReadHeaderTimeout: 10 * time.Second,
+		// gRPC keepalive server options do not apply to ServeHTTP, so
+		// dead agent connections are detected with HTTP/2 pings instead.
+		// Closing the connection cancels its policy stream.
+		HTTP2: &http.HTTP2Config{
+			SendPingTimeout: cfg.Server.KeepaliveInterval,
+			PingTimeout:     cfg.Server.KeepaliveTimeout,
+		},
 	}
 
 	// ─── Prometheus metrics server (plain HTTP, separate port) ───────────
 	metricsCollector := metrics.NewBorCollector(
 		nodeRepo, policyRepo, policyBindingRepo,
 		auditLogRepo, userRepo, dconfRepo,
-	)
+	).WithPolicyHub(policyHub)
 	metricsServer := metrics.NewServer(cfg.Metrics.ListenAddr, cfg.Metrics.BearerToken, metricsCollector)
 
 	// Start both servers.
@@ -627,14 +800,35 @@ func main() {
 	log.Printf("HTTPS + enrollment gRPC listening on %s", cfg.Server.EnrollmentAddr())
 	log.Printf("Agent policy gRPC (mTLS) listening on %s", cfg.Server.PolicyAddr())
 
-	// Block until shutdown signal
+	// Block until shutdown signal, reloading the configuration on SIGHUP.
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	<-sigCh
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigCh {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		reloadConfig(cfg, ldapSvc)
+	}
 
 	log.Println("Shutting down server...")
+
+	// Drain the policy streams: agents are told to reconnect later and
+	// their streams end cleanly. Streams still open after the drain
+	// timeout are closed forcibly.
+	policyHub.Drain(time.Duration(cfg.Server.DrainReconnectSeconds) * time.Second)
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), cfg.Server.DrainTimeout)
+	if !policyHub.WaitDrained(drainCtx) {
+		log.Printf("Drain timeout: closing %d remaining agent streams", policyHub.SubscriberCount())
+	}
 	enrollGrpcSrv.GracefulStop()
-	policyGrpcSrv.GracefulStop()
+	stopGRPCServer(drainCtx, policyGrpcSrv)
+	drainCancel()
+	stopStatusReaper()
+	stopWindowScheduler()
+	stopHeartbeats()
+	stopRevokedTokenPurge()
+	stopAuditRetention()
+	webhookSvc.Close()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := uiServer.Shutdown(ctx); err != nil {
@@ -653,6 +847,48 @@ func main() {
 	log.Println("Server stopped")
 }
 
+// actorFromContext returns the username of the user making the request in
+// ctx, or "" for changes made by the server itself.
+func actorFromContext(ctx context.Context) string {
+	if claims := api.GetUserFromContext(ctx); claims != nil {
+		return claims.Username
+	}
+	return ""
+}
+
+// stopGRPCServer stops srv gracefully, or forcibly once ctx is done.
+func stopGRPCServer(ctx context.Context, srv *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		srv.Stop()
+		<-done
+	}
+}
+
+// databaseConfig converts the database section of the server configuration
+// to the database package's connection configuration.
+func databaseConfig(cfg config.DatabaseConfig) *database.Config {
+	return &database.Config{
+		Host:                 cfg.Host,
+		Port:                 cfg.Port,
+		User:                 cfg.User,
+		Password:             cfg.Password,
+		Database:             cfg.Database,
+		SSLMode:              cfg.SSLMode,
+		ConnectAttempts:      cfg.ConnectAttempts,
+		ConnectRetryInterval: cfg.ConnectRetryInterval,
+		MaxOpenConns:         cfg.MaxOpenConns,
+		MaxIdleConns:         cfg.MaxIdleConns,
+		ConnMaxLifetime:      cfg.ConnMaxLifetime,
+	}
+}
+
 // resetMFAForUser connects to the database using environment config, looks up
 // the user by username, and deletes their MFA record.
 func resetMFAForUser(username string) error {
@@ -661,14 +897,7 @@ func resetMFAForUser(username string) error {
 		return fmt.Errorf("load config: %w", err)
 	}
 
-	db, err := database.New(&database.Config{
-		Host:     cfg.Database.Host,
-		Port:     cfg.Database.Port,
-		User:     cfg.Database.User,
-		Password: cfg.Database.Password,
-		Database: cfg.Database.Database,
-		SSLMode:  cfg.Database.SSLMode,
-	})
+	db, err := database.New(context.Background(), databaseConfig(cfg.Database))
 	if err != nil {
 		return fmt.Errorf("connect to database: %w", err)
 	}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/VuteTech/Bor/server/internal/config"
+	"github.com/VuteTech/Bor/server/internal/services"
+)
+
+// ldapServiceConfig converts the LDAP section of the server configuration
+// to the LDAP service's configuration.
+func ldapServiceConfig(cfg config.LDAPConfig) *services.LDAPConfig {
+	return &services.LDAPConfig{
+		Enabled:         cfg.Enabled,
+		Host:            cfg.Host,
+		Port:            cfg.Port,
+		UseTLS:          cfg.UseTLS,
+		StartTLS:        cfg.StartTLS,
+		TLSCAFile:       cfg.TLSCAFile,
+		TLSSkipVerify:   cfg.TLSSkipVerify,
+		BindDN:          cfg.BindDN,
+		BindPassword:    cfg.BindPassword,
+		BaseDN:          cfg.BaseDN,
+		UserFilter:      cfg.UserFilter,
+		UPNSuffix:       cfg.UPNSuffix,
+		AttrUsername:    cfg.AttrUsername,
+		AttrEmail:       cfg.AttrEmail,
+		AttrFullName:    cfg.AttrFullName,
+		GroupBaseDN:     cfg.GroupBaseDN,
+		GroupFilter:     cfg.GroupFilter,
+		GroupMemberAttr: cfg.GroupMemberAttr,
+		AttrMemberOf:    cfg.AttrMemberOf,
+		PageSize:        cfg.PageSize,
+		GroupRoleMap:    cfg.GroupRoleMap,
+	}
+}
+
+// checkLDAPConfig logs the effective LDAP settings and rejects disabled
+// certificate verification outside development mode.
+func checkLDAPConfig(cfg config.LDAPConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	log.Printf("LDAP authentication enabled (host=%s port=%d tls=%v startTLS=%v)",
+		cfg.Host, cfg.Port, cfg.UseTLS, cfg.StartTLS)
+	if len(cfg.GroupRoleMap) > 0 {
+		log.Printf("LDAP group→role mapping: %d mapping(s) active (set BOR_LDAP_GROUP_ROLE_MAP to configure)", len(cfg.GroupRoleMap))
+		for grp, role := range cfg.GroupRoleMap {
+			log.Printf("  LDAP group %q → Bor role %q", grp, role)
+		}
+	}
+	if cfg.TLSSkipVerify {
+		devMode := strings.EqualFold(os.Getenv("BOR_DEV_MODE"), "true")
+		allowInsecure := strings.EqualFold(os.Getenv("BOR_ALLOW_INSECURE_LDAP"), "true")
+		if !devMode && !allowInsecure {
+			return fmt.Errorf("LDAP_TLS_SKIP_VERIFY=true requires BOR_DEV_MODE=true or BOR_ALLOW_INSECURE_LDAP=true")
+		}
+		log.Printf("WARNING: LDAP TLS certificate verification is disabled (LDAP_TLS_SKIP_VERIFY=true)")
+	}
+	return nil
+}
+
+// reloadConfig re-reads the configuration on SIGHUP and applies the
+// settings that can change at run time — currently the LDAP settings —
+// updating cfg to match. Every other changed setting, such as the TLS
+// certificates or the database connection, is logged by name as requiring
+// a restart. The listeners and agent streams are left untouched.
+func reloadConfig(cfg *config.Config, ldapSvc *services.LDAPService) {
+	log.Println("SIGHUP received: reloading configuration")
+	next, err := config.Load()
+	if err != nil {
+		log.Printf("Configuration reload failed, keeping current configuration: %v", err)
+		return
+	}
+
+	if err := checkLDAPConfig(next.LDAP); err != nil {
+		log.Printf("Configuration reload failed, keeping current LDAP settings: %v", err)
+	} else {
+		ldapSvc.SetConfig(ldapServiceConfig(next.LDAP))
+		if cfg.LDAP.Enabled && !next.LDAP.Enabled {
+			log.Println("LDAP authentication disabled")
+		}
+		cfg.LDAP = next.LDAP
+	}
+
+	if changed := config.RestartRequired(cfg, next); len(changed) > 0 {
+		log.Printf("Configuration changes to %s require a server restart to take effect", strings.Join(changed, ", "))
+	}
+	log.Println("Configuration reloaded")
+}
@@ -7,17 +7,22 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/VuteTech/Bor/server/internal/models"
 	"github.com/VuteTech/Bor/server/internal/services"
+	auditpb "github.com/VuteTech/Bor/server/pkg/grpc/audit"
 )
 
 // AuditLogHandler handles audit log API endpoints
 type AuditLogHandler struct {
-	auditSvc *services.AuditService
+	auditSvc     *services.AuditService
+	anonymizeIPs bool
 }
 
 // NewAuditLogHandler creates a new AuditLogHandler
@@ -25,20 +30,38 @@ func NewAuditLogHandler(auditSvc *services.AuditService) *AuditLogHandler {
 	return &AuditLogHandler{auditSvc: auditSvc}
 }
 
+// WithAnonymizeIPs truncates the source IP recorded for manual purges.
+func (h *AuditLogHandler) WithAnonymizeIPs(anonymize bool) *AuditLogHandler {
+	h.anonymizeIPs = anonymize
+	return h
+}
+
+// ServeHTTP routes /api/v1/audit-logs
+func (h *AuditLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.List(w, r)
+	case http.MethodDelete:
+		h.Purge(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+	}
+}
+
 // List handles GET /api/v1/audit-logs
 func (h *AuditLogHandler) List(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
-	req := &models.AuditLogListRequest{
-		Page:          1,
-		PerPage:       25,
-		ResourceTypes: r.URL.Query()["resource_type"],
-		Actions:       r.URL.Query()["action"],
-		Username:      r.URL.Query().Get("username"),
+	req, err := auditLogFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+		return
 	}
+	req.Page = 1
+	req.PerPage = 25
 
 	if p := r.URL.Query().Get("page"); p != "" {
 		if v, err := strconv.Atoi(p); err == nil && v > 0 {
@@ -54,7 +77,7 @@ func (h *AuditLogHandler) List(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.auditSvc.List(r.Context(), req)
 	if err != nil {
 		log.Printf("Failed to list audit logs: %v", err)
-		http.Error(w, `{"error":"failed to list audit logs"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list audit logs")
 		return
 	}
 
@@ -64,10 +87,101 @@ func (h *AuditLogHandler) List(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Purge handles DELETE /api/v1/audit-logs?before=<time>. before is an RFC
+// 3339 timestamp or a YYYY-MM-DD date (midnight UTC); every entry created
+// earlier is deleted.
+func (h *AuditLogHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	raw := r.URL.Query().Get("before")
+	if raw == "" {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "before is required")
+		return
+	}
+	before, _, err := parseAuditTime(raw)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "before must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		return
+	}
+	if before.After(time.Now()) {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "before must not be in the future")
+		return
+	}
+
+	actor := &auditpb.Actor{}
+	if claims := GetUserFromContext(r.Context()); claims != nil {
+		actor.Username = claims.Username
+		actor.UserId = claims.UserID
+	}
+
+	deleted, err := h.auditSvc.Purge(r.Context(), before, actor, extractAuditIP(r, h.anonymizeIPs))
+	if err != nil {
+		log.Printf("Failed to purge audit logs after %d records: %v", deleted, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to purge audit logs")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		"before":  before.UTC().Format(time.RFC3339),
+		"deleted": deleted,
+	}); err != nil {
+		log.Printf("Failed to encode audit log purge response: %v", err)
+	}
+}
+
+// auditLogFilter reads the audit log filters shared by List and Export
+// from the query string. from and to take an RFC 3339 timestamp or a
+// YYYY-MM-DD date; a date in to includes that whole day.
+func auditLogFilter(r *http.Request) (*models.AuditLogListRequest, error) {
+	q := r.URL.Query()
+	req := &models.AuditLogListRequest{
+		ResourceTypes: q["resource_type"],
+		Actions:       q["action"],
+		Username:      q.Get("username"),
+		CorrelationID: q.Get("correlation_id"),
+		Query:         strings.TrimSpace(q.Get("q")),
+	}
+	if raw := q.Get("from"); raw != "" {
+		from, _, err := parseAuditTime(raw)
+		if err != nil {
+			return nil, fmt.Errorf("from must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		}
+		req.From = &from
+	}
+	if raw := q.Get("to"); raw != "" {
+		to, dateOnly, err := parseAuditTime(raw)
+		if err != nil {
+			return nil, fmt.Errorf("to must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		req.To = &to
+	}
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		return nil, fmt.Errorf("from must be before to")
+	}
+	return req, nil
+}
+
+// parseAuditTime parses an RFC 3339 timestamp or a YYYY-MM-DD date
+// (midnight UTC) and reports which of the two it was.
+func parseAuditTime(raw string) (t time.Time, dateOnly bool, err error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, false, nil
+	}
+	t, err = time.Parse(time.DateOnly, raw)
+	return t, err == nil, err
+}
+
 // Export handles GET /api/v1/audit-logs/export?format=csv|json
 func (h *AuditLogHandler) Export(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
@@ -76,10 +190,10 @@ func (h *AuditLogHandler) Export(w http.ResponseWriter, r *http.Request) {
 		format = "csv"
 	}
 
-	req := &models.AuditLogListRequest{
-		ResourceTypes: r.URL.Query()["resource_type"],
-		Actions:       r.URL.Query()["action"],
-		Username:      r.URL.Query().Get("username"),
+	req, err := auditLogFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+		return
 	}
 
 	switch format {
@@ -97,6 +211,6 @@ func (h *AuditLogHandler) Export(w http.ResponseWriter, r *http.Request) {
 			log.Printf("Failed to export audit logs as JSON: %v", err)
 		}
 	default:
-		http.Error(w, `{"error":"invalid format, use csv or json"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "invalid format, use csv or json")
 	}
 }
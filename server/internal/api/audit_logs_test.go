@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAuditLogPurge_RejectsBadBefore(t *testing.T) {
+	h := NewAuditLogHandler(nil)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	for _, q := range []string{"", "?before=yesterday", "?before=2026-13-01", "?before=" + future} {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/audit-logs"+q, nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("DELETE /api/v1/audit-logs%s = %d, want 400", q, rec.Code)
+		}
+	}
+}
+
+func TestParseAuditTime(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     time.Time
+		dateOnly bool
+	}{
+		{"2026-01-02", time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), true},
+		{"2026-01-02T03:04:05Z", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), false},
+		{"2026-01-02T03:04:05+02:00", time.Date(2026, 1, 2, 1, 4, 5, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		got, dateOnly, err := parseAuditTime(tt.in)
+		if err != nil || !got.Equal(tt.want) || dateOnly != tt.dateOnly {
+			t.Errorf("parseAuditTime(%q) = %v, %v, %v; want %v, %v", tt.in, got, dateOnly, err, tt.want, tt.dateOnly)
+		}
+	}
+}
+
+func TestAuditLogFilter(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/audit-logs?q=+fire+&from=2026-01-01T12:00:00Z&to=2026-01-31", nil)
+	req, err := auditLogFilter(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Query != "fire" {
+		t.Errorf("Query = %q, want fire", req.Query)
+	}
+	if want := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC); req.From == nil || !req.From.Equal(want) {
+		t.Errorf("From = %v, want %v", req.From, want)
+	}
+	// A date in to includes the whole day.
+	if want := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC); req.To == nil || !req.To.Equal(want) {
+		t.Errorf("To = %v, want %v", req.To, want)
+	}
+
+	for _, q := range []string{"from=last-week", "to=2026-02-30", "from=2026-02-01&to=2026-01-01"} {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/audit-logs?"+q, nil)
+		if _, err := auditLogFilter(r); err == nil {
+			t.Errorf("auditLogFilter(%s) = nil error", q)
+		}
+	}
+}
+
+func TestAuditLogList_RejectsBadRange(t *testing.T) {
+	h := NewAuditLogHandler(nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/audit-logs?from=yesterday", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("GET with a bad from = %d, want 400", rec.Code)
+	}
+}
@@ -63,8 +63,12 @@ func captureDetails(r *http.Request) string {
 	if err != nil {
 		return ""
 	}
-	// Restore body so the actual handler can read it.
-	r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	// Restore body so the actual handler can read it, including anything
+	// past the cap.
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(bodyBytes), r.Body), r.Body}
 
 	var m map[string]interface{}
 	if unmarshalErr := json.Unmarshal(bodyBytes, &m); unmarshalErr != nil {
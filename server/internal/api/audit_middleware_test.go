@@ -20,6 +20,7 @@ func TestParseResourceFromPath(t *testing.T) {
 		{"/api/v1/policies/abc-123", "policies", "abc-123"},
 		{"/api/v1/nodes/node-1/", "nodes", "node-1"},
 		{"/api/v1/node-groups", "node-groups", ""},
+		{"/api/v1/node-groups/grp-1/tokens/abc", "node-groups", "grp-1"},
 		{"/api/v1/user-groups/grp-1/members", "user-groups", "grp-1"},
 		{"/api/v1/roles/role-1/permissions", "roles", "role-1"},
 	}
@@ -6,8 +6,10 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,6 +23,7 @@ type AuthHandler struct {
 	mfaSvc           *services.MFAService
 	webauthnSvc      *services.WebAuthnService
 	privacyPolicyURL string
+	loginLimiter     *loginLimiter // nil disables login throttling
 }
 
 // NewAuthHandler creates a new AuthHandler
@@ -34,30 +37,72 @@ func (h *AuthHandler) WithPrivacyPolicyURL(url string) *AuthHandler {
 	return h
 }
 
+// WithLoginLimit accepts at most maxAttempts login attempts per username
+// and per client IP within window. A maxAttempts of 0 disables the limit.
+func (h *AuthHandler) WithLoginLimit(maxAttempts int, window time.Duration) *AuthHandler {
+	h.loginLimiter = nil
+	if maxAttempts > 0 {
+		h.loginLimiter = newLoginLimiter(maxAttempts, window)
+	}
+	return h
+}
+
+// tooManyLoginAttempts is the response to throttled logins and to logins
+// of locked accounts, so that both look the same to the caller.
+const tooManyLoginAttempts = "too many failed login attempts, try again later"
+
+// allowLogin counts a login attempt for username and rejects it with 429
+// when the username or the client IP is over the limit. It reports whether
+// the request may proceed.
+func (h *AuthHandler) allowLogin(w http.ResponseWriter, r *http.Request, username string) bool {
+	if h.loginLimiter == nil {
+		return true
+	}
+	ok, retryAfter := h.loginLimiter.allow(username, clientIP(r), time.Now())
+	if !ok {
+		log.Printf("Login attempt for user %s from %s throttled", username, clientIP(r))
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+		writeError(w, http.StatusTooManyRequests, CodeRateLimited, tooManyLoginAttempts)
+	}
+	return ok
+}
+
 // Login handles POST /api/v1/auth/login
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req models.LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+	if !h.allowLogin(w, r, req.Username) {
 		return
 	}
 
 	resp, err := h.authSvc.Login(r.Context(), &req)
+	if errors.Is(err, services.ErrAccountLocked) {
+		log.Printf("Login refused for locked user %s", req.Username)
+		writeError(w, http.StatusTooManyRequests, CodeRateLimited, tooManyLoginAttempts)
+		return
+	}
 	if err != nil {
 		log.Printf("Login failed for user %s: %v", req.Username, err)
-		http.Error(w, `{"error":"invalid username or password"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeInvalidCredentials, "invalid username or password")
 		return
 	}
 
-	SetSessionCookie(w, resp.Token, h.authSvc.TokenLifetime())
-	SetCSRFCookie(w)
-	if refreshToken, err := h.authSvc.GenerateRefreshToken(&resp.User); err == nil {
-		SetRefreshCookie(w, refreshToken, h.authSvc.RefreshLifetimeSeconds())
+	// An MFA challenge carries no token; the session cookies are set once
+	// the second factor is verified by Step.
+	if !resp.MFARequired {
+		SetSessionCookie(w, resp.Token, h.authSvc.TokenLifetime())
+		SetCSRFCookie(w)
+		if refreshToken, err := h.authSvc.GenerateRefreshToken(&resp.User); err == nil {
+			SetRefreshCookie(w, refreshToken, h.authSvc.RefreshLifetimeSeconds())
+		}
 	}
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
@@ -68,20 +113,23 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 // Begin handles POST /api/v1/auth/begin — starts the multi-step auth flow.
 func (h *AuthHandler) Begin(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req models.AuthBeginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+	if !h.allowLogin(w, r, req.Username) {
 		return
 	}
 
 	resp, err := h.authSvc.AuthBegin(r.Context(), &req)
 	if err != nil {
 		log.Printf("AuthBegin failed for user %s: %v", req.Username, err)
-		http.Error(w, `{"error":"invalid username or password"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeInvalidCredentials, "invalid username or password")
 		return
 	}
 
@@ -94,20 +142,34 @@ func (h *AuthHandler) Begin(w http.ResponseWriter, r *http.Request) {
 // Step handles POST /api/v1/auth/step — advances the multi-step auth flow.
 func (h *AuthHandler) Step(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req models.AuthStepRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+	// The username is taken from the session token; an invalid token is
+	// still counted against the client IP.
+	username := ""
+	if session, err := h.authSvc.ValidateSessionToken(req.SessionToken); err == nil {
+		username = session.Username
+	}
+	if !h.allowLogin(w, r, username) {
 		return
 	}
 
 	resp, err := h.authSvc.AuthStep(r.Context(), &req)
+	if errors.Is(err, services.ErrAccountLocked) {
+		log.Printf("AuthStep refused for locked user %s", username)
+		writeError(w, http.StatusTooManyRequests, CodeRateLimited, tooManyLoginAttempts)
+		return
+	}
 	if err != nil {
 		log.Printf("AuthStep failed: %v", err)
-		http.Error(w, `{"error":"authentication failed"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeInvalidCredentials, "authentication failed")
 		return
 	}
 
@@ -132,26 +194,26 @@ func (h *AuthHandler) Step(w http.ResponseWriter, r *http.Request) {
 // Me handles GET /api/v1/auth/me - returns current user info with permissions
 func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 
 	user, err := h.authSvc.GetUser(r.Context(), claims.UserID)
 	if err != nil || user == nil {
-		http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeUserNotFound, "user not found")
 		return
 	}
 
 	permissions, err := h.authSvc.GetUserPermissions(r.Context(), claims.UserID)
 	if err != nil {
 		log.Printf("Failed to get permissions for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"failed to load permissions"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to load permissions")
 		return
 	}
 	if permissions == nil {
@@ -163,7 +225,10 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 		Username:    user.Username,
 		Email:       user.Email,
 		FullName:    user.FullName,
+		Source:      user.Source,
 		Permissions: permissions,
+
+		MustChangePassword: user.MustChangePassword,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -175,25 +240,25 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 // MFAStatus handles GET /api/v1/users/me/mfa — returns current user's MFA status.
 func (h *AuthHandler) MFAStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 
 	if h.mfaSvc == nil {
-		http.Error(w, `{"error":"MFA not configured"}`, http.StatusServiceUnavailable)
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "MFA not configured")
 		return
 	}
 
 	status, err := h.mfaSvc.GetStatus(r.Context(), claims.UserID)
 	if err != nil {
 		log.Printf("Failed to get MFA status for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"failed to get MFA status"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to get MFA status")
 		return
 	}
 
@@ -206,31 +271,31 @@ func (h *AuthHandler) MFAStatus(w http.ResponseWriter, r *http.Request) {
 // MFASetupBegin handles POST /api/v1/users/me/mfa/setup/begin
 func (h *AuthHandler) MFASetupBegin(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 
 	if h.mfaSvc == nil {
-		http.Error(w, `{"error":"MFA not configured"}`, http.StatusServiceUnavailable)
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "MFA not configured")
 		return
 	}
 
 	user, err := h.authSvc.GetUser(r.Context(), claims.UserID)
 	if err != nil || user == nil {
-		http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeUserNotFound, "user not found")
 		return
 	}
 
 	resp, err := h.mfaSvc.BeginSetup(r.Context(), claims.UserID, user.Username)
 	if err != nil {
 		log.Printf("MFA setup begin failed for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"failed to begin MFA setup"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to begin MFA setup")
 		return
 	}
 
@@ -245,31 +310,31 @@ func (h *AuthHandler) MFASetupBegin(w http.ResponseWriter, r *http.Request) {
 // secret is never sent to an external service.
 func (h *AuthHandler) MFASetupQR(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 
 	if h.mfaSvc == nil {
-		http.Error(w, `{"error":"MFA not configured"}`, http.StatusServiceUnavailable)
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "MFA not configured")
 		return
 	}
 
 	user, err := h.authSvc.GetUser(r.Context(), claims.UserID)
 	if err != nil || user == nil {
-		http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeUserNotFound, "user not found")
 		return
 	}
 
 	png, err := h.mfaSvc.GenerateSetupQR(r.Context(), claims.UserID, user.Username)
 	if err != nil {
 		log.Printf("MFA QR generation failed for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"failed to generate QR code"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to generate QR code")
 		return
 	}
 
@@ -281,31 +346,31 @@ func (h *AuthHandler) MFASetupQR(w http.ResponseWriter, r *http.Request) {
 // MFASetupFinish handles POST /api/v1/users/me/mfa/setup/finish
 func (h *AuthHandler) MFASetupFinish(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 
 	if h.mfaSvc == nil {
-		http.Error(w, `{"error":"MFA not configured"}`, http.StatusServiceUnavailable)
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "MFA not configured")
 		return
 	}
 
 	var req models.MFASetupFinishRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	resp, err := h.mfaSvc.FinishSetup(r.Context(), claims.UserID, req.Code)
 	if err != nil {
 		log.Printf("MFA setup finish failed for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"invalid TOTP code"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "invalid TOTP code")
 		return
 	}
 
@@ -317,13 +382,13 @@ func (h *AuthHandler) MFASetupFinish(w http.ResponseWriter, r *http.Request) {
 
 // webAuthnNotImplemented returns 501 when WebAuthn is not configured.
 func (h *AuthHandler) webAuthnNotImplemented(w http.ResponseWriter) {
-	http.Error(w, `{"error":"WebAuthn not configured"}`, http.StatusNotImplemented)
+	writeError(w, http.StatusNotImplemented, CodeNotImplemented, "WebAuthn not configured")
 }
 
 // WebAuthnRegisterBegin handles POST /api/v1/users/me/webauthn/register/begin
 func (h *AuthHandler) WebAuthnRegisterBegin(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 	if h.webauthnSvc == nil {
@@ -332,18 +397,18 @@ func (h *AuthHandler) WebAuthnRegisterBegin(w http.ResponseWriter, r *http.Reque
 	}
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 	user, err := h.authSvc.GetUser(r.Context(), claims.UserID)
 	if err != nil || user == nil {
-		http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeUserNotFound, "user not found")
 		return
 	}
 	optionsJSON, err := h.webauthnSvc.BeginRegistration(r.Context(), claims.UserID, user.Username)
 	if err != nil {
 		log.Printf("WebAuthn register begin failed for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"failed to begin WebAuthn registration"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to begin WebAuthn registration")
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -353,7 +418,7 @@ func (h *AuthHandler) WebAuthnRegisterBegin(w http.ResponseWriter, r *http.Reque
 // WebAuthnRegisterFinish handles POST /api/v1/users/me/webauthn/register/finish
 func (h *AuthHandler) WebAuthnRegisterFinish(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 	if h.webauthnSvc == nil {
@@ -362,12 +427,12 @@ func (h *AuthHandler) WebAuthnRegisterFinish(w http.ResponseWriter, r *http.Requ
 	}
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 	user, err := h.authSvc.GetUser(r.Context(), claims.UserID)
 	if err != nil || user == nil {
-		http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeUserNotFound, "user not found")
 		return
 	}
 	var body struct {
@@ -376,13 +441,13 @@ func (h *AuthHandler) WebAuthnRegisterFinish(w http.ResponseWriter, r *http.Requ
 	}
 	err = json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 	cred, err := h.webauthnSvc.FinishRegistration(r.Context(), claims.UserID, user.Username, body.Name, body.Credential)
 	if err != nil {
 		log.Printf("WebAuthn register finish failed for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"WebAuthn registration failed"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "WebAuthn registration failed")
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -394,7 +459,7 @@ func (h *AuthHandler) WebAuthnRegisterFinish(w http.ResponseWriter, r *http.Requ
 // WebAuthnListCredentials handles GET /api/v1/users/me/webauthn/credentials
 func (h *AuthHandler) WebAuthnListCredentials(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 	if h.webauthnSvc == nil {
@@ -403,13 +468,13 @@ func (h *AuthHandler) WebAuthnListCredentials(w http.ResponseWriter, r *http.Req
 	}
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 	creds, err := h.webauthnSvc.ListCredentials(r.Context(), claims.UserID)
 	if err != nil {
 		log.Printf("WebAuthn list credentials failed for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"failed to list credentials"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list credentials")
 		return
 	}
 	if creds == nil {
@@ -424,7 +489,7 @@ func (h *AuthHandler) WebAuthnListCredentials(w http.ResponseWriter, r *http.Req
 // WebAuthnRenameCredential handles PUT /api/v1/users/me/webauthn/credentials/{id}
 func (h *AuthHandler) WebAuthnRenameCredential(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 	if h.webauthnSvc == nil {
@@ -433,24 +498,24 @@ func (h *AuthHandler) WebAuthnRenameCredential(w http.ResponseWriter, r *http.Re
 	}
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 	// Extract credential ID from URL path: /api/v1/users/me/webauthn/credentials/{id}
 	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
 	credID := parts[len(parts)-1]
 	if credID == "" {
-		http.Error(w, `{"error":"missing credential id"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "missing credential id")
 		return
 	}
 	var req models.RenameWebAuthnCredentialRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 	if err := h.webauthnSvc.RenameCredential(r.Context(), credID, claims.UserID, req.Name); err != nil {
 		log.Printf("WebAuthn rename credential failed for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"failed to rename credential"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to rename credential")
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -459,7 +524,7 @@ func (h *AuthHandler) WebAuthnRenameCredential(w http.ResponseWriter, r *http.Re
 // WebAuthnDeleteCredential handles DELETE /api/v1/users/me/webauthn/credentials/{id}
 func (h *AuthHandler) WebAuthnDeleteCredential(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 	if h.webauthnSvc == nil {
@@ -468,18 +533,18 @@ func (h *AuthHandler) WebAuthnDeleteCredential(w http.ResponseWriter, r *http.Re
 	}
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
 	credID := parts[len(parts)-1]
 	if credID == "" {
-		http.Error(w, `{"error":"missing credential id"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "missing credential id")
 		return
 	}
 	if err := h.webauthnSvc.DeleteCredential(r.Context(), credID, claims.UserID); err != nil {
 		log.Printf("WebAuthn delete credential failed for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"failed to delete credential"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete credential")
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -495,7 +560,7 @@ func (h *AuthHandler) WebAuthnCredentialHandler(w http.ResponseWriter, r *http.R
 	case http.MethodDelete:
 		h.WebAuthnDeleteCredential(w, r)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -503,7 +568,7 @@ func (h *AuthHandler) WebAuthnCredentialHandler(w http.ResponseWriter, r *http.R
 // Body: {"session_token": "..."}
 func (h *AuthHandler) WebAuthnAuthBegin(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 	if h.webauthnSvc == nil {
@@ -514,18 +579,18 @@ func (h *AuthHandler) WebAuthnAuthBegin(w http.ResponseWriter, r *http.Request)
 		SessionToken string `json:"session_token"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 	sessionClaims, err := h.authSvc.ValidateSessionToken(body.SessionToken)
 	if err != nil {
-		http.Error(w, `{"error":"invalid session token"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeInvalidToken, "invalid session token")
 		return
 	}
 	optionsJSON, err := h.webauthnSvc.BeginAuthentication(r.Context(), sessionClaims.UserID)
 	if err != nil {
 		log.Printf("WebAuthn auth begin failed for user %s: %v", sessionClaims.UserID, err)
-		http.Error(w, `{"error":"failed to begin WebAuthn authentication"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to begin WebAuthn authentication")
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -538,7 +603,7 @@ func (h *AuthHandler) WebAuthnAuthBegin(w http.ResponseWriter, r *http.Request)
 // Body: {"session_token": "...", "credential": <WebAuthn JSON>}
 func (h *AuthHandler) WebAuthnAuthFinish(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 	if h.webauthnSvc == nil {
@@ -550,25 +615,25 @@ func (h *AuthHandler) WebAuthnAuthFinish(w http.ResponseWriter, r *http.Request)
 		Credential   json.RawMessage `json:"credential"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 	sessionClaims, err := h.authSvc.ValidateSessionToken(body.SessionToken)
 	if err != nil {
-		http.Error(w, `{"error":"invalid session token"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeInvalidToken, "invalid session token")
 		return
 	}
 	err = h.webauthnSvc.FinishAuthentication(r.Context(), sessionClaims.UserID, body.Credential)
 	if err != nil {
 		log.Printf("WebAuthn auth finish failed for user %s: %v", sessionClaims.UserID, err)
-		http.Error(w, `{"error":"WebAuthn authentication failed"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeInvalidCredentials, "WebAuthn authentication failed")
 		return
 	}
 	// WebAuthn fully authenticates the user — issue the final JWT directly, no password needed.
 	loginResp, err := h.authSvc.IssueTokenByUserID(r.Context(), sessionClaims.UserID)
 	if err != nil {
 		log.Printf("Failed to issue token after WebAuthn for user %s: %v", sessionClaims.UserID, err)
-		http.Error(w, `{"error":"failed to issue token"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to issue token")
 		return
 	}
 	resp := models.AuthStepResponse{
@@ -586,12 +651,46 @@ func (h *AuthHandler) WebAuthnAuthFinish(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-// Logout handles POST /api/v1/auth/logout — clears the session cookie.
+// JWKS handles GET /api/v1/auth/jwks and publishes the public keys that
+// verify access tokens, so integrations can validate RS256 tokens without
+// sharing a secret. It returns 404 when tokens are signed with HS256.
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	set := h.authSvc.JWTPublicKeys()
+	if set == nil {
+		writeError(w, http.StatusNotFound, CodeNotFound, "tokens are not signed with a public key algorithm")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(set); err != nil {
+		log.Printf("Failed to encode JWKS response: %v", err)
+	}
+}
+
+// Logout handles POST /api/v1/auth/logout — revokes the access token of
+// the request and the refresh token, and clears the session cookies.
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
+	// Revoke the session so that a copy of the tokens cannot be used
+	// until they expire.
+	refreshToken := ""
+	if c, err := r.Cookie(RefreshCookieName); err == nil {
+		refreshToken = c.Value
+	}
+	if err := h.authSvc.Logout(r.Context(), tokenFromRequest(r), refreshToken); err != nil {
+		log.Printf("Failed to revoke tokens on logout: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to log out")
+		return
+	}
+
 	ClearSessionCookie(w)
 	clearCSRFCookie(w)
 	w.Header().Set("Content-Type", "application/json")
@@ -603,37 +702,47 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 // the refresh cookie itself is the credential.
 func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	cookie, err := r.Cookie(RefreshCookieName)
 	if err != nil || cookie.Value == "" {
-		http.Error(w, `{"error":"missing refresh token"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeInvalidToken, "missing refresh token")
 		return
 	}
 
 	claims, err := h.authSvc.ValidateRefreshToken(cookie.Value)
 	if err != nil {
 		log.Printf("Refresh token validation failed: %v", err)
-		http.Error(w, `{"error":"invalid or expired refresh token"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeInvalidToken, "invalid or expired refresh token")
+		return
+	}
+	revoked, err := h.authSvc.IsTokenRevoked(r.Context(), claims.ID)
+	if err != nil {
+		log.Printf("Failed to check refresh token revocation: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to validate refresh token")
+		return
+	}
+	if revoked {
+		writeError(w, http.StatusUnauthorized, CodeInvalidToken, "invalid or expired refresh token")
 		return
 	}
 
 	user, err := h.authSvc.GetUser(r.Context(), claims.UserID)
 	if err != nil || user == nil {
-		http.Error(w, `{"error":"user not found"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "user not found")
 		return
 	}
 	if !user.Enabled {
-		http.Error(w, `{"error":"user account is disabled"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUserDisabled, "user account is disabled")
 		return
 	}
 
 	loginResp, err := h.authSvc.IssueTokenByUserID(r.Context(), claims.UserID)
 	if err != nil {
 		log.Printf("Failed to issue token during refresh for user %q: %v", claims.UserID, err) //nolint:gosec // G706: %q escapes all control characters including newlines, preventing log injection
-		http.Error(w, `{"error":"failed to issue token"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to issue token")
 		return
 	}
 
@@ -668,19 +777,19 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 // Returns a JSON document with all personal data stored for the requesting user.
 func (h *AuthHandler) DataExport(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 
 	user, err := h.authSvc.GetUser(r.Context(), claims.UserID)
 	if err != nil || user == nil {
-		http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeUserNotFound, "user not found")
 		return
 	}
 
@@ -718,54 +827,104 @@ func (h *AuthHandler) DataExport(w http.ResponseWriter, r *http.Request) {
 // MFADisable handles DELETE /api/v1/users/me/mfa (also accepts POST from the route /users/me/mfa/disable)
 func (h *AuthHandler) MFADisable(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	claims := GetUserFromContext(r.Context())
 	if claims == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
 		return
 	}
 
 	if h.mfaSvc == nil {
-		http.Error(w, `{"error":"MFA not configured"}`, http.StatusServiceUnavailable)
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "MFA not configured")
 		return
 	}
 
 	var req models.MFADisableRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	// Require the current password for local users.
 	user, err := h.authSvc.GetUser(r.Context(), claims.UserID)
 	if err != nil || user == nil {
-		http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeUserNotFound, "user not found")
 		return
 	}
 
 	if user.Source == models.SourceLocal {
 		if req.Password == "" {
-			http.Error(w, `{"error":"password is required to disable MFA"}`, http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, "password is required to disable MFA")
 			return
 		}
 		if _, err := h.authSvc.Login(r.Context(), &models.LoginRequest{
 			Username: user.Username,
 			Password: req.Password,
 		}); err != nil {
-			http.Error(w, `{"error":"invalid password"}`, http.StatusUnauthorized)
+			writeError(w, http.StatusUnauthorized, CodeInvalidCredentials, "invalid password")
 			return
 		}
 	}
 
 	if err := h.mfaSvc.Disable(r.Context(), claims.UserID); err != nil {
 		log.Printf("MFA disable failed for user %s: %v", claims.UserID, err)
-		http.Error(w, `{"error":"failed to disable MFA"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to disable MFA")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ChangePassword handles POST /api/v1/auth/change-password, letting a
+// local user change their own password.
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	claims := GetUserFromContext(r.Context())
+	if claims == nil {
+		writeError(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized")
+		return
+	}
+
+	var req models.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "current_password and new_password are required")
+		return
+	}
+
+	err := h.authSvc.ChangePassword(r.Context(), claims.UserID, req.CurrentPassword, req.NewPassword)
+	switch {
+	case err == nil:
+	case errors.Is(err, services.ErrPasswordNotLocal):
+		writeError(w, http.StatusBadRequest, CodeExternalPassword, "the password of directory (LDAP) users must be changed in the directory")
+		return
+	case errors.Is(err, services.ErrWeakPassword):
+		writeError(w, http.StatusBadRequest, CodeWeakPassword, err.Error())
+		return
+	case errors.Is(err, services.ErrAccountLocked):
+		writeError(w, http.StatusTooManyRequests, CodeRateLimited, tooManyLoginAttempts)
+		return
+	case errors.Is(err, services.ErrInvalidCurrentPassword):
+		// Not 401: the session is valid, only the password is wrong.
+		writeError(w, http.StatusForbidden, CodeInvalidCurrentPassword, "current password is incorrect")
+		return
+	default:
+		log.Printf("Password change failed for user %s: %v", claims.UserID, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to change password")
 		return
 	}
 
+	log.Printf("User %s changed their password", claims.Username)
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -773,7 +932,7 @@ func (h *AuthHandler) MFADisable(w http.ResponseWriter, r *http.Request) {
 // configuration needed by the frontend before the user is authenticated.
 func (h *AuthHandler) PublicConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuthHandler_ChangePassword_Validation(t *testing.T) {
+	h := NewAuthHandler(nil, nil, nil)
+
+	tests := []struct {
+		name     string
+		req      *http.Request
+		wantCode int
+	}{
+		{
+			name:     "method not allowed",
+			req:      reqWithUser(http.MethodGet, "/api/v1/auth/change-password"),
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "unauthenticated",
+			req: httptest.NewRequest(http.MethodPost, "/api/v1/auth/change-password",
+				strings.NewReader(`{"current_password":"a","new_password":"b"}`)),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "missing passwords",
+			req:      reqWithUser(http.MethodPost, "/api/v1/auth/change-password"),
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ChangePassword(rec, tt.req)
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
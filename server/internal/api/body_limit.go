@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// DefaultMaxBodyBytes is the largest request body accepted by REST API
+	// mutations.
+	DefaultMaxBodyBytes = 5 << 20
+	// MaxPolicyBodyBytes is the limit for policy create, update, validate
+	// and import requests, whose content may legitimately be large.
+	MaxPolicyBodyBytes = 32 << 20
+)
+
+// BodyLimit overrides the body size limit for the paths starting with
+// Prefix.
+type BodyLimit struct {
+	Prefix string
+	Limit  int64
+}
+
+// NewMaxBodyBytesMiddleware returns a middleware limiting the request body
+// of POST, PUT, PATCH and DELETE requests to /api/v1/ to limit bytes, or to
+// the limit of the longest matching override. Requests whose body is
+// larger get 413, whether the size was declared in Content-Length or only
+// found out while the handler read the body.
+func NewMaxBodyBytesMiddleware(limit int64, overrides ...BodyLimit) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api/v1/") || !hasBody(r.Method) || r.Body == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			max := limit
+			matched := 0
+			for _, o := range overrides {
+				if strings.HasPrefix(r.URL.Path, o.Prefix) && len(o.Prefix) > matched {
+					max, matched = o.Limit, len(o.Prefix)
+				}
+			}
+			if r.ContentLength > max {
+				writeBodyTooLarge(w)
+				return
+			}
+
+			body := &limitedBody{ReadCloser: http.MaxBytesReader(w, r.Body, max)}
+			r.Body = body
+			next.ServeHTTP(&bodyLimitWriter{ResponseWriter: w, body: body}, r)
+		})
+	}
+}
+
+func hasBody(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func writeBodyTooLarge(w http.ResponseWriter) {
+	writeError(w, http.StatusRequestEntityTooLarge, CodeRequestTooLarge, "request body too large")
+}
+
+// limitedBody records whether reading the body hit the size limit.
+type limitedBody struct {
+	io.ReadCloser
+	exceeded atomic.Bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		b.exceeded.Store(true)
+	}
+	return n, err
+}
+
+// bodyLimitWriter replaces the response of a handler that failed because
+// the body was too large, typically a 400 for an unparsable body, with
+// 413.
+type bodyLimitWriter struct {
+	http.ResponseWriter
+	body     *limitedBody
+	replaced bool
+}
+
+func (w *bodyLimitWriter) WriteHeader(code int) {
+	if w.body.exceeded.Load() && !w.replaced {
+		w.replaced = true
+		writeBodyTooLarge(w.ResponseWriter)
+		return
+	}
+	if !w.replaced {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *bodyLimitWriter) Write(b []byte) (int, error) {
+	if w.body.exceeded.Load() && !w.replaced {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.replaced {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyLimitWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
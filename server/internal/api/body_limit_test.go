@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxBodyBytesMiddleware(t *testing.T) {
+	decode := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+			http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	handler := NewMaxBodyBytesMiddleware(16, BodyLimit{Prefix: "/api/v1/policies", Limit: 64})(decode)
+
+	small := `{"a":"b"}`
+	large := `{"a":"` + strings.Repeat("x", 40) + `"}`
+	huge := `{"a":"` + strings.Repeat("x", 100) + `"}`
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		chunked bool
+		want    int
+	}{
+		{"within default", http.MethodPost, "/api/v1/nodes/1", small, false, http.StatusCreated},
+		{"declared too large", http.MethodPost, "/api/v1/nodes/1", large, false, http.StatusRequestEntityTooLarge},
+		{"streamed too large", http.MethodPut, "/api/v1/nodes/1", large, true, http.StatusRequestEntityTooLarge},
+		{"policy override", http.MethodPost, "/api/v1/policies/all", large, false, http.StatusCreated},
+		{"policy override exceeded", http.MethodPut, "/api/v1/policies/all/1", huge, true, http.StatusRequestEntityTooLarge},
+		{"outside api", http.MethodPost, "/other", large, false, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				req.Body = io.NopCloser(strings.NewReader(tt.body))
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %q)", rec.Code, tt.want, rec.Body.String())
+			}
+			if tt.want == http.StatusRequestEntityTooLarge && !strings.Contains(rec.Body.String(), "request body too large") {
+				t.Errorf("body = %q", rec.Body.String())
+			}
+		})
+	}
+}
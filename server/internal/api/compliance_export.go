@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+)
+
+// complianceEvidenceColumns is the header of the CSV evidence report. The
+// column names match the JSON field names so both formats share a schema.
+var complianceEvidenceColumns = []string{
+	"node_id", "node_name", "policy_id", "policy_name", "policy_version",
+	"state", "last_checked_at", "content_hash",
+}
+
+// Export handles GET /api/v1/compliance/export. It writes a compliance
+// evidence report for GRC tools as CSV (default) or JSON. The optional
+// node_group_id, policy_id, from and to query parameters narrow the report;
+// from and to accept RFC 3339 timestamps or YYYY-MM-DD dates, and a date in
+// to includes that whole day.
+func (h *ComplianceHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	format := q.Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "invalid format, use csv or json")
+		return
+	}
+
+	filter := database.ComplianceEvidenceFilter{
+		NodeGroupID: q.Get("node_group_id"),
+		PolicyID:    q.Get("policy_id"),
+	}
+	var err error
+	if filter.From, err = parseReportTime(q.Get("from"), false); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "invalid from, use RFC 3339 or YYYY-MM-DD")
+		return
+	}
+	if filter.To, err = parseReportTime(q.Get("to"), true); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "invalid to, use RFC 3339 or YYYY-MM-DD")
+		return
+	}
+
+	rows, err := h.dconfRepo.ListComplianceEvidence(r.Context(), filter)
+	if err != nil {
+		log.Printf("Failed to list compliance evidence: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to export compliance report")
+		return
+	}
+
+	switch format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", "attachment; filename=compliance_report.csv")
+		if werr := writeComplianceEvidenceCSV(w, rows); werr != nil {
+			log.Printf("Failed to export compliance report as CSV: %v", werr)
+		}
+	case "json":
+		if rows == nil {
+			rows = []*database.ComplianceEvidenceRow{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", "attachment; filename=compliance_report.json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if werr := encoder.Encode(rows); werr != nil {
+			log.Printf("Failed to export compliance report as JSON: %v", werr)
+		}
+	}
+}
+
+// writeComplianceEvidenceCSV writes rows as CSV with a header line.
+func writeComplianceEvidenceCSV(w io.Writer, rows []*database.ComplianceEvidenceRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(complianceEvidenceColumns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, er := range rows {
+		if err := cw.Write([]string{
+			er.NodeID,
+			er.NodeName,
+			er.PolicyID,
+			er.PolicyName,
+			strconv.Itoa(er.PolicyVersion),
+			er.State,
+			er.LastCheckedAt,
+			er.ContentHash,
+		}); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// parseReportTime parses an RFC 3339 timestamp or a YYYY-MM-DD date (UTC).
+// With endOfDay a date yields the start of the following day, so an
+// exclusive upper bound includes the whole date. Empty input yields nil.
+func parseReportTime(s string, endOfDay bool) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+)
+
+func TestComplianceExport_RejectsBadParameters(t *testing.T) {
+	h := NewComplianceHandler(nil)
+
+	for _, query := range []string{"format=xml", "from=yesterday", "to=2026-13-01"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/compliance/export?"+query, http.NoBody)
+		rec := httptest.NewRecorder()
+		h.Export(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, rec.Code)
+		}
+	}
+}
+
+func TestParseReportTime(t *testing.T) {
+	if got, err := parseReportTime("", false); got != nil || err != nil {
+		t.Errorf("empty: got %v, %v; want nil, nil", got, err)
+	}
+
+	from, err := parseReportTime("2026-07-01", false)
+	if err != nil || !from.Equal(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("from date = %v, %v", from, err)
+	}
+	to, err := parseReportTime("2026-09-30", true)
+	if err != nil || !to.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("to date = %v, %v; want the start of the next day", to, err)
+	}
+	ts, err := parseReportTime("2026-09-30T12:00:00Z", true)
+	if err != nil || !ts.Equal(time.Date(2026, 9, 30, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("timestamp = %v, %v; want it unchanged", ts, err)
+	}
+}
+
+func TestWriteComplianceEvidenceCSV(t *testing.T) {
+	rows := []*database.ComplianceEvidenceRow{{
+		NodeID:        "n1",
+		NodeName:      "desk, 1",
+		PolicyID:      "p1",
+		PolicyName:    "Firefox",
+		PolicyVersion: 3,
+		State:         "compliant",
+		LastCheckedAt: "2026-09-30T12:00:00Z",
+		ContentHash:   "abc123",
+	}}
+
+	var buf strings.Builder
+	if err := writeComplianceEvidenceCSV(&buf, rows); err != nil {
+		t.Fatalf("writeComplianceEvidenceCSV() error = %v", err)
+	}
+	want := "node_id,node_name,policy_id,policy_name,policy_version,state,last_checked_at,content_hash\n" +
+		"n1,\"desk, 1\",p1,Firefox,3,compliant,2026-09-30T12:00:00Z,abc123\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestParseComplianceListFilter(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/compliance?policy_id=p1&compliant=false&page=3&per_page=500", http.NoBody)
+	rec := httptest.NewRecorder()
+	f, ok := parseComplianceListFilter(rec, req)
+	if !ok {
+		t.Fatalf("parseComplianceListFilter rejected valid query: %s", rec.Body.String())
+	}
+	if f.PolicyID != "p1" || f.Compliant == nil || *f.Compliant || f.Page != 3 || f.PerPage != 100 {
+		t.Errorf("filter = %+v", f)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/nodes/n1/compliance?compliant=maybe", http.NoBody)
+	rec = httptest.NewRecorder()
+	NewComplianceHandler(nil).NodeCompliance(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("compliant=maybe: status = %d, want 400", rec.Code)
+	}
+}
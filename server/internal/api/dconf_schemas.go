@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/VuteTech/Bor/server/internal/database"
@@ -89,7 +90,7 @@ func protoToSchemaResponse(s *pb.GSettingsSchema) dconfSchemaResponse {
 // Optional query param: node_id=<uuid> to filter by schemas available on a node.
 func (h *DConfHandler) ListSchemas(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
@@ -107,7 +108,7 @@ func (h *DConfHandler) ListSchemas(w http.ResponseWriter, r *http.Request) {
 	}
 	if err != nil {
 		log.Printf("Failed to list dconf schemas: %v", err)
-		http.Error(w, `{"error":"failed to list schemas"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list schemas")
 		return
 	}
 
@@ -129,7 +130,7 @@ func (h *DConfHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.ListSchemas(w, r)
 		return
 	}
-	http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+	writeError(w, http.StatusNotFound, CodeNotFound, "not found")
 }
 
 // ComplianceHandler handles compliance-related REST endpoints.
@@ -142,26 +143,166 @@ func NewComplianceHandler(dconfRepo *database.DConfRepository) *ComplianceHandle
 	return &ComplianceHandler{dconfRepo: dconfRepo}
 }
 
-// List handles GET /api/v1/compliance
+// ComplianceListResponse is a page of compliance results.
+type ComplianceListResponse struct {
+	Items      []*database.ComplianceRow `json:"items"`
+	Total      int                       `json:"total"`
+	Page       int                       `json:"page"`
+	PerPage    int                       `json:"per_page"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+// NodeComplianceResponse is a page of a node's compliance results with a
+// rollup over all of the node's current results.
+type NodeComplianceResponse struct {
+	ComplianceListResponse
+	CompliantCount    int `json:"compliant_count"`
+	NoncompliantCount int `json:"noncompliant_count"`
+}
+
+// List handles GET /api/v1/compliance. The optional policy_id and compliant
+// query parameters narrow the result, which is paginated with page and
+// per_page.
 func (h *ComplianceHandler) List(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
-	results, err := h.dconfRepo.ListComplianceResults(r.Context())
+	f, ok := parseComplianceListFilter(w, r)
+	if !ok {
+		return
+	}
+	resp, err := h.listCompliance(r, f)
 	if err != nil {
 		log.Printf("Failed to list compliance results: %v", err)
-		http.Error(w, `{"error":"failed to list compliance results"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list compliance results")
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Failed to encode compliance response: %v", err)
+	}
+}
+
+// NodeCompliance handles GET /api/v1/nodes/{id}/compliance: the latest
+// report per policy for one node, with the same filters and pagination as
+// List, plus compliant and non-compliant counts.
+func (h *ComplianceHandler) NodeCompliance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	f, ok := parseComplianceListFilter(w, r)
+	if !ok {
+		return
+	}
+	f.NodeID = r.PathValue("id")
+	if f.NodeID == "" {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "node id is required")
+		return
+	}
+
+	resp, err := h.listCompliance(r, f)
+	if err != nil {
+		log.Printf("Failed to list compliance results for node %s: %v", f.NodeID, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list compliance results")
+		return
+	}
+	compliant, noncompliant, err := h.dconfRepo.CountNodeCompliance(r.Context(), f.NodeID)
+	if err != nil {
+		log.Printf("Failed to count compliance results for node %s: %v", f.NodeID, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list compliance results")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&NodeComplianceResponse{
+		ComplianceListResponse: *resp,
+		CompliantCount:         compliant,
+		NoncompliantCount:      noncompliant,
+	}); err != nil {
+		log.Printf("Failed to encode node compliance response: %v", err)
+	}
+}
+
+func (h *ComplianceHandler) listCompliance(r *http.Request, f database.ComplianceListFilter) (*ComplianceListResponse, error) {
+	results, err := h.dconfRepo.ListComplianceResults(r.Context(), f)
+	if err != nil {
+		return nil, err
+	}
+	total, err := h.dconfRepo.CountComplianceResults(r.Context(), f)
+	if err != nil {
+		return nil, err
+	}
 	if results == nil {
 		results = []*database.ComplianceRow{}
 	}
+	return &ComplianceListResponse{
+		Items:      results,
+		Total:      total,
+		Page:       f.Page,
+		PerPage:    f.PerPage,
+		TotalPages: (total + f.PerPage - 1) / f.PerPage,
+	}, nil
+}
+
+// parseComplianceListFilter reads the policy_id, compliant, page and
+// per_page query parameters. It writes a 400 response and returns false
+// when compliant is not a boolean.
+func parseComplianceListFilter(w http.ResponseWriter, r *http.Request) (database.ComplianceListFilter, bool) {
+	q := r.URL.Query()
+	f := database.ComplianceListFilter{
+		PolicyID: q.Get("policy_id"),
+		Page:     1,
+		PerPage:  25,
+	}
+	if c := q.Get("compliant"); c != "" {
+		v, err := strconv.ParseBool(c)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, "compliant must be true or false")
+			return f, false
+		}
+		f.Compliant = &v
+	}
+	if p := q.Get("page"); p != "" {
+		if v, err := strconv.Atoi(p); err == nil && v > 0 {
+			f.Page = v
+		}
+	}
+	if pp := q.Get("per_page"); pp != "" {
+		if v, err := strconv.Atoi(pp); err == nil && v > 0 {
+			f.PerPage = min(v, 100)
+		}
+	}
+	return f, true
+}
+
+// ListAppliedFiles handles GET /api/v1/compliance/applied-files. The optional
+// node_id and node_group_id query parameters narrow the result; with a node
+// group, files whose content differs from the group's peers are flagged.
+func (h *ComplianceHandler) ListAppliedFiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	results, err := h.dconfRepo.ListAppliedFiles(r.Context(), q.Get("node_id"), q.Get("node_group_id"))
+	if err != nil {
+		log.Printf("Failed to list applied files: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list applied files")
+		return
+	}
+
+	if results == nil {
+		results = []*database.AppliedFileRow{}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(results); err != nil {
-		log.Printf("Failed to encode compliance response: %v", err)
+		log.Printf("Failed to encode applied files response: %v", err)
 	}
 }
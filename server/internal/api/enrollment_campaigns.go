@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+	"github.com/VuteTech/Bor/server/internal/services"
+)
+
+// EnrollmentCampaignHandler handles enrollment campaign API endpoints
+type EnrollmentCampaignHandler struct {
+	campaignSvc *services.EnrollmentCampaignService
+}
+
+// NewEnrollmentCampaignHandler creates a new EnrollmentCampaignHandler
+func NewEnrollmentCampaignHandler(campaignSvc *services.EnrollmentCampaignService) *EnrollmentCampaignHandler {
+	return &EnrollmentCampaignHandler{campaignSvc: campaignSvc}
+}
+
+// ServeHTTP routes /api/v1/enrollment-campaigns/{id} and sub-paths
+func (h *EnrollmentCampaignHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, subpath := extractEnrollmentCampaignIDAndSubpath(r.URL.Path)
+
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.List(w, r)
+		case http.MethodPost:
+			h.Create(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	// Handle /api/v1/enrollment-campaigns/{id}/tokens
+	if subpath == "tokens" {
+		h.GenerateToken(w, r, id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.Get(w, r, id)
+	case http.MethodPut:
+		h.Update(w, r, id)
+	case http.MethodDelete:
+		h.Delete(w, r, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+	}
+}
+
+// List handles GET /api/v1/enrollment-campaigns. Each campaign reports its
+// progress as enrolled_count out of max_enrollments (0 = unlimited).
+func (h *EnrollmentCampaignHandler) List(w http.ResponseWriter, r *http.Request) {
+	campaigns, err := h.campaignSvc.ListCampaigns(r.Context())
+	if err != nil {
+		log.Printf("Failed to list enrollment campaigns: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list enrollment campaigns")
+		return
+	}
+
+	if campaigns == nil {
+		campaigns = []*models.EnrollmentCampaign{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(campaigns); err != nil {
+		log.Printf("Failed to encode enrollment campaigns response: %v", err)
+	}
+}
+
+// Create handles POST /api/v1/enrollment-campaigns
+func (h *EnrollmentCampaignHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateEnrollmentCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	claims := GetUserFromContext(r.Context())
+	createdBy := ""
+	if claims != nil {
+		createdBy = claims.Username
+	}
+
+	campaign, err := h.campaignSvc.CreateCampaign(r.Context(), &req, createdBy)
+	if err != nil {
+		log.Printf("Failed to create enrollment campaign: %v", err)
+		writeError(w, http.StatusBadRequest, CodeCampaignInvalid, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(campaign); err != nil {
+		log.Printf("Failed to encode enrollment campaign response: %v", err)
+	}
+}
+
+// Get handles GET /api/v1/enrollment-campaigns/{id}
+func (h *EnrollmentCampaignHandler) Get(w http.ResponseWriter, r *http.Request, id string) {
+	campaign, err := h.campaignSvc.GetCampaign(r.Context(), id)
+	if err != nil || campaign == nil {
+		writeError(w, http.StatusNotFound, CodeCampaignNotFound, "enrollment campaign not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(campaign); err != nil {
+		log.Printf("Failed to encode enrollment campaign response: %v", err)
+	}
+}
+
+// Update handles PUT /api/v1/enrollment-campaigns/{id}
+func (h *EnrollmentCampaignHandler) Update(w http.ResponseWriter, r *http.Request, id string) {
+	var req models.UpdateEnrollmentCampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	campaign, err := h.campaignSvc.UpdateCampaign(r.Context(), id, &req)
+	if err != nil {
+		log.Printf("Failed to update enrollment campaign: %v", err)
+		writeError(w, http.StatusBadRequest, CodeCampaignInvalid, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(campaign); err != nil {
+		log.Printf("Failed to encode enrollment campaign response: %v", err)
+	}
+}
+
+// Delete handles DELETE /api/v1/enrollment-campaigns/{id}
+func (h *EnrollmentCampaignHandler) Delete(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.campaignSvc.DeleteCampaign(r.Context(), id); err != nil {
+		log.Printf("Failed to delete enrollment campaign: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete enrollment campaign")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GenerateToken handles POST /api/v1/enrollment-campaigns/{id}/tokens
+func (h *EnrollmentCampaignHandler) GenerateToken(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	token, err := h.campaignSvc.CreateToken(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to create enrollment token for campaign %s: %v", id, err)
+		writeError(w, http.StatusConflict, CodeEnrollmentTokenUnavailable, err.Error())
+		return
+	}
+	if token == nil {
+		writeError(w, http.StatusNotFound, CodeCampaignNotFound, "enrollment campaign not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(token); err != nil {
+		log.Printf("Failed to encode token response: %v", err)
+	}
+}
+
+// extractEnrollmentCampaignIDAndSubpath extracts the ID and optional sub-path
+// from URL paths like /api/v1/enrollment-campaigns/{id} or
+// /api/v1/enrollment-campaigns/{id}/tokens
+func extractEnrollmentCampaignIDAndSubpath(path string) (id, subpath string) {
+	const prefix = "/api/v1/enrollment-campaigns/"
+	if !strings.HasPrefix(path, prefix) {
+		return "", ""
+	}
+	rest := strings.TrimSuffix(strings.TrimPrefix(path, prefix), "/")
+
+	parts := strings.SplitN(rest, "/", 2)
+	id = parts[0]
+	if id == "" {
+		return "", ""
+	}
+	if len(parts) > 1 {
+		subpath = parts[1]
+	}
+	return id, subpath
+}
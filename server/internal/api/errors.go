@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// Machine-readable error codes returned in the "code" field of API error
+// responses. They are part of the API: clients branch on them and
+// localize messages by them, so existing values must not change.
+const (
+	CodeMethodNotAllowed   = "method_not_allowed"
+	CodeInvalidRequestBody = "invalid_request_body"
+	CodeInvalidRequest     = "invalid_request"
+	CodeRequestTooLarge    = "request_too_large"
+	CodeUnauthorized       = "unauthorized"
+	CodeInvalidCredentials = "invalid_credentials"
+	CodeInvalidToken       = "invalid_token"
+	CodeMFARequired        = "mfa_required"
+	CodeForbidden          = "forbidden"
+	CodeInvalidCSRFToken   = "invalid_csrf_token"
+	CodeNotFound           = "not_found"
+	CodeConflict           = "conflict"
+	CodeRateLimited        = "rate_limited"
+	CodeUnavailable        = "unavailable"
+	CodeNotImplemented     = "not_implemented"
+	CodeInternal           = "internal_error"
+
+	CodePolicyNotFound             = "policy_not_found"
+	CodePolicyInvalid              = "policy_invalid"
+	CodeBindingNotFound            = "binding_not_found"
+	CodeBindingInvalid             = "binding_invalid"
+	CodeBindingConflict            = "binding_conflict"
+	CodeNodeNotFound               = "node_not_found"
+	CodeNodeNotConnected           = "node_not_connected"
+	CodeNodeGroupNotFound          = "node_group_not_found"
+	CodeNodeGroupInvalid           = "node_group_invalid"
+	CodeNodeGroupConflict          = "node_group_conflict"
+	CodeNodeFilterNotFound         = "node_filter_not_found"
+	CodeNodeFilterInvalid          = "node_filter_invalid"
+	CodeUserNotFound               = "user_not_found"
+	CodeUserInvalid                = "user_invalid"
+	CodeUserDisabled               = "user_disabled"
+	CodeUserGroupNotFound          = "user_group_not_found"
+	CodeUserGroupInvalid           = "user_group_invalid"
+	CodeUserGroupConflict          = "user_group_conflict"
+	CodeRoleNotFound               = "role_not_found"
+	CodeProfileNotFound            = "profile_not_found"
+	CodeProfileInvalid             = "profile_invalid"
+	CodeWebhookNotFound            = "webhook_not_found"
+	CodeWebhookInvalid             = "webhook_invalid"
+	CodeCampaignNotFound           = "enrollment_campaign_not_found"
+	CodeCampaignInvalid            = "enrollment_campaign_invalid"
+	CodeEnrollmentTokenNotFound    = "enrollment_token_not_found"
+	CodeEnrollmentTokenUnavailable = "enrollment_token_unavailable"
+	CodeLogCaptureNotFound         = "log_capture_not_found"
+	CodeWeakPassword               = "weak_password"
+	CodeInvalidCurrentPassword     = "invalid_current_password"
+	CodeExternalPassword           = "password_managed_externally"
+)
+
+// ErrorResponse is the body of every API error response.
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes an API error: a stable machine-readable code and
+// a human-readable message.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// writeError writes a JSON error response with the given status, code and
+// message.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	resp := ErrorResponse{Error: ErrorDetail{Code: code, Message: message}}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Failed to encode error response: %v", err)
+	}
+}
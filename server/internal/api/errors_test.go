@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeError(rec, http.StatusNotFound, CodePolicyNotFound, "policy not found")
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Error.Code != CodePolicyNotFound || resp.Error.Message != "policy not found" {
+		t.Errorf("error = %+v", resp.Error)
+	}
+}
+
+func TestPolicyHandler_MethodNotAllowedError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewPolicyHandler(nil).List(rec, httptest.NewRequest(http.MethodPost, "/api/v1/policies", http.NoBody))
+
+	var resp ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusMethodNotAllowed || resp.Error.Code != CodeMethodNotAllowed {
+		t.Errorf("got %d %+v, want 405 %s", rec.Code, resp.Error, CodeMethodNotAllowed)
+	}
+}
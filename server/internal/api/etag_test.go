@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func TestWriteJSONWithETag(t *testing.T) {
+	policy := &models.Policy{ID: "p1", Content: `{"a":1}`, Version: 1}
+
+	rec := httptest.NewRecorder()
+	writeJSONWithETag(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody), policy)
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag == "" || rec.Body.Len() == 0 {
+		t.Fatalf("first fetch: status %d, ETag %q, %d bytes", rec.Code, etag, rec.Body.Len())
+	}
+
+	for _, header := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Header.Set("If-None-Match", header)
+		rec = httptest.NewRecorder()
+		writeJSONWithETag(rec, req, policy)
+		if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+			t.Errorf("If-None-Match %q: status %d, %d bytes, want 304 without body", header, rec.Code, rec.Body.Len())
+		}
+	}
+
+	policy.Version = 2
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	writeJSONWithETag(rec, req, policy)
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("changed policy: status %d, ETag %q, want 200 with a new ETag", rec.Code, rec.Header().Get("ETag"))
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// readinessTimeout bounds each readiness check.
+const readinessTimeout = 2 * time.Second
+
+// ReadinessCheck is a component checked by GET /readyz.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// ComponentStatus is the state of one component in a health response.
+type ComponentStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HealthResponse is the payload for GET /healthz and GET /readyz.
+type HealthResponse struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+// IsHealthPath reports whether path is served by the health endpoints,
+// which must reach the HTTP handlers even for gRPC-looking requests.
+func IsHealthPath(path string) bool {
+	return path == "/healthz" || path == "/readyz"
+}
+
+// NewHealthzHandler returns an http.HandlerFunc for GET /healthz, which
+// reports that the process is alive. No authentication is required.
+func NewHealthzHandler() http.HandlerFunc {
+	payload, _ := json.Marshal(HealthResponse{Status: "ok"})
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write(payload)
+	}
+}
+
+// NewReadyzHandler returns an http.HandlerFunc for GET /readyz, which runs
+// every check and reports the status of each component. It responds 503
+// when any check fails. No authentication is required; failures are
+// logged but only summarised in the response.
+func NewReadyzHandler(checks ...ReadinessCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+			return
+		}
+		resp := HealthResponse{Status: "ok", Components: make(map[string]ComponentStatus, len(checks))}
+		code := http.StatusOK
+		for _, c := range checks {
+			ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+			err := c.Check(ctx)
+			cancel()
+			if err != nil {
+				log.Printf("Readiness check %s failed: %v", c.Name, err)
+				resp.Components[c.Name] = ComponentStatus{Status: "unavailable", Error: err.Error()}
+				resp.Status = "unavailable"
+				code = http.StatusServiceUnavailable
+				continue
+			}
+			resp.Components[c.Name] = ComponentStatus{Status: "ok"}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("Failed to encode readiness response: %v", err)
+		}
+	}
+}
+
+// CertificatesValid returns a readiness check that fails when any of certs
+// is missing or outside its validity period.
+func CertificatesValid(certs ...*x509.Certificate) func(context.Context) error {
+	return func(context.Context) error {
+		now := time.Now()
+		for _, c := range certs {
+			switch {
+			case c == nil:
+				return fmt.Errorf("certificate not loaded")
+			case now.Before(c.NotBefore):
+				return fmt.Errorf("certificate %q is not valid before %s", c.Subject.CommonName, c.NotBefore.Format(time.RFC3339))
+			case now.After(c.NotAfter):
+				return fmt.Errorf("certificate %q expired at %s", c.Subject.CommonName, c.NotAfter.Format(time.RFC3339))
+			}
+		}
+		return nil
+	}
+}
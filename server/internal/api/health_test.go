@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadyzHandler(t *testing.T) {
+	ok := ReadinessCheck{Name: "database", Check: func(context.Context) error { return nil }}
+	failing := ReadinessCheck{Name: "tls", Check: func(context.Context) error { return errors.New("certificate not loaded") }}
+
+	tests := []struct {
+		name   string
+		checks []ReadinessCheck
+		code   int
+		status string
+	}{
+		{"all ok", []ReadinessCheck{ok}, http.StatusOK, "ok"},
+		{"one failing", []ReadinessCheck{ok, failing}, http.StatusServiceUnavailable, "unavailable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewReadyzHandler(tt.checks...)(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if rec.Code != tt.code {
+				t.Fatalf("status code = %d, want %d", rec.Code, tt.code)
+			}
+			var resp HealthResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Status != tt.status || len(resp.Components) != len(tt.checks) {
+				t.Errorf("response = %+v", resp)
+			}
+			if resp.Components["database"].Status != "ok" {
+				t.Errorf("database = %+v, want ok", resp.Components["database"])
+			}
+		})
+	}
+}
+
+func TestHealthzHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHealthzHandler()(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != `{"status":"ok"}` {
+		t.Errorf("GET /healthz = %d %q", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	NewHealthzHandler()(rec, httptest.NewRequest(http.MethodPost, "/healthz", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /healthz = %d, want 405", rec.Code)
+	}
+}
+
+func TestCertificatesValid(t *testing.T) {
+	now := time.Now()
+	valid := &x509.Certificate{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)}
+	expired := &x509.Certificate{NotBefore: now.Add(-2 * time.Hour), NotAfter: now.Add(-time.Hour)}
+	ctx := context.Background()
+
+	if err := CertificatesValid(valid)(ctx); err != nil {
+		t.Errorf("valid certificate: %v", err)
+	}
+	if err := CertificatesValid(valid, expired)(ctx); err == nil {
+		t.Error("expired certificate: want error")
+	}
+	if err := CertificatesValid(valid, nil)(ctx); err == nil {
+		t.Error("missing certificate: want error")
+	}
+}
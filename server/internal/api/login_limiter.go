@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// loginLimiter limits login attempts per username and per client IP within
+// a sliding window. Unlike ipRateLimiter it keeps the time of every attempt
+// in the window, so a burst spanning a window boundary is not let through.
+// Limiting by username slows guessing of one account from many addresses,
+// including LDAP usernames that have no local record to lock yet.
+type loginLimiter struct {
+	mu        sync.Mutex
+	attempts  map[string][]time.Time
+	max       int
+	window    time.Duration
+	lastSweep time.Time
+}
+
+func newLoginLimiter(maxAttempts int, window time.Duration) *loginLimiter {
+	return &loginLimiter{
+		attempts: make(map[string][]time.Time),
+		max:      maxAttempts,
+		window:   window,
+	}
+}
+
+// allow records a login attempt for username from ip at now if neither is
+// over the limit. Otherwise it returns false and how long until the next
+// attempt is accepted.
+func (l *loginLimiter) allow(username, ip string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= l.window {
+		l.sweep(now)
+	}
+
+	keys := []string{"ip:" + ip}
+	if username != "" {
+		keys = append(keys, "user:"+strings.ToLower(username))
+	}
+
+	var retryAfter time.Duration
+	for _, k := range keys {
+		recent := l.recent(k, now)
+		if len(recent) >= l.max {
+			retryAfter = max(retryAfter, recent[0].Add(l.window).Sub(now))
+		}
+	}
+	if retryAfter > 0 {
+		return false, retryAfter
+	}
+
+	for _, k := range keys {
+		l.attempts[k] = append(l.attempts[k], now)
+	}
+	return true, 0
+}
+
+// recent drops the attempts of key that fell out of the window and returns
+// the rest, oldest first.
+func (l *loginLimiter) recent(key string, now time.Time) []time.Time {
+	ts := l.attempts[key]
+	i := 0
+	for i < len(ts) && now.Sub(ts[i]) >= l.window {
+		i++
+	}
+	ts = ts[i:]
+	if len(ts) == 0 {
+		delete(l.attempts, key)
+	} else {
+		l.attempts[key] = ts
+	}
+	return ts
+}
+
+// sweep removes keys without attempts in the window to bound memory use.
+func (l *loginLimiter) sweep(now time.Time) {
+	for k := range l.attempts {
+		l.recent(k, now)
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoginLimiter_PerUsername(t *testing.T) {
+	l := newLoginLimiter(3, time.Minute)
+	now := time.Now()
+
+	// Attempts for one account from different addresses share a limit.
+	for i, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		if ok, _ := l.allow("Alice", ip, now); !ok {
+			t.Fatalf("attempt %d rejected, want allowed", i+1)
+		}
+	}
+	ok, retryAfter := l.allow("alice", "10.0.0.4", now.Add(10*time.Second))
+	if ok {
+		t.Fatal("4th attempt for alice allowed, want rejected")
+	}
+	if retryAfter != 50*time.Second {
+		t.Errorf("retryAfter = %v, want 50s", retryAfter)
+	}
+
+	// Other accounts are not affected.
+	if ok, _ := l.allow("bob", "10.0.0.4", now); !ok {
+		t.Error("attempt for bob rejected, want allowed")
+	}
+
+	// The window slides: attempts older than a minute no longer count.
+	if ok, _ := l.allow("alice", "10.0.0.5", now.Add(time.Minute)); !ok {
+		t.Error("attempt after the window rejected, want allowed")
+	}
+}
+
+func TestLoginLimiter_PerIP(t *testing.T) {
+	l := newLoginLimiter(2, time.Minute)
+	now := time.Now()
+
+	l.allow("alice", "10.0.0.1", now)
+	l.allow("bob", "10.0.0.1", now)
+	if ok, _ := l.allow("carol", "10.0.0.1", now); ok {
+		t.Error("3rd attempt from one IP allowed, want rejected")
+	}
+	// A rejected attempt is not counted against carol.
+	if ok, _ := l.allow("carol", "10.0.0.2", now); !ok {
+		t.Error("attempt for carol from another IP rejected, want allowed")
+	}
+}
+
+func TestLoginLimiter_Sweep(t *testing.T) {
+	l := newLoginLimiter(5, time.Minute)
+	now := time.Now()
+
+	l.allow("alice", "10.0.0.1", now)
+	l.allow("bob", "10.0.0.2", now.Add(2*time.Minute))
+
+	if _, ok := l.attempts["user:alice"]; ok {
+		t.Error("expired attempts of alice were not swept")
+	}
+	if len(l.attempts) != 2 {
+		t.Errorf("len(attempts) = %d, want 2 (bob's user and IP keys)", len(l.attempts))
+	}
+}
+
+func TestAuthHandler_Login_Throttled(t *testing.T) {
+	h := NewAuthHandler(nil, nil, nil).WithLoginLimit(1, time.Minute)
+	h.loginLimiter.allow("alice", "192.0.2.1", time.Now())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login",
+		strings.NewReader(`{"username":"alice","password":"guess"}`))
+	req.RemoteAddr = "198.51.100.7:4321"
+	rec := httptest.NewRecorder()
+
+	h.Login(rec, req)
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("Retry-After header not set")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"log"
 	"net"
 	"net/http"
 	"strconv"
@@ -15,6 +16,7 @@ import (
 	"sync"
 	"time"
 
+	auditsink "github.com/VuteTech/Bor/server/internal/audit"
 	"github.com/VuteTech/Bor/server/internal/authz"
 	"github.com/VuteTech/Bor/server/internal/services"
 )
@@ -28,19 +30,42 @@ const SessionCookieName = "bor_session"
 
 // AuthMiddleware validates JWT tokens on protected routes.
 // It checks the bor_session httpOnly cookie first, then falls back to the
-// Authorization: Bearer header (for API clients and the agent).
+// Authorization: Bearer header (for API clients and the agent). Requests
+// without a token are authenticated by their TLS client certificate when
+// client certificate authentication is enabled.
 func AuthMiddleware(authSvc *services.AuthService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			token := tokenFromRequest(r)
+			if token == "" && r.TLS != nil && len(r.TLS.PeerCertificates) > 0 && authSvc.ClientCertAuthEnabled() {
+				claims, err := authSvc.AuthenticateClientCert(r.Context(), r.TLS.PeerCertificates)
+				if err != nil {
+					log.Printf("Client certificate authentication failed: %v", err)
+					writeError(w, http.StatusUnauthorized, CodeInvalidCredentials, "invalid client certificate")
+					return
+				}
+				ctx := context.WithValue(r.Context(), userContextKey, claims)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
 			if token == "" {
-				http.Error(w, `{"error":"authorization required"}`, http.StatusUnauthorized)
+				writeError(w, http.StatusUnauthorized, CodeUnauthorized, "authorization required")
 				return
 			}
 
 			claims, err := authSvc.ValidateToken(token)
 			if err != nil {
-				http.Error(w, `{"error":"invalid or expired token"}`, http.StatusUnauthorized)
+				writeError(w, http.StatusUnauthorized, CodeInvalidToken, "invalid or expired token")
+				return
+			}
+			revoked, err := authSvc.IsTokenRevoked(r.Context(), claims.ID)
+			if err != nil {
+				log.Printf("Failed to check token revocation: %v", err)
+				writeError(w, http.StatusInternalServerError, CodeInternal, "failed to validate token")
+				return
+			}
+			if revoked {
+				writeError(w, http.StatusUnauthorized, CodeInvalidToken, "invalid or expired token")
 				return
 			}
 
@@ -79,12 +104,24 @@ func SetSessionCookie(w http.ResponseWriter, token string, maxAge int) {
 // RefreshCookieName is the name of the httpOnly cookie that carries the refresh token.
 const RefreshCookieName = "bor_refresh"
 
+// refreshCookiePath limits the refresh cookie to the auth endpoints, so
+// that it reaches both /auth/refresh and /auth/logout, which revokes it.
+const refreshCookiePath = "/api/v1/auth/"
+
+// legacyRefreshCookiePath is the path refresh cookies were set with
+// before logout revoked them; such cookies are still cleared.
+const legacyRefreshCookiePath = "/api/v1/auth/refresh"
+
 // SetRefreshCookie sets the bor_refresh httpOnly cookie on the response.
 func SetRefreshCookie(w http.ResponseWriter, token string, maxAge int) {
+	setRefreshCookie(w, token, maxAge, refreshCookiePath)
+}
+
+func setRefreshCookie(w http.ResponseWriter, token string, maxAge int, path string) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     RefreshCookieName,
 		Value:    token,
-		Path:     "/api/v1/auth/refresh",
+		Path:     path,
 		MaxAge:   maxAge,
 		HttpOnly: true,
 		Secure:   true,
@@ -96,6 +133,7 @@ func SetRefreshCookie(w http.ResponseWriter, token string, maxAge int) {
 func ClearSessionCookie(w http.ResponseWriter) {
 	SetSessionCookie(w, "", -1)
 	SetRefreshCookie(w, "", -1)
+	setRefreshCookie(w, "", -1, legacyRefreshCookiePath)
 	clearCSRFCookie(w)
 }
 
@@ -161,13 +199,13 @@ func CSRFMiddleware(next http.Handler) http.Handler {
 
 		cookie, err := r.Cookie(CSRFCookieName)
 		if err != nil || cookie.Value == "" {
-			http.Error(w, `{"error":"missing CSRF token"}`, http.StatusForbidden)
+			writeError(w, http.StatusForbidden, CodeInvalidCSRFToken, "missing CSRF token")
 			return
 		}
 
 		header := r.Header.Get("X-CSRF-Token")
 		if header == "" || header != cookie.Value {
-			http.Error(w, `{"error":"invalid CSRF token"}`, http.StatusForbidden)
+			writeError(w, http.StatusForbidden, CodeInvalidCSRFToken, "invalid CSRF token")
 			return
 		}
 
@@ -253,7 +291,7 @@ func NewRateLimitMiddleware(rate int, window time.Duration) func(http.Handler) h
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if !rl.allow(clientIP(r)) {
 				w.Header().Set("Retry-After", retryAfter)
-				http.Error(w, `{"error":"rate limit exceeded, try again later"}`, http.StatusTooManyRequests)
+				writeError(w, http.StatusTooManyRequests, CodeRateLimited, "rate limit exceeded, try again later")
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -261,23 +299,70 @@ func NewRateLimitMiddleware(rate int, window time.Duration) func(http.Handler) h
 	}
 }
 
-// SecurityHeadersMiddleware adds standard security headers to all HTTP responses.
-// Applied to the UI/API handler chain (not gRPC).
-func SecurityHeadersMiddleware(next http.Handler) http.Handler {
+// DefaultContentSecurityPolicy allows the embedded web UI to load its own
+// scripts, styles and images only, and forbids framing.
+const DefaultContentSecurityPolicy = "default-src 'self'; script-src 'self'; style-src 'self' 'unsafe-inline'; " +
+	"img-src 'self' data: blob:; connect-src 'self'; frame-ancestors 'none'"
+
+// NewSecurityHeadersMiddleware returns a middleware adding standard security
+// headers to all HTTP responses, with csp as the Content-Security-Policy
+// (empty for DefaultContentSecurityPolicy). Applied to the UI/API handler
+// chain (not gRPC).
+func NewSecurityHeadersMiddleware(csp string) func(http.Handler) http.Handler {
+	if csp == "" {
+		csp = DefaultContentSecurityPolicy
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("Content-Security-Policy", csp)
+			h.Set("X-Frame-Options", "DENY")
+			h.Set("X-Content-Type-Options", "nosniff")
+			h.Set("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
+			h.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+			h.Set("Permissions-Policy", "camera=(), microphone=(), geolocation=()")
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequestIDHeader carries the correlation ID of an API request.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware assigns every request a correlation ID, stores it in
+// the request context for the audit log and echoes it in the response. A
+// well-formed X-Request-ID sent by the client (for example by a reverse
+// proxy) is kept; anything else is replaced by a random ID.
+func RequestIDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		h := w.Header()
-		h.Set("Content-Security-Policy",
-			"default-src 'self'; script-src 'self'; style-src 'self' 'unsafe-inline'; "+
-				"img-src 'self' data: blob:; connect-src 'self'; frame-ancestors 'none'")
-		h.Set("X-Frame-Options", "DENY")
-		h.Set("X-Content-Type-Options", "nosniff")
-		h.Set("Strict-Transport-Security", "max-age=63072000; includeSubDomains")
-		h.Set("Referrer-Policy", "strict-origin-when-cross-origin")
-		h.Set("Permissions-Policy", "camera=(), microphone=(), geolocation=()")
-		next.ServeHTTP(w, r)
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			id = hex.EncodeToString(b)
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(auditsink.WithCorrelationID(r.Context(), id)))
 	})
 }
 
+// validRequestID accepts IDs of up to 64 letters, digits, '-', '_', '.'
+// and ':' so client-supplied values cannot inject into logs or exports.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // RequirePermission checks that the authenticated user has a specific permission
 // via the Authorizer. It replaces hardcoded role checks like AdminOnly.
 func RequirePermission(az authz.Authorizer, resource, action string) func(http.Handler) http.Handler {
@@ -285,18 +370,18 @@ func RequirePermission(az authz.Authorizer, resource, action string) func(http.H
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			claims := GetUserFromContext(r.Context())
 			if claims == nil {
-				http.Error(w, `{"error":"authentication required"}`, http.StatusUnauthorized)
+				writeError(w, http.StatusUnauthorized, CodeUnauthorized, "authentication required")
 				return
 			}
 
 			scopeType := "global"
 			allowed, err := az.HasPermission(r.Context(), claims.UserID, resource, action, scopeType, nil)
 			if err != nil {
-				http.Error(w, `{"error":"authorization check failed"}`, http.StatusInternalServerError)
+				writeError(w, http.StatusInternalServerError, CodeInternal, "authorization check failed")
 				return
 			}
 			if !allowed {
-				http.Error(w, `{"error":"insufficient permissions"}`, http.StatusForbidden)
+				writeError(w, http.StatusForbidden, CodeForbidden, "insufficient permissions")
 				return
 			}
 
@@ -319,7 +404,7 @@ func RequireMethodPermission(az authz.Authorizer, perms []MethodPermission) func
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			claims := GetUserFromContext(r.Context())
 			if claims == nil {
-				http.Error(w, `{"error":"authentication required"}`, http.StatusUnauthorized)
+				writeError(w, http.StatusUnauthorized, CodeUnauthorized, "authentication required")
 				return
 			}
 
@@ -334,18 +419,18 @@ func RequireMethodPermission(az authz.Authorizer, perms []MethodPermission) func
 				}
 			}
 			if !found {
-				http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+				writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 				return
 			}
 
 			scopeType := "global"
 			allowed, err := az.HasPermission(r.Context(), claims.UserID, resource, action, scopeType, nil)
 			if err != nil {
-				http.Error(w, `{"error":"authorization check failed"}`, http.StatusInternalServerError)
+				writeError(w, http.StatusInternalServerError, CodeInternal, "authorization check failed")
 				return
 			}
 			if !allowed {
-				http.Error(w, `{"error":"insufficient permissions"}`, http.StatusForbidden)
+				writeError(w, http.StatusForbidden, CodeForbidden, "insufficient permissions")
 				return
 			}
 
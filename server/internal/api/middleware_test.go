@@ -6,10 +6,14 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	auditsink "github.com/VuteTech/Bor/server/internal/audit"
 	"github.com/VuteTech/Bor/server/internal/authz"
 	"github.com/VuteTech/Bor/server/internal/services"
 )
@@ -31,6 +35,44 @@ func TestAuthMiddleware_MissingHeader(t *testing.T) {
 	}
 }
 
+func TestAuthMiddleware_ClientCertIgnoredWhenDisabled(t *testing.T) {
+	middleware := AuthMiddleware(&services.AuthService{})
+
+	handler := middleware(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Fatal("handler should not be called")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/me", http.NoBody)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{}}}
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), "authorization required") {
+		t.Errorf("got %d %q, want 401 authorization required", rec.Code, rec.Body.String())
+	}
+}
+
+func TestAuthMiddleware_UntrustedClientCert(t *testing.T) {
+	authSvc := (&services.AuthService{}).WithClientCertAuth(
+		services.NewClientCertAuth(x509.NewCertPool(), map[string]string{"ci-pipeline": "svc-ci"}))
+	middleware := AuthMiddleware(authSvc)
+
+	handler := middleware(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Fatal("handler should not be called")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/me", http.NoBody)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{}}}
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), "invalid client certificate") {
+		t.Errorf("got %d %q, want 401 invalid client certificate", rec.Code, rec.Body.String())
+	}
+}
+
 func TestAuthMiddleware_InvalidFormat(t *testing.T) {
 	middleware := AuthMiddleware(nil)
 
@@ -319,3 +361,65 @@ func TestRequireMethodPermission_AllEndpoints_DenyByDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantKeep bool
+	}{
+		{"generated when missing", "", false},
+		{"client ID kept", "req-42:abc.DEF_1", true},
+		{"invalid characters replaced", "id\nforged=entry", false},
+		{"too long replaced", strings.Repeat("a", 65), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := RequestIDMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				seen = auditsink.CorrelationID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/policies/all", http.NoBody)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			got := rr.Header().Get(RequestIDHeader)
+			if got == "" || got != seen {
+				t.Fatalf("response ID %q, context ID %q; want equal and non-empty", got, seen)
+			}
+			if tt.wantKeep != (got == tt.header) {
+				t.Errorf("ID = %q, header %q, wantKeep %v", got, tt.header, tt.wantKeep)
+			}
+			if !validRequestID(got) {
+				t.Errorf("ID %q is not valid", got)
+			}
+		})
+	}
+}
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	rec := httptest.NewRecorder()
+	NewSecurityHeadersMiddleware("")(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	for header, want := range map[string]string{
+		"Content-Security-Policy":   DefaultContentSecurityPolicy,
+		"X-Frame-Options":           "DENY",
+		"X-Content-Type-Options":    "nosniff",
+		"Strict-Transport-Security": "max-age=63072000; includeSubDomains",
+	} {
+		if got := rec.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	NewSecurityHeadersMiddleware("default-src *")(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := rec.Header().Get("Content-Security-Policy"); got != "default-src *" {
+		t.Errorf("custom Content-Security-Policy = %q", got)
+	}
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+	"github.com/VuteTech/Bor/server/internal/services"
+)
+
+// NodeFilterHandler handles saved node filter API endpoints
+type NodeFilterHandler struct {
+	filterSvc *services.NodeFilterService
+	// OnFilterChange is called after a filter is updated or deleted, since
+	// that may change which nodes receive the policies bound to it.
+	OnFilterChange func(filterID string)
+}
+
+// NewNodeFilterHandler creates a new NodeFilterHandler
+func NewNodeFilterHandler(filterSvc *services.NodeFilterService) *NodeFilterHandler {
+	return &NodeFilterHandler{filterSvc: filterSvc}
+}
+
+// ServeHTTP routes /api/v1/node-filters/{id} and sub-paths
+func (h *NodeFilterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, subpath := extractNodeFilterIDAndSubpath(r.URL.Path)
+
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.List(w, r)
+		case http.MethodPost:
+			h.Create(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	// Handle /api/v1/node-filters/{id}/nodes
+	if subpath == "nodes" {
+		h.ListNodes(w, r, id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.Get(w, r, id)
+	case http.MethodPut:
+		h.Update(w, r, id)
+	case http.MethodDelete:
+		h.Delete(w, r, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+	}
+}
+
+// List handles GET /api/v1/node-filters
+func (h *NodeFilterHandler) List(w http.ResponseWriter, r *http.Request) {
+	filters, err := h.filterSvc.ListFilters(r.Context())
+	if err != nil {
+		log.Printf("Failed to list node filters: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list node filters")
+		return
+	}
+
+	if filters == nil {
+		filters = []*models.NodeFilter{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filters); err != nil {
+		log.Printf("Failed to encode node filters response: %v", err)
+	}
+}
+
+// Create handles POST /api/v1/node-filters
+func (h *NodeFilterHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateNodeFilterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	filter, err := h.filterSvc.CreateFilter(r.Context(), &req)
+	if err != nil {
+		log.Printf("Failed to create node filter: %v", err)
+		writeError(w, http.StatusBadRequest, CodeNodeFilterInvalid, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(filter); err != nil {
+		log.Printf("Failed to encode node filter response: %v", err)
+	}
+}
+
+// Get handles GET /api/v1/node-filters/{id}
+func (h *NodeFilterHandler) Get(w http.ResponseWriter, r *http.Request, id string) {
+	filter, err := h.filterSvc.GetFilter(r.Context(), id)
+	if err != nil || filter == nil {
+		writeError(w, http.StatusNotFound, CodeNodeFilterNotFound, "node filter not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filter); err != nil {
+		log.Printf("Failed to encode node filter response: %v", err)
+	}
+}
+
+// Update handles PUT /api/v1/node-filters/{id}
+func (h *NodeFilterHandler) Update(w http.ResponseWriter, r *http.Request, id string) {
+	var req models.UpdateNodeFilterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	filter, err := h.filterSvc.UpdateFilter(r.Context(), id, &req)
+	if err != nil {
+		log.Printf("Failed to update node filter: %v", err)
+		writeError(w, http.StatusBadRequest, CodeNodeFilterInvalid, err.Error())
+		return
+	}
+
+	if h.OnFilterChange != nil && req.Criteria != nil {
+		h.OnFilterChange(id)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filter); err != nil {
+		log.Printf("Failed to encode node filter response: %v", err)
+	}
+}
+
+// Delete handles DELETE /api/v1/node-filters/{id}
+func (h *NodeFilterHandler) Delete(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.filterSvc.DeleteFilter(r.Context(), id); err != nil {
+		log.Printf("Failed to delete node filter: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete node filter")
+		return
+	}
+
+	if h.OnFilterChange != nil {
+		h.OnFilterChange(id)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListNodes handles GET /api/v1/node-filters/{id}/nodes and returns the
+// nodes currently matching the filter.
+func (h *NodeFilterHandler) ListNodes(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	nodes, err := h.filterSvc.ListMatchingNodes(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to list nodes for node filter %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to evaluate node filter")
+		return
+	}
+	if nodes == nil {
+		writeError(w, http.StatusNotFound, CodeNodeFilterNotFound, "node filter not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(nodes); err != nil {
+		log.Printf("Failed to encode nodes response: %v", err)
+	}
+}
+
+// extractNodeFilterIDAndSubpath extracts the ID and optional sub-path from
+// URL paths like /api/v1/node-filters/{id} or /api/v1/node-filters/{id}/nodes
+func extractNodeFilterIDAndSubpath(path string) (id, subpath string) {
+	const prefix = "/api/v1/node-filters/"
+	if !strings.HasPrefix(path, prefix) {
+		return "", ""
+	}
+	rest := strings.TrimSuffix(strings.TrimPrefix(path, prefix), "/")
+
+	parts := strings.SplitN(rest, "/", 2)
+	id = parts[0]
+	if id == "" {
+		return "", ""
+	}
+	if len(parts) > 1 {
+		subpath = parts[1]
+	}
+	return id, subpath
+}
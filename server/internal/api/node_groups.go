@@ -5,10 +5,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/VuteTech/Bor/server/internal/models"
 	"github.com/VuteTech/Bor/server/internal/services"
@@ -18,6 +22,16 @@ import (
 type NodeGroupHandler struct {
 	nodeGroupSvc *services.NodeGroupService
 	enrollSvc    *services.EnrollmentService
+	// OnInventoryOnlyChange is called after a group's inventory-only flag
+	// was changed, so member agents can switch between enforcing and
+	// monitoring.
+	OnInventoryOnlyChange func(groupID string)
+	// OnQuarantineChange is called after a group's quarantine flag was
+	// changed, so quarantined agents pick up the new lockdown policy set.
+	OnQuarantineChange func(groupID string)
+	// OnMembershipChange is called after nodes were added to or removed
+	// from a group in bulk, so their agents resync.
+	OnMembershipChange func(groupID string)
 }
 
 // NewNodeGroupHandler creates a new NodeGroupHandler
@@ -28,33 +42,47 @@ func NewNodeGroupHandler(nodeGroupSvc *services.NodeGroupService, enrollSvc *ser
 	}
 }
 
+// nodeGroupWithCount is a node group in list responses.
+type nodeGroupWithCount struct {
+	models.NodeGroup
+	NodeCount int `json:"node_count"`
+}
+
 // List handles GET /api/v1/node-groups
 func (h *NodeGroupHandler) List(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if writeList(w, r, "node groups", func(page models.Pagination) (*models.ListResponse[nodeGroupWithCount], error) {
+		resp, err := h.nodeGroupSvc.ListNodeGroupsPaginated(r.Context(), page)
+		if err != nil {
+			return nil, err
+		}
+		return models.NewListResponse(h.withNodeCounts(r.Context(), resp.Items), resp.Total, page), nil
+	}) {
 		return
 	}
 
 	groups, err := h.nodeGroupSvc.ListNodeGroups(r.Context())
 	if err != nil {
 		log.Printf("Failed to list node groups: %v", err)
-		http.Error(w, `{"error":"failed to list node groups"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list node groups")
 		return
 	}
 
-	if groups == nil {
-		groups = []*models.NodeGroup{}
-	}
-
-	// Build response with node counts
-	type nodeGroupWithCount struct {
-		models.NodeGroup
-		NodeCount int `json:"node_count"`
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.withNodeCounts(r.Context(), groups)); err != nil {
+		log.Printf("Failed to encode node groups response: %v", err)
 	}
+}
 
+// withNodeCounts adds the number of member nodes to each group.
+func (h *NodeGroupHandler) withNodeCounts(ctx context.Context, groups []*models.NodeGroup) []nodeGroupWithCount {
 	result := make([]nodeGroupWithCount, 0, len(groups))
 	for _, g := range groups {
-		count, err := h.nodeGroupSvc.CountNodesByGroupID(r.Context(), g.ID)
+		count, err := h.nodeGroupSvc.CountNodesByGroupID(ctx, g.ID)
 		if err != nil {
 			log.Printf("Failed to count nodes for group %s: %v", g.ID, err)
 			count = 0
@@ -64,35 +92,26 @@ func (h *NodeGroupHandler) List(w http.ResponseWriter, r *http.Request) {
 			NodeCount: count,
 		})
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(result); err != nil {
-		log.Printf("Failed to encode node groups response: %v", err)
-	}
+	return result
 }
 
 // Create handles POST /api/v1/node-groups
 func (h *NodeGroupHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req models.CreateNodeGroupRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	group, err := h.nodeGroupSvc.CreateNodeGroup(r.Context(), &req)
 	if err != nil {
 		log.Printf("Failed to create node group: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodeNodeGroupInvalid, err.Error())
 		return
 	}
 
@@ -114,7 +133,7 @@ func (h *NodeGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case http.MethodPost:
 			h.Create(w, r)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
@@ -124,6 +143,14 @@ func (h *NodeGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.GenerateToken(w, r, id)
 		return
 	}
+	if tokenID, ok := strings.CutPrefix(subpath, "tokens/"); ok && tokenID != "" {
+		h.RevokeToken(w, r, id, tokenID)
+		return
+	}
+	if subpath == "members" {
+		h.Members(w, r, id)
+		return
+	}
 
 	switch r.Method {
 	case http.MethodGet:
@@ -133,15 +160,21 @@ func (h *NodeGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodDelete:
 		h.Delete(w, r, id)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
-// Get handles GET /api/v1/node-groups/{id}
+// Get handles GET /api/v1/node-groups/{id}, returning the group with its
+// member nodes and policy bindings.
 func (h *NodeGroupHandler) Get(w http.ResponseWriter, r *http.Request, id string) {
-	group, err := h.nodeGroupSvc.GetNodeGroup(r.Context(), id)
-	if err != nil || group == nil {
-		http.Error(w, `{"error":"node group not found"}`, http.StatusNotFound)
+	group, err := h.nodeGroupSvc.GetWithDetails(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to get node group %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to get node group")
+		return
+	}
+	if group == nil {
+		writeError(w, http.StatusNotFound, CodeNodeGroupNotFound, "node group not found")
 		return
 	}
 
@@ -151,26 +184,73 @@ func (h *NodeGroupHandler) Get(w http.ResponseWriter, r *http.Request, id string
 	}
 }
 
+// Members handles POST and DELETE /api/v1/node-groups/{id}/members, adding
+// or removing the nodes listed in the body in one statement.
+func (h *NodeGroupHandler) Members(w http.ResponseWriter, r *http.Request, id string) {
+	var change func(context.Context, string, *models.NodeGroupMembersRequest) (*models.NodeGroupMembersResponse, error)
+	switch r.Method {
+	case http.MethodPost:
+		change = h.nodeGroupSvc.AddMembers
+	case http.MethodDelete:
+		change = h.nodeGroupSvc.RemoveMembers
+	default:
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req models.NodeGroupMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+	if err := services.ValidateNodeGroupMembersRequest(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeNodeGroupInvalid, err.Error())
+		return
+	}
+
+	resp, err := change(r.Context(), id, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, CodeNodeGroupNotFound, "node group not found")
+			return
+		}
+		log.Printf("Failed to change members of node group %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to change node group members")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Failed to encode node group members response: %v", err)
+	}
+
+	if h.OnMembershipChange != nil && resp.Added+resp.Removed > 0 {
+		h.OnMembershipChange(id)
+	}
+}
+
 // Update handles PUT /api/v1/node-groups/{id}
 func (h *NodeGroupHandler) Update(w http.ResponseWriter, r *http.Request, id string) {
 	var req models.UpdateNodeGroupRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	group, err := h.nodeGroupSvc.UpdateNodeGroup(r.Context(), id, &req)
 	if err != nil {
 		log.Printf("Failed to update node group: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodeNodeGroupInvalid, err.Error())
 		return
 	}
 
+	if h.OnInventoryOnlyChange != nil && req.InventoryOnly != nil {
+		h.OnInventoryOnlyChange(id)
+	}
+	if h.OnQuarantineChange != nil && req.Quarantine != nil {
+		h.OnQuarantineChange(id)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(group); err != nil {
 		log.Printf("Failed to encode node group response: %v", err)
@@ -181,12 +261,7 @@ func (h *NodeGroupHandler) Update(w http.ResponseWriter, r *http.Request, id str
 func (h *NodeGroupHandler) Delete(w http.ResponseWriter, r *http.Request, id string) {
 	if err := h.nodeGroupSvc.DeleteNodeGroup(r.Context(), id); err != nil {
 		log.Printf("Failed to delete node group: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusConflict)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusConflict, CodeNodeGroupConflict, err.Error())
 		return
 	}
 
@@ -197,21 +272,41 @@ func (h *NodeGroupHandler) Delete(w http.ResponseWriter, r *http.Request, id str
 // GenerateToken handles POST /api/v1/node-groups/{id}/tokens
 func (h *NodeGroupHandler) GenerateToken(w http.ResponseWriter, r *http.Request, groupID string) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req models.CreateEnrollmentTokenRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+			return
+		}
+	}
+	if req.MaxUses == 0 {
+		req.MaxUses = 1
+	}
+	if req.MaxUses < 1 || req.MaxUses > services.MaxEnrollmentTokenUses {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("max_uses must be between 1 and %d", services.MaxEnrollmentTokenUses))
+		return
+	}
+	maxTTLSeconds := int(services.MaxEnrollmentTokenTTL / time.Second)
+	if req.TTLSeconds < 0 || req.TTLSeconds > maxTTLSeconds {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("ttl_seconds must be between 1 and %d", maxTTLSeconds))
 		return
 	}
 
 	// Verify the group exists
 	group, err := h.nodeGroupSvc.GetNodeGroup(r.Context(), groupID)
 	if err != nil || group == nil {
-		http.Error(w, `{"error":"node group not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeNodeGroupNotFound, "node group not found")
 		return
 	}
 
-	token, err := h.enrollSvc.CreateToken(groupID)
+	token, err := h.enrollSvc.CreateMultiUseToken(groupID, req.MaxUses, time.Duration(req.TTLSeconds)*time.Second)
 	if err != nil {
 		log.Printf("Failed to create enrollment token: %v", err)
-		http.Error(w, `{"error":"failed to create enrollment token"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to create enrollment token")
 		return
 	}
 
@@ -222,6 +317,39 @@ func (h *NodeGroupHandler) GenerateToken(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// RevokeToken handles DELETE /api/v1/node-groups/{id}/tokens/{tokenID}. The
+// path carries the token ID returned at creation, never the token itself,
+// since paths end up in access and audit logs. The audit middleware records
+// the revocation together with the admin who made it.
+func (h *NodeGroupHandler) RevokeToken(w http.ResponseWriter, r *http.Request, groupID, tokenID string) {
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	revokedBy := ""
+	if claims := GetUserFromContext(r.Context()); claims != nil {
+		revokedBy = claims.Username
+	}
+
+	token, err := h.enrollSvc.RevokeToken(groupID, tokenID, revokedBy)
+	if errors.Is(err, services.ErrEnrollmentTokenNotFound) {
+		writeError(w, http.StatusNotFound, CodeEnrollmentTokenNotFound, "enrollment token not found")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to revoke enrollment token: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to revoke enrollment token")
+		return
+	}
+
+	log.Printf("Enrollment token %s for node group %s revoked by %s (%d of %d uses consumed)",
+		token.ID, groupID, token.RevokedBy, token.UseCount, token.MaxUses)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // extractNodeGroupIDAndSubpath extracts the ID and optional sub-path from
 // URL paths like /api/v1/node-groups/{id} or /api/v1/node-groups/{id}/tokens
 func extractNodeGroupIDAndSubpath(path string) (id, subpath string) {
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/pki"
+	"github.com/VuteTech/Bor/server/internal/services"
+)
+
+func newTestEnrollmentService(t *testing.T) *services.EnrollmentService {
+	t.Helper()
+	certPath, keyPath, err := pki.EnsureCA(t.TempDir(), pki.CertOptions{})
+	if err != nil {
+		t.Fatalf("EnsureCA() error = %v", err)
+	}
+	cert, key, err := pki.LoadCA(certPath, keyPath)
+	if err != nil {
+		t.Fatalf("LoadCA() error = %v", err)
+	}
+	return services.NewEnrollmentService(cert, key, nil, nil, nil)
+}
+
+func TestNodeGroupHandler_RevokeToken(t *testing.T) {
+	enrollSvc := newTestEnrollmentService(t)
+	handler := NewNodeGroupHandler(nil, enrollSvc)
+
+	token, err := enrollSvc.CreateToken("group-1")
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, reqWithUser(http.MethodDelete, "/api/v1/node-groups/group-1/tokens/"+token.Token))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("revoke by secret: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, reqWithUser(http.MethodDelete, "/api/v1/node-groups/group-2/tokens/"+token.ID))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("revoke via another group: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, reqWithUser(http.MethodDelete, "/api/v1/node-groups/group-1/tokens/"+token.ID))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("revoke: status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+
+	revoked, err := enrollSvc.RevokeToken("group-1", token.ID, "someone-else")
+	if err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if revoked.RevokedBy != "tester" {
+		t.Errorf("RevokedBy = %q, want %q", revoked.RevokedBy, "tester")
+	}
+	if _, err := enrollSvc.RedeemToken(token.Token); err == nil {
+		t.Error("RedeemToken() should fail for a revoked token")
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, reqWithUser(http.MethodGet, "/api/v1/node-groups/group-1/tokens/"+token.ID))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET token: status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestNodeGroupHandler_Members_Validation(t *testing.T) {
+	handler := NewNodeGroupHandler(nil, nil)
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"wrong method", http.MethodPut, `{"node_ids":["a"]}`, http.StatusMethodNotAllowed},
+		{"invalid body", http.MethodPost, `{`, http.StatusBadRequest},
+		{"no nodes", http.MethodPost, `{"node_ids":[]}`, http.StatusBadRequest},
+		{"empty id", http.MethodDelete, `{"node_ids":["a",""]}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/api/v1/node-groups/group-1/members", strings.NewReader(tt.body))
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeGroupHandler_GenerateToken_Validation(t *testing.T) {
+	handler := NewNodeGroupHandler(nil, nil)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid body", `{`},
+		{"too many uses", `{"max_uses":10001}`},
+		{"negative ttl", `{"max_uses":5,"ttl_seconds":-1}`},
+		{"ttl too long", `{"max_uses":5,"ttl_seconds":604801}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/node-groups/group-1/tokens", strings.NewReader(tt.body))
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
@@ -6,12 +6,15 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/VuteTech/Bor/server/internal/models"
 	"github.com/VuteTech/Bor/server/internal/services"
+	"github.com/VuteTech/Bor/server/pkg/remediation"
 )
 
 // MetadataRequestSender can push a metadata refresh request to a named agent.
@@ -19,22 +22,99 @@ type MetadataRequestSender interface {
 	SendMetadataRefreshRequest(clientID string) bool
 }
 
+// LogRequestSender can push a log capture request to a named agent.
+type LogRequestSender interface {
+	SendLogRequest(clientID, requestID string) bool
+}
+
+// RemediationRequestSender can push a remediation request to a named agent.
+type RemediationRequestSender interface {
+	SendRemediationRequest(clientID, requestID, action string) bool
+}
+
+// ResyncRequester can push a fresh policy snapshot to a named agent.
+type ResyncRequester interface {
+	SendResyncRequest(clientID string) bool
+}
+
+// NodeDisconnector can end the policy stream of a named agent.
+type NodeDisconnector interface {
+	Disconnect(clientID string) bool
+}
+
 // NodeHandler handles node API endpoints
 type NodeHandler struct {
 	nodeSvc    *services.NodeService
 	enrollSvc  *services.EnrollmentService
 	metaSender MetadataRequestSender // may be nil if hub not available
+	resolver   *services.PolicyResolver
+	logSvc     *services.NodeLogCaptureService
+	logSender  LogRequestSender // nil disables log capture
+	remedSvc   *services.NodeRemediationService
+	remedSend  RemediationRequestSender // nil disables remediation
+	filterSvc  *services.NodeFilterService
+	disconn    NodeDisconnector // nil disables bulk deletion
+	resync     ResyncRequester  // nil disables quarantine
 }
 
 // NewNodeHandler creates a new NodeHandler
-func NewNodeHandler(nodeSvc *services.NodeService, enrollSvc *services.EnrollmentService, hub MetadataRequestSender) *NodeHandler {
-	return &NodeHandler{nodeSvc: nodeSvc, enrollSvc: enrollSvc, metaSender: hub}
+func NewNodeHandler(nodeSvc *services.NodeService, enrollSvc *services.EnrollmentService, hub MetadataRequestSender, resolver *services.PolicyResolver) *NodeHandler {
+	return &NodeHandler{nodeSvc: nodeSvc, enrollSvc: enrollSvc, metaSender: hub, resolver: resolver}
 }
 
-// List handles GET /api/v1/nodes
+// WithLogCapture enables on-demand collection of agent logs.
+func (h *NodeHandler) WithLogCapture(logSvc *services.NodeLogCaptureService, sender LogRequestSender) *NodeHandler {
+	h.logSvc = logSvc
+	h.logSender = sender
+	return h
+}
+
+// WithRemediation enables one-time remediation actions on nodes.
+func (h *NodeHandler) WithRemediation(remedSvc *services.NodeRemediationService, sender RemediationRequestSender) *NodeHandler {
+	h.remedSvc = remedSvc
+	h.remedSend = sender
+	return h
+}
+
+// WithBulkDelete enables bulk node deletion. filterSvc resolves node
+// filters; disconn ends the streams of deleted nodes.
+func (h *NodeHandler) WithBulkDelete(filterSvc *services.NodeFilterService, disconn NodeDisconnector) *NodeHandler {
+	h.filterSvc = filterSvc
+	h.disconn = disconn
+	return h
+}
+
+// WithQuarantine enables quarantining nodes. resync pushes the changed
+// policy set to the agent at once.
+func (h *NodeHandler) WithQuarantine(resync ResyncRequester) *NodeHandler {
+	h.resync = resync
+	return h
+}
+
+// List handles GET /api/v1/nodes. With page, per_page, os_name,
+// desktop_env or group_id it returns a NodeListResponse page of the nodes
+// matching all given filters (search and status included); otherwise it
+// returns every node, or those matching search or status, as an array.
 func (h *NodeHandler) List(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if req, paginated, errMsg := parseNodeListRequest(r.URL.Query()); errMsg != "" {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, errMsg)
+		return
+	} else if paginated {
+		resp, err := h.nodeSvc.ListNodesPaginated(r.Context(), req)
+		if err != nil {
+			log.Printf("Failed to list nodes: %v", err)
+			writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list nodes")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Printf("Failed to encode nodes response: %v", err)
+		}
 		return
 	}
 
@@ -46,7 +126,7 @@ func (h *NodeHandler) List(w http.ResponseWriter, r *http.Request) {
 	status := r.URL.Query().Get("status")
 
 	if len(search) > 500 {
-		http.Error(w, `{"error":"search term too long"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "search term too long")
 		return
 	}
 
@@ -61,7 +141,7 @@ func (h *NodeHandler) List(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		log.Printf("Failed to list nodes: %v", err)
-		http.Error(w, `{"error":"failed to list nodes"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list nodes")
 		return
 	}
 
@@ -75,22 +155,61 @@ func (h *NodeHandler) List(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// paginatedNodeListParams are the query parameters that select the
+// paginated node list.
+var paginatedNodeListParams = []string{"page", "per_page", "os_name", "desktop_env", "group_id"}
+
+// parseNodeListRequest parses the node list query parameters. paginated
+// reports whether any parameter of the paginated list was given; without
+// them List keeps returning a plain array. errMsg describes an invalid
+// parameter.
+func parseNodeListRequest(q url.Values) (req *models.NodeListRequest, paginated bool, errMsg string) {
+	for _, p := range paginatedNodeListParams {
+		if q.Has(p) {
+			paginated = true
+		}
+	}
+
+	page, _, errMsg := parsePagination(q)
+	if errMsg != "" {
+		return nil, paginated, errMsg
+	}
+	req = &models.NodeListRequest{
+		Page:       page.Page,
+		PerPage:    page.PerPage,
+		Search:     q.Get("search"),
+		Status:     q.Get("status"),
+		OSName:     q.Get("os_name"),
+		DesktopEnv: q.Get("desktop_env"),
+		GroupID:    q.Get("group_id"),
+	}
+	if len(req.Search) > 500 {
+		return nil, paginated, "search term too long"
+	}
+	switch req.Status {
+	case "", models.NodeStatusOnline, models.NodeStatusDegraded, models.NodeStatusOffline, models.NodeStatusUnknown:
+	default:
+		return nil, paginated, "invalid status"
+	}
+	return req, paginated, ""
+}
+
 // Get handles GET /api/v1/nodes/{id}
 func (h *NodeHandler) Get(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	id, _, _ := parseNodePath(r.URL.Path)
 	if id == "" {
-		http.Error(w, `{"error":"node id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "node id required")
 		return
 	}
 
 	node, err := h.nodeSvc.GetNode(r.Context(), id)
 	if err != nil || node == nil {
-		http.Error(w, `{"error":"node not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeNodeNotFound, "node not found")
 		return
 	}
 
@@ -103,31 +222,26 @@ func (h *NodeHandler) Get(w http.ResponseWriter, r *http.Request) {
 // Update handles PUT /api/v1/nodes/{id}
 func (h *NodeHandler) Update(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	id, _, _ := parseNodePath(r.URL.Path)
 	if id == "" {
-		http.Error(w, `{"error":"node id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "node id required")
 		return
 	}
 
 	var req models.UpdateNodeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	node, err := h.nodeSvc.UpdateNode(r.Context(), id, &req)
 	if err != nil {
 		log.Printf("Failed to update node: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err.Error())
 		return
 	}
 
@@ -140,14 +254,14 @@ func (h *NodeHandler) Update(w http.ResponseWriter, r *http.Request) {
 // CountByStatus handles GET /api/v1/nodes/status-counts
 func (h *NodeHandler) CountByStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	counts, err := h.nodeSvc.CountByStatus(r.Context())
 	if err != nil {
 		log.Printf("Failed to count nodes by status: %v", err)
-		http.Error(w, `{"error":"failed to count nodes"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to count nodes")
 		return
 	}
 
@@ -157,49 +271,177 @@ func (h *NodeHandler) CountByStatus(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// StalePolicies handles GET /api/v1/nodes/stale-policies and lists the
+// nodes that still receive a deprecated policy or are still bound to an
+// archived one.
+func (h *NodeHandler) StalePolicies(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	nodes, err := h.nodeSvc.ListAllNodes(r.Context())
+	if err != nil {
+		log.Printf("Failed to list nodes: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list nodes")
+		return
+	}
+	report, err := h.resolver.StaleNodes(r.Context(), nodes)
+	if err != nil {
+		log.Printf("Failed to resolve stale policies: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to resolve stale policies")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Printf("Failed to encode stale policies response: %v", err)
+	}
+}
+
 // Delete handles DELETE /api/v1/nodes/{id}.
-// Deleting a node removes it from the database. Its mTLS certificate is no longer
-// trusted at the application level — reconnection will be rejected until the agent
-// re-enrolls with a new token.
+// Deleting a node removes it from the database and revokes its mTLS
+// certificate, so the agent cannot reconnect until it re-enrolls with a new
+// token. A connected agent's stream is ended when bulk deletion is enabled.
 func (h *NodeHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id, _, _ := parseNodePath(r.URL.Path)
 	if id == "" {
-		http.Error(w, `{"error":"node id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "node id required")
 		return
 	}
 
 	node, err := h.nodeSvc.GetNode(r.Context(), id)
 	if err != nil || node == nil {
-		http.Error(w, `{"error":"node not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeNodeNotFound, "node not found")
 		return
 	}
 
-	if err := h.nodeSvc.DeleteNode(r.Context(), id); err != nil {
+	if err := h.nodeSvc.DeleteNode(r.Context(), node); err != nil {
 		log.Printf("Failed to delete node %s: %v", id, err) //nolint:gosec // id comes from URL path parameter
-		http.Error(w, `{"error":"failed to delete node"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete node")
 		return
 	}
+	if h.disconn != nil {
+		h.disconn.Disconnect(node.Name)
+	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// BulkDelete handles POST /api/v1/nodes:bulk-delete.
+// It deletes the selected nodes and revokes their certificates in one
+// transaction, then ends the streams of connected agents. The request must
+// state the number of nodes it expects to delete; on a mismatch nothing is
+// deleted and 409 is returned with the actual count.
+func (h *NodeHandler) BulkDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	if h.disconn == nil {
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "bulk deletion not available")
+		return
+	}
+
+	var req models.BulkDeleteNodesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+	if err := services.ValidateBulkDeleteRequest(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+		return
+	}
+
+	var targets []*models.Node
+	var results []*models.BulkDeleteNodeResult
+	if req.FilterID != "" {
+		if h.filterSvc == nil {
+			writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "node filters not available")
+			return
+		}
+		nodes, err := h.filterSvc.ListMatchingNodes(r.Context(), req.FilterID)
+		if err != nil {
+			log.Printf("Failed to resolve node filter %s: %v", req.FilterID, err) //nolint:gosec // filter ID comes from authenticated request
+			writeError(w, http.StatusInternalServerError, CodeInternal, "failed to resolve node filter")
+			return
+		}
+		if nodes == nil {
+			writeError(w, http.StatusNotFound, CodeNodeFilterNotFound, "node filter not found")
+			return
+		}
+		targets = nodes
+	} else {
+		for _, id := range req.NodeIDs {
+			node, err := h.nodeSvc.GetNode(r.Context(), id)
+			if err != nil || node == nil {
+				results = append(results, &models.BulkDeleteNodeResult{NodeID: id, Status: models.BulkDeleteStatusNotFound})
+				continue
+			}
+			targets = append(targets, node)
+		}
+	}
+
+	if len(targets) != req.ExpectedCount {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		errResp := map[string]any{
+			"error": ErrorDetail{
+				Code:    CodeConflict,
+				Message: fmt.Sprintf("expected_count is %d but %d nodes match", req.ExpectedCount, len(targets)),
+			},
+			"count": len(targets),
+		}
+		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
+			log.Printf("Failed to encode error response: %v", encErr)
+		}
+		return
+	}
+	if len(targets) > services.MaxBulkDeleteNodes {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "too many nodes to delete at once")
+		return
+	}
+
+	if err := h.nodeSvc.BulkDeleteNodes(r.Context(), targets, req.Reason); err != nil {
+		log.Printf("Failed to bulk delete %d nodes: %v", len(targets), err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete nodes")
+		return
+	}
+
+	for _, node := range targets {
+		results = append(results, &models.BulkDeleteNodeResult{
+			NodeID:       node.ID,
+			Name:         node.Name,
+			Status:       models.BulkDeleteStatusDeleted,
+			CertRevoked:  node.CertSerial != nil && *node.CertSerial != "",
+			Disconnected: h.disconn.Disconnect(node.Name),
+		})
+	}
+	log.Printf("Bulk deleted %d nodes", len(targets))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&models.BulkDeleteNodesResponse{Deleted: len(targets), Results: results}); err != nil {
+		log.Printf("Failed to encode bulk delete response: %v", err)
+	}
+}
+
 // RefreshMetadata handles POST /api/v1/nodes/{id}/refresh-metadata.
 // It sends a METADATA_REQUEST event to the named agent's stream, asking it
 // to collect fresh system info and report back via the Heartbeat RPC.
 func (h *NodeHandler) RefreshMetadata(w http.ResponseWriter, r *http.Request, id string) {
 	node, err := h.nodeSvc.GetNode(r.Context(), id)
 	if err != nil || node == nil {
-		http.Error(w, `{"error":"node not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeNodeNotFound, "node not found")
 		return
 	}
 
 	if h.metaSender == nil {
-		http.Error(w, `{"error":"metadata refresh not available"}`, http.StatusServiceUnavailable)
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "metadata refresh not available")
 		return
 	}
 
 	if !h.metaSender.SendMetadataRefreshRequest(node.Name) {
-		http.Error(w, `{"error":"agent not connected"}`, http.StatusServiceUnavailable)
+		writeError(w, http.StatusServiceUnavailable, CodeNodeNotConnected, "agent not connected")
 		return
 	}
 
@@ -207,35 +449,401 @@ func (h *NodeHandler) RefreshMetadata(w http.ResponseWriter, r *http.Request, id
 	_, _ = w.Write([]byte(`{"ok":true}`))
 }
 
+// CollectLogs handles POST /api/v1/nodes/{id}/collect-logs. It records a
+// pending log capture and sends a LOG_REQUEST event to the agent, which
+// uploads its recent log buffer via the UploadLogs RPC.
+func (h *NodeHandler) CollectLogs(w http.ResponseWriter, r *http.Request, id string) {
+	node, err := h.nodeSvc.GetNode(r.Context(), id)
+	if err != nil || node == nil {
+		writeError(w, http.StatusNotFound, CodeNodeNotFound, "node not found")
+		return
+	}
+
+	if h.logSvc == nil || h.logSender == nil {
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "log capture not available")
+		return
+	}
+
+	requestedBy := ""
+	if claims := GetUserFromContext(r.Context()); claims != nil {
+		requestedBy = claims.Username
+	}
+	capture, err := h.logSvc.RequestCapture(r.Context(), node.ID, requestedBy)
+	if err != nil {
+		log.Printf("Failed to create log capture for node %s: %v", node.ID, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to create log capture")
+		return
+	}
+
+	if !h.logSender.SendLogRequest(node.Name, capture.ID) {
+		if err := h.logSvc.CancelCapture(r.Context(), capture.ID); err != nil {
+			log.Printf("Failed to remove undelivered log capture %s: %v", capture.ID, err)
+		}
+		writeError(w, http.StatusServiceUnavailable, CodeNodeNotConnected, "agent not connected")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(capture); err != nil {
+		log.Printf("Failed to encode log capture response: %v", err)
+	}
+}
+
+// ServeCollectLogs serves the /api/v1/nodes/{id}/collect-logs route. Like
+// ServeLogCaptures it is registered apart from ServeHTTP so that it can
+// require node:collect_logs, since agent logs may contain hostnames, user
+// names and policy content.
+func (h *NodeHandler) ServeCollectLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	h.CollectLogs(w, r, r.PathValue("id"))
+}
+
+// ServeLogCaptures serves the /api/v1/nodes/{id}/logs and
+// /api/v1/nodes/{id}/logs/{captureID} routes.
+func (h *NodeHandler) ServeLogCaptures(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	if captureID := r.PathValue("captureID"); captureID != "" {
+		h.DownloadLogCapture(w, r, r.PathValue("id"), captureID)
+		return
+	}
+	h.ListLogCaptures(w, r, r.PathValue("id"))
+}
+
+// RunCommand handles POST /api/v1/nodes/{id}/run-command. The body names
+// one of the predefined remediation actions; free-form commands are never
+// accepted. The action is recorded as pending and sent to the agent, which
+// reports the result via the ReportRemediationResult RPC.
+func (h *NodeHandler) RunCommand(w http.ResponseWriter, r *http.Request, id string) {
+	if h.remedSvc == nil || h.remedSend == nil {
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "remediation not available")
+		return
+	}
+
+	var req models.RunRemediationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+	if _, ok := remediation.Lookup(req.Action); !ok {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "unknown remediation action")
+		return
+	}
+
+	node, err := h.nodeSvc.GetNode(r.Context(), id)
+	if err != nil || node == nil {
+		writeError(w, http.StatusNotFound, CodeNodeNotFound, "node not found")
+		return
+	}
+
+	requestedBy := ""
+	if claims := GetUserFromContext(r.Context()); claims != nil {
+		requestedBy = claims.Username
+	}
+	rem, err := h.remedSvc.RequestRemediation(r.Context(), node.ID, req.Action, requestedBy)
+	if err != nil {
+		log.Printf("Failed to create remediation for node %s: %v", node.ID, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to create remediation")
+		return
+	}
+
+	if !h.remedSend.SendRemediationRequest(node.Name, rem.ID, rem.Action) {
+		if err := h.remedSvc.CancelRemediation(r.Context(), rem.ID); err != nil {
+			log.Printf("Failed to remove undelivered remediation %s: %v", rem.ID, err)
+		}
+		writeError(w, http.StatusServiceUnavailable, CodeNodeNotConnected, "agent not connected")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(rem); err != nil {
+		log.Printf("Failed to encode remediation response: %v", err)
+	}
+}
+
+// ServeRunCommand serves the /api/v1/nodes/{id}/run-command route. It is
+// registered apart from ServeHTTP so that it can require node:remediate
+// instead of the permission for the request method.
+func (h *NodeHandler) ServeRunCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	h.RunCommand(w, r, r.PathValue("id"))
+}
+
+// Quarantine handles POST /api/v1/nodes/{id}/quarantine. The node then
+// receives only the policies bound to quarantine node groups; a connected
+// agent is resynced immediately, others on their next connect.
+func (h *NodeHandler) Quarantine(w http.ResponseWriter, r *http.Request, id string) {
+	var req models.QuarantineNodeRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+			return
+		}
+	}
+	if len(req.Reason) > services.MaxQuarantineReasonLength {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "reason is too long")
+		return
+	}
+	h.setQuarantine(w, r, id, true, req.Reason)
+}
+
+// Unquarantine handles POST /api/v1/nodes/{id}/unquarantine, restoring
+// normal policy resolution for the node.
+func (h *NodeHandler) Unquarantine(w http.ResponseWriter, r *http.Request, id string) {
+	h.setQuarantine(w, r, id, false, "")
+}
+
+// ServeQuarantine serves the /api/v1/nodes/{id}/quarantine route. It and
+// ServeUnquarantine are registered apart from ServeHTTP so that placing
+// and lifting a quarantine require node:quarantine.
+func (h *NodeHandler) ServeQuarantine(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	h.Quarantine(w, r, r.PathValue("id"))
+}
+
+// ServeUnquarantine serves the /api/v1/nodes/{id}/unquarantine route.
+func (h *NodeHandler) ServeUnquarantine(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	h.Unquarantine(w, r, r.PathValue("id"))
+}
+
+func (h *NodeHandler) setQuarantine(w http.ResponseWriter, r *http.Request, id string, quarantined bool, reason string) {
+	if h.resync == nil {
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "quarantine not available")
+		return
+	}
+	node, err := h.nodeSvc.GetNode(r.Context(), id)
+	if err != nil || node == nil {
+		writeError(w, http.StatusNotFound, CodeNodeNotFound, "node not found")
+		return
+	}
+
+	if quarantined {
+		node, err = h.nodeSvc.QuarantineNode(r.Context(), id, reason)
+	} else {
+		node, err = h.nodeSvc.UnquarantineNode(r.Context(), id)
+	}
+	if err != nil || node == nil {
+		log.Printf("Failed to update quarantine of node %s: %v", id, err) //nolint:gosec // id comes from authenticated request
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to update quarantine")
+		return
+	}
+	if !h.resync.SendResyncRequest(node.Name) {
+		log.Printf("Node %s is not connected; quarantine change applies on its next connect", node.Name)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(node); err != nil {
+		log.Printf("Failed to encode node response: %v", err)
+	}
+}
+
+// ListRemediations handles GET /api/v1/nodes/{id}/remediations.
+func (h *NodeHandler) ListRemediations(w http.ResponseWriter, r *http.Request, id string) {
+	if h.remedSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "remediation not available")
+		return
+	}
+
+	list, err := h.remedSvc.ListRemediations(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to list remediations for node %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list remediations")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		log.Printf("Failed to encode remediations response: %v", err)
+	}
+}
+
+// RemediationActions handles GET /api/v1/remediation-actions and lists the
+// actions that may be run on a node.
+func (h *NodeHandler) RemediationActions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(remediation.List()); err != nil {
+		log.Printf("Failed to encode remediation actions response: %v", err)
+	}
+}
+
+// ListLogCaptures handles GET /api/v1/nodes/{id}/logs.
+func (h *NodeHandler) ListLogCaptures(w http.ResponseWriter, r *http.Request, id string) {
+	if h.logSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "log capture not available")
+		return
+	}
+
+	captures, err := h.logSvc.ListCaptures(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to list log captures for node %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list log captures")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(captures); err != nil {
+		log.Printf("Failed to encode log captures response: %v", err)
+	}
+}
+
+// DownloadLogCapture handles GET /api/v1/nodes/{id}/logs/{captureID} and
+// returns the uploaded log content as a plain-text attachment.
+func (h *NodeHandler) DownloadLogCapture(w http.ResponseWriter, r *http.Request, id, captureID string) {
+	if h.logSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "log capture not available")
+		return
+	}
+
+	capture, content, err := h.logSvc.GetCapture(r.Context(), captureID, id)
+	if err != nil || capture == nil {
+		writeError(w, http.StatusNotFound, CodeLogCaptureNotFound, "log capture not found")
+		return
+	}
+	if capture.Status != models.LogCaptureReceived {
+		writeError(w, http.StatusConflict, CodeConflict, "logs not yet received")
+		return
+	}
+
+	filename := fmt.Sprintf("bor-agent-%s.log", capture.ReceivedAt.UTC().Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	if _, err := w.Write(content); err != nil {
+		log.Printf("Failed to write log capture %s: %v", captureID, err)
+	}
+}
+
+// EffectivePolicies handles GET /api/v1/nodes/{id}/effective-policies.
+// Without query parameters it returns the policies the node receives today.
+// Any of the following parameters turns the request into a dry run that
+// resolves the policies the node would receive with the given changes:
+//
+//	group_id         replace group membership (repeatable)
+//	add_group_id     add a group (repeatable)
+//	remove_group_id  remove a group (repeatable)
+//	os_name, os_version, desktop_env, agent_version
+//	                 override the reported node facts
+func (h *NodeHandler) EffectivePolicies(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	node, err := h.nodeSvc.GetNode(r.Context(), id)
+	if err != nil || node == nil {
+		writeError(w, http.StatusNotFound, CodeNodeNotFound, "node not found")
+		return
+	}
+
+	result, err := h.resolver.Effective(r.Context(), node, parseNodeOverrides(r.URL.Query()))
+	if err != nil {
+		log.Printf("Failed to resolve effective policies for node %s: %v", id, err) //nolint:gosec // id comes from URL path parameter
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to resolve effective policies")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Printf("Failed to encode effective policies response: %v", err)
+	}
+}
+
+// parseNodeOverrides builds dry-run overrides from query parameters. It
+// returns nil when no override parameter is present.
+func parseNodeOverrides(q url.Values) *models.NodeOverrides {
+	o := &models.NodeOverrides{}
+	set := false
+	if v, ok := q["group_id"]; ok {
+		o.GroupIDs = nonEmpty(v)
+		set = true
+	}
+	if v := nonEmpty(q["add_group_id"]); len(v) > 0 {
+		o.AddGroupIDs = v
+		set = true
+	}
+	if v := nonEmpty(q["remove_group_id"]); len(v) > 0 {
+		o.RemoveGroupIDs = v
+		set = true
+	}
+	for key, dst := range map[string]**string{
+		"os_name":       &o.OSName,
+		"os_version":    &o.OSVersion,
+		"desktop_env":   &o.DesktopEnv,
+		"agent_version": &o.AgentVersion,
+	} {
+		if q.Has(key) {
+			v := q.Get(key)
+			*dst = &v
+			set = true
+		}
+	}
+	if !set {
+		return nil
+	}
+	return o
+}
+
+// nonEmpty returns the non-empty values of vs; a lone empty value (as in
+// "?group_id=") yields an empty, non-nil slice.
+func nonEmpty(vs []string) []string {
+	out := []string{}
+	for _, v := range vs {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 // AddToGroup handles POST /api/v1/nodes/{id}/groups — adds node to a group.
 func (h *NodeHandler) AddToGroup(w http.ResponseWriter, r *http.Request) {
 	id, _, _ := parseNodePath(r.URL.Path)
 	if id == "" {
-		http.Error(w, `{"error":"node id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "node id required")
 		return
 	}
 	var req struct {
 		GroupID string `json:"group_id"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GroupID == "" {
-		http.Error(w, `{"error":"group_id is required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "group_id is required")
 		return
 	}
 	node, err := h.nodeSvc.GetNode(r.Context(), id)
 	if err != nil || node == nil {
-		http.Error(w, `{"error":"node not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeNodeNotFound, "node not found")
 		return
 	}
 	err = h.nodeSvc.AddNodeToGroup(r.Context(), id, req.GroupID)
 	if err != nil {
 		log.Printf("Failed to add node %s to group %s: %v", id, req.GroupID, err) //nolint:gosec // id comes from URL path parameter
-		http.Error(w, `{"error":"failed to add node to group"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to add node to group")
 		return
 	}
 	// Return updated node
 	updated, err := h.nodeSvc.GetNode(r.Context(), id)
 	if err != nil || updated == nil {
-		http.Error(w, `{"error":"failed to reload node"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to reload node")
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -248,17 +856,17 @@ func (h *NodeHandler) AddToGroup(w http.ResponseWriter, r *http.Request) {
 func (h *NodeHandler) RemoveFromGroup(w http.ResponseWriter, r *http.Request) {
 	id, _, groupID := parseNodePath(r.URL.Path)
 	if id == "" || groupID == "" {
-		http.Error(w, `{"error":"node id and group id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "node id and group id required")
 		return
 	}
 	node, err := h.nodeSvc.GetNode(r.Context(), id)
 	if err != nil || node == nil {
-		http.Error(w, `{"error":"node not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeNodeNotFound, "node not found")
 		return
 	}
 	if err := h.nodeSvc.RemoveNodeFromGroup(r.Context(), id, groupID); err != nil {
 		log.Printf("Failed to remove node %s from group %s: %v", id, groupID, err) //nolint:gosec // id comes from URL path parameter
-		http.Error(w, `{"error":"failed to remove node from group"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to remove node from group")
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -273,23 +881,37 @@ func (h *NodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case http.MethodGet:
 			h.List(w, r)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
 
 	if action == "refresh-metadata" {
 		if r.Method != http.MethodPost {
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 			return
 		}
 		h.RefreshMetadata(w, r, id)
 		return
 	}
 
+	if action == "remediations" {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+			return
+		}
+		h.ListRemediations(w, r, id)
+		return
+	}
+
+	if action == "effective-policies" {
+		h.EffectivePolicies(w, r, id)
+		return
+	}
+
 	if action == "revoke" {
 		if r.Method != http.MethodPost {
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 			return
 		}
 		h.RevokeNodeCertificate(w, r, id)
@@ -303,7 +925,7 @@ func (h *NodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case http.MethodDelete:
 			h.RemoveFromGroup(w, r)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
@@ -316,7 +938,7 @@ func (h *NodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodDelete:
 		h.Delete(w, r)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -326,11 +948,11 @@ func (h *NodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func (h *NodeHandler) RevokeNodeCertificate(w http.ResponseWriter, r *http.Request, nodeID string) {
 	node, err := h.nodeSvc.GetNode(r.Context(), nodeID)
 	if err != nil || node == nil {
-		http.Error(w, `{"error":"node not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeNodeNotFound, "node not found")
 		return
 	}
 	if node.CertSerial == nil || *node.CertSerial == "" {
-		http.Error(w, `{"error":"node has no certificate to revoke"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "node has no certificate to revoke")
 		return
 	}
 	var req models.RevokeCertificateRequest
@@ -343,7 +965,7 @@ func (h *NodeHandler) RevokeNodeCertificate(w http.ResponseWriter, r *http.Reque
 	}
 	if err := h.enrollSvc.RevokeCertificate(r.Context(), nodeID, *node.CertSerial, reason); err != nil {
 		log.Printf("Failed to revoke certificate for node %s: %v", nodeID, err) //nolint:gosec // nodeID comes from authenticated request
-		http.Error(w, `{"error":"failed to revoke certificate"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to revoke certificate")
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -357,6 +979,7 @@ func (h *NodeHandler) RevokeNodeCertificate(w http.ResponseWriter, r *http.Reque
 //
 //	/api/v1/nodes/abc123                       → ("abc123", "", "")
 //	/api/v1/nodes/abc123/refresh-metadata      → ("abc123", "refresh-metadata", "")
+//	/api/v1/nodes/abc123/effective-policies    → ("abc123", "effective-policies", "")
 //	/api/v1/nodes/abc123/groups                → ("abc123", "groups", "")
 //	/api/v1/nodes/abc123/groups/{groupId}      → ("abc123", "groups", groupId)
 func parseNodePath(path string) (id, action, subAction string) {
@@ -7,7 +7,12 @@ package api
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/services"
 )
 
 func TestNodeHandler_List_MethodNotAllowed(t *testing.T) {
@@ -62,6 +67,267 @@ func TestNodeHandler_CountByStatus_MethodNotAllowed(t *testing.T) {
 	}
 }
 
+func TestNodeHandler_StalePolicies_MethodNotAllowed(t *testing.T) {
+	handler := &NodeHandler{}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/nodes/stale-policies", http.NoBody)
+	rr := httptest.NewRecorder()
+
+	handler.StalePolicies(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("StalePolicies() status = %v, want %v", rr.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestNodeHandler_LogCapture_MethodNotAllowed(t *testing.T) {
+	handler := &NodeHandler{}
+
+	tests := []struct {
+		method  string
+		path    string
+		serve   http.HandlerFunc
+		capture string
+	}{
+		{http.MethodGet, "/api/v1/nodes/node-1/collect-logs", handler.ServeCollectLogs, ""},
+		{http.MethodPost, "/api/v1/nodes/node-1/logs", handler.ServeLogCaptures, ""},
+		{http.MethodDelete, "/api/v1/nodes/node-1/logs/capture-1", handler.ServeLogCaptures, "capture-1"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+		req.SetPathValue("id", "node-1")
+		req.SetPathValue("captureID", tt.capture)
+		rr := httptest.NewRecorder()
+
+		tt.serve(rr, req)
+
+		if rr.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s %s status = %v, want %v", tt.method, tt.path, rr.Code, http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func TestNodeHandler_ListLogCaptures_Unavailable(t *testing.T) {
+	handler := &NodeHandler{}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/nodes/node-1/logs", http.NoBody)
+	req.SetPathValue("id", "node-1")
+	rr := httptest.NewRecorder()
+
+	handler.ServeLogCaptures(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("ListLogCaptures() status = %v, want %v", rr.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestNodeHandler_ServeHTTP_DoesNotServeLogs(t *testing.T) {
+	handler := &NodeHandler{}
+
+	// Log routes have their own permission; the generic node route must
+	// not reach them.
+	for _, path := range []string{"/api/v1/nodes/node-1/collect-logs", "/api/v1/nodes/node-1%2Fcollect-logs"} {
+		req := httptest.NewRequest(http.MethodPost, path, http.NoBody)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusMethodNotAllowed {
+			t.Errorf("POST %s status = %v, want %v", path, rr.Code, http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+type fakeRemediationSender struct{ sent int }
+
+func (f *fakeRemediationSender) SendRemediationRequest(_, _, _ string) bool {
+	f.sent++
+	return true
+}
+
+func newRunCommandRequest(method, body string) *http.Request {
+	req := httptest.NewRequest(method, "/api/v1/nodes/node-1/run-command", strings.NewReader(body))
+	req.SetPathValue("id", "node-1")
+	return req
+}
+
+func TestNodeHandler_Remediation_MethodNotAllowed(t *testing.T) {
+	handler := &NodeHandler{}
+
+	rr := httptest.NewRecorder()
+	handler.ServeRunCommand(rr, newRunCommandRequest(http.MethodGet, ""))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET run-command status = %v, want %v", rr.Code, http.StatusMethodNotAllowed)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/nodes/node-1/remediations", http.NoBody)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST remediations status = %v, want %v", rr.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestNodeHandler_ServeHTTP_DoesNotRunCommands(t *testing.T) {
+	sender := &fakeRemediationSender{}
+	handler := (&NodeHandler{}).WithRemediation(services.NewNodeRemediationService(nil), sender)
+
+	// run-command has its own route and permission; the generic node
+	// route must not reach it, whatever the path looks like.
+	for _, path := range []string{
+		"/api/v1/nodes/node-1/run-command",
+		"/api/v1/nodes/node-1/run-command/",
+		"/api/v1/nodes/node-1%2Frun-command",
+	} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"action":"dconf-update"}`))
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusMethodNotAllowed {
+			t.Errorf("POST %s status = %v, want %v", path, rr.Code, http.StatusMethodNotAllowed)
+		}
+	}
+	if sender.sent != 0 {
+		t.Errorf("sent %d remediation requests, want 0", sender.sent)
+	}
+}
+
+func TestNodeHandler_RunCommand_RejectsUnknownAction(t *testing.T) {
+	sender := &fakeRemediationSender{}
+	handler := (&NodeHandler{}).WithRemediation(services.NewNodeRemediationService(nil), sender)
+
+	for _, body := range []string{`{"action":"rm -rf /"}`, `{"action":""}`, `{"command":"dconf update"}`, `not json`} {
+		rr := httptest.NewRecorder()
+
+		handler.ServeRunCommand(rr, newRunCommandRequest(http.MethodPost, body))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("body %s: status = %v, want %v", body, rr.Code, http.StatusBadRequest)
+		}
+	}
+	if sender.sent != 0 {
+		t.Errorf("sent %d remediation requests, want 0", sender.sent)
+	}
+}
+
+func TestNodeHandler_RunCommand_Unavailable(t *testing.T) {
+	handler := &NodeHandler{}
+
+	rr := httptest.NewRecorder()
+
+	handler.ServeRunCommand(rr, newRunCommandRequest(http.MethodPost, `{"action":"dconf-update"}`))
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("RunCommand() status = %v, want %v", rr.Code, http.StatusServiceUnavailable)
+	}
+}
+
+type fakeDisconnector struct{ disconnected int }
+
+func (f *fakeDisconnector) Disconnect(string) bool {
+	f.disconnected++
+	return true
+}
+
+func TestNodeHandler_BulkDelete_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler *NodeHandler
+		method  string
+		body    string
+		want    int
+	}{
+		{"method", (&NodeHandler{}).WithBulkDelete(nil, &fakeDisconnector{}), http.MethodDelete, "", http.StatusMethodNotAllowed},
+		{"unavailable", &NodeHandler{}, http.MethodPost, `{"node_ids":["a"],"expected_count":1}`, http.StatusServiceUnavailable},
+		{"bad json", (&NodeHandler{}).WithBulkDelete(nil, &fakeDisconnector{}), http.MethodPost, `nope`, http.StatusBadRequest},
+		{"no selector", (&NodeHandler{}).WithBulkDelete(nil, &fakeDisconnector{}), http.MethodPost, `{"expected_count":1}`, http.StatusBadRequest},
+		{"no expected count", (&NodeHandler{}).WithBulkDelete(nil, &fakeDisconnector{}), http.MethodPost, `{"node_ids":["a"]}`, http.StatusBadRequest},
+		{"filters unavailable", (&NodeHandler{}).WithBulkDelete(nil, &fakeDisconnector{}), http.MethodPost, `{"filter_id":"f","expected_count":1}`, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/nodes:bulk-delete", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+
+			tt.handler.BulkDelete(rr, req)
+
+			if rr.Code != tt.want {
+				t.Errorf("BulkDelete() status = %v, want %v", rr.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeHandler_QuarantineGuards(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler *NodeHandler
+		action  string
+		body    string
+		want    int
+	}{
+		{"quarantine unavailable", &NodeHandler{}, "quarantine", "", http.StatusServiceUnavailable},
+		{"unquarantine unavailable", &NodeHandler{}, "unquarantine", "", http.StatusServiceUnavailable},
+		{"invalid body", &NodeHandler{}, "quarantine", "{", http.StatusBadRequest},
+		{"reason too long", &NodeHandler{}, "quarantine", `{"reason":"` + strings.Repeat("x", 501) + `"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/nodes/n1/"+tt.action, strings.NewReader(tt.body))
+			req.SetPathValue("id", "n1")
+			rr := httptest.NewRecorder()
+
+			if tt.action == "quarantine" {
+				tt.handler.ServeQuarantine(rr, req)
+			} else {
+				tt.handler.ServeUnquarantine(rr, req)
+			}
+
+			if rr.Code != tt.want {
+				t.Errorf("%s status = %v, want %v", tt.action, rr.Code, tt.want)
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/nodes/n1/quarantine", http.NoBody)
+	req.SetPathValue("id", "n1")
+	rr := httptest.NewRecorder()
+	(&NodeHandler{}).ServeQuarantine(rr, req)
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET quarantine status = %v, want %v", rr.Code, http.StatusMethodNotAllowed)
+	}
+
+	// The generic node route must not reach either action.
+	for _, action := range []string{"quarantine", "unquarantine"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/nodes/n1/"+action, http.NoBody)
+		rr := httptest.NewRecorder()
+		(&NodeHandler{}).ServeHTTP(rr, req)
+		if rr.Code != http.StatusMethodNotAllowed {
+			t.Errorf("POST %s via ServeHTTP status = %v, want %v", action, rr.Code, http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func TestNodeHandler_RemediationActions(t *testing.T) {
+	handler := &NodeHandler{}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/remediation-actions", http.NoBody)
+	rr := httptest.NewRecorder()
+
+	handler.RemediationActions(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("RemediationActions() status = %v, want %v", rr.Code, http.StatusOK)
+	}
+	if strings.Contains(rr.Body.String(), "/usr/bin") {
+		t.Errorf("response must not expose command lines: %s", rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), `"dconf-update"`) {
+		t.Errorf("response missing dconf-update: %s", rr.Body.String())
+	}
+}
+
 func TestParseNodePath(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -77,6 +343,7 @@ func TestParseNodePath(t *testing.T) {
 		{"trailing slash", "/api/v1/nodes/abc-123/", "abc-123", "", ""},
 		{"with action", "/api/v1/nodes/abc-123/refresh-metadata", "abc-123", "refresh-metadata", ""},
 		{"with groups action", "/api/v1/nodes/abc-123/groups", "abc-123", "groups", ""},
+		{"with effective-policies action", "/api/v1/nodes/abc-123/effective-policies", "abc-123", "effective-policies", ""},
 		{"with groups sub-action", "/api/v1/nodes/abc-123/groups/grp-456", "abc-123", "groups", "grp-456"},
 	}
 
@@ -95,3 +362,68 @@ func TestParseNodePath(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNodeOverrides(t *testing.T) {
+	if o := parseNodeOverrides(url.Values{}); o != nil {
+		t.Fatalf("no parameters: got %+v, want nil", o)
+	}
+
+	q, _ := url.ParseQuery("add_group_id=g2&add_group_id=&remove_group_id=g1&os_version=42&desktop_env=GNOME")
+	o := parseNodeOverrides(q)
+	if o == nil {
+		t.Fatal("expected overrides, got nil")
+	}
+	if o.GroupIDs != nil {
+		t.Errorf("GroupIDs = %v, want nil (membership not replaced)", o.GroupIDs)
+	}
+	if !slices.Equal(o.AddGroupIDs, []string{"g2"}) || !slices.Equal(o.RemoveGroupIDs, []string{"g1"}) {
+		t.Errorf("AddGroupIDs = %v, RemoveGroupIDs = %v", o.AddGroupIDs, o.RemoveGroupIDs)
+	}
+	if o.OSVersion == nil || *o.OSVersion != "42" || o.DesktopEnv == nil || *o.DesktopEnv != "GNOME" {
+		t.Errorf("fact overrides not parsed: %+v", o)
+	}
+	if o.OSName != nil || o.AgentVersion != nil {
+		t.Errorf("unset facts must stay nil: %+v", o)
+	}
+
+	// An empty group_id replaces membership with no groups at all.
+	q, _ = url.ParseQuery("group_id=")
+	o = parseNodeOverrides(q)
+	if o == nil || o.GroupIDs == nil || len(o.GroupIDs) != 0 {
+		t.Errorf("group_id= : got %+v, want empty non-nil GroupIDs", o)
+	}
+}
+
+func TestParseNodeListRequest(t *testing.T) {
+	tests := []struct {
+		query     string
+		paginated bool
+		errMsg    string
+	}{
+		{"", false, ""},
+		{"search=web&status=online", false, ""},
+		{"page=2", true, ""},
+		{"os_name=Fedora&desktop_env=KDE", true, ""},
+		{"group_id=abc", true, ""},
+		{"page=0", true, "page must be a positive integer"},
+		{"per_page=x", true, "per_page must be a positive integer"},
+		{"status=degraded&page=1", true, ""},
+		{"status=bogus&page=1", true, "invalid status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			_, paginated, errMsg := parseNodeListRequest(q)
+			if paginated != tt.paginated || errMsg != tt.errMsg {
+				t.Errorf("parseNodeListRequest(%q) = %v, %q; want %v, %q", tt.query, paginated, errMsg, tt.paginated, tt.errMsg)
+			}
+		})
+	}
+
+	q, _ := url.ParseQuery("page=3&per_page=20&os_name=Ubuntu&desktop_env=GNOME&group_id=g1&status=online&search=lab")
+	req, _, _ := parseNodeListRequest(q)
+	if req.Page != 3 || req.PerPage != 20 || req.OSName != "Ubuntu" || req.DesktopEnv != "GNOME" ||
+		req.GroupID != "g1" || req.Status != "online" || req.Search != "lab" {
+		t.Errorf("parsed request = %+v", req)
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+const (
+	defaultPerPage = 50
+	maxPerPage     = 500
+
+	// maxPage keeps models.Pagination.Offset from overflowing a 32-bit int.
+	maxPage = math.MaxInt32/maxPerPage + 1
+)
+
+// parsePagination parses the page and per_page query parameters. paginated
+// reports whether either is present: list endpoints answer with the
+// models.ListResponse envelope only then, and with a bare array otherwise.
+// per_page defaults to 50 and is capped at 500; page may not exceed
+// maxPage.
+func parsePagination(q url.Values) (p models.Pagination, paginated bool, errMsg string) {
+	paginated = q.Has("page") || q.Has("per_page")
+	p = models.Pagination{Page: 1, PerPage: defaultPerPage}
+	if v := q.Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return p, paginated, "page must be a positive integer"
+		}
+		if n > maxPage {
+			return p, paginated, "page is too large"
+		}
+		p.Page = n
+	}
+	if v := q.Get("per_page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return p, paginated, "per_page must be a positive integer"
+		}
+		p.PerPage = min(n, maxPerPage)
+	}
+	return p, paginated, ""
+}
+
+// writeList answers a list request with the page selected by its page and
+// per_page query parameters, as returned by page. It reports whether the
+// request was answered; when neither parameter is present it was not, and
+// the caller answers with the bare array. what names the listed items in
+// errors, e.g. "user groups".
+func writeList[T any](w http.ResponseWriter, r *http.Request, what string, page func(models.Pagination) (*models.ListResponse[T], error)) bool {
+	p, paginated, errMsg := parsePagination(r.URL.Query())
+	if errMsg != "" {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, errMsg)
+		return true
+	}
+	if !paginated {
+		return false
+	}
+	resp, err := page(p)
+	if err != nil {
+		log.Printf("Failed to list %s: %v", what, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list "+what)
+		return true
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Failed to encode %s response: %v", what, err)
+	}
+	return true
+}
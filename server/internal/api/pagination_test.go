@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		query     string
+		want      models.Pagination
+		paginated bool
+		wantErr   bool
+	}{
+		{"", models.Pagination{Page: 1, PerPage: defaultPerPage}, false, false},
+		{"page=2", models.Pagination{Page: 2, PerPage: defaultPerPage}, true, false},
+		{"per_page=10", models.Pagination{Page: 1, PerPage: 10}, true, false},
+		{"page=3&per_page=100000", models.Pagination{Page: 3, PerPage: maxPerPage}, true, false},
+		{"page=4294968&per_page=500", models.Pagination{Page: maxPage, PerPage: maxPerPage}, true, false},
+		{"page=0", models.Pagination{}, true, true},
+		{"page=4294969", models.Pagination{}, true, true},
+		{"page=9223372036854775807", models.Pagination{}, true, true},
+		{"per_page=abc", models.Pagination{}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			got, paginated, errMsg := parsePagination(q)
+			if (errMsg != "") != tt.wantErr {
+				t.Fatalf("errMsg = %q, wantErr %v", errMsg, tt.wantErr)
+			}
+			if paginated != tt.paginated {
+				t.Errorf("paginated = %v, want %v", paginated, tt.paginated)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("pagination = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -16,12 +17,14 @@ import (
 
 // PolicyHandler handles policy endpoints
 type PolicyHandler struct {
-	policySvc *services.PolicyService
+	policySvc  *services.PolicyService
+	bindingSvc *services.PolicyBindingService
 	// OnPolicyChange is called after a mutation that may affect agents:
-	// Update, SetState, Deprecate. It receives the policy ID so the
-	// caller can scope notifications to the affected node groups.
+	// Update, SetState, Deprecate. It receives the request context, which
+	// carries the acting user, and the changed policy so the caller can
+	// scope notifications to the affected node groups.
 	// Not called for Create (draft) or Delete (blocked when enabled bindings exist).
-	OnPolicyChange func(policyID string)
+	OnPolicyChange func(ctx context.Context, policy *models.Policy)
 }
 
 // NewPolicyHandler creates a new PolicyHandler
@@ -29,17 +32,23 @@ func NewPolicyHandler(policySvc *services.PolicyService) *PolicyHandler {
 	return &PolicyHandler{policySvc: policySvc}
 }
 
+// WithBindings enables GET /api/v1/policies/all/{id}/affected-nodes.
+func (h *PolicyHandler) WithBindings(bindingSvc *services.PolicyBindingService) *PolicyHandler {
+	h.bindingSvc = bindingSvc
+	return h
+}
+
 // List handles GET /api/v1/policies
 func (h *PolicyHandler) List(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	policies, err := h.policySvc.ListEnabledPolicies(r.Context())
 	if err != nil {
 		log.Printf("Failed to list policies: %v", err)
-		http.Error(w, `{"error":"failed to list policies"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list policies")
 		return
 	}
 
@@ -56,14 +65,20 @@ func (h *PolicyHandler) List(w http.ResponseWriter, r *http.Request) {
 // ListAll handles GET /api/v1/policies/all
 func (h *PolicyHandler) ListAll(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if writeList(w, r, "policies", func(page models.Pagination) (*models.ListResponse[*models.Policy], error) {
+		return h.policySvc.ListAllPoliciesPaginated(r.Context(), page)
+	}) {
 		return
 	}
 
 	policies, err := h.policySvc.ListAllPolicies(r.Context())
 	if err != nil {
 		log.Printf("Failed to list all policies: %v", err)
-		http.Error(w, `{"error":"failed to list policies"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list policies")
 		return
 	}
 
@@ -80,13 +95,13 @@ func (h *PolicyHandler) ListAll(w http.ResponseWriter, r *http.Request) {
 // Create handles POST /api/v1/policies/all
 func (h *PolicyHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req models.CreatePolicyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
@@ -99,12 +114,7 @@ func (h *PolicyHandler) Create(w http.ResponseWriter, r *http.Request) {
 	policy, err := h.policySvc.CreatePolicy(r.Context(), &req, createdBy)
 	if err != nil {
 		log.Printf("Failed to create policy: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodePolicyInvalid, err.Error())
 		return
 	}
 
@@ -120,56 +130,48 @@ func (h *PolicyHandler) Create(w http.ResponseWriter, r *http.Request) {
 // Get handles GET /api/v1/policies/{id}
 func (h *PolicyHandler) Get(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	id := extractPolicyIDFromPath(r.URL.Path)
 	if id == "" {
-		http.Error(w, `{"error":"policy id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "policy id required")
 		return
 	}
 
 	policy, err := h.policySvc.GetPolicy(r.Context(), id)
 	if err != nil || policy == nil {
-		http.Error(w, `{"error":"policy not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodePolicyNotFound, "policy not found")
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(policy); err != nil {
-		log.Printf("Failed to encode policy response: %v", err)
-	}
+	writeJSONWithETag(w, r, policy)
 }
 
 // Update handles PUT /api/v1/policies/{id}
 func (h *PolicyHandler) Update(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	id := extractPolicyIDFromPath(r.URL.Path)
 	if id == "" {
-		http.Error(w, `{"error":"policy id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "policy id required")
 		return
 	}
 
 	var req models.UpdatePolicyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	policy, err := h.policySvc.UpdatePolicy(r.Context(), id, &req)
 	if err != nil {
 		log.Printf("Failed to update policy: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodePolicyInvalid, err.Error())
 		return
 	}
 
@@ -179,7 +181,37 @@ func (h *PolicyHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if h.OnPolicyChange != nil {
-		h.OnPolicyChange(policy.ID)
+		h.OnPolicyChange(r.Context(), policy)
+	}
+}
+
+// Validate handles POST /api/v1/policies/validate. It reports whether the
+// content would be accepted for a policy of the given type on create or
+// update, so that editors can check it before saving.
+func (h *PolicyHandler) Validate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req models.ValidatePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+	if req.Type == "" {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "policy type is required")
+		return
+	}
+
+	resp := models.ValidatePolicyResponse{Valid: true}
+	if err := services.CheckPolicyContent(req.Type, req.Content); err != nil {
+		resp = models.ValidatePolicyResponse{Error: err.Error()}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Failed to encode validation response: %v", err)
 	}
 }
 
@@ -194,7 +226,7 @@ func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case http.MethodPost:
 			h.Create(w, r)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
@@ -208,6 +240,14 @@ func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.Deprecate(w, r, id)
 		return
 	}
+	if subpath == "rendered" {
+		h.Rendered(w, r, id)
+		return
+	}
+	if subpath == "affected-nodes" {
+		h.AffectedNodes(w, r, id)
+		return
+	}
 
 	switch r.Method {
 	case http.MethodGet:
@@ -217,32 +257,27 @@ func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodDelete:
 		h.Delete(w, r, id)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
 // SetState handles PUT /api/v1/policies/all/{id}/state
 func (h *PolicyHandler) SetState(w http.ResponseWriter, r *http.Request, id string) {
 	if r.Method != http.MethodPut {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req models.SetPolicyStateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	policy, err := h.policySvc.SetPolicyState(r.Context(), id, req.State)
 	if err != nil {
 		log.Printf("Failed to set policy state: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodePolicyInvalid, err.Error())
 		return
 	}
 
@@ -252,32 +287,27 @@ func (h *PolicyHandler) SetState(w http.ResponseWriter, r *http.Request, id stri
 	}
 
 	if h.OnPolicyChange != nil {
-		h.OnPolicyChange(policy.ID)
+		h.OnPolicyChange(r.Context(), policy)
 	}
 }
 
 // Deprecate handles POST /api/v1/policies/all/{id}/deprecate
 func (h *PolicyHandler) Deprecate(w http.ResponseWriter, r *http.Request, id string) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req models.DeprecatePolicyRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	policy, err := h.policySvc.DeprecatePolicy(r.Context(), id, &req)
 	if err != nil {
 		log.Printf("Failed to deprecate policy: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodePolicyInvalid, err.Error())
 		return
 	}
 
@@ -287,32 +317,84 @@ func (h *PolicyHandler) Deprecate(w http.ResponseWriter, r *http.Request, id str
 	}
 
 	if h.OnPolicyChange != nil {
-		h.OnPolicyChange(policy.ID)
+		h.OnPolicyChange(r.Context(), policy)
+	}
+}
+
+// Rendered handles GET /api/v1/policies/all/{id}/rendered, returning the
+// files the policy renders to on an agent (e.g. KConfig INI with [$i]
+// markers) so authors can check them before releasing.
+func (h *PolicyHandler) Rendered(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	files, err := h.policySvc.RenderPolicy(r.Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			writeError(w, http.StatusNotFound, CodePolicyNotFound, err.Error())
+			return
+		}
+		writeError(w, http.StatusBadRequest, CodePolicyInvalid, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := map[string]interface{}{"policy_id": id, "files": files}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("Failed to encode rendered policy response: %v", err)
+	}
+}
+
+// AffectedNodes handles GET /api/v1/policies/all/{id}/affected-nodes,
+// returning the nodes reached by the policy's enabled bindings so admins
+// can see the blast radius of a change before making it.
+func (h *PolicyHandler) AffectedNodes(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+	if h.bindingSvc == nil {
+		writeError(w, http.StatusNotImplemented, CodeNotImplemented, "policy bindings not configured")
+		return
+	}
+
+	nodes, err := h.bindingSvc.ListPolicyAffectedNodes(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to list affected nodes for policy %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list affected nodes")
+		return
+	}
+	if nodes == nil {
+		writeError(w, http.StatusNotFound, CodePolicyNotFound, "policy not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(nodes); err != nil {
+		log.Printf("Failed to encode nodes response: %v", err)
 	}
 }
 
 // Delete handles DELETE /api/v1/policies/all/{id}
 func (h *PolicyHandler) Delete(w http.ResponseWriter, r *http.Request, id string) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	err := h.policySvc.DeletePolicy(r.Context(), id)
 	if err != nil {
 		log.Printf("Failed to delete policy: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		status := http.StatusBadRequest
 		errMsg := err.Error()
-		if strings.Contains(errMsg, "not found") {
-			status = http.StatusNotFound
-		} else if strings.Contains(errMsg, "enabled binding") {
-			status = http.StatusConflict
-		}
-		w.WriteHeader(status)
-		errResp := map[string]string{"error": errMsg}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
+		switch {
+		case strings.Contains(errMsg, "not found"):
+			writeError(w, http.StatusNotFound, CodePolicyNotFound, errMsg)
+		case strings.Contains(errMsg, "enabled binding"):
+			writeError(w, http.StatusConflict, CodeBindingConflict, errMsg)
+		default:
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, errMsg)
 		}
 		return
 	}
@@ -5,9 +5,13 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
 )
 
 func TestPolicyHandler_List_MethodNotAllowed(t *testing.T) {
@@ -23,6 +27,19 @@ func TestPolicyHandler_List_MethodNotAllowed(t *testing.T) {
 	}
 }
 
+func TestPolicyHandler_Rendered_MethodNotAllowed(t *testing.T) {
+	handler := &PolicyHandler{}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/policies/all/p1/rendered", http.NoBody)
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Rendered() status = %v, want %v", rr.Code, http.StatusMethodNotAllowed)
+	}
+}
+
 func TestExtractPolicyIDAndSubpath(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -51,3 +68,46 @@ func TestExtractPolicyIDAndSubpath(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicyHandler_Validate(t *testing.T) {
+	handler := &PolicyHandler{}
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		wantCode  int
+		wantValid bool
+	}{
+		{"wrong method", http.MethodGet, "", http.StatusMethodNotAllowed, false},
+		{"bad body", http.MethodPost, "{", http.StatusBadRequest, false},
+		{"missing type", http.MethodPost, `{"content":"{}"}`, http.StatusBadRequest, false},
+		{"valid content", http.MethodPost, `{"type":"Firefox","content":"{\"DisableTelemetry\":true}"}`, http.StatusOK, true},
+		{"invalid content", http.MethodPost, `{"type":"Firefox","content":"{\"DisableTelemetry\":"}`, http.StatusOK, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/policies/validate", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.Validate(rr, req)
+
+			if rr.Code != tt.wantCode {
+				t.Fatalf("Validate() status = %v, want %v", rr.Code, tt.wantCode)
+			}
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var resp models.ValidatePolicyResponse
+			if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v", resp.Valid, tt.wantValid)
+			}
+			if !resp.Valid && resp.Error == "" {
+				t.Error("Error should be set for invalid content")
+			}
+		})
+	}
+}
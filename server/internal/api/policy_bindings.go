@@ -5,6 +5,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -18,10 +19,11 @@ import (
 type PolicyBindingHandler struct {
 	bindingSvc *services.PolicyBindingService
 	// OnBindingChange is called after a binding mutation that may affect
-	// agents: Update and Delete. It receives the affected binding so the
-	// caller can scope notifications to the right node group.
+	// agents: Update and Delete. It receives the request context, which
+	// carries the acting user, and the affected binding so the caller can
+	// scope notifications to the right node group.
 	// Not called for Create (new bindings start disabled).
-	OnBindingChange func(b *models.PolicyBinding)
+	OnBindingChange func(ctx context.Context, b *models.PolicyBinding)
 }
 
 // NewPolicyBindingHandler creates a new PolicyBindingHandler
@@ -32,14 +34,20 @@ func NewPolicyBindingHandler(bindingSvc *services.PolicyBindingService) *PolicyB
 // List handles GET /api/v1/policy-bindings
 func (h *PolicyBindingHandler) List(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if writeList(w, r, "policy bindings", func(page models.Pagination) (*models.ListResponse[*models.PolicyBindingWithDetails], error) {
+		return h.bindingSvc.ListBindingsPaginated(r.Context(), page)
+	}) {
 		return
 	}
 
 	bindings, err := h.bindingSvc.ListBindings(r.Context())
 	if err != nil {
 		log.Printf("Failed to list policy bindings: %v", err)
-		http.Error(w, `{"error":"failed to list policy bindings"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list policy bindings")
 		return
 	}
 
@@ -56,25 +64,20 @@ func (h *PolicyBindingHandler) List(w http.ResponseWriter, r *http.Request) {
 // Create handles POST /api/v1/policy-bindings
 func (h *PolicyBindingHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req models.CreatePolicyBindingRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	binding, err := h.bindingSvc.CreateBinding(r.Context(), &req)
 	if err != nil {
 		log.Printf("Failed to create policy binding: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodeBindingInvalid, err.Error())
 		return
 	}
 
@@ -86,9 +89,10 @@ func (h *PolicyBindingHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// New bindings start as DISABLED — no agents need to know yet.
 }
 
-// ServeHTTP routes /api/v1/policy-bindings and /api/v1/policy-bindings/{id}
+// ServeHTTP routes /api/v1/policy-bindings, /api/v1/policy-bindings/{id}
+// and sub-paths
 func (h *PolicyBindingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	id := extractBindingIDFromPath(r.URL.Path)
+	id, subpath := extractBindingIDAndSubpath(r.URL.Path)
 
 	if id == "" {
 		switch r.Method {
@@ -97,11 +101,17 @@ func (h *PolicyBindingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		case http.MethodPost:
 			h.Create(w, r)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
 
+	// Handle /api/v1/policy-bindings/{id}/nodes
+	if subpath == "nodes" {
+		h.ListNodes(w, r, id)
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		h.Get(w, r, id)
@@ -110,7 +120,7 @@ func (h *PolicyBindingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	case http.MethodDelete:
 		h.Delete(w, r, id)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -118,7 +128,7 @@ func (h *PolicyBindingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 func (h *PolicyBindingHandler) Get(w http.ResponseWriter, r *http.Request, id string) {
 	binding, err := h.bindingSvc.GetBinding(r.Context(), id)
 	if err != nil || binding == nil {
-		http.Error(w, `{"error":"policy binding not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeBindingNotFound, "policy binding not found")
 		return
 	}
 
@@ -132,19 +142,14 @@ func (h *PolicyBindingHandler) Get(w http.ResponseWriter, r *http.Request, id st
 func (h *PolicyBindingHandler) Update(w http.ResponseWriter, r *http.Request, id string) {
 	var req models.UpdatePolicyBindingRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	binding, err := h.bindingSvc.UpdateBinding(r.Context(), id, &req)
 	if err != nil {
 		log.Printf("Failed to update policy binding: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodeBindingInvalid, err.Error())
 		return
 	}
 
@@ -153,10 +158,10 @@ func (h *PolicyBindingHandler) Update(w http.ResponseWriter, r *http.Request, id
 		log.Printf("Failed to encode policy binding response: %v", err)
 	}
 
-	// Notify agents when state or priority changes — both affect which policy
-	// values are applied on the node.
-	if h.OnBindingChange != nil && (req.State != nil || req.Priority != nil) {
-		h.OnBindingChange(binding)
+	// Notify agents when state, priority or window changes — all affect
+	// which policy values are applied on the node.
+	if h.OnBindingChange != nil && (req.State != nil || req.Priority != nil || req.Window != nil) {
+		h.OnBindingChange(r.Context(), binding)
 	}
 }
 
@@ -167,12 +172,7 @@ func (h *PolicyBindingHandler) Delete(w http.ResponseWriter, r *http.Request, id
 
 	if err := h.bindingSvc.DeleteBinding(r.Context(), id); err != nil {
 		log.Printf("Failed to delete policy binding: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusConflict)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusConflict, CodeBindingConflict, err.Error())
 		return
 	}
 
@@ -180,17 +180,51 @@ func (h *PolicyBindingHandler) Delete(w http.ResponseWriter, r *http.Request, id
 	w.WriteHeader(http.StatusNoContent)
 
 	if h.OnBindingChange != nil && binding != nil && binding.State == models.BindingStateEnabled {
-		h.OnBindingChange(binding)
+		h.OnBindingChange(r.Context(), binding)
 	}
 }
 
-// extractBindingIDFromPath extracts the ID from URL path like /api/v1/policy-bindings/{id}
-func extractBindingIDFromPath(path string) string {
+// ListNodes handles GET /api/v1/policy-bindings/{id}/nodes and returns the
+// nodes the binding targets, whether or not it is enabled.
+func (h *PolicyBindingHandler) ListNodes(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	nodes, err := h.bindingSvc.ListBindingNodes(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to list nodes for policy binding %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list binding nodes")
+		return
+	}
+	if nodes == nil {
+		writeError(w, http.StatusNotFound, CodeBindingNotFound, "policy binding not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(nodes); err != nil {
+		log.Printf("Failed to encode nodes response: %v", err)
+	}
+}
+
+// extractBindingIDAndSubpath extracts the ID and optional sub-path from URL
+// paths like /api/v1/policy-bindings/{id} or /api/v1/policy-bindings/{id}/nodes
+func extractBindingIDAndSubpath(path string) (id, subpath string) {
 	const prefix = "/api/v1/policy-bindings/"
 	if !strings.HasPrefix(path, prefix) {
-		return ""
+		return "", ""
+	}
+	rest := strings.TrimSuffix(strings.TrimPrefix(path, prefix), "/")
+
+	parts := strings.SplitN(rest, "/", 2)
+	id = parts[0]
+	if id == "" {
+		return "", ""
+	}
+	if len(parts) > 1 {
+		subpath = parts[1]
 	}
-	id := strings.TrimPrefix(path, prefix)
-	id = strings.TrimSuffix(id, "/")
-	return id
+	return id, subpath
 }
@@ -36,24 +36,26 @@ func TestPolicyBindingHandler_Create_MethodNotAllowed(t *testing.T) {
 	}
 }
 
-func TestExtractBindingIDFromPath(t *testing.T) {
+func TestExtractBindingIDAndSubpath(t *testing.T) {
 	tests := []struct {
-		name string
-		path string
-		want string
+		name        string
+		path        string
+		wantID      string
+		wantSubpath string
 	}{
-		{"empty path", "/api/v1/policy-bindings", ""},
-		{"with trailing slash", "/api/v1/policy-bindings/", ""},
-		{"with id", "/api/v1/policy-bindings/abc-123", "abc-123"},
-		{"with id and trailing slash", "/api/v1/policy-bindings/abc-123/", "abc-123"},
-		{"wrong prefix", "/api/v1/nodes/abc-123", ""},
+		{"empty path", "/api/v1/policy-bindings", "", ""},
+		{"with trailing slash", "/api/v1/policy-bindings/", "", ""},
+		{"with id", "/api/v1/policy-bindings/abc-123", "abc-123", ""},
+		{"with id and trailing slash", "/api/v1/policy-bindings/abc-123/", "abc-123", ""},
+		{"with nodes sub-path", "/api/v1/policy-bindings/abc-123/nodes", "abc-123", "nodes"},
+		{"wrong prefix", "/api/v1/nodes/abc-123", "", ""},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := extractBindingIDFromPath(tt.path)
-			if got != tt.want {
-				t.Errorf("extractBindingIDFromPath(%q) = %q, want %q", tt.path, got, tt.want)
+			id, subpath := extractBindingIDAndSubpath(tt.path)
+			if id != tt.wantID || subpath != tt.wantSubpath {
+				t.Errorf("extractBindingIDAndSubpath(%q) = %q, %q, want %q, %q", tt.path, id, subpath, tt.wantID, tt.wantSubpath)
 			}
 		})
 	}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+	"github.com/VuteTech/Bor/server/internal/services"
+)
+
+// PolicyBundleHandler handles policy bundle export/import endpoints
+type PolicyBundleHandler struct {
+	bundleSvc *services.PolicyBundleService
+}
+
+// NewPolicyBundleHandler creates a new PolicyBundleHandler
+func NewPolicyBundleHandler(bundleSvc *services.PolicyBundleService) *PolicyBundleHandler {
+	return &PolicyBundleHandler{bundleSvc: bundleSvc}
+}
+
+// Export handles GET /api/v1/policies/export
+// Query parameters:
+//   - id: a policy to export; may be repeated. Without it all released
+//     policies are exported.
+func (h *PolicyBundleHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	bundle, err := h.bundleSvc.Export(r.Context(), r.URL.Query()["id"])
+	if err != nil {
+		if errors.Is(err, services.ErrPolicyNotFound) {
+			writeError(w, http.StatusNotFound, CodePolicyNotFound, err.Error())
+			return
+		}
+		log.Printf("Failed to export policies: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to export policies")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="bor-policies.json"`)
+	if err := json.NewEncoder(w).Encode(bundle); err != nil {
+		log.Printf("Failed to encode policy bundle: %v", err)
+	}
+}
+
+// Import handles POST /api/v1/policies/import
+func (h *PolicyBundleHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var bundle models.PolicyBundle
+	if err := json.NewDecoder(r.Body).Decode(&bundle); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	importedBy := ""
+	if claims := GetUserFromContext(r.Context()); claims != nil {
+		importedBy = claims.Username
+	}
+
+	result, err := h.bundleSvc.Import(r.Context(), &bundle, importedBy)
+	if err != nil {
+		log.Printf("Failed to import policies: %v", err)
+		writeError(w, http.StatusBadRequest, CodePolicyInvalid, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Printf("Failed to encode policy import result: %v", err)
+	}
+}
@@ -50,7 +50,7 @@ func protoToPolkitActionResponse(a *pb.PolkitActionDescription) polkitActionResp
 // Optional query param: node_id=<uuid> to filter to actions available on a specific node.
 func (h *PolkitHandler) ListActions(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
@@ -68,7 +68,7 @@ func (h *PolkitHandler) ListActions(w http.ResponseWriter, r *http.Request) {
 	}
 	if err != nil {
 		log.Printf("Failed to list polkit actions: %v", err)
-		http.Error(w, `{"error":"failed to list polkit actions"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list polkit actions")
 		return
 	}
 
@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+	"github.com/VuteTech/Bor/server/internal/services"
+)
+
+// ProfileHandler handles policy profile API endpoints
+type ProfileHandler struct {
+	profileSvc *services.ProfileService
+	// OnProfileChange is called with the groups whose policy bindings were
+	// reconciled by a profile change, so their agents can be resynced once.
+	OnProfileChange func(groupIDs []string)
+}
+
+// NewProfileHandler creates a new ProfileHandler
+func NewProfileHandler(profileSvc *services.ProfileService) *ProfileHandler {
+	return &ProfileHandler{profileSvc: profileSvc}
+}
+
+// ServeHTTP routes /api/v1/profiles/{id} and sub-paths
+func (h *ProfileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, subpath := extractProfileIDAndSubpath(r.URL.Path)
+
+	if id == "" {
+		switch r.Method {
+		case http.MethodGet:
+			h.List(w, r)
+		case http.MethodPost:
+			h.Create(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	// Handle /api/v1/profiles/{id}/bindings[/{groupID}]
+	if subpath == "bindings" {
+		switch r.Method {
+		case http.MethodGet:
+			h.ListBindings(w, r, id)
+		case http.MethodPost:
+			h.Bind(w, r, id)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+	if groupID, ok := strings.CutPrefix(subpath, "bindings/"); ok && groupID != "" {
+		if r.Method != http.MethodDelete {
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+			return
+		}
+		h.Unbind(w, r, id, groupID)
+		return
+	}
+	if subpath != "" {
+		writeError(w, http.StatusNotFound, CodeNotFound, "not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.Get(w, r, id)
+	case http.MethodPut:
+		h.Update(w, r, id)
+	case http.MethodDelete:
+		h.Delete(w, r, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+	}
+}
+
+// List handles GET /api/v1/profiles
+func (h *ProfileHandler) List(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.profileSvc.ListProfiles(r.Context())
+	if err != nil {
+		log.Printf("Failed to list profiles: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list profiles")
+		return
+	}
+
+	if profiles == nil {
+		profiles = []*models.Profile{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(profiles); err != nil {
+		log.Printf("Failed to encode profiles response: %v", err)
+	}
+}
+
+// Create handles POST /api/v1/profiles
+func (h *ProfileHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	profile, err := h.profileSvc.CreateProfile(r.Context(), &req)
+	if err != nil {
+		log.Printf("Failed to create profile: %v", err)
+		writeError(w, http.StatusBadRequest, CodeProfileInvalid, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(profile); err != nil {
+		log.Printf("Failed to encode profile response: %v", err)
+	}
+}
+
+// Get handles GET /api/v1/profiles/{id}
+func (h *ProfileHandler) Get(w http.ResponseWriter, r *http.Request, id string) {
+	profile, err := h.profileSvc.GetProfile(r.Context(), id)
+	if err != nil || profile == nil {
+		writeError(w, http.StatusNotFound, CodeProfileNotFound, "profile not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(profile); err != nil {
+		log.Printf("Failed to encode profile response: %v", err)
+	}
+}
+
+// Update handles PUT /api/v1/profiles/{id}. Replacing policy_ids reconciles
+// the bindings of every group the profile is bound to.
+func (h *ProfileHandler) Update(w http.ResponseWriter, r *http.Request, id string) {
+	var req models.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	profile, groupIDs, err := h.profileSvc.UpdateProfile(r.Context(), id, &req)
+	if err != nil {
+		log.Printf("Failed to update profile: %v", err)
+		writeError(w, http.StatusBadRequest, CodeProfileInvalid, err.Error())
+		return
+	}
+
+	h.notify(groupIDs)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(profile); err != nil {
+		log.Printf("Failed to encode profile response: %v", err)
+	}
+}
+
+// Delete handles DELETE /api/v1/profiles/{id}
+func (h *ProfileHandler) Delete(w http.ResponseWriter, r *http.Request, id string) {
+	groupIDs, err := h.profileSvc.DeleteProfile(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to delete profile: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete profile")
+		return
+	}
+
+	h.notify(groupIDs)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListBindings handles GET /api/v1/profiles/{id}/bindings
+func (h *ProfileHandler) ListBindings(w http.ResponseWriter, r *http.Request, id string) {
+	bindings, err := h.profileSvc.ListBindings(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to list bindings for profile %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list profile bindings")
+		return
+	}
+	if bindings == nil {
+		writeError(w, http.StatusNotFound, CodeProfileNotFound, "profile not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(bindings); err != nil {
+		log.Printf("Failed to encode profile bindings response: %v", err)
+	}
+}
+
+// Bind handles POST /api/v1/profiles/{id}/bindings and binds the profile to
+// a node group.
+func (h *ProfileHandler) Bind(w http.ResponseWriter, r *http.Request, id string) {
+	var req models.CreateProfileBindingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	binding, err := h.profileSvc.BindProfile(r.Context(), id, &req)
+	if err != nil {
+		log.Printf("Failed to bind profile %s: %v", id, err)
+		writeError(w, http.StatusBadRequest, CodeProfileInvalid, err.Error())
+		return
+	}
+
+	h.notify([]string{binding.GroupID})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(binding); err != nil {
+		log.Printf("Failed to encode profile binding response: %v", err)
+	}
+}
+
+// Unbind handles DELETE /api/v1/profiles/{id}/bindings/{groupID}
+func (h *ProfileHandler) Unbind(w http.ResponseWriter, r *http.Request, id, groupID string) {
+	if err := h.profileSvc.UnbindProfile(r.Context(), id, groupID); err != nil {
+		log.Printf("Failed to unbind profile %s from group %s: %v", id, groupID, err)
+		writeError(w, http.StatusNotFound, CodeProfileNotFound, err.Error())
+		return
+	}
+
+	h.notify([]string{groupID})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ProfileHandler) notify(groupIDs []string) {
+	if h.OnProfileChange != nil && len(groupIDs) > 0 {
+		h.OnProfileChange(groupIDs)
+	}
+}
+
+// extractProfileIDAndSubpath extracts the ID and optional sub-path from URL
+// paths like /api/v1/profiles/{id} or /api/v1/profiles/{id}/bindings/{groupID}
+func extractProfileIDAndSubpath(path string) (id, subpath string) {
+	const prefix = "/api/v1/profiles/"
+	if !strings.HasPrefix(path, prefix) {
+		return "", ""
+	}
+	rest := strings.TrimSuffix(strings.TrimPrefix(path, prefix), "/")
+
+	parts := strings.SplitN(rest, "/", 2)
+	id = parts[0]
+	if id == "" {
+		return "", ""
+	}
+	if len(parts) > 1 {
+		subpath = parts[1]
+	}
+	return id, subpath
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExtractProfileIDAndSubpath(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		wantID      string
+		wantSubpath string
+	}{
+		{"collection", "/api/v1/profiles", "", ""},
+		{"collection trailing slash", "/api/v1/profiles/", "", ""},
+		{"profile", "/api/v1/profiles/p1", "p1", ""},
+		{"bindings", "/api/v1/profiles/p1/bindings", "p1", "bindings"},
+		{"binding", "/api/v1/profiles/p1/bindings/g1/", "p1", "bindings/g1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotID, gotSubpath := extractProfileIDAndSubpath(tt.path)
+			if gotID != tt.wantID {
+				t.Errorf("extractProfileIDAndSubpath(%q) id = %q, want %q", tt.path, gotID, tt.wantID)
+			}
+			if gotSubpath != tt.wantSubpath {
+				t.Errorf("extractProfileIDAndSubpath(%q) subpath = %q, want %q", tt.path, gotSubpath, tt.wantSubpath)
+			}
+		})
+	}
+}
+
+func TestProfileHandler_MethodNotAllowed(t *testing.T) {
+	handler := &ProfileHandler{}
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPatch, "/api/v1/profiles"},
+		{http.MethodPut, "/api/v1/profiles/p1/bindings"},
+		{http.MethodGet, "/api/v1/profiles/p1/bindings/g1"},
+		{http.MethodPost, "/api/v1/profiles/p1"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, http.NoBody)
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s %s: status = %v, want %v", tt.method, tt.path, rr.Code, http.StatusMethodNotAllowed)
+		}
+	}
+}
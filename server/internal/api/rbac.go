@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+	"github.com/VuteTech/Bor/server/internal/services"
+)
+
+// RBACHandler handles RBAC export/import endpoints
+type RBACHandler struct {
+	rbacSvc *services.RBACService
+}
+
+// NewRBACHandler creates a new RBACHandler
+func NewRBACHandler(rbacSvc *services.RBACService) *RBACHandler {
+	return &RBACHandler{rbacSvc: rbacSvc}
+}
+
+// Export handles GET /api/v1/rbac/export
+// Query parameters:
+//   - bindings=true: include user and user-group role bindings
+func (h *RBACHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	includeBindings := r.URL.Query().Get("bindings") == "true"
+
+	export, err := h.rbacSvc.Export(r.Context(), includeBindings)
+	if err != nil {
+		log.Printf("Failed to export RBAC configuration: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to export RBAC configuration")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="bor-rbac.json"`)
+	if err := json.NewEncoder(w).Encode(export); err != nil {
+		log.Printf("Failed to encode RBAC export: %v", err)
+	}
+}
+
+// Import handles POST /api/v1/rbac/import. Replacing the permissions of a
+// built-in role requires ?overwrite_builtin=true.
+func (h *RBACHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var doc models.RBACExport
+	if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	overwriteBuiltin := r.URL.Query().Get("overwrite_builtin") == "true"
+
+	result, err := h.rbacSvc.Import(r.Context(), &doc, overwriteBuiltin)
+	if err != nil {
+		log.Printf("Failed to import RBAC configuration: %v", err)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Printf("Failed to encode RBAC import result: %v", err)
+	}
+}
@@ -27,14 +27,14 @@ func NewUserRoleBindingHandler(bindingRepo *database.UserRoleBindingRepository)
 func (h *UserRoleBindingHandler) ListByUser(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
-		http.Error(w, `{"error":"user_id query parameter required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "user_id query parameter required")
 		return
 	}
 
 	bindings, err := h.bindingRepo.ListByUserID(r.Context(), userID)
 	if err != nil {
 		log.Printf("Failed to list user role bindings: %v", err)
-		http.Error(w, `{"error":"failed to list bindings"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list bindings")
 		return
 	}
 
@@ -52,18 +52,21 @@ func (h *UserRoleBindingHandler) ListByUser(w http.ResponseWriter, r *http.Reque
 func (h *UserRoleBindingHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var binding models.UserRoleBinding
 	if err := json.NewDecoder(r.Body).Decode(&binding); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	if binding.UserID == "" || binding.RoleID == "" || binding.ScopeType == "" {
-		http.Error(w, `{"error":"user_id, role_id, and scope_type are required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "user_id, role_id, and scope_type are required")
 		return
 	}
+	// Bindings created through the API are always manual, so the LDAP
+	// role sync never revokes them.
+	binding.Source = models.RoleBindingSourceManual
 
 	if err := h.bindingRepo.Create(r.Context(), &binding); err != nil {
 		log.Printf("Failed to create user role binding: %v", err)
-		http.Error(w, `{"error":"failed to create binding"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to create binding")
 		return
 	}
 
@@ -78,13 +81,13 @@ func (h *UserRoleBindingHandler) Create(w http.ResponseWriter, r *http.Request)
 func (h *UserRoleBindingHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := extractIDFromPath(r.URL.Path, "/api/v1/user-role-bindings/")
 	if id == "" {
-		http.Error(w, `{"error":"binding id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "binding id required")
 		return
 	}
 
 	if err := h.bindingRepo.Delete(r.Context(), id); err != nil {
 		log.Printf("Failed to delete user role binding: %v", err)
-		http.Error(w, `{"error":"failed to delete binding"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete binding")
 		return
 	}
 
@@ -102,7 +105,7 @@ func (h *UserRoleBindingHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		case http.MethodPost:
 			h.Create(w, r)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
@@ -111,6 +114,6 @@ func (h *UserRoleBindingHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	case http.MethodDelete:
 		h.Delete(w, r)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
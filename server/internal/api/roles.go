@@ -5,7 +5,11 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strings"
@@ -33,7 +37,7 @@ func (h *RoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if idx := strings.Index(path, "/permissions"); idx > 0 {
 		roleID := extractRoleID(path)
 		if roleID == "" {
-			http.Error(w, `{"error":"role id required"}`, http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, "role id required")
 			return
 		}
 		switch r.Method {
@@ -42,11 +46,41 @@ func (h *RoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case http.MethodPut:
 			h.SetRolePermissions(w, r, roleID)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
 
+	// Users sub-resource: /api/v1/roles/{id}/users
+	if strings.HasSuffix(strings.TrimSuffix(path, "/"), "/users") {
+		roleID := extractRoleID(path)
+		if roleID == "" {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, "role id required")
+			return
+		}
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+			return
+		}
+		h.ListUsers(w, r, roleID)
+		return
+	}
+
+	// Clone sub-resource: /api/v1/roles/{id}/clone
+	if strings.HasSuffix(strings.TrimSuffix(path, "/"), "/clone") {
+		roleID := extractRoleID(path)
+		if roleID == "" {
+			writeError(w, http.StatusBadRequest, CodeInvalidRequest, "role id required")
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+			return
+		}
+		h.Clone(w, r, roleID)
+		return
+	}
+
 	id := extractIDFromPath(r.URL.Path, "/api/v1/roles/")
 
 	if id == "" {
@@ -56,7 +90,7 @@ func (h *RoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case http.MethodPost:
 			h.Create(w, r)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
@@ -69,7 +103,7 @@ func (h *RoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodDelete:
 		h.Delete(w, r, id)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -87,24 +121,42 @@ func extractRoleID(path string) string {
 	return parts[0]
 }
 
+// roleWithCount is a role in list responses.
+type roleWithCount struct {
+	models.Role
+	PermissionCount int `json:"permission_count"`
+}
+
 // List handles GET /api/v1/roles
 func (h *RoleHandler) List(w http.ResponseWriter, r *http.Request) {
+	if writeList(w, r, "roles", func(page models.Pagination) (*models.ListResponse[roleWithCount], error) {
+		roles, total, err := h.roleRepo.ListPaginated(r.Context(), page)
+		if err != nil {
+			return nil, err
+		}
+		return models.NewListResponse(h.withPermissionCounts(r.Context(), roles), total, page), nil
+	}) {
+		return
+	}
+
 	roles, err := h.roleRepo.List(r.Context())
 	if err != nil {
 		log.Printf("Failed to list roles: %v", err)
-		http.Error(w, `{"error":"failed to list roles"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list roles")
 		return
 	}
 
-	// Build response with permission counts
-	type roleWithCount struct {
-		models.Role
-		PermissionCount int `json:"permission_count"`
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.withPermissionCounts(r.Context(), roles)); err != nil {
+		log.Printf("Failed to encode roles response: %v", err)
 	}
+}
 
+// withPermissionCounts adds the number of permissions to each role.
+func (h *RoleHandler) withPermissionCounts(ctx context.Context, roles []*models.Role) []roleWithCount {
 	result := make([]roleWithCount, 0, len(roles))
 	for _, role := range roles {
-		perms, err := h.roleRepo.GetPermissionsByRoleID(r.Context(), role.ID)
+		perms, err := h.roleRepo.GetPermissionsByRoleID(ctx, role.ID)
 		if err != nil {
 			log.Printf("Failed to get permissions for role %s: %v", role.ID, err)
 			result = append(result, roleWithCount{Role: *role, PermissionCount: 0})
@@ -112,18 +164,14 @@ func (h *RoleHandler) List(w http.ResponseWriter, r *http.Request) {
 		}
 		result = append(result, roleWithCount{Role: *role, PermissionCount: len(perms)})
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(result); err != nil {
-		log.Printf("Failed to encode roles response: %v", err)
-	}
+	return result
 }
 
 // Get handles GET /api/v1/roles/{id}
 func (h *RoleHandler) Get(w http.ResponseWriter, r *http.Request, id string) {
 	role, err := h.roleRepo.GetByID(r.Context(), id)
 	if err != nil || role == nil {
-		http.Error(w, `{"error":"role not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeRoleNotFound, "role not found")
 		return
 	}
 
@@ -137,12 +185,12 @@ func (h *RoleHandler) Get(w http.ResponseWriter, r *http.Request, id string) {
 func (h *RoleHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateRoleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	if req.Name == "" {
-		http.Error(w, `{"error":"name is required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "name is required")
 		return
 	}
 
@@ -153,7 +201,7 @@ func (h *RoleHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.roleRepo.Create(r.Context(), role); err != nil {
 		log.Printf("Failed to create role: %v", err)
-		http.Error(w, `{"error":"failed to create role"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to create role")
 		return
 	}
 
@@ -164,23 +212,129 @@ func (h *RoleHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ListUsers handles GET /api/v1/roles/{id}/users, listing the users
+// holding the role directly or through a user group, with the scope of
+// each grant.
+func (h *RoleHandler) ListUsers(w http.ResponseWriter, r *http.Request, id string) {
+	role, err := h.roleRepo.GetByID(r.Context(), id)
+	if err != nil || role == nil {
+		writeError(w, http.StatusNotFound, CodeRoleNotFound, "role not found")
+		return
+	}
+
+	holders, err := h.bindingRepo.ListUsersByRoleID(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to list users of role %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list role users")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(holders); err != nil {
+		log.Printf("Failed to encode role users response: %v", err)
+	}
+}
+
+// maxCloneNameAttempts bounds the search for a free derived role name.
+const maxCloneNameAttempts = 100
+
+// Clone handles POST /api/v1/roles/{id}/clone, creating a new role with
+// the source role's description and permissions. Unless the body names
+// the new role, it is called "<name> (copy)", or "<name> (copy N)" when
+// that is taken.
+func (h *RoleHandler) Clone(w http.ResponseWriter, r *http.Request, id string) {
+	src, err := h.roleRepo.GetByID(r.Context(), id)
+	if err != nil || src == nil {
+		writeError(w, http.StatusNotFound, CodeRoleNotFound, "role not found")
+		return
+	}
+
+	var req models.CloneRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	name := req.Name
+	if name == "" {
+		if name, err = h.cloneName(r.Context(), src.Name); err != nil {
+			log.Printf("Failed to derive name for clone of role %s: %v", id, err)
+			writeError(w, http.StatusInternalServerError, CodeInternal, "failed to clone role")
+			return
+		}
+		if name == "" {
+			writeError(w, http.StatusConflict, CodeConflict, "too many copies of this role; name the clone explicitly")
+			return
+		}
+	} else if existing, err := h.roleRepo.GetByName(r.Context(), name); err == nil && existing != nil {
+		writeError(w, http.StatusConflict, CodeConflict, "a role with this name already exists")
+		return
+	}
+
+	role := &models.Role{Name: name, Description: src.Description}
+	if err := h.roleRepo.Create(r.Context(), role); err != nil {
+		log.Printf("Failed to create clone of role %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to clone role")
+		return
+	}
+	if err := h.roleRepo.ClonePermissions(r.Context(), src.ID, role.ID); err != nil {
+		log.Printf("Failed to copy permissions of role %s: %v", id, err)
+		if delErr := h.roleRepo.Delete(r.Context(), role.ID); delErr != nil {
+			log.Printf("Failed to remove incomplete clone %s: %v", role.ID, delErr)
+		}
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to clone role")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(role); err != nil {
+		log.Printf("Failed to encode role response: %v", err)
+	}
+}
+
+// cloneName returns the first derived name for a copy of role name that no
+// role has, or "" if the first maxCloneNameAttempts are all taken.
+func (h *RoleHandler) cloneName(ctx context.Context, name string) (string, error) {
+	for n := 1; n <= maxCloneNameAttempts; n++ {
+		candidate := cloneNameCandidate(name, n)
+		existing, err := h.roleRepo.GetByName(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// cloneNameCandidate returns the n-th derived name for a copy of a role
+// called name.
+func cloneNameCandidate(name string, n int) string {
+	if n == 1 {
+		return name + " (copy)"
+	}
+	return fmt.Sprintf("%s (copy %d)", name, n)
+}
+
 // Update handles PUT /api/v1/roles/{id}
 func (h *RoleHandler) Update(w http.ResponseWriter, r *http.Request, id string) {
 	role, err := h.roleRepo.GetByID(r.Context(), id)
 	if err != nil || role == nil {
-		http.Error(w, `{"error":"role not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeRoleNotFound, "role not found")
 		return
 	}
 
 	var req models.UpdateRoleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	if err := h.roleRepo.Update(r.Context(), id, &req); err != nil {
 		log.Printf("Failed to update role: %v", err)
-		http.Error(w, `{"error":"failed to update role"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to update role")
 		return
 	}
 
@@ -191,7 +345,7 @@ func (h *RoleHandler) Update(w http.ResponseWriter, r *http.Request, id string)
 func (h *RoleHandler) Delete(w http.ResponseWriter, r *http.Request, id string) {
 	if err := h.roleRepo.Delete(r.Context(), id); err != nil {
 		log.Printf("Failed to delete role: %v", err)
-		http.Error(w, `{"error":"failed to delete role"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete role")
 		return
 	}
 
@@ -203,7 +357,7 @@ func (h *RoleHandler) GetRolePermissions(w http.ResponseWriter, r *http.Request,
 	perms, err := h.roleRepo.GetPermissionsByRoleID(r.Context(), roleID)
 	if err != nil {
 		log.Printf("Failed to get role permissions: %v", err)
-		http.Error(w, `{"error":"failed to get role permissions"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to get role permissions")
 		return
 	}
 
@@ -221,13 +375,13 @@ func (h *RoleHandler) GetRolePermissions(w http.ResponseWriter, r *http.Request,
 func (h *RoleHandler) SetRolePermissions(w http.ResponseWriter, r *http.Request, roleID string) {
 	var req models.SetRolePermissionsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	if err := h.roleRepo.SetPermissions(r.Context(), roleID, req.PermissionIDs); err != nil {
 		log.Printf("Failed to set role permissions: %v", err)
-		http.Error(w, `{"error":"failed to set role permissions"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to set role permissions")
 		return
 	}
 
@@ -237,14 +391,14 @@ func (h *RoleHandler) SetRolePermissions(w http.ResponseWriter, r *http.Request,
 // ListAllPermissions handles GET /api/v1/permissions
 func (h *RoleHandler) ListAllPermissions(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	perms, err := h.permRepo.List(r.Context())
 	if err != nil {
 		log.Printf("Failed to list permissions: %v", err)
-		http.Error(w, `{"error":"failed to list permissions"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list permissions")
 		return
 	}
 
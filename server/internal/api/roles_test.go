@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCloneNameCandidate(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{1, "Auditor (copy)"},
+		{2, "Auditor (copy 2)"},
+		{10, "Auditor (copy 10)"},
+	}
+	for _, tt := range tests {
+		if got := cloneNameCandidate("Auditor", tt.n); got != tt.want {
+			t.Errorf("cloneNameCandidate(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestRoleHandler_Clone_MethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewRoleHandler(nil, nil, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/roles/abc/clone", http.NoBody))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestRoleHandler_ListUsers_MethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewRoleHandler(nil, nil, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/roles/abc/users", http.NoBody))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
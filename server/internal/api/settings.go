@@ -32,7 +32,7 @@ func (h *SettingsHandler) AgentNotifications(w http.ResponseWriter, r *http.Requ
 	case http.MethodPut:
 		h.updateAgentNotifications(w, r)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -40,7 +40,7 @@ func (h *SettingsHandler) getAgentNotifications(w http.ResponseWriter, r *http.R
 	settings, err := h.settingsSvc.GetAgentNotificationSettings(r.Context())
 	if err != nil {
 		log.Printf("Failed to get agent notification settings: %v", err)
-		http.Error(w, `{"error":"failed to get agent notification settings"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to get agent notification settings")
 		return
 	}
 
@@ -53,13 +53,13 @@ func (h *SettingsHandler) getAgentNotifications(w http.ResponseWriter, r *http.R
 func (h *SettingsHandler) updateAgentNotifications(w http.ResponseWriter, r *http.Request) {
 	var settings models.AgentNotificationSettings
 	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	if err := h.settingsSvc.UpdateAgentNotificationSettings(r.Context(), &settings); err != nil {
 		log.Printf("Failed to update agent notification settings: %v", err)
-		http.Error(w, `{"error":"`+err.Error()+`"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err.Error())
 		return
 	}
 
@@ -67,7 +67,7 @@ func (h *SettingsHandler) updateAgentNotifications(w http.ResponseWriter, r *htt
 	updated, err := h.settingsSvc.GetAgentNotificationSettings(r.Context())
 	if err != nil {
 		log.Printf("Failed to get updated agent notification settings: %v", err)
-		http.Error(w, `{"error":"failed to get updated settings"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to get updated settings")
 		return
 	}
 
@@ -80,7 +80,7 @@ func (h *SettingsHandler) updateAgentNotifications(w http.ResponseWriter, r *htt
 // MFASettings handles GET/PUT /api/v1/settings/mfa
 func (h *SettingsHandler) MFASettings(w http.ResponseWriter, r *http.Request) {
 	if h.mfaSvc == nil {
-		http.Error(w, `{"error":"MFA not configured"}`, http.StatusServiceUnavailable)
+		writeError(w, http.StatusServiceUnavailable, CodeUnavailable, "MFA not configured")
 		return
 	}
 	switch r.Method {
@@ -89,7 +89,7 @@ func (h *SettingsHandler) MFASettings(w http.ResponseWriter, r *http.Request) {
 	case http.MethodPut:
 		h.updateMFASettings(w, r)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -97,7 +97,7 @@ func (h *SettingsHandler) getMFASettings(w http.ResponseWriter, r *http.Request)
 	settings, err := h.mfaSvc.GetMFASettings(r.Context())
 	if err != nil {
 		log.Printf("Failed to get MFA settings: %v", err)
-		http.Error(w, `{"error":"failed to get MFA settings"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to get MFA settings")
 		return
 	}
 
@@ -110,13 +110,13 @@ func (h *SettingsHandler) getMFASettings(w http.ResponseWriter, r *http.Request)
 func (h *SettingsHandler) updateMFASettings(w http.ResponseWriter, r *http.Request) {
 	var settings models.MFASettings
 	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	if err := h.mfaSvc.UpdateMFASettings(r.Context(), &settings); err != nil {
 		log.Printf("Failed to update MFA settings: %v", err)
-		http.Error(w, `{"error":"`+err.Error()+`"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, err.Error())
 		return
 	}
 
@@ -124,7 +124,7 @@ func (h *SettingsHandler) updateMFASettings(w http.ResponseWriter, r *http.Reque
 	updated, err := h.mfaSvc.GetMFASettings(r.Context())
 	if err != nil {
 		log.Printf("Failed to get updated MFA settings: %v", err)
-		http.Error(w, `{"error":"failed to get updated MFA settings"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to get updated MFA settings")
 		return
 	}
 
@@ -6,7 +6,10 @@ package api
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
+
+	"github.com/VuteTech/Bor/server/internal/services"
 )
 
 // VersionResponse is the payload for GET /api/v1/version.
@@ -25,3 +28,23 @@ func NewVersionHandler(version string) http.HandlerFunc {
 		_, _ = w.Write(payload)
 	}
 }
+
+// NewCRLHandler returns an http.HandlerFunc that serves the internal CA's
+// certificate revocation list as PEM. No authentication is required — a CRL
+// is signed and public by design.
+func NewCRLHandler(enrollSvc *services.EnrollmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+			return
+		}
+		crl, err := enrollSvc.CRL(r.Context())
+		if err != nil {
+			log.Printf("Failed to generate CRL: %v", err)
+			writeError(w, http.StatusInternalServerError, CodeInternal, "failed to generate CRL")
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-pem-file")
+		_, _ = w.Write(crl)
+	}
+}
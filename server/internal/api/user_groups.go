@@ -20,6 +20,10 @@ type UserGroupHandler struct {
 	userGroupSvc *services.UserGroupService
 	memberRepo   *database.UserGroupMemberRepository
 	bindingRepo  *database.UserGroupRoleBindingRepository
+	// OnMembershipChange is called after members are added or removed or a
+	// group is deleted, since that may change which logged-in users receive
+	// the policies bound to the group.
+	OnMembershipChange func(groupID string)
 }
 
 // NewUserGroupHandler creates a new UserGroupHandler
@@ -47,7 +51,7 @@ func (h *UserGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case http.MethodPost:
 			h.Create(w, r)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
@@ -60,7 +64,7 @@ func (h *UserGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case "role-bindings":
 			h.handleRoleBindings(w, r, id, subID)
 		default:
-			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+			writeError(w, http.StatusNotFound, CodeNotFound, "not found")
 		}
 		return
 	}
@@ -74,16 +78,22 @@ func (h *UserGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodDelete:
 		h.Delete(w, r, id)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
 // List handles GET /api/v1/user-groups
 func (h *UserGroupHandler) List(w http.ResponseWriter, r *http.Request) {
+	if writeList(w, r, "user groups", func(page models.Pagination) (*models.ListResponse[*models.UserGroup], error) {
+		return h.userGroupSvc.ListUserGroupsPaginated(r.Context(), page)
+	}) {
+		return
+	}
+
 	groups, err := h.userGroupSvc.ListUserGroups(r.Context())
 	if err != nil {
 		log.Printf("Failed to list user groups: %v", err)
-		http.Error(w, `{"error":"failed to list user groups"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list user groups")
 		return
 	}
 
@@ -101,19 +111,14 @@ func (h *UserGroupHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *UserGroupHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateUserGroupRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	group, err := h.userGroupSvc.CreateUserGroup(r.Context(), &req)
 	if err != nil {
 		log.Printf("Failed to create user group: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodeUserGroupInvalid, err.Error())
 		return
 	}
 
@@ -128,7 +133,7 @@ func (h *UserGroupHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *UserGroupHandler) Get(w http.ResponseWriter, r *http.Request, id string) {
 	group, err := h.userGroupSvc.GetUserGroup(r.Context(), id)
 	if err != nil || group == nil {
-		http.Error(w, `{"error":"user group not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeUserGroupNotFound, "user group not found")
 		return
 	}
 
@@ -142,19 +147,14 @@ func (h *UserGroupHandler) Get(w http.ResponseWriter, r *http.Request, id string
 func (h *UserGroupHandler) Update(w http.ResponseWriter, r *http.Request, id string) {
 	var req models.UpdateUserGroupRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	group, err := h.userGroupSvc.UpdateUserGroup(r.Context(), id, &req)
 	if err != nil {
 		log.Printf("Failed to update user group: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodeUserGroupInvalid, err.Error())
 		return
 	}
 
@@ -168,15 +168,13 @@ func (h *UserGroupHandler) Update(w http.ResponseWriter, r *http.Request, id str
 func (h *UserGroupHandler) Delete(w http.ResponseWriter, r *http.Request, id string) {
 	if err := h.userGroupSvc.DeleteUserGroup(r.Context(), id); err != nil {
 		log.Printf("Failed to delete user group: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusConflict)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusConflict, CodeUserGroupConflict, err.Error())
 		return
 	}
 
+	if h.OnMembershipChange != nil {
+		h.OnMembershipChange(id)
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -189,16 +187,16 @@ func (h *UserGroupHandler) handleMembers(w http.ResponseWriter, r *http.Request,
 		case http.MethodPost:
 			h.AddMember(w, r, groupID)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
 
 	switch r.Method {
 	case http.MethodDelete:
-		h.RemoveMember(w, r, memberID)
+		h.RemoveMember(w, r, groupID, memberID)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -207,7 +205,7 @@ func (h *UserGroupHandler) ListMembers(w http.ResponseWriter, r *http.Request, g
 	members, err := h.memberRepo.ListByGroupID(r.Context(), groupID)
 	if err != nil {
 		log.Printf("Failed to list group members: %v", err)
-		http.Error(w, `{"error":"failed to list members"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list members")
 		return
 	}
 
@@ -225,12 +223,12 @@ func (h *UserGroupHandler) ListMembers(w http.ResponseWriter, r *http.Request, g
 func (h *UserGroupHandler) AddMember(w http.ResponseWriter, r *http.Request, groupID string) {
 	var req models.AddGroupMemberRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	if req.UserID == "" {
-		http.Error(w, `{"error":"user_id is required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "user_id is required")
 		return
 	}
 
@@ -240,9 +238,12 @@ func (h *UserGroupHandler) AddMember(w http.ResponseWriter, r *http.Request, gro
 	}
 	if err := h.memberRepo.Create(r.Context(), member); err != nil {
 		log.Printf("Failed to add group member: %v", err)
-		http.Error(w, `{"error":"failed to add member"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to add member")
 		return
 	}
+	if h.OnMembershipChange != nil {
+		h.OnMembershipChange(groupID)
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -252,12 +253,15 @@ func (h *UserGroupHandler) AddMember(w http.ResponseWriter, r *http.Request, gro
 }
 
 // RemoveMember handles DELETE /api/v1/user-groups/{id}/members/{member_id}
-func (h *UserGroupHandler) RemoveMember(w http.ResponseWriter, r *http.Request, memberID string) {
+func (h *UserGroupHandler) RemoveMember(w http.ResponseWriter, r *http.Request, groupID, memberID string) {
 	if err := h.memberRepo.Delete(r.Context(), memberID); err != nil {
 		log.Printf("Failed to remove group member: %v", err)
-		http.Error(w, `{"error":"failed to remove member"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to remove member")
 		return
 	}
+	if h.OnMembershipChange != nil {
+		h.OnMembershipChange(groupID)
+	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -271,7 +275,7 @@ func (h *UserGroupHandler) handleRoleBindings(w http.ResponseWriter, r *http.Req
 		case http.MethodPost:
 			h.AddGroupRoleBinding(w, r, groupID)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
@@ -280,7 +284,7 @@ func (h *UserGroupHandler) handleRoleBindings(w http.ResponseWriter, r *http.Req
 	case http.MethodDelete:
 		h.RemoveGroupRoleBinding(w, r, bindingID)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -289,7 +293,7 @@ func (h *UserGroupHandler) ListGroupRoleBindings(w http.ResponseWriter, r *http.
 	bindings, err := h.bindingRepo.ListByGroupID(r.Context(), groupID)
 	if err != nil {
 		log.Printf("Failed to list group role bindings: %v", err)
-		http.Error(w, `{"error":"failed to list role bindings"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list role bindings")
 		return
 	}
 
@@ -307,12 +311,12 @@ func (h *UserGroupHandler) ListGroupRoleBindings(w http.ResponseWriter, r *http.
 func (h *UserGroupHandler) AddGroupRoleBinding(w http.ResponseWriter, r *http.Request, groupID string) {
 	var req models.CreateGroupRoleBindingRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	if req.RoleID == "" || req.ScopeType == "" {
-		http.Error(w, `{"error":"role_id and scope_type are required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "role_id and scope_type are required")
 		return
 	}
 
@@ -324,7 +328,7 @@ func (h *UserGroupHandler) AddGroupRoleBinding(w http.ResponseWriter, r *http.Re
 	}
 	if err := h.bindingRepo.Create(r.Context(), binding); err != nil {
 		log.Printf("Failed to create group role binding: %v", err)
-		http.Error(w, `{"error":"failed to create role binding"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to create role binding")
 		return
 	}
 
@@ -339,7 +343,7 @@ func (h *UserGroupHandler) AddGroupRoleBinding(w http.ResponseWriter, r *http.Re
 func (h *UserGroupHandler) RemoveGroupRoleBinding(w http.ResponseWriter, r *http.Request, bindingID string) {
 	if err := h.bindingRepo.Delete(r.Context(), bindingID); err != nil {
 		log.Printf("Failed to delete group role binding: %v", err)
-		http.Error(w, `{"error":"failed to delete role binding"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete role binding")
 		return
 	}
 
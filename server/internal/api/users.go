@@ -6,6 +6,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -28,7 +29,13 @@ func NewUserHandler(authSvc *services.AuthService) *UserHandler {
 // List handles GET /api/v1/users
 func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if writeList(w, r, "users", func(page models.Pagination) (*models.ListResponse[*models.User], error) {
+		return h.authSvc.ListUsersPaginated(r.Context(), page)
+	}) {
 		return
 	}
 
@@ -38,7 +45,7 @@ func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 	users, err := h.authSvc.ListUsers(r.Context(), limit, offset)
 	if err != nil {
 		log.Printf("Failed to list users: %v", err)
-		http.Error(w, `{"error":"failed to list users"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list users")
 		return
 	}
 
@@ -51,25 +58,20 @@ func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 // Create handles POST /api/v1/users
 func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	var req models.CreateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	user, err := h.authSvc.CreateUser(r.Context(), &req)
 	if err != nil {
 		log.Printf("Failed to create user: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
-		errResp := map[string]string{"error": err.Error()}
-		if encErr := json.NewEncoder(w).Encode(errResp); encErr != nil {
-			log.Printf("Failed to encode error response: %v", encErr)
-		}
+		writeError(w, http.StatusBadRequest, CodeUserInvalid, err.Error())
 		return
 	}
 
@@ -83,19 +85,19 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 // Get handles GET /api/v1/users/{id}
 func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	id := extractIDFromPath(r.URL.Path, "/api/v1/users/")
 	if id == "" {
-		http.Error(w, `{"error":"user id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "user id required")
 		return
 	}
 
 	user, err := h.authSvc.GetUser(r.Context(), id)
 	if err != nil || user == nil {
-		http.Error(w, `{"error":"user not found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, CodeUserNotFound, "user not found")
 		return
 	}
 
@@ -108,30 +110,30 @@ func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
 // Update handles PUT /api/v1/users/{id}
 func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	id := extractIDFromPath(r.URL.Path, "/api/v1/users/")
 	if id == "" {
-		http.Error(w, `{"error":"user id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "user id required")
 		return
 	}
 
 	var req models.UpdateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
 		return
 	}
 
 	if req.Email == nil && req.FullName == nil && req.Enabled == nil {
-		http.Error(w, `{"error":"at least one field must be provided"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "at least one field must be provided")
 		return
 	}
 
 	if err := h.authSvc.UpdateUser(r.Context(), id, &req); err != nil {
 		log.Printf("Failed to update user: %v", err)
-		http.Error(w, `{"error":"failed to update user"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to update user")
 		return
 	}
 
@@ -141,29 +143,109 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 // Delete handles DELETE /api/v1/users/{id}
 func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		return
 	}
 
 	id := extractIDFromPath(r.URL.Path, "/api/v1/users/")
 	if id == "" {
-		http.Error(w, `{"error":"user id required"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "user id required")
 		return
 	}
 
 	if err := h.authSvc.DeleteUser(r.Context(), id); err != nil {
 		log.Printf("Failed to delete user: %v", err)
-		http.Error(w, `{"error":"failed to delete user"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResetPassword handles POST /api/v1/users/{id}/reset-password, setting a
+// new password for a local user. The audit middleware records the reset
+// with the acting admin; the password itself is redacted.
+func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req models.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+	if req.NewPassword == "" {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequest, "new_password is required")
+		return
+	}
+
+	err := h.authSvc.ResetPassword(r.Context(), id, req.NewPassword, req.MustChange)
+	switch {
+	case err == nil:
+	case errors.Is(err, services.ErrUserNotFound):
+		writeError(w, http.StatusNotFound, CodeUserNotFound, "user not found")
+		return
+	case errors.Is(err, services.ErrPasswordNotLocal):
+		writeError(w, http.StatusBadRequest, CodeExternalPassword, "the password of directory (LDAP) users must be reset in the directory")
+		return
+	case errors.Is(err, services.ErrWeakPassword):
+		writeError(w, http.StatusBadRequest, CodeWeakPassword, err.Error())
+		return
+	default:
+		log.Printf("Failed to reset password of user %s: %v", id, err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to reset password")
 		return
 	}
 
+	admin := ""
+	if claims := GetUserFromContext(r.Context()); claims != nil {
+		admin = claims.Username
+	}
+	log.Printf("Password of user %s reset by %s (must change: %v)", id, admin, req.MustChange)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// EffectivePermissions handles GET /api/v1/users/{id}/effective-permissions,
+// returning the user's permissions per scope with the role bindings that
+// grant them, so admins can see why a user can or cannot do something.
+func (h *UserHandler) EffectivePermissions(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	perms, err := h.authSvc.GetEffectivePermissions(r.Context(), id)
+	if err != nil {
+		log.Printf("Failed to get effective permissions of user %s: %v", id, err) //nolint:gosec // id comes from URL path parameter
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to get effective permissions")
+		return
+	}
+	if perms == nil {
+		writeError(w, http.StatusNotFound, CodeUserNotFound, "user not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(perms); err != nil {
+		log.Printf("Failed to encode effective permissions response: %v", err)
+	}
+}
+
 // ServeHTTP routes requests to the appropriate handler method
 func (h *UserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	id := extractIDFromPath(r.URL.Path, "/api/v1/users/")
 
+	if userID, ok := strings.CutSuffix(id, "/reset-password"); ok && userID != "" {
+		h.ResetPassword(w, r, userID)
+		return
+	}
+	if userID, ok := strings.CutSuffix(id, "/effective-permissions"); ok && userID != "" {
+		h.EffectivePermissions(w, r, userID)
+		return
+	}
+
 	if id == "" {
 		switch r.Method {
 		case http.MethodGet:
@@ -171,7 +253,7 @@ func (h *UserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		case http.MethodPost:
 			h.Create(w, r)
 		default:
-			http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 		}
 		return
 	}
@@ -184,7 +266,7 @@ func (h *UserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodDelete:
 		h.Delete(w, r)
 	default:
-		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
 	}
 }
 
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUserHandler_ResetPassword_Validation(t *testing.T) {
+	h := NewUserHandler(nil)
+
+	tests := []struct {
+		name     string
+		req      *http.Request
+		wantCode int
+	}{
+		{
+			name:     "method not allowed",
+			req:      reqWithUser(http.MethodGet, "/api/v1/users/u-1/reset-password"),
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "missing body",
+			req:      reqWithUser(http.MethodPost, "/api/v1/users/u-1/reset-password"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "missing new password",
+			req: httptest.NewRequest(http.MethodPost, "/api/v1/users/u-1/reset-password",
+				strings.NewReader(`{"must_change":true}`)),
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, tt.req)
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+	"github.com/VuteTech/Bor/server/internal/services"
+)
+
+// WebhookHandler handles webhook settings endpoints
+type WebhookHandler struct {
+	webhookSvc *services.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler
+func NewWebhookHandler(webhookSvc *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookSvc: webhookSvc}
+}
+
+// ServeHTTP routes /api/v1/settings/webhooks, /api/v1/settings/webhooks/test
+// and /api/v1/settings/webhooks/{id}
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/settings/webhooks"), "/")
+
+	switch {
+	case id == "":
+		switch r.Method {
+		case http.MethodGet:
+			h.List(w, r)
+		case http.MethodPost:
+			h.Create(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		}
+	case id == "test":
+		h.Test(w, r)
+	case strings.Contains(id, "/"):
+		writeError(w, http.StatusNotFound, CodeNotFound, "not found")
+	default:
+		switch r.Method {
+		case http.MethodGet:
+			h.Get(w, r, id)
+		case http.MethodPut:
+			h.Update(w, r, id)
+		case http.MethodDelete:
+			h.Delete(w, r, id)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		}
+	}
+}
+
+// List handles GET /api/v1/settings/webhooks
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhookSvc.ListWebhooks(r.Context())
+	if err != nil {
+		log.Printf("Failed to list webhooks: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to list webhooks")
+		return
+	}
+
+	if webhooks == nil {
+		webhooks = []*models.Webhook{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(webhooks); err != nil {
+		log.Printf("Failed to encode webhooks response: %v", err)
+	}
+}
+
+// Create handles POST /api/v1/settings/webhooks
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	webhook, err := h.webhookSvc.CreateWebhook(r.Context(), &req)
+	if err != nil {
+		log.Printf("Failed to create webhook: %v", err)
+		writeError(w, http.StatusBadRequest, CodeWebhookInvalid, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(webhook); err != nil {
+		log.Printf("Failed to encode webhook response: %v", err)
+	}
+}
+
+// Get handles GET /api/v1/settings/webhooks/{id}
+func (h *WebhookHandler) Get(w http.ResponseWriter, r *http.Request, id string) {
+	webhook, err := h.webhookSvc.GetWebhook(r.Context(), id)
+	if err != nil || webhook == nil {
+		writeError(w, http.StatusNotFound, CodeWebhookNotFound, "webhook not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(webhook); err != nil {
+		log.Printf("Failed to encode webhook response: %v", err)
+	}
+}
+
+// Update handles PUT /api/v1/settings/webhooks/{id}
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request, id string) {
+	var req models.UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	webhook, err := h.webhookSvc.UpdateWebhook(r.Context(), id, &req)
+	if err != nil {
+		log.Printf("Failed to update webhook: %v", err)
+		writeError(w, http.StatusBadRequest, CodeWebhookInvalid, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(webhook); err != nil {
+		log.Printf("Failed to encode webhook response: %v", err)
+	}
+}
+
+// Delete handles DELETE /api/v1/settings/webhooks/{id}
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.webhookSvc.DeleteWebhook(r.Context(), id); err != nil {
+		log.Printf("Failed to delete webhook: %v", err)
+		writeError(w, http.StatusInternalServerError, CodeInternal, "failed to delete webhook")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Test handles POST /api/v1/settings/webhooks/test. It sends a test event
+// to one webhook, or to every enabled webhook when no webhook_id is given,
+// and returns the result of each delivery.
+func (h *WebhookHandler) Test(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req models.WebhookTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, CodeInvalidRequestBody, "invalid request body")
+		return
+	}
+
+	actor := ""
+	if claims := GetUserFromContext(r.Context()); claims != nil {
+		actor = claims.Username
+	}
+
+	results, err := h.webhookSvc.Test(r.Context(), req.WebhookID, actor)
+	if err != nil {
+		log.Printf("Failed to test webhooks: %v", err)
+		writeError(w, http.StatusBadRequest, CodeWebhookInvalid, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		log.Printf("Failed to encode webhook test results: %v", err)
+	}
+}
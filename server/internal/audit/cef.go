@@ -53,6 +53,10 @@ func FormatCEF(event *auditpb.AuditEvent) string {
 	writeExt(&ext, "cs3Label", "resourceType")
 	writeExt(&ext, "cs3", event.GetResource().GetType())
 	writeExt(&ext, "outcome", outcomeString(event.GetOutcome()))
+	if cid := event.GetCorrelationId(); cid != "" {
+		writeExt(&ext, "cs4Label", "correlationId")
+		writeExt(&ext, "cs4", cid)
+	}
 
 	// Payload-specific extensions
 	switch p := event.GetPayload().(type) {
@@ -72,6 +76,16 @@ func FormatCEF(event *auditpb.AuditEvent) string {
 			}
 			writeExt(&ext, "msg", cefEscapeVal(strings.Join(parts, "; ")))
 		}
+
+	case *auditpb.AuditEvent_Agent:
+		writeExt(&ext, "request", p.Agent.GetMethod())
+		if details := p.Agent.GetDetailsJson(); details != "" {
+			writeExt(&ext, "msg", cefEscapeVal(details))
+		}
+
+	case *auditpb.AuditEvent_Purge:
+		writeExt(&ext, "cnt", fmt.Sprintf("%d", p.Purge.GetDeleted()))
+		writeExt(&ext, "end", fmt.Sprintf("%d", p.Purge.GetBefore().AsTime().UnixMilli()))
 	}
 
 	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%d|%s",
@@ -6,6 +6,7 @@ package audit
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	auditpb "github.com/VuteTech/Bor/server/pkg/grpc/audit"
@@ -62,6 +63,8 @@ type ocsfMetadata struct {
 	Product   ocsfProduct `json:"product"`
 	Version   string      `json:"version"`
 	EventCode string      `json:"event_code,omitempty"`
+	// CorrelationUID groups the events of one request.
+	CorrelationUID string `json:"correlation_uid,omitempty"`
 }
 
 type ocsfProduct struct {
@@ -143,7 +146,8 @@ func buildOCSFEvent(event *auditpb.AuditEvent) ocsfEvent {
 				Vendor:  "Vute Tech",
 				Version: "1.0",
 			},
-			EventCode: event.GetAction(),
+			EventCode:      event.GetAction(),
+			CorrelationUID: event.GetCorrelationId(),
 		},
 		Actor: &ocsfActor{
 			User: ocsfUser{
@@ -191,6 +195,20 @@ func buildOCSFEvent(event *auditpb.AuditEvent) ocsfEvent {
 		}
 		ev.FileActivity = fa
 		ev.Message = "managed file tampered: " + p.Tamper.GetFilePath()
+
+	case *auditpb.AuditEvent_Agent:
+		ev.APIActivity = &ocsfAPIActivity{
+			Operation: event.GetAction(),
+			Request: &ocsfAPIRequest{
+				URL:  p.Agent.GetMethod(),
+				Body: p.Agent.GetDetailsJson(),
+			},
+		}
+		ev.Message = "agent " + event.GetActor().GetUsername() + " " + event.GetAction()
+
+	case *auditpb.AuditEvent_Purge:
+		ev.Message = fmt.Sprintf("purged %d audit log entries created before %s",
+			p.Purge.GetDeleted(), p.Purge.GetBefore().AsTime().UTC().Format(time.RFC3339))
 	}
 
 	return ev
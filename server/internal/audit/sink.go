@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"time"
 
 	auditpb "github.com/VuteTech/Bor/server/pkg/grpc/audit"
 )
@@ -39,6 +40,8 @@ type DBEntry struct {
 	ResourceID   string
 	Details      string
 	IPAddress    string
+	// CorrelationID groups the entries produced by one request.
+	CorrelationID string
 }
 
 // NewDatabaseSink creates a DatabaseSink.  The create function must insert
@@ -55,6 +58,8 @@ func (s *DatabaseSink) Emit(ctx context.Context, event *auditpb.AuditEvent) {
 		ResourceType: event.GetResource().GetType(),
 		ResourceID:   event.GetResource().GetId(),
 		IPAddress:    event.GetSrcIp(),
+
+		CorrelationID: event.GetCorrelationId(),
 	}
 
 	if uid := event.GetActor().GetUserId(); uid != "" {
@@ -106,6 +111,23 @@ func marshalDetails(event *auditpb.AuditEvent) string {
 		}
 		return string(b)
 
+	case *auditpb.AuditEvent_Agent:
+		// details_json is already a JSON object string.
+		return p.Agent.GetDetailsJson()
+
+	case *auditpb.AuditEvent_Purge:
+		b, err := json.Marshal(struct {
+			Before  string `json:"before"`
+			Deleted int64  `json:"deleted"`
+		}{
+			Before:  p.Purge.GetBefore().AsTime().UTC().Format(time.RFC3339),
+			Deleted: p.Purge.GetDeleted(),
+		})
+		if err != nil {
+			return ""
+		}
+		return string(b)
+
 	default:
 		return ""
 	}
@@ -18,24 +18,46 @@ type Authorizer interface {
 	HasPermission(ctx context.Context, userID, resource, action, scopeType string, scopeID *string) (bool, error)
 }
 
+// userBindingLister lists the direct role bindings of a user.
+type userBindingLister interface {
+	ListByUserID(ctx context.Context, userID string) ([]*models.UserRoleBinding, error)
+}
+
+// groupBindingLister lists the role bindings of the user groups a user is
+// a member of.
+type groupBindingLister interface {
+	ListByUserID(ctx context.Context, userID string) ([]*models.UserGroupRoleBinding, error)
+}
+
+// rolePermissionLister lists the permissions of a role.
+type rolePermissionLister interface {
+	GetPermissionsByRoleID(ctx context.Context, roleID string) ([]*models.Permission, error)
+}
+
 // authorizer implements the Authorizer interface using the RBAC database tables
 type authorizer struct {
-	bindingRepo *database.UserRoleBindingRepository
-	roleRepo    *database.RoleRepository
+	bindingRepo      userBindingLister
+	groupBindingRepo groupBindingLister // nil ignores user group role bindings
+	roleRepo         rolePermissionLister
 }
 
-// New creates a new Authorizer
-func New(bindingRepo *database.UserRoleBindingRepository, roleRepo *database.RoleRepository) Authorizer {
-	return &authorizer{
+// New creates a new Authorizer. Users hold the roles of their direct role
+// bindings and of the role bindings of the user groups they are members of.
+func New(bindingRepo *database.UserRoleBindingRepository, groupBindingRepo *database.UserGroupRoleBindingRepository, roleRepo *database.RoleRepository) Authorizer {
+	a := &authorizer{
 		bindingRepo: bindingRepo,
 		roleRepo:    roleRepo,
 	}
+	if groupBindingRepo != nil {
+		a.groupBindingRepo = groupBindingRepo
+	}
+	return a
 }
 
 // HasPermission checks if a user has a specific permission within a given scope.
 //
 // Logic:
-//   - Fetch all role bindings for the user
+//   - Fetch all role bindings for the user, direct and through user groups
 //   - Filter by matching scope:
 //   - "global" applies everywhere
 //   - "organization" applies only if scope matches
@@ -43,9 +65,9 @@ func New(bindingRepo *database.UserRoleBindingRepository, roleRepo *database.Rol
 //   - Collect permissions via role_permissions
 //   - Match resource + action
 func (a *authorizer) HasPermission(ctx context.Context, userID, resource, action, scopeType string, scopeID *string) (bool, error) {
-	bindings, err := a.bindingRepo.ListByUserID(ctx, userID)
+	bindings, err := a.userBindings(ctx, userID)
 	if err != nil {
-		return false, fmt.Errorf("failed to fetch role bindings: %w", err)
+		return false, err
 	}
 
 	// Filter bindings by scope
@@ -73,6 +95,35 @@ func (a *authorizer) HasPermission(ctx context.Context, userID, resource, action
 	return false, nil
 }
 
+// userBindings returns the direct role bindings of a user followed by the
+// role bindings of the user groups the user is a member of. Group bindings
+// are returned as user bindings with the group binding's ID, role and scope,
+// so that scope matching applies to both alike.
+func (a *authorizer) userBindings(ctx context.Context, userID string) ([]*models.UserRoleBinding, error) {
+	bindings, err := a.bindingRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch role bindings: %w", err)
+	}
+	if a.groupBindingRepo == nil {
+		return bindings, nil
+	}
+	groupBindings, err := a.groupBindingRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch group role bindings: %w", err)
+	}
+	for _, gb := range groupBindings {
+		bindings = append(bindings, &models.UserRoleBinding{
+			ID:        gb.ID,
+			UserID:    userID,
+			RoleID:    gb.RoleID,
+			ScopeType: gb.ScopeType,
+			ScopeID:   gb.ScopeID,
+			CreatedAt: gb.CreatedAt,
+		})
+	}
+	return bindings, nil
+}
+
 // matchesScope checks if a user role binding matches the requested scope.
 // Global scope always matches regardless of the requested scope.
 // For organization and group scopes, both the scope type and scope ID must match.
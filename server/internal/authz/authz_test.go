@@ -34,38 +34,29 @@ func (m *mockRoleRepo) GetPermissionsByRoleID(_ context.Context, roleID string)
 	return m.permissions[roleID], nil
 }
 
+// mockGroupBindingRepo implements the methods used by the authorizer from UserGroupRoleBindingRepository
+type mockGroupBindingRepo struct {
+	bindings []*models.UserGroupRoleBinding
+	err      error
+}
+
+func (m *mockGroupBindingRepo) ListByUserID(_ context.Context, _ string) ([]*models.UserGroupRoleBinding, error) {
+	return m.bindings, m.err
+}
+
 // testAuthorizer creates an authorizer with mock repos for testing
 type testAuthorizer struct {
-	bindingRepo *mockBindingRepo
-	roleRepo    *mockRoleRepo
+	bindingRepo      *mockBindingRepo
+	groupBindingRepo *mockGroupBindingRepo
+	roleRepo         *mockRoleRepo
 }
 
 func (a *testAuthorizer) HasPermission(ctx context.Context, userID, resource, action, scopeType string, scopeID *string) (bool, error) {
-	bindings, err := a.bindingRepo.ListByUserID(ctx, userID)
-	if err != nil {
-		return false, err
-	}
-
-	var matchingBindings []*models.UserRoleBinding
-	for _, b := range bindings {
-		if matchesScope(b, scopeType, scopeID) {
-			matchingBindings = append(matchingBindings, b)
-		}
-	}
-
-	for _, b := range matchingBindings {
-		perms, err := a.roleRepo.GetPermissionsByRoleID(ctx, b.RoleID)
-		if err != nil {
-			return false, err
-		}
-		for _, p := range perms {
-			if p.Resource == resource && p.Action == action {
-				return true, nil
-			}
-		}
+	az := &authorizer{bindingRepo: a.bindingRepo, roleRepo: a.roleRepo}
+	if a.groupBindingRepo != nil {
+		az.groupBindingRepo = a.groupBindingRepo
 	}
-
-	return false, nil
+	return az.HasPermission(ctx, userID, resource, action, scopeType, scopeID)
 }
 
 func strPtr(s string) *string { return &s }
@@ -330,3 +321,102 @@ func TestHasPermission_GlobalBindingAppliesEverywhere(t *testing.T) {
 		t.Error("global binding should apply to group scope checks")
 	}
 }
+
+func TestHasPermission_ViaUserGroup(t *testing.T) {
+	az := &testAuthorizer{
+		bindingRepo: &mockBindingRepo{bindings: nil},
+		groupBindingRepo: &mockGroupBindingRepo{
+			bindings: []*models.UserGroupRoleBinding{
+				{GroupID: "ug-1", RoleID: "role-editor", ScopeType: models.ScopeGlobal},
+			},
+		},
+		roleRepo: &mockRoleRepo{
+			permissions: map[string][]*models.Permission{
+				"role-editor": {{Resource: "policy", Action: "edit"}},
+			},
+		},
+	}
+
+	ok, err := az.HasPermission(context.Background(), "user-1", "policy", "edit", models.ScopeGlobal, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("user should have policy:edit through user group membership")
+	}
+
+	ok, err = az.HasPermission(context.Background(), "user-1", "policy", "delete", models.ScopeGlobal, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("user should not have policy:delete")
+	}
+}
+
+func TestHasPermission_ViaUserGroupScoped(t *testing.T) {
+	groupID := "grp-1"
+	az := &testAuthorizer{
+		bindingRepo: &mockBindingRepo{
+			bindings: []*models.UserRoleBinding{
+				{RoleID: "role-viewer", ScopeType: models.ScopeGlobal},
+			},
+		},
+		groupBindingRepo: &mockGroupBindingRepo{
+			bindings: []*models.UserGroupRoleBinding{
+				{GroupID: "ug-1", RoleID: "role-editor", ScopeType: models.ScopeGroup, ScopeID: &groupID},
+			},
+		},
+		roleRepo: &mockRoleRepo{
+			permissions: map[string][]*models.Permission{
+				"role-viewer": {{Resource: "policy", Action: "view"}},
+				"role-editor": {{Resource: "policy", Action: "edit"}},
+			},
+		},
+	}
+	ctx := context.Background()
+
+	ok, err := az.HasPermission(ctx, "user-1", "policy", "edit", models.ScopeGroup, &groupID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("group-derived binding should grant policy:edit in its scope")
+	}
+
+	otherGroup := "grp-2"
+	ok, err = az.HasPermission(ctx, "user-1", "policy", "edit", models.ScopeGroup, &otherGroup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("group-derived binding should not grant policy:edit in another scope")
+	}
+
+	ok, err = az.HasPermission(ctx, "user-1", "policy", "edit", models.ScopeGlobal, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("group-derived scoped binding should not grant policy:edit globally")
+	}
+
+	ok, err = az.HasPermission(ctx, "user-1", "policy", "view", models.ScopeGroup, &otherGroup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Error("direct global binding should still apply")
+	}
+}
+
+func TestHasPermission_GroupBindingError(t *testing.T) {
+	az := &testAuthorizer{
+		bindingRepo:      &mockBindingRepo{},
+		groupBindingRepo: &mockGroupBindingRepo{err: context.DeadlineExceeded},
+		roleRepo:         &mockRoleRepo{},
+	}
+	if _, err := az.HasPermission(context.Background(), "user-1", "policy", "view", models.ScopeGlobal, nil); err == nil {
+		t.Error("expected error when group bindings cannot be listed")
+	}
+}
@@ -11,10 +11,12 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/VuteTech/Bor/server/internal/pki"
 	"gopkg.in/yaml.v3"
 )
 
@@ -31,6 +33,18 @@ type Config struct {
 	Metrics  MetricsConfig
 	Audit    AuditConfig
 	UI       UIConfig
+
+	Compliance ComplianceConfig
+}
+
+// ComplianceConfig controls when repeated compliance failures flag a node
+// as unhealthy for a policy.
+type ComplianceConfig struct {
+	// UnhealthyThreshold is the number of consecutive failed reports,
+	// within UnhealthyWindow of the first, after which the node is flagged
+	// unhealthy for the policy. A successful report resets the count.
+	UnhealthyThreshold int           // BOR_COMPLIANCE_UNHEALTHY_THRESHOLD (default 3)
+	UnhealthyWindow    time.Duration // BOR_COMPLIANCE_UNHEALTHY_WINDOW (default 1h)
 }
 
 // AuditConfig holds configuration for audit event forwarding.
@@ -92,6 +106,19 @@ type DatabaseConfig struct {
 	Password string
 	Database string
 	SSLMode  string
+	// ConnectAttempts is how many times startup tries to reach the
+	// database before giving up. The first retry waits ConnectRetryInterval
+	// and each further one twice as long as the last, up to 30s.
+	ConnectAttempts      int           // BOR_DB_CONNECT_ATTEMPTS (default 10)
+	ConnectRetryInterval time.Duration // BOR_DB_CONNECT_RETRY_INTERVAL (default 1s)
+	// MaxOpenConns caps the connections the server opens to the database
+	// and should stay well below PostgreSQL's max_connections, summed over
+	// all server instances; 0 means unlimited. Up to MaxIdleConns are kept
+	// open between requests; 0 keeps none. Connections are closed once older
+	// than ConnMaxLifetime; 0 means never.
+	MaxOpenConns    int           // BOR_DB_MAX_OPEN_CONNS (default 25)
+	MaxIdleConns    int           // BOR_DB_MAX_IDLE_CONNS (default 10)
+	ConnMaxLifetime time.Duration // BOR_DB_CONN_MAX_LIFETIME (default 30m)
 }
 
 // ServerConfig holds server configuration.
@@ -100,6 +127,44 @@ type ServerConfig struct {
 	EnrollmentPort int      // BOR_ENROLLMENT_PORT – UI + enrollment gRPC listen port (default 8443)
 	PolicyPort     int      // BOR_POLICY_PORT – mTLS agent policy gRPC listen port (default 8444)
 	Hostnames      []string // BOR_HOSTNAMES – additional SANs for the auto-generated TLS cert
+	// BackpressureSubscribers is the number of connected agents above which
+	// the server asks agents to back off before reconnecting.
+	BackpressureSubscribers int // BOR_BACKPRESSURE_SUBSCRIBERS (default 0 = disabled)
+	// BackpressureSeconds is the base back-off hint sent to agents while
+	// overloaded; it grows with the overload, up to ten times this value.
+	BackpressureSeconds int // BOR_BACKPRESSURE_SECONDS (default 120)
+	// GRPCExemptMethods lists additional full gRPC method names on the
+	// enrollment port that may be called without a client certificate.
+	// Enroll and KerberosEnroll are always exempt.
+	GRPCExemptMethods []string // BOR_GRPC_EXEMPT_METHODS – comma-separated
+	// DrainTimeout bounds how long shutdown waits for agents to leave the
+	// policy stream after being told the server is going away; remaining
+	// streams are then closed forcibly.
+	DrainTimeout time.Duration // BOR_DRAIN_TIMEOUT (default 30s)
+	// DrainReconnectSeconds is the delay agents are asked to wait before
+	// reconnecting after a drain; agents add jitter to spread the fleet.
+	DrainReconnectSeconds int // BOR_DRAIN_RECONNECT_SECONDS (default 10)
+	// KeepaliveInterval is how long an agent connection may be idle before
+	// the server pings it; a connection whose ping is not answered within
+	// KeepaliveTimeout is closed, ending its policy stream. 0 disables the
+	// pings.
+	KeepaliveInterval time.Duration // BOR_KEEPALIVE_INTERVAL (default 30s)
+	KeepaliveTimeout  time.Duration // BOR_KEEPALIVE_TIMEOUT (default 10s)
+	// HeartbeatFlushInterval is how often buffered heartbeat last_seen
+	// updates are written to the database. Heartbeats with changed facts
+	// are always written at once. 0 writes every heartbeat immediately.
+	HeartbeatFlushInterval time.Duration // BOR_HEARTBEAT_FLUSH_INTERVAL (default 10s)
+	// NodeDegradedAfter and NodeOfflineAfter are how long after its last
+	// heartbeat a node is marked degraded and offline. Agents send a
+	// heartbeat every minute by default. 0 disables the status reaper.
+	NodeDegradedAfter time.Duration // BOR_NODE_DEGRADED_AFTER (default 2m)
+	NodeOfflineAfter  time.Duration // BOR_NODE_OFFLINE_AFTER (default 4m)
+	// MinAgentVersion is the oldest agent version the server accepts, e.g.
+	// "1.4.0". Older agents are refused on heartbeat and policy subscribe.
+	MinAgentVersion string // BOR_MIN_AGENT_VERSION (default: accept all)
+	// PolicyLogSize is the number of policy events kept for delta sync.
+	// Agents that reconnect further behind get a full snapshot instead.
+	PolicyLogSize int // BOR_POLICY_LOG_SIZE (default 1000)
 }
 
 // EnrollmentAddr returns the host:port for the UI + enrollment server.
@@ -122,6 +187,53 @@ type SecurityConfig struct {
 	TLSKeyFile      string
 	AdminToken      string // BOR_ADMIN_TOKEN – static admin token for gRPC enrollment calls
 	AdminPassword   string // BOR_ADMIN_PASSWORD – initial admin password (used once on first startup when no users exist)
+
+	// JWTAlgorithm selects how tokens are signed: HS256 with JWTSecret or
+	// RS256 with JWTPrivateKeyFile.
+	JWTAlgorithm string // BOR_JWT_ALGORITHM – HS256 (default) or RS256
+	// JWTPreviousSecrets are earlier HS256 secrets still accepted when
+	// verifying tokens, so the secret can be rotated without logging users out.
+	JWTPreviousSecrets []string // BOR_JWT_PREVIOUS_SECRETS – comma-separated
+	JWTPrivateKeyFile  string   // BOR_JWT_PRIVATE_KEY_FILE – PEM RSA private key for RS256
+	// JWTPreviousPublicKeyFiles are the PEM public keys of earlier RS256 key
+	// pairs still accepted when verifying tokens.
+	JWTPreviousPublicKeyFiles []string // BOR_JWT_PREVIOUS_PUBLIC_KEY_FILES – comma-separated
+	// JWTIssuer and JWTAudience are set as the iss and aud claims of issued
+	// tokens, and tokens without them are rejected. Empty values are
+	// neither set nor checked.
+	JWTIssuer   string // BOR_JWT_ISSUER
+	JWTAudience string // BOR_JWT_AUDIENCE
+
+	// DataEncryptionKey derives the keys that encrypt secrets at rest, such
+	// as TOTP secrets. It defaults to an explicitly set JWTSecret, so rotating
+	// that secret needs this set to the old value first. Empty means neither
+	// is set; the server then keeps a generated key in the CA autogen dir.
+	DataEncryptionKey string // BOR_DATA_ENCRYPTION_KEY (or legacy BOR_MFA_SECRET)
+
+	// ClientCertCAFile enables REST API authentication with TLS client
+	// certificates issued by this CA. It must not be the agent CA.
+	ClientCertCAFile string // BOR_CLIENT_CERT_CA_FILE – PEM CA bundle; empty disables
+	// ClientCertUsers maps a client certificate CN to the username of the
+	// service-account user it authenticates as.
+	ClientCertUsers map[string]string // BOR_CLIENT_CERT_USERS – comma-separated cn=username pairs
+
+	// LoginMaxAttempts is the number of login attempts accepted per
+	// username and per client IP within LoginAttemptWindow.
+	LoginMaxAttempts   int           // BOR_LOGIN_MAX_ATTEMPTS (default 10; 0 disables)
+	LoginAttemptWindow time.Duration // BOR_LOGIN_ATTEMPT_WINDOW (default 1m)
+	// LockoutThreshold is the number of consecutive failed logins after
+	// which an account, local or LDAP, is locked for LockoutDuration.
+	LockoutThreshold int           // BOR_LOCKOUT_THRESHOLD (default 5; 0 disables)
+	LockoutDuration  time.Duration // BOR_LOCKOUT_DURATION (default 15m)
+
+	// ContentSecurityPolicy replaces the Content-Security-Policy header sent
+	// with web UI and REST API responses, for deployments that need to
+	// relax it (e.g. to load assets from a CDN). Empty keeps the default.
+	ContentSecurityPolicy string // BOR_CONTENT_SECURITY_POLICY
+
+	// jwtSecretGenerated is set when JWTSecret was generated because none
+	// is configured.
+	jwtSecretGenerated bool
 }
 
 // TLSConfig holds UI HTTPS TLS configuration.
@@ -129,6 +241,8 @@ type TLSConfig struct {
 	CertFile   string // BOR_TLS_CERT_FILE – path to TLS certificate
 	KeyFile    string // BOR_TLS_KEY_FILE  – path to TLS private key
 	AutogenDir string // BOR_TLS_AUTOGEN_DIR – dir for auto-generated self-signed cert
+	// CertValidityDays is the lifetime of the auto-generated UI certificate.
+	CertValidityDays int // BOR_TLS_CERT_VALIDITY_DAYS (default 365)
 }
 
 // PKCS11Config holds PKCS#11 HSM configuration for the CA private key.
@@ -152,6 +266,15 @@ type CAConfig struct {
 	KeyFile    string       // BOR_CA_KEY_FILE    – path to CA private key (unused when PKCS11 is set)
 	AutogenDir string       // BOR_CA_AUTOGEN_DIR – dir for auto-generated CA
 	PKCS11     PKCS11Config // optional: load CA key from PKCS#11 HSM instead of a file
+	// ValidityDays is the lifetime of an auto-generated CA certificate.
+	ValidityDays int // BOR_CA_VALIDITY_DAYS (default 3650)
+	// AgentCertValidityDays is the lifetime of agent certificates issued at
+	// enrollment and renewal. Agents renew once a third of it remains.
+	AgentCertValidityDays int // BOR_AGENT_CERT_VALIDITY_DAYS (default 90)
+	// KeyAlgorithm is the key type of auto-generated CA and UI keys:
+	// rsa2048, rsa4096, ecdsa-p256 or ecdsa-p384. Empty keeps the ECDSA
+	// P-384 CA / P-256 UI default. Existing keys are never regenerated.
+	KeyAlgorithm pki.KeyAlgorithm // BOR_CA_KEY_ALGORITHM
 }
 
 // LDAPConfig holds LDAP connection configuration.
@@ -202,7 +325,8 @@ type LDAPConfig struct {
 	AttrMemberOf string
 	// PageSize controls LDAP result paging (RFC 2696).  0 disables paging.
 	PageSize int
-	// GroupRoleMap maps LDAP group CNs to Bor role names.
+	// GroupRoleMap maps LDAP groups to Bor role names. Keys are group CNs
+	// or, in the ldap.group_role_map YAML map only, full group DNs.
 	// Set via BOR_LDAP_GROUP_ROLE_MAP="Domain Admins=Super Admin,IT Staff=Org Admin"
 	// or the ldap.group_role_map YAML map.
 	GroupRoleMap map[string]string
@@ -236,6 +360,26 @@ type fileConfig struct {
 		EnrollmentPort int      `yaml:"enrollment_port"`
 		PolicyPort     int      `yaml:"policy_port"`
 		Hostnames      []string `yaml:"hostnames"`
+
+		BackpressureSubscribers int `yaml:"backpressure_subscribers"`
+		BackpressureSeconds     int `yaml:"backpressure_seconds"`
+
+		GRPCExemptMethods []string `yaml:"grpc_exempt_methods"`
+
+		DrainTimeout          string `yaml:"drain_timeout"`
+		DrainReconnectSeconds int    `yaml:"drain_reconnect_seconds"`
+
+		KeepaliveInterval string `yaml:"keepalive_interval"`
+		KeepaliveTimeout  string `yaml:"keepalive_timeout"`
+
+		HeartbeatFlushInterval string `yaml:"heartbeat_flush_interval"`
+
+		NodeDegradedAfter string `yaml:"node_degraded_after"`
+		NodeOfflineAfter  string `yaml:"node_offline_after"`
+
+		MinAgentVersion string `yaml:"min_agent_version"`
+
+		PolicyLogSize int `yaml:"policy_log_size"`
 	} `yaml:"server"`
 	Database struct {
 		Host     string `yaml:"host"`
@@ -244,6 +388,12 @@ type fileConfig struct {
 		Password string `yaml:"password"`
 		Name     string `yaml:"name"`
 		SSLMode  string `yaml:"sslmode"`
+
+		ConnectAttempts      int    `yaml:"connect_attempts"`
+		ConnectRetryInterval string `yaml:"connect_retry_interval"`
+		MaxOpenConns         int    `yaml:"max_open_conns"`
+		MaxIdleConns         int    `yaml:"max_idle_conns"`
+		ConnMaxLifetime      string `yaml:"conn_max_lifetime"`
 	} `yaml:"database"`
 	Security struct {
 		JWTSecret       string `yaml:"jwt_secret"`
@@ -251,17 +401,39 @@ type fileConfig struct {
 		RefreshLifetime string `yaml:"refresh_lifetime"`
 		AdminToken      string `yaml:"admin_token"`
 		AdminPassword   string `yaml:"admin_password"`
+
+		JWTAlgorithm              string   `yaml:"jwt_algorithm"`
+		JWTPreviousSecrets        []string `yaml:"jwt_previous_secrets"`
+		JWTPrivateKeyFile         string   `yaml:"jwt_private_key_file"`
+		JWTPreviousPublicKeyFiles []string `yaml:"jwt_previous_public_key_files"`
+		JWTIssuer                 string   `yaml:"jwt_issuer"`
+		JWTAudience               string   `yaml:"jwt_audience"`
+		DataEncryptionKey         string   `yaml:"data_encryption_key"`
+
+		ClientCertCAFile string            `yaml:"client_cert_ca_file"`
+		ClientCertUsers  map[string]string `yaml:"client_cert_users"`
+
+		LoginMaxAttempts   int    `yaml:"login_max_attempts"`
+		LoginAttemptWindow string `yaml:"login_attempt_window"`
+		LockoutThreshold   int    `yaml:"lockout_threshold"`
+		LockoutDuration    string `yaml:"lockout_duration"`
+
+		ContentSecurityPolicy string `yaml:"content_security_policy"`
 	} `yaml:"security"`
 	TLS struct {
-		CertFile   string `yaml:"cert_file"`
-		KeyFile    string `yaml:"key_file"`
-		AutogenDir string `yaml:"autogen_dir"`
+		CertFile         string `yaml:"cert_file"`
+		KeyFile          string `yaml:"key_file"`
+		AutogenDir       string `yaml:"autogen_dir"`
+		CertValidityDays int    `yaml:"cert_validity_days"`
 	} `yaml:"tls"`
 	CA struct {
-		CertFile   string `yaml:"cert_file"`
-		KeyFile    string `yaml:"key_file"`
-		AutogenDir string `yaml:"autogen_dir"`
-		PKCS11     struct {
+		CertFile              string `yaml:"cert_file"`
+		KeyFile               string `yaml:"key_file"`
+		AutogenDir            string `yaml:"autogen_dir"`
+		ValidityDays          int    `yaml:"validity_days"`
+		AgentCertValidityDays int    `yaml:"agent_cert_validity_days"`
+		KeyAlgorithm          string `yaml:"key_algorithm"`
+		PKCS11                struct {
 			Lib        string `yaml:"lib"`
 			TokenLabel string `yaml:"token_label"`
 			KeyLabel   string `yaml:"key_label"`
@@ -312,6 +484,10 @@ type fileConfig struct {
 	UI struct {
 		PrivacyPolicyURL string `yaml:"privacy_policy_url"`
 	} `yaml:"ui"`
+	Compliance struct {
+		UnhealthyThreshold int    `yaml:"unhealthy_threshold"`
+		UnhealthyWindow    string `yaml:"unhealthy_window"`
+	} `yaml:"compliance"`
 	Audit struct {
 		RetentionDays int `yaml:"retention_days"`
 		Syslog        struct {
@@ -345,6 +521,35 @@ func Load() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid DB_PORT: %w", err)
 	}
+	dbConnectAttempts, err := strconv.Atoi(getEnv("BOR_DB_CONNECT_ATTEMPTS", strconv.Itoa(fc.Database.ConnectAttempts)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_DB_CONNECT_ATTEMPTS: %w", err)
+	}
+	dbConnectRetryInterval, err := time.ParseDuration(getEnv("BOR_DB_CONNECT_RETRY_INTERVAL", fc.Database.ConnectRetryInterval))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_DB_CONNECT_RETRY_INTERVAL: %w", err)
+	}
+	if dbConnectAttempts < 1 || dbConnectRetryInterval < 0 {
+		return nil, fmt.Errorf("database connect_attempts must be at least 1 and connect_retry_interval must not be negative")
+	}
+	dbMaxOpenConns, err := strconv.Atoi(getEnv("BOR_DB_MAX_OPEN_CONNS", strconv.Itoa(fc.Database.MaxOpenConns)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_DB_MAX_OPEN_CONNS: %w", err)
+	}
+	dbMaxIdleConns, err := strconv.Atoi(getEnv("BOR_DB_MAX_IDLE_CONNS", strconv.Itoa(fc.Database.MaxIdleConns)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_DB_MAX_IDLE_CONNS: %w", err)
+	}
+	dbConnMaxLifetime, err := time.ParseDuration(getEnv("BOR_DB_CONN_MAX_LIFETIME", fc.Database.ConnMaxLifetime))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_DB_CONN_MAX_LIFETIME: %w", err)
+	}
+	if dbMaxOpenConns < 0 || dbMaxIdleConns < 0 || dbConnMaxLifetime < 0 {
+		return nil, fmt.Errorf("database max_open_conns, max_idle_conns and conn_max_lifetime must not be negative")
+	}
+	if dbMaxOpenConns > 0 && dbMaxIdleConns > dbMaxOpenConns {
+		return nil, fmt.Errorf("database max_idle_conns must not exceed max_open_conns")
+	}
 
 	// ─── Server ports ──────────────────────────────────────────────────────
 	enrollPortStr := getEnv("BOR_ENROLLMENT_PORT", strconv.Itoa(fc.Server.EnrollmentPort))
@@ -358,6 +563,79 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid BOR_POLICY_PORT: %w", err)
 	}
 
+	// ─── Agent backpressure ────────────────────────────────────────────────
+	bpSubscribers, err := strconv.Atoi(getEnv("BOR_BACKPRESSURE_SUBSCRIBERS", strconv.Itoa(fc.Server.BackpressureSubscribers)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_BACKPRESSURE_SUBSCRIBERS: %w", err)
+	}
+	bpSeconds, err := strconv.Atoi(getEnv("BOR_BACKPRESSURE_SECONDS", strconv.Itoa(fc.Server.BackpressureSeconds)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_BACKPRESSURE_SECONDS: %w", err)
+	}
+	if bpSubscribers < 0 || bpSeconds < 1 {
+		return nil, fmt.Errorf("backpressure_subscribers must not be negative and backpressure_seconds must be at least 1")
+	}
+
+	// ─── Shutdown draining ─────────────────────────────────────────────────
+	drainTimeout, err := time.ParseDuration(getEnv("BOR_DRAIN_TIMEOUT", fc.Server.DrainTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_DRAIN_TIMEOUT: %w", err)
+	}
+	drainReconnect, err := strconv.Atoi(getEnv("BOR_DRAIN_RECONNECT_SECONDS", strconv.Itoa(fc.Server.DrainReconnectSeconds)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_DRAIN_RECONNECT_SECONDS: %w", err)
+	}
+	if drainTimeout < 0 || drainReconnect < 0 {
+		return nil, fmt.Errorf("drain_timeout and drain_reconnect_seconds must not be negative")
+	}
+
+	// ─── Agent connection keepalive ────────────────────────────────────────
+	keepaliveInterval, err := time.ParseDuration(getEnv("BOR_KEEPALIVE_INTERVAL", fc.Server.KeepaliveInterval))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_KEEPALIVE_INTERVAL: %w", err)
+	}
+	keepaliveTimeout, err := time.ParseDuration(getEnv("BOR_KEEPALIVE_TIMEOUT", fc.Server.KeepaliveTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_KEEPALIVE_TIMEOUT: %w", err)
+	}
+	if keepaliveInterval < 0 || keepaliveTimeout <= 0 {
+		return nil, fmt.Errorf("keepalive_interval must not be negative and keepalive_timeout must be positive")
+	}
+
+	// ─── Heartbeat write coalescing ────────────────────────────────────────
+	heartbeatFlush, err := time.ParseDuration(getEnv("BOR_HEARTBEAT_FLUSH_INTERVAL", fc.Server.HeartbeatFlushInterval))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_HEARTBEAT_FLUSH_INTERVAL: %w", err)
+	}
+	if heartbeatFlush < 0 {
+		return nil, fmt.Errorf("heartbeat_flush_interval must not be negative")
+	}
+
+	// ─── Node status reaper ────────────────────────────────────────────────
+	nodeDegradedAfter, err := time.ParseDuration(getEnv("BOR_NODE_DEGRADED_AFTER", fc.Server.NodeDegradedAfter))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_NODE_DEGRADED_AFTER: %w", err)
+	}
+	nodeOfflineAfter, err := time.ParseDuration(getEnv("BOR_NODE_OFFLINE_AFTER", fc.Server.NodeOfflineAfter))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_NODE_OFFLINE_AFTER: %w", err)
+	}
+	if nodeDegradedAfter < 0 || nodeOfflineAfter < 0 {
+		return nil, fmt.Errorf("node_degraded_after and node_offline_after must not be negative")
+	}
+	if nodeDegradedAfter > 0 && nodeOfflineAfter <= nodeDegradedAfter {
+		return nil, fmt.Errorf("node_offline_after must be greater than node_degraded_after")
+	}
+
+	// ─── Policy event log ──────────────────────────────────────────────────
+	policyLogSize, err := strconv.Atoi(getEnv("BOR_POLICY_LOG_SIZE", strconv.Itoa(fc.Server.PolicyLogSize)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_POLICY_LOG_SIZE: %w", err)
+	}
+	if policyLogSize < 1 {
+		return nil, fmt.Errorf("policy_log_size must be at least 1")
+	}
+
 	// ─── LDAP ──────────────────────────────────────────────────────────────
 	ldapEnabled := getEnvBool("LDAP_ENABLED", fc.LDAP.Enabled)
 	ldapPortStr := getEnv("LDAP_PORT", strconv.Itoa(fc.LDAP.Port))
@@ -393,6 +671,39 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("both BOR_CA_CERT_FILE and BOR_CA_KEY_FILE must be set, or neither")
 	}
 
+	// ─── Certificate validity ──────────────────────────────────────────────
+	tlsValidityStr := getEnv("BOR_TLS_CERT_VALIDITY_DAYS", strconv.Itoa(fc.TLS.CertValidityDays))
+	tlsValidityDays, err := strconv.Atoi(tlsValidityStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_TLS_CERT_VALIDITY_DAYS: %w", err)
+	}
+	caValidityStr := getEnv("BOR_CA_VALIDITY_DAYS", strconv.Itoa(fc.CA.ValidityDays))
+	caValidityDays, err := strconv.Atoi(caValidityStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_CA_VALIDITY_DAYS: %w", err)
+	}
+	agentValidityStr := getEnv("BOR_AGENT_CERT_VALIDITY_DAYS", strconv.Itoa(fc.CA.AgentCertValidityDays))
+	agentValidityDays, err := strconv.Atoi(agentValidityStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_AGENT_CERT_VALIDITY_DAYS: %w", err)
+	}
+	if tlsValidityDays < 1 || caValidityDays < 1 || agentValidityDays < 1 {
+		return nil, fmt.Errorf("certificate validity periods must be at least 1 day")
+	}
+	if agentValidityDays >= caValidityDays {
+		return nil, fmt.Errorf("BOR_AGENT_CERT_VALIDITY_DAYS (%d) must be shorter than BOR_CA_VALIDITY_DAYS (%d)",
+			agentValidityDays, caValidityDays)
+	}
+	if tlsValidityDays > caValidityDays {
+		return nil, fmt.Errorf("BOR_TLS_CERT_VALIDITY_DAYS (%d) must not exceed BOR_CA_VALIDITY_DAYS (%d)",
+			tlsValidityDays, caValidityDays)
+	}
+
+	caKeyAlgorithm, err := pki.ParseKeyAlgorithm(strings.ToLower(getEnv("BOR_CA_KEY_ALGORITHM", fc.CA.KeyAlgorithm)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_CA_KEY_ALGORITHM: %w", err)
+	}
+
 	// ─── CA PKCS#11 (optional HSM) ─────────────────────────────────────────
 	pkcs11Lib := getEnv("BOR_CA_PKCS11_LIB", fc.CA.PKCS11.Lib)
 	pkcs11TokenLabel := getEnv("BOR_CA_PKCS11_TOKEN_LABEL", fc.CA.PKCS11.TokenLabel)
@@ -407,6 +718,12 @@ func Load() (*Config, error) {
 		hostnames = splitComma(envHostnames)
 	}
 
+	// Same override semantics for the gRPC exempt method list.
+	grpcExemptMethods := fc.Server.GRPCExemptMethods
+	if envExempt := os.Getenv("BOR_GRPC_EXEMPT_METHODS"); envExempt != "" {
+		grpcExemptMethods = splitComma(envExempt)
+	}
+
 	// ─── WebAuthn ──────────────────────────────────────────────────────────
 	webAuthnRPID := getEnv("BOR_WEBAUTHN_RPID", fc.WebAuthn.RPID)
 	webAuthnOrigins := fc.WebAuthn.Origins
@@ -452,12 +769,94 @@ func Load() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid BOR_JWT_LIFETIME: %w", err)
 	}
+	jwtAlgorithm := strings.ToUpper(getEnv("BOR_JWT_ALGORITHM", fc.Security.JWTAlgorithm))
+	jwtPrivateKeyFile := getEnv("BOR_JWT_PRIVATE_KEY_FILE", fc.Security.JWTPrivateKeyFile)
+	switch jwtAlgorithm {
+	case "HS256":
+	case "RS256":
+		if jwtPrivateKeyFile == "" {
+			return nil, fmt.Errorf("BOR_JWT_ALGORITHM=RS256 requires BOR_JWT_PRIVATE_KEY_FILE")
+		}
+	default:
+		return nil, fmt.Errorf("invalid BOR_JWT_ALGORITHM %q: must be HS256 or RS256", jwtAlgorithm)
+	}
+	jwtSecret := getEnv("JWT_SECRET", fc.Security.JWTSecret)
+	resolvedJWTSecret, jwtSecretGenerated := resolveJWTSecret(jwtSecret)
+	dataEncryptionKey := getEnv("BOR_DATA_ENCRYPTION_KEY", getEnv("BOR_MFA_SECRET", fc.Security.DataEncryptionKey))
+	if dataEncryptionKey == "" && jwtSecret != defaultJWTSecret {
+		dataEncryptionKey = jwtSecret
+	}
+	jwtPreviousSecrets := fc.Security.JWTPreviousSecrets
+	if env := os.Getenv("BOR_JWT_PREVIOUS_SECRETS"); env != "" {
+		jwtPreviousSecrets = splitComma(env)
+	}
+	jwtPreviousPublicKeyFiles := fc.Security.JWTPreviousPublicKeyFiles
+	if env := os.Getenv("BOR_JWT_PREVIOUS_PUBLIC_KEY_FILES"); env != "" {
+		jwtPreviousPublicKeyFiles = splitComma(env)
+	}
+	clientCertCAFile := getEnv("BOR_CLIENT_CERT_CA_FILE", fc.Security.ClientCertCAFile)
+	clientCertUsers := fc.Security.ClientCertUsers
+	if env := os.Getenv("BOR_CLIENT_CERT_USERS"); env != "" {
+		clientCertUsers = parseGroupRoleMap(env)
+	}
+	if clientCertCAFile != "" && len(clientCertUsers) == 0 {
+		return nil, fmt.Errorf("BOR_CLIENT_CERT_CA_FILE requires at least one BOR_CLIENT_CERT_USERS mapping")
+	}
+	if clientCertCAFile == "" && len(clientCertUsers) > 0 {
+		return nil, fmt.Errorf("BOR_CLIENT_CERT_USERS requires BOR_CLIENT_CERT_CA_FILE")
+	}
 	refreshLifetimeStr := getEnv("BOR_REFRESH_LIFETIME", fc.Security.RefreshLifetime)
 	refreshLifetime, err := time.ParseDuration(refreshLifetimeStr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid BOR_REFRESH_LIFETIME: %w", err)
 	}
 
+	// ─── Login throttling and account lockout ──────────────────────────────
+	loginMaxAttempts, err := strconv.Atoi(getEnv("BOR_LOGIN_MAX_ATTEMPTS", strconv.Itoa(fc.Security.LoginMaxAttempts)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_LOGIN_MAX_ATTEMPTS: %w", err)
+	}
+	loginAttemptWindow, err := time.ParseDuration(getEnv("BOR_LOGIN_ATTEMPT_WINDOW", fc.Security.LoginAttemptWindow))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_LOGIN_ATTEMPT_WINDOW: %w", err)
+	}
+	if loginMaxAttempts < 0 {
+		return nil, fmt.Errorf("login_max_attempts must not be negative")
+	}
+	if loginMaxAttempts > 0 && loginAttemptWindow <= 0 {
+		return nil, fmt.Errorf("login_attempt_window must be positive when login_max_attempts is set")
+	}
+	lockoutThreshold, err := strconv.Atoi(getEnv("BOR_LOCKOUT_THRESHOLD", strconv.Itoa(fc.Security.LockoutThreshold)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_LOCKOUT_THRESHOLD: %w", err)
+	}
+	lockoutDuration, err := time.ParseDuration(getEnv("BOR_LOCKOUT_DURATION", fc.Security.LockoutDuration))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_LOCKOUT_DURATION: %w", err)
+	}
+	if lockoutThreshold < 0 {
+		return nil, fmt.Errorf("lockout_threshold must not be negative")
+	}
+	if lockoutThreshold > 0 && lockoutDuration <= 0 {
+		return nil, fmt.Errorf("lockout_duration must be positive when lockout_threshold is set")
+	}
+
+	// ─── Compliance health ─────────────────────────────────────────────────
+	unhealthyThreshold, err := strconv.Atoi(getEnv("BOR_COMPLIANCE_UNHEALTHY_THRESHOLD", strconv.Itoa(fc.Compliance.UnhealthyThreshold)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_COMPLIANCE_UNHEALTHY_THRESHOLD: %w", err)
+	}
+	unhealthyWindow, err := time.ParseDuration(getEnv("BOR_COMPLIANCE_UNHEALTHY_WINDOW", fc.Compliance.UnhealthyWindow))
+	if err != nil {
+		return nil, fmt.Errorf("invalid BOR_COMPLIANCE_UNHEALTHY_WINDOW: %w", err)
+	}
+	if unhealthyThreshold < 1 {
+		return nil, fmt.Errorf("compliance unhealthy_threshold must be at least 1")
+	}
+	if unhealthyThreshold > 1 && unhealthyWindow <= 0 {
+		return nil, fmt.Errorf("compliance unhealthy_window must be positive when unhealthy_threshold is greater than 1")
+	}
+
 	return &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", fc.Database.Host),
@@ -466,15 +865,34 @@ func Load() (*Config, error) {
 			Password: getEnv("DB_PASSWORD", fc.Database.Password),
 			Database: getEnv("DB_NAME", fc.Database.Name),
 			SSLMode:  getEnv("DB_SSLMODE", fc.Database.SSLMode),
+
+			ConnectAttempts:      dbConnectAttempts,
+			ConnectRetryInterval: dbConnectRetryInterval,
+			MaxOpenConns:         dbMaxOpenConns,
+			MaxIdleConns:         dbMaxIdleConns,
+			ConnMaxLifetime:      dbConnMaxLifetime,
 		},
 		Server: ServerConfig{
 			Address:        getEnv("BOR_ADDRESS", fc.Server.Address),
 			EnrollmentPort: enrollPort,
 			PolicyPort:     policyPort,
 			Hostnames:      hostnames,
+
+			BackpressureSubscribers: bpSubscribers,
+			BackpressureSeconds:     bpSeconds,
+			GRPCExemptMethods:       grpcExemptMethods,
+			DrainTimeout:            drainTimeout,
+			DrainReconnectSeconds:   drainReconnect,
+			KeepaliveInterval:       keepaliveInterval,
+			KeepaliveTimeout:        keepaliveTimeout,
+			HeartbeatFlushInterval:  heartbeatFlush,
+			NodeDegradedAfter:       nodeDegradedAfter,
+			NodeOfflineAfter:        nodeOfflineAfter,
+			MinAgentVersion:         getEnv("BOR_MIN_AGENT_VERSION", fc.Server.MinAgentVersion),
+			PolicyLogSize:           policyLogSize,
 		},
 		Security: SecurityConfig{
-			JWTSecret:       resolveJWTSecret(getEnv("JWT_SECRET", fc.Security.JWTSecret)),
+			JWTSecret:       resolvedJWTSecret,
 			JWTLifetime:     jwtLifetime,
 			RefreshLifetime: refreshLifetime,
 			TLSEnabled:      getEnvBool("TLS_ENABLED", false),
@@ -482,11 +900,31 @@ func Load() (*Config, error) {
 			TLSKeyFile:      getEnv("TLS_KEY_FILE", ""),
 			AdminToken:      getEnv("BOR_ADMIN_TOKEN", fc.Security.AdminToken),
 			AdminPassword:   getEnv("BOR_ADMIN_PASSWORD", fc.Security.AdminPassword),
+
+			JWTAlgorithm:              jwtAlgorithm,
+			JWTPreviousSecrets:        jwtPreviousSecrets,
+			JWTPrivateKeyFile:         jwtPrivateKeyFile,
+			JWTPreviousPublicKeyFiles: jwtPreviousPublicKeyFiles,
+			JWTIssuer:                 getEnv("BOR_JWT_ISSUER", fc.Security.JWTIssuer),
+			JWTAudience:               getEnv("BOR_JWT_AUDIENCE", fc.Security.JWTAudience),
+			DataEncryptionKey:         dataEncryptionKey,
+			ClientCertCAFile:          clientCertCAFile,
+			ClientCertUsers:           clientCertUsers,
+
+			LoginMaxAttempts:   loginMaxAttempts,
+			LoginAttemptWindow: loginAttemptWindow,
+			LockoutThreshold:   lockoutThreshold,
+			LockoutDuration:    lockoutDuration,
+
+			ContentSecurityPolicy: strings.TrimSpace(getEnv("BOR_CONTENT_SECURITY_POLICY", fc.Security.ContentSecurityPolicy)),
+
+			jwtSecretGenerated: jwtSecretGenerated,
 		},
 		TLS: TLSConfig{
-			CertFile:   tlsCertFile,
-			KeyFile:    tlsKeyFile,
-			AutogenDir: getEnv("BOR_TLS_AUTOGEN_DIR", fc.TLS.AutogenDir),
+			CertFile:         tlsCertFile,
+			KeyFile:          tlsKeyFile,
+			AutogenDir:       getEnv("BOR_TLS_AUTOGEN_DIR", fc.TLS.AutogenDir),
+			CertValidityDays: tlsValidityDays,
 		},
 		CA: CAConfig{
 			CertFile:   caCertFile,
@@ -498,6 +936,9 @@ func Load() (*Config, error) {
 				KeyLabel:   pkcs11KeyLabel,
 				PIN:        pkcs11PIN,
 			},
+			ValidityDays:          caValidityDays,
+			AgentCertValidityDays: agentValidityDays,
+			KeyAlgorithm:          caKeyAlgorithm,
 		},
 		LDAP: LDAPConfig{
 			Enabled:         ldapEnabled,
@@ -555,26 +996,95 @@ func Load() (*Config, error) {
 				TLSCAFile: syslogTLSCA,
 			},
 		},
+		Compliance: ComplianceConfig{
+			UnhealthyThreshold: unhealthyThreshold,
+			UnhealthyWindow:    unhealthyWindow,
+		},
 	}, nil
 }
 
+// CertOptions returns the options for the certificates and keys the
+// internal CA generates and issues.
+func (c *Config) CertOptions() pki.CertOptions {
+	const day = 24 * time.Hour
+	return pki.CertOptions{
+		CAValidity:         time.Duration(c.CA.ValidityDays) * day,
+		ServerCertValidity: time.Duration(c.TLS.CertValidityDays) * day,
+		AgentCertValidity:  time.Duration(c.CA.AgentCertValidityDays) * day,
+		KeyAlgorithm:       c.CA.KeyAlgorithm,
+	}
+}
+
+// RestartRequired returns the settings that differ between old and cur
+// but only take effect on a server restart, named "Section.Setting" (e.g.
+// "Database.Host"). Every setting outside the LDAP section, which is
+// applied on reload, is compared. A JWT secret generated because none is
+// configured differs on every load and is not reported.
+func RestartRequired(old, cur *Config) []string {
+	var changed []string
+	oldV, curV := reflect.ValueOf(*old), reflect.ValueOf(*cur)
+	for i := range oldV.NumField() {
+		section := oldV.Type().Field(i).Name
+		if section == "LDAP" {
+			continue
+		}
+		oldS, curS := oldV.Field(i), curV.Field(i)
+		for j := range oldS.NumField() {
+			field := oldS.Type().Field(j)
+			if !field.IsExported() {
+				continue
+			}
+			if section == "Security" && field.Name == "JWTSecret" &&
+				old.Security.jwtSecretGenerated && cur.Security.jwtSecretGenerated {
+				continue
+			}
+			if !reflect.DeepEqual(oldS.Field(j).Interface(), curS.Field(j).Interface()) {
+				changed = append(changed, section+"."+field.Name)
+			}
+		}
+	}
+	return changed
+}
+
 // defaultFileConfig returns a fileConfig pre-populated with built-in defaults.
 func defaultFileConfig() fileConfig {
 	var fc fileConfig
 	fc.Server.Address = ""
 	fc.Server.EnrollmentPort = 8443
 	fc.Server.PolicyPort = 8444
+	fc.Server.BackpressureSeconds = 120
+	fc.Server.DrainTimeout = "30s"
+	fc.Server.DrainReconnectSeconds = 10
+	fc.Server.KeepaliveInterval = "30s"
+	fc.Server.KeepaliveTimeout = "10s"
+	fc.Server.HeartbeatFlushInterval = "10s"
+	fc.Server.NodeDegradedAfter = "2m"
+	fc.Server.NodeOfflineAfter = "4m"
+	fc.Server.PolicyLogSize = 1000
 	fc.Database.Host = "localhost"
 	fc.Database.Port = 5432
 	fc.Database.User = "bor"
 	fc.Database.Password = "bor"
 	fc.Database.Name = "bor"
 	fc.Database.SSLMode = "require"
+	fc.Database.ConnectAttempts = 10
+	fc.Database.ConnectRetryInterval = "1s"
+	fc.Database.MaxOpenConns = 25
+	fc.Database.MaxIdleConns = 10
+	fc.Database.ConnMaxLifetime = "30m"
 	fc.Security.JWTSecret = defaultJWTSecret
 	fc.Security.JWTLifetime = "1h"
+	fc.Security.JWTAlgorithm = "HS256"
 	fc.Security.RefreshLifetime = "24h"
+	fc.Security.LoginMaxAttempts = 10
+	fc.Security.LoginAttemptWindow = "1m"
+	fc.Security.LockoutThreshold = 5
+	fc.Security.LockoutDuration = "15m"
 	fc.TLS.AutogenDir = "/var/lib/bor/pki/ui"
+	fc.TLS.CertValidityDays = 365
 	fc.CA.AutogenDir = "/var/lib/bor/pki/ca"
+	fc.CA.ValidityDays = 3650
+	fc.CA.AgentCertValidityDays = 90
 	fc.LDAP.Host = "localhost"
 	fc.LDAP.Port = 389
 	fc.LDAP.UserFilter = "(uid=%s)"
@@ -590,17 +1100,20 @@ func defaultFileConfig() fileConfig {
 	fc.Audit.Syslog.Addr = "localhost:514"
 	fc.Audit.Syslog.Format = "cef"
 	fc.Audit.Syslog.Facility = 16 // local0
+	fc.Compliance.UnhealthyThreshold = 3
+	fc.Compliance.UnhealthyWindow = "1h"
 	return fc
 }
 
 const defaultJWTSecret = "change-me-in-production"
 
 // resolveJWTSecret validates the JWT secret and auto-generates one if the
-// default placeholder is still in use. A generated secret does not survive
-// server restarts (all sessions are invalidated on restart).
-func resolveJWTSecret(secret string) string {
+// default placeholder is still in use, reporting whether it did. A
+// generated secret does not survive server restarts (all sessions are
+// invalidated on restart).
+func resolveJWTSecret(secret string) (resolved string, generated bool) {
 	if secret != defaultJWTSecret && len(secret) >= 32 {
-		return secret
+		return secret, false
 	}
 
 	if secret == defaultJWTSecret {
@@ -611,12 +1124,12 @@ func resolveJWTSecret(secret string) string {
 		}
 		generated := hex.EncodeToString(b)
 		log.Println("WARNING: JWT_SECRET not set — using auto-generated secret (sessions will not survive restarts)")
-		return generated
+		return generated, true
 	}
 
 	// Secret was set explicitly but is too short.
 	log.Fatalf("JWT_SECRET is too short (%d bytes); minimum 32 bytes required", len(secret))
-	return "" // unreachable
+	return "", false // unreachable
 }
 
 func getEnv(key, defaultValue string) string {
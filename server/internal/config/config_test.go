@@ -6,7 +6,9 @@ package config
 
 import (
 	"os"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestLoad_Defaults(t *testing.T) {
@@ -39,6 +41,218 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.CA.AutogenDir != "/var/lib/bor/pki/ca" {
 		t.Errorf("CA.AutogenDir = %q, want %q", cfg.CA.AutogenDir, "/var/lib/bor/pki/ca")
 	}
+	if cfg.Server.BackpressureSubscribers != 0 || cfg.Server.BackpressureSeconds != 120 {
+		t.Errorf("Server backpressure = %d/%ds, want 0/120s", cfg.Server.BackpressureSubscribers, cfg.Server.BackpressureSeconds)
+	}
+	if cfg.Compliance.UnhealthyThreshold != 3 || cfg.Compliance.UnhealthyWindow != time.Hour {
+		t.Errorf("Compliance unhealthy = %d/%v, want 3/1h", cfg.Compliance.UnhealthyThreshold, cfg.Compliance.UnhealthyWindow)
+	}
+	if cfg.Server.DrainTimeout != 30*time.Second || cfg.Server.DrainReconnectSeconds != 10 {
+		t.Errorf("Server drain = %v/%ds, want 30s/10s", cfg.Server.DrainTimeout, cfg.Server.DrainReconnectSeconds)
+	}
+	if cfg.Server.KeepaliveInterval != 30*time.Second || cfg.Server.KeepaliveTimeout != 10*time.Second {
+		t.Errorf("Server keepalive = %v/%v, want 30s/10s", cfg.Server.KeepaliveInterval, cfg.Server.KeepaliveTimeout)
+	}
+	if cfg.Server.HeartbeatFlushInterval != 10*time.Second {
+		t.Errorf("Server.HeartbeatFlushInterval = %v, want 10s", cfg.Server.HeartbeatFlushInterval)
+	}
+	if cfg.Server.NodeDegradedAfter != 2*time.Minute || cfg.Server.NodeOfflineAfter != 4*time.Minute {
+		t.Errorf("Server node status = %v/%v, want 2m/4m", cfg.Server.NodeDegradedAfter, cfg.Server.NodeOfflineAfter)
+	}
+	if cfg.Server.MinAgentVersion != "" {
+		t.Errorf("Server.MinAgentVersion = %q, want empty", cfg.Server.MinAgentVersion)
+	}
+	if cfg.Server.PolicyLogSize != 1000 {
+		t.Errorf("Server.PolicyLogSize = %d, want 1000", cfg.Server.PolicyLogSize)
+	}
+	if cfg.Security.LoginMaxAttempts != 10 || cfg.Security.LoginAttemptWindow != time.Minute {
+		t.Errorf("Security login attempts = %d/%v, want 10/1m", cfg.Security.LoginMaxAttempts, cfg.Security.LoginAttemptWindow)
+	}
+	if cfg.Security.LockoutThreshold != 5 || cfg.Security.LockoutDuration != 15*time.Minute {
+		t.Errorf("Security lockout = %d/%v, want 5/15m", cfg.Security.LockoutThreshold, cfg.Security.LockoutDuration)
+	}
+}
+
+func TestLoad_FailFast_NegativeDrainTimeout(t *testing.T) {
+	t.Setenv("BOR_DRAIN_TIMEOUT", "-1s")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject a negative drain timeout")
+	}
+}
+
+func TestLoad_FailFast_ZeroDBConnectAttempts(t *testing.T) {
+	t.Setenv("BOR_DB_CONNECT_ATTEMPTS", "0")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject zero database connect attempts")
+	}
+}
+
+func TestLoad_FailFast_IdleConnsAboveOpenConns(t *testing.T) {
+	t.Setenv("BOR_DB_MAX_OPEN_CONNS", "5")
+	t.Setenv("BOR_DB_MAX_IDLE_CONNS", "10")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject more idle than open database connections")
+	}
+}
+
+func TestLoad_FailFast_ZeroKeepaliveTimeout(t *testing.T) {
+	t.Setenv("BOR_KEEPALIVE_TIMEOUT", "0s")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject a zero keepalive timeout")
+	}
+}
+
+func TestLoad_FailFast_NegativeHeartbeatFlushInterval(t *testing.T) {
+	t.Setenv("BOR_HEARTBEAT_FLUSH_INTERVAL", "-10s")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject a negative heartbeat flush interval")
+	}
+}
+
+func TestLoad_FailFast_NodeOfflineBeforeDegraded(t *testing.T) {
+	t.Setenv("BOR_NODE_DEGRADED_AFTER", "5m")
+	t.Setenv("BOR_NODE_OFFLINE_AFTER", "5m")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should require node_offline_after to exceed node_degraded_after")
+	}
+}
+
+func TestLoad_FailFast_LockoutWithoutDuration(t *testing.T) {
+	t.Setenv("BOR_LOCKOUT_THRESHOLD", "3")
+	t.Setenv("BOR_LOCKOUT_DURATION", "0s")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should require a positive lockout duration")
+	}
+}
+
+func TestLoad_FailFast_PolicyLogSize(t *testing.T) {
+	t.Setenv("BOR_POLICY_LOG_SIZE", "0")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject a policy log size below 1")
+	}
+}
+
+func TestLoad_FailFast_UnhealthyThreshold(t *testing.T) {
+	t.Setenv("BOR_COMPLIANCE_UNHEALTHY_THRESHOLD", "0")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject an unhealthy threshold below 1")
+	}
+}
+
+func TestLoad_FailFast_UnhealthyWindowRequired(t *testing.T) {
+	t.Setenv("BOR_COMPLIANCE_UNHEALTHY_THRESHOLD", "5")
+	t.Setenv("BOR_COMPLIANCE_UNHEALTHY_WINDOW", "0s")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should require a window when the threshold is above 1")
+	}
+}
+
+func TestLoad_CertValidity(t *testing.T) {
+	t.Setenv("BOR_CA_VALIDITY_DAYS", "730")
+	t.Setenv("BOR_AGENT_CERT_VALIDITY_DAYS", "30")
+	t.Setenv("BOR_TLS_CERT_VALIDITY_DAYS", "90")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.CA.ValidityDays != 730 || cfg.CA.AgentCertValidityDays != 30 || cfg.TLS.CertValidityDays != 90 {
+		t.Errorf("validity = CA %d, agent %d, TLS %d; want 730, 30, 90",
+			cfg.CA.ValidityDays, cfg.CA.AgentCertValidityDays, cfg.TLS.CertValidityDays)
+	}
+}
+
+func TestLoad_FailFast_AgentCertOutlivesCA(t *testing.T) {
+	t.Setenv("BOR_CA_VALIDITY_DAYS", "90")
+	t.Setenv("BOR_AGENT_CERT_VALIDITY_DAYS", "90")
+	t.Setenv("BOR_TLS_CERT_VALIDITY_DAYS", "30")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should require agent certificates to be shorter-lived than the CA")
+	}
+}
+
+func TestLoad_FailFast_UnknownCAKeyAlgorithm(t *testing.T) {
+	t.Setenv("BOR_CA_KEY_ALGORITHM", "dsa1024")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject an unknown CA key algorithm")
+	}
+}
+
+func TestLoad_GRPCExemptMethodsFromEnv(t *testing.T) {
+	t.Setenv("BOR_GRPC_EXEMPT_METHODS", "/svc/Ping, /svc/SelfTest")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	got := cfg.Server.GRPCExemptMethods
+	if len(got) != 2 || got[0] != "/svc/Ping" || got[1] != "/svc/SelfTest" {
+		t.Errorf("GRPCExemptMethods = %v, want [/svc/Ping /svc/SelfTest]", got)
+	}
+}
+
+func TestLoad_FailFast_RS256WithoutPrivateKey(t *testing.T) {
+	t.Setenv("BOR_JWT_ALGORITHM", "RS256")
+	t.Setenv("BOR_JWT_PRIVATE_KEY_FILE", "")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail when RS256 is selected without a private key file")
+	}
+}
+
+func TestLoad_FailFast_UnknownJWTAlgorithm(t *testing.T) {
+	t.Setenv("BOR_JWT_ALGORITHM", "none")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should reject an unsupported JWT algorithm")
+	}
+}
+
+func TestLoad_ClientCertUsers(t *testing.T) {
+	t.Setenv("BOR_CLIENT_CERT_CA_FILE", "/etc/bor/api-clients-ca.crt")
+	t.Setenv("BOR_CLIENT_CERT_USERS", "ci-pipeline=svc-ci, backup = svc-backup")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := map[string]string{"ci-pipeline": "svc-ci", "backup": "svc-backup"}
+	if len(cfg.Security.ClientCertUsers) != len(want) {
+		t.Fatalf("ClientCertUsers = %v, want %v", cfg.Security.ClientCertUsers, want)
+	}
+	for cn, user := range want {
+		if cfg.Security.ClientCertUsers[cn] != user {
+			t.Errorf("ClientCertUsers[%q] = %q, want %q", cn, cfg.Security.ClientCertUsers[cn], user)
+		}
+	}
+}
+
+func TestLoad_FailFast_ClientCertCAWithoutUsers(t *testing.T) {
+	t.Setenv("BOR_CLIENT_CERT_CA_FILE", "/etc/bor/api-clients-ca.crt")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail when a client cert CA is set without user mappings")
+	}
+}
+
+func TestLoad_FailFast_ClientCertUsersWithoutCA(t *testing.T) {
+	t.Setenv("BOR_CLIENT_CERT_USERS", "ci-pipeline=svc-ci")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() should fail when client cert users are set without a CA")
+	}
 }
 
 func TestLoad_FailFast_TLSCertWithoutKey(t *testing.T) {
@@ -101,6 +315,60 @@ func TestLoad_CustomPort(t *testing.T) {
 	}
 }
 
+func TestLoad_JWTIssuerAudience(t *testing.T) {
+	t.Setenv("BOR_JWT_ISSUER", "https://bor.example.com")
+	t.Setenv("BOR_JWT_AUDIENCE", "bor-api")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Security.JWTIssuer != "https://bor.example.com" || cfg.Security.JWTAudience != "bor-api" {
+		t.Errorf("Security issuer/audience = %q/%q, want %q/%q",
+			cfg.Security.JWTIssuer, cfg.Security.JWTAudience, "https://bor.example.com", "bor-api")
+	}
+}
+
+func TestLoad_DataEncryptionKey(t *testing.T) {
+	jwtSecret := "0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"unset", map[string]string{}, ""},
+		{"jwt secret", map[string]string{"JWT_SECRET": jwtSecret}, jwtSecret},
+		{"legacy mfa secret", map[string]string{"JWT_SECRET": jwtSecret, "BOR_MFA_SECRET": "mfa"}, "mfa"},
+		{"dedicated", map[string]string{"JWT_SECRET": jwtSecret, "BOR_MFA_SECRET": "mfa", "BOR_DATA_ENCRYPTION_KEY": "data"}, "data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"JWT_SECRET", "BOR_MFA_SECRET", "BOR_DATA_ENCRYPTION_KEY"} {
+				t.Setenv(key, tt.env[key])
+			}
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Security.DataEncryptionKey != tt.want {
+				t.Errorf("Security.DataEncryptionKey = %q, want %q", cfg.Security.DataEncryptionKey, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_ContentSecurityPolicy(t *testing.T) {
+	t.Setenv("BOR_CONTENT_SECURITY_POLICY", " default-src 'self' cdn.example.com ")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := "default-src 'self' cdn.example.com"; cfg.Security.ContentSecurityPolicy != want {
+		t.Errorf("Security.ContentSecurityPolicy = %q, want %q", cfg.Security.ContentSecurityPolicy, want)
+	}
+}
+
 func TestLoad_AdminToken(t *testing.T) {
 	os.Setenv("BOR_ADMIN_TOKEN", "secret123")
 	defer os.Unsetenv("BOR_ADMIN_TOKEN")
@@ -113,3 +381,57 @@ func TestLoad_AdminToken(t *testing.T) {
 		t.Errorf("Security.AdminToken = %q, want %q", cfg.Security.AdminToken, "secret123")
 	}
 }
+
+func TestRestartRequired(t *testing.T) {
+	old, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	cur := *old
+	cur.LDAP.Host = "ldap.example.com"
+	if got := RestartRequired(old, &cur); len(got) != 0 {
+		t.Errorf("RestartRequired() after LDAP change = %v, want none", got)
+	}
+
+	cur.Database.Host = "db.example.com"
+	cur.Server.PolicyPort = 9444
+	cur.Server.HeartbeatFlushInterval = time.Minute
+	cur.Security.JWTLifetime = 2 * time.Hour
+	cur.Security.ClientCertUsers = map[string]string{"ci": "svc-ci"}
+	cur.TLS.CertFile = "/etc/bor/tls.crt"
+	cur.Audit.RetentionDays = 30
+	want := []string{
+		"Database.Host",
+		"Server.PolicyPort", "Server.HeartbeatFlushInterval",
+		"Security.JWTLifetime", "Security.ClientCertUsers",
+		"TLS.CertFile",
+		"Audit.RetentionDays",
+	}
+	if got := RestartRequired(old, &cur); !slices.Equal(got, want) {
+		t.Errorf("RestartRequired() = %v, want %v", got, want)
+	}
+}
+
+func TestRestartRequired_GeneratedJWTSecret(t *testing.T) {
+	t.Setenv("JWT_SECRET", "")
+	old, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	cur, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := RestartRequired(old, cur); len(got) != 0 {
+		t.Errorf("RestartRequired() with generated JWT secrets = %v, want none", got)
+	}
+
+	t.Setenv("JWT_SECRET", "0123456789abcdef0123456789abcdef")
+	if cur, err = Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := []string{"Security.JWTSecret", "Security.DataEncryptionKey"}
+	if got := RestartRequired(old, cur); !slices.Equal(got, want) {
+		t.Errorf("RestartRequired() after setting JWT_SECRET = %v, want %v", got, want)
+	}
+}
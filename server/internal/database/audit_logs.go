@@ -25,14 +25,14 @@ func NewAuditLogRepository(db *DB) *AuditLogRepository {
 
 // Create inserts a new audit log entry
 func (r *AuditLogRepository) Create(ctx context.Context, entry *models.AuditLog) error {
-	query := `INSERT INTO audit_logs (user_id, username, action, resource_type, resource_id, details, ip_address, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+	query := `INSERT INTO audit_logs (user_id, username, action, resource_type, resource_id, details, ip_address, created_at, correlation_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
 
 	entry.CreatedAt = time.Now()
 
 	err := r.db.QueryRowContext(ctx, query,
 		entry.UserID, entry.Username, entry.Action, entry.ResourceType,
-		entry.ResourceID, entry.Details, entry.IPAddress, entry.CreatedAt,
+		entry.ResourceID, entry.Details, entry.IPAddress, entry.CreatedAt, entry.CorrelationID,
 	).Scan(&entry.ID)
 	if err != nil {
 		return fmt.Errorf("failed to create audit log: %w", err)
@@ -41,10 +41,15 @@ func (r *AuditLogRepository) Create(ctx context.Context, entry *models.AuditLog)
 	return nil
 }
 
-// DeleteOlderThan removes audit log entries with a timestamp before the given cutoff.
-// It returns the number of deleted rows.
-func (r *AuditLogRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
-	result, err := r.db.ExecContext(ctx, "DELETE FROM audit_logs WHERE created_at < $1", cutoff)
+// DeleteOlderThan removes up to limit audit log entries with a timestamp
+// before the given cutoff, oldest first, so a large purge can run as many
+// short statements instead of locking the table. It returns the number of
+// deleted rows.
+func (r *AuditLogRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time, limit int) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM audit_logs WHERE id IN (
+			SELECT id FROM audit_logs WHERE created_at < $1 ORDER BY created_at LIMIT $2
+		)`, cutoff, limit)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete old audit logs: %w", err)
 	}
@@ -55,7 +60,8 @@ func (r *AuditLogRepository) DeleteOlderThan(ctx context.Context, cutoff time.Ti
 func (r *AuditLogRepository) List(ctx context.Context, req *models.AuditLogListRequest) ([]*models.AuditLog, error) {
 	where, args := buildAuditLogFilter(req)
 
-	query := fmt.Sprintf(`SELECT id, user_id, username, action, resource_type, resource_id, details, ip_address, created_at
+	query := fmt.Sprintf(`SELECT id, user_id, username, action, resource_type, resource_id, details, ip_address, created_at,
+			correlation_id
 		FROM audit_logs %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`,
 		where, len(args)+1, len(args)+2)
 
@@ -86,7 +92,7 @@ func (r *AuditLogRepository) List(ctx context.Context, req *models.AuditLogListR
 		if err := rows.Scan(
 			&entry.ID, &entry.UserID, &entry.Username, &entry.Action,
 			&entry.ResourceType, &entry.ResourceID, &entry.Details,
-			&entry.IPAddress, &entry.CreatedAt,
+			&entry.IPAddress, &entry.CreatedAt, &entry.CorrelationID,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan audit log: %w", err)
 		}
@@ -154,8 +160,28 @@ func buildAuditLogFilter(req *models.AuditLogListRequest) (clause string, args [
 		conditions = append(conditions, "action IN ("+strings.Join(placeholders, ", ")+")")
 	}
 	if req.Username != "" {
-		conditions = append(conditions, fmt.Sprintf("username ILIKE $%d", argIdx))
-		args = append(args, "%"+req.Username+"%")
+		conditions = append(conditions, fmt.Sprintf(`username ILIKE $%d ESCAPE '\'`, argIdx))
+		args = append(args, likeContains(req.Username))
+		argIdx++
+	}
+	if req.CorrelationID != "" {
+		conditions = append(conditions, fmt.Sprintf("correlation_id = $%d", argIdx))
+		args = append(args, req.CorrelationID)
+		argIdx++
+	}
+	if req.Query != "" {
+		conditions = append(conditions, fmt.Sprintf(`(details ILIKE $%[1]d ESCAPE '\' OR resource_id ILIKE $%[1]d ESCAPE '\')`, argIdx))
+		args = append(args, likeContains(req.Query))
+		argIdx++
+	}
+	if req.From != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argIdx))
+		args = append(args, *req.From)
+		argIdx++
+	}
+	if req.To != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", argIdx))
+		args = append(args, *req.To)
 	}
 
 	where := ""
@@ -165,3 +191,13 @@ func buildAuditLogFilter(req *models.AuditLogListRequest) (clause string, args [
 
 	return where, args
 }
+
+// likeEscaper escapes the LIKE metacharacters, with \ as the escape
+// character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// likeContains returns the LIKE pattern matching s anywhere in a value,
+// with any % or _ in s matched literally.
+func likeContains(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
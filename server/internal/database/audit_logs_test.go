@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package database
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func TestBuildAuditLogFilter(t *testing.T) {
+	where, args := buildAuditLogFilter(&models.AuditLogListRequest{})
+	if where != "" || len(args) != 0 {
+		t.Errorf("no filters: where = %q, args = %v", where, args)
+	}
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	where, args = buildAuditLogFilter(&models.AuditLogListRequest{
+		Actions:       []string{"update"},
+		CorrelationID: "req-1",
+		Query:         "firefox",
+		From:          &from,
+		To:            &to,
+	})
+	for _, want := range []string{
+		"action IN ($1)",
+		"correlation_id = $2",
+		`(details ILIKE $3 ESCAPE '\' OR resource_id ILIKE $3 ESCAPE '\')`,
+		"created_at >= $4",
+		"created_at < $5",
+	} {
+		if !strings.Contains(where, want) {
+			t.Errorf("where = %q, want it to contain %q", where, want)
+		}
+	}
+	if len(args) != 5 || args[2] != "%firefox%" || args[3] != from || args[4] != to {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestBuildAuditLogFilter_EscapesLikePatterns(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"policy_binding", `%policy\_binding%`},
+		{"100%", `%100\%%`},
+		{`C:\temp`, `%C:\\temp%`},
+	}
+	for _, tt := range tests {
+		_, args := buildAuditLogFilter(&models.AuditLogListRequest{Username: tt.query, Query: tt.query})
+		if len(args) != 2 || args[0] != tt.want || args[1] != tt.want {
+			t.Errorf("query %q: args = %v, want both %q", tt.query, args, tt.want)
+		}
+	}
+}
@@ -6,12 +6,14 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"embed"
 	"fmt"
 	"log"
 	"sort"
 	"strings"
+	"time"
 
 	_ "github.com/lib/pq" // register postgres driver
 )
@@ -32,8 +34,22 @@ type Config struct {
 	Password string
 	Database string
 	SSLMode  string
+	// ConnectAttempts is how many times New pings the database before
+	// giving up; values below 1 mean a single attempt.
+	ConnectAttempts int
+	// ConnectRetryInterval is the wait before the first retry; it doubles
+	// with each further retry, up to maxConnectRetryDelay.
+	ConnectRetryInterval time.Duration
+	// MaxOpenConns, MaxIdleConns and ConnMaxLifetime size the connection
+	// pool; see the matching sql.DB setters.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
 }
 
+// maxConnectRetryDelay caps the wait between connection attempts.
+const maxConnectRetryDelay = 30 * time.Second
+
 // buildDSN constructs a libpq connection string from cfg.
 // When Host starts with '/' it is treated as a Unix socket directory:
 // port is omitted (libpq ignores it for sockets) and sslmode defaults
@@ -56,20 +72,49 @@ func buildDSN(cfg *Config) string {
 	)
 }
 
-// New creates a new database connection
-func New(cfg *Config) (*DB, error) {
+// New creates a new database connection and waits for the database to
+// answer a ping, retrying with backoff as set by cfg so the server can start
+// before PostgreSQL is ready. It gives up when ctx is done.
+func New(ctx context.Context, cfg *Config) (*DB, error) {
 	connStr := buildDSN(cfg)
 
 	db, err := sql.Open("postgres", connStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
-
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	attempts := max(cfg.ConnectAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		err = db.PingContext(ctx)
+		if err == nil {
+			return &DB{db}, nil
+		}
+		if attempt >= attempts || ctx.Err() != nil {
+			break
+		}
+		delay := connectRetryDelay(cfg.ConnectRetryInterval, attempt)
+		log.Printf("Database not ready (attempt %d/%d): %v; retrying in %s", attempt, attempts, err, delay)
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
 	}
+	_ = db.Close()
+	return nil, fmt.Errorf("failed to ping database: %w", err)
+}
 
-	return &DB{db}, nil
+// connectRetryDelay returns the wait after the given failed connection
+// attempt: base after the first, doubling after each further one, capped
+// at maxConnectRetryDelay.
+func connectRetryDelay(base time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxConnectRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxConnectRetryDelay)
 }
 
 // RunMigrations executes all pending SQL migrations in order.
@@ -150,6 +195,12 @@ func (db *DB) RunMigrations() error {
 	return nil
 }
 
+// Check verifies that the database answers queries.
+func (db *DB) Check(ctx context.Context) error {
+	var one int
+	return db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+}
+
 // Close closes the database connection
 func (db *DB) Close() error {
 	return db.DB.Close()
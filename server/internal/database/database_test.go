@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMigrationFilesEmbedded(t *testing.T) {
@@ -79,3 +80,24 @@ func TestMigrationFilesContainExpected(t *testing.T) {
 		}
 	}
 }
+
+func TestConnectRetryDelay(t *testing.T) {
+	tests := []struct {
+		base    time.Duration
+		attempt int
+		want    time.Duration
+	}{
+		{time.Second, 1, time.Second},
+		{time.Second, 2, 2 * time.Second},
+		{time.Second, 4, 8 * time.Second},
+		{time.Second, 6, maxConnectRetryDelay},
+		{time.Second, 100, maxConnectRetryDelay},
+		{time.Minute, 1, maxConnectRetryDelay},
+		{0, 3, 0},
+	}
+	for _, tt := range tests {
+		if got := connectRetryDelay(tt.base, tt.attempt); got != tt.want {
+			t.Errorf("connectRetryDelay(%s, %d) = %s, want %s", tt.base, tt.attempt, got, tt.want)
+		}
+	}
+}
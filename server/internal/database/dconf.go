@@ -6,6 +6,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -122,11 +123,22 @@ func (r *DConfRepository) ListSchemasByNode(ctx context.Context, nodeID string)
 // UpsertComplianceResult inserts or updates a compliance result for a (node, policy) pair.
 // itemsJSON is a JSON array of per-item results (may be nil/empty for non-dconf policies).
 func (r *DConfRepository) UpsertComplianceResult(ctx context.Context, nodeID, policyID, statusStr, message string, itemsJSON []byte) error {
+	return upsertComplianceResult(ctx, r.db, nodeID, policyID, statusStr, message, itemsJSON)
+}
+
+// complianceQuerier is implemented by *DB and *sql.Tx, so compliance
+// results can be written on their own or as part of a batch.
+type complianceQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func upsertComplianceResult(ctx context.Context, q complianceQuerier, nodeID, policyID, statusStr, message string, itemsJSON []byte) error {
 	var items any
 	if len(itemsJSON) > 0 {
 		items = itemsJSON
 	}
-	_, err := r.db.ExecContext(ctx, `
+	_, err := q.ExecContext(ctx, `
 		INSERT INTO compliance_results (node_id, policy_id, status, message, items_json, reported_at)
 		VALUES ($1, $2, $3, $4, $5, NOW())
 		ON CONFLICT (node_id, policy_id) DO UPDATE
@@ -142,6 +154,134 @@ func (r *DConfRepository) UpsertComplianceResult(ctx context.Context, nodeID, po
 	return nil
 }
 
+// UpsertCheckResult records the result of a policy's compliance check command
+// for a (node, policy) pair without touching the deployment status.
+func (r *DConfRepository) UpsertCheckResult(ctx context.Context, nodeID, policyID, statusStr string, exitCode int, message string, checkedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO compliance_results (node_id, policy_id, check_status, check_exit_code, check_message, checked_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (node_id, policy_id) DO UPDATE
+		  SET check_status    = EXCLUDED.check_status,
+		      check_exit_code = EXCLUDED.check_exit_code,
+		      check_message   = EXCLUDED.check_message,
+		      checked_at      = EXCLUDED.checked_at`,
+		nodeID, policyID, statusStr, exitCode, nullableString(message), checkedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("dconf: upsert check result: %w", err)
+	}
+	return nil
+}
+
+// RecordComplianceHealth updates the failure streak of a (node, policy)
+// pair after a compliance report. A failed report extends the streak when
+// the node is already unhealthy or the streak started within window, and
+// otherwise starts a new one; any other report clears it. The node is
+// unhealthy for the policy once the streak reaches threshold. It returns
+// the unhealthy flag before and after the update.
+func (r *DConfRepository) RecordComplianceHealth(ctx context.Context, nodeID, policyID string, failed bool, threshold int, window time.Duration) (wasUnhealthy, unhealthy bool, err error) {
+	return recordComplianceHealth(ctx, r.db, nodeID, policyID, failed, threshold, window)
+}
+
+func recordComplianceHealth(ctx context.Context, q complianceQuerier, nodeID, policyID string, failed bool, threshold int, window time.Duration) (wasUnhealthy, unhealthy bool, err error) {
+	var row *sql.Row
+	if failed {
+		row = q.QueryRowContext(ctx, `
+			UPDATE compliance_results cr
+			SET consecutive_failures = s.n,
+			    failing_since        = s.since,
+			    unhealthy            = s.n >= $3
+			FROM (
+				SELECT id, unhealthy AS was,
+				       CASE WHEN unhealthy OR failing_since > NOW() - make_interval(secs => $4)
+				            THEN consecutive_failures + 1 ELSE 1 END AS n,
+				       CASE WHEN unhealthy OR failing_since > NOW() - make_interval(secs => $4)
+				            THEN failing_since ELSE NOW() END AS since
+				FROM compliance_results
+				WHERE node_id = $1 AND policy_id = $2
+			) s
+			WHERE cr.id = s.id
+			RETURNING s.was, cr.unhealthy`,
+			nodeID, policyID, threshold, window.Seconds(),
+		)
+	} else {
+		row = q.QueryRowContext(ctx, `
+			UPDATE compliance_results cr
+			SET consecutive_failures = 0, failing_since = NULL, unhealthy = FALSE
+			FROM (
+				SELECT id, unhealthy AS was
+				FROM compliance_results
+				WHERE node_id = $1 AND policy_id = $2
+			) s
+			WHERE cr.id = s.id
+			RETURNING s.was, cr.unhealthy`,
+			nodeID, policyID,
+		)
+	}
+	if err := row.Scan(&wasUnhealthy, &unhealthy); err != nil {
+		if err == sql.ErrNoRows {
+			return false, false, nil
+		}
+		return false, false, fmt.Errorf("dconf: record compliance health: %w", err)
+	}
+	return wasUnhealthy, unhealthy, nil
+}
+
+// ComplianceReport is one compliance result of a node, see
+// SaveComplianceReports.
+type ComplianceReport struct {
+	PolicyID  string
+	Status    string
+	Message   string
+	ItemsJSON []byte
+	// Failed extends the failure streak, see RecordComplianceHealth.
+	Failed bool
+	// AppliedFiles replace the stored hashes for the policy; empty leaves
+	// them in place.
+	AppliedFiles []*pb.AppliedFile
+}
+
+// ComplianceHealth is the unhealthy flag of a (node, policy) pair before
+// and after a compliance report.
+type ComplianceHealth struct {
+	WasUnhealthy bool
+	Unhealthy    bool
+}
+
+// SaveComplianceReports stores a batch of compliance reports of a node in
+// one transaction: each result is upserted, its failure streak updated as
+// by RecordComplianceHealth and its applied files replaced. It returns the
+// health of each report, in order.
+func (r *DConfRepository) SaveComplianceReports(ctx context.Context, nodeID string, reports []ComplianceReport, threshold int, window time.Duration) ([]ComplianceHealth, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("dconf: begin tx: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	health := make([]ComplianceHealth, 0, len(reports))
+	for _, rep := range reports {
+		if err := upsertComplianceResult(ctx, tx, nodeID, rep.PolicyID, rep.Status, rep.Message, rep.ItemsJSON); err != nil {
+			return nil, err
+		}
+		var h ComplianceHealth
+		if h.WasUnhealthy, h.Unhealthy, err = recordComplianceHealth(ctx, tx, nodeID, rep.PolicyID, rep.Failed, threshold, window); err != nil {
+			return nil, err
+		}
+		health = append(health, h)
+		if len(rep.AppliedFiles) > 0 {
+			if err := replaceAppliedFiles(ctx, tx, nodeID, rep.PolicyID, rep.AppliedFiles); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("dconf: commit compliance reports: %w", err)
+	}
+	return health, nil
+}
+
 // ComplianceRow is a single compliance result row with joined names.
 type ComplianceRow struct {
 	NodeID     string          `json:"node_id"`
@@ -152,15 +292,35 @@ type ComplianceRow struct {
 	Message    *string         `json:"message,omitempty"`
 	Items      json.RawMessage `json:"items,omitempty"`
 	ReportedAt string          `json:"reported_at"`
+	// Check* hold the result of the policy's compliance check command, when
+	// the policy has one and the agent ran it.
+	CheckStatus   *string `json:"check_status,omitempty"`
+	CheckExitCode *int    `json:"check_exit_code,omitempty"`
+	CheckMessage  *string `json:"check_message,omitempty"`
+	CheckedAt     *string `json:"checked_at,omitempty"`
+	// Unhealthy is set once the node has failed this policy
+	// ConsecutiveFailures times in a row (see RecordComplianceHealth).
+	Unhealthy           bool `json:"unhealthy"`
+	ConsecutiveFailures int  `json:"consecutive_failures"`
 }
 
-// ListComplianceResults returns compliance results joined with node and policy names.
-// Only results where there is still an enabled binding connecting the policy to a
-// node group that contains the node are returned — stale results from removed or
-// disabled bindings are silently excluded.
-func (r *DConfRepository) ListComplianceResults(ctx context.Context) ([]*ComplianceRow, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT cr.node_id, n.name, cr.policy_id, p.name, cr.status, cr.message, cr.items_json, cr.reported_at
+// ComplianceListFilter narrows a compliance result listing. Empty fields
+// match everything. Compliant selects passing (status "compliant") or
+// failing (status "non_compliant" or "error") results. Page and PerPage
+// follow the AuditLogListRequest convention.
+type ComplianceListFilter struct {
+	NodeID    string
+	PolicyID  string
+	Compliant *bool
+	Page      int
+	PerPage   int
+}
+
+// complianceResultsWhere selects the compliance results matching f. Only
+// results where there is still an enabled binding connecting the policy to a
+// node group that contains the node are included — stale results from removed
+// or disabled bindings are silently excluded.
+const complianceResultsWhere = `
 		FROM compliance_results cr
 		JOIN nodes    n ON n.id    = cr.node_id
 		JOIN policies p ON p.id    = cr.policy_id
@@ -172,7 +332,34 @@ func (r *DConfRepository) ListComplianceResults(ctx context.Context) ([]*Complia
 			  AND ngm.node_id  = cr.node_id
 			  AND pb.state     = 'enabled'
 		)
-		ORDER BY cr.reported_at DESC`)
+		  AND ($1 = '' OR cr.node_id::text = $1)
+		  AND ($2 = '' OR cr.policy_id::text = $2)
+		  AND ($3::boolean IS NULL OR CASE WHEN $3 THEN cr.status = 'compliant'
+		                                   ELSE cr.status IN ('non_compliant', 'error') END)`
+
+// ListComplianceResults returns the compliance results matching f, joined
+// with node and policy names, newest first. A node has at most one result
+// per policy: each report replaces the previous one.
+func (r *DConfRepository) ListComplianceResults(ctx context.Context, f ComplianceListFilter) ([]*ComplianceRow, error) {
+	limit := f.PerPage
+	if limit <= 0 {
+		limit = 25
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	page := f.Page
+	if page < 1 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT cr.node_id, n.name, cr.policy_id, p.name, cr.status, cr.message, cr.items_json, cr.reported_at,
+		       cr.check_status, cr.check_exit_code, cr.check_message, cr.checked_at,
+		       cr.unhealthy, cr.consecutive_failures`+complianceResultsWhere+`
+		ORDER BY cr.reported_at DESC, n.name, p.name
+		LIMIT $4 OFFSET $5`, f.NodeID, f.PolicyID, f.Compliant, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("dconf: list compliance results: %w", err)
 	}
@@ -183,18 +370,246 @@ func (r *DConfRepository) ListComplianceResults(ctx context.Context) ([]*Complia
 		var cr ComplianceRow
 		var itemsJSON []byte
 		var reportedAt time.Time
-		if err := rows.Scan(&cr.NodeID, &cr.NodeName, &cr.PolicyID, &cr.PolicyName, &cr.Status, &cr.Message, &itemsJSON, &reportedAt); err != nil {
+		var checkedAt sql.NullTime
+		if err := rows.Scan(&cr.NodeID, &cr.NodeName, &cr.PolicyID, &cr.PolicyName, &cr.Status, &cr.Message, &itemsJSON, &reportedAt,
+			&cr.CheckStatus, &cr.CheckExitCode, &cr.CheckMessage, &checkedAt,
+			&cr.Unhealthy, &cr.ConsecutiveFailures); err != nil {
 			return nil, fmt.Errorf("dconf: scan compliance row: %w", err)
 		}
 		if len(itemsJSON) > 0 {
 			cr.Items = json.RawMessage(itemsJSON)
 		}
 		cr.ReportedAt = reportedAt.UTC().Format(time.RFC3339)
+		if checkedAt.Valid {
+			ts := checkedAt.Time.UTC().Format(time.RFC3339)
+			cr.CheckedAt = &ts
+		}
 		results = append(results, &cr)
 	}
 	return results, rows.Err()
 }
 
+// CountComplianceResults returns the number of compliance results matching
+// f, ignoring its pagination.
+func (r *DConfRepository) CountComplianceResults(ctx context.Context, f ComplianceListFilter) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*)`+complianceResultsWhere,
+		f.NodeID, f.PolicyID, f.Compliant).Scan(&count); err != nil {
+		return 0, fmt.Errorf("dconf: count compliance results: %w", err)
+	}
+	return count, nil
+}
+
+// CountNodeCompliance returns how many of the node's current compliance
+// results pass and fail, in the sense of ComplianceListFilter.Compliant.
+func (r *DConfRepository) CountNodeCompliance(ctx context.Context, nodeID string) (compliant, noncompliant int, err error) {
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FILTER (WHERE cr.status = 'compliant'),
+		       COUNT(*) FILTER (WHERE cr.status IN ('non_compliant', 'error'))`+complianceResultsWhere,
+		nodeID, "", nil).Scan(&compliant, &noncompliant); err != nil {
+		return 0, 0, fmt.Errorf("dconf: count node compliance: %w", err)
+	}
+	return compliant, noncompliant, nil
+}
+
+// ComplianceEvidenceFilter narrows a compliance evidence export. Empty
+// fields match everything; From and To bound the last report time
+// (inclusive and exclusive respectively).
+type ComplianceEvidenceFilter struct {
+	NodeGroupID string
+	PolicyID    string
+	From        *time.Time
+	To          *time.Time
+}
+
+// ComplianceEvidenceRow is one (node, policy) line of a compliance evidence
+// report.
+type ComplianceEvidenceRow struct {
+	NodeID        string `json:"node_id"`
+	NodeName      string `json:"node_name"`
+	PolicyID      string `json:"policy_id"`
+	PolicyName    string `json:"policy_name"`
+	PolicyVersion int    `json:"policy_version"`
+	State         string `json:"state"`
+	LastCheckedAt string `json:"last_checked_at"`
+	// ContentHash is the hex SHA-256 of the "<path> <sha256>" lines of the
+	// files the node last applied for the policy, sorted by path and joined
+	// by newlines. Empty when the node reported no files.
+	ContentHash string `json:"content_hash"`
+}
+
+// ListComplianceEvidence returns the current compliance state of every
+// assigned (node, policy) pair matching f, ordered by node and policy name.
+// Like ListComplianceResults it skips results of removed or disabled
+// bindings.
+func (r *DConfRepository) ListComplianceEvidence(ctx context.Context, f ComplianceEvidenceFilter) ([]*ComplianceEvidenceRow, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT cr.node_id, n.name, cr.policy_id, p.name, p.version, cr.status, cr.reported_at,
+		       (SELECT encode(sha256(convert_to(string_agg(af.path || ' ' || af.sha256, E'\n' ORDER BY af.path), 'UTF8')), 'hex')
+		        FROM applied_file_hashes af
+		        WHERE af.node_id = cr.node_id AND af.policy_id = cr.policy_id)
+		FROM compliance_results cr
+		JOIN nodes    n ON n.id = cr.node_id
+		JOIN policies p ON p.id = cr.policy_id
+		WHERE EXISTS (
+			SELECT 1
+			FROM policy_bindings pb
+			JOIN node_group_members ngm ON ngm.node_group_id = pb.group_id
+			WHERE pb.policy_id = cr.policy_id
+			  AND ngm.node_id  = cr.node_id
+			  AND pb.state     = 'enabled'
+		)
+		  AND ($1 = '' OR EXISTS (
+			SELECT 1 FROM node_group_members ngm
+			WHERE ngm.node_id = cr.node_id AND ngm.node_group_id::text = $1
+		  ))
+		  AND ($2 = '' OR cr.policy_id::text = $2)
+		  AND ($3::timestamptz IS NULL OR cr.reported_at >= $3)
+		  AND ($4::timestamptz IS NULL OR cr.reported_at <  $4)
+		ORDER BY n.name, p.name`, f.NodeGroupID, f.PolicyID, f.From, f.To)
+	if err != nil {
+		return nil, fmt.Errorf("dconf: list compliance evidence: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []*ComplianceEvidenceRow
+	for rows.Next() {
+		var er ComplianceEvidenceRow
+		var reportedAt time.Time
+		var contentHash sql.NullString
+		if err := rows.Scan(&er.NodeID, &er.NodeName, &er.PolicyID, &er.PolicyName, &er.PolicyVersion,
+			&er.State, &reportedAt, &contentHash); err != nil {
+			return nil, fmt.Errorf("dconf: scan compliance evidence row: %w", err)
+		}
+		er.LastCheckedAt = reportedAt.UTC().Format(time.RFC3339)
+		er.ContentHash = contentHash.String
+		results = append(results, &er)
+	}
+	return results, rows.Err()
+}
+
+// ReplaceAppliedFiles replaces the applied file hashes reported by a node
+// for a policy.
+func (r *DConfRepository) ReplaceAppliedFiles(ctx context.Context, nodeID, policyID string, files []*pb.AppliedFile) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("dconf: begin tx: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	if err := replaceAppliedFiles(ctx, tx, nodeID, policyID, files); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func replaceAppliedFiles(ctx context.Context, tx *sql.Tx, nodeID, policyID string, files []*pb.AppliedFile) error {
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM applied_file_hashes WHERE node_id = $1 AND policy_id = $2`, nodeID, policyID,
+	); err != nil {
+		return fmt.Errorf("dconf: delete applied files: %w", err)
+	}
+
+	for _, f := range files {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO applied_file_hashes (node_id, policy_id, path, sha256, reported_at)
+			VALUES ($1, $2, $3, $4, NOW())
+			ON CONFLICT (node_id, policy_id, path) DO UPDATE
+			  SET sha256 = EXCLUDED.sha256, reported_at = EXCLUDED.reported_at`,
+			nodeID, policyID, f.GetPath(), f.GetSha256(),
+		); err != nil {
+			return fmt.Errorf("dconf: insert applied file %s: %w", f.GetPath(), err)
+		}
+	}
+	return nil
+}
+
+// AppliedFileRow is the content hash of a managed file as last reported by
+// a node for a policy.
+type AppliedFileRow struct {
+	NodeID     string `json:"node_id"`
+	NodeName   string `json:"node_name"`
+	PolicyID   string `json:"policy_id"`
+	PolicyName string `json:"policy_name"`
+	Path       string `json:"path"`
+	SHA256     string `json:"sha256"`
+	ReportedAt string `json:"reported_at"`
+	// Divergent is set when the hash differs from the one most nodes in
+	// the result report for the same policy and path.
+	Divergent bool `json:"divergent"`
+}
+
+// ListAppliedFiles returns applied file hashes, optionally restricted to a
+// node and/or to the members of a node group. Empty filters match all.
+func (r *DConfRepository) ListAppliedFiles(ctx context.Context, nodeID, nodeGroupID string) ([]*AppliedFileRow, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT af.node_id, n.name, af.policy_id, p.name, af.path, af.sha256, af.reported_at
+		FROM applied_file_hashes af
+		JOIN nodes    n ON n.id = af.node_id
+		JOIN policies p ON p.id = af.policy_id
+		WHERE ($1 = '' OR af.node_id::text = $1)
+		  AND ($2 = '' OR EXISTS (
+			SELECT 1 FROM node_group_members ngm
+			WHERE ngm.node_id = af.node_id AND ngm.node_group_id::text = $2
+		  ))
+		ORDER BY p.name, af.path, n.name`, nodeID, nodeGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("dconf: list applied files: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []*AppliedFileRow
+	for rows.Next() {
+		var af AppliedFileRow
+		var reportedAt time.Time
+		if err := rows.Scan(&af.NodeID, &af.NodeName, &af.PolicyID, &af.PolicyName, &af.Path, &af.SHA256, &reportedAt); err != nil {
+			return nil, fmt.Errorf("dconf: scan applied file row: %w", err)
+		}
+		af.ReportedAt = reportedAt.UTC().Format(time.RFC3339)
+		results = append(results, &af)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	MarkDivergentFiles(results)
+	return results, nil
+}
+
+// MarkDivergentFiles flags rows whose hash differs from the most common hash
+// reported for the same policy and path. When no single hash is most common
+// every row for that file is flagged, since no consensus exists.
+func MarkDivergentFiles(rows []*AppliedFileRow) {
+	type fileKey struct{ policyID, path string }
+	counts := make(map[fileKey]map[string]int)
+	for _, r := range rows {
+		k := fileKey{r.PolicyID, r.Path}
+		if counts[k] == nil {
+			counts[k] = make(map[string]int)
+		}
+		counts[k][r.SHA256]++
+	}
+
+	consensus := make(map[fileKey]string, len(counts))
+	for k, hashes := range counts {
+		best, bestN, tied := "", 0, false
+		for h, n := range hashes {
+			switch {
+			case n > bestN:
+				best, bestN, tied = h, n, false
+			case n == bestN:
+				tied = true
+			}
+		}
+		if !tied {
+			consensus[k] = best
+		}
+	}
+
+	for _, r := range rows {
+		r.Divergent = r.SHA256 != consensus[fileKey{r.PolicyID, r.Path}]
+	}
+}
+
 // ListSchemas returns all schemas in the catalogue.
 func (r *DConfRepository) ListSchemas(ctx context.Context) ([]*pb.GSettingsSchema, error) {
 	rows, err := r.db.QueryContext(ctx, `
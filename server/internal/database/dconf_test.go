@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package database
+
+import "testing"
+
+func TestMarkDivergentFiles(t *testing.T) {
+	rows := []*AppliedFileRow{
+		{NodeID: "n1", PolicyID: "p1", Path: "/etc/xdg/kdeglobals", SHA256: "aaa"},
+		{NodeID: "n2", PolicyID: "p1", Path: "/etc/xdg/kdeglobals", SHA256: "aaa"},
+		{NodeID: "n3", PolicyID: "p1", Path: "/etc/xdg/kdeglobals", SHA256: "bbb"},
+		{NodeID: "n1", PolicyID: "p2", Path: "/etc/polkit-1/rules.d/50-bor.rules", SHA256: "ccc"},
+		{NodeID: "n2", PolicyID: "p2", Path: "/etc/polkit-1/rules.d/50-bor.rules", SHA256: "ddd"},
+		{NodeID: "n1", PolicyID: "p3", Path: "/etc/firefox/policies/policies.json", SHA256: "eee"},
+	}
+	MarkDivergentFiles(rows)
+
+	want := []bool{false, false, true, true, true, false}
+	for i, r := range rows {
+		if r.Divergent != want[i] {
+			t.Errorf("row %d (%s %s): Divergent = %v, want %v", i, r.NodeID, r.Path, r.Divergent, want[i])
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+const enrollmentCampaignColumns = `id, name, description, node_group_id, token_ttl_seconds,
+	max_enrollments, enrolled_count, created_by, created_at, updated_at`
+
+// EnrollmentCampaignRepository handles enrollment_campaigns database operations
+type EnrollmentCampaignRepository struct {
+	db *DB
+}
+
+// NewEnrollmentCampaignRepository creates a new EnrollmentCampaignRepository
+func NewEnrollmentCampaignRepository(db *DB) *EnrollmentCampaignRepository {
+	return &EnrollmentCampaignRepository{db: db}
+}
+
+// Create inserts a new enrollment campaign
+func (r *EnrollmentCampaignRepository) Create(ctx context.Context, c *models.EnrollmentCampaign) error {
+	query := `INSERT INTO enrollment_campaigns (name, description, node_group_id, token_ttl_seconds,
+			max_enrollments, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+
+	now := time.Now()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+
+	err := r.db.QueryRowContext(ctx, query,
+		c.Name, c.Description, c.NodeGroupID, c.TokenTTLSeconds,
+		c.MaxEnrollments, c.CreatedBy, c.CreatedAt, c.UpdatedAt,
+	).Scan(&c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create enrollment campaign: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an enrollment campaign by ID
+func (r *EnrollmentCampaignRepository) GetByID(ctx context.Context, id string) (*models.EnrollmentCampaign, error) {
+	query := `SELECT ` + enrollmentCampaignColumns + ` FROM enrollment_campaigns WHERE id = $1`
+	c, err := scanEnrollmentCampaign(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrollment campaign: %w", err)
+	}
+	return c, nil
+}
+
+// ListAll returns all enrollment campaigns, newest first
+func (r *EnrollmentCampaignRepository) ListAll(ctx context.Context) ([]*models.EnrollmentCampaign, error) {
+	query := `SELECT ` + enrollmentCampaignColumns + ` FROM enrollment_campaigns ORDER BY created_at DESC`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enrollment campaigns: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var campaigns []*models.EnrollmentCampaign
+	for rows.Next() {
+		c, err := scanEnrollmentCampaign(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan enrollment campaign: %w", err)
+		}
+		campaigns = append(campaigns, c)
+	}
+	return campaigns, rows.Err()
+}
+
+// Update updates an enrollment campaign
+func (r *EnrollmentCampaignRepository) Update(ctx context.Context, id string, req *models.UpdateEnrollmentCampaignRequest) error {
+	setClauses := []string{}
+	args := []interface{}{}
+	argIdx := 1
+
+	if req.Name != nil {
+		setClauses = append(setClauses, fmt.Sprintf("name = $%d", argIdx))
+		args = append(args, *req.Name)
+		argIdx++
+	}
+	if req.Description != nil {
+		setClauses = append(setClauses, fmt.Sprintf("description = $%d", argIdx))
+		args = append(args, *req.Description)
+		argIdx++
+	}
+	if req.TokenTTLSeconds != nil {
+		setClauses = append(setClauses, fmt.Sprintf("token_ttl_seconds = $%d", argIdx))
+		args = append(args, *req.TokenTTLSeconds)
+		argIdx++
+	}
+	if req.MaxEnrollments != nil {
+		setClauses = append(setClauses, fmt.Sprintf("max_enrollments = $%d", argIdx))
+		args = append(args, *req.MaxEnrollments)
+		argIdx++
+	}
+
+	if len(setClauses) == 0 {
+		return nil
+	}
+
+	setClauses = append(setClauses, fmt.Sprintf("updated_at = $%d", argIdx))
+	args = append(args, time.Now())
+	argIdx++
+
+	args = append(args, id)
+	query := fmt.Sprintf("UPDATE enrollment_campaigns SET %s WHERE id = $%d",
+		strings.Join(setClauses, ", "), argIdx)
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update enrollment campaign: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("enrollment campaign not found")
+	}
+	return nil
+}
+
+// Delete removes an enrollment campaign by ID. Nodes enrolled through it
+// keep their record; only the campaign reference is cleared.
+func (r *EnrollmentCampaignRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM enrollment_campaigns WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete enrollment campaign: %w", err)
+	}
+	return nil
+}
+
+// ReserveEnrollment atomically counts one more enrollment against the
+// campaign. It returns false, without changing anything, when the campaign
+// does not exist or its enrollment limit has been reached.
+func (r *EnrollmentCampaignRepository) ReserveEnrollment(ctx context.Context, id string) (bool, error) {
+	query := `UPDATE enrollment_campaigns
+		SET enrolled_count = enrolled_count + 1, updated_at = $2
+		WHERE id = $1 AND (max_enrollments = 0 OR enrolled_count < max_enrollments)`
+	result, err := r.db.ExecContext(ctx, query, id, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve enrollment: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func scanEnrollmentCampaign(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.EnrollmentCampaign, error) {
+	c := &models.EnrollmentCampaign{}
+	err := row.Scan(&c.ID, &c.Name, &c.Description, &c.NodeGroupID, &c.TokenTTLSeconds,
+		&c.MaxEnrollments, &c.EnrolledCount, &c.CreatedBy, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
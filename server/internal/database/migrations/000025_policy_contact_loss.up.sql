@@ -0,0 +1,11 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Dead-man's switch: per-policy behaviour once an agent has been out of
+-- contact with the server for longer than contact_loss_ttl_seconds.
+--   keep     - keep enforcing (default, previous behaviour)
+--   revert   - stop enforcing and restore the original settings
+--   activate - only enforce while the agent is out of contact
+ALTER TABLE policies
+    ADD COLUMN contact_loss_action VARCHAR(20) NOT NULL DEFAULT 'keep',
+    ADD COLUMN contact_loss_ttl_seconds INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+ALTER TABLE compliance_results
+    DROP COLUMN IF EXISTS checked_at,
+    DROP COLUMN IF EXISTS check_message,
+    DROP COLUMN IF EXISTS check_exit_code,
+    DROP COLUMN IF EXISTS check_status;
+
+ALTER TABLE policies
+    DROP COLUMN IF EXISTS check_timeout_seconds,
+    DROP COLUMN IF EXISTS check_args,
+    DROP COLUMN IF EXISTS check_command;
@@ -0,0 +1,15 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Optional per-policy compliance check command run by opted-in agents.
+ALTER TABLE policies
+    ADD COLUMN check_command TEXT NOT NULL DEFAULT '',
+    ADD COLUMN check_args TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN check_timeout_seconds INTEGER NOT NULL DEFAULT 0;
+
+-- Check results are kept next to, but separate from, the deployment status.
+ALTER TABLE compliance_results
+    ADD COLUMN check_status VARCHAR(20),
+    ADD COLUMN check_exit_code INTEGER,
+    ADD COLUMN check_message TEXT,
+    ADD COLUMN checked_at TIMESTAMPTZ;
@@ -0,0 +1,14 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+DELETE FROM policy_bindings WHERE filter_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_policy_bindings_filter_id;
+
+ALTER TABLE policy_bindings
+    DROP CONSTRAINT IF EXISTS policy_bindings_policy_id_filter_id_key,
+    DROP CONSTRAINT IF EXISTS policy_bindings_target_check,
+    DROP COLUMN IF EXISTS filter_id,
+    ALTER COLUMN group_id SET NOT NULL;
+
+DROP TABLE IF EXISTS node_filters;
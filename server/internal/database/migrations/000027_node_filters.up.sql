@@ -0,0 +1,22 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Saved node filters: dynamic node selections evaluated against node facts.
+CREATE TABLE node_filters (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    criteria JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- A binding targets either a static node group or a saved filter.
+ALTER TABLE policy_bindings
+    ALTER COLUMN group_id DROP NOT NULL,
+    ADD COLUMN filter_id UUID REFERENCES node_filters(id) ON DELETE CASCADE,
+    ADD CONSTRAINT policy_bindings_target_check
+        CHECK ((group_id IS NULL) <> (filter_id IS NULL)),
+    ADD CONSTRAINT policy_bindings_policy_id_filter_id_key UNIQUE (policy_id, filter_id);
+
+CREATE INDEX idx_policy_bindings_filter_id ON policy_bindings(filter_id);
@@ -0,0 +1,7 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+DROP INDEX IF EXISTS idx_nodes_campaign_id;
+ALTER TABLE nodes DROP COLUMN IF EXISTS campaign_id;
+
+DROP TABLE IF EXISTS enrollment_campaigns;
@@ -0,0 +1,24 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Enrollment campaigns group enrollment tokens for a provisioning effort and
+-- cap how many machines may enroll through them.
+CREATE TABLE enrollment_campaigns (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    node_group_id UUID NOT NULL REFERENCES node_groups(id) ON DELETE CASCADE,
+    token_ttl_seconds INTEGER NOT NULL DEFAULT 300,
+    max_enrollments INTEGER NOT NULL DEFAULT 0,
+    enrolled_count INTEGER NOT NULL DEFAULT 0,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_enrollment_campaigns_node_group_id ON enrollment_campaigns(node_group_id);
+
+ALTER TABLE nodes
+    ADD COLUMN campaign_id UUID REFERENCES enrollment_campaigns(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_nodes_campaign_id ON nodes(campaign_id);
@@ -0,0 +1,14 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Content hashes of the managed files each node last wrote for a policy,
+-- replaced on every successful compliance report.
+CREATE TABLE applied_file_hashes (
+    node_id     UUID        NOT NULL REFERENCES nodes(id)    ON DELETE CASCADE,
+    policy_id   UUID        NOT NULL REFERENCES policies(id) ON DELETE CASCADE,
+    path        TEXT        NOT NULL,
+    sha256      CHAR(64)    NOT NULL,
+    reported_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (node_id, policy_id, path)
+);
+CREATE INDEX ON applied_file_hashes (policy_id, path);
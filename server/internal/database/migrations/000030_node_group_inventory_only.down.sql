@@ -0,0 +1,4 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+ALTER TABLE node_groups DROP COLUMN IF EXISTS inventory_only;
@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Nodes in an inventory-only group connect, heartbeat and report
+-- compliance, but their agents do not enforce any policy.
+ALTER TABLE node_groups ADD COLUMN inventory_only BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,7 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+ALTER TABLE policy_bindings DROP COLUMN IF EXISTS profile_managed;
+DROP TABLE IF EXISTS profile_bindings;
+DROP TABLE IF EXISTS profile_policies;
+DROP TABLE IF EXISTS profiles;
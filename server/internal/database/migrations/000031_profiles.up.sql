@@ -0,0 +1,33 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Profiles bundle related policies so they can be bound to a node group in
+-- one step.
+CREATE TABLE profiles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE profile_policies (
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    policy_id  UUID NOT NULL REFERENCES policies(id) ON DELETE CASCADE,
+    PRIMARY KEY (profile_id, policy_id)
+);
+CREATE INDEX idx_profile_policies_policy_id ON profile_policies(policy_id);
+
+CREATE TABLE profile_bindings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    profile_id UUID NOT NULL REFERENCES profiles(id) ON DELETE CASCADE,
+    group_id UUID NOT NULL REFERENCES node_groups(id) ON DELETE CASCADE,
+    priority INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (profile_id, group_id)
+);
+CREATE INDEX idx_profile_bindings_group_id ON profile_bindings(group_id);
+
+-- Policy bindings created by a profile binding; they are removed again
+-- when no profile bound to the group contains the policy any more.
+ALTER TABLE policy_bindings ADD COLUMN profile_managed BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,17 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Log captures hold agent log buffers collected on demand for diagnostics.
+-- A row is created when an administrator requests logs and completed when
+-- the agent uploads them.
+CREATE TABLE node_log_captures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    node_id UUID NOT NULL REFERENCES nodes(id) ON DELETE CASCADE,
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    received_at TIMESTAMP,
+    content BYTEA,
+    truncated BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX idx_node_log_captures_node_id ON node_log_captures(node_id, requested_at DESC);
@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Preconditions on live node state that agents evaluate before applying a
+-- policy, e.g. "only if Plasma is the active session".
+ALTER TABLE policies ADD COLUMN preconditions JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,7 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+ALTER TABLE compliance_results
+    DROP COLUMN IF EXISTS unhealthy,
+    DROP COLUMN IF EXISTS failing_since,
+    DROP COLUMN IF EXISTS consecutive_failures;
@@ -0,0 +1,10 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Debounced compliance health: a node is flagged unhealthy for a policy only
+-- after a configurable number of consecutive failed reports within a window.
+ALTER TABLE compliance_results
+    ADD COLUMN consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN failing_since TIMESTAMPTZ,
+    ADD COLUMN unhealthy BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX ON compliance_results (node_id) WHERE unhealthy;
@@ -0,0 +1,20 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Remediations record one-time runs of predefined remediation actions on a
+-- node. A row is created when an administrator requests the action and
+-- completed when the agent reports the result.
+CREATE TABLE node_remediations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    node_id UUID NOT NULL REFERENCES nodes(id) ON DELETE CASCADE,
+    action VARCHAR(100) NOT NULL,
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP,
+    success BOOLEAN,
+    exit_code INTEGER,
+    output TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_node_remediations_node_id ON node_remediations(node_id, requested_at DESC);
@@ -0,0 +1,5 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+DROP INDEX IF EXISTS idx_audit_logs_correlation_id;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS correlation_id;
@@ -0,0 +1,8 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- The correlation ID groups all audit entries recorded while serving one
+-- API request, so a single logical operation can be reconstructed.
+ALTER TABLE audit_logs ADD COLUMN correlation_id VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_audit_logs_correlation_id ON audit_logs(correlation_id) WHERE correlation_id <> '';
@@ -0,0 +1,9 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+DELETE FROM revoked_certificates WHERE node_id IS NULL;
+ALTER TABLE revoked_certificates DROP CONSTRAINT IF EXISTS revoked_certificates_node_id_fkey;
+ALTER TABLE revoked_certificates
+    ADD CONSTRAINT revoked_certificates_node_id_fkey
+    FOREIGN KEY (node_id) REFERENCES nodes(id) ON DELETE CASCADE;
+ALTER TABLE revoked_certificates ALTER COLUMN node_id SET NOT NULL;
@@ -0,0 +1,10 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Keep certificate revocations when their node is deleted, so the
+-- certificate of a decommissioned machine stays blocked.
+ALTER TABLE revoked_certificates ALTER COLUMN node_id DROP NOT NULL;
+ALTER TABLE revoked_certificates DROP CONSTRAINT revoked_certificates_node_id_fkey;
+ALTER TABLE revoked_certificates
+    ADD CONSTRAINT revoked_certificates_node_id_fkey
+    FOREIGN KEY (node_id) REFERENCES nodes(id) ON DELETE SET NULL;
@@ -0,0 +1,14 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- compliance:export gates the compliance evidence report for GRC tools.
+-- It is granted to the roles that can already export audit logs, and to
+-- Compliance Viewer.
+INSERT INTO permissions (resource, action) VALUES ('compliance', 'export')
+ON CONFLICT (resource, action) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name IN ('Super Admin', 'Org Admin', 'Auditor', 'Compliance Viewer')
+  AND p.resource = 'compliance' AND p.action = 'export'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+ALTER TABLE node_groups DROP COLUMN IF EXISTS quarantine;
+ALTER TABLE nodes DROP COLUMN IF EXISTS quarantine_reason;
+ALTER TABLE nodes DROP COLUMN IF EXISTS quarantined_at;
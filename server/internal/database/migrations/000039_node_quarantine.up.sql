@@ -0,0 +1,9 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- A quarantined node receives only the policies bound to quarantine node
+-- groups, regardless of its own groups and matching filters. Quarantine
+-- groups hold the lockdown bindings and normally have no members.
+ALTER TABLE nodes ADD COLUMN quarantined_at TIMESTAMPTZ;
+ALTER TABLE nodes ADD COLUMN quarantine_reason TEXT;
+ALTER TABLE node_groups ADD COLUMN quarantine BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Set when an admin resets a password and asks the user to choose a new
+-- one; cleared when the user changes their password.
+ALTER TABLE users ADD COLUMN must_change_password BOOLEAN NOT NULL DEFAULT FALSE;
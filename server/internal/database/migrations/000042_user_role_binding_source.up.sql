@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Who created a user role binding: 'manual' (an administrator) or 'ldap'
+-- (the LDAP group→role sync). The sync only revokes its own bindings.
+ALTER TABLE user_role_bindings ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'manual';
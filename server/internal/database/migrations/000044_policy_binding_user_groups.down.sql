@@ -0,0 +1,15 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+ALTER TABLE nodes DROP COLUMN IF EXISTS session_users;
+
+DELETE FROM policy_bindings WHERE user_group_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_policy_bindings_user_group_id;
+
+ALTER TABLE policy_bindings
+    DROP CONSTRAINT IF EXISTS policy_bindings_policy_id_user_group_id_key,
+    DROP CONSTRAINT IF EXISTS policy_bindings_target_check,
+    DROP COLUMN IF EXISTS user_group_id,
+    ADD CONSTRAINT policy_bindings_target_check
+        CHECK ((group_id IS NULL) <> (filter_id IS NULL));
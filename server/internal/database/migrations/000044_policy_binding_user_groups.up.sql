@@ -0,0 +1,17 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- A binding may target a user group instead of a node group or a saved
+-- filter: the policy then applies to the login sessions of the group's
+-- members on any node.
+ALTER TABLE policy_bindings
+    ADD COLUMN user_group_id UUID REFERENCES user_groups(id) ON DELETE CASCADE,
+    DROP CONSTRAINT policy_bindings_target_check,
+    ADD CONSTRAINT policy_bindings_target_check
+        CHECK (num_nonnulls(group_id, filter_id, user_group_id) = 1),
+    ADD CONSTRAINT policy_bindings_policy_id_user_group_id_key UNIQUE (policy_id, user_group_id);
+
+CREATE INDEX idx_policy_bindings_user_group_id ON policy_bindings(user_group_id);
+
+-- Users with a login session on the node, as last reported by its agent.
+ALTER TABLE nodes ADD COLUMN session_users TEXT[] NOT NULL DEFAULT '{}';
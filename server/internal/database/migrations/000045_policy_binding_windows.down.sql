@@ -0,0 +1,12 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+DROP INDEX IF EXISTS idx_policy_bindings_windowed;
+
+ALTER TABLE policy_bindings
+    DROP CONSTRAINT IF EXISTS policy_bindings_window_check,
+    DROP COLUMN IF EXISTS in_window,
+    DROP COLUMN IF EXISTS schedule_timezone,
+    DROP COLUMN IF EXISTS schedule,
+    DROP COLUMN IF EXISTS active_to,
+    DROP COLUMN IF EXISTS active_from;
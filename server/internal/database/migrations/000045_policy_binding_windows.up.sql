@@ -0,0 +1,17 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- A binding may be limited to a time range and to a weekly schedule in a
+-- time zone. in_window is maintained by the server's binding scheduler:
+-- bindings out of their window deliver no policies.
+ALTER TABLE policy_bindings
+    ADD COLUMN active_from TIMESTAMPTZ,
+    ADD COLUMN active_to TIMESTAMPTZ,
+    ADD COLUMN schedule TEXT NOT NULL DEFAULT '',
+    ADD COLUMN schedule_timezone TEXT NOT NULL DEFAULT '',
+    ADD COLUMN in_window BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD CONSTRAINT policy_bindings_window_check
+        CHECK (active_from IS NULL OR active_to IS NULL OR active_from < active_to);
+
+CREATE INDEX idx_policy_bindings_windowed ON policy_bindings(id)
+    WHERE active_from IS NOT NULL OR active_to IS NOT NULL OR schedule <> '';
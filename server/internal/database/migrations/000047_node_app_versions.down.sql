@@ -0,0 +1,7 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+ALTER TABLE nodes
+    DROP COLUMN IF EXISTS kernel_version,
+    DROP COLUMN IF EXISTS chrome_version,
+    DROP COLUMN IF EXISTS firefox_version;
@@ -0,0 +1,8 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Browser and kernel versions reported in agent heartbeats.
+ALTER TABLE nodes
+    ADD COLUMN firefox_version VARCHAR(100),
+    ADD COLUMN chrome_version VARCHAR(100),
+    ADD COLUMN kernel_version VARCHAR(255);
@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- Hardware and resource facts reported in agent heartbeats (memory, CPU,
+-- root filesystem usage, boot time).
+ALTER TABLE nodes ADD COLUMN hardware JSONB;
@@ -0,0 +1,4 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+DELETE FROM permissions WHERE resource = 'audit_log' AND action = 'purge';
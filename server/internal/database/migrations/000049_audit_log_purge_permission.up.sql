@@ -0,0 +1,13 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- audit_log:purge gates manual deletion of old audit log entries. Only
+-- Super Admin gets it; the retention job does not need a permission.
+INSERT INTO permissions (resource, action) VALUES ('audit_log', 'purge')
+ON CONFLICT (resource, action) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'Super Admin'
+  AND p.resource = 'audit_log' AND p.action = 'purge'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,13 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- node:collect_logs gates collecting and downloading agent logs, which may
+-- contain hostnames, user names and policy content. Only Super Admin gets it.
+INSERT INTO permissions (resource, action) VALUES ('node', 'collect_logs')
+ON CONFLICT (resource, action) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'Super Admin'
+  AND p.resource = 'node' AND p.action = 'collect_logs'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,13 @@
+-- SPDX-License-Identifier: LGPL-3.0-or-later
+-- Copyright (C) 2026 Vute Tech LTD
+
+-- node:quarantine gates placing and lifting a node quarantine, which replaces
+-- the node's whole policy set. Only Super Admin gets it.
+INSERT INTO permissions (resource, action) VALUES ('node', 'quarantine')
+ON CONFLICT (resource, action) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'Super Admin'
+  AND p.resource = 'node' AND p.action = 'quarantine'
+ON CONFLICT DO NOTHING;
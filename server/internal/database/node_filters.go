@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// NodeFilterRepository handles node_filters database operations
+type NodeFilterRepository struct {
+	db *DB
+}
+
+// NewNodeFilterRepository creates a new NodeFilterRepository
+func NewNodeFilterRepository(db *DB) *NodeFilterRepository {
+	return &NodeFilterRepository{db: db}
+}
+
+// Create inserts a new node filter
+func (r *NodeFilterRepository) Create(ctx context.Context, f *models.NodeFilter) error {
+	criteria, err := json.Marshal(f.Criteria)
+	if err != nil {
+		return fmt.Errorf("failed to encode node filter criteria: %w", err)
+	}
+
+	query := `INSERT INTO node_filters (name, description, criteria, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+
+	now := time.Now()
+	f.CreatedAt = now
+	f.UpdatedAt = now
+
+	err = r.db.QueryRowContext(ctx, query, f.Name, f.Description, criteria, f.CreatedAt, f.UpdatedAt).Scan(&f.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create node filter: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a node filter by ID
+func (r *NodeFilterRepository) GetByID(ctx context.Context, id string) (*models.NodeFilter, error) {
+	query := `SELECT id, name, description, criteria, created_at, updated_at FROM node_filters WHERE id = $1`
+	f, err := scanNodeFilter(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node filter: %w", err)
+	}
+	return f, nil
+}
+
+// ListAll returns all node filters
+func (r *NodeFilterRepository) ListAll(ctx context.Context) ([]*models.NodeFilter, error) {
+	query := `SELECT id, name, description, criteria, created_at, updated_at FROM node_filters ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node filters: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var filters []*models.NodeFilter
+	for rows.Next() {
+		f, err := scanNodeFilter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan node filter: %w", err)
+		}
+		filters = append(filters, f)
+	}
+	return filters, rows.Err()
+}
+
+// Update updates a node filter
+func (r *NodeFilterRepository) Update(ctx context.Context, id string, req *models.UpdateNodeFilterRequest) error {
+	setClauses := []string{}
+	args := []interface{}{}
+	argIdx := 1
+
+	if req.Name != nil {
+		setClauses = append(setClauses, fmt.Sprintf("name = $%d", argIdx))
+		args = append(args, *req.Name)
+		argIdx++
+	}
+	if req.Description != nil {
+		setClauses = append(setClauses, fmt.Sprintf("description = $%d", argIdx))
+		args = append(args, *req.Description)
+		argIdx++
+	}
+	if req.Criteria != nil {
+		criteria, err := json.Marshal(req.Criteria)
+		if err != nil {
+			return fmt.Errorf("failed to encode node filter criteria: %w", err)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("criteria = $%d", argIdx))
+		args = append(args, criteria)
+		argIdx++
+	}
+
+	if len(setClauses) == 0 {
+		return nil
+	}
+
+	setClauses = append(setClauses, fmt.Sprintf("updated_at = $%d", argIdx))
+	args = append(args, time.Now())
+	argIdx++
+
+	args = append(args, id)
+	query := fmt.Sprintf("UPDATE node_filters SET %s WHERE id = $%d",
+		strings.Join(setClauses, ", "), argIdx)
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update node filter: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("node filter not found")
+	}
+	return nil
+}
+
+// Delete removes a node filter by ID. Bindings that target the filter are
+// removed with it.
+func (r *NodeFilterRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM node_filters WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete node filter: %w", err)
+	}
+	return nil
+}
+
+func scanNodeFilter(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.NodeFilter, error) {
+	f := &models.NodeFilter{}
+	var criteria []byte
+	if err := row.Scan(&f.ID, &f.Name, &f.Description, &criteria, &f.CreatedAt, &f.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(criteria, &f.Criteria); err != nil {
+		return nil, fmt.Errorf("invalid criteria for node filter %s: %w", f.ID, err)
+	}
+	return f, nil
+}
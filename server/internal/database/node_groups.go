@@ -26,14 +26,14 @@ func NewNodeGroupRepository(db *DB) *NodeGroupRepository {
 
 // Create inserts a new node group
 func (r *NodeGroupRepository) Create(ctx context.Context, ng *models.NodeGroup) error {
-	query := `INSERT INTO node_groups (name, description, created_at, updated_at)
-		VALUES ($1, $2, $3, $4) RETURNING id`
+	query := `INSERT INTO node_groups (name, description, inventory_only, quarantine, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
 
 	now := time.Now()
 	ng.CreatedAt = now
 	ng.UpdatedAt = now
 
-	err := r.db.QueryRowContext(ctx, query, ng.Name, ng.Description, ng.CreatedAt, ng.UpdatedAt).Scan(&ng.ID)
+	err := r.db.QueryRowContext(ctx, query, ng.Name, ng.Description, ng.InventoryOnly, ng.Quarantine, ng.CreatedAt, ng.UpdatedAt).Scan(&ng.ID)
 	if err != nil {
 		return fmt.Errorf("failed to create node group: %w", err)
 	}
@@ -42,9 +42,9 @@ func (r *NodeGroupRepository) Create(ctx context.Context, ng *models.NodeGroup)
 
 // GetByID retrieves a node group by ID
 func (r *NodeGroupRepository) GetByID(ctx context.Context, id string) (*models.NodeGroup, error) {
-	query := `SELECT id, name, description, created_at, updated_at FROM node_groups WHERE id = $1`
+	query := `SELECT id, name, description, inventory_only, quarantine, created_at, updated_at FROM node_groups WHERE id = $1`
 	ng := &models.NodeGroup{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&ng.ID, &ng.Name, &ng.Description, &ng.CreatedAt, &ng.UpdatedAt)
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&ng.ID, &ng.Name, &ng.Description, &ng.InventoryOnly, &ng.Quarantine, &ng.CreatedAt, &ng.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -56,8 +56,28 @@ func (r *NodeGroupRepository) GetByID(ctx context.Context, id string) (*models.N
 
 // ListAll returns all node groups
 func (r *NodeGroupRepository) ListAll(ctx context.Context) ([]*models.NodeGroup, error) {
-	query := `SELECT id, name, description, created_at, updated_at FROM node_groups ORDER BY name`
-	rows, err := r.db.QueryContext(ctx, query)
+	query := `SELECT id, name, description, inventory_only, quarantine, created_at, updated_at FROM node_groups ORDER BY name`
+	return r.scanNodeGroups(ctx, query)
+}
+
+// ListPaginated returns one page of all node groups, ordered by name, and the
+// total number of node groups.
+func (r *NodeGroupRepository) ListPaginated(ctx context.Context, p models.Pagination) ([]*models.NodeGroup, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM node_groups`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count node groups: %w", err)
+	}
+
+	query := `SELECT id, name, description, inventory_only, quarantine, created_at, updated_at FROM node_groups ORDER BY name, id LIMIT $1 OFFSET $2`
+	groups, err := r.scanNodeGroups(ctx, query, p.PerPage, p.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+func (r *NodeGroupRepository) scanNodeGroups(ctx context.Context, query string, args ...interface{}) ([]*models.NodeGroup, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list node groups: %w", err)
 	}
@@ -66,7 +86,7 @@ func (r *NodeGroupRepository) ListAll(ctx context.Context) ([]*models.NodeGroup,
 	var groups []*models.NodeGroup
 	for rows.Next() {
 		ng := &models.NodeGroup{}
-		if err := rows.Scan(&ng.ID, &ng.Name, &ng.Description, &ng.CreatedAt, &ng.UpdatedAt); err != nil {
+		if err := rows.Scan(&ng.ID, &ng.Name, &ng.Description, &ng.InventoryOnly, &ng.Quarantine, &ng.CreatedAt, &ng.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan node group: %w", err)
 		}
 		groups = append(groups, ng)
@@ -90,6 +110,16 @@ func (r *NodeGroupRepository) Update(ctx context.Context, id string, req *models
 		args = append(args, *req.Description)
 		argIdx++
 	}
+	if req.InventoryOnly != nil {
+		setClauses = append(setClauses, fmt.Sprintf("inventory_only = $%d", argIdx))
+		args = append(args, *req.InventoryOnly)
+		argIdx++
+	}
+	if req.Quarantine != nil {
+		setClauses = append(setClauses, fmt.Sprintf("quarantine = $%d", argIdx))
+		args = append(args, *req.Quarantine)
+		argIdx++
+	}
 
 	if len(setClauses) == 0 {
 		return nil
@@ -135,3 +165,59 @@ func (r *NodeGroupRepository) CountNodesByGroupID(ctx context.Context, groupID s
 	}
 	return count, nil
 }
+
+// ListMembers returns the member nodes of a node group, ordered by name.
+func (r *NodeGroupRepository) ListMembers(ctx context.Context, groupID string) ([]models.NodeGroupMember, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT CAST(n.id AS TEXT), n.name, n.status_cached
+		FROM node_group_members ngm
+		JOIN nodes n ON n.id = ngm.node_id
+		WHERE ngm.node_group_id = $1
+		ORDER BY n.name`, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node group members: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	members := []models.NodeGroupMember{}
+	for rows.Next() {
+		var m models.NodeGroupMember
+		if err := rows.Scan(&m.ID, &m.Name, &m.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan node group member: %w", err)
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// IsNodeInventoryOnly reports whether the node belongs to at least one
+// inventory-only node group.
+func (r *NodeGroupRepository) IsNodeInventoryOnly(ctx context.Context, nodeID string) (bool, error) {
+	query := `SELECT EXISTS (
+		SELECT 1 FROM node_group_members m
+		JOIN node_groups g ON g.id = m.node_group_id
+		WHERE m.node_id = $1 AND g.inventory_only)`
+	var inventoryOnly bool
+	if err := r.db.QueryRowContext(ctx, query, nodeID).Scan(&inventoryOnly); err != nil {
+		return false, fmt.Errorf("failed to check inventory-only mode: %w", err)
+	}
+	return inventoryOnly, nil
+}
+
+// ListQuarantineGroupIDs returns the IDs of the quarantine node groups.
+func (r *NodeGroupRepository) ListQuarantineGroupIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT CAST(id AS TEXT) FROM node_groups WHERE quarantine ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantine node groups: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan quarantine node group: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
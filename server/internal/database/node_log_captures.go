@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+const nodeLogCaptureColumns = `id, node_id, requested_by, requested_at, received_at,
+	COALESCE(octet_length(content), 0), truncated`
+
+// NodeLogCaptureRepository handles node_log_captures database operations
+type NodeLogCaptureRepository struct {
+	db *DB
+}
+
+// NewNodeLogCaptureRepository creates a new NodeLogCaptureRepository
+func NewNodeLogCaptureRepository(db *DB) *NodeLogCaptureRepository {
+	return &NodeLogCaptureRepository{db: db}
+}
+
+// Create inserts a pending log capture
+func (r *NodeLogCaptureRepository) Create(ctx context.Context, c *models.NodeLogCapture) error {
+	query := `INSERT INTO node_log_captures (node_id, requested_by, requested_at)
+		VALUES ($1, $2, $3) RETURNING id`
+
+	c.RequestedAt = time.Now()
+	c.Status = models.LogCapturePending
+	if err := r.db.QueryRowContext(ctx, query, c.NodeID, c.RequestedBy, c.RequestedAt).Scan(&c.ID); err != nil {
+		return fmt.Errorf("failed to create log capture: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a log capture. It is used when the request could not be
+// delivered to the agent.
+func (r *NodeLogCaptureRepository) Delete(ctx context.Context, id string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM node_log_captures WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete log capture: %w", err)
+	}
+	return nil
+}
+
+// Complete stores the uploaded log content of a pending capture belonging
+// to nodeID. It reports false when no such pending capture exists.
+func (r *NodeLogCaptureRepository) Complete(ctx context.Context, id, nodeID string, content []byte, truncated bool, receivedAt time.Time) (bool, error) {
+	query := `UPDATE node_log_captures
+		SET content = $3, truncated = $4, received_at = $5
+		WHERE id = $1 AND node_id = $2 AND received_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, id, nodeID, content, truncated, receivedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to store log capture: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to store log capture: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ListByNode returns the log captures of a node, newest first, without
+// their content
+func (r *NodeLogCaptureRepository) ListByNode(ctx context.Context, nodeID string) ([]*models.NodeLogCapture, error) {
+	query := `SELECT ` + nodeLogCaptureColumns + ` FROM node_log_captures
+		WHERE node_id = $1 ORDER BY requested_at DESC`
+	rows, err := r.db.QueryContext(ctx, query, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list log captures: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var captures []*models.NodeLogCapture
+	for rows.Next() {
+		c, err := scanNodeLogCapture(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan log capture: %w", err)
+		}
+		captures = append(captures, c)
+	}
+	return captures, rows.Err()
+}
+
+// GetContent returns a node's log capture together with its content, or
+// nil if it does not exist. The content is nil while the capture is pending.
+func (r *NodeLogCaptureRepository) GetContent(ctx context.Context, id, nodeID string) (*models.NodeLogCapture, []byte, error) {
+	query := `SELECT ` + nodeLogCaptureColumns + `, content FROM node_log_captures
+		WHERE id = $1 AND node_id = $2`
+
+	var c models.NodeLogCapture
+	var receivedAt sql.NullTime
+	var content []byte
+	err := r.db.QueryRowContext(ctx, query, id, nodeID).Scan(
+		&c.ID, &c.NodeID, &c.RequestedBy, &c.RequestedAt, &receivedAt, &c.Size, &c.Truncated, &content,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get log capture: %w", err)
+	}
+	setLogCaptureStatus(&c, receivedAt)
+	return &c, content, nil
+}
+
+func scanNodeLogCapture(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.NodeLogCapture, error) {
+	var c models.NodeLogCapture
+	var receivedAt sql.NullTime
+	if err := row.Scan(&c.ID, &c.NodeID, &c.RequestedBy, &c.RequestedAt, &receivedAt, &c.Size, &c.Truncated); err != nil {
+		return nil, err
+	}
+	setLogCaptureStatus(&c, receivedAt)
+	return &c, nil
+}
+
+func setLogCaptureStatus(c *models.NodeLogCapture, receivedAt sql.NullTime) {
+	c.Status = models.LogCapturePending
+	if receivedAt.Valid {
+		t := receivedAt.Time
+		c.ReceivedAt = &t
+		c.Status = models.LogCaptureReceived
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// NodeRemediationRepository handles node_remediations database operations
+type NodeRemediationRepository struct {
+	db *DB
+}
+
+// NewNodeRemediationRepository creates a new NodeRemediationRepository
+func NewNodeRemediationRepository(db *DB) *NodeRemediationRepository {
+	return &NodeRemediationRepository{db: db}
+}
+
+// Create inserts a pending remediation
+func (r *NodeRemediationRepository) Create(ctx context.Context, rem *models.NodeRemediation) error {
+	query := `INSERT INTO node_remediations (node_id, action, requested_by, requested_at)
+		VALUES ($1, $2, $3, $4) RETURNING id`
+
+	rem.RequestedAt = time.Now()
+	rem.Status = models.RemediationPending
+	if err := r.db.QueryRowContext(ctx, query, rem.NodeID, rem.Action, rem.RequestedBy, rem.RequestedAt).Scan(&rem.ID); err != nil {
+		return fmt.Errorf("failed to create remediation: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a remediation. It is used when the request could not be
+// delivered to the agent.
+func (r *NodeRemediationRepository) Delete(ctx context.Context, id string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM node_remediations WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete remediation: %w", err)
+	}
+	return nil
+}
+
+// Complete stores the result of a pending remediation of the given action
+// belonging to nodeID. It reports false when no such pending remediation
+// exists.
+func (r *NodeRemediationRepository) Complete(ctx context.Context, id, nodeID, action string, success bool, exitCode int, output, errMsg string, completedAt time.Time) (bool, error) {
+	query := `UPDATE node_remediations
+		SET success = $4, exit_code = $5, output = $6, error = $7, completed_at = $8
+		WHERE id = $1 AND node_id = $2 AND action = $3 AND completed_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, id, nodeID, action, success, exitCode, output, errMsg, completedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to store remediation result: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to store remediation result: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ListByNode returns the remediations of a node, newest first
+func (r *NodeRemediationRepository) ListByNode(ctx context.Context, nodeID string) ([]*models.NodeRemediation, error) {
+	query := `SELECT id, node_id, action, requested_by, requested_at, completed_at,
+			success, exit_code, output, error
+		FROM node_remediations
+		WHERE node_id = $1 ORDER BY requested_at DESC`
+	rows, err := r.db.QueryContext(ctx, query, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remediations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var list []*models.NodeRemediation
+	for rows.Next() {
+		var rem models.NodeRemediation
+		var completedAt sql.NullTime
+		var success sql.NullBool
+		var exitCode sql.NullInt32
+		if err := rows.Scan(&rem.ID, &rem.NodeID, &rem.Action, &rem.RequestedBy, &rem.RequestedAt,
+			&completedAt, &success, &exitCode, &rem.Output, &rem.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan remediation: %w", err)
+		}
+		rem.Status = models.RemediationPending
+		if completedAt.Valid {
+			t := completedAt.Time
+			rem.CompletedAt = &t
+			rem.Status = models.RemediationFailed
+			if success.Valid && success.Bool {
+				rem.Status = models.RemediationSucceeded
+			}
+		}
+		if exitCode.Valid {
+			code := int(exitCode.Int32)
+			rem.ExitCode = &code
+		}
+		list = append(list, &rem)
+	}
+	return list, rows.Err()
+}
@@ -7,11 +7,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/VuteTech/Bor/server/internal/models"
+	"github.com/lib/pq"
 )
 
 // NodeRepository handles node database operations
@@ -28,7 +30,9 @@ func NewNodeRepository(db *DB) *NodeRepository {
 const nodeSelect = `
 	n.id, n.name, n.fqdn, n.machine_id, n.ip_address, n.os_name, n.os_version, n.desktop_env,
 	n.agent_version, n.status_cached, n.status_reason, n.groups, n.notes,
-	n.last_seen, n.created_at, n.updated_at, n.cert_serial, n.cert_not_after`
+	n.last_seen, n.created_at, n.updated_at, n.cert_serial, n.cert_not_after, n.campaign_id,
+	n.quarantined_at, n.quarantine_reason, n.session_users,
+	n.firefox_version, n.chrome_version, n.kernel_version, n.hardware`
 
 const nodeFrom = `FROM nodes n`
 
@@ -36,15 +40,27 @@ func scanNode(row interface {
 	Scan(dest ...interface{}) error
 }) (*models.Node, error) {
 	node := &models.Node{}
+	var hardware []byte
 	err := row.Scan(
 		&node.ID, &node.Name, &node.FQDN, &node.MachineID,
 		&node.IPAddress, &node.OSName, &node.OSVersion, &node.DesktopEnv,
 		&node.AgentVersion, &node.StatusCached, &node.StatusReason,
 		&node.Groups, &node.Notes,
 		&node.LastSeen, &node.CreatedAt, &node.UpdatedAt,
-		&node.CertSerial, &node.CertNotAfter,
+		&node.CertSerial, &node.CertNotAfter, &node.CampaignID,
+		&node.QuarantinedAt, &node.QuarantineReason, pq.Array(&node.SessionUsers),
+		&node.FirefoxVersion, &node.ChromeVersion, &node.KernelVersion, &hardware,
 	)
-	return node, err
+	if err != nil {
+		return nil, err
+	}
+	if hardware != nil {
+		if err := json.Unmarshal(hardware, &node.Hardware); err != nil {
+			return nil, fmt.Errorf("invalid hardware facts for node %s: %w", node.ID, err)
+		}
+		node.LowDisk = node.Hardware.LowDisk()
+	}
+	return node, nil
 }
 
 // populateGroups loads group memberships for a slice of nodes in one query.
@@ -94,8 +110,8 @@ func (r *NodeRepository) populateGroups(ctx context.Context, nodes []*models.Nod
 func (r *NodeRepository) Create(ctx context.Context, node *models.Node) error {
 	query := `
 		INSERT INTO nodes (name, fqdn, machine_id, ip_address, os_version, desktop_env,
-			agent_version, status_cached, status_reason, groups, notes, last_seen, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			agent_version, status_cached, status_reason, groups, notes, last_seen, created_at, updated_at, campaign_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id`
 
 	now := time.Now()
@@ -109,7 +125,7 @@ func (r *NodeRepository) Create(ctx context.Context, node *models.Node) error {
 		node.Name, node.FQDN, node.MachineID, node.IPAddress,
 		node.OSVersion, node.DesktopEnv, node.AgentVersion,
 		node.StatusCached, node.StatusReason, node.Groups, node.Notes,
-		node.LastSeen, node.CreatedAt, node.UpdatedAt,
+		node.LastSeen, node.CreatedAt, node.UpdatedAt, node.CampaignID,
 	).Scan(&node.ID)
 	if err != nil {
 		return fmt.Errorf("failed to create node: %w", err)
@@ -185,6 +201,62 @@ func (r *NodeRepository) ListAll(ctx context.Context) ([]*models.Node, error) {
 	return nodes, nil
 }
 
+// ListByGroupIDs returns the nodes that are members of any of the given
+// node groups, ordered by name.
+func (r *NodeRepository) ListByGroupIDs(ctx context.Context, groupIDs []string) ([]*models.Node, error) {
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	query := fmt.Sprintf(`SELECT %s %s
+		WHERE EXISTS (SELECT 1 FROM node_group_members ngm
+			WHERE ngm.node_id = n.id AND CAST(ngm.node_group_id AS TEXT) = ANY($1))
+		ORDER BY n.name`, nodeSelect, nodeFrom)
+	return r.listNodes(ctx, query, pq.Array(groupIDs))
+}
+
+// ListBySessionUserGroupIDs returns the nodes on which a member of any of
+// the given user groups has a login session, ordered by name.
+func (r *NodeRepository) ListBySessionUserGroupIDs(ctx context.Context, userGroupIDs []string) ([]*models.Node, error) {
+	if len(userGroupIDs) == 0 {
+		return nil, nil
+	}
+	query := fmt.Sprintf(`SELECT %s %s
+		WHERE n.session_users && ARRAY(
+			SELECT u.username FROM user_group_members ugm
+			JOIN users u ON u.id = ugm.user_id
+			WHERE CAST(ugm.group_id AS TEXT) = ANY($1))
+		ORDER BY n.name`, nodeSelect, nodeFrom)
+	return r.listNodes(ctx, query, pq.Array(userGroupIDs))
+}
+
+// listNodes runs a node query selecting nodeSelect and returns the nodes
+// with their groups populated.
+func (r *NodeRepository) listNodes(ctx context.Context, query string, args ...interface{}) ([]*models.Node, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var nodes []*models.Node
+	for rows.Next() {
+		node, err := scanNode(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan node: %w", err)
+		}
+		nodes = append(nodes, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.populateGroups(ctx, nodes); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
 // ListByStatus returns nodes with a given status
 func (r *NodeRepository) ListByStatus(ctx context.Context, status string) ([]*models.Node, error) {
 	query := fmt.Sprintf(`SELECT %s %s WHERE n.status_cached = $1 ORDER BY n.last_seen DESC NULLS LAST`, nodeSelect, nodeFrom)
@@ -246,6 +318,90 @@ func (r *NodeRepository) Search(ctx context.Context, term string) ([]*models.Nod
 	return nodes, nil
 }
 
+// ListPaginated returns one page of the nodes matching req, ordered by
+// last_seen descending, and the total number of matching nodes. Page and
+// PerPage must be positive.
+func (r *NodeRepository) ListPaginated(ctx context.Context, req *models.NodeListRequest) ([]*models.Node, int, error) {
+	where, args := buildNodeListFilter(req)
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) %s %s`, nodeFrom, where)
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count nodes: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s %s %s ORDER BY n.last_seen DESC NULLS LAST, n.name LIMIT $%d OFFSET $%d`,
+		nodeSelect, nodeFrom, where, len(args)+1, len(args)+2)
+	args = append(args, req.PerPage, (req.Page-1)*req.PerPage)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var nodes []*models.Node
+	for rows.Next() {
+		node, err := scanNode(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan node: %w", err)
+		}
+		nodes = append(nodes, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.populateGroups(ctx, nodes); err != nil {
+		return nil, 0, err
+	}
+
+	return nodes, total, nil
+}
+
+// buildNodeListFilter composes the WHERE clause for ListPaginated from the
+// non-empty filters of req.
+func buildNodeListFilter(req *models.NodeListRequest) (clause string, args []interface{}) {
+	var conditions []string
+	argIdx := 1
+
+	if req.Search != "" {
+		conditions = append(conditions, fmt.Sprintf(
+			"(n.name ILIKE $%[1]d OR n.fqdn ILIKE $%[1]d OR n.ip_address ILIKE $%[1]d OR n.groups ILIKE $%[1]d)", argIdx))
+		args = append(args, "%"+req.Search+"%")
+		argIdx++
+	}
+	if req.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("n.status_cached = $%d", argIdx))
+		args = append(args, req.Status)
+		argIdx++
+	}
+	if req.OSName != "" {
+		conditions = append(conditions, fmt.Sprintf("LOWER(n.os_name) = LOWER($%d)", argIdx))
+		args = append(args, req.OSName)
+		argIdx++
+	}
+	if req.DesktopEnv != "" {
+		// desktop_env holds the comma-separated list reported by heartbeats.
+		conditions = append(conditions, fmt.Sprintf(
+			"LOWER($%d) = ANY(string_to_array(LOWER(REPLACE(n.desktop_env, ' ', '')), ','))", argIdx))
+		args = append(args, strings.ReplaceAll(req.DesktopEnv, " ", ""))
+		argIdx++
+	}
+	if req.GroupID != "" {
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM node_group_members ngm WHERE ngm.node_id = n.id AND CAST(ngm.node_group_id AS TEXT) = $%d)", argIdx))
+		args = append(args, req.GroupID)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return where, args
+}
+
 // Update updates an existing node
 func (r *NodeRepository) Update(ctx context.Context, node *models.Node) error {
 	setClauses := []string{}
@@ -343,9 +499,89 @@ func (r *NodeRepository) UpdateStatus(ctx context.Context, id, status, reason st
 	return nil
 }
 
-// UpdateHeartbeat updates the last_seen timestamp and optionally facts
+// UpdateSessionUsers replaces the users recorded as logged in on a node.
+func (r *NodeRepository) UpdateSessionUsers(ctx context.Context, id string, users []string) error {
+	if users == nil {
+		users = []string{}
+	}
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE nodes SET session_users = $1, updated_at = $2 WHERE id = $3`,
+		pq.Array(users), time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update node session users: %w", err)
+	}
+	return nil
+}
+
+// NodeStatusTransition is a status change made by MarkStale.
+type NodeStatusTransition struct {
+	NodeID string
+	Name   string
+	From   string
+	To     string
+}
+
+// MarkStale sets the status of every node whose status is one of from and
+// whose last_seen is before cutoff to status, and returns the transitions.
+func (r *NodeRepository) MarkStale(ctx context.Context, from []string, status, reason string, cutoff time.Time) ([]NodeStatusTransition, error) {
+	query := `WITH stale AS (
+			SELECT id, status_cached FROM nodes
+			WHERE status_cached = ANY($1) AND last_seen < $2
+			FOR UPDATE
+		)
+		UPDATE nodes AS n SET status_cached = $3, status_reason = $4, updated_at = $5
+		FROM stale WHERE n.id = stale.id
+		RETURNING n.id, n.name, stale.status_cached`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(from), cutoff, status, reason, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark stale nodes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var transitions []NodeStatusTransition
+	for rows.Next() {
+		t := NodeStatusTransition{To: status}
+		if err := rows.Scan(&t.NodeID, &t.Name, &t.From); err != nil {
+			return nil, fmt.Errorf("failed to scan stale node: %w", err)
+		}
+		transitions = append(transitions, t)
+	}
+	return transitions, rows.Err()
+}
+
+// SetQuarantine quarantines the node with reason, or releases it from
+// quarantine when quarantined is false.
+func (r *NodeRepository) SetQuarantine(ctx context.Context, id string, quarantined bool, reason string) error {
+	query := `UPDATE nodes SET quarantined_at = NULL, quarantine_reason = NULL, updated_at = $1 WHERE id = $2`
+	args := []interface{}{time.Now(), id}
+	if quarantined {
+		query = `UPDATE nodes SET quarantined_at = $1, quarantine_reason = NULLIF($3, ''), updated_at = $1 WHERE id = $2`
+		args = append(args, reason)
+	}
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update node quarantine: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("node not found")
+	}
+	return nil
+}
+
+// degradedRecovery brings a degraded node back online when it is heard
+// from again.
+const degradedRecovery = `status_cached = CASE WHEN status_cached = 'degraded' THEN 'online' ELSE status_cached END, ` +
+	`status_reason = CASE WHEN status_cached = 'degraded' THEN '' ELSE status_reason END`
+
+// UpdateHeartbeat updates the last_seen timestamp and optionally facts. A
+// degraded node is marked online again.
 func (r *NodeRepository) UpdateHeartbeat(ctx context.Context, id string, facts map[string]string) error {
-	setClauses := []string{"last_seen = $1", "updated_at = $1"}
+	setClauses := []string{"last_seen = $1", "updated_at = $1", degradedRecovery}
 	args := []interface{}{time.Now()}
 	argIdx := 2
 
@@ -384,6 +620,13 @@ func (r *NodeRepository) UpdateHeartbeat(ctx context.Context, id string, facts m
 		args = append(args, v)
 		argIdx++
 	}
+	for _, col := range []string{"firefox_version", "chrome_version", "kernel_version", "hardware"} {
+		if v, ok := facts[col]; ok {
+			setClauses = append(setClauses, fmt.Sprintf("%s = $%d", col, argIdx))
+			args = append(args, v)
+			argIdx++
+		}
+	}
 
 	args = append(args, id)
 	query := fmt.Sprintf("UPDATE nodes SET %s WHERE id = $%d",
@@ -397,6 +640,31 @@ func (r *NodeRepository) UpdateHeartbeat(ctx context.Context, id string, facts m
 	return nil
 }
 
+// UpdateLastSeen sets last_seen for many nodes in a single statement. A
+// node's last_seen never moves backwards, and degraded nodes are marked
+// online again.
+func (r *NodeRepository) UpdateLastSeen(ctx context.Context, seen map[string]time.Time) error {
+	if len(seen) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(seen))
+	times := make([]string, 0, len(seen))
+	for id, t := range seen {
+		ids = append(ids, id)
+		// last_seen is a TIMESTAMP column holding local wall-clock time,
+		// as written by UpdateHeartbeat.
+		times = append(times, t.Format("2006-01-02 15:04:05.999999"))
+	}
+
+	query := `UPDATE nodes AS n SET last_seen = v.seen, updated_at = v.seen, ` + degradedRecovery + `
+		FROM unnest($1::uuid[], $2::timestamp[]) AS v(id, seen)
+		WHERE n.id = v.id AND (n.last_seen IS NULL OR n.last_seen < v.seen)`
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(ids), pq.Array(times)); err != nil {
+		return fmt.Errorf("failed to update last seen: %w", err)
+	}
+	return nil
+}
+
 // CountByStatus returns the count of nodes per status
 func (r *NodeRepository) CountByStatus(ctx context.Context) (map[string]int, error) {
 	query := `SELECT status_cached, COUNT(*) FROM nodes GROUP BY status_cached`
@@ -448,6 +716,31 @@ func (r *NodeRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// DeleteAndRevoke deletes nodes and revokes their current certificates in a
+// single transaction. The revocations outlive the deleted nodes.
+func (r *NodeRepository) DeleteAndRevoke(ctx context.Context, nodes []*models.Node, reason string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, n := range nodes {
+		if n.CertSerial != nil && *n.CertSerial != "" {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO revoked_certificates (node_id, serial, reason) VALUES ($1, $2, $3)`,
+				n.ID, *n.CertSerial, reason); err != nil {
+				return fmt.Errorf("failed to revoke certificate of node %s: %w", n.ID, err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM nodes WHERE id = $1", n.ID); err != nil {
+			return fmt.Errorf("failed to delete node %s: %w", n.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
 // AddToGroup adds a node to a node group (no-op if already a member).
 func (r *NodeRepository) AddToGroup(ctx context.Context, nodeID, groupID string) error {
 	_, err := r.db.ExecContext(ctx,
@@ -470,6 +763,41 @@ func (r *NodeRepository) RemoveFromGroup(ctx context.Context, nodeID, groupID st
 	return nil
 }
 
+// AddManyToGroup adds nodes to a node group in a single statement and
+// returns how many were added. Nodes that are already members or do not
+// exist are skipped.
+func (r *NodeRepository) AddManyToGroup(ctx context.Context, groupID string, nodeIDs []string) (int, error) {
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO node_group_members (node_id, node_group_id)
+		SELECT n.id, $1 FROM nodes n WHERE CAST(n.id AS TEXT) = ANY($2)
+		ON CONFLICT DO NOTHING`,
+		groupID, pq.Array(nodeIDs))
+	if err != nil {
+		return 0, fmt.Errorf("failed to add nodes to group: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// RemoveManyFromGroup removes nodes from a node group in a single statement
+// and returns how many were removed.
+func (r *NodeRepository) RemoveManyFromGroup(ctx context.Context, groupID string, nodeIDs []string) (int, error) {
+	res, err := r.db.ExecContext(ctx,
+		`DELETE FROM node_group_members WHERE node_group_id = $1 AND CAST(node_id AS TEXT) = ANY($2)`,
+		groupID, pq.Array(nodeIDs))
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove nodes from group: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
 // UpdateCertificate persists the serial hex and notAfter time for a node's mTLS certificate.
 func (r *NodeRepository) UpdateCertificate(ctx context.Context, nodeID, serial string, notAfter time.Time) error {
 	_, err := r.db.ExecContext(ctx,
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func TestBuildNodeListFilter(t *testing.T) {
+	where, args := buildNodeListFilter(&models.NodeListRequest{})
+	if where != "" || len(args) != 0 {
+		t.Errorf("no filters: where = %q, args = %v", where, args)
+	}
+
+	where, args = buildNodeListFilter(&models.NodeListRequest{
+		Status:     "online",
+		OSName:     "Fedora",
+		DesktopEnv: "KDE Plasma",
+		GroupID:    "g1",
+	})
+	for _, want := range []string{
+		"n.status_cached = $1",
+		"LOWER(n.os_name) = LOWER($2)",
+		"LOWER($3) = ANY(",
+		"CAST(ngm.node_group_id AS TEXT) = $4",
+	} {
+		if !strings.Contains(where, want) {
+			t.Errorf("where = %q, want it to contain %q", where, want)
+		}
+	}
+	if strings.Count(where, " AND ") != 4 { // three joins plus the one inside EXISTS
+		t.Errorf("where = %q, want all filters combined with AND", where)
+	}
+	if len(args) != 4 || args[2] != "KDEPlasma" {
+		t.Errorf("args = %v", args)
+	}
+
+	where, args = buildNodeListFilter(&models.NodeListRequest{Search: "lab", GroupID: "g1"})
+	if !strings.Contains(where, "n.name ILIKE $1 OR") || !strings.Contains(where, "= $2)") || len(args) != 2 || args[0] != "%lab%" {
+		t.Errorf("search filter: where = %q, args = %v", where, args)
+	}
+}
@@ -7,9 +7,12 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/VuteTech/Bor/server/internal/models"
 )
 
@@ -26,8 +29,11 @@ func NewPolicyRepository(db *DB) *PolicyRepository {
 // Create inserts a new policy into the database
 func (r *PolicyRepository) Create(ctx context.Context, policy *models.Policy) error {
 	query := `
-		INSERT INTO policies (name, description, type, content, version, status, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO policies (name, description, type, content, version, status,
+		                      contact_loss_action, contact_loss_ttl_seconds,
+		                      check_command, check_args, check_timeout_seconds,
+		                      preconditions, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id`
 
 	now := time.Now()
@@ -37,10 +43,23 @@ func (r *PolicyRepository) Create(ctx context.Context, policy *models.Policy) er
 	if policy.State == "" {
 		policy.State = models.PolicyStateDraft
 	}
+	if policy.ContactLossAction == "" {
+		policy.ContactLossAction = models.ContactLossKeep
+	}
+	if policy.CheckArgs == nil {
+		policy.CheckArgs = []string{}
+	}
+	preconditions, err := encodePreconditions(policy.Preconditions)
+	if err != nil {
+		return err
+	}
 
-	err := r.db.QueryRowContext(ctx, query,
+	err = r.db.QueryRowContext(ctx, query,
 		policy.Name, policy.Description, policy.Type, policy.Content,
-		policy.Version, policy.State, policy.CreatedBy,
+		policy.Version, policy.State,
+		policy.ContactLossAction, policy.ContactLossTTLSeconds,
+		policy.CheckCommand, pq.Array(policy.CheckArgs), policy.CheckTimeoutSeconds,
+		preconditions, policy.CreatedBy,
 		policy.CreatedAt, policy.UpdatedAt,
 	).Scan(&policy.ID)
 	if err != nil {
@@ -53,7 +72,7 @@ func (r *PolicyRepository) Create(ctx context.Context, policy *models.Policy) er
 // GetByName retrieves a policy by name
 func (r *PolicyRepository) GetByName(ctx context.Context, name string) (*models.Policy, error) {
 	query := `
-		SELECT id, name, description, type, content, version, status, deprecated_at, deprecation_message, replacement_policy_id, created_by, created_at, updated_at
+		SELECT id, name, description, type, content, version, status, deprecated_at, deprecation_message, replacement_policy_id, contact_loss_action, contact_loss_ttl_seconds, check_command, check_args, check_timeout_seconds, preconditions, created_by, created_at, updated_at
 		FROM policies WHERE name = $1`
 
 	policy := &models.Policy{}
@@ -61,6 +80,8 @@ func (r *PolicyRepository) GetByName(ctx context.Context, name string) (*models.
 		&policy.ID, &policy.Name, &policy.Description, &policy.Type,
 		&policy.Content, &policy.Version, &policy.State,
 		&policy.DeprecatedAt, &policy.DeprecationMessage, &policy.ReplacementPolicyID,
+		&policy.ContactLossAction, &policy.ContactLossTTLSeconds,
+		&policy.CheckCommand, pq.Array(&policy.CheckArgs), &policy.CheckTimeoutSeconds, jsonColumn{&policy.Preconditions},
 		&policy.CreatedBy, &policy.CreatedAt, &policy.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -76,7 +97,7 @@ func (r *PolicyRepository) GetByName(ctx context.Context, name string) (*models.
 // GetByID retrieves a policy by ID
 func (r *PolicyRepository) GetByID(ctx context.Context, id string) (*models.Policy, error) {
 	query := `
-		SELECT id, name, description, type, content, version, status, deprecated_at, deprecation_message, replacement_policy_id, created_by, created_at, updated_at
+		SELECT id, name, description, type, content, version, status, deprecated_at, deprecation_message, replacement_policy_id, contact_loss_action, contact_loss_ttl_seconds, check_command, check_args, check_timeout_seconds, preconditions, created_by, created_at, updated_at
 		FROM policies WHERE id = $1`
 
 	policy := &models.Policy{}
@@ -84,6 +105,8 @@ func (r *PolicyRepository) GetByID(ctx context.Context, id string) (*models.Poli
 		&policy.ID, &policy.Name, &policy.Description, &policy.Type,
 		&policy.Content, &policy.Version, &policy.State,
 		&policy.DeprecatedAt, &policy.DeprecationMessage, &policy.ReplacementPolicyID,
+		&policy.ContactLossAction, &policy.ContactLossTTLSeconds,
+		&policy.CheckCommand, pq.Array(&policy.CheckArgs), &policy.CheckTimeoutSeconds, jsonColumn{&policy.Preconditions},
 		&policy.CreatedBy, &policy.CreatedAt, &policy.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -99,7 +122,7 @@ func (r *PolicyRepository) GetByID(ctx context.Context, id string) (*models.Poli
 // ListEnabled returns all released policies (for agent consumption)
 func (r *PolicyRepository) ListEnabled(ctx context.Context) ([]*models.Policy, error) {
 	query := `
-		SELECT id, name, description, type, content, version, status, deprecated_at, deprecation_message, replacement_policy_id, created_by, created_at, updated_at
+		SELECT id, name, description, type, content, version, status, deprecated_at, deprecation_message, replacement_policy_id, contact_loss_action, contact_loss_ttl_seconds, check_command, check_args, check_timeout_seconds, preconditions, created_by, created_at, updated_at
 		FROM policies WHERE status = 'released' ORDER BY name`
 
 	return r.scanPolicies(ctx, query)
@@ -108,12 +131,31 @@ func (r *PolicyRepository) ListEnabled(ctx context.Context) ([]*models.Policy, e
 // ListAll returns all policies regardless of state
 func (r *PolicyRepository) ListAll(ctx context.Context) ([]*models.Policy, error) {
 	query := `
-		SELECT id, name, description, type, content, version, status, deprecated_at, deprecation_message, replacement_policy_id, created_by, created_at, updated_at
+		SELECT id, name, description, type, content, version, status, deprecated_at, deprecation_message, replacement_policy_id, contact_loss_action, contact_loss_ttl_seconds, check_command, check_args, check_timeout_seconds, preconditions, created_by, created_at, updated_at
 		FROM policies ORDER BY updated_at DESC`
 
 	return r.scanPolicies(ctx, query)
 }
 
+// ListPaginated returns one page of all policies, ordered like ListAll, and the
+// total number of policies.
+func (r *PolicyRepository) ListPaginated(ctx context.Context, p models.Pagination) ([]*models.Policy, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM policies`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count policies: %w", err)
+	}
+
+	query := `
+		SELECT id, name, description, type, content, version, status, deprecated_at, deprecation_message, replacement_policy_id, contact_loss_action, contact_loss_ttl_seconds, check_command, check_args, check_timeout_seconds, preconditions, created_by, created_at, updated_at
+		FROM policies ORDER BY updated_at DESC, id LIMIT $1 OFFSET $2`
+
+	policies, err := r.scanPolicies(ctx, query, p.PerPage, p.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	return policies, total, nil
+}
+
 // scanPolicies is a helper to scan multiple policies from a query
 func (r *PolicyRepository) scanPolicies(ctx context.Context, query string, args ...interface{}) ([]*models.Policy, error) {
 	rows, err := r.db.QueryContext(ctx, query, args...)
@@ -129,6 +171,8 @@ func (r *PolicyRepository) scanPolicies(ctx context.Context, query string, args
 			&policy.ID, &policy.Name, &policy.Description, &policy.Type,
 			&policy.Content, &policy.Version, &policy.State,
 			&policy.DeprecatedAt, &policy.DeprecationMessage, &policy.ReplacementPolicyID,
+			&policy.ContactLossAction, &policy.ContactLossTTLSeconds,
+			&policy.CheckCommand, pq.Array(&policy.CheckArgs), &policy.CheckTimeoutSeconds, jsonColumn{&policy.Preconditions},
 			&policy.CreatedBy, &policy.CreatedAt, &policy.UpdatedAt,
 		)
 		if err != nil {
@@ -145,15 +189,26 @@ func (r *PolicyRepository) Update(ctx context.Context, policy *models.Policy) er
 	query := `
 		UPDATE policies
 		SET name = $1, description = $2, type = $3, content = $4,
-		    version = version + 1, updated_at = $5
-		WHERE id = $6
+		    contact_loss_action = $5, contact_loss_ttl_seconds = $6,
+		    check_command = $7, check_args = $8, check_timeout_seconds = $9,
+		    preconditions = $10, version = version + 1, updated_at = $11
+		WHERE id = $12
 		RETURNING version`
 
 	policy.UpdatedAt = time.Now()
+	if policy.CheckArgs == nil {
+		policy.CheckArgs = []string{}
+	}
+	preconditions, err := encodePreconditions(policy.Preconditions)
+	if err != nil {
+		return err
+	}
 
-	err := r.db.QueryRowContext(ctx, query,
+	err = r.db.QueryRowContext(ctx, query,
 		policy.Name, policy.Description, policy.Type, policy.Content,
-		policy.UpdatedAt, policy.ID,
+		policy.ContactLossAction, policy.ContactLossTTLSeconds,
+		policy.CheckCommand, pq.Array(policy.CheckArgs), policy.CheckTimeoutSeconds,
+		preconditions, policy.UpdatedAt, policy.ID,
 	).Scan(&policy.Version)
 	if err != nil {
 		return fmt.Errorf("failed to update policy: %w", err)
@@ -239,3 +294,35 @@ func (r *PolicyRepository) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// encodePreconditions encodes a policy's preconditions for the JSONB
+// preconditions column.
+func encodePreconditions(preconditions []models.PolicyPrecondition) ([]byte, error) {
+	if preconditions == nil {
+		preconditions = []models.PolicyPrecondition{}
+	}
+	b, err := json.Marshal(preconditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode policy preconditions: %w", err)
+	}
+	return b, nil
+}
+
+// jsonColumn scans a JSON or JSONB column into dst. NULL leaves dst as is.
+type jsonColumn struct {
+	dst interface{}
+}
+
+// Scan implements sql.Scanner.
+func (c jsonColumn) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c.dst)
+	case string:
+		return json.Unmarshal([]byte(v), c.dst)
+	default:
+		return fmt.Errorf("cannot scan %T into a JSON column", src)
+	}
+}
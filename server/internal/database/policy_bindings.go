@@ -11,6 +11,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/VuteTech/Bor/server/internal/models"
 )
 
@@ -26,8 +28,9 @@ func NewPolicyBindingRepository(db *DB) *PolicyBindingRepository {
 
 // Create inserts a new policy binding
 func (r *PolicyBindingRepository) Create(ctx context.Context, b *models.PolicyBinding) error {
-	query := `INSERT INTO policy_bindings (policy_id, group_id, state, priority, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	query := `INSERT INTO policy_bindings (policy_id, group_id, filter_id, user_group_id, state, priority,
+			active_from, active_to, schedule, schedule_timezone, in_window, created_at, updated_at)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
 
 	now := time.Now()
 	b.CreatedAt = now
@@ -37,40 +40,189 @@ func (r *PolicyBindingRepository) Create(ctx context.Context, b *models.PolicyBi
 		b.State = models.BindingStateDisabled
 	}
 
-	err := r.db.QueryRowContext(ctx, query, b.PolicyID, b.GroupID, b.State, b.Priority, b.CreatedAt, b.UpdatedAt).Scan(&b.ID)
+	var w windowColumns
+	w.set(b.Window)
+	err := r.db.QueryRowContext(ctx, query, b.PolicyID, b.GroupID, b.FilterID, b.UserGroupID, b.State, b.Priority,
+		w.from, w.to, w.schedule, w.timezone, b.InWindow, b.CreatedAt, b.UpdatedAt).Scan(&b.ID)
 	if err != nil {
 		return fmt.Errorf("failed to create policy binding: %w", err)
 	}
+	setScopeKind(b)
 	return nil
 }
 
+// setScopeKind sets b.ScopeKind from the target b has.
+func setScopeKind(b *models.PolicyBinding) {
+	switch {
+	case b.UserGroupID != nil:
+		b.ScopeKind = models.BindingScopeUserGroup
+	case b.FilterID != nil:
+		b.ScopeKind = models.BindingScopeNodeFilter
+	default:
+		b.ScopeKind = models.BindingScopeNodeGroup
+	}
+}
+
+// windowColumns holds the activity window columns of a binding row.
+type windowColumns struct {
+	from, to           sql.NullTime
+	schedule, timezone string
+}
+
+func (c *windowColumns) dest() []interface{} {
+	return []interface{}{&c.from, &c.to, &c.schedule, &c.timezone}
+}
+
+func (c *windowColumns) set(w *models.BindingWindow) {
+	*c = windowColumns{}
+	if w == nil {
+		return
+	}
+	if w.ActiveFrom != nil {
+		c.from = sql.NullTime{Time: *w.ActiveFrom, Valid: true}
+	}
+	if w.ActiveTo != nil {
+		c.to = sql.NullTime{Time: *w.ActiveTo, Valid: true}
+	}
+	c.schedule, c.timezone = w.Schedule, w.Timezone
+}
+
+// window returns the window the columns describe, or nil for none.
+func (c *windowColumns) window() *models.BindingWindow {
+	if !c.from.Valid && !c.to.Valid && c.schedule == "" {
+		return nil
+	}
+	w := &models.BindingWindow{Schedule: c.schedule, Timezone: c.timezone}
+	if c.from.Valid {
+		w.ActiveFrom = &c.from.Time
+	}
+	if c.to.Valid {
+		w.ActiveTo = &c.to.Time
+	}
+	return w
+}
+
+// bindingColumns is the column list of policy_bindings SELECT queries that
+// scan into a models.PolicyBinding with scanBindingDest.
+const bindingColumns = `pb.id, pb.policy_id, COALESCE(pb.group_id::text, ''), pb.filter_id, pb.user_group_id, pb.state, pb.priority,
+			pb.active_from, pb.active_to, pb.schedule, pb.schedule_timezone, pb.in_window,
+			pb.profile_managed, pb.created_at, pb.updated_at`
+
+// scanBindingDest returns the scan destinations for bindingColumns.
+func scanBindingDest(b *models.PolicyBinding, w *windowColumns) []interface{} {
+	dest := []interface{}{&b.ID, &b.PolicyID, &b.GroupID, &b.FilterID, &b.UserGroupID, &b.State, &b.Priority}
+	dest = append(dest, w.dest()...)
+	return append(dest, &b.InWindow, &b.ProfileManaged, &b.CreatedAt, &b.UpdatedAt)
+}
+
 // GetByID retrieves a policy binding by ID
 func (r *PolicyBindingRepository) GetByID(ctx context.Context, id string) (*models.PolicyBinding, error) {
-	query := `SELECT id, policy_id, group_id, state, priority, created_at, updated_at
-		FROM policy_bindings WHERE id = $1`
+	query := `SELECT ` + bindingColumns + ` FROM policy_bindings pb WHERE pb.id = $1`
 	b := &models.PolicyBinding{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&b.ID, &b.PolicyID, &b.GroupID, &b.State, &b.Priority, &b.CreatedAt, &b.UpdatedAt)
+	var w windowColumns
+	err := r.db.QueryRowContext(ctx, query, id).Scan(scanBindingDest(b, &w)...)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get policy binding: %w", err)
 	}
+	b.Window = w.window()
+	setScopeKind(b)
 	return b, nil
 }
 
-// ListAll returns all policy bindings with related policy and group details
+// ListWindowed returns all bindings that have an activity window.
+func (r *PolicyBindingRepository) ListWindowed(ctx context.Context) ([]*models.PolicyBinding, error) {
+	return r.scanBindings(ctx, `SELECT `+bindingColumns+` FROM policy_bindings pb
+		WHERE pb.active_from IS NOT NULL OR pb.active_to IS NOT NULL OR pb.schedule <> ''`)
+}
+
+// ListEnabledByPolicyID returns the enabled bindings of a policy, whether
+// or not they are in their activity window.
+func (r *PolicyBindingRepository) ListEnabledByPolicyID(ctx context.Context, policyID string) ([]*models.PolicyBinding, error) {
+	return r.scanBindings(ctx, `SELECT `+bindingColumns+` FROM policy_bindings pb
+		WHERE pb.policy_id = $1 AND pb.state = 'enabled'
+		ORDER BY pb.priority DESC, pb.id`, policyID)
+}
+
+func (r *PolicyBindingRepository) scanBindings(ctx context.Context, query string, args ...interface{}) ([]*models.PolicyBinding, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policy bindings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var bindings []*models.PolicyBinding
+	for rows.Next() {
+		b := &models.PolicyBinding{}
+		var w windowColumns
+		if err := rows.Scan(scanBindingDest(b, &w)...); err != nil {
+			return nil, fmt.Errorf("failed to scan policy binding: %w", err)
+		}
+		b.Window = w.window()
+		setScopeKind(b)
+		bindings = append(bindings, b)
+	}
+	return bindings, rows.Err()
+}
+
+// SetInWindow records whether a binding is in its activity window.
+func (r *PolicyBindingRepository) SetInWindow(ctx context.Context, id string, inWindow bool) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE policy_bindings SET in_window = $1 WHERE id = $2 AND in_window <> $1", inWindow, id)
+	if err != nil {
+		return fmt.Errorf("failed to update binding window state: %w", err)
+	}
+	return nil
+}
+
+// ListAll returns all policy bindings with related policy and group (or
+// filter, or user group) details. NodeCount is only filled in for group
+// bindings; filter membership is evaluated by the service layer, and user
+// group bindings are not tied to nodes.
 func (r *PolicyBindingRepository) ListAll(ctx context.Context) ([]*models.PolicyBindingWithDetails, error) {
-	query := `SELECT pb.id, pb.policy_id, pb.group_id, pb.state, pb.priority, pb.created_at, pb.updated_at,
+	return r.scanBindingsWithDetails(ctx, bindingDetailsQuery+` ORDER BY pb.priority DESC, p.name, pb.id`)
+}
+
+// ListPaginated returns one page of all policy bindings, ordered and detailed
+// like ListAll, and the total number of bindings.
+func (r *PolicyBindingRepository) ListPaginated(ctx context.Context, p models.Pagination) ([]*models.PolicyBindingWithDetails, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM policy_bindings`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count policy bindings: %w", err)
+	}
+
+	bindings, err := r.scanBindingsWithDetails(ctx,
+		bindingDetailsQuery+` ORDER BY pb.priority DESC, p.name, pb.id LIMIT $1 OFFSET $2`, p.PerPage, p.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	return bindings, total, nil
+}
+
+// ListByGroupID returns the bindings targeting a node group, ordered and
+// detailed like ListAll.
+func (r *PolicyBindingRepository) ListByGroupID(ctx context.Context, groupID string) ([]*models.PolicyBindingWithDetails, error) {
+	return r.scanBindingsWithDetails(ctx,
+		bindingDetailsQuery+` WHERE pb.group_id = $1 ORDER BY pb.priority DESC, p.name, pb.id`, groupID)
+}
+
+// bindingDetailsQuery selects bindings with the details of ListAll.
+const bindingDetailsQuery = `SELECT ` + bindingColumns + `,
 			p.name AS policy_name, p.status AS policy_state,
-			ng.name AS group_name,
-			(SELECT COUNT(*) FROM node_group_members ngm WHERE ngm.node_group_id = ng.id) AS node_count
+			COALESCE(ng.name, '') AS group_name,
+			COALESCE(nf.name, '') AS filter_name,
+			COALESCE(ug.name, '') AS user_group_name,
+			(SELECT COUNT(*) FROM node_group_members ngm WHERE ngm.node_group_id = pb.group_id) AS node_count
 		FROM policy_bindings pb
 		JOIN policies p ON p.id = pb.policy_id
-		JOIN node_groups ng ON ng.id = pb.group_id
-		ORDER BY pb.priority DESC, p.name, pb.id`
+		LEFT JOIN node_groups ng ON ng.id = pb.group_id
+		LEFT JOIN node_filters nf ON nf.id = pb.filter_id
+		LEFT JOIN user_groups ug ON ug.id = pb.user_group_id`
 
-	rows, err := r.db.QueryContext(ctx, query)
+func (r *PolicyBindingRepository) scanBindingsWithDetails(ctx context.Context, query string, args ...interface{}) ([]*models.PolicyBindingWithDetails, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list policy bindings: %w", err)
 	}
@@ -79,10 +231,14 @@ func (r *PolicyBindingRepository) ListAll(ctx context.Context) ([]*models.Policy
 	var bindings []*models.PolicyBindingWithDetails
 	for rows.Next() {
 		b := &models.PolicyBindingWithDetails{}
-		if err := rows.Scan(&b.ID, &b.PolicyID, &b.GroupID, &b.State, &b.Priority,
-			&b.CreatedAt, &b.UpdatedAt, &b.PolicyName, &b.PolicyState, &b.GroupName, &b.NodeCount); err != nil {
+		var w windowColumns
+		dest := append(scanBindingDest(&b.PolicyBinding, &w),
+			&b.PolicyName, &b.PolicyState, &b.GroupName, &b.FilterName, &b.UserGroupName, &b.NodeCount)
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("failed to scan policy binding: %w", err)
 		}
+		b.Window = w.window()
+		setScopeKind(&b.PolicyBinding)
 		bindings = append(bindings, b)
 	}
 	return bindings, rows.Err()
@@ -104,6 +260,15 @@ func (r *PolicyBindingRepository) Update(ctx context.Context, id string, req *mo
 		args = append(args, *req.Priority)
 		argIdx++
 	}
+	if req.Window != nil {
+		var w windowColumns
+		w.set(req.Window)
+		for _, col := range []string{"active_from", "active_to", "schedule", "schedule_timezone"} {
+			setClauses = append(setClauses, fmt.Sprintf("%s = $%d", col, argIdx))
+			argIdx++
+		}
+		args = append(args, w.from, w.to, w.schedule, w.timezone)
+	}
 
 	if len(setClauses) == 0 {
 		return nil
@@ -144,7 +309,7 @@ func (r *PolicyBindingRepository) Delete(ctx context.Context, id string) error {
 // the given policy, regardless of the policy's current state.
 func (r *PolicyBindingRepository) GetEnabledGroupIDsByPolicyID(ctx context.Context, policyID string) ([]string, error) {
 	rows, err := r.db.QueryContext(ctx,
-		"SELECT group_id FROM policy_bindings WHERE policy_id = $1 AND state = 'enabled'", policyID)
+		"SELECT group_id FROM policy_bindings WHERE policy_id = $1 AND state = 'enabled' AND group_id IS NOT NULL", policyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get enabled group IDs: %w", err)
 	}
@@ -161,6 +326,19 @@ func (r *PolicyBindingRepository) GetEnabledGroupIDsByPolicyID(ctx context.Conte
 	return ids, rows.Err()
 }
 
+// CountEnabledDynamicBindingsByPolicyID returns the number of enabled
+// bindings that target the given policy at a saved node filter or a user
+// group, i.e. not at fixed node groups.
+func (r *PolicyBindingRepository) CountEnabledDynamicBindingsByPolicyID(ctx context.Context, policyID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM policy_bindings WHERE policy_id = $1 AND state = 'enabled' AND group_id IS NULL", policyID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count dynamic bindings: %w", err)
+	}
+	return count, nil
+}
+
 // CountEnabledByPolicyID returns the count of enabled bindings for a given policy
 func (r *PolicyBindingRepository) CountEnabledByPolicyID(ctx context.Context, policyID string) (int, error) {
 	var count int
@@ -171,15 +349,19 @@ func (r *PolicyBindingRepository) CountEnabledByPolicyID(ctx context.Context, po
 	return count, nil
 }
 
-// ListPoliciesByGroupID returns released policies with enabled bindings for a given node group
+// ListPoliciesByGroupID returns released policies with enabled bindings for
+// a given node group that are in their activity window.
 func (r *PolicyBindingRepository) ListPoliciesByGroupID(ctx context.Context, groupID string) ([]*models.Policy, error) {
 	query := `SELECT p.id, p.name, p.description, p.type, p.content, p.version, p.status,
 			p.deprecated_at, p.deprecation_message, p.replacement_policy_id,
+			p.contact_loss_action, p.contact_loss_ttl_seconds,
+			p.check_command, p.check_args, p.check_timeout_seconds, p.preconditions,
 			p.created_by, p.created_at, p.updated_at
 		FROM policies p
 		JOIN policy_bindings pb ON pb.policy_id = p.id
 		WHERE pb.group_id = $1
 		  AND pb.state = 'enabled'
+		  AND pb.in_window
 		  AND p.status = 'released'
 		ORDER BY pb.priority DESC, p.name, pb.id`
 
@@ -195,6 +377,8 @@ func (r *PolicyBindingRepository) ListPoliciesByGroupID(ctx context.Context, gro
 		if err := rows.Scan(
 			&p.ID, &p.Name, &p.Description, &p.Type, &p.Content, &p.Version, &p.State,
 			&p.DeprecatedAt, &p.DeprecationMessage, &p.ReplacementPolicyID,
+			&p.ContactLossAction, &p.ContactLossTTLSeconds,
+			&p.CheckCommand, pq.Array(&p.CheckArgs), &p.CheckTimeoutSeconds, jsonColumn{&p.Preconditions},
 			&p.CreatedBy, &p.CreatedAt, &p.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan policy: %w", err)
@@ -207,28 +391,49 @@ func (r *PolicyBindingRepository) ListPoliciesByGroupID(ctx context.Context, gro
 // ListPoliciesByGroupIDs returns released policies with enabled bindings for any of the given node groups.
 // Policies are deduplicated; priority is the max across all bindings.
 func (r *PolicyBindingRepository) ListPoliciesByGroupIDs(ctx context.Context, groupIDs []string) ([]*models.Policy, error) {
-	if len(groupIDs) == 0 {
+	return r.ListPoliciesByTargets(ctx, groupIDs, nil)
+}
+
+// ListPoliciesByTargets returns released policies with enabled bindings, in
+// their activity window, for any of the given node groups or saved node
+// filters. Policies are
+// deduplicated; priority is the max across all bindings.
+func (r *PolicyBindingRepository) ListPoliciesByTargets(ctx context.Context, groupIDs, filterIDs []string) ([]*models.Policy, error) {
+	if len(groupIDs) == 0 && len(filterIDs) == 0 {
 		return nil, nil
 	}
-	placeholders := make([]string, len(groupIDs))
-	args := make([]interface{}, len(groupIDs))
-	for i, id := range groupIDs {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = id
+	args := make([]interface{}, 0, len(groupIDs)+len(filterIDs))
+	var conds []string
+	for _, t := range []struct {
+		column string
+		ids    []string
+	}{{"pb.group_id", groupIDs}, {"pb.filter_id", filterIDs}} {
+		if len(t.ids) == 0 {
+			continue
+		}
+		placeholders := make([]string, len(t.ids))
+		for i, id := range t.ids {
+			args = append(args, id)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conds = append(conds, fmt.Sprintf("%s IN (%s)", t.column, strings.Join(placeholders, ",")))
 	}
 	query := fmt.Sprintf(`SELECT DISTINCT ON (p.id) p.id, p.name, p.description, p.type, p.content, p.version, p.status,
 			pb.priority,
 			p.deprecated_at, p.deprecation_message, p.replacement_policy_id,
+			p.contact_loss_action, p.contact_loss_ttl_seconds,
+			p.check_command, p.check_args, p.check_timeout_seconds, p.preconditions,
 			p.created_by, p.created_at, p.updated_at
 		FROM policies p
 		JOIN policy_bindings pb ON pb.policy_id = p.id
-		WHERE pb.group_id IN (%s)
+		WHERE (%s)
 		  AND pb.state = 'enabled'
+		  AND pb.in_window
 		  AND p.status = 'released'
-		ORDER BY p.id, pb.priority DESC, p.name`, strings.Join(placeholders, ","))
+		ORDER BY p.id, pb.priority DESC, p.name`, strings.Join(conds, " OR "))
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list policies by groups: %w", err)
+		return nil, fmt.Errorf("failed to list policies by targets: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
 	var policies []*models.Policy
@@ -238,6 +443,8 @@ func (r *PolicyBindingRepository) ListPoliciesByGroupIDs(ctx context.Context, gr
 			&p.ID, &p.Name, &p.Description, &p.Type, &p.Content, &p.Version, &p.State,
 			&p.Priority,
 			&p.DeprecatedAt, &p.DeprecationMessage, &p.ReplacementPolicyID,
+			&p.ContactLossAction, &p.ContactLossTTLSeconds,
+			&p.CheckCommand, pq.Array(&p.CheckArgs), &p.CheckTimeoutSeconds, jsonColumn{&p.Preconditions},
 			&p.CreatedBy, &p.CreatedAt, &p.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan policy: %w", err)
@@ -247,6 +454,85 @@ func (r *PolicyBindingRepository) ListPoliciesByGroupIDs(ctx context.Context, gr
 	return policies, rows.Err()
 }
 
+// ListPoliciesByUsernames returns released policies with enabled bindings,
+// in their activity window, to a user group that any of the given users is a member of. Each policy
+// is listed once, with the maximum priority across those bindings and the
+// matching users, sorted, in ScopeUsers.
+func (r *PolicyBindingRepository) ListPoliciesByUsernames(ctx context.Context, usernames []string) ([]*models.Policy, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	query := `SELECT p.id, p.name, p.description, p.type, p.content, p.version, p.status,
+			MAX(pb.priority),
+			p.deprecated_at, p.deprecation_message, p.replacement_policy_id,
+			p.contact_loss_action, p.contact_loss_ttl_seconds,
+			p.check_command, p.check_args, p.check_timeout_seconds, p.preconditions,
+			p.created_by, p.created_at, p.updated_at,
+			ARRAY_AGG(DISTINCT u.username ORDER BY u.username)
+		FROM policies p
+		JOIN policy_bindings pb ON pb.policy_id = p.id
+		JOIN user_group_members ugm ON ugm.group_id = pb.user_group_id
+		JOIN users u ON u.id = ugm.user_id
+		WHERE u.username = ANY($1)
+		  AND pb.state = 'enabled'
+		  AND pb.in_window
+		  AND p.status = 'released'
+		GROUP BY p.id
+		ORDER BY p.id`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(usernames))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policies by users: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var policies []*models.Policy
+	for rows.Next() {
+		p := &models.Policy{}
+		if err := rows.Scan(
+			&p.ID, &p.Name, &p.Description, &p.Type, &p.Content, &p.Version, &p.State,
+			&p.Priority,
+			&p.DeprecatedAt, &p.DeprecationMessage, &p.ReplacementPolicyID,
+			&p.ContactLossAction, &p.ContactLossTTLSeconds,
+			&p.CheckCommand, pq.Array(&p.CheckArgs), &p.CheckTimeoutSeconds, jsonColumn{&p.Preconditions},
+			&p.CreatedBy, &p.CreatedAt, &p.UpdatedAt,
+			pq.Array(&p.ScopeUsers),
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan policy: %w", err)
+		}
+		policies = append(policies, p)
+	}
+	return policies, rows.Err()
+}
+
+// ListStaleBindings returns the enabled node group and filter bindings of
+// released policies that have been deprecated and of archived policies.
+func (r *PolicyBindingRepository) ListStaleBindings(ctx context.Context) ([]*models.StaleBinding, error) {
+	query := `SELECT pb.group_id::text, pb.filter_id::text, p.id, p.name,
+			CASE WHEN p.status = 'archived' THEN 'archived' ELSE 'deprecated' END,
+			p.deprecation_message, p.replacement_policy_id
+		FROM policy_bindings pb
+		JOIN policies p ON p.id = pb.policy_id
+		WHERE pb.state = 'enabled'
+		  AND pb.user_group_id IS NULL
+		  AND (p.status = 'archived' OR (p.status = 'released' AND p.deprecated_at IS NOT NULL))
+		ORDER BY p.name, p.id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale bindings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var bindings []*models.StaleBinding
+	for rows.Next() {
+		b := &models.StaleBinding{}
+		if err := rows.Scan(&b.GroupID, &b.FilterID, &b.Policy.PolicyID, &b.Policy.PolicyName,
+			&b.Policy.Reason, &b.Policy.DeprecationMessage, &b.Policy.ReplacementPolicyID); err != nil {
+			return nil, fmt.Errorf("failed to scan stale binding: %w", err)
+		}
+		bindings = append(bindings, b)
+	}
+	return bindings, rows.Err()
+}
+
 // DeleteByPolicyID deletes all bindings for a given policy
 func (r *PolicyBindingRepository) DeleteByPolicyID(ctx context.Context, policyID string) error {
 	_, err := r.db.ExecContext(ctx, "DELETE FROM policy_bindings WHERE policy_id = $1", policyID)
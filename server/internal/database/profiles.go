@@ -0,0 +1,338 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// ProfileRepository handles profiles, their member policies and their
+// bindings to node groups. Every change that affects which policies a
+// profile contributes to a group reconciles that group's policy bindings in
+// the same transaction.
+type ProfileRepository struct {
+	db *DB
+}
+
+// NewProfileRepository creates a new ProfileRepository
+func NewProfileRepository(db *DB) *ProfileRepository {
+	return &ProfileRepository{db: db}
+}
+
+const profileSelect = `SELECT p.id, p.name, p.description,
+		COALESCE(array_agg(pp.policy_id::text ORDER BY pp.policy_id) FILTER (WHERE pp.policy_id IS NOT NULL), '{}'),
+		p.created_at, p.updated_at
+	FROM profiles p
+	LEFT JOIN profile_policies pp ON pp.profile_id = p.id`
+
+// Create inserts a new profile together with its member policies
+func (r *ProfileRepository) Create(ctx context.Context, p *models.Profile) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	now := time.Now()
+	p.CreatedAt = now
+	p.UpdatedAt = now
+
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO profiles (name, description, created_at, updated_at) VALUES ($1, $2, $3, $4) RETURNING id`,
+		p.Name, p.Description, p.CreatedAt, p.UpdatedAt).Scan(&p.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+	if err := replaceProfilePolicies(ctx, tx, p.ID, p.PolicyIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetByID retrieves a profile by ID
+func (r *ProfileRepository) GetByID(ctx context.Context, id string) (*models.Profile, error) {
+	query := profileSelect + ` WHERE p.id = $1 GROUP BY p.id`
+	p, err := scanProfile(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	return p, nil
+}
+
+// ListAll returns all profiles
+func (r *ProfileRepository) ListAll(ctx context.Context) ([]*models.Profile, error) {
+	query := profileSelect + ` GROUP BY p.id ORDER BY p.name`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var profiles []*models.Profile
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan profile: %w", err)
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, rows.Err()
+}
+
+// Update updates a profile. When the membership is replaced, the policy
+// bindings of every group the profile is bound to are reconciled and the
+// IDs of those groups are returned.
+func (r *ProfileRepository) Update(ctx context.Context, id string, req *models.UpdateProfileRequest) ([]string, error) {
+	setClauses := []string{}
+	args := []interface{}{}
+	argIdx := 1
+
+	if req.Name != nil {
+		setClauses = append(setClauses, fmt.Sprintf("name = $%d", argIdx))
+		args = append(args, *req.Name)
+		argIdx++
+	}
+	if req.Description != nil {
+		setClauses = append(setClauses, fmt.Sprintf("description = $%d", argIdx))
+		args = append(args, *req.Description)
+		argIdx++
+	}
+	setClauses = append(setClauses, fmt.Sprintf("updated_at = $%d", argIdx))
+	args = append(args, time.Now())
+	argIdx++
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	args = append(args, id)
+	query := fmt.Sprintf("UPDATE profiles SET %s WHERE id = $%d",
+		strings.Join(setClauses, ", "), argIdx)
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rows == 0 {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	var groupIDs []string
+	if req.PolicyIDs != nil {
+		if err := replaceProfilePolicies(ctx, tx, id, *req.PolicyIDs); err != nil {
+			return nil, err
+		}
+		if groupIDs, err = profileGroupIDs(ctx, tx, id); err != nil {
+			return nil, err
+		}
+		for _, groupID := range groupIDs {
+			if err := reconcileGroupBindings(ctx, tx, groupID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit profile update: %w", err)
+	}
+	return groupIDs, nil
+}
+
+// Delete removes a profile and the policy bindings it created, and returns
+// the IDs of the groups it was bound to.
+func (r *ProfileRepository) Delete(ctx context.Context, id string) ([]string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	groupIDs, err := profileGroupIDs(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM profiles WHERE id = $1", id); err != nil {
+		return nil, fmt.Errorf("failed to delete profile: %w", err)
+	}
+	for _, groupID := range groupIDs {
+		if err := reconcileGroupBindings(ctx, tx, groupID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit profile deletion: %w", err)
+	}
+	return groupIDs, nil
+}
+
+// ListBindings returns the node groups a profile is bound to
+func (r *ProfileRepository) ListBindings(ctx context.Context, profileID string) ([]*models.ProfileBinding, error) {
+	query := `SELECT pfb.id, pfb.profile_id, pfb.group_id, ng.name, pfb.priority, pfb.created_at
+		FROM profile_bindings pfb
+		JOIN node_groups ng ON ng.id = pfb.group_id
+		WHERE pfb.profile_id = $1
+		ORDER BY ng.name`
+	rows, err := r.db.QueryContext(ctx, query, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profile bindings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var bindings []*models.ProfileBinding
+	for rows.Next() {
+		b := &models.ProfileBinding{}
+		if err := rows.Scan(&b.ID, &b.ProfileID, &b.GroupID, &b.GroupName, &b.Priority, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan profile binding: %w", err)
+		}
+		bindings = append(bindings, b)
+	}
+	return bindings, rows.Err()
+}
+
+// Bind binds a profile to a node group and, atomically, creates or enables
+// a policy binding in that group for every member policy.
+func (r *ProfileRepository) Bind(ctx context.Context, b *models.ProfileBinding) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	b.CreatedAt = time.Now()
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO profile_bindings (profile_id, group_id, priority, created_at) VALUES ($1, $2, $3, $4) RETURNING id`,
+		b.ProfileID, b.GroupID, b.Priority, b.CreatedAt).Scan(&b.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create profile binding: %w", err)
+	}
+	if err := reconcileGroupBindings(ctx, tx, b.GroupID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Unbind removes a profile's binding to a node group together with the
+// policy bindings only it required. It reports whether the binding existed.
+func (r *ProfileRepository) Unbind(ctx context.Context, profileID, groupID string) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	result, err := tx.ExecContext(ctx,
+		"DELETE FROM profile_bindings WHERE profile_id = $1 AND group_id = $2", profileID, groupID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete profile binding: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+	if err := reconcileGroupBindings(ctx, tx, groupID); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// replaceProfilePolicies replaces the member policies of a profile.
+func replaceProfilePolicies(ctx context.Context, tx *sql.Tx, profileID string, policyIDs []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM profile_policies WHERE profile_id = $1", profileID); err != nil {
+		return fmt.Errorf("failed to clear profile policies: %w", err)
+	}
+	for _, policyID := range policyIDs {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO profile_policies (profile_id, policy_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+			profileID, policyID); err != nil {
+			return fmt.Errorf("failed to add policy %s to profile: %w", policyID, err)
+		}
+	}
+	return nil
+}
+
+// profileGroupIDs returns the IDs of the groups a profile is bound to.
+func profileGroupIDs(ctx context.Context, tx *sql.Tx, profileID string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT group_id FROM profile_bindings WHERE profile_id = $1 ORDER BY group_id", profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profile groups: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan profile group: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// reconcileGroupBindings brings a group's policy bindings in line with the
+// profiles bound to it. Every policy contained in one of those profiles gets
+// a binding, enabled when the policy is released; a binding that already
+// existed is enabled but otherwise left alone. Profile-managed bindings for
+// policies no bound profile contains any more are removed. The priority of
+// a profile-managed binding is the highest among the profiles requiring it.
+func reconcileGroupBindings(ctx context.Context, tx *sql.Tx, groupID string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO policy_bindings
+			(policy_id, group_id, state, priority, profile_managed, created_at, updated_at)
+		SELECT pp.policy_id, pfb.group_id,
+			CASE WHEN p.status = 'released' THEN 'enabled' ELSE 'disabled' END,
+			MAX(pfb.priority), TRUE, NOW(), NOW()
+		FROM profile_bindings pfb
+		JOIN profile_policies pp ON pp.profile_id = pfb.profile_id
+		JOIN policies p ON p.id = pp.policy_id
+		WHERE pfb.group_id = $1
+		GROUP BY pp.policy_id, pfb.group_id, p.status
+		ON CONFLICT (policy_id, group_id) DO UPDATE SET
+			state = CASE WHEN EXCLUDED.state = 'enabled' THEN 'enabled' ELSE policy_bindings.state END,
+			priority = CASE WHEN policy_bindings.profile_managed THEN EXCLUDED.priority ELSE policy_bindings.priority END,
+			updated_at = NOW()`, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile profile bindings: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM policy_bindings pb
+		WHERE pb.group_id = $1 AND pb.profile_managed
+		  AND NOT EXISTS (
+			SELECT 1 FROM profile_bindings pfb
+			JOIN profile_policies pp ON pp.profile_id = pfb.profile_id
+			WHERE pfb.group_id = pb.group_id AND pp.policy_id = pb.policy_id)`, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to remove stale profile bindings: %w", err)
+	}
+	return nil
+}
+
+func scanProfile(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.Profile, error) {
+	p := &models.Profile{}
+	if err := row.Scan(&p.ID, &p.Name, &p.Description, pq.Array(&p.PolicyIDs), &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -48,7 +48,7 @@ func (r *RevocationRepository) IsRevoked(ctx context.Context, serial string) (bo
 func (r *RevocationRepository) GetByNodeID(ctx context.Context, nodeID string) (*models.RevokedCertificate, error) {
 	var rev models.RevokedCertificate
 	err := r.db.QueryRowContext(ctx,
-		`SELECT id, node_id, serial, revoked_at, reason FROM revoked_certificates WHERE node_id = $1 ORDER BY revoked_at DESC LIMIT 1`,
+		`SELECT id, COALESCE(node_id::text, ''), serial, revoked_at, reason FROM revoked_certificates WHERE node_id = $1 ORDER BY revoked_at DESC LIMIT 1`,
 		nodeID).Scan(&rev.ID, &rev.NodeID, &rev.Serial, &rev.RevokedAt, &rev.Reason)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -70,9 +70,10 @@ func (r *RevocationRepository) DeleteByNodeID(ctx context.Context, nodeID string
 }
 
 // ListAll returns all revoked certificates ordered by revoked_at descending.
+// Revocations of deleted nodes have an empty NodeID.
 func (r *RevocationRepository) ListAll(ctx context.Context) ([]*models.RevokedCertificate, error) {
 	rows, err := r.db.QueryContext(ctx,
-		`SELECT id, node_id, serial, revoked_at, reason FROM revoked_certificates ORDER BY revoked_at DESC`)
+		`SELECT id, COALESCE(node_id::text, ''), serial, revoked_at, reason FROM revoked_certificates ORDER BY revoked_at DESC`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list revocations: %w", err)
 	}
@@ -87,3 +88,21 @@ func (r *RevocationRepository) ListAll(ctx context.Context) ([]*models.RevokedCe
 	}
 	return revs, rows.Err()
 }
+
+// ListSerials returns the serials of all revoked certificates.
+func (r *RevocationRepository) ListSerials(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT serial FROM revoked_certificates`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revoked serials: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var serials []string
+	for rows.Next() {
+		var serial string
+		if err := rows.Scan(&serial); err != nil {
+			return nil, fmt.Errorf("failed to scan revoked serial: %w", err)
+		}
+		serials = append(serials, serial)
+	}
+	return serials, rows.Err()
+}
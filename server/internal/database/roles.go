@@ -63,8 +63,27 @@ func (r *RoleRepository) GetByName(ctx context.Context, name string) (*models.Ro
 // List returns all roles
 func (r *RoleRepository) List(ctx context.Context) ([]*models.Role, error) {
 	query := `SELECT id, name, description, created_at, updated_at FROM roles ORDER BY name`
+	return r.scanRoles(ctx, query)
+}
+
+// ListPaginated returns one page of all roles, ordered by name, and the total
+// number of roles.
+func (r *RoleRepository) ListPaginated(ctx context.Context, p models.Pagination) ([]*models.Role, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM roles`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count roles: %w", err)
+	}
+
+	query := `SELECT id, name, description, created_at, updated_at FROM roles ORDER BY name, id LIMIT $1 OFFSET $2`
+	roles, err := r.scanRoles(ctx, query, p.PerPage, p.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	return roles, total, nil
+}
 
-	rows, err := r.db.QueryContext(ctx, query)
+func (r *RoleRepository) scanRoles(ctx context.Context, query string, args ...interface{}) ([]*models.Role, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list roles: %w", err)
 	}
@@ -205,3 +224,116 @@ func (r *RoleRepository) SetPermissions(ctx context.Context, roleID string, perm
 
 	return tx.Commit()
 }
+
+// ClonePermissions replaces the permissions of role dstRoleID with those of
+// role srcRoleID.
+func (r *RoleRepository) ClonePermissions(ctx context.Context, srcRoleID, dstRoleID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM role_permissions WHERE role_id = $1`, dstRoleID); err != nil {
+		return fmt.Errorf("failed to clear role permissions: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO role_permissions (role_id, permission_id)
+		SELECT $2, permission_id FROM role_permissions WHERE role_id = $1`,
+		srcRoleID, dstRoleID); err != nil {
+		return fmt.Errorf("failed to copy role permissions: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// RBACImportPlan holds the writes of an RBAC import so that ApplyImport can
+// make them in one transaction.
+type RBACImportPlan struct {
+	Roles         []RBACImportRole
+	UserBindings  []RBACImportBinding
+	GroupBindings []RBACImportBinding
+}
+
+// RBACImportRole creates a role, or updates the one with ID, and replaces
+// its permissions.
+type RBACImportRole struct {
+	ID            string // empty creates the role
+	Name          string
+	Description   string
+	PermissionIDs []string
+}
+
+// RBACImportBinding creates a user or user-group role binding. RoleName
+// names a role created by the same import when RoleID is empty.
+type RBACImportBinding struct {
+	SubjectID string // user or user group ID
+	RoleID    string
+	RoleName  string
+	ScopeType string
+	ScopeID   *string
+}
+
+// ApplyImport applies an RBAC import plan in one transaction, so a failure
+// leaves the authorization model as it was.
+func (r *RoleRepository) ApplyImport(ctx context.Context, plan *RBACImportPlan) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	created := make(map[string]string)
+	for _, role := range plan.Roles {
+		id := role.ID
+		if id == "" {
+			if err := tx.QueryRowContext(ctx,
+				`INSERT INTO roles (name, description, created_at, updated_at) VALUES ($1, $2, $3, $3) RETURNING id`,
+				role.Name, role.Description, now).Scan(&id); err != nil {
+				return fmt.Errorf("failed to create role %s: %w", role.Name, err)
+			}
+			created[role.Name] = id
+		} else if _, err := tx.ExecContext(ctx,
+			`UPDATE roles SET description = $1, updated_at = $2 WHERE id = $3`,
+			role.Description, now, id); err != nil {
+			return fmt.Errorf("failed to update role %s: %w", role.Name, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM role_permissions WHERE role_id = $1`, id); err != nil {
+			return fmt.Errorf("failed to clear permissions of role %s: %w", role.Name, err)
+		}
+		for _, permID := range role.PermissionIDs {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO role_permissions (role_id, permission_id) VALUES ($1, $2)`,
+				id, permID); err != nil {
+				return fmt.Errorf("failed to add permission %s to role %s: %w", permID, role.Name, err)
+			}
+		}
+	}
+
+	roleID := func(b RBACImportBinding) string {
+		if b.RoleID != "" {
+			return b.RoleID
+		}
+		return created[b.RoleName]
+	}
+	for _, b := range plan.UserBindings {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO user_role_bindings (user_id, role_id, scope_type, scope_id, source, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			b.SubjectID, roleID(b), b.ScopeType, b.ScopeID, models.RoleBindingSourceManual, now); err != nil {
+			return fmt.Errorf("failed to create user role binding: %w", err)
+		}
+	}
+	for _, b := range plan.GroupBindings {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO user_group_role_bindings (group_id, role_id, scope_type, scope_id, created_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			b.SubjectID, roleID(b), b.ScopeType, b.ScopeID, now); err != nil {
+			return fmt.Errorf("failed to create group role binding: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
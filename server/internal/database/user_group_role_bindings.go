@@ -66,6 +66,60 @@ func (r *UserGroupRoleBindingRepository) ListByGroupID(ctx context.Context, grou
 	return bindings, rows.Err()
 }
 
+// ListByUserID returns the role bindings of every user group the user is a
+// member of, with GroupName filled in.
+func (r *UserGroupRoleBindingRepository) ListByUserID(ctx context.Context, userID string) ([]*models.UserGroupRoleBinding, error) {
+	query := `
+		SELECT b.id, b.group_id, b.role_id, b.scope_type, b.scope_id, b.created_at, g.name
+		FROM user_group_role_bindings b
+		JOIN user_group_members m ON m.group_id = b.group_id
+		JOIN user_groups g ON g.id = b.group_id
+		WHERE m.user_id = $1
+		ORDER BY b.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group role bindings of user: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var bindings []*models.UserGroupRoleBinding
+	for rows.Next() {
+		b := &models.UserGroupRoleBinding{}
+		if err := rows.Scan(&b.ID, &b.GroupID, &b.RoleID, &b.ScopeType, &b.ScopeID, &b.CreatedAt, &b.GroupName); err != nil {
+			return nil, fmt.Errorf("failed to scan group role binding: %w", err)
+		}
+		bindings = append(bindings, b)
+	}
+
+	return bindings, rows.Err()
+}
+
+// ListAll returns every group role binding
+func (r *UserGroupRoleBindingRepository) ListAll(ctx context.Context) ([]*models.UserGroupRoleBinding, error) {
+	query := `
+		SELECT id, group_id, role_id, scope_type, scope_id, created_at
+		FROM user_group_role_bindings
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group role bindings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var bindings []*models.UserGroupRoleBinding
+	for rows.Next() {
+		b := &models.UserGroupRoleBinding{}
+		if err := rows.Scan(&b.ID, &b.GroupID, &b.RoleID, &b.ScopeType, &b.ScopeID, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan group role binding: %w", err)
+		}
+		bindings = append(bindings, b)
+	}
+
+	return bindings, rows.Err()
+}
+
 // Delete removes a group role binding
 func (r *UserGroupRoleBindingRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM user_group_role_bindings WHERE id = $1`
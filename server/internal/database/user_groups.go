@@ -57,7 +57,27 @@ func (r *UserGroupRepository) GetByID(ctx context.Context, id string) (*models.U
 // ListAll returns all user groups
 func (r *UserGroupRepository) ListAll(ctx context.Context) ([]*models.UserGroup, error) {
 	query := `SELECT id, name, description, created_at, updated_at FROM user_groups ORDER BY name`
-	rows, err := r.db.QueryContext(ctx, query)
+	return r.scanUserGroups(ctx, query)
+}
+
+// ListPaginated returns one page of all user groups, ordered by name, and the
+// total number of user groups.
+func (r *UserGroupRepository) ListPaginated(ctx context.Context, p models.Pagination) ([]*models.UserGroup, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_groups`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count user groups: %w", err)
+	}
+
+	query := `SELECT id, name, description, created_at, updated_at FROM user_groups ORDER BY name, id LIMIT $1 OFFSET $2`
+	groups, err := r.scanUserGroups(ctx, query, p.PerPage, p.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+func (r *UserGroupRepository) scanUserGroups(ctx context.Context, query string, args ...interface{}) ([]*models.UserGroup, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list user groups: %w", err)
 	}
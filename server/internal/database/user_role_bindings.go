@@ -25,14 +25,17 @@ func NewUserRoleBindingRepository(db *DB) *UserRoleBindingRepository {
 // Create inserts a new user role binding
 func (r *UserRoleBindingRepository) Create(ctx context.Context, binding *models.UserRoleBinding) error {
 	query := `
-		INSERT INTO user_role_bindings (user_id, role_id, scope_type, scope_id, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO user_role_bindings (user_id, role_id, scope_type, scope_id, source, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id`
 
+	if binding.Source == "" {
+		binding.Source = models.RoleBindingSourceManual
+	}
 	binding.CreatedAt = time.Now()
 
 	err := r.db.QueryRowContext(ctx, query,
-		binding.UserID, binding.RoleID, binding.ScopeType, binding.ScopeID, binding.CreatedAt,
+		binding.UserID, binding.RoleID, binding.ScopeType, binding.ScopeID, binding.Source, binding.CreatedAt,
 	).Scan(&binding.ID)
 	if err != nil {
 		return fmt.Errorf("failed to create user role binding: %w", err)
@@ -44,7 +47,7 @@ func (r *UserRoleBindingRepository) Create(ctx context.Context, binding *models.
 // ListByUserID returns all role bindings for a user
 func (r *UserRoleBindingRepository) ListByUserID(ctx context.Context, userID string) ([]*models.UserRoleBinding, error) {
 	query := `
-		SELECT id, user_id, role_id, scope_type, scope_id, created_at
+		SELECT id, user_id, role_id, scope_type, scope_id, source, created_at
 		FROM user_role_bindings WHERE user_id = $1`
 
 	rows, err := r.db.QueryContext(ctx, query, userID)
@@ -56,7 +59,32 @@ func (r *UserRoleBindingRepository) ListByUserID(ctx context.Context, userID str
 	var bindings []*models.UserRoleBinding
 	for rows.Next() {
 		b := &models.UserRoleBinding{}
-		if err := rows.Scan(&b.ID, &b.UserID, &b.RoleID, &b.ScopeType, &b.ScopeID, &b.CreatedAt); err != nil {
+		if err := rows.Scan(&b.ID, &b.UserID, &b.RoleID, &b.ScopeType, &b.ScopeID, &b.Source, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan user role binding: %w", err)
+		}
+		bindings = append(bindings, b)
+	}
+
+	return bindings, rows.Err()
+}
+
+// ListAll returns every user role binding
+func (r *UserRoleBindingRepository) ListAll(ctx context.Context) ([]*models.UserRoleBinding, error) {
+	query := `
+		SELECT id, user_id, role_id, scope_type, scope_id, source, created_at
+		FROM user_role_bindings
+		ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user role bindings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var bindings []*models.UserRoleBinding
+	for rows.Next() {
+		b := &models.UserRoleBinding{}
+		if err := rows.Scan(&b.ID, &b.UserID, &b.RoleID, &b.ScopeType, &b.ScopeID, &b.Source, &b.CreatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan user role binding: %w", err)
 		}
 		bindings = append(bindings, b)
@@ -65,6 +93,45 @@ func (r *UserRoleBindingRepository) ListByUserID(ctx context.Context, userID str
 	return bindings, rows.Err()
 }
 
+// ListUsersByRoleID returns the users holding a role, directly or through
+// a user group role binding, ordered by username. A user appears once per
+// binding granting the role.
+func (r *UserRoleBindingRepository) ListUsersByRoleID(ctx context.Context, roleID string) ([]*models.RoleHolder, error) {
+	query := `
+		SELECT u.id, u.username, u.full_name, u.enabled,
+			urb.id, urb.scope_type, urb.scope_id, '', ''
+		FROM user_role_bindings urb
+		JOIN users u ON u.id = urb.user_id
+		WHERE urb.role_id = $1
+		UNION ALL
+		SELECT u.id, u.username, u.full_name, u.enabled,
+			ugrb.id, ugrb.scope_type, ugrb.scope_id, CAST(ug.id AS TEXT), ug.name
+		FROM user_group_role_bindings ugrb
+		JOIN user_groups ug ON ug.id = ugrb.group_id
+		JOIN user_group_members ugm ON ugm.group_id = ugrb.group_id
+		JOIN users u ON u.id = ugm.user_id
+		WHERE ugrb.role_id = $1
+		ORDER BY 2, 9`
+
+	rows, err := r.db.QueryContext(ctx, query, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users by role: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	holders := []*models.RoleHolder{}
+	for rows.Next() {
+		h := &models.RoleHolder{}
+		if err := rows.Scan(&h.UserID, &h.Username, &h.FullName, &h.Enabled,
+			&h.BindingID, &h.ScopeType, &h.ScopeID, &h.ViaGroupID, &h.ViaGroupName); err != nil {
+			return nil, fmt.Errorf("failed to scan role holder: %w", err)
+		}
+		holders = append(holders, h)
+	}
+
+	return holders, rows.Err()
+}
+
 // Delete removes a user role binding
 func (r *UserRoleBindingRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM user_role_bindings WHERE id = $1`
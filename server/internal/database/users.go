@@ -48,13 +48,15 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 // GetByID retrieves a user by ID
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
 	query := `
-		SELECT id, username, password_hash, email, full_name, source, enabled, created_at, updated_at
+		SELECT id, username, password_hash, email, full_name, source, enabled,
+		       failed_attempts, locked_until, must_change_password, created_at, updated_at
 		FROM users WHERE id = $1`
 
 	user := &models.User{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&user.ID, &user.Username, &user.PasswordHash, &user.Email,
 		&user.FullName, &user.Source, &user.Enabled,
+		&user.FailedAttempts, &user.LockedUntil, &user.MustChangePassword,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -70,13 +72,15 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*models.User,
 // GetByUsername retrieves a user by username
 func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
 	query := `
-		SELECT id, username, password_hash, email, full_name, source, enabled, created_at, updated_at
+		SELECT id, username, password_hash, email, full_name, source, enabled,
+		       failed_attempts, locked_until, must_change_password, created_at, updated_at
 		FROM users WHERE username = $1`
 
 	user := &models.User{}
 	err := r.db.QueryRowContext(ctx, query, username).Scan(
 		&user.ID, &user.Username, &user.PasswordHash, &user.Email,
 		&user.FullName, &user.Source, &user.Enabled,
+		&user.FailedAttempts, &user.LockedUntil, &user.MustChangePassword,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
 	if err == sql.ErrNoRows {
@@ -92,7 +96,8 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*m
 // List returns all users with optional pagination
 func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
 	query := `
-		SELECT id, username, password_hash, email, full_name, source, enabled, created_at, updated_at
+		SELECT id, username, password_hash, email, full_name, source, enabled,
+		       failed_attempts, locked_until, must_change_password, created_at, updated_at
 		FROM users ORDER BY username LIMIT $1 OFFSET $2`
 
 	rows, err := r.db.QueryContext(ctx, query, limit, offset)
@@ -107,6 +112,7 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*models
 		err := rows.Scan(
 			&user.ID, &user.Username, &user.PasswordHash, &user.Email,
 			&user.FullName, &user.Source, &user.Enabled,
+			&user.FailedAttempts, &user.LockedUntil, &user.MustChangePassword,
 			&user.CreatedAt, &user.UpdatedAt,
 		)
 		if err != nil {
@@ -118,6 +124,15 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*models
 	return users, rows.Err()
 }
 
+// Count returns the total number of users.
+func (r *UserRepository) Count(ctx context.Context) (int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return total, nil
+}
+
 // Update updates user fields
 func (r *UserRepository) Update(ctx context.Context, id string, req *models.UpdateUserRequest) error {
 	query := `
@@ -160,11 +175,15 @@ func (r *UserRepository) Update(ctx context.Context, id string, req *models.Upda
 	return nil
 }
 
-// UpdatePassword updates a user's password hash
-func (r *UserRepository) UpdatePassword(ctx context.Context, id, passwordHash string) error {
-	query := `UPDATE users SET password_hash = $1, updated_at = NOW() WHERE id = $2`
+// UpdatePassword updates a user's password hash and whether the user must
+// change it after the next login. A new password also lifts any lockout.
+func (r *UserRepository) UpdatePassword(ctx context.Context, id, passwordHash string, mustChange bool) error {
+	query := `
+		UPDATE users SET password_hash = $1, must_change_password = $2,
+			failed_attempts = 0, locked_until = NULL, updated_at = NOW()
+		WHERE id = $3`
 
-	result, err := r.db.ExecContext(ctx, query, passwordHash, id)
+	result, err := r.db.ExecContext(ctx, query, passwordHash, mustChange, id)
 	if err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
@@ -180,6 +199,47 @@ func (r *UserRepository) UpdatePassword(ctx context.Context, id, passwordHash st
 	return nil
 }
 
+// RecordLoginFailure counts a failed login of the user with the given
+// username. Once threshold consecutive failures are reached the account is
+// locked for lockout; the count restarts after a lock has expired. It
+// returns the lock expiry, or nil when the account is not locked, and does
+// nothing for unknown usernames. A threshold of 0 only counts.
+func (r *UserRepository) RecordLoginFailure(ctx context.Context, username string, threshold int, lockout time.Duration) (*time.Time, error) {
+	query := `
+		UPDATE users SET
+			failed_attempts = CASE WHEN locked_until <= NOW() THEN 1 ELSE failed_attempts + 1 END,
+			locked_until = CASE
+				WHEN $2 > 0 AND (CASE WHEN locked_until <= NOW() THEN 1 ELSE failed_attempts + 1 END) >= $2
+					THEN NOW() + $3 * INTERVAL '1 second'
+				WHEN locked_until <= NOW() THEN NULL
+				ELSE locked_until
+			END
+		WHERE username = $1
+		RETURNING locked_until`
+
+	var lockedUntil *time.Time
+	err := r.db.QueryRowContext(ctx, query, username, threshold, lockout.Seconds()).Scan(&lockedUntil)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+	return lockedUntil, nil
+}
+
+// ResetLoginFailures clears the failed login count and any lock of a user.
+func (r *UserRepository) ResetLoginFailures(ctx context.Context, id string) error {
+	query := `
+		UPDATE users SET failed_attempts = 0, locked_until = NULL
+		WHERE id = $1 AND (failed_attempts <> 0 OR locked_until IS NOT NULL)`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	return nil
+}
+
 // Delete removes a user
 func (r *UserRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM users WHERE id = $1`
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+const webhookColumns = `id, name, url, secret, events, enabled, created_at, updated_at`
+
+// WebhookRepository handles webhooks database operations
+type WebhookRepository struct {
+	db *DB
+}
+
+// NewWebhookRepository creates a new WebhookRepository
+func NewWebhookRepository(db *DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Create inserts a new webhook. Secret must already be encrypted.
+func (r *WebhookRepository) Create(ctx context.Context, wh *models.Webhook) error {
+	query := `INSERT INTO webhooks (name, url, secret, events, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	now := time.Now()
+	wh.CreatedAt = now
+	wh.UpdatedAt = now
+
+	err := r.db.QueryRowContext(ctx, query, wh.Name, wh.URL, wh.Secret, pq.Array(wh.Events), wh.Enabled,
+		wh.CreatedAt, wh.UpdatedAt).Scan(&wh.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a webhook by ID
+func (r *WebhookRepository) GetByID(ctx context.Context, id string) (*models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+	wh, err := scanWebhook(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+	return wh, nil
+}
+
+// ListAll returns all webhooks
+func (r *WebhookRepository) ListAll(ctx context.Context) ([]*models.Webhook, error) {
+	return r.list(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY name`)
+}
+
+// ListEnabled returns the enabled webhooks
+func (r *WebhookRepository) ListEnabled(ctx context.Context) ([]*models.Webhook, error) {
+	return r.list(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE enabled ORDER BY name`)
+}
+
+func (r *WebhookRepository) list(ctx context.Context, query string) ([]*models.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var webhooks []*models.Webhook
+	for rows.Next() {
+		wh, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, wh)
+	}
+	return webhooks, rows.Err()
+}
+
+// Update updates a webhook. A new secret in req must already be encrypted.
+func (r *WebhookRepository) Update(ctx context.Context, id string, req *models.UpdateWebhookRequest) error {
+	setClauses := []string{}
+	args := []interface{}{}
+	argIdx := 1
+
+	set := func(column string, value interface{}) {
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", column, argIdx))
+		args = append(args, value)
+		argIdx++
+	}
+	if req.Name != nil {
+		set("name", *req.Name)
+	}
+	if req.URL != nil {
+		set("url", *req.URL)
+	}
+	if req.Secret != nil {
+		set("secret", *req.Secret)
+	}
+	if req.Events != nil {
+		set("events", pq.Array(*req.Events))
+	}
+	if req.Enabled != nil {
+		set("enabled", *req.Enabled)
+	}
+
+	if len(setClauses) == 0 {
+		return nil
+	}
+	set("updated_at", time.Now())
+
+	args = append(args, id)
+	query := fmt.Sprintf("UPDATE webhooks SET %s WHERE id = $%d",
+		strings.Join(setClauses, ", "), argIdx)
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("webhook not found")
+	}
+	return nil
+}
+
+// Delete removes a webhook by ID
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+func scanWebhook(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.Webhook, error) {
+	wh := &models.Webhook{}
+	if err := row.Scan(&wh.ID, &wh.Name, &wh.URL, &wh.Secret, pq.Array(&wh.Events), &wh.Enabled,
+		&wh.CreatedAt, &wh.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if wh.Events == nil {
+		wh.Events = []string{}
+	}
+	return wh, nil
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+func TestValidAppliedFiles(t *testing.T) {
+	good := strings.Repeat("ab", 32)
+	files := validAppliedFiles([]*pb.AppliedFile{
+		{Path: "/etc/xdg/kdeglobals", Sha256: good},
+		{Path: "", Sha256: good},
+		{Path: "/etc/kde5rc", Sha256: "abc"},
+		{Path: "/etc/kde6rc", Sha256: strings.Repeat("zz", 32)},
+	})
+	if len(files) != 1 || files[0].GetPath() != "/etc/xdg/kdeglobals" {
+		t.Errorf("validAppliedFiles() = %v, want only /etc/xdg/kdeglobals", files)
+	}
+}
+
+func TestComplianceReportFromProto(t *testing.T) {
+	rep := complianceReportFromProto(&pb.ReportComplianceRequest{
+		PolicyId: "p1",
+		Status:   pb.ComplianceStatus_COMPLIANCE_STATUS_ERROR,
+		Message:  "failed",
+		Items: []*pb.ComplianceItemResult{
+			{SchemaId: "org.gnome.desktop.lockdown", Key: "disable-printing", Status: pb.ComplianceStatus_COMPLIANCE_STATUS_COMPLIANT},
+		},
+		AppliedFiles: []*pb.AppliedFile{{Path: "/etc/kde5rc", Sha256: "abc"}},
+	})
+	if rep.PolicyID != "p1" || rep.Status != "error" || !rep.Failed || rep.Message != "failed" {
+		t.Errorf("complianceReportFromProto() = %+v", rep)
+	}
+	if want := `[{"schema_id":"org.gnome.desktop.lockdown","key":"disable-printing","status":"compliant"}]`; string(rep.ItemsJSON) != want {
+		t.Errorf("ItemsJSON = %s, want %s", rep.ItemsJSON, want)
+	}
+	if len(rep.AppliedFiles) != 0 {
+		t.Errorf("AppliedFiles = %v, want the malformed hash dropped", rep.AppliedFiles)
+	}
+
+	legacy := complianceReportFromProto(&pb.ReportComplianceRequest{PolicyId: "p2", Compliant: true})
+	if legacy.Status != "compliant" || legacy.Failed || legacy.ItemsJSON != nil {
+		t.Errorf("complianceReportFromProto(legacy) = %+v", legacy)
+	}
+}
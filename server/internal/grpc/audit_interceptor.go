@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/VuteTech/Bor/server/internal/services"
+	auditpb "github.com/VuteTech/Bor/server/pkg/grpc/audit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// agentAudit collects the audit events of one agent RPC. The actor of each
+// event is the node itself: its name is the username and its ID the node ID.
+type agentAudit struct {
+	method string
+	srcIP  string
+	// emit, when set, sends each event as it is recorded. Streams use it
+	// because they may run for days; unary calls emit when they return.
+	emit   func(*auditpb.AuditEvent)
+	events []*auditpb.AuditEvent
+}
+
+type agentAuditKey struct{}
+
+// recordAgentEvent records that the agent of node nodeID/nodeName caused
+// action, described by details (encoded as JSON). It does nothing unless
+// the RPC passed through an agent audit interceptor.
+func recordAgentEvent(ctx context.Context, action, nodeID, nodeName string, details any) {
+	a, ok := ctx.Value(agentAuditKey{}).(*agentAudit)
+	if !ok {
+		return
+	}
+	event := a.newEvent(action, nodeID, nodeName, details)
+	if a.emit != nil {
+		a.emit(event)
+		return
+	}
+	a.events = append(a.events, event)
+}
+
+func (a *agentAudit) newEvent(action, nodeID, nodeName string, details any) *auditpb.AuditEvent {
+	detailsJSON := ""
+	if details != nil {
+		b, err := json.Marshal(details)
+		if err != nil {
+			log.Printf("WARNING: failed to encode audit details for %s: %v", a.method, err)
+		} else {
+			detailsJSON = string(b)
+		}
+	}
+	return &auditpb.AuditEvent{
+		OccurredAt: timestamppb.Now(),
+		Actor:      &auditpb.Actor{Username: nodeName, NodeId: nodeID},
+		Action:     action,
+		Resource:   &auditpb.Resource{Type: "nodes", Id: nodeID, Name: nodeName},
+		Outcome:    auditpb.Outcome_OUTCOME_SUCCESS,
+		SrcIp:      a.srcIP,
+		Payload: &auditpb.AuditEvent_Agent{
+			Agent: &auditpb.AgentPayload{Method: a.method, DetailsJson: detailsJSON},
+		},
+	}
+}
+
+// AgentAuditInterceptor returns a unary server interceptor that records the
+// audit events of agent-facing RPCs: enrollments, node metadata changes and
+// policy deliveries. The handlers decide what is worth recording; failed
+// enrollments are recorded by the interceptor. It must run after the auth
+// interceptor.
+func AgentAuditInterceptor(auditSvc *services.AuditService) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		a := &agentAudit{method: info.FullMethod, srcIP: peerAddr(ctx)}
+		resp, err := handler(context.WithValue(ctx, agentAuditKey{}, a), req)
+
+		// Enrollment requests name the node they are about to create.
+		if enroll, ok := req.(interface{ GetNodeName() string }); ok && err != nil && len(a.events) == 0 {
+			event := a.newEvent("enroll", "", enroll.GetNodeName(), map[string]string{"error": status.Convert(err).Message()})
+			event.Outcome = auditpb.Outcome_OUTCOME_FAILURE
+			a.events = append(a.events, event)
+		}
+		for _, event := range a.events {
+			auditSvc.Emit(ctx, event)
+		}
+		return resp, err
+	}
+}
+
+// AgentAuditStreamInterceptor returns a stream server interceptor that
+// records the audit events of agent-facing streams as they happen. Like
+// AgentAuditInterceptor it must run after the auth interceptor.
+func AgentAuditStreamInterceptor(auditSvc *services.AuditService) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := ss.Context()
+		a := &agentAudit{
+			method: info.FullMethod,
+			srcIP:  peerAddr(ctx),
+			emit:   func(event *auditpb.AuditEvent) { auditSvc.Emit(ctx, event) },
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: context.WithValue(ctx, agentAuditKey{}, a)})
+	}
+}
+
+// peerAddr returns the address of the caller in ctx, or "".
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+	"github.com/VuteTech/Bor/server/internal/services"
+	auditpb "github.com/VuteTech/Bor/server/pkg/grpc/audit"
+	enrollpb "github.com/VuteTech/Bor/server/pkg/grpc/enrollment"
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type captureSink struct{ events []*auditpb.AuditEvent }
+
+func (c *captureSink) Emit(_ context.Context, event *auditpb.AuditEvent) {
+	c.events = append(c.events, event)
+}
+
+func newCapturingAuditService() (*services.AuditService, *captureSink) {
+	sink := &captureSink{}
+	svc := services.NewAuditService(nil)
+	svc.AddSink(sink)
+	return svc, sink
+}
+
+func TestAgentAuditInterceptor_EmitsRecordedEvents(t *testing.T) {
+	svc, sink := newCapturingAuditService()
+	intercept := AgentAuditInterceptor(svc)
+	info := &grpc.UnaryServerInfo{FullMethod: pb.PolicyService_Heartbeat_FullMethodName}
+
+	_, err := intercept(context.Background(), &pb.HeartbeatRequest{}, info,
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			recordAgentEvent(ctx, "update", "node-id", "node-a", map[string]string{"os_version": "42"})
+			if len(sink.events) != 0 {
+				t.Error("event emitted before the handler returned")
+			}
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sink.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(sink.events))
+	}
+	ev := sink.events[0]
+	if ev.GetActor().GetUsername() != "node-a" || ev.GetActor().GetNodeId() != "node-id" ||
+		ev.GetAction() != "update" || ev.GetOutcome() != auditpb.Outcome_OUTCOME_SUCCESS {
+		t.Errorf("event = %v", ev)
+	}
+	if got := ev.GetAgent(); got.GetMethod() != info.FullMethod || got.GetDetailsJson() != `{"os_version":"42"}` {
+		t.Errorf("payload = %v", got)
+	}
+}
+
+func TestAgentAuditInterceptor_RecordsFailedEnrollment(t *testing.T) {
+	svc, sink := newCapturingAuditService()
+	intercept := AgentAuditInterceptor(svc)
+	fail := func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unauthenticated, "enrollment failed: invalid token")
+	}
+
+	_, _ = intercept(context.Background(), &enrollpb.EnrollRequest{NodeName: "node-a"},
+		&grpc.UnaryServerInfo{FullMethod: enrollpb.EnrollmentService_Enroll_FullMethodName}, fail)
+	if len(sink.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(sink.events))
+	}
+	if ev := sink.events[0]; ev.GetAction() != "enroll" || ev.GetOutcome() != auditpb.Outcome_OUTCOME_FAILURE ||
+		ev.GetActor().GetUsername() != "node-a" {
+		t.Errorf("event = %v", ev)
+	}
+
+	// Other failing RPCs record nothing on their own.
+	_, _ = intercept(context.Background(), &pb.HeartbeatRequest{},
+		&grpc.UnaryServerInfo{FullMethod: pb.PolicyService_Heartbeat_FullMethodName}, fail)
+	if len(sink.events) != 1 {
+		t.Errorf("failed heartbeat recorded %d events", len(sink.events)-1)
+	}
+}
+
+func TestRecordPolicyDelivery(t *testing.T) {
+	svc, sink := newCapturingAuditService()
+	node := &models.Node{ID: "node-id", Name: "node-a"}
+	stream := &fakeSubscribeStream{ctx: context.Background()}
+	intercept := AgentAuditStreamInterceptor(svc)
+
+	err := intercept(nil, stream, &grpc.StreamServerInfo{FullMethod: pb.PolicyService_SubscribePolicyUpdates_FullMethodName},
+		func(_ interface{}, ss grpc.ServerStream) error {
+			recordPolicyDelivery(ss.Context(), node, []*pb.PolicyUpdate{
+				{Type: pb.PolicyUpdate_METADATA_REQUEST, Revision: 6},
+				{Type: pb.PolicyUpdate_UPDATED, Revision: 7, Policy: &pb.Policy{Id: "p1", Name: "Firefox", Version: 3}},
+			})
+			if len(sink.events) != 1 {
+				t.Errorf("stream events are not emitted at once: got %d", len(sink.events))
+			}
+			recordPolicyDelivery(ss.Context(), node, []*pb.PolicyUpdate{{Type: pb.PolicyUpdate_LOG_REQUEST}})
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sink.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(sink.events))
+	}
+	var d policyDelivery
+	if err := json.Unmarshal([]byte(sink.events[0].GetAgent().GetDetailsJson()), &d); err != nil {
+		t.Fatal(err)
+	}
+	want := deliveredPolicy{ID: "p1", Name: "Firefox", Version: 3, Change: "UPDATED"}
+	if d.Revision != 7 || d.Snapshot || len(d.Policies) != 1 || d.Policies[0] != want {
+		t.Errorf("delivery = %+v", d)
+	}
+}
+
+func TestNodeMetadataChanges(t *testing.T) {
+	osVersion, agentVersion := "41", "1.2.0"
+	node := &models.Node{OSVersion: &osVersion, AgentVersion: &agentVersion}
+	info := &models.NodeHeartbeatInfo{
+		OSVersion:    "42",
+		AgentVersion: "1.2.0",
+		FQDN:         "a.example.com",
+		// Unreported facts are kept, so they are not changes.
+		KernelVersion: "",
+	}
+
+	got := nodeMetadataChanges(node, info)
+	want := map[string]metadataChange{
+		"os_version": {Old: "41", New: "42"},
+		"fqdn":       {Old: "", New: "a.example.com"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("changes = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("changes[%s] = %v, want %v", k, got[k], v)
+		}
+	}
+}
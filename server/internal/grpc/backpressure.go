@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"log"
+	"time"
+)
+
+// maxBackoffFactor caps the back-off hint at this multiple of the base.
+const maxBackoffFactor = 10
+
+// SetBackpressure enables cooperative backpressure: once more than
+// subscribers agents are connected to the policy stream, heartbeat and
+// agent-config responses carry a back-off hint that starts at base and
+// grows with the overload. subscribers <= 0 disables the hint.
+func (s *PolicyServer) SetBackpressure(subscribers int, base time.Duration) {
+	s.bpSubscribers = subscribers
+	s.bpBase = base
+}
+
+// backoffHint returns the number of seconds agents should wait before
+// reconnecting, or 0 when the server is not overloaded.
+func (s *PolicyServer) backoffHint() int32 {
+	if s.bpSubscribers <= 0 || s.hub == nil {
+		return 0
+	}
+	hint := backoffFor(s.hub.SubscriberCount(), s.bpSubscribers, s.bpBase)
+	if hint > 0 {
+		log.Printf("Backpressure: %d subscribers exceed threshold %d, asking agents to back off %v",
+			s.hub.SubscriberCount(), s.bpSubscribers, hint)
+	}
+	return int32(hint / time.Second) //nolint:gosec // capped at maxBackoffFactor × base
+}
+
+// backoffFor scales base by how far count exceeds threshold.
+func backoffFor(count, threshold int, base time.Duration) time.Duration {
+	if threshold <= 0 || count <= threshold {
+		return 0
+	}
+	factor := count / threshold
+	if factor > maxBackoffFactor {
+		factor = maxBackoffFactor
+	}
+	return base * time.Duration(factor)
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBackoffFor(t *testing.T) {
+	base := 2 * time.Minute
+	tests := []struct {
+		count, threshold int
+		want             time.Duration
+	}{
+		{count: 100, threshold: 0, want: 0},
+		{count: 50, threshold: 100, want: 0},
+		{count: 100, threshold: 100, want: 0},
+		{count: 101, threshold: 100, want: base},
+		{count: 350, threshold: 100, want: 3 * base},
+		{count: 5000, threshold: 100, want: maxBackoffFactor * base},
+	}
+	for _, tt := range tests {
+		if got := backoffFor(tt.count, tt.threshold, base); got != tt.want {
+			t.Errorf("backoffFor(%d, %d) = %v, want %v", tt.count, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyServer_BackoffHint(t *testing.T) {
+	hub := NewPolicyHub()
+	s := &PolicyServer{hub: hub}
+
+	if got := s.backoffHint(); got != 0 {
+		t.Errorf("hint with backpressure disabled = %d, want 0", got)
+	}
+
+	s.SetBackpressure(1, 30*time.Second)
+	_, cancel1 := hub.Subscribe(context.Background(), "a")
+	defer cancel1()
+	if got := s.backoffHint(); got != 0 {
+		t.Errorf("hint at threshold = %d, want 0", got)
+	}
+
+	_, cancel2 := hub.Subscribe(context.Background(), "b")
+	defer cancel2()
+	if got := s.backoffHint(); got != 60 {
+		t.Errorf("hint above threshold = %d, want 60", got)
+	}
+}
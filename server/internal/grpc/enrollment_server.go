@@ -7,7 +7,10 @@ package grpc
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"log"
+	"time"
 
 	"github.com/VuteTech/Bor/server/internal/services"
 	pb "github.com/VuteTech/Bor/server/pkg/grpc/enrollment"
@@ -48,16 +51,30 @@ func (s *EnrollmentServer) CreateEnrollmentToken(ctx context.Context, req *pb.Cr
 		return nil, status.Errorf(codes.InvalidArgument, "node_group_id is required")
 	}
 
-	token, err := s.enrollSvc.CreateToken(req.GetNodeGroupId())
+	maxUses := int(req.GetMaxUses())
+	if maxUses == 0 {
+		maxUses = 1
+	}
+	if maxUses < 1 || maxUses > services.MaxEnrollmentTokenUses {
+		return nil, status.Errorf(codes.InvalidArgument, "max_uses must be between 1 and %d", services.MaxEnrollmentTokenUses)
+	}
+	ttl := time.Duration(req.GetTtlSeconds()) * time.Second
+	if ttl < 0 || ttl > services.MaxEnrollmentTokenTTL {
+		return nil, status.Errorf(codes.InvalidArgument, "ttl_seconds must be between 1 and %d", int(services.MaxEnrollmentTokenTTL/time.Second))
+	}
+
+	token, err := s.enrollSvc.CreateMultiUseToken(req.GetNodeGroupId(), maxUses, ttl)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create enrollment token: %v", err)
 	}
 
-	log.Printf("Enrollment token created for node group %s (expires %s)", req.GetNodeGroupId(), token.ExpiresAt)
+	log.Printf("Enrollment token created for node group %s (%d uses, expires %s)", req.GetNodeGroupId(), token.MaxUses, token.ExpiresAt)
 
 	return &pb.CreateEnrollmentTokenResponse{
 		Token:     token.Token,
 		ExpiresAt: timestamppb.New(token.ExpiresAt),
+		MaxUses:   int32(token.MaxUses), //nolint:gosec // G115: bounded by MaxEnrollmentTokenUses
+		TokenId:   token.ID,
 	}, nil
 }
 
@@ -70,10 +87,14 @@ func (s *EnrollmentServer) Enroll(ctx context.Context, req *pb.EnrollRequest) (*
 		return nil, status.Errorf(codes.InvalidArgument, "csr_pem is required")
 	}
 
-	nodeGroupID, err := s.enrollSvc.ConsumeToken(req.GetEnrollmentToken())
+	token, err := s.enrollSvc.RedeemToken(req.GetEnrollmentToken())
+	if errors.Is(err, services.ErrEnrollmentTokenExhausted) {
+		return nil, status.Errorf(codes.ResourceExhausted, "enrollment failed: %v", err)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "enrollment failed: %v", err)
 	}
+	nodeGroupID := token.NodeGroupID
 
 	signedCert, serial, notAfter, err := s.enrollSvc.SignCSR(req.GetCsrPem())
 	if err != nil {
@@ -87,8 +108,16 @@ func (s *EnrollmentServer) Enroll(ctx context.Context, req *pb.EnrollRequest) (*
 		nodeName = "unnamed-agent"
 	}
 
+	// Count the enrollment against its campaign only once the CSR has been
+	// accepted, so malformed requests do not use up the campaign's limit.
+	if token.CampaignID != "" {
+		if err := s.enrollSvc.ReserveCampaignEnrollment(ctx, token.CampaignID); err != nil {
+			return nil, status.Errorf(codes.ResourceExhausted, "enrollment failed: %v", err)
+		}
+	}
+
 	// Create node record in database
-	nodeID, err := s.enrollSvc.CreateNodeOnEnroll(ctx, nodeName, nodeGroupID)
+	nodeID, err := s.enrollSvc.CreateNodeOnEnroll(ctx, nodeName, nodeGroupID, token.CampaignID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "enrolled but failed to create node record: %v", err)
 	}
@@ -98,8 +127,13 @@ func (s *EnrollmentServer) Enroll(ctx context.Context, req *pb.EnrollRequest) (*
 		log.Printf("Warning: failed to store cert serial for node %s: %v", nodeID, err)
 	}
 
-	log.Printf("Agent enrolled: name=%s group=%s node_id=%s cert_serial=%s expires=%s",
-		nodeName, nodeGroupID, nodeID, serial, notAfter.Format("2006-01-02"))
+	log.Printf("Agent enrolled: name=%s group=%s campaign=%s node_id=%s cert_serial=%s expires=%s",
+		nodeName, nodeGroupID, token.CampaignID, nodeID, serial, notAfter.Format("2006-01-02"))
+	recordAgentEvent(ctx, "enroll", nodeID, nodeName, enrollDetails{
+		NodeGroupID: nodeGroupID,
+		CampaignID:  token.CampaignID,
+		CertSerial:  serial,
+	})
 
 	return &pb.EnrollResponse{
 		NodeId:            nodeID,
@@ -149,7 +183,7 @@ func (s *EnrollmentServer) KerberosEnroll(ctx context.Context, req *pb.KerberosE
 		nodeName = services.PrincipalToHostname(principal)
 	}
 
-	nodeID, err := s.enrollSvc.CreateNodeOnEnroll(ctx, nodeName, nodeGroupID)
+	nodeID, err := s.enrollSvc.CreateNodeOnEnroll(ctx, nodeName, nodeGroupID, "")
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "enrolled but failed to create node record: %v", err)
 	}
@@ -160,6 +194,11 @@ func (s *EnrollmentServer) KerberosEnroll(ctx context.Context, req *pb.KerberosE
 
 	log.Printf("Kerberos agent enrolled: principal=%s name=%s group=%s node_id=%s cert_serial=%s expires=%s",
 		principal, nodeName, nodeGroupID, nodeID, serial, notAfter.Format("2006-01-02"))
+	recordAgentEvent(ctx, "enroll", nodeID, nodeName, enrollDetails{
+		NodeGroupID: nodeGroupID,
+		CertSerial:  serial,
+		Principal:   principal,
+	})
 
 	return &pb.EnrollResponse{
 		NodeId:            nodeID,
@@ -169,7 +208,17 @@ func (s *EnrollmentServer) KerberosEnroll(ctx context.Context, req *pb.KerberosE
 	}, nil
 }
 
-// checkAdminAuth validates the x-admin-token metadata header.
+// enrollDetails is the audit record of a successful enrollment.
+type enrollDetails struct {
+	NodeGroupID string `json:"node_group_id"`
+	CampaignID  string `json:"campaign_id,omitempty"`
+	CertSerial  string `json:"cert_serial"`
+	Principal   string `json:"principal,omitempty"` // Kerberos enrollments only
+}
+
+// checkAdminAuth validates the x-admin-token metadata header. It fails
+// closed when no admin token is configured and compares the token in
+// constant time.
 func (s *EnrollmentServer) checkAdminAuth(ctx context.Context) error {
 	if s.adminToken == "" {
 		return status.Errorf(codes.FailedPrecondition, "BOR_ADMIN_TOKEN not configured on server")
@@ -185,7 +234,7 @@ func (s *EnrollmentServer) checkAdminAuth(ctx context.Context) error {
 		return status.Errorf(codes.Unauthenticated, "x-admin-token header required")
 	}
 
-	if tokens[0] != s.adminToken {
+	if subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(s.adminToken)) != 1 {
 		return status.Errorf(codes.PermissionDenied, "invalid admin token")
 	}
 
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckAdminAuth(t *testing.T) {
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-admin-token", token))
+	}
+
+	tests := []struct {
+		name       string
+		configured string
+		ctx        context.Context
+		want       codes.Code
+	}{
+		{"valid token", "secret", withToken("secret"), codes.OK},
+		{"wrong token", "secret", withToken("secreT"), codes.PermissionDenied},
+		{"token prefix", "secret", withToken("sec"), codes.PermissionDenied},
+		{"empty token sent", "secret", withToken(""), codes.PermissionDenied},
+		{"missing header", "secret", metadata.NewIncomingContext(context.Background(), metadata.MD{}), codes.Unauthenticated},
+		{"missing metadata", "secret", context.Background(), codes.Unauthenticated},
+		{"empty configured token", "", withToken(""), codes.FailedPrecondition},
+		{"empty configured token with token sent", "", withToken("anything"), codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewEnrollmentServer(nil, tt.configured)
+			if got := status.Code(s.checkAdminAuth(tt.ctx)); got != tt.want {
+				t.Errorf("code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ package grpc
 
 import (
 	"context"
+	"crypto/x509"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -20,62 +22,193 @@ type RevocationChecker interface {
 	IsRevoked(ctx context.Context, serial string) (bool, error)
 }
 
-// RequireClientCertInterceptor returns a unary server interceptor that
-// requires a verified TLS client certificate for all methods except those
-// in the exemptMethods set (e.g. the Enroll RPC which bootstraps mTLS).
-// If rc is non-nil the cert serial is also checked against the revocation list.
-func RequireClientCertInterceptor(exemptMethods map[string]bool, rc RevocationChecker) grpc.UnaryServerInterceptor {
+// MethodAuthorizer decides whether the caller in ctx may invoke an RPC. It
+// returns a gRPC status error when the call must be rejected.
+type MethodAuthorizer func(ctx context.Context) error
+
+// AuthPolicy maps full gRPC method names ("/package.Service/Method") to the
+// authorizer guarding them. Methods without an entry use Default; a nil
+// Default rejects them.
+type AuthPolicy struct {
+	Methods map[string]MethodAuthorizer
+	Default MethodAuthorizer
+}
+
+// NewAuthPolicy returns an AuthPolicy applying def to every method.
+func NewAuthPolicy(def MethodAuthorizer) *AuthPolicy {
+	return &AuthPolicy{Methods: make(map[string]MethodAuthorizer), Default: def}
+}
+
+// Set guards method with a, replacing any previous authorizer.
+func (p *AuthPolicy) Set(method string, a MethodAuthorizer) *AuthPolicy {
+	p.Methods[method] = a
+	return p
+}
+
+// Exempt lets methods be called without a client certificate.
+func (p *AuthPolicy) Exempt(methods ...string) *AuthPolicy {
+	for _, m := range methods {
+		p.Methods[m] = AllowAny()
+	}
+	return p
+}
+
+// Authorize applies the authorizer configured for method.
+func (p *AuthPolicy) Authorize(ctx context.Context, method string) error {
+	a, ok := p.Methods[method]
+	if !ok {
+		a = p.Default
+	}
+	if a == nil {
+		return status.Errorf(codes.PermissionDenied, "method %s is not allowed", method)
+	}
+	return a(ctx)
+}
+
+// AllowAny accepts every caller, with or without a client certificate.
+// It is meant for bootstrapping calls such as Enroll.
+func AllowAny() MethodAuthorizer {
+	return func(context.Context) error { return nil }
+}
+
+// RequireClientCert accepts callers presenting a verified TLS client
+// certificate. If rc is non-nil the cert serial is also checked against the
+// revocation list.
+func RequireClientCert(rc RevocationChecker) MethodAuthorizer {
+	return func(ctx context.Context) error {
+		if err := verifyClientCert(ctx); err != nil {
+			return err
+		}
+		if rc != nil {
+			return checkRevocation(ctx, rc)
+		}
+		return nil
+	}
+}
+
+// RequireRenewableClientCert is like RequireClientCert but additionally
+// requires the certificate to be in the second half of its lifetime. Agents
+// renew once a third of the lifetime remains, so this leaves ample margin
+// for clock skew while preventing a stolen certificate from being renewed
+// indefinitely right after issuance.
+func RequireRenewableClientCert(rc RevocationChecker) MethodAuthorizer {
+	requireCert := RequireClientCert(rc)
+	return func(ctx context.Context) error {
+		if err := requireCert(ctx); err != nil {
+			return err
+		}
+		cert, err := clientCert(ctx)
+		if err != nil {
+			return err
+		}
+		lifetime := cert.NotAfter.Sub(cert.NotBefore)
+		if time.Until(cert.NotAfter) > lifetime/2 {
+			return status.Errorf(codes.FailedPrecondition, "certificate is not yet due for renewal")
+		}
+		return nil
+	}
+}
+
+// AuthPolicyInterceptor returns a unary server interceptor enforcing p.
+// The CN of a verified client certificate is made available to the
+// handler through ClientCN.
+func AuthPolicyInterceptor(p *AuthPolicy) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req interface{},
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		if exemptMethods[info.FullMethod] {
-			return handler(ctx, req)
-		}
-
-		if err := verifyClientCert(ctx); err != nil {
+		if err := p.Authorize(ctx, info.FullMethod); err != nil {
 			return nil, err
 		}
-
-		if rc != nil {
-			if err := checkRevocation(ctx, rc); err != nil {
-				return nil, err
-			}
-		}
-
-		return handler(ctx, req)
+		return handler(withClientCN(ctx), req)
 	}
 }
 
-// RequireClientCertStreamInterceptor returns a stream server interceptor
-// that requires a verified TLS client certificate for all streaming
-// methods except those in the exemptMethods set.
-// If rc is non-nil the cert serial is also checked against the revocation list.
-func RequireClientCertStreamInterceptor(exemptMethods map[string]bool, rc RevocationChecker) grpc.StreamServerInterceptor {
+// AuthPolicyStreamInterceptor returns a stream server interceptor enforcing
+// p. Like AuthPolicyInterceptor it makes the client certificate CN
+// available through ClientCN.
+func AuthPolicyStreamInterceptor(p *AuthPolicy) grpc.StreamServerInterceptor {
 	return func(
 		srv interface{},
 		ss grpc.ServerStream,
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
-		if exemptMethods[info.FullMethod] {
-			return handler(srv, ss)
-		}
-
-		if err := verifyClientCert(ss.Context()); err != nil {
+		if err := p.Authorize(ss.Context(), info.FullMethod); err != nil {
 			return err
 		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: withClientCN(ss.Context())})
+	}
+}
 
-		if rc != nil {
-			if err := checkRevocation(ss.Context(), rc); err != nil {
-				return err
-			}
-		}
+// authenticatedStream is a ServerStream whose context was extended by an
+// interceptor, e.g. with the client certificate CN.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context { return s.ctx }
+
+type clientCNKey struct{}
+
+// withClientCN stores the CN of the verified client certificate in ctx, if
+// the peer presented one.
+func withClientCN(ctx context.Context) context.Context {
+	cert, err := clientCert(ctx)
+	if err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, clientCNKey{}, cert.Subject.CommonName)
+}
+
+// ClientCN returns the CN of the verified client certificate stored in ctx
+// by the auth interceptors.
+func ClientCN(ctx context.Context) (string, bool) {
+	cn, ok := ctx.Value(clientCNKey{}).(string)
+	return cn, ok
+}
+
+// requireClientID rejects a request whose claimed client_id is not the CN
+// of the caller's client certificate, so that one agent's certificate
+// cannot be used to act as another node.
+func requireClientID(ctx context.Context, clientID string) error {
+	cn, ok := ClientCN(ctx)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "no verified client certificate")
+	}
+	if cn != clientID {
+		return status.Errorf(codes.PermissionDenied, "client_id %q does not match the client certificate", clientID)
+	}
+	return nil
+}
+
+// RequireClientCertInterceptor returns a unary server interceptor that
+// requires a verified TLS client certificate for all methods except those
+// in the exemptMethods set (e.g. the Enroll RPC which bootstraps mTLS).
+// If rc is non-nil the cert serial is also checked against the revocation list.
+func RequireClientCertInterceptor(exemptMethods map[string]bool, rc RevocationChecker) grpc.UnaryServerInterceptor {
+	return AuthPolicyInterceptor(exemptPolicy(exemptMethods, rc))
+}
+
+// RequireClientCertStreamInterceptor returns a stream server interceptor
+// that requires a verified TLS client certificate for all streaming
+// methods except those in the exemptMethods set.
+// If rc is non-nil the cert serial is also checked against the revocation list.
+func RequireClientCertStreamInterceptor(exemptMethods map[string]bool, rc RevocationChecker) grpc.StreamServerInterceptor {
+	return AuthPolicyStreamInterceptor(exemptPolicy(exemptMethods, rc))
+}
 
-		return handler(srv, ss)
+func exemptPolicy(exemptMethods map[string]bool, rc RevocationChecker) *AuthPolicy {
+	p := NewAuthPolicy(RequireClientCert(rc))
+	for m, exempt := range exemptMethods {
+		if exempt {
+			p.Exempt(m)
+		}
 	}
+	return p
 }
 
 // verifyClientCert checks that the context contains a verified TLS client certificate.
@@ -97,18 +230,26 @@ func verifyClientCert(ctx context.Context) error {
 	return nil
 }
 
-// extractCertSerial extracts the serial number (as a lowercase hex string) from
-// the verified TLS client certificate in the context.
-func extractCertSerial(ctx context.Context) (string, error) {
+// clientCert returns the verified TLS client certificate in the context.
+func clientCert(ctx context.Context) (*x509.Certificate, error) {
 	p, ok := peer.FromContext(ctx)
 	if !ok {
-		return "", status.Errorf(codes.Unauthenticated, "no peer info")
+		return nil, status.Errorf(codes.Unauthenticated, "no peer info")
 	}
 	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
 	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
-		return "", status.Errorf(codes.Unauthenticated, "no verified client certificate")
+		return nil, status.Errorf(codes.Unauthenticated, "no verified client certificate")
+	}
+	return tlsInfo.State.VerifiedChains[0][0], nil
+}
+
+// extractCertSerial extracts the serial number (as a lowercase hex string) from
+// the verified TLS client certificate in the context.
+func extractCertSerial(ctx context.Context) (string, error) {
+	cert, err := clientCert(ctx)
+	if err != nil {
+		return "", err
 	}
-	cert := tlsInfo.State.VerifiedChains[0][0]
 	return cert.SerialNumber.Text(16), nil
 }
 
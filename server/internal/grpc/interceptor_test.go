@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type fakeRevocations map[string]bool
+
+func (f fakeRevocations) IsRevoked(_ context.Context, serial string) (bool, error) {
+	return f[serial], nil
+}
+
+// ctxWithCert returns a context whose peer presents a verified client
+// certificate valid from notBefore to notAfter.
+func ctxWithCert(serial int64, notBefore, notAfter time.Time) context.Context {
+	cert := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	info := credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{cert}},
+	}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+}
+
+func TestAuthPolicy_Authorize(t *testing.T) {
+	now := time.Now()
+	fresh := ctxWithCert(0x10, now.Add(-time.Hour), now.Add(89*24*time.Hour))
+	expiring := ctxWithCert(0x11, now.Add(-80*24*time.Hour), now.Add(10*24*time.Hour))
+	revoked := ctxWithCert(0x12, now.Add(-80*24*time.Hour), now.Add(10*24*time.Hour))
+	noCert := context.Background()
+
+	rc := fakeRevocations{"12": true}
+	p := NewAuthPolicy(RequireClientCert(rc)).
+		Exempt("/svc/Enroll").
+		Set("/svc/Renew", RequireRenewableClientCert(rc))
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+	}{
+		{"exempt without cert", noCert, "/svc/Enroll", codes.OK},
+		{"default without cert", noCert, "/svc/Ping", codes.Unauthenticated},
+		{"default with cert", fresh, "/svc/Ping", codes.OK},
+		{"default with revoked cert", revoked, "/svc/Ping", codes.Unauthenticated},
+		{"renew with fresh cert", fresh, "/svc/Renew", codes.FailedPrecondition},
+		{"renew with expiring cert", expiring, "/svc/Renew", codes.OK},
+		{"renew with revoked cert", revoked, "/svc/Renew", codes.Unauthenticated},
+		{"renew without cert", noCert, "/svc/Renew", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Authorize(tt.ctx, tt.method)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("Authorize() code = %v, want %v (err = %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAuthPolicy_NilDefaultDenies(t *testing.T) {
+	p := NewAuthPolicy(nil).Exempt("/svc/Enroll")
+	if err := p.Authorize(context.Background(), "/svc/Other"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Authorize() = %v, want PermissionDenied", err)
+	}
+	if err := p.Authorize(context.Background(), "/svc/Enroll"); err != nil {
+		t.Errorf("Authorize() exempt method = %v, want nil", err)
+	}
+}
+
+// ctxAuthenticatedAs returns a context as seen by a handler behind the
+// auth interceptors for a peer whose verified certificate has CN cn.
+func ctxAuthenticatedAs(cn string) context.Context {
+	cert := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: cn}}
+	info := credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{cert}},
+	}}
+	return withClientCN(peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info}))
+}
+
+func TestAuthPolicyInterceptor_StoresClientCN(t *testing.T) {
+	now := time.Now()
+	ctx := ctxWithCert(0x10, now.Add(-time.Hour), now.Add(time.Hour))
+	p, _ := peer.FromContext(ctx)
+	p.AuthInfo.(credentials.TLSInfo).State.VerifiedChains[0][0].Subject.CommonName = "node-a"
+
+	intercept := AuthPolicyInterceptor(NewAuthPolicy(RequireClientCert(nil)))
+	var got string
+	_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Ping"},
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			got, _ = ClientCN(ctx)
+			return nil, nil
+		})
+	if err != nil || got != "node-a" {
+		t.Errorf("handler saw CN %q (err %v), want node-a", got, err)
+	}
+}
+
+func TestRequireClientID(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		clientID string
+		want     codes.Code
+	}{
+		{"matching CN", ctxAuthenticatedAs("node-a"), "node-a", codes.OK},
+		{"other node", ctxAuthenticatedAs("node-a"), "node-b", codes.PermissionDenied},
+		{"no certificate", context.Background(), "node-a", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(requireClientID(tt.ctx, tt.clientID)); got != tt.want {
+				t.Errorf("requireClientID() code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeSubscribeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeSubscribeStream) Context() context.Context    { return f.ctx }
+func (f *fakeSubscribeStream) Send(*pb.PolicyUpdate) error { return nil }
+
+func TestPolicyServer_RejectsForeignClientID(t *testing.T) {
+	s := &PolicyServer{}
+	ctx := ctxAuthenticatedAs("node-a")
+
+	_, err := s.Heartbeat(ctx, &pb.HeartbeatRequest{ClientId: "node-b"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Heartbeat() = %v, want PermissionDenied", err)
+	}
+	_, err = s.ReportCompliance(ctx, &pb.ReportComplianceRequest{ClientId: "node-b", PolicyId: "p1"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ReportCompliance() = %v, want PermissionDenied", err)
+	}
+	_, err = s.ReportComplianceBatch(ctx, &pb.ReportComplianceBatchRequest{ClientId: "node-b"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ReportComplianceBatch() = %v, want PermissionDenied", err)
+	}
+	_, err = s.ReportComplianceBatch(ctx, &pb.ReportComplianceBatchRequest{
+		ClientId: "node-a",
+		Reports:  []*pb.ReportComplianceRequest{{ClientId: "node-b", PolicyId: "p1"}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ReportComplianceBatch() with a foreign report = %v, want InvalidArgument", err)
+	}
+	_, err = s.ListPolicies(ctx, &pb.ListPoliciesRequest{ClientId: "node-b"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListPolicies() = %v, want PermissionDenied", err)
+	}
+	err = s.SubscribePolicyUpdates(&pb.SubscribePolicyUpdatesRequest{ClientId: "node-b"}, &fakeSubscribeStream{ctx: ctx})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("SubscribePolicyUpdates() = %v, want PermissionDenied", err)
+	}
+}
@@ -8,12 +8,14 @@ import (
 	"context"
 	"log"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
 )
 
-// defaultEventLogSize is the maximum number of events kept in the
-// ring buffer for delta computation. Events beyond this are compacted
+// defaultEventLogSize is the default maximum number of events kept in
+// the ring buffer for delta computation. Events beyond this are compacted
 // (dropped), forcing reconnecting clients to do a full snapshot.
 const defaultEventLogSize = 1000
 
@@ -23,6 +25,7 @@ const defaultEventLogSize = 1000
 type hubEvent struct {
 	update           *pb.PolicyUpdate
 	affectedGroupIDs []string // nil/empty = broadcast to all agents
+	refreshNode      bool     // reload the node before resyncing
 }
 
 // PolicyHub is an in-process publish/subscribe hub that tracks policy
@@ -40,14 +43,35 @@ type PolicyHub struct {
 	maxLogSize  int
 	subscribers map[chan *hubEvent]struct{}
 	clients     map[string]chan *hubEvent // clientID → channel
+
+	// deltaMisses counts EventsSince calls that could not be served from
+	// the event log.
+	deltaMisses atomic.Int64
+
+	// drainCh is closed by Drain; streams then send GOING_AWAY and end.
+	drainCh        chan struct{}
+	drainOnce      sync.Once
+	reconnectAfter time.Duration
 }
 
-// NewPolicyHub creates a ready-to-use PolicyHub.
+// NewPolicyHub creates a ready-to-use PolicyHub with the default event
+// log size.
 func NewPolicyHub() *PolicyHub {
+	return NewPolicyHubWithSize(defaultEventLogSize)
+}
+
+// NewPolicyHubWithSize creates a PolicyHub that keeps up to n events for
+// delta sync. A larger log lets agents that fell further behind catch up
+// with a delta instead of a full snapshot. n < 1 uses defaultEventLogSize.
+func NewPolicyHubWithSize(n int) *PolicyHub {
+	if n < 1 {
+		n = defaultEventLogSize
+	}
 	return &PolicyHub{
-		maxLogSize:  defaultEventLogSize,
+		maxLogSize:  n,
 		subscribers: make(map[chan *hubEvent]struct{}),
 		clients:     make(map[string]chan *hubEvent),
+		drainCh:     make(chan struct{}),
 	}
 }
 
@@ -107,7 +131,8 @@ func (h *PolicyHub) Publish(updateType pb.PolicyUpdate_UpdateType, policy *pb.Po
 }
 
 // EventsSince returns all events with revision > sinceRevision.
-// Returns nil if the requested revision has been compacted (too old).
+// Returns nil if the requested revision has been compacted (too old);
+// such misses are counted, see DeltaMisses.
 func (h *PolicyHub) EventsSince(sinceRevision int64) []*pb.PolicyUpdate {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -116,11 +141,13 @@ func (h *PolicyHub) EventsSince(sinceRevision int64) []*pb.PolicyUpdate {
 		if sinceRevision >= h.revision {
 			return []*pb.PolicyUpdate{}
 		}
+		h.deltaMisses.Add(1)
 		return nil
 	}
 
 	oldestAvailable := h.eventLog[0].Revision
 	if sinceRevision < oldestAvailable-1 {
+		h.deltaMisses.Add(1)
 		return nil
 	}
 
@@ -133,20 +160,42 @@ func (h *PolicyHub) EventsSince(sinceRevision int64) []*pb.PolicyUpdate {
 	return result
 }
 
-// PublishResync bumps the revision and broadcasts a resync signal.
-// affectedGroupIDs scopes the signal: only agents whose node groups overlap
-// with affectedGroupIDs will trigger a fresh snapshot. When affectedGroupIDs
-// is empty (or nil), all connected agents are signalled.
-func (h *PolicyHub) PublishResync(affectedGroupIDs ...string) {
-	h.publish(pb.PolicyUpdate_SNAPSHOT, nil, affectedGroupIDs)
+// DeltaMisses returns how many times EventsSince could not serve a delta
+// because the requested revision was no longer in the event log.
+func (h *PolicyHub) DeltaMisses() int64 {
+	return h.deltaMisses.Load()
+}
+
+// PublishResync bumps the revision and signals every connected agent to
+// take a fresh snapshot. Use it only for changes that cannot be scoped to
+// node groups; see PublishResyncForGroups.
+func (h *PolicyHub) PublishResync() {
+	h.publish(pb.PolicyUpdate_SNAPSHOT, nil, nil)
+}
+
+// PublishResyncForGroups bumps the revision and publishes a resync signal
+// that only agents in one of groupIDs act on. An empty groupIDs affects no
+// agent and publishes nothing.
+func (h *PolicyHub) PublishResyncForGroups(groupIDs []string) {
+	if len(groupIDs) == 0 {
+		return
+	}
+	h.publish(pb.PolicyUpdate_SNAPSHOT, nil, groupIDs)
 }
 
 // IsResyncSignal returns true if the event is a resync signal
-// (published by PublishResync).
+// (published by PublishResync or PublishResyncForGroups).
 func IsResyncSignal(ev *pb.PolicyUpdate) bool {
 	return ev.Type == pb.PolicyUpdate_SNAPSHOT && ev.Policy == nil
 }
 
+// SubscriberCount returns the number of connected streaming clients.
+func (h *PolicyHub) SubscriberCount() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers)
+}
+
 // Subscribe returns a channel that will receive future events and a
 // cancel function. The caller MUST call cancel when done (e.g. when
 // the gRPC stream ends) to avoid resource leaks. clientID is used
@@ -175,6 +224,36 @@ func (h *PolicyHub) Subscribe(_ context.Context, clientID string) (<-chan *hubEv
 	return ch, cancel
 }
 
+// SendResyncRequest sends a resync signal directly to the named client's
+// stream, causing it to reload its node and receive a fresh snapshot.
+// Returns false if the client is not connected.
+func (h *PolicyHub) SendResyncRequest(clientID string) bool {
+	h.mu.RLock()
+	ch, ok := h.clients[clientID]
+	rev := h.revision
+	h.mu.RUnlock()
+
+	if !ok {
+		return false
+	}
+
+	ev := &hubEvent{
+		update: &pb.PolicyUpdate{
+			Type:     pb.PolicyUpdate_SNAPSHOT,
+			Revision: rev,
+		},
+		refreshNode: true,
+	}
+
+	select {
+	case ch <- ev:
+		return true
+	default:
+		log.Printf("policy_hub: dropping resync request for slow subscriber %s", clientID)
+		return false
+	}
+}
+
 // SendMetadataRefreshRequest sends a METADATA_REQUEST event directly to
 // the named client's stream. Returns false if the client is not connected.
 func (h *PolicyHub) SendMetadataRefreshRequest(clientID string) bool {
@@ -202,3 +281,139 @@ func (h *PolicyHub) SendMetadataRefreshRequest(clientID string) bool {
 		return false
 	}
 }
+
+// SendLogRequest sends a LOG_REQUEST event carrying requestID directly to
+// the named client's stream. Returns false if the client is not connected.
+func (h *PolicyHub) SendLogRequest(clientID, requestID string) bool {
+	h.mu.RLock()
+	ch, ok := h.clients[clientID]
+	rev := h.revision
+	h.mu.RUnlock()
+
+	if !ok {
+		return false
+	}
+
+	ev := &hubEvent{
+		update: &pb.PolicyUpdate{
+			Type:         pb.PolicyUpdate_LOG_REQUEST,
+			Revision:     rev,
+			LogRequestId: requestID,
+		},
+	}
+
+	select {
+	case ch <- ev:
+		return true
+	default:
+		log.Printf("policy_hub: dropping LOG_REQUEST for slow subscriber %s", clientID)
+		return false
+	}
+}
+
+// SendRemediationRequest sends a REMEDIATION_REQUEST event asking the named
+// client to run a predefined remediation action. Returns false if the
+// client is not connected.
+func (h *PolicyHub) SendRemediationRequest(clientID, requestID, action string) bool {
+	h.mu.RLock()
+	ch, ok := h.clients[clientID]
+	rev := h.revision
+	h.mu.RUnlock()
+
+	if !ok {
+		return false
+	}
+
+	ev := &hubEvent{
+		update: &pb.PolicyUpdate{
+			Type:                 pb.PolicyUpdate_REMEDIATION_REQUEST,
+			Revision:             rev,
+			RemediationRequestId: requestID,
+			RemediationAction:    action,
+		},
+	}
+
+	select {
+	case ch <- ev:
+		return true
+	default:
+		log.Printf("policy_hub: dropping REMEDIATION_REQUEST for slow subscriber %s", clientID)
+		return false
+	}
+}
+
+// Disconnect ends the named client's stream with a GOING_AWAY event, used
+// when its node has been deleted. An agent whose certificate was revoked
+// is then rejected when it reconnects. Returns false if the client is not
+// connected.
+func (h *PolicyHub) Disconnect(clientID string) bool {
+	h.mu.RLock()
+	ch, ok := h.clients[clientID]
+	rev := h.revision
+	h.mu.RUnlock()
+
+	if !ok {
+		return false
+	}
+
+	ev := &hubEvent{
+		update: &pb.PolicyUpdate{
+			Type:     pb.PolicyUpdate_GOING_AWAY,
+			Revision: rev,
+		},
+	}
+
+	select {
+	case ch <- ev:
+		return true
+	default:
+		log.Printf("policy_hub: dropping GOING_AWAY for slow subscriber %s", clientID)
+		return false
+	}
+}
+
+// Drain starts a graceful shutdown of the policy streams: every watching
+// stream sends its agent a GOING_AWAY event asking it to reconnect after
+// reconnectAfter, then ends cleanly, and new subscriptions are refused.
+// Calling Drain more than once has no further effect.
+func (h *PolicyHub) Drain(reconnectAfter time.Duration) {
+	h.drainOnce.Do(func() {
+		h.mu.Lock()
+		h.reconnectAfter = reconnectAfter
+		h.mu.Unlock()
+		close(h.drainCh)
+	})
+}
+
+// Draining returns a channel that is closed once Drain has been called.
+func (h *PolicyHub) Draining() <-chan struct{} {
+	return h.drainCh
+}
+
+// GoingAway returns the GOING_AWAY event streams send while draining.
+func (h *PolicyHub) GoingAway() *pb.PolicyUpdate {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return &pb.PolicyUpdate{
+		Type:                  pb.PolicyUpdate_GOING_AWAY,
+		Revision:              h.revision,
+		ReconnectAfterSeconds: int32(h.reconnectAfter / time.Second), //nolint:gosec // configured delay fits in int32
+	}
+}
+
+// WaitDrained blocks until every subscriber has left or ctx is done. It
+// reports whether all subscribers left.
+func (h *PolicyHub) WaitDrained(ctx context.Context) bool {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if h.SubscriberCount() == 0 {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
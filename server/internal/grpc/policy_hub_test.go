@@ -103,9 +103,8 @@ func TestPolicyHub_EventsSince_EmptyHub(t *testing.T) {
 }
 
 func TestPolicyHub_EventsSince_Compacted(t *testing.T) {
-	hub := NewPolicyHub()
 	// Use a small log size to force compaction.
-	hub.maxLogSize = 5
+	hub := NewPolicyHubWithSize(5)
 
 	for i := 0; i < 10; i++ {
 		hub.Publish(pb.PolicyUpdate_CREATED, makeTestPolicy("p", "P"))
@@ -117,12 +116,24 @@ func TestPolicyHub_EventsSince_Compacted(t *testing.T) {
 	if events != nil {
 		t.Fatalf("EventsSince(0) after compaction should return nil, got %d events", len(events))
 	}
+	if got := hub.DeltaMisses(); got != 1 {
+		t.Errorf("DeltaMisses() = %d, want 1", got)
+	}
 
 	// Asking for a recent revision should still work.
 	events = hub.EventsSince(hub.Revision() - 1)
 	if events == nil {
 		t.Fatal("EventsSince(recent) after compaction should not return nil")
 	}
+	if got := hub.DeltaMisses(); got != 1 {
+		t.Errorf("DeltaMisses() after a served delta = %d, want 1", got)
+	}
+}
+
+func TestNewPolicyHubWithSize_DefaultsInvalidSize(t *testing.T) {
+	if got := NewPolicyHubWithSize(0).maxLogSize; got != defaultEventLogSize {
+		t.Errorf("maxLogSize = %d, want default %d", got, defaultEventLogSize)
+	}
 }
 
 func TestPolicyHub_Subscribe_ReceivesPublishedEvents(t *testing.T) {
@@ -375,3 +386,84 @@ func TestPolicyHub_PublishResync_SubscriberReceivesSignal(t *testing.T) {
 		t.Fatal("timed out waiting for resync event")
 	}
 }
+
+func TestPolicyHub_PublishResyncForGroups(t *testing.T) {
+	hub := NewPolicyHub()
+	ch, unsub := hub.Subscribe(context.Background(), "")
+	defer unsub()
+
+	// No groups: nothing to signal.
+	hub.PublishResyncForGroups(nil)
+	if got := hub.Revision(); got != 0 {
+		t.Errorf("revision after empty PublishResyncForGroups = %d, want 0", got)
+	}
+
+	hub.PublishResyncForGroups([]string{"g1", "g2"})
+	select {
+	case ev := <-ch:
+		if !IsResyncSignal(ev.update) {
+			t.Error("expected resync signal")
+		}
+		if !groupsOverlap([]string{"g2"}, ev.affectedGroupIDs) || groupsOverlap([]string{"g3"}, ev.affectedGroupIDs) {
+			t.Errorf("affectedGroupIDs = %v, want [g1 g2]", ev.affectedGroupIDs)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for resync event")
+	}
+}
+
+func TestPolicyHub_Drain(t *testing.T) {
+	hub := NewPolicyHub()
+	_, cancel := hub.Subscribe(context.Background(), "node-1")
+
+	select {
+	case <-hub.Draining():
+		t.Fatal("hub must not be draining before Drain")
+	default:
+	}
+
+	hub.Drain(15 * time.Second)
+	hub.Drain(time.Hour) // no effect
+
+	select {
+	case <-hub.Draining():
+	default:
+		t.Fatal("Draining channel should be closed after Drain")
+	}
+	if got := hub.GoingAway(); got.GetType() != pb.PolicyUpdate_GOING_AWAY || got.GetReconnectAfterSeconds() != 15 {
+		t.Errorf("GoingAway = %v, want GOING_AWAY with 15s", got)
+	}
+
+	ctx, stop := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	if hub.WaitDrained(ctx) {
+		t.Error("WaitDrained should time out while a subscriber is connected")
+	}
+	stop()
+
+	cancel()
+	if !hub.WaitDrained(context.Background()) {
+		t.Error("WaitDrained should succeed once subscribers have left")
+	}
+}
+
+func TestPolicyHub_Disconnect(t *testing.T) {
+	hub := NewPolicyHub()
+	ch, cancel := hub.Subscribe(context.Background(), "node-1")
+	defer cancel()
+
+	if hub.Disconnect("node-2") {
+		t.Error("Disconnect should fail for a client that is not connected")
+	}
+	if !hub.Disconnect("node-1") {
+		t.Fatal("Disconnect failed for a connected client")
+	}
+
+	select {
+	case ev := <-ch:
+		if ev.update.GetType() != pb.PolicyUpdate_GOING_AWAY {
+			t.Errorf("got %v, want GOING_AWAY", ev.update.GetType())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for GOING_AWAY")
+	}
+}
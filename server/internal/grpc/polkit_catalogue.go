@@ -20,6 +20,9 @@ func (s *PolicyServer) ReportPolkitCatalogue(ctx context.Context, req *pb.Report
 	if clientID == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
 	}
+	if err := requireClientID(ctx, clientID); err != nil {
+		return nil, err
+	}
 
 	if s.polkitRepo == nil {
 		// No database configured — accept silently.
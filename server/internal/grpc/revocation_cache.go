@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// RevokedSerialLister lists the serials of all revoked client certificates.
+type RevokedSerialLister interface {
+	ListSerials(ctx context.Context) ([]string, error)
+}
+
+// RevocationCache is a RevocationChecker that keeps the revoked serials in
+// memory instead of querying the database on every RPC. The set is reloaded
+// once it is older than the refresh interval, which bounds how long a
+// revocation made by another server instance takes to apply here;
+// revocations made by this instance call Invalidate and apply on the next
+// check.
+type RevocationCache struct {
+	src     RevokedSerialLister
+	refresh time.Duration
+
+	mu       sync.Mutex
+	serials  map[string]struct{}
+	loadedAt time.Time // zero forces a reload
+}
+
+// NewRevocationCache returns a RevocationCache reading from src.
+func NewRevocationCache(src RevokedSerialLister, refresh time.Duration) *RevocationCache {
+	return &RevocationCache{src: src, refresh: refresh}
+}
+
+// IsRevoked reports whether serial is in the revoked set. When a reload
+// fails the previous set stays in use until the next refresh; only a cache
+// that has never loaded returns the error, so checks fail closed.
+func (c *RevocationCache) IsRevoked(ctx context.Context, serial string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if time.Since(c.loadedAt) >= c.refresh {
+		if err := c.reload(ctx); err != nil {
+			if c.serials == nil {
+				return false, err
+			}
+			log.Printf("Failed to refresh revoked certificates, using cached list: %v", err)
+			c.loadedAt = time.Now()
+		}
+	}
+	_, revoked := c.serials[serial]
+	return revoked, nil
+}
+
+// Invalidate makes the next check reload the revoked set.
+func (c *RevocationCache) Invalidate() {
+	c.mu.Lock()
+	c.loadedAt = time.Time{}
+	c.mu.Unlock()
+}
+
+func (c *RevocationCache) reload(ctx context.Context) error {
+	serials, err := c.src.ListSerials(ctx)
+	if err != nil {
+		return err
+	}
+	set := make(map[string]struct{}, len(serials))
+	for _, s := range serials {
+		set[s] = struct{}{}
+	}
+	c.serials = set
+	c.loadedAt = time.Now()
+	return nil
+}
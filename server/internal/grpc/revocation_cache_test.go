@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeSerialLister struct {
+	serials []string
+	err     error
+	calls   int
+}
+
+func (f *fakeSerialLister) ListSerials(context.Context) ([]string, error) {
+	f.calls++
+	return f.serials, f.err
+}
+
+func TestRevocationCache(t *testing.T) {
+	ctx := context.Background()
+	src := &fakeSerialLister{serials: []string{"a1"}}
+	c := NewRevocationCache(src, time.Hour)
+
+	if revoked, err := c.IsRevoked(ctx, "a1"); err != nil || !revoked {
+		t.Errorf("IsRevoked(a1) = %v, %v; want true", revoked, err)
+	}
+	if revoked, _ := c.IsRevoked(ctx, "b2"); revoked {
+		t.Error("IsRevoked(b2) = true before revocation")
+	}
+	if src.calls != 1 {
+		t.Errorf("ListSerials calls = %d, want 1 within the refresh interval", src.calls)
+	}
+
+	src.serials = append(src.serials, "b2")
+	c.Invalidate()
+	if revoked, _ := c.IsRevoked(ctx, "b2"); !revoked {
+		t.Error("IsRevoked(b2) = false after Invalidate")
+	}
+
+	src.err = errors.New("db down")
+	c.Invalidate()
+	if revoked, err := c.IsRevoked(ctx, "a1"); err != nil || !revoked {
+		t.Errorf("IsRevoked after failed reload = %v, %v; want cached true", revoked, err)
+	}
+}
+
+func TestRevocationCache_FailsClosedBeforeFirstLoad(t *testing.T) {
+	c := NewRevocationCache(&fakeSerialLister{err: errors.New("db down")}, time.Hour)
+	if _, err := c.IsRevoked(context.Background(), "a1"); err == nil {
+		t.Error("IsRevoked succeeded without a loaded revocation list")
+	}
+}
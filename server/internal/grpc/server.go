@@ -6,10 +6,15 @@ package grpc
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"log"
 	"slices"
+	"strings"
+	"time"
 
+	"github.com/VuteTech/Bor/server/internal/database"
 	"github.com/VuteTech/Bor/server/internal/models"
 	"github.com/VuteTech/Bor/server/internal/services"
 	auditpb "github.com/VuteTech/Bor/server/pkg/grpc/audit"
@@ -33,6 +38,20 @@ type PolicyServer struct {
 	dconfRepo   dconfRepository
 	polkitRepo  polkitRepository
 	hub         *PolicyHub
+	filterSvc   *services.NodeFilterService
+	resolver    *services.PolicyResolver
+	groupSvc    *services.NodeGroupService
+	logSvc      *services.NodeLogCaptureService
+	remedSvc    *services.NodeRemediationService
+	snapshots   *snapshotCache
+
+	// Backpressure settings, see SetBackpressure.
+	bpSubscribers int
+	bpBase        time.Duration
+
+	// Compliance health settings, see SetUnhealthyThreshold.
+	unhealthyThreshold int
+	unhealthyWindow    time.Duration
 }
 
 // dconfRepository is the subset of database.DConfRepository used by PolicyServer.
@@ -40,6 +59,10 @@ type dconfRepository interface {
 	UpsertSchema(ctx context.Context, schema *pb.GSettingsSchema, source string) error
 	ReplaceNodeSchemas(ctx context.Context, nodeID string, schemaIDs []string) error
 	UpsertComplianceResult(ctx context.Context, nodeID, policyID, statusStr, message string, itemsJSON []byte) error
+	UpsertCheckResult(ctx context.Context, nodeID, policyID, statusStr string, exitCode int, message string, checkedAt time.Time) error
+	RecordComplianceHealth(ctx context.Context, nodeID, policyID string, failed bool, threshold int, window time.Duration) (wasUnhealthy, unhealthy bool, err error)
+	ReplaceAppliedFiles(ctx context.Context, nodeID, policyID string, files []*pb.AppliedFile) error
+	SaveComplianceReports(ctx context.Context, nodeID string, reports []database.ComplianceReport, threshold int, window time.Duration) ([]database.ComplianceHealth, error)
 }
 
 // polkitRepository is the subset of database.PolkitRepository used by PolicyServer.
@@ -50,7 +73,41 @@ type polkitRepository interface {
 
 // NewPolicyServer creates a new PolicyServer.
 func NewPolicyServer(policySvc *services.PolicyService, nodeSvc *services.NodeService, settingsSvc *services.SettingsService, auditSvc *services.AuditService, enrollSvc *services.EnrollmentService, dconfRepo dconfRepository, polkitRepo polkitRepository, hub *PolicyHub) *PolicyServer {
-	return &PolicyServer{policySvc: policySvc, nodeSvc: nodeSvc, settingsSvc: settingsSvc, auditSvc: auditSvc, enrollSvc: enrollSvc, dconfRepo: dconfRepo, polkitRepo: polkitRepo, hub: hub}
+	return &PolicyServer{policySvc: policySvc, nodeSvc: nodeSvc, settingsSvc: settingsSvc, auditSvc: auditSvc, enrollSvc: enrollSvc, dconfRepo: dconfRepo, polkitRepo: polkitRepo, hub: hub,
+		resolver: services.NewPolicyResolver(policySvc, nil), snapshots: newSnapshotCache(), unhealthyThreshold: 1}
+}
+
+// SetUnhealthyThreshold sets how many consecutive failed compliance reports
+// for a policy, within window of the first, flag a node as unhealthy for
+// it. The default of 1 flags every failure.
+func (s *PolicyServer) SetUnhealthyThreshold(threshold int, window time.Duration) {
+	s.unhealthyThreshold = threshold
+	s.unhealthyWindow = window
+}
+
+// SetNodeFilterService enables policy bindings that target saved node
+// filters. Without it only node-group bindings are resolved.
+func (s *PolicyServer) SetNodeFilterService(filterSvc *services.NodeFilterService) {
+	s.filterSvc = filterSvc
+	s.resolver = services.NewPolicyResolver(s.policySvc, filterSvc).WithQuarantine(s.groupSvc)
+}
+
+// SetNodeGroupService enables inventory-only mode and quarantine: snapshots
+// for nodes in an inventory-only group tell the agent not to enforce them,
+// and quarantined nodes receive the quarantine groups' policies.
+func (s *PolicyServer) SetNodeGroupService(groupSvc *services.NodeGroupService) {
+	s.groupSvc = groupSvc
+	s.resolver.WithQuarantine(groupSvc)
+}
+
+// SetNodeLogCaptureService enables the UploadLogs RPC.
+func (s *PolicyServer) SetNodeLogCaptureService(logSvc *services.NodeLogCaptureService) {
+	s.logSvc = logSvc
+}
+
+// SetNodeRemediationService enables the ReportRemediationResult RPC.
+func (s *PolicyServer) SetNodeRemediationService(remedSvc *services.NodeRemediationService) {
+	s.remedSvc = remedSvc
 }
 
 // GetPolicy returns a single policy by ID.
@@ -72,12 +129,16 @@ func (s *PolicyServer) GetPolicy(ctx context.Context, req *pb.GetPolicyRequest)
 	}, nil
 }
 
-// ListPolicies returns policies bound to the calling node's group with enabled bindings.
+// ListPolicies returns policies bound to the calling node's groups or
+// matching saved filters with enabled bindings.
 func (s *PolicyServer) ListPolicies(ctx context.Context, req *pb.ListPoliciesRequest) (*pb.ListPoliciesResponse, error) {
 	clientID := req.GetClientId()
 	if clientID == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
 	}
+	if err := requireClientID(ctx, clientID); err != nil {
+		return nil, err
+	}
 
 	// Look up the node to determine its node group
 	node, err := s.nodeSvc.GetNodeByName(ctx, clientID)
@@ -87,7 +148,7 @@ func (s *PolicyServer) ListPolicies(ctx context.Context, req *pb.ListPoliciesReq
 	if node == nil {
 		return nil, status.Errorf(codes.NotFound, "node not found for client_id: %s", clientID)
 	}
-	if len(node.NodeGroupIDs) == 0 {
+	if len(node.NodeGroupIDs) == 0 && s.filterSvc == nil && node.QuarantinedAt == nil && len(node.SessionUsers) == 0 {
 		log.Printf("Node %s (%s) has no node group assigned; no policies will be delivered.", node.ID, node.Name)
 		return &pb.ListPoliciesResponse{
 			Policies:   []*pb.Policy{},
@@ -95,8 +156,8 @@ func (s *PolicyServer) ListPolicies(ctx context.Context, req *pb.ListPoliciesReq
 		}, nil
 	}
 
-	// Fetch only policies with enabled bindings for this node's groups
-	policies, err := s.policySvc.ListPoliciesForNodeGroups(ctx, node.NodeGroupIDs)
+	// Fetch only policies with enabled bindings for this node's groups and filters
+	policies, err := s.resolveSnapshot(ctx, node, s.hub.Revision())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list policies for node group: %v", err)
 	}
@@ -104,10 +165,10 @@ func (s *PolicyServer) ListPolicies(ctx context.Context, req *pb.ListPoliciesReq
 	typeFilter := req.GetTypeFilter()
 	var result []*pb.Policy
 	for _, p := range policies {
-		if typeFilter != "" && p.Type != typeFilter {
+		if typeFilter != "" && p.GetType() != typeFilter {
 			continue
 		}
-		result = append(result, modelToProto(p))
+		result = append(result, p)
 	}
 
 	return &pb.ListPoliciesResponse{
@@ -125,6 +186,16 @@ func (s *PolicyServer) SubscribePolicyUpdates(req *pb.SubscribePolicyUpdatesRequ
 	}
 
 	ctx := stream.Context()
+	if err := requireClientID(ctx, clientID); err != nil {
+		return err
+	}
+
+	// Agents reconnecting while the server drains are sent away at once.
+	select {
+	case <-s.hub.Draining():
+		return stream.Send(s.hub.GoingAway())
+	default:
+	}
 
 	// Resolve node and its group to scope the snapshot.
 	node, err := s.nodeSvc.GetNodeByName(ctx, clientID)
@@ -134,6 +205,16 @@ func (s *PolicyServer) SubscribePolicyUpdates(req *pb.SubscribePolicyUpdatesRequ
 	if node == nil {
 		return status.Errorf(codes.NotFound, "node not found for client_id: %s", clientID)
 	}
+	// Agents report their version when subscribing; older agents only in
+	// heartbeats, so fall back to the last version recorded for the node.
+	agentVersion := req.GetAgentVersion()
+	if agentVersion == "" && node.AgentVersion != nil {
+		agentVersion = *node.AgentVersion
+	}
+	if err := s.nodeSvc.CheckAgentVersion(agentVersion); err != nil {
+		log.Printf("Refusing policy stream for %s: %v", clientID, err)
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
 
 	lastKnown := req.GetLastKnownRevision()
 	currentRev := s.hub.Revision()
@@ -170,6 +251,7 @@ func (s *PolicyServer) SubscribePolicyUpdates(req *pb.SubscribePolicyUpdatesRequ
 						return err
 					}
 				}
+				recordPolicyDelivery(ctx, node, events)
 			}
 		}
 	}
@@ -196,13 +278,33 @@ func (s *PolicyServer) SubscribePolicyUpdates(req *pb.SubscribePolicyUpdatesRequ
 		case <-ctx.Done():
 			log.Printf("Client %s stream ended: %v", clientID, ctx.Err())
 			return nil
+		case <-s.hub.Draining():
+			// Server shutdown: ask the agent to come back later and end the
+			// stream cleanly instead of letting it be cut off.
+			if err := stream.Send(s.hub.GoingAway()); err != nil {
+				return err
+			}
+			log.Printf("Client %s stream drained for shutdown", clientID)
+			return nil
 		case ev := <-updates:
 			if IsResyncSignal(ev.update) {
 				// Only resync if this agent's groups are in the affected set.
-				// An empty affectedGroupIDs means "all agents".
-				if !groupsOverlap(node.NodeGroupIDs, ev.affectedGroupIDs) {
+				// An empty affectedGroupIDs means "all agents". Quarantined
+				// agents get their policies from the quarantine groups and
+				// resync on every change.
+				if node.QuarantinedAt == nil && !groupsOverlap(node.NodeGroupIDs, ev.affectedGroupIDs) {
 					continue
 				}
+				if ev.refreshNode {
+					// The node itself changed, e.g. it was quarantined.
+					fresh, err := s.nodeSvc.GetNodeByName(ctx, clientID)
+					if err != nil {
+						return status.Errorf(codes.Internal, "failed to look up node: %v", err)
+					}
+					if fresh != nil {
+						node = fresh
+					}
+				}
 				if err := s.sendSnapshot(ctx, stream, node); err != nil {
 					return err
 				}
@@ -210,6 +312,11 @@ func (s *PolicyServer) SubscribePolicyUpdates(req *pb.SubscribePolicyUpdatesRequ
 				if err := stream.Send(ev.update); err != nil {
 					return err
 				}
+				recordPolicyDelivery(ctx, node, []*pb.PolicyUpdate{ev.update})
+				if ev.update.Type == pb.PolicyUpdate_GOING_AWAY {
+					log.Printf("Client %s stream closed by server", clientID)
+					return nil
+				}
 			}
 		}
 	}
@@ -235,25 +342,30 @@ func groupsOverlap(nodeGroups, eventGroups []string) bool {
 
 // sendSnapshot sends a full policy snapshot to the stream.
 func (s *PolicyServer) sendSnapshot(ctx context.Context, stream pb.PolicyService_SubscribePolicyUpdatesServer, node *models.Node) error {
-	var policies []*models.Policy
-	var err error
-
-	if len(node.NodeGroupIDs) > 0 {
-		policies, err = s.policySvc.ListPoliciesForNodeGroups(ctx, node.NodeGroupIDs)
-	}
+	currentRev := s.hub.Revision()
+	policies, err := s.resolveSnapshot(ctx, node, currentRev)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed to list policies for snapshot: %v", err)
 	}
 
-	currentRev := s.hub.Revision()
+	// A quarantined node must enforce its lockdown policies even if one of
+	// its own groups is inventory-only.
+	inventoryOnly := false
+	if s.groupSvc != nil && node.QuarantinedAt == nil {
+		inventoryOnly, err = s.groupSvc.IsNodeInventoryOnly(ctx, node.ID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to check inventory-only mode: %v", err)
+		}
+	}
 
 	for i, p := range policies {
 		isLast := i == len(policies)-1
 		update := &pb.PolicyUpdate{
 			Type:             pb.PolicyUpdate_SNAPSHOT,
-			Policy:           modelToProto(p),
+			Policy:           p,
 			Revision:         currentRev,
 			SnapshotComplete: isLast,
+			InventoryOnly:    inventoryOnly,
 		}
 		if err := stream.Send(update); err != nil {
 			return err
@@ -266,19 +378,112 @@ func (s *PolicyServer) sendSnapshot(ctx context.Context, stream pb.PolicyService
 			Type:             pb.PolicyUpdate_SNAPSHOT,
 			Revision:         currentRev,
 			SnapshotComplete: true,
+			InventoryOnly:    inventoryOnly,
 		}); err != nil {
 			return err
 		}
 	}
 
+	delivered := make([]deliveredPolicy, 0, len(policies))
+	for _, p := range policies {
+		delivered = append(delivered, deliveredPolicy{ID: p.GetId(), Name: p.GetName(), Version: p.GetVersion()})
+	}
+	recordAgentEvent(ctx, "deliver", node.ID, node.Name, policyDelivery{
+		Revision: currentRev,
+		Snapshot: true,
+		Policies: delivered,
+	})
 	return nil
 }
 
+// policyDelivery is the audit record of policies sent to an agent over
+// its policy stream. Deliveries through the ListPolicies polling fallback
+// are not recorded: they repeat the same snapshot on every poll.
+type policyDelivery struct {
+	Revision int64             `json:"revision"`
+	Snapshot bool              `json:"snapshot"`
+	Policies []deliveredPolicy `json:"policies"`
+}
+
+type deliveredPolicy struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Version int32  `json:"version,omitempty"`
+	// Change is CREATED, UPDATED or DELETED; it is empty in snapshots.
+	Change string `json:"change,omitempty"`
+}
+
+// recordPolicyDelivery records the policy changes among updates sent to
+// node. Commands such as METADATA_REQUEST are not deliveries and are
+// skipped.
+func recordPolicyDelivery(ctx context.Context, node *models.Node, updates []*pb.PolicyUpdate) {
+	d := policyDelivery{}
+	for _, u := range updates {
+		switch u.GetType() {
+		case pb.PolicyUpdate_CREATED, pb.PolicyUpdate_UPDATED, pb.PolicyUpdate_DELETED:
+			p := u.GetPolicy()
+			d.Revision = u.GetRevision()
+			d.Policies = append(d.Policies, deliveredPolicy{
+				ID:      p.GetId(),
+				Name:    p.GetName(),
+				Version: p.GetVersion(),
+				Change:  u.GetType().String(),
+			})
+		}
+	}
+	if len(d.Policies) > 0 {
+		recordAgentEvent(ctx, "deliver", node.ID, node.Name, d)
+	}
+}
+
+// resolveSnapshot returns the converted policies that apply to node at
+// revision. Nodes with the same groups and matching filters share one
+// cached result, so only the first of them queries the database. Policies
+// bound to the user groups of the node's logged-in users are resolved per
+// node and added to it.
+func (s *PolicyServer) resolveSnapshot(ctx context.Context, node *models.Node, revision int64) ([]*pb.Policy, error) {
+	groupIDs, filterIDs, err := s.resolver.Targets(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	// The build may be shared with other streams, so it must not fail
+	// because the stream that started it went away.
+	buildCtx := context.WithoutCancel(ctx)
+	shared, err := s.snapshots.get(ctx, revision, snapshotKey(groupIDs, filterIDs), func() ([]*pb.Policy, error) {
+		policies, err := s.resolver.ResolveTargets(buildCtx, groupIDs, filterIDs)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*pb.Policy, 0, len(policies))
+		for _, p := range policies {
+			result = append(result, modelToProto(p))
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.resolver.SessionPolicies(ctx, node)
+	if err != nil || len(session) == 0 {
+		return shared, err
+	}
+	// shared is cached for other nodes; never append to it in place.
+	result := slices.Clip(shared)
+	for _, p := range session {
+		result = append(result, modelToProto(p))
+	}
+	return result, nil
+}
+
 // ReportCompliance accepts a compliance report from a client.
 func (s *PolicyServer) ReportCompliance(ctx context.Context, req *pb.ReportComplianceRequest) (*pb.ReportComplianceResponse, error) {
 	if req.GetClientId() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
 	}
+	if err := requireClientID(ctx, req.GetClientId()); err != nil {
+		return nil, err
+	}
 	if req.GetPolicyId() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "policy_id is required")
 	}
@@ -297,6 +502,75 @@ func (s *PolicyServer) ReportCompliance(ctx context.Context, req *pb.ReportCompl
 		return &pb.ReportComplianceResponse{Success: true}, nil
 	}
 
+	rep := complianceReportFromProto(req)
+	if err := s.dconfRepo.UpsertComplianceResult(ctx, node.ID, rep.PolicyID, rep.Status, rep.Message, rep.ItemsJSON); err != nil {
+		log.Printf("WARNING: ReportCompliance: failed to persist result for node %s policy %s: %v", node.ID, rep.PolicyID, err)
+	} else {
+		s.recordComplianceHealth(ctx, node, rep.PolicyID, rep.Failed)
+	}
+
+	// Applied file hashes are only sent after a successful write; a failed
+	// sync leaves the last known hashes in place.
+	if len(rep.AppliedFiles) > 0 {
+		if err := s.dconfRepo.ReplaceAppliedFiles(ctx, node.ID, rep.PolicyID, rep.AppliedFiles); err != nil {
+			log.Printf("WARNING: ReportCompliance: failed to persist applied files for node %s policy %s: %v", node.ID, rep.PolicyID, err)
+		}
+	}
+
+	return &pb.ReportComplianceResponse{Success: true}, nil
+}
+
+// ReportComplianceBatch accepts several compliance reports from a client
+// and stores them in one transaction.
+func (s *PolicyServer) ReportComplianceBatch(ctx context.Context, req *pb.ReportComplianceBatchRequest) (*pb.ReportComplianceBatchResponse, error) {
+	if req.GetClientId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
+	}
+	if err := requireClientID(ctx, req.GetClientId()); err != nil {
+		return nil, err
+	}
+	for i, r := range req.GetReports() {
+		if r.GetPolicyId() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "reports[%d]: policy_id is required", i)
+		}
+		if r.GetClientId() != "" && r.GetClientId() != req.GetClientId() {
+			return nil, status.Errorf(codes.InvalidArgument, "reports[%d]: client_id does not match the batch", i)
+		}
+	}
+	if len(req.GetReports()) == 0 {
+		return &pb.ReportComplianceBatchResponse{Success: true}, nil
+	}
+
+	log.Printf("Compliance batch: client=%s reports=%d", req.GetClientId(), len(req.GetReports()))
+
+	node, err := s.nodeSvc.GetNodeByName(ctx, req.GetClientId())
+	if err != nil {
+		log.Printf("WARNING: ReportComplianceBatch: failed to look up node %s: %v", req.GetClientId(), err)
+		return &pb.ReportComplianceBatchResponse{Success: true}, nil
+	}
+	if node == nil {
+		log.Printf("WARNING: ReportComplianceBatch: unknown node %s", req.GetClientId())
+		return &pb.ReportComplianceBatchResponse{Success: true}, nil
+	}
+
+	reports := make([]database.ComplianceReport, 0, len(req.GetReports()))
+	for _, r := range req.GetReports() {
+		reports = append(reports, complianceReportFromProto(r))
+	}
+	health, err := s.dconfRepo.SaveComplianceReports(ctx, node.ID, reports, s.unhealthyThreshold, s.unhealthyWindow)
+	if err != nil {
+		log.Printf("WARNING: ReportComplianceBatch: failed to persist %d results for node %s: %v", len(reports), node.ID, err)
+		return nil, status.Errorf(codes.Internal, "failed to store compliance reports")
+	}
+	for i, h := range health {
+		logComplianceHealth(node, reports[i].PolicyID, h.WasUnhealthy, h.Unhealthy, s.unhealthyThreshold)
+	}
+
+	return &pb.ReportComplianceBatchResponse{Success: true}, nil
+}
+
+// complianceReportFromProto converts a compliance report for storage.
+func complianceReportFromProto(req *pb.ReportComplianceRequest) database.ComplianceReport {
 	// Map protobuf ComplianceStatus to VARCHAR string.
 	statusStr := complianceStatusToString(req.GetStatus(), req.GetCompliant())
 
@@ -323,11 +597,158 @@ func (s *PolicyServer) ReportCompliance(ctx context.Context, req *pb.ReportCompl
 		}
 	}
 
-	if err := s.dconfRepo.UpsertComplianceResult(ctx, node.ID, req.GetPolicyId(), statusStr, req.GetMessage(), itemsJSON); err != nil {
-		log.Printf("WARNING: ReportCompliance: failed to persist result for node %s policy %s: %v", node.ID, req.GetPolicyId(), err)
+	return database.ComplianceReport{
+		PolicyID:     req.GetPolicyId(),
+		Status:       statusStr,
+		Message:      req.GetMessage(),
+		ItemsJSON:    itemsJSON,
+		Failed:       statusStr == "non_compliant" || statusStr == "error",
+		AppliedFiles: validAppliedFiles(req.GetAppliedFiles()),
 	}
+}
 
-	return &pb.ReportComplianceResponse{Success: true}, nil
+// validAppliedFiles drops applied file entries without a path or with a
+// malformed SHA-256.
+func validAppliedFiles(files []*pb.AppliedFile) []*pb.AppliedFile {
+	valid := make([]*pb.AppliedFile, 0, len(files))
+	for _, f := range files {
+		if f.GetPath() == "" || len(f.GetSha256()) != sha256.Size*2 {
+			continue
+		}
+		if _, err := hex.DecodeString(f.GetSha256()); err != nil {
+			continue
+		}
+		valid = append(valid, f)
+	}
+	return valid
+}
+
+// ReportCheckResult accepts the result of a policy's compliance check command.
+func (s *PolicyServer) ReportCheckResult(ctx context.Context, req *pb.ReportCheckResultRequest) (*pb.ReportCheckResultResponse, error) {
+	if req.GetClientId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
+	}
+	if err := requireClientID(ctx, req.GetClientId()); err != nil {
+		return nil, err
+	}
+	if req.GetPolicyId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "policy_id is required")
+	}
+
+	log.Printf("Compliance check: client=%s policy=%s status=%s exit_code=%d",
+		req.GetClientId(), req.GetPolicyId(), req.GetStatus(), req.GetExitCode())
+
+	node, err := s.nodeSvc.GetNodeByName(ctx, req.GetClientId())
+	if err != nil {
+		log.Printf("WARNING: ReportCheckResult: failed to look up node %s: %v", req.GetClientId(), err)
+		return &pb.ReportCheckResultResponse{Success: true}, nil
+	}
+	if node == nil {
+		log.Printf("WARNING: ReportCheckResult: unknown node %s", req.GetClientId())
+		return &pb.ReportCheckResultResponse{Success: true}, nil
+	}
+
+	checkedAt := time.Now()
+	if req.GetCheckedAt() != nil {
+		checkedAt = req.GetCheckedAt().AsTime()
+	}
+	statusStr := complianceStatusToString(req.GetStatus(), false)
+	if err := s.dconfRepo.UpsertCheckResult(ctx, node.ID, req.GetPolicyId(), statusStr, int(req.GetExitCode()), req.GetMessage(), checkedAt); err != nil {
+		log.Printf("WARNING: ReportCheckResult: failed to persist result for node %s policy %s: %v", node.ID, req.GetPolicyId(), err)
+	}
+
+	return &pb.ReportCheckResultResponse{Success: true}, nil
+}
+
+// UploadLogs stores an agent's log buffer for the log capture it was
+// requested for.
+func (s *PolicyServer) UploadLogs(ctx context.Context, req *pb.UploadLogsRequest) (*pb.UploadLogsResponse, error) {
+	if req.GetClientId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
+	}
+	if err := requireClientID(ctx, req.GetClientId()); err != nil {
+		return nil, err
+	}
+	if req.GetRequestId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "request_id is required")
+	}
+	if s.logSvc == nil {
+		return nil, status.Errorf(codes.Unimplemented, "log capture is not enabled")
+	}
+
+	node, err := s.nodeSvc.GetNodeByName(ctx, req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to look up node")
+	}
+	if node == nil {
+		return nil, status.Errorf(codes.NotFound, "unknown node")
+	}
+
+	receivedAt := time.Now()
+	if err := s.logSvc.CompleteCapture(ctx, req.GetRequestId(), node.ID, req.GetContent(), req.GetTruncated(), receivedAt); err != nil {
+		log.Printf("WARNING: UploadLogs: node %s: %v", req.GetClientId(), err)
+		return nil, status.Errorf(codes.FailedPrecondition, "no pending log capture with this request_id")
+	}
+
+	log.Printf("Log capture received: node=%s request=%s bytes=%d", req.GetClientId(), req.GetRequestId(), len(req.GetContent()))
+	return &pb.UploadLogsResponse{Success: true}, nil
+}
+
+// ReportRemediationResult stores the outcome of a remediation action run by
+// an agent and records it in the audit log.
+func (s *PolicyServer) ReportRemediationResult(ctx context.Context, req *pb.ReportRemediationResultRequest) (*pb.ReportRemediationResultResponse, error) {
+	if req.GetClientId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
+	}
+	if err := requireClientID(ctx, req.GetClientId()); err != nil {
+		return nil, err
+	}
+	if req.GetRequestId() == "" || req.GetAction() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "request_id and action are required")
+	}
+	if s.remedSvc == nil {
+		return nil, status.Errorf(codes.Unimplemented, "remediation is not enabled")
+	}
+
+	node, err := s.nodeSvc.GetNodeByName(ctx, req.GetClientId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to look up node")
+	}
+	if node == nil {
+		return nil, status.Errorf(codes.NotFound, "unknown node")
+	}
+
+	completedAt := time.Now()
+	if err := s.remedSvc.CompleteRemediation(ctx, req.GetRequestId(), node.ID, req.GetAction(), req.GetSuccess(),
+		int(req.GetExitCode()), req.GetOutput(), req.GetError(), completedAt); err != nil {
+		log.Printf("WARNING: ReportRemediationResult: node %s: %v", req.GetClientId(), err)
+		return nil, status.Errorf(codes.FailedPrecondition, "no pending remediation with this request_id")
+	}
+
+	outcome := auditpb.Outcome_OUTCOME_SUCCESS
+	if !req.GetSuccess() {
+		outcome = auditpb.Outcome_OUTCOME_FAILURE
+	}
+	ipAddr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		ipAddr = p.Addr.String()
+	}
+	s.auditSvc.Emit(ctx, &auditpb.AuditEvent{
+		OccurredAt: timestamppb.New(completedAt),
+		Actor:      &auditpb.Actor{Username: req.GetClientId()},
+		Action:     "remediation_" + req.GetAction(),
+		Resource: &auditpb.Resource{
+			Type: "nodes",
+			Id:   node.ID,
+			Name: node.Name,
+		},
+		Outcome: outcome,
+		SrcIp:   ipAddr,
+	})
+
+	log.Printf("Remediation result: node=%s action=%s request=%s success=%v exit=%d",
+		req.GetClientId(), req.GetAction(), req.GetRequestId(), req.GetSuccess(), req.GetExitCode())
+	return &pb.ReportRemediationResultResponse{Success: true}, nil
 }
 
 // complianceStatusToString converts a pb.ComplianceStatus to its VARCHAR representation.
@@ -351,6 +772,28 @@ func complianceStatusToString(s pb.ComplianceStatus, legacyCompliant bool) strin
 	}
 }
 
+// recordComplianceHealth updates the node's failure streak for the policy
+// and logs transitions into and out of the unhealthy state.
+func (s *PolicyServer) recordComplianceHealth(ctx context.Context, node *models.Node, policyID string, failed bool) {
+	was, now, err := s.dconfRepo.RecordComplianceHealth(ctx, node.ID, policyID, failed, s.unhealthyThreshold, s.unhealthyWindow)
+	if err != nil {
+		log.Printf("WARNING: ReportCompliance: failed to update health for node %s policy %s: %v", node.ID, policyID, err)
+		return
+	}
+	logComplianceHealth(node, policyID, was, now, s.unhealthyThreshold)
+}
+
+// logComplianceHealth logs a node's transition into or out of the
+// unhealthy state for a policy.
+func logComplianceHealth(node *models.Node, policyID string, was, now bool, threshold int) {
+	switch {
+	case now && !was:
+		log.Printf("Node %s flagged unhealthy for policy %s after %d consecutive failure(s)", node.Name, policyID, threshold)
+	case was && !now:
+		log.Printf("Node %s recovered for policy %s", node.Name, policyID)
+	}
+}
+
 // GetAgentConfig returns the agent configuration (notification settings, etc.).
 func (s *PolicyServer) GetAgentConfig(ctx context.Context, _ *pb.GetAgentConfigRequest) (*pb.GetAgentConfigResponse, error) {
 	settings, err := s.settingsSvc.GetAgentNotificationSettings(ctx)
@@ -366,6 +809,7 @@ func (s *PolicyServer) GetAgentConfig(ctx context.Context, _ *pb.GetAgentConfigR
 			NotifyMessageFirefox:  settings.NotifyMessageFirefox,
 			NotifyMessageChrome:   settings.NotifyMessageChrome,
 		},
+		BackoffSeconds: s.backoffHint(),
 	}, nil
 }
 
@@ -375,6 +819,9 @@ func (s *PolicyServer) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest)
 	if clientID == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
 	}
+	if err := requireClientID(ctx, clientID); err != nil {
+		return nil, err
+	}
 
 	node, err := s.nodeSvc.GetNodeByName(ctx, clientID)
 	if err != nil {
@@ -393,16 +840,91 @@ func (s *PolicyServer) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest)
 		info.DesktopEnvs = ni.GetDesktopEnvs()
 		info.AgentVersion = ni.GetAgentVersion()
 		info.MachineID = ni.GetMachineId()
+		info.FirefoxVersion = ni.GetFirefoxVersion()
+		info.ChromeVersion = ni.GetChromeVersion()
+		info.KernelVersion = ni.GetKernelVersion()
+		info.Hardware = nodeHardwareFromProto(ni.GetHardware())
 	}
+	sessionUsers := req.GetInfo().GetSessionUsers()
 
 	if err := s.nodeSvc.ProcessHeartbeat(ctx, node.ID, info); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to process heartbeat: %v", err)
 	}
+	if changes := nodeMetadataChanges(node, info); len(changes) > 0 {
+		recordAgentEvent(ctx, "update", node.ID, node.Name, changes)
+	}
 
 	log.Printf("Heartbeat from %s: OS=%s %s, DE=%v, agent=%s",
 		clientID, info.OSName, info.OSVersion, info.DesktopEnvs, info.AgentVersion)
 
-	return &pb.HeartbeatResponse{Accepted: true}, nil
+	// The heartbeat is recorded first so the UI shows the outdated version.
+	if err := s.nodeSvc.CheckAgentVersion(info.AgentVersion); err != nil {
+		log.Printf("Refusing heartbeat from %s: %v", clientID, err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	// New facts may move the node in or out of saved filters; if so, its
+	// filter-bound policies changed and the agent needs a fresh snapshot.
+	if s.filterSvc != nil {
+		changed, err := s.filterSvc.RefreshNode(ctx, node.ID)
+		if err != nil {
+			log.Printf("Failed to evaluate node filters for %s: %v", clientID, err)
+		} else if changed {
+			s.hub.SendResyncRequest(clientID)
+		}
+	}
+
+	// A user logging in or out changes which user-group-bound policies the
+	// node receives.
+	changed, err := s.nodeSvc.SetSessionUsers(ctx, node, sessionUsers)
+	if err != nil {
+		log.Printf("Failed to record session users for %s: %v", clientID, err)
+	} else if changed {
+		s.hub.SendResyncRequest(clientID)
+	}
+
+	return &pb.HeartbeatResponse{Accepted: true, BackoffSeconds: s.backoffHint()}, nil
+}
+
+// metadataChange is the audit record of one node fact changed by a
+// heartbeat.
+type metadataChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// nodeMetadataChanges returns the facts in info that differ from those
+// recorded for node, keyed by column name. Facts the agent did not report
+// are kept by ProcessHeartbeat and so never change; hardware facts are left
+// out because free memory and disk space change all the time.
+func nodeMetadataChanges(node *models.Node, info *models.NodeHeartbeatInfo) map[string]metadataChange {
+	facts := []struct {
+		name     string
+		recorded *string
+		reported string
+	}{
+		{"fqdn", node.FQDN, info.FQDN},
+		{"ip_address", node.IPAddress, info.IPAddress},
+		{"os_name", node.OSName, info.OSName},
+		{"os_version", node.OSVersion, info.OSVersion},
+		{"desktop_env", node.DesktopEnv, strings.Join(info.DesktopEnvs, ", ")},
+		{"agent_version", node.AgentVersion, info.AgentVersion},
+		{"machine_id", node.MachineID, info.MachineID},
+		{"firefox_version", node.FirefoxVersion, info.FirefoxVersion},
+		{"chrome_version", node.ChromeVersion, info.ChromeVersion},
+		{"kernel_version", node.KernelVersion, info.KernelVersion},
+	}
+	changes := make(map[string]metadataChange)
+	for _, f := range facts {
+		old := ""
+		if f.recorded != nil {
+			old = *f.recorded
+		}
+		if f.reported != "" && f.reported != old {
+			changes[f.name] = metadataChange{Old: old, New: f.reported}
+		}
+	}
+	return changes
 }
 
 // ReportTamperEvent records an agent-reported file tamper event in the audit log.
@@ -411,6 +933,9 @@ func (s *PolicyServer) ReportTamperEvent(ctx context.Context, req *pb.ReportTamp
 	if clientID == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "client_id is required")
 	}
+	if err := requireClientID(ctx, clientID); err != nil {
+		return nil, err
+	}
 	if req.GetFilePath() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "file_path is required")
 	}
@@ -493,6 +1018,36 @@ func (s *PolicyServer) RenewCertificate(ctx context.Context, req *pb.RenewCertif
 	return &pb.RenewCertificateResponse{SignedCertPem: certPEM}, nil
 }
 
+// contactLossActionToProto maps a stored contact-loss action to its proto
+// enum. Unknown values fall back to KEEP so agents never drop enforcement
+// because of bad data.
+func contactLossActionToProto(action string) pb.ContactLossAction {
+	switch action {
+	case models.ContactLossRevert:
+		return pb.ContactLossAction_CONTACT_LOSS_ACTION_REVERT
+	case models.ContactLossActivate:
+		return pb.ContactLossAction_CONTACT_LOSS_ACTION_ACTIVATE
+	default:
+		return pb.ContactLossAction_CONTACT_LOSS_ACTION_KEEP
+	}
+}
+
+// preconditionTypeToProto maps a model precondition type to its enum value.
+func preconditionTypeToProto(t string) pb.PreconditionType {
+	switch t {
+	case models.PreconditionFileExists:
+		return pb.PreconditionType_PRECONDITION_TYPE_FILE_EXISTS
+	case models.PreconditionCommandExists:
+		return pb.PreconditionType_PRECONDITION_TYPE_COMMAND_EXISTS
+	case models.PreconditionDesktopInstalled:
+		return pb.PreconditionType_PRECONDITION_TYPE_DESKTOP_INSTALLED
+	case models.PreconditionDesktopSession:
+		return pb.PreconditionType_PRECONDITION_TYPE_DESKTOP_SESSION
+	default:
+		return pb.PreconditionType_PRECONDITION_TYPE_UNSPECIFIED
+	}
+}
+
 // modelToProto converts an internal Policy model to its protobuf representation.
 func modelToProto(p *models.Policy) *pb.Policy {
 	pol := &pb.Policy{
@@ -506,6 +1061,27 @@ func modelToProto(p *models.Policy) *pb.Policy {
 		CreatedAt:   timestamppb.New(p.CreatedAt),
 		UpdatedAt:   timestamppb.New(p.UpdatedAt),
 		Enabled:     p.State == models.PolicyStateReleased,
+
+		ContactLossAction:     contactLossActionToProto(p.ContactLossAction),
+		ContactLossTtlSeconds: int64(p.ContactLossTTLSeconds),
+		ScopeUsers:            p.ScopeUsers,
+		ContentHash:           p.ContentHash(),
+	}
+
+	if p.CheckCommand != "" {
+		pol.ComplianceCheck = &pb.ComplianceCheck{
+			Command:        p.CheckCommand,
+			Args:           p.CheckArgs,
+			TimeoutSeconds: int32(p.CheckTimeoutSeconds), //nolint:gosec // capped by the policy service
+		}
+	}
+
+	for _, pc := range p.Preconditions {
+		pol.Preconditions = append(pol.Preconditions, &pb.Precondition{
+			Type:   preconditionTypeToProto(pc.Type),
+			Value:  pc.Value,
+			Negate: pc.Negate,
+		})
 	}
 
 	// Populate typed_content based on policy type.
@@ -545,7 +1121,35 @@ func modelToProto(p *models.Policy) *pb.Policy {
 		} else {
 			pol.TypedContent = &pb.Policy_PolkitPolicy{PolkitPolicy: &pkPol}
 		}
+	case "Sysctl":
+		var scPol pb.SysctlPolicy
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(p.Content), &scPol); err != nil {
+			log.Printf("WARNING: failed to unmarshal Sysctl typed_content for policy %s: %v", p.ID, err)
+		} else {
+			pol.TypedContent = &pb.Policy_SysctlPolicy{SysctlPolicy: &scPol}
+		}
 	}
 
 	return pol
 }
+
+// nodeHardwareFromProto converts reported hardware facts, returning nil
+// when hw is unset.
+func nodeHardwareFromProto(hw *pb.NodeHardware) *models.NodeHardware {
+	if hw == nil {
+		return nil
+	}
+	out := &models.NodeHardware{
+		MemoryTotalBytes:     hw.GetMemoryTotalBytes(),
+		MemoryAvailableBytes: hw.GetMemoryAvailableBytes(),
+		CPUModel:             hw.GetCpuModel(),
+		CPUCores:             int(hw.GetCpuCores()),
+		RootDiskTotalBytes:   hw.GetRootDiskTotalBytes(),
+		RootDiskFreeBytes:    hw.GetRootDiskFreeBytes(),
+	}
+	if bt := hw.GetBootTime(); bt > 0 {
+		t := time.Unix(bt, 0).UTC()
+		out.BootTime = &t
+	}
+	return out
+}
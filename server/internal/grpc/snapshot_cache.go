@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+// snapshotCache holds assembled policy snapshots keyed by the set of node
+// groups and saved filters they were resolved for. Every policy or binding
+// change bumps the hub revision, and entries of an older revision are
+// discarded on the next lookup, so a fleet-wide resync resolves and converts
+// each distinct target set once and shares the result between its agents.
+//
+// The cached pb.Policy messages are shared between streams and must not be
+// modified.
+type snapshotCache struct {
+	mu       sync.Mutex
+	revision int64
+	entries  map[string]*snapshotEntry
+}
+
+// snapshotEntry is a snapshot being assembled or ready for reuse. ready is
+// closed once policies and err are set.
+type snapshotEntry struct {
+	ready    chan struct{}
+	policies []*pb.Policy
+	err      error
+}
+
+func newSnapshotCache() *snapshotCache {
+	return &snapshotCache{entries: make(map[string]*snapshotEntry)}
+}
+
+// get returns the snapshot for key at revision, calling build to assemble
+// it if no current entry exists. Concurrent callers for the same key wait
+// for a single build. Failed builds are not cached.
+func (c *snapshotCache) get(ctx context.Context, revision int64, key string, build func() ([]*pb.Policy, error)) ([]*pb.Policy, error) {
+	c.mu.Lock()
+	if revision > c.revision {
+		c.revision = revision
+		clear(c.entries)
+	}
+	if revision < c.revision {
+		// Policies changed while this caller resolved its targets; build
+		// without caching a result that is already stale.
+		c.mu.Unlock()
+		return build()
+	}
+	if e, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-e.ready:
+			return e.policies, e.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	e := &snapshotEntry{ready: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	e.policies, e.err = build()
+	if e.err != nil {
+		c.mu.Lock()
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+	close(e.ready)
+	return e.policies, e.err
+}
+
+// size returns the number of cached snapshots.
+func (c *snapshotCache) size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// snapshotKey identifies a target set independently of the order its
+// groups and filters are listed in.
+func snapshotKey(groupIDs, filterIDs []string) string {
+	g := slices.Clone(groupIDs)
+	slices.Sort(g)
+	f := slices.Clone(filterIDs)
+	slices.Sort(f)
+	return strings.Join(g, ",") + "|" + strings.Join(f, ",")
+}
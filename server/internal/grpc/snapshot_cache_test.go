@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+)
+
+func TestSnapshotCache_SharesBuildPerRevision(t *testing.T) {
+	c := newSnapshotCache()
+	ctx := context.Background()
+	var builds atomic.Int32
+	build := func() ([]*pb.Policy, error) {
+		builds.Add(1)
+		return []*pb.Policy{{Id: "p1"}}, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := c.get(ctx, 1, snapshotKey([]string{"g1", "g2"}, nil), build)
+			if err != nil || len(got) != 1 || got[0].GetId() != "p1" {
+				t.Errorf("get = %v, %v", got, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := builds.Load(); n != 1 {
+		t.Errorf("builds at revision 1 = %d, want 1", n)
+	}
+
+	if _, err := c.get(ctx, 1, snapshotKey([]string{"g2", "g1"}, nil), build); err != nil {
+		t.Fatal(err)
+	}
+	if n := builds.Load(); n != 1 {
+		t.Errorf("builds after reordered groups = %d, want 1", n)
+	}
+
+	if _, err := c.get(ctx, 2, snapshotKey([]string{"g1", "g2"}, nil), build); err != nil {
+		t.Fatal(err)
+	}
+	if n := builds.Load(); n != 2 {
+		t.Errorf("builds after revision bump = %d, want 2", n)
+	}
+	if n := c.size(); n != 1 {
+		t.Errorf("cached snapshots = %d, want 1", n)
+	}
+}
+
+func TestSnapshotCache_StaleRevisionNotCached(t *testing.T) {
+	c := newSnapshotCache()
+	ctx := context.Background()
+	build := func() ([]*pb.Policy, error) { return nil, nil }
+
+	if _, err := c.get(ctx, 5, snapshotKey([]string{"g1"}, nil), build); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.get(ctx, 4, snapshotKey([]string{"g2"}, nil), build); err != nil {
+		t.Fatal(err)
+	}
+	if n := c.size(); n != 1 {
+		t.Errorf("cached snapshots = %d, want 1", n)
+	}
+}
+
+func TestSnapshotCache_ErrorsNotCached(t *testing.T) {
+	c := newSnapshotCache()
+	ctx := context.Background()
+	key := snapshotKey([]string{"g1"}, []string{"f1"})
+
+	if _, err := c.get(ctx, 1, key, func() ([]*pb.Policy, error) { return nil, errors.New("db down") }); err == nil {
+		t.Fatal("expected build error")
+	}
+	got, err := c.get(ctx, 1, key, func() ([]*pb.Policy, error) { return []*pb.Policy{{Id: "p1"}}, nil })
+	if err != nil || len(got) != 1 {
+		t.Errorf("get after failed build = %v, %v", got, err)
+	}
+}
+
+func TestSnapshotKey_SeparatesGroupsAndFilters(t *testing.T) {
+	if snapshotKey([]string{"a"}, nil) == snapshotKey(nil, []string{"a"}) {
+		t.Error("group and filter with the same ID share a key")
+	}
+}
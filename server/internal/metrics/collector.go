@@ -24,12 +24,19 @@ type repos struct {
 	compliance     *database.DConfRepository
 }
 
+// PolicyHubStats exposes the in-process counters of the policy hub.
+type PolicyHubStats interface {
+	DeltaMisses() int64
+}
+
 // BorCollector implements prometheus.Collector and emits Bor-specific metrics
-// by querying the database on every scrape. All metrics are Gauges — the
-// collector runs lightweight aggregation queries (COUNT … GROUP BY) so the
-// overhead per scrape is minimal even for large fleets.
+// by querying the database on every scrape. The database metrics are Gauges —
+// the collector runs lightweight aggregation queries (COUNT … GROUP BY) so the
+// overhead per scrape is minimal even for large fleets. Policy hub metrics
+// are process-local counters.
 type BorCollector struct {
 	repos repos
+	hub   PolicyHubStats // optional
 
 	// ── Node metrics ──────────────────────────────────────────────────────
 	nodesTotal     *prometheus.Desc
@@ -48,6 +55,9 @@ type BorCollector struct {
 
 	// ── Audit metrics ─────────────────────────────────────────────────────
 	auditEventsTotal *prometheus.Desc
+
+	// ── Policy hub metrics ────────────────────────────────────────────────
+	policyDeltaMisses *prometheus.Desc
 }
 
 // NewBorCollector creates a new BorCollector wired to the given repositories.
@@ -113,9 +123,21 @@ func NewBorCollector(
 			"Total number of audit log entries, partitioned by action. This is a snapshot count, not a monotonic counter.",
 			[]string{"action"}, nil,
 		),
+
+		policyDeltaMisses: prometheus.NewDesc(
+			"bor_policy_delta_misses_total",
+			"Number of agent reconnects that needed a full snapshot because their revision was no longer in the policy event log.",
+			nil, nil,
+		),
 	}
 }
 
+// WithPolicyHub makes the collector report the policy hub's counters.
+func (c *BorCollector) WithPolicyHub(hub PolicyHubStats) *BorCollector {
+	c.hub = hub
+	return c
+}
+
 // Describe sends all metric descriptors to ch.
 func (c *BorCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.nodesTotal
@@ -126,6 +148,7 @@ func (c *BorCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.complianceTotal
 	ch <- c.usersTotal
 	ch <- c.auditEventsTotal
+	ch <- c.policyDeltaMisses
 }
 
 // Collect runs all DB queries and emits the current metric values.
@@ -141,6 +164,10 @@ func (c *BorCollector) Collect(ch chan<- prometheus.Metric) {
 	c.collectCompliance(ctx, ch)
 	c.collectUsers(ctx, ch)
 	c.collectAuditEvents(ctx, ch)
+	if c.hub != nil {
+		ch <- prometheus.MustNewConstMetric(c.policyDeltaMisses, prometheus.CounterValue,
+			float64(c.hub.DeltaMisses()))
+	}
 }
 
 func (c *BorCollector) collectNodes(ctx context.Context, ch chan<- prometheus.Metric) {
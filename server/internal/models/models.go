@@ -6,6 +6,8 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 )
 
@@ -16,12 +18,29 @@ const (
 	PolicyStateArchived = "archived"
 )
 
+// Contact-loss action constants: what an agent does with a policy once it has
+// been out of contact with the server for longer than the policy's TTL.
+const (
+	ContactLossKeep     = "keep"     // keep enforcing (default)
+	ContactLossRevert   = "revert"   // stop enforcing and restore originals
+	ContactLossActivate = "activate" // only enforce while out of contact
+)
+
 // Binding state constants (enforcement lifecycle)
 const (
 	BindingStateEnabled  = "enabled"
 	BindingStateDisabled = "disabled"
 )
 
+// Binding scope kinds: what a policy binding targets.
+const (
+	BindingScopeNodeGroup  = "node_group"
+	BindingScopeNodeFilter = "node_filter"
+	// BindingScopeUserGroup bindings apply the policy to the login
+	// sessions of the user group's members on any node, not machine-wide.
+	BindingScopeUserGroup = "user_group"
+)
+
 // Policy represents a desktop policy
 type Policy struct {
 	ID          string `json:"id" db:"id"`
@@ -38,9 +57,50 @@ type Policy struct {
 	DeprecatedAt        *time.Time `json:"deprecated_at,omitempty" db:"deprecated_at"`
 	DeprecationMessage  *string    `json:"deprecation_message,omitempty" db:"deprecation_message"`
 	ReplacementPolicyID *string    `json:"replacement_policy_id,omitempty" db:"replacement_policy_id"`
-	CreatedBy           string     `json:"created_by" db:"created_by"`
-	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+	// ContactLossAction and ContactLossTTLSeconds form the dead-man's switch:
+	// after ContactLossTTLSeconds without server contact the agent applies
+	// ContactLossAction to this policy.
+	ContactLossAction     string `json:"contact_loss_action" db:"contact_loss_action"`
+	ContactLossTTLSeconds int    `json:"contact_loss_ttl_seconds" db:"contact_loss_ttl_seconds"`
+	// CheckCommand is an optional absolute path run by opted-in agents to
+	// verify system state; its exit status is reported as compliance.
+	CheckCommand        string    `json:"check_command" db:"check_command"`
+	CheckArgs           []string  `json:"check_args" db:"check_args"`
+	CheckTimeoutSeconds int       `json:"check_timeout_seconds" db:"check_timeout_seconds"`
+	CreatedBy           string    `json:"created_by" db:"created_by"`
+	CreatedAt           time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
+
+	// Preconditions are evaluated by the agent against live local state;
+	// when any is unmet the policy is skipped rather than applied.
+	Preconditions []PolicyPrecondition `json:"preconditions" db:"preconditions"`
+
+	// ScopeUsers is set on policies resolved for a node's session users
+	// (ListPoliciesForSessionUsers): the users the policy applies to. The
+	// agent applies such policies to their sessions only.
+	ScopeUsers []string `json:"scope_users,omitempty"`
+}
+
+// ContentHash returns the hex SHA-256 of the policy content.
+func (p *Policy) ContentHash() string {
+	sum := sha256.Sum256([]byte(p.Content))
+	return hex.EncodeToString(sum[:])
+}
+
+// Policy precondition types
+const (
+	PreconditionFileExists       = "file_exists"
+	PreconditionCommandExists    = "command_exists"
+	PreconditionDesktopInstalled = "desktop_installed"
+	PreconditionDesktopSession   = "desktop_session"
+)
+
+// PolicyPrecondition is a check on the node's local state that must hold
+// for the agent to apply a policy. Negate inverts the result.
+type PolicyPrecondition struct {
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	Negate bool   `json:"negate,omitempty"`
 }
 
 // CreatePolicyRequest represents a request to create a policy
@@ -49,6 +109,15 @@ type CreatePolicyRequest struct {
 	Description string `json:"description"`
 	Type        string `json:"type"`
 	Content     string `json:"content"`
+	// ContactLossAction defaults to "keep" when empty.
+	ContactLossAction     string `json:"contact_loss_action,omitempty"`
+	ContactLossTTLSeconds int    `json:"contact_loss_ttl_seconds,omitempty"`
+
+	CheckCommand        string   `json:"check_command,omitempty"`
+	CheckArgs           []string `json:"check_args,omitempty"`
+	CheckTimeoutSeconds int      `json:"check_timeout_seconds,omitempty"`
+
+	Preconditions []PolicyPrecondition `json:"preconditions,omitempty"`
 }
 
 // UpdatePolicyRequest represents a request to update a policy (only allowed in DRAFT state)
@@ -57,6 +126,21 @@ type UpdatePolicyRequest struct {
 	Description *string `json:"description,omitempty"`
 	Type        *string `json:"type,omitempty"`
 	Content     *string `json:"content,omitempty"`
+
+	ContactLossAction     *string `json:"contact_loss_action,omitempty"`
+	ContactLossTTLSeconds *int    `json:"contact_loss_ttl_seconds,omitempty"`
+
+	CheckCommand        *string   `json:"check_command,omitempty"`
+	CheckArgs           *[]string `json:"check_args,omitempty"`
+	CheckTimeoutSeconds *int      `json:"check_timeout_seconds,omitempty"`
+
+	Preconditions *[]PolicyPrecondition `json:"preconditions,omitempty"`
+}
+
+// RenderedPolicyFile is a file as an agent would write it for a policy.
+type RenderedPolicyFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
 }
 
 // SetPolicyStateRequest represents a request to change policy state
@@ -70,6 +154,53 @@ type DeprecatePolicyRequest struct {
 	ReplacementPolicyID *string `json:"replacement_policy_id,omitempty"`
 }
 
+// PolicyBundleVersion is the current version of the policy bundle format.
+const PolicyBundleVersion = 1
+
+// PolicyBundle is a portable set of policies, used to move policies between
+// Bor instances. Imported policies are created as drafts with new IDs.
+type PolicyBundle struct {
+	Version    int                 `json:"version"`
+	ExportedAt time.Time           `json:"exported_at"`
+	Policies   []PolicyBundleEntry `json:"policies"`
+}
+
+// PolicyBundleEntry is a policy in a bundle. Its fields are those of a
+// create request, so it is validated like one on import.
+type PolicyBundleEntry struct {
+	// ID is the policy's ID on the exporting instance.
+	ID string `json:"id"`
+	CreatePolicyRequest
+}
+
+// PolicyImportResult summarises a policy bundle import.
+type PolicyImportResult struct {
+	Imported []PolicyImportMapping `json:"imported"`
+	// Collisions lists the names of bundle policies that were skipped
+	// because a policy with the same name already exists.
+	Collisions []string `json:"collisions"`
+}
+
+// PolicyImportMapping maps a bundle policy to the policy created for it.
+type PolicyImportMapping struct {
+	SourceID string `json:"source_id"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+}
+
+// ValidatePolicyRequest is the body of POST /api/v1/policies/validate.
+type ValidatePolicyRequest struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// ValidatePolicyResponse reports whether policy content would be accepted
+// on create or update, and why not.
+type ValidatePolicyResponse struct {
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
 // Node status constants
 const (
 	NodeStatusOnline   = "online"
@@ -92,15 +223,22 @@ type Client struct {
 
 // Node represents a managed desktop node/agent
 type Node struct {
-	ID             string     `json:"id" db:"id"`
-	Name           string     `json:"name" db:"name"`
-	FQDN           *string    `json:"fqdn,omitempty" db:"fqdn"`
-	MachineID      *string    `json:"machine_id,omitempty" db:"machine_id"`
-	IPAddress      *string    `json:"ip_address,omitempty" db:"ip_address"`
-	OSName         *string    `json:"os_name,omitempty" db:"os_name"`
-	OSVersion      *string    `json:"os_version,omitempty" db:"os_version"`
-	DesktopEnv     *string    `json:"desktop_env,omitempty" db:"desktop_env"`
-	AgentVersion   *string    `json:"agent_version,omitempty" db:"agent_version"`
+	ID             string  `json:"id" db:"id"`
+	Name           string  `json:"name" db:"name"`
+	FQDN           *string `json:"fqdn,omitempty" db:"fqdn"`
+	MachineID      *string `json:"machine_id,omitempty" db:"machine_id"`
+	IPAddress      *string `json:"ip_address,omitempty" db:"ip_address"`
+	OSName         *string `json:"os_name,omitempty" db:"os_name"`
+	OSVersion      *string `json:"os_version,omitempty" db:"os_version"`
+	DesktopEnv     *string `json:"desktop_env,omitempty" db:"desktop_env"`
+	AgentVersion   *string `json:"agent_version,omitempty" db:"agent_version"`
+	FirefoxVersion *string `json:"firefox_version,omitempty" db:"firefox_version"`
+	ChromeVersion  *string `json:"chrome_version,omitempty" db:"chrome_version"`
+	KernelVersion  *string `json:"kernel_version,omitempty" db:"kernel_version"`
+	// Hardware holds the hardware facts last reported by the agent.
+	Hardware *NodeHardware `json:"hardware,omitempty" db:"hardware"`
+	// LowDisk is set when the root filesystem is nearly full.
+	LowDisk        bool       `json:"low_disk,omitempty"`
 	StatusCached   string     `json:"status" db:"status_cached"`
 	StatusReason   *string    `json:"status_reason,omitempty" db:"status_reason"`
 	Groups         *string    `json:"groups,omitempty" db:"groups"`
@@ -112,6 +250,42 @@ type Node struct {
 	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
 	CertSerial     *string    `json:"cert_serial,omitempty" db:"cert_serial"`
 	CertNotAfter   *time.Time `json:"cert_not_after,omitempty" db:"cert_not_after"`
+	CampaignID     *string    `json:"campaign_id,omitempty" db:"campaign_id"`
+	// SessionUsers are the users with a login session on the node, as last
+	// reported by its agent.
+	SessionUsers []string `json:"session_users"`
+	// QuarantinedAt is set while the node is quarantined: it then receives
+	// only the policies bound to quarantine node groups.
+	QuarantinedAt    *time.Time `json:"quarantined_at,omitempty" db:"quarantined_at"`
+	QuarantineReason *string    `json:"quarantine_reason,omitempty" db:"quarantine_reason"`
+	// AgentOutdated is set when AgentVersion is below the server's minimum
+	// supported agent version; such agents are refused until upgraded.
+	AgentOutdated bool `json:"agent_outdated,omitempty"`
+}
+
+// LowDiskFreeRatio is the share of free space on the root filesystem below
+// which a node is flagged as low on disk.
+const LowDiskFreeRatio = 0.1
+
+// NodeHardware holds hardware and resource facts reported by an agent.
+// Zero values mean the agent could not determine the fact.
+type NodeHardware struct {
+	MemoryTotalBytes     uint64     `json:"memory_total_bytes,omitempty"`
+	MemoryAvailableBytes uint64     `json:"memory_available_bytes,omitempty"`
+	CPUModel             string     `json:"cpu_model,omitempty"`
+	CPUCores             int        `json:"cpu_cores,omitempty"`
+	RootDiskTotalBytes   uint64     `json:"root_disk_total_bytes,omitempty"`
+	RootDiskFreeBytes    uint64     `json:"root_disk_free_bytes,omitempty"`
+	BootTime             *time.Time `json:"boot_time,omitempty"`
+}
+
+// LowDisk reports whether less than LowDiskFreeRatio of the root
+// filesystem is free.
+func (h *NodeHardware) LowDisk() bool {
+	if h == nil || h.RootDiskTotalBytes == 0 {
+		return false
+	}
+	return float64(h.RootDiskFreeBytes) < LowDiskFreeRatio*float64(h.RootDiskTotalBytes)
 }
 
 // UpdateNodeRequest represents a request to update a node
@@ -121,6 +295,151 @@ type UpdateNodeRequest struct {
 	Notes  *string `json:"notes,omitempty"`
 }
 
+// QuarantineNodeRequest is the body of a node quarantine request.
+type QuarantineNodeRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// BulkDeleteNodesRequest selects nodes to decommission, either by ID or by
+// saved node filter. ExpectedCount must equal the number of nodes that
+// would be deleted, guarding against accidental mass deletion.
+type BulkDeleteNodesRequest struct {
+	NodeIDs       []string `json:"node_ids,omitempty"`
+	FilterID      string   `json:"filter_id,omitempty"`
+	ExpectedCount int      `json:"expected_count"`
+	Reason        string   `json:"reason,omitempty"`
+}
+
+// Bulk node deletion result statuses
+const (
+	BulkDeleteStatusDeleted  = "deleted"
+	BulkDeleteStatusNotFound = "not_found"
+)
+
+// BulkDeleteNodeResult reports what happened to one requested node.
+type BulkDeleteNodeResult struct {
+	NodeID       string `json:"node_id"`
+	Name         string `json:"name,omitempty"`
+	Status       string `json:"status"`
+	CertRevoked  bool   `json:"cert_revoked"`
+	Disconnected bool   `json:"disconnected"`
+}
+
+// BulkDeleteNodesResponse is the response of a bulk node deletion.
+type BulkDeleteNodesResponse struct {
+	Deleted int                     `json:"deleted"`
+	Results []*BulkDeleteNodeResult `json:"results"`
+}
+
+// NodeOverrides holds hypothetical node attributes for an effective-policy
+// dry run ("what would this node receive if ..."). Nil or empty fields keep
+// the node's stored value.
+type NodeOverrides struct {
+	// GroupIDs replaces the node's group membership when non-nil.
+	GroupIDs       []string `json:"group_ids,omitempty"`
+	AddGroupIDs    []string `json:"add_group_ids,omitempty"`
+	RemoveGroupIDs []string `json:"remove_group_ids,omitempty"`
+	OSName         *string  `json:"os_name,omitempty"`
+	OSVersion      *string  `json:"os_version,omitempty"`
+	DesktopEnv     *string  `json:"desktop_env,omitempty"`
+	AgentVersion   *string  `json:"agent_version,omitempty"`
+}
+
+// EffectivePolicies is the set of policies a node receives. For a dry run
+// it also lists which policies would be added or removed compared to what
+// the node receives today.
+type EffectivePolicies struct {
+	NodeID    string         `json:"node_id"`
+	DryRun    bool           `json:"dry_run"`
+	Overrides *NodeOverrides `json:"overrides,omitempty"`
+	GroupIDs  []string       `json:"group_ids"`
+	FilterIDs []string       `json:"filter_ids"`
+	Policies  []*Policy      `json:"policies"`
+	Added     []string       `json:"added,omitempty"`
+	Removed   []string       `json:"removed,omitempty"`
+	// Stale lists the deprecated policies among Policies and the archived
+	// policies the node is still bound to.
+	Stale []*StalePolicy `json:"stale_policies"`
+}
+
+// Stale policy reasons
+const (
+	StaleReasonDeprecated = "deprecated"
+	StaleReasonArchived   = "archived"
+)
+
+// StaleBinding is an enabled binding of a deprecated or archived policy.
+// Exactly one of GroupID and FilterID is set.
+type StaleBinding struct {
+	GroupID  *string
+	FilterID *string
+	Policy   StalePolicy
+}
+
+// StalePolicy is a policy a node still receives although it has been
+// deprecated, or is still bound to although it has been archived (archived
+// policies are no longer delivered, but the binding was never cleaned up).
+type StalePolicy struct {
+	PolicyID            string  `json:"policy_id"`
+	PolicyName          string  `json:"policy_name"`
+	Reason              string  `json:"reason"`
+	DeprecationMessage  *string `json:"deprecation_message,omitempty"`
+	ReplacementPolicyID *string `json:"replacement_policy_id,omitempty"`
+}
+
+// NodeStalePolicies lists the stale policies of a single node.
+type NodeStalePolicies struct {
+	NodeID   string         `json:"node_id"`
+	NodeName string         `json:"node_name"`
+	Policies []*StalePolicy `json:"policies"`
+}
+
+// NodeLogCapture statuses
+const (
+	LogCapturePending  = "pending"
+	LogCaptureReceived = "received"
+)
+
+// NodeLogCapture is an on-demand capture of an agent's recent log buffer.
+// The log content itself is only returned by the download endpoint.
+type NodeLogCapture struct {
+	ID          string     `json:"id" db:"id"`
+	NodeID      string     `json:"node_id" db:"node_id"`
+	Status      string     `json:"status"`
+	RequestedBy string     `json:"requested_by" db:"requested_by"`
+	RequestedAt time.Time  `json:"requested_at" db:"requested_at"`
+	ReceivedAt  *time.Time `json:"received_at,omitempty" db:"received_at"`
+	Size        int        `json:"size"`
+	Truncated   bool       `json:"truncated" db:"truncated"`
+}
+
+// NodeRemediation statuses
+const (
+	RemediationPending   = "pending"
+	RemediationSucceeded = "succeeded"
+	RemediationFailed    = "failed"
+)
+
+// NodeRemediation is a one-time run of a predefined remediation action on
+// a node.
+type NodeRemediation struct {
+	ID          string     `json:"id" db:"id"`
+	NodeID      string     `json:"node_id" db:"node_id"`
+	Action      string     `json:"action" db:"action"`
+	Status      string     `json:"status"`
+	RequestedBy string     `json:"requested_by" db:"requested_by"`
+	RequestedAt time.Time  `json:"requested_at" db:"requested_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	ExitCode    *int       `json:"exit_code,omitempty" db:"exit_code"`
+	Output      string     `json:"output" db:"output"`
+	Error       string     `json:"error,omitempty" db:"error"`
+}
+
+// RunRemediationRequest is the body of POST /api/v1/nodes/{id}/run-command.
+type RunRemediationRequest struct {
+	Action string `json:"action"`
+}
+
 // ComplianceReport represents a policy compliance report from a client
 type ComplianceReport struct {
 	ID         string    `json:"id" db:"id"`
@@ -133,15 +452,27 @@ type ComplianceReport struct {
 
 // User represents a system user for authentication
 type User struct {
-	ID           string    `json:"id" db:"id"`
-	Username     string    `json:"username" db:"username"`
-	PasswordHash string    `json:"-" db:"password_hash"`
-	Email        string    `json:"email" db:"email"`
-	FullName     string    `json:"full_name" db:"full_name"`
-	Source       string    `json:"source" db:"source"` // "local" or "ldap"
-	Enabled      bool      `json:"enabled" db:"enabled"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID           string `json:"id" db:"id"`
+	Username     string `json:"username" db:"username"`
+	PasswordHash string `json:"-" db:"password_hash"`
+	Email        string `json:"email" db:"email"`
+	FullName     string `json:"full_name" db:"full_name"`
+	Source       string `json:"source" db:"source"` // "local" or "ldap"
+	Enabled      bool   `json:"enabled" db:"enabled"`
+	// FailedAttempts counts consecutive failed logins; reaching the
+	// lockout threshold locks the account until LockedUntil.
+	FailedAttempts int        `json:"failed_attempts" db:"failed_attempts"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty" db:"locked_until"`
+	// MustChangePassword is set by an admin password reset; the user is
+	// asked to choose a new password after their next login.
+	MustChangePassword bool      `json:"must_change_password" db:"must_change_password"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IsLocked reports whether the account is locked out at now.
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
 }
 
 // Legacy role constants (kept for backward compatibility during migration)
@@ -175,14 +506,75 @@ type Permission struct {
 
 // UserRoleBinding represents a binding between a user, a role, and a scope
 type UserRoleBinding struct {
-	ID        string    `json:"id" db:"id"`
-	UserID    string    `json:"user_id" db:"user_id"`
-	RoleID    string    `json:"role_id" db:"role_id"`
-	ScopeType string    `json:"scope_type" db:"scope_type"`
-	ScopeID   *string   `json:"scope_id,omitempty" db:"scope_id"`
+	ID        string  `json:"id" db:"id"`
+	UserID    string  `json:"user_id" db:"user_id"`
+	RoleID    string  `json:"role_id" db:"role_id"`
+	ScopeType string  `json:"scope_type" db:"scope_type"`
+	ScopeID   *string `json:"scope_id,omitempty" db:"scope_id"`
+	// Source records who created the binding; see RoleBindingSourceManual.
+	Source    string    `json:"source" db:"source"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
+// RoleHolder is a user holding a role through one role binding: a direct
+// binding, or a binding of a user group the user is a member of.
+type RoleHolder struct {
+	UserID    string  `json:"user_id"`
+	Username  string  `json:"username"`
+	FullName  string  `json:"full_name"`
+	Enabled   bool    `json:"enabled"`
+	BindingID string  `json:"binding_id"`
+	ScopeType string  `json:"scope_type"`
+	ScopeID   *string `json:"scope_id,omitempty"`
+	// ViaGroupID and ViaGroupName identify the user group whose binding
+	// grants the role; they are empty for direct bindings.
+	ViaGroupID   string `json:"via_group_id,omitempty"`
+	ViaGroupName string `json:"via_group_name,omitempty"`
+}
+
+// EffectivePermissions explains a user's permissions for debugging access:
+// every permission the user holds, grouped by the scope it is held in,
+// with the role bindings granting it.
+type EffectivePermissions struct {
+	UserID   string           `json:"user_id"`
+	Username string           `json:"username"`
+	Scopes   []EffectiveScope `json:"scopes"`
+}
+
+// EffectiveScope lists the permissions a user holds in one scope. ScopeID
+// is nil for the global scope.
+type EffectiveScope struct {
+	ScopeType   string                `json:"scope_type"`
+	ScopeID     *string               `json:"scope_id,omitempty"`
+	Permissions []EffectivePermission `json:"permissions"`
+}
+
+// EffectivePermission is one "resource:action" permission and the role
+// bindings granting it.
+type EffectivePermission struct {
+	Permission string            `json:"permission"`
+	Grants     []PermissionGrant `json:"grants"`
+}
+
+// PermissionGrant identifies a role binding granting a permission. ViaGroupID
+// and ViaGroupName are set for bindings of a user group the user is a
+// member of.
+type PermissionGrant struct {
+	BindingID    string `json:"binding_id"`
+	RoleID       string `json:"role_id"`
+	RoleName     string `json:"role_name"`
+	ViaGroupID   string `json:"via_group_id,omitempty"`
+	ViaGroupName string `json:"via_group_name,omitempty"`
+}
+
+// Role binding sources. Bindings created by the LDAP group→role sync are
+// marked RoleBindingSourceLDAP so that the sync only ever revokes its own
+// grants.
+const (
+	RoleBindingSourceManual = "manual"
+	RoleBindingSourceLDAP   = "ldap"
+)
+
 // Default role name constants
 const (
 	RoleSuperAdmin       = "Super Admin"
@@ -209,6 +601,12 @@ type LoginRequest struct {
 type LoginResponse struct {
 	Token string `json:"token"`
 	User  User   `json:"user"`
+	// MFARequired is set, without Token and User, for a user with a second
+	// factor enrolled. SessionToken is then sent to POST /api/v1/auth/step
+	// with a TOTP code to obtain the token.
+	MFARequired  bool     `json:"mfa_required,omitempty"`
+	SessionToken string   `json:"session_token,omitempty"`
+	MFAMethods   []string `json:"mfa_methods,omitempty"`
 }
 
 // MeResponse represents the response for GET /api/v1/auth/me
@@ -217,7 +615,23 @@ type MeResponse struct {
 	Username    string   `json:"username"`
 	Email       string   `json:"email"`
 	FullName    string   `json:"full_name"`
+	Source      string   `json:"source"`
 	Permissions []string `json:"permissions"`
+	// MustChangePassword is set after an admin reset the user's password.
+	MustChangePassword bool `json:"must_change_password"`
+}
+
+// ResetPasswordRequest is the body of an admin password reset. When
+// MustChange is set the user has to choose a new password after logging in.
+type ResetPasswordRequest struct {
+	NewPassword string `json:"new_password"`
+	MustChange  bool   `json:"must_change"`
+}
+
+// ChangePasswordRequest is the body of a self-service password change.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
 }
 
 // CreateUserRequest represents a request to create a user
@@ -242,6 +656,12 @@ type CreateRoleRequest struct {
 	Description string `json:"description"`
 }
 
+// CloneRoleRequest is the optional body of a role clone request. An empty
+// Name derives one from the source role's name.
+type CloneRoleRequest struct {
+	Name string `json:"name"`
+}
+
 // UpdateRoleRequest represents a request to update a role
 type UpdateRoleRequest struct {
 	Name        *string `json:"name,omitempty"`
@@ -253,6 +673,53 @@ type SetRolePermissionsRequest struct {
 	PermissionIDs []string `json:"permission_ids"`
 }
 
+// RBACExportVersion is the current version of the RBAC export document format.
+const RBACExportVersion = 1
+
+// RBACExport is a portable snapshot of the authorization model. All
+// references are by name (role name, "resource:action", username, user
+// group name, node group name) so the document can be applied to another
+// Bor instance whose IDs differ.
+type RBACExport struct {
+	Version       int                      `json:"version"`
+	ExportedAt    time.Time                `json:"exported_at"`
+	Roles         []RBACExportRole         `json:"roles"`
+	UserBindings  []RBACExportUserBinding  `json:"user_bindings,omitempty"`
+	GroupBindings []RBACExportGroupBinding `json:"group_bindings,omitempty"`
+}
+
+// RBACExportRole is a role and its permissions in an RBAC export.
+type RBACExportRole struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"` // "resource:action"
+}
+
+// RBACExportUserBinding is a user role binding in an RBAC export.
+type RBACExportUserBinding struct {
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	ScopeType string `json:"scope_type"`
+	ScopeName string `json:"scope_name,omitempty"` // node group name for "group" scope
+}
+
+// RBACExportGroupBinding is a user group role binding in an RBAC export.
+type RBACExportGroupBinding struct {
+	Group     string `json:"group"`
+	Role      string `json:"role"`
+	ScopeType string `json:"scope_type"`
+	ScopeName string `json:"scope_name,omitempty"` // node group name for "group" scope
+}
+
+// RBACImportResult summarises the changes made by an RBAC import.
+type RBACImportResult struct {
+	RolesCreated         int      `json:"roles_created"`
+	RolesUpdated         int      `json:"roles_updated"`
+	UserBindingsCreated  int      `json:"user_bindings_created"`
+	GroupBindingsCreated int      `json:"group_bindings_created"`
+	Warnings             []string `json:"warnings"`
+}
+
 // UserGroup represents a logical grouping of users (identity domain)
 type UserGroup struct {
 	ID          string    `json:"id" db:"id"`
@@ -295,6 +762,8 @@ type UserGroupRoleBinding struct {
 	ScopeType string    `json:"scope_type" db:"scope_type"`
 	ScopeID   *string   `json:"scope_id,omitempty" db:"scope_id"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	// GroupName is only filled in when listing the bindings of a user.
+	GroupName string `json:"group_name,omitempty"`
 }
 
 // CreateGroupRoleBindingRequest represents a request to assign a role to a group
@@ -306,31 +775,211 @@ type CreateGroupRoleBindingRequest struct {
 
 // NodeGroup represents a logical grouping of nodes (infrastructure domain)
 type NodeGroup struct {
-	ID          string    `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name"`
-	Description string    `json:"description" db:"description"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID          string `json:"id" db:"id"`
+	Name        string `json:"name" db:"name"`
+	Description string `json:"description" db:"description"`
+	// InventoryOnly makes the agents of member nodes report inventory and
+	// compliance without enforcing any policy.
+	InventoryOnly bool `json:"inventory_only" db:"inventory_only"`
+	// Quarantine marks a group whose enabled bindings form the lockdown
+	// policy set delivered to quarantined nodes instead of their own.
+	Quarantine bool      `json:"quarantine" db:"quarantine"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NodeGroupDetails is a node group with its member nodes and the policy
+// bindings targeting it.
+type NodeGroupDetails struct {
+	NodeGroup
+	Nodes    []NodeGroupMember           `json:"nodes"`
+	Bindings []*PolicyBindingWithDetails `json:"bindings"`
+}
+
+// NodeGroupMember identifies a member node of a node group.
+type NodeGroupMember struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// NodeGroupMembersRequest names the nodes to add to or remove from a node
+// group in one call.
+type NodeGroupMembersRequest struct {
+	NodeIDs []string `json:"node_ids"`
+}
+
+// NodeGroupMembersResponse reports a bulk node group membership change.
+// Skipped counts the requested nodes that were already members (when
+// adding), were not members (when removing) or do not exist.
+type NodeGroupMembersResponse struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+	Skipped int `json:"skipped"`
 }
 
 // CreateNodeGroupRequest represents a request to create a node group
 type CreateNodeGroupRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	InventoryOnly bool   `json:"inventory_only"`
+	Quarantine    bool   `json:"quarantine"`
 }
 
 // UpdateNodeGroupRequest represents a request to update a node group
 type UpdateNodeGroupRequest struct {
-	Name        *string `json:"name,omitempty"`
-	Description *string `json:"description,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	InventoryOnly *bool   `json:"inventory_only,omitempty"`
+	Quarantine    *bool   `json:"quarantine,omitempty"`
+}
+
+// NodeListRequest represents query parameters for a paginated node list.
+// All non-empty filters must match.
+type NodeListRequest struct {
+	Page       int    `json:"page"`
+	PerPage    int    `json:"per_page"`
+	Search     string `json:"search,omitempty"`      // substring of name, FQDN, IP address or groups
+	Status     string `json:"status,omitempty"`      // exact status
+	OSName     string `json:"os_name,omitempty"`     // exact OS name, case-insensitive
+	DesktopEnv string `json:"desktop_env,omitempty"` // one of the node's desktop environments, case-insensitive
+	GroupID    string `json:"group_id,omitempty"`    // node group the node is a member of
+}
+
+// NodeListResponse represents a paginated list of nodes
+type NodeListResponse = ListResponse[*Node]
+
+// Pagination selects one page of a list. Page starts at 1.
+type Pagination struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
 }
 
-// EnrollmentToken represents a short-lived, single-use enrollment token
+// Offset returns the number of items on the pages before p.
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+// ListResponse is the envelope of every paginated list.
+type ListResponse[T any] struct {
+	Items      []T `json:"items"`
+	Total      int `json:"total"`
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	TotalPages int `json:"total_pages"`
+}
+
+// NewListResponse wraps page p of a list of total items. A nil items
+// slice is encoded as an empty array.
+func NewListResponse[T any](items []T, total int, p Pagination) *ListResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+	totalPages := 0
+	if p.PerPage > 0 {
+		totalPages = (total + p.PerPage - 1) / p.PerPage
+	}
+	return &ListResponse[T]{
+		Items:      items,
+		Total:      total,
+		Page:       p.Page,
+		PerPage:    p.PerPage,
+		TotalPages: totalPages,
+	}
+}
+
+// NodeFilter is a saved, dynamic node selection. Unlike a NodeGroup it has
+// no stored membership: a node belongs to the filter whenever its reported
+// facts match the criteria.
+type NodeFilter struct {
+	ID          string             `json:"id" db:"id"`
+	Name        string             `json:"name" db:"name"`
+	Description string             `json:"description" db:"description"`
+	Criteria    NodeFilterCriteria `json:"criteria" db:"criteria"`
+	CreatedAt   time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// NodeFilterCriteria holds the conditions of a NodeFilter. Each non-empty
+// field is a case-insensitive glob pattern (path.Match syntax) and all of
+// them must match. DesktopEnv matches if any of the node's desktop
+// environments matches.
+type NodeFilterCriteria struct {
+	NamePattern  string `json:"name_pattern,omitempty"`
+	OSName       string `json:"os_name,omitempty"`
+	OSVersion    string `json:"os_version,omitempty"`
+	DesktopEnv   string `json:"desktop_env,omitempty"`
+	AgentVersion string `json:"agent_version,omitempty"`
+}
+
+// CreateNodeFilterRequest represents a request to create a node filter
+type CreateNodeFilterRequest struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Criteria    NodeFilterCriteria `json:"criteria"`
+}
+
+// UpdateNodeFilterRequest represents a request to update a node filter
+type UpdateNodeFilterRequest struct {
+	Name        *string             `json:"name,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	Criteria    *NodeFilterCriteria `json:"criteria,omitempty"`
+}
+
+// EnrollmentToken represents a short-lived enrollment token that can be
+// redeemed MaxUses times. Used is set once all uses are consumed.
 type EnrollmentToken struct {
-	Token       string    `json:"token"`
-	NodeGroupID string    `json:"node_group_id"`
-	ExpiresAt   time.Time `json:"expires_at"`
-	Used        bool      `json:"used"`
+	// ID identifies the token, e.g. to revoke it, without revealing it.
+	ID          string     `json:"id"`
+	Token       string     `json:"token"`
+	NodeGroupID string     `json:"node_group_id"`
+	CampaignID  string     `json:"campaign_id,omitempty"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	MaxUses     int        `json:"max_uses"`
+	UseCount    int        `json:"use_count"`
+	Used        bool       `json:"used"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy   string     `json:"revoked_by,omitempty"`
+}
+
+// CreateEnrollmentTokenRequest is the optional body of a node group token
+// request. MaxUses defaults to 1 and TTLSeconds to five minutes.
+type CreateEnrollmentTokenRequest struct {
+	MaxUses    int `json:"max_uses,omitempty"`
+	TTLSeconds int `json:"ttl_seconds,omitempty"`
+}
+
+// EnrollmentCampaign organizes the enrollment of a batch of machines into a
+// node group. Tokens issued under a campaign share its TTL, and the campaign
+// stops accepting enrollments once MaxEnrollments is reached (0 = no limit).
+type EnrollmentCampaign struct {
+	ID              string    `json:"id" db:"id"`
+	Name            string    `json:"name" db:"name"`
+	Description     string    `json:"description" db:"description"`
+	NodeGroupID     string    `json:"node_group_id" db:"node_group_id"`
+	TokenTTLSeconds int       `json:"token_ttl_seconds" db:"token_ttl_seconds"`
+	MaxEnrollments  int       `json:"max_enrollments" db:"max_enrollments"`
+	EnrolledCount   int       `json:"enrolled_count" db:"enrolled_count"`
+	CreatedBy       string    `json:"created_by" db:"created_by"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateEnrollmentCampaignRequest represents a request to create an enrollment campaign
+type CreateEnrollmentCampaignRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	NodeGroupID     string `json:"node_group_id"`
+	TokenTTLSeconds int    `json:"token_ttl_seconds"`
+	MaxEnrollments  int    `json:"max_enrollments"`
+}
+
+// UpdateEnrollmentCampaignRequest represents a request to update an enrollment campaign
+type UpdateEnrollmentCampaignRequest struct {
+	Name            *string `json:"name,omitempty"`
+	Description     *string `json:"description,omitempty"`
+	TokenTTLSeconds *int    `json:"token_ttl_seconds,omitempty"`
+	MaxEnrollments  *int    `json:"max_enrollments,omitempty"`
 }
 
 // AgentNotificationSettings holds the notification configuration for agents
@@ -342,6 +991,78 @@ type AgentNotificationSettings struct {
 	NotifyMessageChrome  string `json:"notify_message_chrome"`
 }
 
+// Webhook event types
+const (
+	WebhookEventPolicyChanged  = "policy.changed"
+	WebhookEventBindingChanged = "binding.changed"
+	WebhookEventTest           = "test"
+)
+
+// Webhook is an external URL called with a signed JSON payload when
+// policies or bindings change. An empty Events list subscribes to every
+// event type.
+type Webhook struct {
+	ID        string    `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	URL       string    `json:"url" db:"url"`
+	Secret    string    `json:"-" db:"secret"` // encrypted, never returned
+	Events    []string  `json:"events" db:"events"`
+	Enabled   bool      `json:"enabled" db:"enabled"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateWebhookRequest represents a request to create a webhook
+type CreateWebhookRequest struct {
+	Name    string   `json:"name"`
+	URL     string   `json:"url"`
+	Secret  string   `json:"secret"`
+	Events  []string `json:"events"`
+	Enabled *bool    `json:"enabled,omitempty"`
+}
+
+// UpdateWebhookRequest represents a request to update a webhook. An
+// omitted secret keeps the current one.
+type UpdateWebhookRequest struct {
+	Name    *string   `json:"name,omitempty"`
+	URL     *string   `json:"url,omitempty"`
+	Secret  *string   `json:"secret,omitempty"`
+	Events  *[]string `json:"events,omitempty"`
+	Enabled *bool     `json:"enabled,omitempty"`
+}
+
+// WebhookEvent is the JSON payload POSTed to webhooks.
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"event"`
+	Resource  WebhookResource `json:"resource"`
+	Actor     string          `json:"actor"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// WebhookResource identifies the object a webhook event is about.
+type WebhookResource struct {
+	Type     string `json:"type"` // "policy" or "policy_binding"
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	State    string `json:"state,omitempty"`
+	PolicyID string `json:"policy_id,omitempty"`
+}
+
+// WebhookTestRequest is sent by POST /api/v1/settings/webhooks/test. An
+// empty WebhookID tests every enabled webhook.
+type WebhookTestRequest struct {
+	WebhookID string `json:"webhook_id,omitempty"`
+}
+
+// WebhookDeliveryResult reports one test delivery.
+type WebhookDeliveryResult struct {
+	WebhookID  string `json:"webhook_id"`
+	Name       string `json:"name"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
 // AuditLog represents an audit log entry
 type AuditLog struct {
 	ID           string    `json:"id" db:"id"`
@@ -353,6 +1074,10 @@ type AuditLog struct {
 	Details      string    `json:"details" db:"details"`
 	IPAddress    string    `json:"ip_address" db:"ip_address"`
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+
+	// CorrelationID is shared by all entries recorded while serving one
+	// request.
+	CorrelationID string `json:"correlation_id,omitempty" db:"correlation_id"`
 }
 
 // AuditLogListRequest represents query parameters for listing audit logs
@@ -362,48 +1087,129 @@ type AuditLogListRequest struct {
 	ResourceTypes []string `json:"resource_types,omitempty"`
 	Actions       []string `json:"actions,omitempty"`
 	Username      string   `json:"username,omitempty"`
+	CorrelationID string   `json:"correlation_id,omitempty"`
+	// Query matches entries whose details or resource ID contain it,
+	// ignoring case.
+	Query string `json:"q,omitempty"`
+	// From and To bound created_at: From is inclusive, To exclusive.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
 }
 
 // AuditLogListResponse represents a paginated list of audit logs
-type AuditLogListResponse struct {
-	Items      []*AuditLog `json:"items"`
-	Total      int         `json:"total"`
-	Page       int         `json:"page"`
-	PerPage    int         `json:"per_page"`
-	TotalPages int         `json:"total_pages"`
-}
+type AuditLogListResponse = ListResponse[*AuditLog]
 
-// PolicyBinding represents a binding between a policy and a node group
+// PolicyBinding represents a binding between a policy and its targets: a
+// static node group or, when FilterID is set, a saved node filter or, when
+// UserGroupID is set, a user group.
 type PolicyBinding struct {
-	ID        string    `json:"id" db:"id"`
-	PolicyID  string    `json:"policy_id" db:"policy_id"`
-	GroupID   string    `json:"group_id" db:"group_id"`
-	State     string    `json:"state" db:"state"`
-	Priority  int       `json:"priority" db:"priority"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID          string  `json:"id" db:"id"`
+	PolicyID    string  `json:"policy_id" db:"policy_id"`
+	GroupID     string  `json:"group_id" db:"group_id"`
+	FilterID    *string `json:"filter_id,omitempty" db:"filter_id"`
+	UserGroupID *string `json:"user_group_id,omitempty" db:"user_group_id"`
+	// ScopeKind tells which of GroupID, FilterID and UserGroupID is the
+	// target (one of the BindingScope constants).
+	ScopeKind string `json:"scope_kind"`
+	State     string `json:"state" db:"state"`
+	Priority  int    `json:"priority" db:"priority"`
+	// Window limits when an enabled binding is in effect; nil means always.
+	Window *BindingWindow `json:"window,omitempty"`
+	// InWindow reports whether the binding is currently in its window.
+	// Bindings out of their window deliver nothing, as if disabled.
+	InWindow bool `json:"in_window" db:"in_window"`
+	// ProfileManaged is set on bindings created by binding a profile; they
+	// are reconciled with the profile's membership.
+	ProfileManaged bool      `json:"profile_managed" db:"profile_managed"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// BindingWindow limits when a policy binding is in effect, e.g. to lock
+// down lab machines during class hours. The binding is in its window
+// between ActiveFrom and ActiveTo, when set, and, when Schedule is set,
+// only during its weekly windows, such as "Mon-Fri 08:00-16:00", in the
+// IANA time zone Timezone (default UTC).
+type BindingWindow struct {
+	ActiveFrom *time.Time `json:"active_from,omitempty"`
+	ActiveTo   *time.Time `json:"active_to,omitempty"`
+	Schedule   string     `json:"schedule,omitempty"`
+	Timezone   string     `json:"timezone,omitempty"`
 }
 
 // PolicyBindingWithDetails includes related policy and group information
 type PolicyBindingWithDetails struct {
 	PolicyBinding
-	PolicyName  string `json:"policy_name"`
-	PolicyState string `json:"policy_state"`
-	GroupName   string `json:"group_name"`
-	NodeCount   int    `json:"node_count"`
+	PolicyName    string `json:"policy_name"`
+	PolicyState   string `json:"policy_state"`
+	GroupName     string `json:"group_name"`
+	FilterName    string `json:"filter_name,omitempty"`
+	UserGroupName string `json:"user_group_name,omitempty"`
+	NodeCount     int    `json:"node_count"`
 }
 
-// CreatePolicyBindingRequest represents a request to create a policy binding
+// CreatePolicyBindingRequest represents a request to create a policy binding.
+// Exactly one of GroupID, FilterID and UserGroupID must be set.
 type CreatePolicyBindingRequest struct {
-	PolicyID string `json:"policy_id"`
-	GroupID  string `json:"group_id"`
-	Priority int    `json:"priority"`
+	PolicyID    string `json:"policy_id"`
+	GroupID     string `json:"group_id"`
+	FilterID    string `json:"filter_id"`
+	UserGroupID string `json:"user_group_id"`
+	Priority    int    `json:"priority"`
+	// Window is optional; without it the binding is always in effect.
+	Window *BindingWindow `json:"window,omitempty"`
 }
 
 // UpdatePolicyBindingRequest represents a request to update a policy binding
 type UpdatePolicyBindingRequest struct {
 	State    *string `json:"state,omitempty"`
 	Priority *int    `json:"priority,omitempty"`
+	// Window, when set, replaces the binding's window; an empty window
+	// removes it.
+	Window *BindingWindow `json:"window,omitempty"`
+}
+
+// Profile bundles a set of policies. Binding a profile to a node group
+// creates and enables a policy binding for every member policy.
+type Profile struct {
+	ID          string    `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	PolicyIDs   []string  `json:"policy_ids"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateProfileRequest represents a request to create a profile
+type CreateProfileRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	PolicyIDs   []string `json:"policy_ids"`
+}
+
+// UpdateProfileRequest represents a request to update a profile. A non-nil
+// PolicyIDs replaces the membership and reconciles every bound group.
+type UpdateProfileRequest struct {
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	PolicyIDs   *[]string `json:"policy_ids,omitempty"`
+}
+
+// ProfileBinding binds a profile to a node group.
+type ProfileBinding struct {
+	ID        string    `json:"id" db:"id"`
+	ProfileID string    `json:"profile_id" db:"profile_id"`
+	GroupID   string    `json:"group_id" db:"group_id"`
+	GroupName string    `json:"group_name"`
+	Priority  int       `json:"priority" db:"priority"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateProfileBindingRequest represents a request to bind a profile to a
+// node group. Priority applies to the policy bindings the profile creates.
+type CreateProfileBindingRequest struct {
+	GroupID  string `json:"group_id"`
+	Priority int    `json:"priority"`
 }
 
 // RevokedCertificate tracks revoked agent certificate serials.
@@ -429,6 +1235,13 @@ type NodeHeartbeatInfo struct {
 	DesktopEnvs  []string
 	AgentVersion string
 	MachineID    string
+	// FirefoxVersion, ChromeVersion and KernelVersion are empty when the
+	// agent could not detect them.
+	FirefoxVersion string
+	ChromeVersion  string
+	KernelVersion  string
+	// Hardware is nil when the agent reported no hardware facts.
+	Hardware *NodeHardware
 }
 
 // ─── MFA Models ───────────────────────────────────────────────────────────────
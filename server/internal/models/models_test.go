@@ -7,6 +7,7 @@ package models
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestMeResponse_JSON(t *testing.T) {
@@ -82,3 +83,66 @@ func TestMeResponse_EmptyPermissions(t *testing.T) {
 		t.Errorf("len(permissions) = %d, want 0", len(perms))
 	}
 }
+
+func TestUser_IsLocked(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		lockedUntil *time.Time
+		want        bool
+	}{
+		{"never locked", nil, false},
+		{"lock expired", &past, false},
+		{"locked", &future, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &User{LockedUntil: tt.lockedUntil}
+			if got := u.IsLocked(now); got != tt.want {
+				t.Errorf("IsLocked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeHardware_LowDisk(t *testing.T) {
+	tests := []struct {
+		name string
+		hw   *NodeHardware
+		want bool
+	}{
+		{"nil", nil, false},
+		{"unknown size", &NodeHardware{RootDiskFreeBytes: 0}, false},
+		{"plenty free", &NodeHardware{RootDiskTotalBytes: 100 << 30, RootDiskFreeBytes: 40 << 30}, false},
+		{"at threshold", &NodeHardware{RootDiskTotalBytes: 100 << 30, RootDiskFreeBytes: 10 << 30}, false},
+		{"nearly full", &NodeHardware{RootDiskTotalBytes: 100 << 30, RootDiskFreeBytes: 5 << 30}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.hw.LowDisk(); got != tt.want {
+			t.Errorf("%s: LowDisk() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNewListResponse(t *testing.T) {
+	resp := NewListResponse([]*Policy(nil), 101, Pagination{Page: 3, PerPage: 25})
+	if resp.TotalPages != 5 || resp.Total != 101 || resp.Page != 3 || resp.PerPage != 25 {
+		t.Errorf("resp = %+v, want 5 pages of 101 items", resp)
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("failed to marshal ListResponse: %v", err)
+	}
+	want := `{"items":[],"total":101,"page":3,"per_page":25,"total_pages":5}`
+	if string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+
+	if got := (Pagination{Page: 3, PerPage: 25}).Offset(); got != 50 {
+		t.Errorf("Offset() = %d, want 50", got)
+	}
+}
@@ -54,14 +54,14 @@ import (
 //   - If a key with keyLabel already exists on the token and certPath exists,
 //     both are loaded and returned.
 //   - If the key exists but certPath is missing, a new self-signed CA
-//     certificate is generated (ECDSA P-384, 10 years) and written to certPath.
+//     certificate is generated (ECDSA P-384, 10 years by default) and written to certPath.
 //   - If no key with keyLabel is found on the token, a new ECDSA P-384 key is
 //     generated on the HSM, then a CA certificate is created and written to certPath.
 //
 // The returned crypto.Signer wraps the HSM key; signing operations happen
 // inside the HSM. The PKCS#11 context is intentionally kept open for the
 // process lifetime so the signer remains valid.
-func EnsureCAWithHSM(certPath, lib, tokenLabel, keyLabel, pin string) (*x509.Certificate, crypto.Signer, error) {
+func EnsureCAWithHSM(certPath, lib, tokenLabel, keyLabel, pin string, opts CertOptions) (*x509.Certificate, crypto.Signer, error) {
 	ctx, err := crypto11.Configure(&crypto11.Config{
 		Path:       lib,
 		TokenLabel: tokenLabel,
@@ -92,7 +92,7 @@ func EnsureCAWithHSM(certPath, lib, tokenLabel, keyLabel, pin string) (*x509.Cer
 			return nil, nil, fmt.Errorf("failed to create CA cert directory: %w", err)
 		}
 		log.Printf("pki: generating CA certificate for HSM key %q → %s", keyLabel, certPath)
-		if err := generateCACert(certPath, signer); err != nil {
+		if err := generateCACert(certPath, signer, opts); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -107,7 +107,7 @@ func EnsureCAWithHSM(certPath, lib, tokenLabel, keyLabel, pin string) (*x509.Cer
 
 // generateCACert creates a self-signed CA certificate using the given signer
 // and writes it to certPath (PEM, 0o644).
-func generateCACert(certPath string, signer crypto.Signer) error {
+func generateCACert(certPath string, signer crypto.Signer, opts CertOptions) error {
 	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
 	if err != nil {
 		return fmt.Errorf("failed to generate CA serial number: %w", err)
@@ -120,7 +120,7 @@ func generateCACert(certPath string, signer crypto.Signer) error {
 			CommonName:   "Bor Internal CA",
 		},
 		NotBefore:             time.Now().Add(-1 * time.Minute),
-		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		NotAfter:              time.Now().Add(opts.caValidity()),
 		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
 		BasicConstraintsValid: true,
 		IsCA:                  true,
@@ -19,7 +19,7 @@ import (
 
 // EnsureCAWithHSM is not available in this build.
 // Rebuild with -tags pkcs11 to enable PKCS#11 HSM support.
-func EnsureCAWithHSM(_, _, _, _, _ string) (*x509.Certificate, crypto.Signer, error) {
+func EnsureCAWithHSM(_, _, _, _, _ string, _ CertOptions) (*x509.Certificate, crypto.Signer, error) {
 	return nil, nil, fmt.Errorf(
 		"PKCS#11 HSM support is not compiled in: " +
 			"rebuild the server with '-tags pkcs11' and add the dependency: " +
@@ -6,6 +6,7 @@
 package pki
 
 import (
+	"cmp"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
@@ -23,12 +24,83 @@ import (
 	"time"
 )
 
+// Default certificate lifetimes.
+const (
+	DefaultCAValidity         = 10 * 365 * 24 * time.Hour
+	DefaultServerCertValidity = 365 * 24 * time.Hour
+	DefaultAgentCertValidity  = 90 * 24 * time.Hour
+)
+
+// CertOptions controls the certificates and keys the internal CA generates
+// and issues. Zero fields take the defaults, so CertOptions{} yields a
+// 10-year ECDSA P-384 CA, 1-year ECDSA P-256 UI certificates and 90-day
+// agent certificates. Leaf lifetimes are validated against the CA by the
+// server configuration; issued certificates never outlive their CA.
+type CertOptions struct {
+	CAValidity         time.Duration
+	ServerCertValidity time.Duration
+	AgentCertValidity  time.Duration
+	KeyAlgorithm       KeyAlgorithm
+}
+
+func (o CertOptions) caValidity() time.Duration {
+	return cmp.Or(o.CAValidity, DefaultCAValidity)
+}
+
+func (o CertOptions) serverCertValidity() time.Duration {
+	return cmp.Or(o.ServerCertValidity, DefaultServerCertValidity)
+}
+
+func (o CertOptions) agentCertValidity() time.Duration {
+	return cmp.Or(o.AgentCertValidity, DefaultAgentCertValidity)
+}
+
+// KeyAlgorithm selects the key type of auto-generated CA and UI server keys.
+type KeyAlgorithm string
+
+// Supported key algorithms. KeyAlgorithmDefault generates an ECDSA P-384 CA
+// key and ECDSA P-256 server keys; every other value is used for both.
+const (
+	KeyAlgorithmDefault   KeyAlgorithm = ""
+	KeyAlgorithmRSA2048   KeyAlgorithm = "rsa2048"
+	KeyAlgorithmRSA4096   KeyAlgorithm = "rsa4096"
+	KeyAlgorithmECDSAP256 KeyAlgorithm = "ecdsa-p256"
+	KeyAlgorithmECDSAP384 KeyAlgorithm = "ecdsa-p384"
+)
+
+// ParseKeyAlgorithm validates a configured key algorithm name.
+func ParseKeyAlgorithm(s string) (KeyAlgorithm, error) {
+	switch a := KeyAlgorithm(s); a {
+	case KeyAlgorithmDefault, KeyAlgorithmRSA2048, KeyAlgorithmRSA4096, KeyAlgorithmECDSAP256, KeyAlgorithmECDSAP384:
+		return a, nil
+	default:
+		return "", fmt.Errorf("unsupported key algorithm %q (want rsa2048, rsa4096, ecdsa-p256 or ecdsa-p384)", s)
+	}
+}
+
+// generateKey creates a key of algorithm alg, or of the given ECDSA curve
+// for KeyAlgorithmDefault.
+func generateKey(alg KeyAlgorithm, defaultCurve elliptic.Curve) (crypto.Signer, error) {
+	switch alg {
+	case KeyAlgorithmRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case KeyAlgorithmRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case KeyAlgorithmECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyAlgorithmECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	default:
+		return ecdsa.GenerateKey(defaultCurve, rand.Reader)
+	}
+}
+
 // EnsureServerCert checks for an existing cert/key pair at dir/ui.crt
 // and dir/ui.key. If they exist AND are signed by the provided CA,
 // they are reused. If they do not exist, or were signed by a different
 // CA (e.g. self-signed from a previous run), a new TLS server
-// certificate is generated (ECDSA P-256, 365 days) and signed by the
-// given CA.
+// certificate is generated (ECDSA P-256, 365 days by default — see
+// CertOptions) and signed by the given CA.
 //
 // ECDSA P-256 satisfies FIPS 140-3, BSI TR-02102-1 (2024), ANSSI RGS,
 // ENISA 2023, and ETSI TS 119 312 recommendations.
@@ -40,7 +112,7 @@ import (
 // When caCert/caKey are nil the certificate is self-signed (fallback
 // for when no CA is available).
 // Returns the paths to the cert and key files.
-func EnsureServerCert(dir string, caCert *x509.Certificate, caKey crypto.Signer, extraHostnames []string) (certPath, keyPath string, err error) {
+func EnsureServerCert(dir string, caCert *x509.Certificate, caKey crypto.Signer, extraHostnames []string, opts CertOptions) (certPath, keyPath string, err error) {
 	certPath = filepath.Join(dir, "ui.crt")
 	keyPath = filepath.Join(dir, "ui.key")
 
@@ -62,10 +134,10 @@ func EnsureServerCert(dir string, caCert *x509.Certificate, caKey crypto.Signer,
 		return "", "", fmt.Errorf("failed to create TLS autogen dir %s: %w", dir, err)
 	}
 
-	// ECDSA P-256: 128-bit security, FIPS 140-3 + BSI TR-02102-1 + ANSSI + ETSI approved.
-	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	// ECDSA P-256 by default: 128-bit security, FIPS 140-3 + BSI TR-02102-1 + ANSSI + ETSI approved.
+	key, err := generateKey(opts.KeyAlgorithm, elliptic.P256())
 	if err != nil {
-		return "", "", fmt.Errorf("failed to generate ECDSA P-256 key: %w", err)
+		return "", "", fmt.Errorf("failed to generate server key: %w", err)
 	}
 
 	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
@@ -97,7 +169,7 @@ func EnsureServerCert(dir string, caCert *x509.Certificate, caKey crypto.Signer,
 			CommonName:   "Bor UI",
 		},
 		NotBefore: time.Now().Add(-1 * time.Minute),
-		NotAfter:  time.Now().Add(365 * 24 * time.Hour),
+		NotAfter:  time.Now().Add(opts.serverCertValidity()),
 		// KeyUsageDigitalSignature only — KeyUsageKeyEncipherment is RSA-specific
 		// and must not be set for ECDSA certs (ETSI EN 319 412, RFC 5480).
 		KeyUsage:              x509.KeyUsageDigitalSignature,
@@ -135,14 +207,15 @@ func EnsureServerCert(dir string, caCert *x509.Certificate, caKey crypto.Signer,
 }
 
 // EnsureCA checks for an existing CA cert/key at dir/ca.crt and dir/ca.key.
-// If they do not exist it generates a new CA (ECDSA P-384, 10 years).
+// If they do not exist it generates a new CA (ECDSA P-384, 10 years by
+// default — see CertOptions).
 //
 // ECDSA P-384 provides 192-bit security — appropriate for a CA with a
 // 10-year lifetime. Satisfies FIPS 140-3, BSI TR-02102-1, ANSSI RGS,
 // and ETSI TS 119 312.
 //
 // Returns the paths to the CA cert and key files.
-func EnsureCA(dir string) (certPath, keyPath string, err error) {
+func EnsureCA(dir string, opts CertOptions) (certPath, keyPath string, err error) {
 	certPath = filepath.Join(dir, "ca.crt")
 	keyPath = filepath.Join(dir, "ca.key")
 
@@ -154,10 +227,10 @@ func EnsureCA(dir string) (certPath, keyPath string, err error) {
 		return "", "", fmt.Errorf("failed to create CA autogen dir %s: %w", dir, err)
 	}
 
-	// ECDSA P-384: 192-bit security, appropriate for a long-lived CA key.
-	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	// ECDSA P-384 by default: 192-bit security, appropriate for a long-lived CA key.
+	key, err := generateKey(opts.KeyAlgorithm, elliptic.P384())
 	if err != nil {
-		return "", "", fmt.Errorf("failed to generate ECDSA P-384 CA key: %w", err)
+		return "", "", fmt.Errorf("failed to generate CA key: %w", err)
 	}
 
 	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
@@ -172,7 +245,7 @@ func EnsureCA(dir string) (certPath, keyPath string, err error) {
 			CommonName:   "Bor Internal CA",
 		},
 		NotBefore:             time.Now().Add(-1 * time.Minute),
-		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		NotAfter:              time.Now().Add(opts.caValidity()),
 		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
 		BasicConstraintsValid: true,
 		IsCA:                  true,
@@ -201,8 +274,8 @@ func EnsureCA(dir string) (certPath, keyPath string, err error) {
 
 // LoadCA loads a CA certificate and private key from PEM files and returns
 // the parsed certificate and a crypto.Signer for the private key.
-// Accepts PKCS#8 ECDSA and RSA keys (the latter for operational flexibility
-// when an externally-provided RSA CA is in use).
+// Accepts ECDSA and RSA keys in PKCS#8, SEC 1 ("EC PRIVATE KEY") or PKCS#1
+// ("RSA PRIVATE KEY") form, so externally-provided CAs work unconverted.
 func LoadCA(certPath, keyPath string) (*x509.Certificate, crypto.Signer, error) {
 	certPEM, err := os.ReadFile(certPath) //nolint:gosec // cert path is admin-configured
 	if err != nil {
@@ -235,9 +308,9 @@ func LoadCA(certPath, keyPath string) (*x509.Certificate, crypto.Signer, error)
 
 // SignCSR signs a PEM-encoded CSR with the given CA and returns the signed
 // certificate PEM, the certificate serial number as a hex string, and the
-// NotAfter time. The issued certificate is valid for 90 days with
-// client-auth extended key usage.
-func SignCSR(csrPEM []byte, caCert *x509.Certificate, caKey crypto.Signer) (certPEM []byte, serial string, notAfter time.Time, err error) {
+// NotAfter time. The issued certificate is valid for 90 days by default
+// (see CertOptions) with client-auth extended key usage.
+func SignCSR(csrPEM []byte, caCert *x509.Certificate, caKey crypto.Signer, opts CertOptions) (certPEM []byte, serial string, notAfter time.Time, err error) {
 	block, _ := pem.Decode(csrPEM)
 	if block == nil || block.Type != "CERTIFICATE REQUEST" {
 		return nil, "", time.Time{}, fmt.Errorf("failed to decode CSR PEM")
@@ -260,13 +333,17 @@ func SignCSR(csrPEM []byte, caCert *x509.Certificate, caKey crypto.Signer) (cert
 		SerialNumber: serialNumber,
 		Subject:      csr.Subject,
 		NotBefore:    time.Now().Add(-1 * time.Minute),
-		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		NotAfter:     time.Now().Add(opts.agentCertValidity()),
 		// KeyUsageDigitalSignature only — KeyUsageKeyEncipherment is RSA-specific
 		// and must not appear in ECDSA client certs (RFC 5480, ETSI EN 319 412).
 		KeyUsage:              x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 		BasicConstraintsValid: true,
 	}
+	// A leaf certificate must not outlive its issuer.
+	if tmpl.NotAfter.After(caCert.NotAfter) {
+		tmpl.NotAfter = caCert.NotAfter
+	}
 
 	certDER, certErr := x509.CreateCertificate(rand.Reader, tmpl, caCert, csr.PublicKey, caKey)
 	if certErr != nil {
@@ -282,6 +359,35 @@ func SignCSR(csrPEM []byte, caCert *x509.Certificate, caKey crypto.Signer) (cert
 	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), serialHex, tmpl.NotAfter, nil
 }
 
+// CRLValidity is how long a CRL from GenerateCRL is valid before relying
+// parties should fetch a new one.
+const CRLValidity = 24 * time.Hour
+
+// GenerateCRL returns a PEM-encoded certificate revocation list signed by the
+// CA that lists the given serial numbers. The CRL number is the issue time
+// in Unix seconds, so later lists always carry a higher number.
+func GenerateCRL(caCert *x509.Certificate, caKey crypto.Signer, revoked []*big.Int) ([]byte, error) {
+	now := time.Now()
+	entries := make([]x509.RevocationListEntry, 0, len(revoked))
+	for _, serial := range revoked {
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: now,
+		})
+	}
+	tmpl := &x509.RevocationList{
+		Number:                    big.NewInt(now.Unix()),
+		ThisUpdate:                now,
+		NextUpdate:                now.Add(CRLValidity),
+		RevokedCertificateEntries: entries,
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, tmpl, caCert, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CRL: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}), nil
+}
+
 // LoadCACertPool loads a CA certificate from the given path and returns
 // a CertPool containing it.
 func LoadCACertPool(certPath string) (*x509.CertPool, error) {
@@ -316,15 +422,20 @@ func writePEM(path, pemType string, data []byte) error {
 	return pem.Encode(f, &pem.Block{Type: pemType, Bytes: data})
 }
 
-// parsePrivateKey parses a PKCS#8 private key from a PEM block.
-// Supports ECDSA (generated by Bor) and RSA (for externally-provided CA keys).
+// parsePrivateKey parses an ECDSA or RSA private key from a PEM block. The
+// encoding is detected from the content rather than the block type: PKCS#8
+// is tried first, then SEC 1 and PKCS#1.
 func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
-	if block.Type != "PRIVATE KEY" {
-		return nil, fmt.Errorf("expected PKCS#8 PEM block type \"PRIVATE KEY\", got %q", block.Type)
-	}
 	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
 	if err != nil {
-		return nil, err
+		if ecKey, ecErr := x509.ParseECPrivateKey(block.Bytes); ecErr == nil {
+			key, err = ecKey, nil
+		} else if rsaKey, rsaErr := x509.ParsePKCS1PrivateKey(block.Bytes); rsaErr == nil {
+			key, err = rsaKey, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unrecognised %q key (tried PKCS#8, SEC 1 and PKCS#1): %w", block.Type, err)
 	}
 	switch k := key.(type) {
 	case *ecdsa.PrivateKey:
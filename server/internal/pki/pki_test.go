@@ -9,20 +9,23 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestEnsureServerCert_SelfSigned(t *testing.T) {
 	dir := t.TempDir()
 
 	// Without CA: self-signed fallback
-	certPath, keyPath, err := EnsureServerCert(dir, nil, nil, nil)
+	certPath, keyPath, err := EnsureServerCert(dir, nil, nil, nil, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureServerCert() error = %v", err)
 	}
@@ -73,7 +76,7 @@ func TestEnsureServerCert_SelfSigned(t *testing.T) {
 	}
 
 	// Calling again should reuse existing cert (idempotent)
-	certPath2, keyPath2, err := EnsureServerCert(dir, nil, nil, nil)
+	certPath2, keyPath2, err := EnsureServerCert(dir, nil, nil, nil, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureServerCert() second call error = %v", err)
 	}
@@ -84,7 +87,7 @@ func TestEnsureServerCert_SelfSigned(t *testing.T) {
 
 func TestEnsureServerCert_SignedByCA(t *testing.T) {
 	caDir := t.TempDir()
-	caCertPath, caKeyPath, err := EnsureCA(caDir)
+	caCertPath, caKeyPath, err := EnsureCA(caDir, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureCA() error = %v", err)
 	}
@@ -94,7 +97,7 @@ func TestEnsureServerCert_SignedByCA(t *testing.T) {
 	}
 
 	dir := t.TempDir()
-	certPath, keyPath, err := EnsureServerCert(dir, caCert, caKey, nil)
+	certPath, keyPath, err := EnsureServerCert(dir, caCert, caKey, nil, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureServerCert() error = %v", err)
 	}
@@ -138,7 +141,7 @@ func TestEnsureServerCert_RegeneratesWhenCAChanges(t *testing.T) {
 	dir := t.TempDir()
 
 	// Step 1: Create a self-signed server cert (no CA)
-	certPath, keyPath, err := EnsureServerCert(dir, nil, nil, nil)
+	certPath, keyPath, err := EnsureServerCert(dir, nil, nil, nil, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureServerCert(nil CA) error = %v", err)
 	}
@@ -149,7 +152,7 @@ func TestEnsureServerCert_RegeneratesWhenCAChanges(t *testing.T) {
 
 	// Step 2: Create a CA
 	caDir := t.TempDir()
-	caCertPath, caKeyPath, err := EnsureCA(caDir)
+	caCertPath, caKeyPath, err := EnsureCA(caDir, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureCA() error = %v", err)
 	}
@@ -160,7 +163,7 @@ func TestEnsureServerCert_RegeneratesWhenCAChanges(t *testing.T) {
 
 	// Step 3: Call EnsureServerCert WITH the CA — should detect mismatch
 	// and regenerate the cert.
-	certPath2, keyPath2, err := EnsureServerCert(dir, caCert, caKey, nil)
+	certPath2, keyPath2, err := EnsureServerCert(dir, caCert, caKey, nil, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureServerCert(with CA) error = %v", err)
 	}
@@ -199,7 +202,7 @@ func TestEnsureServerCert_RegeneratesWhenCAChanges(t *testing.T) {
 func TestEnsureCA(t *testing.T) {
 	dir := t.TempDir()
 
-	certPath, keyPath, err := EnsureCA(dir)
+	certPath, keyPath, err := EnsureCA(dir, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureCA() error = %v", err)
 	}
@@ -221,7 +224,7 @@ func TestEnsureCA(t *testing.T) {
 	_ = caKey
 
 	// Idempotent
-	certPath2, keyPath2, err := EnsureCA(dir)
+	certPath2, keyPath2, err := EnsureCA(dir, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureCA() second call error = %v", err)
 	}
@@ -230,10 +233,87 @@ func TestEnsureCA(t *testing.T) {
 	}
 }
 
+func TestEnsureCA_KeyAlgorithm(t *testing.T) {
+	tests := []struct {
+		alg  KeyAlgorithm
+		want x509.PublicKeyAlgorithm
+	}{
+		{KeyAlgorithmDefault, x509.ECDSA},
+		{KeyAlgorithmRSA2048, x509.RSA},
+		{KeyAlgorithmECDSAP256, x509.ECDSA},
+	}
+	for _, tt := range tests {
+		opts := CertOptions{KeyAlgorithm: tt.alg}
+		dir := t.TempDir()
+		certPath, keyPath, err := EnsureCA(dir, opts)
+		if err != nil {
+			t.Fatalf("%q: EnsureCA() error = %v", tt.alg, err)
+		}
+		caCert, caKey, err := LoadCA(certPath, keyPath)
+		if err != nil {
+			t.Fatalf("%q: LoadCA() error = %v", tt.alg, err)
+		}
+		if caCert.PublicKeyAlgorithm != tt.want {
+			t.Errorf("%q: CA key algorithm = %v, want %v", tt.alg, caCert.PublicKeyAlgorithm, tt.want)
+		}
+		// The UI certificate is signed by, and uses the same algorithm as, the CA.
+		uiCert, _, err := EnsureServerCert(dir, caCert, caKey, nil, opts)
+		if err != nil {
+			t.Fatalf("%q: EnsureServerCert() error = %v", tt.alg, err)
+		}
+		if !isSignedByCA(uiCert, caCert) {
+			t.Errorf("%q: UI certificate not signed by the CA", tt.alg)
+		}
+	}
+
+	if _, err := ParseKeyAlgorithm("dsa1024"); err == nil {
+		t.Error("ParseKeyAlgorithm accepted an unsupported algorithm")
+	}
+}
+
+func TestLoadCA_KeyEncodings(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath, err := EnsureCA(dir, CertOptions{})
+	if err != nil {
+		t.Fatalf("EnsureCA() error = %v", err)
+	}
+	_, caKey, err := LoadCA(certPath, keyPath)
+	if err != nil {
+		t.Fatalf("LoadCA() error = %v", err)
+	}
+	ecKey := caKey.(*ecdsa.PrivateKey)
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		pemType string
+		der     []byte
+	}{
+		{"SEC 1", "EC PRIVATE KEY", sec1},
+		{"PKCS#1", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, "alt.key")
+		if err := writePEM(path, tt.pemType, tt.der); err != nil {
+			t.Fatal(err)
+		}
+		if _, key, err := LoadCA(certPath, path); err != nil || key == nil {
+			t.Errorf("%s: LoadCA() = %v, %v", tt.name, key, err)
+		}
+	}
+}
+
 func TestSignCSR(t *testing.T) {
 	dir := t.TempDir()
 
-	caCertPath, caKeyPath, err := EnsureCA(dir)
+	caCertPath, caKeyPath, err := EnsureCA(dir, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureCA() error = %v", err)
 	}
@@ -261,7 +341,7 @@ func TestSignCSR(t *testing.T) {
 	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
 
 	// Sign the CSR
-	certPEM, _, _, err := SignCSR(csrPEM, caCert, caKey)
+	certPEM, _, _, err := SignCSR(csrPEM, caCert, caKey, CertOptions{})
 	if err != nil {
 		t.Fatalf("SignCSR() error = %v", err)
 	}
@@ -303,12 +383,85 @@ func TestSignCSR(t *testing.T) {
 	}
 }
 
+func TestSignCSR_AgentCertValidity(t *testing.T) {
+	opts := CertOptions{AgentCertValidity: 7 * 24 * time.Hour}
+
+	dir := t.TempDir()
+	caCertPath, caKeyPath, err := EnsureCA(dir, CertOptions{})
+	if err != nil {
+		t.Fatalf("EnsureCA() error = %v", err)
+	}
+	caCert, caKey, err := LoadCA(caCertPath, caKeyPath)
+	if err != nil {
+		t.Fatalf("LoadCA() error = %v", err)
+	}
+
+	agentKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate agent key: %v", err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader,
+		&x509.CertificateRequest{Subject: pkix.Name{CommonName: "short-lived"}}, agentKey)
+	if err != nil {
+		t.Fatalf("Failed to create CSR: %v", err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	_, _, notAfter, err := SignCSR(csrPEM, caCert, caKey, opts)
+	if err != nil {
+		t.Fatalf("SignCSR() error = %v", err)
+	}
+	if d := time.Until(notAfter); d > 7*24*time.Hour || d < 7*24*time.Hour-time.Hour {
+		t.Errorf("agent cert lifetime = %v, want ~7 days", d)
+	}
+}
+
+func TestGenerateCRL(t *testing.T) {
+	dir := t.TempDir()
+	caCertPath, caKeyPath, err := EnsureCA(dir, CertOptions{})
+	if err != nil {
+		t.Fatalf("EnsureCA() error = %v", err)
+	}
+	caCert, caKey, err := LoadCA(caCertPath, caKeyPath)
+	if err != nil {
+		t.Fatalf("LoadCA() error = %v", err)
+	}
+
+	revoked := []*big.Int{big.NewInt(0xbeef), big.NewInt(42)}
+	crlPEM, err := GenerateCRL(caCert, caKey, revoked)
+	if err != nil {
+		t.Fatalf("GenerateCRL() error = %v", err)
+	}
+	block, _ := pem.Decode(crlPEM)
+	if block == nil || block.Type != "X509 CRL" {
+		t.Fatalf("GenerateCRL() returned no X509 CRL PEM block")
+	}
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParseRevocationList() error = %v", err)
+	}
+	if err := crl.CheckSignatureFrom(caCert); err != nil {
+		t.Errorf("CRL signature does not verify against the CA: %v", err)
+	}
+	if len(crl.RevokedCertificateEntries) != len(revoked) {
+		t.Fatalf("CRL lists %d serials, want %d", len(crl.RevokedCertificateEntries), len(revoked))
+	}
+	for i, e := range crl.RevokedCertificateEntries {
+		if e.SerialNumber.Cmp(revoked[i]) != 0 {
+			t.Errorf("entry %d serial = %s, want %s", i, e.SerialNumber, revoked[i])
+		}
+	}
+	if !crl.NextUpdate.After(crl.ThisUpdate) {
+		t.Errorf("NextUpdate %v is not after ThisUpdate %v", crl.NextUpdate, crl.ThisUpdate)
+	}
+}
+
 func TestSignCSR_InvalidPEM(t *testing.T) {
 	dir := t.TempDir()
-	caCertPath, caKeyPath, _ := EnsureCA(dir)
+	caCertPath, caKeyPath, _ := EnsureCA(dir, CertOptions{})
 	caCert, caKey, _ := LoadCA(caCertPath, caKeyPath)
 
-	_, _, _, err := SignCSR([]byte("not a PEM"), caCert, caKey)
+	_, _, _, err := SignCSR([]byte("not a PEM"), caCert, caKey, CertOptions{})
 	if err == nil {
 		t.Error("SignCSR() should return error for invalid PEM")
 	}
@@ -316,7 +469,7 @@ func TestSignCSR_InvalidPEM(t *testing.T) {
 
 func TestLoadCACertPool(t *testing.T) {
 	dir := t.TempDir()
-	certPath, _, err := EnsureCA(dir)
+	certPath, _, err := EnsureCA(dir, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureCA() error = %v", err)
 	}
@@ -340,7 +493,7 @@ func TestLoadCACertPool_MissingFile(t *testing.T) {
 func TestEnsureServerCert_FilePermissions(t *testing.T) {
 	dir := t.TempDir()
 
-	_, keyPath, err := EnsureServerCert(dir, nil, nil, nil)
+	_, keyPath, err := EnsureServerCert(dir, nil, nil, nil, CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureServerCert() error = %v", err)
 	}
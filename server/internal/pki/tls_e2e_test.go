@@ -28,7 +28,7 @@ func TestFullTLSChain(t *testing.T) {
 	agentDir := t.TempDir()
 
 	// Step 1: Generate CA
-	caCertPath, caKeyPath, err := pki.EnsureCA(caDir)
+	caCertPath, caKeyPath, err := pki.EnsureCA(caDir, pki.CertOptions{})
 	if err != nil {
 		t.Fatal("EnsureCA:", err)
 	}
@@ -42,7 +42,7 @@ func TestFullTLSChain(t *testing.T) {
 	}
 
 	// Step 2: Generate server cert signed by CA
-	certPath, keyPath, err := pki.EnsureServerCert(uiDir, caCert, caKey, nil)
+	certPath, keyPath, err := pki.EnsureServerCert(uiDir, caCert, caKey, nil, pki.CertOptions{})
 	if err != nil {
 		t.Fatal("EnsureServerCert:", err)
 	}
@@ -60,7 +60,7 @@ func TestFullTLSChain(t *testing.T) {
 		t.Fatal("CreateCertificateRequest:", err)
 	}
 	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
-	agentCertPEM, _, _, err := pki.SignCSR(csrPEM, caCert, caKey)
+	agentCertPEM, _, _, err := pki.SignCSR(csrPEM, caCert, caKey, pki.CertOptions{})
 	if err != nil {
 		t.Fatal("SignCSR:", err)
 	}
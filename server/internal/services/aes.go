@@ -21,10 +21,18 @@ import (
 // Changing this value would invalidate all existing encrypted data.
 var hkdfSalt = []byte("bor-mfa-aes-key-v1") //nolint:gochecknoglobals // fixed cryptographic parameter
 
-// deriveAESKey derives a 256-bit AES key from an arbitrary-length passphrase
-// using HKDF-SHA256 (FIPS 140-3 / BSI TR-02102 compliant).
-func deriveAESKey(passphrase string) []byte {
-	r := hkdf.New(sha256.New, []byte(passphrase), hkdfSalt, []byte("mfa-secret-encryption"))
+// HKDF info labels keep the keys for different kinds of secrets apart even
+// when they are derived from the same passphrase.
+const (
+	mfaKeyInfo     = "mfa-secret-encryption"
+	webhookKeyInfo = "webhook-secret-encryption"
+)
+
+// deriveAESKey derives a 256-bit AES key for the given purpose (an HKDF info
+// label) from an arbitrary-length passphrase using HKDF-SHA256
+// (FIPS 140-3 / BSI TR-02102 compliant).
+func deriveAESKey(passphrase, info string) []byte {
+	r := hkdf.New(sha256.New, []byte(passphrase), hkdfSalt, []byte(info))
 	key := make([]byte, 32)
 	if _, err := io.ReadFull(r, key); err != nil {
 		panic("hkdf: " + err.Error()) // only fails if output exceeds 255*HashLen
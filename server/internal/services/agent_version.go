@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// ParseAgentVersion parses a dotted numeric agent version such as "1.4.2"
+// or "v1.4". A leading "v" is ignored, as is anything after the first "-"
+// or "+" (pre-release and build suffixes, e.g. "1.4.2-3-gabcdef").
+func ParseAgentVersion(v string) ([]int, error) {
+	core := strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	if core == "" {
+		return nil, fmt.Errorf("invalid agent version %q", v)
+	}
+	parts := strings.Split(core, ".")
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid agent version %q", v)
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
+
+// compareAgentVersions compares two parsed versions component by component;
+// missing trailing components count as zero, so "1.4" equals "1.4.0".
+func compareAgentVersions(a, b []int) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// SetMinAgentVersion makes CheckAgentVersion refuse agents older than
+// minimum. An empty minimum accepts every agent.
+func (s *NodeService) SetMinAgentVersion(minimum string) error {
+	if minimum == "" {
+		s.minAgentVersion, s.minAgentVersionStr = nil, ""
+		return nil
+	}
+	v, err := ParseAgentVersion(minimum)
+	if err != nil {
+		return err
+	}
+	s.minAgentVersion, s.minAgentVersionStr = v, minimum
+	return nil
+}
+
+// AgentVersionOutdated reports whether an agent reporting version is older
+// than the configured minimum. Versions that cannot be parsed, such as
+// "dev" builds or nodes that have not reported one yet, are not outdated.
+func (s *NodeService) AgentVersionOutdated(version string) bool {
+	if s.minAgentVersion == nil {
+		return false
+	}
+	v, err := ParseAgentVersion(version)
+	if err != nil {
+		return false
+	}
+	return compareAgentVersions(v, s.minAgentVersion) < 0
+}
+
+// CheckAgentVersion returns an error telling the agent to upgrade when its
+// version is older than the configured minimum.
+func (s *NodeService) CheckAgentVersion(version string) error {
+	if s.AgentVersionOutdated(version) {
+		return fmt.Errorf("agent version %s is no longer supported: upgrade the Bor agent to %s or later", version, s.minAgentVersionStr)
+	}
+	return nil
+}
+
+// markOutdatedAgents sets AgentOutdated on nodes running an agent older
+// than the configured minimum.
+func (s *NodeService) markOutdatedAgents(nodes ...*models.Node) {
+	for _, n := range nodes {
+		if n != nil && n.AgentVersion != nil {
+			n.AgentOutdated = s.AgentVersionOutdated(*n.AgentVersion)
+		}
+	}
+}
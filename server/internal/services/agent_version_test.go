@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func TestParseAgentVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{"1.4.2", []int{1, 4, 2}, false},
+		{"v1.4", []int{1, 4}, false},
+		{"1.4.2-3-gabcdef", []int{1, 4, 2}, false},
+		{"2.0.0+build7", []int{2, 0, 0}, false},
+		{"dev", nil, true},
+		{"", nil, true},
+		{"1..2", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAgentVersion(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAgentVersion(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if compareAgentVersions(got, tt.want) != 0 || len(got) != len(tt.want) {
+			t.Errorf("ParseAgentVersion(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNodeService_CheckAgentVersion(t *testing.T) {
+	svc := &NodeService{}
+	if err := svc.CheckAgentVersion("0.0.1"); err != nil {
+		t.Fatalf("no minimum: CheckAgentVersion() = %v, want nil", err)
+	}
+	if err := svc.SetMinAgentVersion("not-a-version"); err == nil {
+		t.Fatal("SetMinAgentVersion() should reject an unparseable minimum")
+	}
+	if err := svc.SetMinAgentVersion("1.4"); err != nil {
+		t.Fatalf("SetMinAgentVersion() error = %v", err)
+	}
+
+	for version, outdated := range map[string]bool{
+		"1.3.9":       true,
+		"v1.3.12-rc1": true,
+		"1.4":         false,
+		"1.4.0":       false,
+		"1.10.0":      false,
+		"2.0":         false,
+		"dev":         false,
+		"":            false,
+	} {
+		err := svc.CheckAgentVersion(version)
+		if (err != nil) != outdated {
+			t.Errorf("CheckAgentVersion(%q) = %v, want outdated=%v", version, err, outdated)
+		}
+		if err != nil && !strings.Contains(err.Error(), "upgrade") {
+			t.Errorf("CheckAgentVersion(%q) error %q should tell the agent to upgrade", version, err)
+		}
+	}
+
+	old, current := "1.2.0", "1.5.0"
+	nodes := []*models.Node{{AgentVersion: &old}, {AgentVersion: &current}, {}}
+	svc.markOutdatedAgents(nodes...)
+	if !nodes[0].AgentOutdated || nodes[1].AgentOutdated || nodes[2].AgentOutdated {
+		t.Errorf("AgentOutdated = %v/%v/%v, want true/false/false",
+			nodes[0].AgentOutdated, nodes[1].AgentOutdated, nodes[2].AgentOutdated)
+	}
+}
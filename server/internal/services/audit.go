@@ -11,11 +11,13 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"time"
 
 	auditsink "github.com/VuteTech/Bor/server/internal/audit"
 	"github.com/VuteTech/Bor/server/internal/database"
 	"github.com/VuteTech/Bor/server/internal/models"
 	auditpb "github.com/VuteTech/Bor/server/pkg/grpc/audit"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // AuditService provides audit logging functionality
@@ -26,6 +28,16 @@ type AuditService struct {
 
 const exportBatchSize = 100
 
+// purgeBatchSize is the number of audit log entries Purge deletes per
+// statement.
+const purgeBatchSize = 1000
+
+// auditLogDeleter is the subset of database.AuditLogRepository used to
+// purge old entries.
+type auditLogDeleter interface {
+	DeleteOlderThan(ctx context.Context, cutoff time.Time, limit int) (int64, error)
+}
+
 // NewAuditService creates a new AuditService
 func NewAuditService(repo *database.AuditLogRepository) *AuditService {
 	return &AuditService{repo: repo}
@@ -36,8 +48,12 @@ func (s *AuditService) AddSink(sink auditsink.Sink) {
 	s.sinks = append(s.sinks, sink)
 }
 
-// Emit fans an AuditEvent out to all registered sinks.
+// Emit fans an AuditEvent out to all registered sinks. Events without a
+// correlation ID inherit the one of the request in ctx.
 func (s *AuditService) Emit(ctx context.Context, event *auditpb.AuditEvent) {
+	if event.GetCorrelationId() == "" {
+		event.CorrelationId = auditsink.CorrelationID(ctx)
+	}
 	for _, sink := range s.sinks {
 		sink.Emit(ctx, event)
 	}
@@ -45,11 +61,89 @@ func (s *AuditService) Emit(ctx context.Context, event *auditpb.AuditEvent) {
 
 // LogEvent records an audit log entry (legacy path — use Emit for new code).
 func (s *AuditService) LogEvent(ctx context.Context, entry *models.AuditLog) {
+	if entry.CorrelationID == "" {
+		entry.CorrelationID = auditsink.CorrelationID(ctx)
+	}
 	if err := s.repo.Create(ctx, entry); err != nil {
 		log.Printf("Failed to write audit log: %v", err)
 	}
 }
 
+// Purge deletes the audit log entries created before the given time in
+// batches and records a single audit entry with the number deleted. actor
+// requested the purge; nil means the retention job, which only records
+// purges that deleted something.
+func (s *AuditService) Purge(ctx context.Context, before time.Time, actor *auditpb.Actor, srcIP string) (int64, error) {
+	n, err := purgeAuditLogs(ctx, s.repo, before, purgeBatchSize)
+	if n == 0 && actor == nil {
+		return n, err
+	}
+	if actor == nil {
+		actor = &auditpb.Actor{Username: "system"}
+	}
+	outcome := auditpb.Outcome_OUTCOME_SUCCESS
+	if err != nil {
+		outcome = auditpb.Outcome_OUTCOME_FAILURE
+	}
+	s.Emit(ctx, &auditpb.AuditEvent{
+		OccurredAt: timestamppb.Now(),
+		Actor:      actor,
+		Action:     "purge",
+		Resource:   &auditpb.Resource{Type: "audit-logs"},
+		Outcome:    outcome,
+		SrcIp:      srcIP,
+		Payload: &auditpb.AuditEvent_Purge{
+			Purge: &auditpb.PurgePayload{Before: timestamppb.New(before), Deleted: n},
+		},
+	})
+	return n, err
+}
+
+// purgeAuditLogs deletes the entries created before the given time, batch
+// at a time, and returns how many were deleted.
+func purgeAuditLogs(ctx context.Context, store auditLogDeleter, before time.Time, batch int) (int64, error) {
+	var total int64
+	for {
+		n, err := store.DeleteOlderThan(ctx, before, batch)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < int64(batch) {
+			return total, nil
+		}
+	}
+}
+
+// StartRetention deletes audit log entries older than retention now and
+// then every interval. The returned function stops it.
+func (s *AuditService) StartRetention(retention, interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			n, err := s.Purge(ctx, time.Now().Add(-retention), nil, "")
+			if err != nil && ctx.Err() == nil {
+				log.Printf("Audit log retention purge failed after %d records: %v", n, err)
+			} else if n > 0 {
+				log.Printf("Audit log retention: purged %d records older than %s", n, retention)
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
 // List retrieves audit logs with pagination
 func (s *AuditService) List(ctx context.Context, req *models.AuditLogListRequest) (*models.AuditLogListResponse, error) {
 	if req.Page < 1 {
@@ -72,22 +166,7 @@ func (s *AuditService) List(ctx context.Context, req *models.AuditLogListRequest
 		return nil, fmt.Errorf("failed to count audit logs: %w", err)
 	}
 
-	if items == nil {
-		items = []*models.AuditLog{}
-	}
-
-	totalPages := total / req.PerPage
-	if total%req.PerPage > 0 {
-		totalPages++
-	}
-
-	return &models.AuditLogListResponse{
-		Items:      items,
-		Total:      total,
-		Page:       req.Page,
-		PerPage:    req.PerPage,
-		TotalPages: totalPages,
-	}, nil
+	return models.NewListResponse(items, total, models.Pagination{Page: req.Page, PerPage: req.PerPage}), nil
 }
 
 // ExportCSV writes audit logs as CSV to the given writer
@@ -100,7 +179,7 @@ func (s *AuditService) ExportCSV(ctx context.Context, req *models.AuditLogListRe
 	defer csvWriter.Flush()
 
 	// Write header
-	if err := csvWriter.Write([]string{"ID", "Timestamp", "Username", "Action", "Resource Type", "Resource ID", "Details", "IP Address"}); err != nil {
+	if err := csvWriter.Write([]string{"ID", "Timestamp", "Username", "Action", "Resource Type", "Resource ID", "Details", "IP Address", "Correlation ID"}); err != nil {
 		return fmt.Errorf("failed to write CSV header: %w", err)
 	}
 
@@ -120,6 +199,7 @@ func (s *AuditService) ExportCSV(ctx context.Context, req *models.AuditLogListRe
 				entry.ResourceID,
 				entry.Details,
 				entry.IPAddress,
+				entry.CorrelationID,
 			}); err != nil {
 				return fmt.Errorf("failed to write CSV row: %w", err)
 			}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	auditsink "github.com/VuteTech/Bor/server/internal/audit"
+	auditpb "github.com/VuteTech/Bor/server/pkg/grpc/audit"
+)
+
+type captureSink struct{ events []*auditpb.AuditEvent }
+
+func (c *captureSink) Emit(_ context.Context, event *auditpb.AuditEvent) {
+	c.events = append(c.events, event)
+}
+
+func TestEmitSetsCorrelationID(t *testing.T) {
+	sink := &captureSink{}
+	svc := NewAuditService(nil)
+	svc.AddSink(sink)
+
+	ctx := auditsink.WithCorrelationID(context.Background(), "req-1")
+	svc.Emit(ctx, &auditpb.AuditEvent{Action: "create"})
+	svc.Emit(ctx, &auditpb.AuditEvent{Action: "update", CorrelationId: "explicit"})
+	svc.Emit(context.Background(), &auditpb.AuditEvent{Action: "delete"})
+
+	want := []string{"req-1", "explicit", ""}
+	for i, ev := range sink.events {
+		if ev.GetCorrelationId() != want[i] {
+			t.Errorf("event %d correlation ID = %q, want %q", i, ev.GetCorrelationId(), want[i])
+		}
+	}
+}
+
+// fakeAuditLogDeleter deletes from a fixed number of old entries.
+type fakeAuditLogDeleter struct {
+	remaining int64
+	failAfter int // fail on this call; 0 never fails
+	calls     int
+}
+
+func (f *fakeAuditLogDeleter) DeleteOlderThan(_ context.Context, _ time.Time, limit int) (int64, error) {
+	f.calls++
+	if f.calls == f.failAfter {
+		return 0, errors.New("connection reset")
+	}
+	n := min(f.remaining, int64(limit))
+	f.remaining -= n
+	return n, nil
+}
+
+func TestPurgeAuditLogsBatches(t *testing.T) {
+	store := &fakeAuditLogDeleter{remaining: 25}
+	n, err := purgeAuditLogs(context.Background(), store, time.Now(), 10)
+	if err != nil || n != 25 {
+		t.Fatalf("purgeAuditLogs() = %d, %v; want 25, nil", n, err)
+	}
+	if store.calls != 3 {
+		t.Errorf("DeleteOlderThan called %d times, want 3", store.calls)
+	}
+
+	// An exact multiple of the batch size needs one more, empty batch.
+	store = &fakeAuditLogDeleter{remaining: 20}
+	if n, err := purgeAuditLogs(context.Background(), store, time.Now(), 10); err != nil || n != 20 || store.calls != 3 {
+		t.Errorf("purgeAuditLogs() = %d, %v after %d calls; want 20, nil after 3", n, err, store.calls)
+	}
+}
+
+func TestPurgeAuditLogsReportsPartialProgress(t *testing.T) {
+	store := &fakeAuditLogDeleter{remaining: 25, failAfter: 2}
+	n, err := purgeAuditLogs(context.Background(), store, time.Now(), 10)
+	if err == nil || n != 10 {
+		t.Errorf("purgeAuditLogs() = %d, %v; want 10 and an error", n, err)
+	}
+}
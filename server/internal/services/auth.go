@@ -10,11 +10,14 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"sort"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/VuteTech/Bor/server/internal/database"
 	"github.com/VuteTech/Bor/server/internal/models"
@@ -89,13 +92,43 @@ type AuthService struct {
 	userRepo        *database.UserRepository
 	roleRepo        *database.RoleRepository
 	bindingRepo     *database.UserRoleBindingRepository
+	groupBindings   *database.UserGroupRoleBindingRepository // nil ignores user group role bindings
 	jwtSecret       string
+	jwtKeys         *JWTKeys // nil signs and verifies HS256 with jwtSecret only
 	tokenLifetime   time.Duration
 	refreshLifetime time.Duration
+	issuer          string // iss claim of issued tokens; "" omits and does not check it
+	audience        string // aud claim of issued tokens; "" omits and does not check it
 	ldapSvc         *LDAPService
 	mfaSvc          *MFAService
 	webauthnSvc     *WebAuthnService
-	adminPassword   string // initial admin password; used once when no users exist
+	adminPassword   string          // initial admin password; used once when no users exist
+	clientCertAuth  *ClientCertAuth // nil disables client certificate authentication
+
+	lockoutThreshold int // consecutive failed logins that lock an account; 0 disables
+	lockoutDuration  time.Duration
+
+	revokedTokens revokedTokenStore // nil disables token revocation
+}
+
+// ErrAccountLocked is returned by Login and AuthStep for an account that is
+// locked after repeated failed logins.
+var ErrAccountLocked = errors.New("account is temporarily locked after repeated failed logins")
+
+// WithLockout locks an account for duration after threshold consecutive
+// failed logins. A threshold of 0 disables lockout.
+func (s *AuthService) WithLockout(threshold int, duration time.Duration) *AuthService {
+	s.lockoutThreshold = threshold
+	s.lockoutDuration = duration
+	return s
+}
+
+// WithUserGroupBindings makes GetUserPermissions and GetEffectivePermissions
+// include the role bindings of the user groups a user is a member of, as
+// the authorizer does.
+func (s *AuthService) WithUserGroupBindings(repo *database.UserGroupRoleBindingRepository) *AuthService {
+	s.groupBindings = repo
+	return s
 }
 
 // WithAdminPassword sets the initial admin password used by EnsureDefaultAdmin.
@@ -105,6 +138,65 @@ func (s *AuthService) WithAdminPassword(password string) *AuthService {
 	return s
 }
 
+// WithJWTKeys replaces the HS256 jwtSecret key with the given signing and
+// verification keys, enabling RS256 and rotation of the signing key.
+func (s *AuthService) WithJWTKeys(keys *JWTKeys) *AuthService {
+	s.jwtKeys = keys
+	return s
+}
+
+// WithTokenIssuer sets the iss and aud claims of issued tokens and rejects
+// tokens that do not carry them. An empty value is neither set nor checked.
+func (s *AuthService) WithTokenIssuer(issuer, audience string) *AuthService {
+	s.issuer = issuer
+	s.audience = audience
+	return s
+}
+
+// registeredClaims returns the registered claims of a token for subject
+// that expires after lifetime.
+func (s *AuthService) registeredClaims(subject string, lifetime time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	rc := jwt.RegisteredClaims{
+		ID:        newTokenID(),
+		Issuer:    s.issuer,
+		ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		Subject:   subject,
+	}
+	if s.audience != "" {
+		rc.Audience = jwt.ClaimStrings{s.audience}
+	}
+	return rc
+}
+
+// parserOptions returns the options that make the JWT parser check the
+// configured issuer and audience.
+func (s *AuthService) parserOptions() []jwt.ParserOption {
+	var opts []jwt.ParserOption
+	if s.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(s.issuer))
+	}
+	if s.audience != "" {
+		opts = append(opts, jwt.WithAudience(s.audience))
+	}
+	return opts
+}
+
+// keys returns the JWT signing and verification keys.
+func (s *AuthService) keys() *JWTKeys {
+	if s.jwtKeys != nil {
+		return s.jwtKeys
+	}
+	return NewHMACJWTKeys(s.jwtSecret, nil)
+}
+
+// JWTPublicKeys returns the public keys that verify issued tokens, or nil
+// when tokens are signed with a shared secret.
+func (s *AuthService) JWTPublicKeys() *JWKSet {
+	return s.keys().PublicKeys()
+}
+
 // NewAuthService creates a new AuthService
 func NewAuthService(userRepo *database.UserRepository, roleRepo *database.RoleRepository, bindingRepo *database.UserRoleBindingRepository, jwtSecret string, tokenLifetime, refreshLifetime time.Duration, ldapSvc *LDAPService) *AuthService {
 	return &AuthService{
@@ -156,15 +248,20 @@ type Claims struct {
 
 // AuthSessionClaims is a short-lived JWT used during the multi-step auth flow.
 type AuthSessionClaims struct {
-	UserID      string `json:"user_id"`
-	Username    string `json:"username"`
-	Source      string `json:"source"` // "local" or "ldap"
-	TOTPDone    bool   `json:"totp_done"`
-	SessionType string `json:"session_type"` // always "auth_session"
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Source   string `json:"source"` // "local" or "ldap"
+	TOTPDone bool   `json:"totp_done"`
+	// PasswordDone is set on the MFA challenge returned by Login, whose
+	// password was already verified; a TOTP step then completes the login.
+	PasswordDone bool   `json:"password_done,omitempty"`
+	SessionType  string `json:"session_type"` // always "auth_session"
 	jwt.RegisteredClaims
 }
 
-// Login authenticates a user and returns a JWT token
+// Login authenticates a user and returns a JWT token. For a user with a
+// second factor enrolled it returns an MFA challenge instead: a session
+// token that AuthStep exchanges for the JWT together with a TOTP code.
 func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.LoginResponse, error) {
 	if req.Username == "" || req.Password == "" {
 		return nil, fmt.Errorf("username and password are required")
@@ -176,52 +273,144 @@ func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 		return nil, fmt.Errorf("failed to look up user: %w", err)
 	}
 
-	if user != nil && user.Source == models.SourceLocal {
-		return s.authenticateLocal(user, req.Password)
+	switch {
+	case user != nil && user.Source == models.SourceLocal:
+		user, err = s.authenticateLocal(ctx, user, req.Password)
+	case s.ldapSvc != nil && s.ldapSvc.IsEnabled():
+		// Try LDAP authentication if configured
+		user, err = s.authenticateLDAP(ctx, req.Username, req.Password)
+	default:
+		return nil, fmt.Errorf("invalid username or password")
 	}
-
-	// Try LDAP authentication if configured
-	if s.ldapSvc != nil && s.ldapSvc.IsEnabled() {
-		return s.authenticateLDAP(ctx, req.Username, req.Password)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("invalid username or password")
-}
-
-// authenticateLocal verifies credentials against local database
-func (s *AuthService) authenticateLocal(user *models.User, password string) (*models.LoginResponse, error) {
-	if !user.Enabled {
-		return nil, fmt.Errorf("user account is disabled")
+	methods, err := s.mfaMethods(ctx, user)
+	if err != nil {
+		return nil, err
 	}
-
-	if err := verifyPassword(user.PasswordHash, password); err != nil {
-		return nil, fmt.Errorf("invalid username or password")
+	if len(methods) > 0 {
+		sessionToken, err := s.generateMFAChallengeToken(user)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate session token: %w", err)
+		}
+		return &models.LoginResponse{
+			MFARequired:  true,
+			SessionToken: sessionToken,
+			MFAMethods:   methods,
+		}, nil
 	}
+	return s.issueLogin(user)
+}
 
+// issueLogin returns the JWT of user after a successful login.
+func (s *AuthService) issueLogin(user *models.User) (*models.LoginResponse, error) {
 	token, err := s.generateToken(user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
-
 	return &models.LoginResponse{
 		Token: token,
 		User:  *user,
 	}, nil
 }
 
-// authenticateLDAP verifies credentials against LDAP server
-func (s *AuthService) authenticateLDAP(ctx context.Context, username, password string) (*models.LoginResponse, error) {
-	ldapUser, err := s.ldapSvc.Authenticate(username, password)
+// ErrMFARequired is returned by AuthStep when a password step would
+// complete the login of a user with a second factor that was not verified.
+var ErrMFARequired = errors.New("multi-factor authentication required")
+
+// mfaMethods returns the second factors user has enrolled, WebAuthn first.
+func (s *AuthService) mfaMethods(ctx context.Context, user *models.User) ([]string, error) {
+	var methods []string
+	if s.webauthnSvc != nil {
+		hasCreds, err := s.webauthnSvc.HasCredentials(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check user WebAuthn credentials: %w", err)
+		}
+		if hasCreds {
+			methods = append(methods, "webauthn")
+		}
+	}
+	if s.mfaSvc != nil {
+		enabled, err := s.mfaSvc.IsMFAEnabled(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check user MFA: %w", err)
+		}
+		if enabled {
+			methods = append(methods, "totp")
+		}
+	}
+	return methods, nil
+}
+
+// recordLoginFailure counts a failed login for username and logs when it
+// locks the account.
+func (s *AuthService) recordLoginFailure(ctx context.Context, username string) {
+	lockedUntil, err := s.userRepo.RecordLoginFailure(ctx, username, s.lockoutThreshold, s.lockoutDuration)
 	if err != nil {
-		log.Printf("LDAP authentication failed for user %s: %v", username, err)
+		log.Printf("Failed to record failed login for user %s: %v", username, err)
+		return
+	}
+	if lockedUntil != nil {
+		log.Printf("User %s is locked until %s after %d failed logins",
+			username, lockedUntil.Format(time.RFC3339), s.lockoutThreshold)
+	}
+}
+
+// resetLoginFailures clears the failed login count of user after a
+// successful login.
+func (s *AuthService) resetLoginFailures(ctx context.Context, user *models.User) {
+	if user.FailedAttempts == 0 && user.LockedUntil == nil {
+		return
+	}
+	if err := s.userRepo.ResetLoginFailures(ctx, user.ID); err != nil {
+		log.Printf("Failed to reset failed logins for user %s: %v", user.Username, err)
+		return
+	}
+	user.FailedAttempts = 0
+	user.LockedUntil = nil
+}
+
+// authenticateLocal verifies credentials against local database and returns
+// the user on success.
+func (s *AuthService) authenticateLocal(ctx context.Context, user *models.User, password string) (*models.User, error) {
+	if !user.Enabled {
+		return nil, fmt.Errorf("user account is disabled")
+	}
+	if user.IsLocked(time.Now()) {
+		return nil, ErrAccountLocked
+	}
+
+	if err := verifyPassword(user.PasswordHash, password); err != nil {
+		s.recordLoginFailure(ctx, user.Username)
 		return nil, fmt.Errorf("invalid username or password")
 	}
+	s.resetLoginFailures(ctx, user)
+	return user, nil
+}
 
+// authenticateLDAP verifies credentials against LDAP server and returns the
+// local record of the user, created on first login. Failed logins count
+// towards the lockout of the local record of the user, if any.
+func (s *AuthService) authenticateLDAP(ctx context.Context, username, password string) (*models.User, error) {
 	// Check if user exists in local DB, create/update if needed
 	user, err := s.userRepo.GetByUsername(ctx, username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to look up user: %w", err)
 	}
+	if user != nil && user.IsLocked(time.Now()) {
+		return nil, ErrAccountLocked
+	}
+
+	ldapUser, err := s.ldapSvc.Authenticate(username, password)
+	if err != nil {
+		log.Printf("LDAP authentication failed for user %s: %v", username, err)
+		if user != nil {
+			s.recordLoginFailure(ctx, username)
+		}
+		return nil, fmt.Errorf("invalid username or password")
+	}
 
 	if user == nil {
 		// Create LDAP user in local database
@@ -252,102 +441,100 @@ func (s *AuthService) authenticateLDAP(ctx context.Context, username, password s
 		}
 		user.Email = email
 		user.FullName = fullName
+		s.resetLoginFailures(ctx, user)
 	}
 
-	// Sync role bindings based on LDAP group membership.
-	if len(s.ldapSvc.config.GroupRoleMap) > 0 {
-		s.syncLDAPRoles(ctx, user.ID, ldapUser.Groups, s.ldapSvc.config.GroupRoleMap)
-	}
-
-	token, err := s.generateToken(user)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
-	}
-
-	return &models.LoginResponse{
-		Token: token,
-		User:  *user,
-	}, nil
+	// Sync role bindings based on LDAP group membership. This runs even
+	// without mappings so that roles granted under a mapping since removed
+	// from the configuration are revoked.
+	s.syncLDAPRoles(ctx, user.ID, ldapUser.MappedRoles(s.ldapSvc.Config().GroupRoleMap))
+	return user, nil
 }
 
-// syncLDAPRoles grants or revokes roles whose names appear in groupRoleMap based
-// on the user's current LDAP group memberships. Only roles that are present in
-// groupRoleMap are touched; role bindings created by an administrator are left
-// intact.
-func (s *AuthService) syncLDAPRoles(ctx context.Context, userID string, ldapGroups []string, groupRoleMap map[string]string) {
-	// Build the set of role names this user should hold.
-	wantedRoles := make(map[string]bool)
-	for _, grp := range ldapGroups {
-		if roleName, ok := groupRoleMap[grp]; ok {
-			wantedRoles[roleName] = true
+// syncLDAPRoles grants the user the global roles named in wantedRoles and
+// revokes the roles the LDAP sync granted earlier that are no longer
+// wanted. Bindings it creates are marked RoleBindingSourceLDAP; bindings
+// created by an administrator are never revoked, and a role the user
+// already holds globally is not granted again.
+func (s *AuthService) syncLDAPRoles(ctx context.Context, userID string, wantedRoles map[string]bool) {
+	wantedRoleIDs := make(map[string]string, len(wantedRoles)) // roleID → name
+	for roleName := range wantedRoles {
+		role, err := s.roleRepo.GetByName(ctx, roleName)
+		if err != nil {
+			// Without the full set a wanted role would look unwanted and
+			// be revoked, so leave the bindings alone until the next login.
+			log.Printf("syncLDAPRoles: look up role %q: %v", roleName, err)
+			return
 		}
+		if role == nil {
+			log.Printf("syncLDAPRoles: role %q not found in DB, skipping", roleName)
+			continue
+		}
+		wantedRoleIDs[role.ID] = roleName
 	}
 
-	// Build the set of all role names managed by this map.
-	managedRoles := make(map[string]bool)
-	for _, roleName := range groupRoleMap {
-		managedRoles[roleName] = true
-	}
-
-	// Fetch the user's current role bindings.
 	bindings, err := s.bindingRepo.ListByUserID(ctx, userID)
 	if err != nil {
 		log.Printf("syncLDAPRoles: list bindings for user %s: %v", userID, err)
 		return
 	}
 
-	// Index current bindings by role ID.
-	currentByRoleID := make(map[string]string) // roleID → bindingID
-	for _, b := range bindings {
-		currentByRoleID[b.RoleID] = b.ID
+	grant, revoke := planLDAPRoleSync(bindings, wantedRoleIDs)
+	for _, roleID := range grant {
+		roleName := wantedRoleIDs[roleID]
+		if err := s.bindingRepo.Create(ctx, &models.UserRoleBinding{
+			UserID:    userID,
+			RoleID:    roleID,
+			ScopeType: "global",
+			Source:    models.RoleBindingSourceLDAP,
+		}); err != nil {
+			log.Printf("syncLDAPRoles: grant role %q to user %s: %v", roleName, userID, err)
+		} else {
+			log.Printf("syncLDAPRoles: granted role %q to LDAP user %s", roleName, userID)
+		}
+	}
+	for _, b := range revoke {
+		if err := s.bindingRepo.Delete(ctx, b.ID); err != nil {
+			log.Printf("syncLDAPRoles: revoke role %s from user %s: %v", b.RoleID, userID, err)
+		} else {
+			log.Printf("syncLDAPRoles: revoked role %s from LDAP user %s", b.RoleID, userID)
+		}
 	}
+}
 
-	// For each managed role, grant or revoke as needed.
-	for roleName := range managedRoles {
-		role, roleErr := s.roleRepo.GetByName(ctx, roleName)
-		if roleErr != nil || role == nil {
-			log.Printf("syncLDAPRoles: role %q not found in DB, skipping", roleName)
+// planLDAPRoleSync compares the user's bindings with the roles the LDAP
+// groups grant (wantedRoleIDs, keyed by role ID). It returns the IDs of the
+// wanted roles the user holds no global binding for, sorted, and the
+// LDAP-sourced bindings whose role is no longer wanted.
+func planLDAPRoleSync(bindings []*models.UserRoleBinding, wantedRoleIDs map[string]string) (grant []string, revoke []*models.UserRoleBinding) {
+	held := make(map[string]bool)
+	for _, b := range bindings {
+		if _, wanted := wantedRoleIDs[b.RoleID]; b.Source == models.RoleBindingSourceLDAP && !wanted {
+			revoke = append(revoke, b)
 			continue
 		}
-
-		_, hasBinding := currentByRoleID[role.ID]
-		shouldHave := wantedRoles[roleName]
-
-		switch {
-		case shouldHave && !hasBinding:
-			if createErr := s.bindingRepo.Create(ctx, &models.UserRoleBinding{
-				UserID:    userID,
-				RoleID:    role.ID,
-				ScopeType: "global",
-			}); createErr != nil {
-				log.Printf("syncLDAPRoles: grant role %q to user %s: %v", roleName, userID, createErr)
-			} else {
-				log.Printf("syncLDAPRoles: granted role %q to LDAP user %s", roleName, userID)
-			}
-		case !shouldHave && hasBinding:
-			if delErr := s.bindingRepo.Delete(ctx, currentByRoleID[role.ID]); delErr != nil {
-				log.Printf("syncLDAPRoles: revoke role %q from user %s: %v", roleName, userID, delErr)
-			} else {
-				log.Printf("syncLDAPRoles: revoked role %q from LDAP user %s", roleName, userID)
-			}
+		if b.ScopeType == "global" {
+			held[b.RoleID] = true
 		}
 	}
+	for roleID := range wantedRoleIDs {
+		if !held[roleID] {
+			grant = append(grant, roleID)
+		}
+	}
+	sort.Strings(grant)
+	return grant, revoke
 }
 
 // generateToken creates a JWT token for the given user
 func (s *AuthService) generateToken(user *models.User) (string, error) {
 	claims := &Claims{
-		UserID:   user.ID,
-		Username: user.Username,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.tokenLifetime)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Subject:   user.ID,
-		},
+		UserID:           user.ID,
+		Username:         user.Username,
+		RegisteredClaims: s.registeredClaims(user.ID, s.tokenLifetime),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	return s.keys().sign(claims)
 }
 
 // RefreshClaims represents JWT claims for a refresh token.
@@ -361,27 +548,17 @@ type RefreshClaims struct {
 // GenerateRefreshToken creates a refresh JWT for the given user.
 func (s *AuthService) GenerateRefreshToken(user *models.User) (string, error) {
 	claims := &RefreshClaims{
-		UserID:    user.ID,
-		Username:  user.Username,
-		TokenType: "refresh",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.refreshLifetime)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Subject:   user.ID,
-		},
+		UserID:           user.ID,
+		Username:         user.Username,
+		TokenType:        "refresh",
+		RegisteredClaims: s.registeredClaims(user.ID, s.refreshLifetime),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	return s.keys().sign(claims)
 }
 
 // ValidateRefreshToken validates a refresh JWT and returns its claims.
 func (s *AuthService) ValidateRefreshToken(tokenString string) (*RefreshClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &RefreshClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.jwtSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &RefreshClaims{}, s.keys().keyfunc, s.parserOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
@@ -410,12 +587,7 @@ func (s *AuthService) RefreshLifetimeSeconds() int {
 // It rejects auth session tokens (session_type == "auth_session") so they
 // cannot be used as regular bearer tokens.
 func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.jwtSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.keys().keyfunc, s.parserOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
@@ -430,9 +602,7 @@ func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
 	// does not carry it.
 	if raw, ok2 := token.Claims.(*Claims); ok2 {
 		// Re-parse as MapClaims to check session_type without a full decode.
-		mapToken, _ := jwt.ParseWithClaims(tokenString, jwt.MapClaims{}, func(_ *jwt.Token) (interface{}, error) {
-			return []byte(s.jwtSecret), nil
-		})
+		mapToken, _ := jwt.ParseWithClaims(tokenString, jwt.MapClaims{}, s.keys().keyfunc, s.parserOptions()...)
 		if mapToken != nil {
 			if mc, ok3 := mapToken.Claims.(jwt.MapClaims); ok3 {
 				if st, ok4 := mc["session_type"].(string); ok4 && st == "auth_session" {
@@ -456,19 +626,28 @@ func (s *AuthService) GenerateSessionToken(userID, username, source string, totp
 // generateSessionToken creates a short-lived (5 min) JWT for the auth flow.
 func (s *AuthService) generateSessionToken(userID, username, source string, totpDone bool) (string, error) {
 	claims := &AuthSessionClaims{
-		UserID:      userID,
-		Username:    username,
-		Source:      source,
-		TOTPDone:    totpDone,
-		SessionType: "auth_session",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(5 * time.Minute)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Subject:   userID,
-		},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+		UserID:           userID,
+		Username:         username,
+		Source:           source,
+		TOTPDone:         totpDone,
+		SessionType:      "auth_session",
+		RegisteredClaims: s.registeredClaims(userID, 5*time.Minute),
+	}
+	return s.keys().sign(claims)
+}
+
+// generateMFAChallengeToken creates the session token of an MFA challenge
+// for user, whose password has been verified.
+func (s *AuthService) generateMFAChallengeToken(user *models.User) (string, error) {
+	claims := &AuthSessionClaims{
+		UserID:           user.ID,
+		Username:         user.Username,
+		Source:           user.Source,
+		PasswordDone:     true,
+		SessionType:      "auth_session",
+		RegisteredClaims: s.registeredClaims(user.ID, 5*time.Minute),
+	}
+	return s.keys().sign(claims)
 }
 
 // ValidateSessionToken parses and validates an AuthSessionClaims token.
@@ -479,12 +658,7 @@ func (s *AuthService) ValidateSessionToken(tokenString string) (*AuthSessionClai
 
 // validateSessionToken parses and validates an AuthSessionClaims token.
 func (s *AuthService) validateSessionToken(tokenString string) (*AuthSessionClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &AuthSessionClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.jwtSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &AuthSessionClaims{}, s.keys().keyfunc, s.parserOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("invalid session token: %w", err)
 	}
@@ -537,26 +711,9 @@ func (s *AuthService) AuthBegin(ctx context.Context, req *models.AuthBeginReques
 	// Check whether MFA is needed. Applies to all user sources (local and LDAP).
 	// Only future OAuth/SAML sources, which delegate authentication entirely to an
 	// external IdP, would be exempt — they are not yet implemented.
-	var mfaMethods []string
-	if s.mfaSvc != nil {
-		var enabled bool
-		enabled, err = s.mfaSvc.IsMFAEnabled(ctx, user.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check user MFA: %w", err)
-		}
-		if enabled {
-			mfaMethods = append(mfaMethods, "totp")
-		}
-	}
-	if s.webauthnSvc != nil {
-		var hasCreds bool
-		hasCreds, err = s.webauthnSvc.HasCredentials(ctx, user.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check user WebAuthn credentials: %w", err)
-		}
-		if hasCreds {
-			mfaMethods = append([]string{"webauthn"}, mfaMethods...)
-		}
+	mfaMethods, err := s.mfaMethods(ctx, user)
+	if err != nil {
+		return nil, err
 	}
 
 	sessionToken, err := s.generateSessionToken(user.ID, user.Username, source, false)
@@ -591,6 +748,24 @@ func (s *AuthService) AuthStep(ctx context.Context, req *models.AuthStepRequest)
 		if err := s.mfaSvc.VerifyCode(ctx, sessionClaims.UserID, req.Credential); err != nil {
 			return nil, fmt.Errorf("invalid TOTP code")
 		}
+		if sessionClaims.PasswordDone {
+			// MFA challenge of Login: the password is already verified.
+			user, err := s.userRepo.GetByID(ctx, sessionClaims.UserID)
+			if err != nil || user == nil {
+				return nil, fmt.Errorf("invalid username or password")
+			}
+			if !user.Enabled {
+				return nil, fmt.Errorf("user account is disabled")
+			}
+			if user.IsLocked(time.Now()) {
+				return nil, ErrAccountLocked
+			}
+			loginResp, err := s.issueLogin(user)
+			if err != nil {
+				return nil, err
+			}
+			return &models.AuthStepResponse{Token: loginResp.Token, User: &loginResp.User}, nil
+		}
 		// Issue new session token with totp_done=true.
 		newToken, err := s.generateSessionToken(sessionClaims.UserID, sessionClaims.Username, sessionClaims.Source, true)
 		if err != nil {
@@ -605,7 +780,11 @@ func (s *AuthService) AuthStep(ctx context.Context, req *models.AuthStepRequest)
 		// UserID is empty for first-time LDAP users (not yet in local DB).
 		// In that case, skip MFA checks (no local record) and go straight to LDAP.
 		if sessionClaims.UserID == "" && sessionClaims.Source == models.SourceLDAP {
-			loginResp, err := s.authenticateLDAP(ctx, sessionClaims.Username, req.Credential)
+			user, err := s.authenticateLDAP(ctx, sessionClaims.Username, req.Credential)
+			if err != nil {
+				return nil, err
+			}
+			loginResp, err := s.issueLogin(user)
 			if err != nil {
 				return nil, err
 			}
@@ -620,12 +799,27 @@ func (s *AuthService) AuthStep(ctx context.Context, req *models.AuthStepRequest)
 			return nil, fmt.Errorf("user account is disabled")
 		}
 
-		var loginResp *models.LoginResponse
+		// A session token only skips the second factor once it has been
+		// verified (TOTPDone, also set after a WebAuthn assertion).
+		if !sessionClaims.TOTPDone {
+			methods, err := s.mfaMethods(ctx, user)
+			if err != nil {
+				return nil, err
+			}
+			if len(methods) > 0 {
+				return nil, ErrMFARequired
+			}
+		}
+
 		if sessionClaims.Source == models.SourceLDAP {
-			loginResp, err = s.authenticateLDAP(ctx, user.Username, req.Credential)
+			user, err = s.authenticateLDAP(ctx, user.Username, req.Credential)
 		} else {
-			loginResp, err = s.authenticateLocal(user, req.Credential)
+			user, err = s.authenticateLocal(ctx, user, req.Credential)
+		}
+		if err != nil {
+			return nil, err
 		}
+		loginResp, err := s.issueLogin(user)
 		if err != nil {
 			return nil, err
 		}
@@ -640,6 +834,116 @@ func (s *AuthService) AuthStep(ctx context.Context, req *models.AuthStepRequest)
 	}
 }
 
+// MinPasswordLength is the minimum length of a password set by a user or
+// an admin.
+const MinPasswordLength = 12
+
+var (
+	// ErrUserNotFound is returned for an unknown user ID.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrPasswordNotLocal is returned when changing the password of a user
+	// whose password is managed by an external directory such as LDAP.
+	ErrPasswordNotLocal = errors.New("password is managed by an external directory")
+	// ErrInvalidCurrentPassword is returned by ChangePassword when the
+	// current password does not match.
+	ErrInvalidCurrentPassword = errors.New("current password is incorrect")
+	// ErrWeakPassword is returned for a new password that does not meet
+	// the length and complexity requirements.
+	ErrWeakPassword = errors.New("password is too weak")
+)
+
+// ValidatePasswordStrength checks that password is at least
+// MinPasswordLength characters long and mixes at least three of lowercase
+// letters, uppercase letters, digits and other characters.
+func ValidatePasswordStrength(password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return fmt.Errorf("%w: must be at least %d characters", ErrWeakPassword, MinPasswordLength)
+	}
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	classes := 0
+	for _, ok := range []bool{lower, upper, digit, other} {
+		if ok {
+			classes++
+		}
+	}
+	if classes < 3 {
+		return fmt.Errorf("%w: must contain at least three of lowercase letters, uppercase letters, digits and symbols", ErrWeakPassword)
+	}
+	return nil
+}
+
+// ChangePassword replaces the password of a local user after verifying the
+// current one. Wrong current passwords count towards the account lockout.
+func (s *AuthService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to look up user: %w", err)
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.Source != models.SourceLocal {
+		return ErrPasswordNotLocal
+	}
+	if user.IsLocked(time.Now()) {
+		return ErrAccountLocked
+	}
+	if err := verifyPassword(user.PasswordHash, currentPassword); err != nil {
+		s.recordLoginFailure(ctx, user.Username)
+		return ErrInvalidCurrentPassword
+	}
+	if newPassword == currentPassword {
+		return fmt.Errorf("%w: must differ from the current password", ErrWeakPassword)
+	}
+	if err := ValidatePasswordStrength(newPassword); err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	return s.userRepo.UpdatePassword(ctx, user.ID, hash, false)
+}
+
+// ResetPassword sets a new password for a local user on behalf of an
+// admin, e.g. when the user forgot theirs, and lifts any lockout. With
+// mustChange the user is asked to choose their own password after logging
+// in with it.
+func (s *AuthService) ResetPassword(ctx context.Context, userID, newPassword string, mustChange bool) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to look up user: %w", err)
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if user.Source != models.SourceLocal {
+		return ErrPasswordNotLocal
+	}
+	if err := ValidatePasswordStrength(newPassword); err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	return s.userRepo.UpdatePassword(ctx, user.ID, hash, mustChange)
+}
+
 // CreateUser creates a new local user
 func (s *AuthService) CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.User, error) {
 	if req.Username == "" {
@@ -712,7 +1016,8 @@ func (s *AuthService) GetUser(ctx context.Context, id string) (*models.User, err
 }
 
 // GetUserPermissions returns a deduplicated, sorted list of "resource:action"
-// permission strings for the given user, aggregated from all their role bindings.
+// permission strings for the given user, aggregated from all their role bindings,
+// direct and through the user groups they are members of.
 // All bindings (global, organization, and group scoped) are included so the
 // frontend has the full set of permissions to show/hide UI elements.
 // Note: The backend still enforces scoped permissions at request time via the
@@ -722,14 +1027,27 @@ func (s *AuthService) GetUserPermissions(ctx context.Context, userID string) ([]
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch role bindings: %w", err)
 	}
+	roleIDs := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		roleIDs = append(roleIDs, b.RoleID)
+	}
+	if s.groupBindings != nil {
+		groupBindings, err := s.groupBindings.ListByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch group role bindings: %w", err)
+		}
+		for _, b := range groupBindings {
+			roleIDs = append(roleIDs, b.RoleID)
+		}
+	}
 
 	seen := make(map[string]struct{})
 	var perms []string
 
-	for _, b := range bindings {
-		rolePerms, err := s.roleRepo.GetPermissionsByRoleID(ctx, b.RoleID)
+	for _, roleID := range roleIDs {
+		rolePerms, err := s.roleRepo.GetPermissionsByRoleID(ctx, roleID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch permissions for role %s: %w", b.RoleID, err)
+			return nil, fmt.Errorf("failed to fetch permissions for role %s: %w", roleID, err)
 		}
 		for _, p := range rolePerms {
 			key := p.Resource + ":" + p.Action
@@ -744,6 +1062,134 @@ func (s *AuthService) GetUserPermissions(ctx context.Context, userID string) ([]
 	return perms, nil
 }
 
+// scopedGrant is a role binding granting a role's permissions in a scope.
+type scopedGrant struct {
+	scopeType string
+	scopeID   *string
+	grant     models.PermissionGrant
+}
+
+// GetEffectivePermissions returns the permissions a user holds through
+// direct and user group role bindings, grouped by scope, with the bindings
+// granting each one. It returns nil if the user does not exist.
+func (s *AuthService) GetEffectivePermissions(ctx context.Context, userID string) (*models.EffectivePermissions, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, nil
+	}
+
+	bindings, err := s.bindingRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch role bindings: %w", err)
+	}
+	var grants []scopedGrant
+	for _, b := range bindings {
+		grants = append(grants, scopedGrant{b.ScopeType, b.ScopeID,
+			models.PermissionGrant{BindingID: b.ID, RoleID: b.RoleID}})
+	}
+	if s.groupBindings != nil {
+		groupBindings, err := s.groupBindings.ListByUserID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch group role bindings: %w", err)
+		}
+		for _, b := range groupBindings {
+			grants = append(grants, scopedGrant{b.ScopeType, b.ScopeID,
+				models.PermissionGrant{BindingID: b.ID, RoleID: b.RoleID, ViaGroupID: b.GroupID, ViaGroupName: b.GroupName}})
+		}
+	}
+
+	rolePerms := make(map[string][]*models.Permission)
+	roleNames := make(map[string]string)
+	for _, g := range grants {
+		roleID := g.grant.RoleID
+		if _, ok := rolePerms[roleID]; ok {
+			continue
+		}
+		perms, err := s.roleRepo.GetPermissionsByRoleID(ctx, roleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch permissions for role %s: %w", roleID, err)
+		}
+		rolePerms[roleID] = perms
+		if role, err := s.roleRepo.GetByID(ctx, roleID); err == nil && role != nil {
+			roleNames[roleID] = role.Name
+		}
+	}
+	for i := range grants {
+		grants[i].grant.RoleName = roleNames[grants[i].grant.RoleID]
+	}
+
+	return &models.EffectivePermissions{
+		UserID:   user.ID,
+		Username: user.Username,
+		Scopes:   groupGrantsByScope(grants, rolePerms),
+	}, nil
+}
+
+// scopeOrder sorts scope types from broadest to narrowest.
+var scopeOrder = map[string]int{models.ScopeGlobal: 0, models.ScopeOrganization: 1, models.ScopeGroup: 2}
+
+// groupGrantsByScope expands grants into the permissions of their roles and
+// groups them by scope. Scopes are ordered global, organization, group,
+// then by scope ID; permissions are sorted within a scope.
+func groupGrantsByScope(grants []scopedGrant, rolePerms map[string][]*models.Permission) []models.EffectiveScope {
+	type scopeKey struct{ scopeType, scopeID string }
+	scopes := make(map[scopeKey]*models.EffectiveScope)
+	perms := make(map[scopeKey]map[string]*models.EffectivePermission)
+	for _, g := range grants {
+		key := scopeKey{g.scopeType, deref(g.scopeID)}
+		if g.scopeType == models.ScopeGlobal {
+			key.scopeID = ""
+		}
+		if scopes[key] == nil {
+			scope := &models.EffectiveScope{ScopeType: g.scopeType}
+			if key.scopeID != "" {
+				id := key.scopeID
+				scope.ScopeID = &id
+			}
+			scopes[key] = scope
+			perms[key] = make(map[string]*models.EffectivePermission)
+		}
+		for _, p := range rolePerms[g.grant.RoleID] {
+			name := p.Resource + ":" + p.Action
+			if perms[key][name] == nil {
+				perms[key][name] = &models.EffectivePermission{Permission: name}
+			}
+			perms[key][name].Grants = append(perms[key][name].Grants, g.grant)
+		}
+	}
+
+	keys := make([]scopeKey, 0, len(scopes))
+	for k := range scopes {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if oi, oj := scopeOrder[keys[i].scopeType], scopeOrder[keys[j].scopeType]; oi != oj {
+			return oi < oj
+		}
+		if keys[i].scopeType != keys[j].scopeType {
+			return keys[i].scopeType < keys[j].scopeType
+		}
+		return keys[i].scopeID < keys[j].scopeID
+	})
+
+	result := make([]models.EffectiveScope, 0, len(keys))
+	for _, k := range keys {
+		scope := scopes[k]
+		scope.Permissions = []models.EffectivePermission{}
+		for _, p := range perms[k] {
+			scope.Permissions = append(scope.Permissions, *p)
+		}
+		sort.Slice(scope.Permissions, func(i, j int) bool {
+			return scope.Permissions[i].Permission < scope.Permissions[j].Permission
+		})
+		result = append(result, *scope)
+	}
+	return result
+}
+
 // ListUsers returns all users
 func (s *AuthService) ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error) {
 	if limit <= 0 {
@@ -752,6 +1198,19 @@ func (s *AuthService) ListUsers(ctx context.Context, limit, offset int) ([]*mode
 	return s.userRepo.List(ctx, limit, offset)
 }
 
+// ListUsersPaginated returns page p of all users.
+func (s *AuthService) ListUsersPaginated(ctx context.Context, p models.Pagination) (*models.ListResponse[*models.User], error) {
+	users, err := s.userRepo.List(ctx, p.PerPage, p.Offset())
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.userRepo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewListResponse(users, total, p), nil
+}
+
 // UpdateUser updates a user
 func (s *AuthService) UpdateUser(ctx context.Context, id string, req *models.UpdateUserRequest) error {
 	return s.userRepo.Update(ctx, id, req)
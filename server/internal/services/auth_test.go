@@ -5,6 +5,8 @@
 package services
 
 import (
+	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -117,3 +119,141 @@ func TestAuthService_ValidateToken_WrongSigningMethod(t *testing.T) {
 		t.Fatal("ValidateToken() should reject tokens with non-HMAC signing method")
 	}
 }
+
+func TestValidatePasswordStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		wantErr  bool
+	}{
+		{"Sh0rt!", true},
+		{"alllowercaseletters", true},
+		{"lowercase and digits 123", false},
+		{"NoDigitsButUpperAndLower", true},
+		{"Upper Lower space", false},
+		{"Correct-Horse-9", false},
+		{"ÄÖÜäöü123456", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			err := ValidatePasswordStrength(tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePasswordStrength(%q) error = %v, wantErr %v", tt.password, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrWeakPassword) {
+				t.Errorf("error = %v, want ErrWeakPassword", err)
+			}
+		})
+	}
+}
+
+func TestPlanLDAPRoleSync(t *testing.T) {
+	groupID := "group-1"
+	bindings := []*models.UserRoleBinding{
+		// Granted by the sync and still wanted: kept.
+		{ID: "b-ldap-kept", RoleID: "editor", ScopeType: "global", Source: models.RoleBindingSourceLDAP},
+		// Granted by the sync but no longer wanted: revoked.
+		{ID: "b-ldap-stale", RoleID: "auditor", ScopeType: "global", Source: models.RoleBindingSourceLDAP},
+		// Created by an admin for a role the groups no longer grant: kept.
+		{ID: "b-manual", RoleID: "admin", ScopeType: "global", Source: models.RoleBindingSourceManual},
+		// A scoped binding does not satisfy a wanted global role.
+		{ID: "b-scoped", RoleID: "viewer", ScopeType: "node_group", ScopeID: &groupID, Source: models.RoleBindingSourceManual},
+	}
+	wanted := map[string]string{
+		"editor": "Policy Editor",
+		"admin":  "Org Admin",
+		"viewer": "Compliance Viewer",
+	}
+
+	grant, revoke := planLDAPRoleSync(bindings, wanted)
+
+	if len(grant) != 1 || grant[0] != "viewer" {
+		t.Errorf("grant = %v, want [viewer]", grant)
+	}
+	if len(revoke) != 1 || revoke[0].ID != "b-ldap-stale" {
+		ids := make([]string, 0, len(revoke))
+		for _, b := range revoke {
+			ids = append(ids, b.ID)
+		}
+		t.Errorf("revoke = %v, want [b-ldap-stale]", ids)
+	}
+
+	grant, revoke = planLDAPRoleSync(bindings, nil)
+	if len(grant) != 0 {
+		t.Errorf("without mappings: grant = %v, want none", grant)
+	}
+	if len(revoke) != 2 {
+		t.Errorf("without mappings: revoked %d bindings, want both LDAP bindings", len(revoke))
+	}
+}
+
+func TestAuthService_MFAChallengeToken(t *testing.T) {
+	authSvc := &AuthService{jwtSecret: "test-secret-key", tokenLifetime: time.Hour}
+	user := &models.User{ID: "u-1", Username: "alice", Source: models.SourceLocal}
+
+	challenge, err := authSvc.generateMFAChallengeToken(user)
+	if err != nil {
+		t.Fatalf("generateMFAChallengeToken() error = %v", err)
+	}
+	claims, err := authSvc.ValidateSessionToken(challenge)
+	if err != nil {
+		t.Fatalf("ValidateSessionToken() error = %v", err)
+	}
+	if !claims.PasswordDone || claims.TOTPDone || claims.UserID != user.ID {
+		t.Errorf("challenge claims = %+v, want password done for %s without TOTP", claims, user.ID)
+	}
+	if _, err := authSvc.ValidateToken(challenge); err == nil {
+		t.Error("ValidateToken() accepted an MFA challenge as a bearer token")
+	}
+
+	begin, err := authSvc.GenerateSessionToken(user.ID, user.Username, user.Source, false)
+	if err != nil {
+		t.Fatalf("GenerateSessionToken() error = %v", err)
+	}
+	claims, err = authSvc.ValidateSessionToken(begin)
+	if err != nil {
+		t.Fatalf("ValidateSessionToken() error = %v", err)
+	}
+	if claims.PasswordDone {
+		t.Error("session token of AuthBegin has PasswordDone set")
+	}
+}
+
+func TestGroupGrantsByScope(t *testing.T) {
+	groupID := "ng-1"
+	rolePerms := map[string][]*models.Permission{
+		"viewer": {{Resource: "policy", Action: "view"}, {Resource: "node", Action: "view"}},
+		"editor": {{Resource: "policy", Action: "edit"}, {Resource: "policy", Action: "view"}},
+	}
+	grants := []scopedGrant{
+		{models.ScopeGroup, &groupID, models.PermissionGrant{BindingID: "b1", RoleID: "editor"}},
+		{models.ScopeGlobal, nil, models.PermissionGrant{BindingID: "b2", RoleID: "viewer"}},
+		{models.ScopeGroup, &groupID, models.PermissionGrant{BindingID: "b3", RoleID: "viewer", ViaGroupID: "ug-1"}},
+	}
+
+	scopes := groupGrantsByScope(grants, rolePerms)
+	if len(scopes) != 2 {
+		t.Fatalf("got %d scopes, want 2", len(scopes))
+	}
+	if scopes[0].ScopeType != models.ScopeGlobal || scopes[0].ScopeID != nil {
+		t.Errorf("first scope = %s %v, want global", scopes[0].ScopeType, scopes[0].ScopeID)
+	}
+	if got := len(scopes[0].Permissions); got != 2 {
+		t.Errorf("global scope has %d permissions, want 2", got)
+	}
+
+	group := scopes[1]
+	if group.ScopeType != models.ScopeGroup || group.ScopeID == nil || *group.ScopeID != groupID {
+		t.Fatalf("second scope = %s %v, want group %s", group.ScopeType, group.ScopeID, groupID)
+	}
+	var names []string
+	for _, p := range group.Permissions {
+		names = append(names, p.Permission)
+	}
+	if want := []string{"node:view", "policy:edit", "policy:view"}; !slices.Equal(names, want) {
+		t.Errorf("group permissions = %v, want %v", names, want)
+	}
+	view := group.Permissions[2]
+	if len(view.Grants) != 2 || view.Grants[0].BindingID != "b1" || view.Grants[1].ViaGroupID != "ug-1" {
+		t.Errorf("policy:view grants = %+v, want b1 and b3 via ug-1", view.Grants)
+	}
+}
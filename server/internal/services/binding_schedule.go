@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+const minutesPerWeek = 7 * 24 * 60
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// scheduleWindow is one recurring window: it opens at start minutes after
+// midnight on each of days and stays open for length minutes, possibly
+// past midnight.
+type scheduleWindow struct {
+	days   [7]bool
+	start  int
+	length int
+}
+
+// bindingSchedule is a parsed binding schedule: a set of weekly windows in
+// a time zone. A time is in the schedule when it is in any of the windows,
+// so overlapping windows simply merge.
+type bindingSchedule struct {
+	windows []scheduleWindow
+	loc     *time.Location
+}
+
+// parseBindingSchedule parses a schedule such as
+// "Mon-Fri 08:00-16:00; Sat 09:00-12:00". Windows are separated by ";".
+// Each is a comma-separated list of days or day ranges ("*" for every day)
+// followed by a time range; a range whose end is not after its start, such
+// as "22:00-06:00", runs past midnight into the next day, and "24:00" is
+// the end of the day. Times are wall-clock times in timezone, an IANA name
+// defaulting to UTC, so windows follow daylight saving time.
+func parseBindingSchedule(spec, timezone string) (*bindingSchedule, error) {
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule timezone %q", timezone)
+	}
+	s := &bindingSchedule{loc: loc}
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		w, err := parseScheduleWindow(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule window %q: %w", part, err)
+		}
+		s.windows = append(s.windows, w)
+	}
+	if len(s.windows) == 0 {
+		return nil, fmt.Errorf("schedule has no windows")
+	}
+	return s, nil
+}
+
+func parseScheduleWindow(spec string) (scheduleWindow, error) {
+	var w scheduleWindow
+	fields := strings.Fields(spec)
+	if len(fields) != 2 {
+		return w, fmt.Errorf("expected days and a time range")
+	}
+	for _, d := range strings.Split(fields[0], ",") {
+		if d == "*" {
+			for i := range w.days {
+				w.days[i] = true
+			}
+			continue
+		}
+		from, to, isRange := strings.Cut(d, "-")
+		first, ok := weekdayNames[strings.ToLower(from)]
+		if !ok {
+			return w, fmt.Errorf("unknown day %q", from)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekdayNames[strings.ToLower(to)]; !ok {
+				return w, fmt.Errorf("unknown day %q", to)
+			}
+		}
+		// Ranges may wrap around the end of the week, e.g. Fri-Mon.
+		for day := first; ; day = (day + 1) % 7 {
+			w.days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+
+	from, to, ok := strings.Cut(fields[1], "-")
+	if !ok {
+		return w, fmt.Errorf("expected a time range such as 08:00-16:00")
+	}
+	start, err := parseClock(from)
+	if err != nil || start == 24*60 {
+		return w, fmt.Errorf("invalid start time %q", from)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return w, fmt.Errorf("invalid end time %q", to)
+	}
+	w.start = start
+	w.length = end - start
+	if w.length <= 0 {
+		w.length += 24 * 60
+	}
+	return w, nil
+}
+
+// parseClock parses HH:MM into minutes after midnight; 24:00 is allowed.
+func parseClock(s string) (int, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil || len(s) != 5 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	if h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// active reports whether t falls in any of the schedule's windows.
+func (s *bindingSchedule) active(t time.Time) bool {
+	lt := t.In(s.loc)
+	now := int(lt.Weekday())*24*60 + lt.Hour()*60 + lt.Minute()
+	for _, w := range s.windows {
+		for day, on := range w.days {
+			if !on {
+				continue
+			}
+			start := day*24*60 + w.start
+			// A window opened late on Saturday may still be open early
+			// on Sunday, at the start of the week.
+			for _, m := range []int{now, now + minutesPerWeek} {
+				if m >= start && m < start+w.length {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// validateBindingWindow checks a binding's activity window.
+func validateBindingWindow(w *models.BindingWindow) error {
+	if w == nil {
+		return nil
+	}
+	if w.ActiveFrom != nil && w.ActiveTo != nil && !w.ActiveFrom.Before(*w.ActiveTo) {
+		return fmt.Errorf("active_from must be before active_to")
+	}
+	if w.Schedule == "" {
+		if w.Timezone != "" {
+			return fmt.Errorf("timezone requires a schedule")
+		}
+		return nil
+	}
+	_, err := parseBindingSchedule(w.Schedule, w.Timezone)
+	return err
+}
+
+// bindingInWindow reports whether a binding with activity window w is in
+// its window at t. Bindings without a window always are; an invalid
+// schedule, which validation prevents, never matches.
+func bindingInWindow(w *models.BindingWindow, t time.Time) bool {
+	if w == nil {
+		return true
+	}
+	if w.ActiveFrom != nil && t.Before(*w.ActiveFrom) {
+		return false
+	}
+	if w.ActiveTo != nil && !t.Before(*w.ActiveTo) {
+		return false
+	}
+	if w.Schedule == "" {
+		return true
+	}
+	s, err := parseBindingSchedule(w.Schedule, w.Timezone)
+	return err == nil && s.active(t)
+}
@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func mustSchedule(t *testing.T, spec, tz string) *bindingSchedule {
+	t.Helper()
+	s, err := parseBindingSchedule(spec, tz)
+	if err != nil {
+		t.Fatalf("parseBindingSchedule(%q, %q) error = %v", spec, tz, err)
+	}
+	return s
+}
+
+func TestParseBindingSchedule_Invalid(t *testing.T) {
+	for _, tc := range []struct{ spec, tz string }{
+		{"", ""},
+		{" ; ", ""},
+		{"Mon-Fri", ""},
+		{"Mon-Fri 08:00", ""},
+		{"Funday 08:00-16:00", ""},
+		{"Mon-Fri 8:00-16:00", ""},
+		{"Mon-Fri 08:00-24:01", ""},
+		{"Mon-Fri 24:00-08:00", ""},
+		{"Mon-Fri 08:60-16:00", ""},
+		{"Mon-Fri 08:00-16:00", "Mars/Olympus_Mons"},
+	} {
+		if _, err := parseBindingSchedule(tc.spec, tc.tz); err == nil {
+			t.Errorf("parseBindingSchedule(%q, %q) = nil error", tc.spec, tc.tz)
+		}
+	}
+}
+
+func TestBindingSchedule_Active(t *testing.T) {
+	// 2026-05-04 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 5, 4+day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name string
+		spec string
+		t    time.Time
+		want bool
+	}{
+		{"weekday inside", "Mon-Fri 08:00-16:00", at(2, 12, 0), true},
+		{"start is inclusive", "Mon-Fri 08:00-16:00", at(0, 8, 0), true},
+		{"end is exclusive", "Mon-Fri 08:00-16:00", at(0, 16, 0), false},
+		{"weekend", "Mon-Fri 08:00-16:00", at(5, 12, 0), false},
+		{"day list", "Mon,Wed 08:00-16:00", at(1, 12, 0), false},
+		{"every day", "* 08:00-16:00", at(6, 12, 0), true},
+		{"until end of day", "Mon 20:00-24:00", at(0, 23, 59), true},
+		{"past midnight, before", "Mon 22:00-06:00", at(1, 5, 59), true},
+		{"past midnight, after", "Mon 22:00-06:00", at(1, 6, 0), false},
+		{"past midnight, not started", "Mon 22:00-06:00", at(0, 5, 0), false},
+		{"Saturday night into Sunday", "Sat 22:00-06:00", at(6, 3, 0), true},
+		{"range wrapping the week", "Fri-Mon 08:00-16:00", at(6, 12, 0), true},
+		{"range wrapping the week, outside", "Fri-Mon 08:00-16:00", at(2, 12, 0), false},
+		{"lowercase days", "sat-sun 00:00-24:00", at(5, 0, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustSchedule(t, tt.spec, "").active(tt.t); got != tt.want {
+				t.Errorf("%q active at %s = %v, want %v", tt.spec, tt.t.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindingSchedule_OverlappingWindows(t *testing.T) {
+	// Two overlapping windows act as their union, 08:00-18:00 on Monday.
+	s := mustSchedule(t, "Mon 08:00-12:00; Mon-Tue 10:00-18:00", "")
+	for _, tc := range []struct {
+		hour int
+		want bool
+	}{{7, false}, {8, true}, {11, true}, {12, true}, {17, true}, {18, false}} {
+		got := s.active(time.Date(2026, 5, 4, tc.hour, 30, 0, 0, time.UTC))
+		if got != tc.want {
+			t.Errorf("active at Mon %02d:30 = %v, want %v", tc.hour, got, tc.want)
+		}
+	}
+	if !s.active(time.Date(2026, 5, 5, 11, 0, 0, 0, time.UTC)) {
+		t.Error("second window should cover Tuesday 11:00")
+	}
+}
+
+func TestBindingSchedule_Timezone(t *testing.T) {
+	s := mustSchedule(t, "Mon-Fri 08:00-16:00", "Europe/Sofia")
+
+	// Sofia is UTC+3 in summer: 08:00 local is 05:00 UTC.
+	summer := time.Date(2026, 7, 6, 5, 0, 0, 0, time.UTC)
+	if !s.active(summer) {
+		t.Error("05:00 UTC in July is 08:00 in Sofia and should be active")
+	}
+	if s.active(summer.Add(-time.Minute)) {
+		t.Error("04:59 UTC in July is 07:59 in Sofia and should be inactive")
+	}
+	// and UTC+2 in winter: 08:00 local is 06:00 UTC.
+	winter := time.Date(2026, 1, 5, 5, 30, 0, 0, time.UTC)
+	if s.active(winter) {
+		t.Error("05:30 UTC in January is 07:30 in Sofia and should be inactive")
+	}
+	if !s.active(winter.Add(30 * time.Minute)) {
+		t.Error("06:00 UTC in January is 08:00 in Sofia and should be active")
+	}
+
+	// Friday 22:00 UTC is already Saturday in Sofia.
+	utc := mustSchedule(t, "Fri 20:00-24:00", "")
+	sofia := mustSchedule(t, "Fri 20:00-24:00", "Europe/Sofia")
+	fri := time.Date(2026, 7, 10, 22, 0, 0, 0, time.UTC)
+	if !utc.active(fri) || sofia.active(fri) {
+		t.Errorf("Friday 22:00 UTC: UTC schedule active = %v, Sofia schedule active = %v", utc.active(fri), sofia.active(fri))
+	}
+}
+
+func TestBindingInWindow(t *testing.T) {
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	w := &models.BindingWindow{ActiveFrom: &from, ActiveTo: &to, Schedule: "Mon-Fri 08:00-16:00"}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"before range", time.Date(2026, 4, 27, 12, 0, 0, 0, time.UTC), false},
+		{"in range and schedule", time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC), true},
+		{"in range, out of schedule", time.Date(2026, 5, 4, 20, 0, 0, 0, time.UTC), false},
+		{"at end of range", time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := bindingInWindow(w, tt.t); got != tt.want {
+			t.Errorf("%s: bindingInWindow = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if !bindingInWindow(nil, from) || !bindingInWindow(&models.BindingWindow{}, from) {
+		t.Error("a binding without a window is always in it")
+	}
+}
+
+func TestValidateBindingWindow(t *testing.T) {
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+	for _, w := range []*models.BindingWindow{
+		{ActiveFrom: &from, ActiveTo: &to},
+		{ActiveFrom: &from, ActiveTo: &from},
+		{Timezone: "Europe/Sofia"},
+		{Schedule: "Mon-Fri"},
+	} {
+		if err := validateBindingWindow(w); err == nil {
+			t.Errorf("validateBindingWindow(%+v) = nil", w)
+		}
+	}
+	if err := validateBindingWindow(&models.BindingWindow{Schedule: "Mon-Fri 08:00-16:00", Timezone: "Europe/Sofia"}); err != nil {
+		t.Errorf("validateBindingWindow() error = %v", err)
+	}
+}
+
+type fakeBindingWindowStore struct {
+	bindings []*models.PolicyBinding
+	set      map[string]bool
+}
+
+func (f *fakeBindingWindowStore) ListWindowed(context.Context) ([]*models.PolicyBinding, error) {
+	out := make([]*models.PolicyBinding, len(f.bindings))
+	for i, b := range f.bindings {
+		c := *b
+		out[i] = &c
+	}
+	return out, nil
+}
+
+func (f *fakeBindingWindowStore) SetInWindow(_ context.Context, id string, inWindow bool) error {
+	f.set[id] = inWindow
+	for _, b := range f.bindings {
+		if b.ID == id {
+			b.InWindow = inWindow
+		}
+	}
+	return nil
+}
+
+func TestBindingWindowScheduler_Check(t *testing.T) {
+	store := &fakeBindingWindowStore{
+		bindings: []*models.PolicyBinding{
+			{ID: "lab", State: models.BindingStateEnabled, InWindow: true, Window: &models.BindingWindow{Schedule: "Mon-Fri 08:00-16:00"}},
+			{ID: "off", State: models.BindingStateDisabled, InWindow: true, Window: &models.BindingWindow{Schedule: "Mon-Fri 08:00-16:00"}},
+		},
+		set: map[string]bool{},
+	}
+	s := &bindingWindowScheduler{store: store}
+	ctx := context.Background()
+	mon := func(hour, minute int) time.Time { return time.Date(2026, 5, 4, hour, minute, 0, 0, time.UTC) }
+
+	// At 07:00 both are out of their window: the stored flags are fixed,
+	// and the enabled binding is reported.
+	changed, err := s.check(ctx, mon(7, 0))
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if len(changed) != 1 || changed[0].ID != "lab" || changed[0].InWindow {
+		t.Fatalf("changed = %+v, want lab leaving its window", changed)
+	}
+	if store.set["lab"] || store.set["off"] || len(store.set) != 2 {
+		t.Errorf("set = %v, want both out of window", store.set)
+	}
+
+	// Nothing changes within the same state.
+	if changed, _ := s.check(ctx, mon(7, 30)); len(changed) != 0 {
+		t.Errorf("changed = %+v, want none", changed)
+	}
+
+	changed, _ = s.check(ctx, mon(8, 0))
+	if len(changed) != 1 || !changed[0].InWindow {
+		t.Errorf("changed = %+v, want lab entering its window", changed)
+	}
+}
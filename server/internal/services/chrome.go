@@ -24,5 +24,25 @@ func ValidateChromeContent(content string) error {
 	if err := opts.Unmarshal([]byte(content), &pol); err != nil {
 		return fmt.Errorf("invalid Chrome policy: %w", err)
 	}
+	return validateChromeRecommended(&pol)
+}
+
+// validateChromeRecommended checks that every name listed in
+// BorRecommendedPolicies is a Chrome policy set in the same policy.
+func validateChromeRecommended(pol *pb.ChromePolicy) error {
+	m := pol.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	for _, name := range pol.GetBorRecommendedPolicies() {
+		fd := fields.ByJSONName(name)
+		if fd == nil || fd.Number() == chromeRecommendedField {
+			return fmt.Errorf("invalid Chrome policy: unknown recommended policy %q", name)
+		}
+		if !m.Has(fd) {
+			return fmt.Errorf("invalid Chrome policy: recommended policy %q is not set", name)
+		}
+	}
 	return nil
 }
+
+// chromeRecommendedField is the field number of BorRecommendedPolicies.
+const chromeRecommendedField = 1000
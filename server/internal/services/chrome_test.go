@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateChromeContent_Recommended(t *testing.T) {
+	content := `{
+		"HomepageLocation": "https://intranet.example.com",
+		"BookmarkBarEnabled": true,
+		"BorRecommendedPolicies": ["HomepageLocation"]
+	}`
+	if err := ValidateChromeContent(content); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateChromeContent_RecommendedNotSet(t *testing.T) {
+	content := `{"BookmarkBarEnabled": true, "BorRecommendedPolicies": ["HomepageLocation"]}`
+	err := ValidateChromeContent(content)
+	if err == nil || !strings.Contains(err.Error(), `recommended policy "HomepageLocation" is not set`) {
+		t.Fatalf("error = %v, want not-set error", err)
+	}
+}
+
+func TestValidateChromeContent_RecommendedUnknown(t *testing.T) {
+	for _, name := range []string{"NoSuchPolicy", "BorRecommendedPolicies"} {
+		content := `{"BookmarkBarEnabled": true, "BorRecommendedPolicies": ["` + name + `"]}`
+		err := ValidateChromeContent(content)
+		if err == nil || !strings.Contains(err.Error(), "unknown recommended policy") {
+			t.Errorf("%s: error = %v, want unknown-policy error", name, err)
+		}
+	}
+}
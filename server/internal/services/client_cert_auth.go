@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+)
+
+// ClientCertAuth maps TLS client certificates to service-account users so
+// API clients can authenticate to the REST API without a bearer token.
+//
+// Certificates are verified against their own CA pool, never the agent CA:
+// agents choose their certificate subject at enrollment, so a CN signed by
+// the agent CA does not prove anything about the caller.
+type ClientCertAuth struct {
+	roots *x509.CertPool
+	users map[string]string // certificate CN → username
+}
+
+// NewClientCertAuth returns a ClientCertAuth accepting certificates issued
+// by roots whose CN is a key of users.
+func NewClientCertAuth(roots *x509.CertPool, users map[string]string) *ClientCertAuth {
+	return &ClientCertAuth{roots: roots, users: users}
+}
+
+// Username verifies the peer certificate chain and returns the username the
+// leaf certificate's CN is mapped to.
+func (c *ClientCertAuth) Username(chain []*x509.Certificate) (string, error) {
+	if len(chain) == 0 {
+		return "", fmt.Errorf("no client certificate")
+	}
+	leaf := chain[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         c.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return "", fmt.Errorf("client certificate not trusted: %w", err)
+	}
+	username, ok := c.users[leaf.Subject.CommonName]
+	if !ok {
+		return "", fmt.Errorf("client certificate CN %q is not mapped to a user", leaf.Subject.CommonName)
+	}
+	return username, nil
+}
+
+// WithClientCertAuth enables authentication with TLS client certificates.
+func (s *AuthService) WithClientCertAuth(c *ClientCertAuth) *AuthService {
+	s.clientCertAuth = c
+	return s
+}
+
+// ClientCertAuthEnabled reports whether client certificates are accepted.
+func (s *AuthService) ClientCertAuthEnabled() bool {
+	return s.clientCertAuth != nil
+}
+
+// AuthenticateClientCert returns the claims of the enabled user the peer
+// certificate chain is mapped to.
+func (s *AuthService) AuthenticateClientCert(ctx context.Context, chain []*x509.Certificate) (*Claims, error) {
+	if s.clientCertAuth == nil {
+		return nil, fmt.Errorf("client certificate authentication is disabled")
+	}
+	username, err := s.clientCertAuth.Username(chain)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.Enabled {
+		return nil, fmt.Errorf("user %q for client certificate not found or disabled", username)
+	}
+	return &Claims{UserID: user.ID, Username: user.Username}, nil
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// issueTestClientCert signs a leaf certificate with the given CA.
+func issueTestClientCert(t *testing.T, caCert *x509.Certificate, caKey crypto.Signer, cn string, usage x509.ExtKeyUsage) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, key.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestClientCertAuth_Username(t *testing.T) {
+	apiCACert, apiCAKey := newTestCA(t)
+	agentCACert, agentCAKey := newTestCA(t)
+	roots := x509.NewCertPool()
+	roots.AddCert(apiCACert)
+	auth := NewClientCertAuth(roots, map[string]string{"ci-pipeline": "svc-ci"})
+	issue := func(caCert *x509.Certificate, caKey crypto.Signer, cn string, usage x509.ExtKeyUsage) []*x509.Certificate {
+		return []*x509.Certificate{issueTestClientCert(t, caCert, caKey, cn, usage)}
+	}
+
+	tests := []struct {
+		name     string
+		chain    []*x509.Certificate
+		wantUser string
+		wantErr  string
+	}{
+		{"mapped", issue(apiCACert, apiCAKey, "ci-pipeline", x509.ExtKeyUsageClientAuth), "svc-ci", ""},
+		{"unmapped CN", issue(apiCACert, apiCAKey, "someone", x509.ExtKeyUsageClientAuth), "", "not mapped"},
+		{"agent CA", issue(agentCACert, agentCAKey, "ci-pipeline", x509.ExtKeyUsageClientAuth), "", "not trusted"},
+		{"server cert", issue(apiCACert, apiCAKey, "ci-pipeline", x509.ExtKeyUsageServerAuth), "", "not trusted"},
+		{"no cert", nil, "", "no client certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := auth.Username(tt.chain)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Username() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Username() error = %v", err)
+			}
+			if user != tt.wantUser {
+				t.Errorf("Username() = %q, want %q", user, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestAuthService_AuthenticateClientCert_Disabled(t *testing.T) {
+	authSvc := &AuthService{}
+	if authSvc.ClientCertAuthEnabled() {
+		t.Fatal("client certificate authentication should be disabled by default")
+	}
+	if _, err := authSvc.AuthenticateClientCert(t.Context(), nil); err == nil {
+		t.Fatal("AuthenticateClientCert() should fail when disabled")
+	}
+}
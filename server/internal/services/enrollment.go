@@ -10,7 +10,10 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"log"
+	"math/big"
 	"sync"
 	"time"
 
@@ -21,17 +24,35 @@ import (
 
 const enrollmentTokenTTL = 5 * time.Minute
 
+// MaxEnrollmentTokenTTL bounds how long an enrollment token may remain
+// valid.
+const MaxEnrollmentTokenTTL = 7 * 24 * time.Hour
+
+// MaxEnrollmentTokenUses bounds the number of enrollments a single token
+// may be used for.
+const MaxEnrollmentTokenUses = 10000
+
+// ErrEnrollmentTokenExhausted is returned by RedeemToken for a token whose
+// uses have all been consumed.
+var ErrEnrollmentTokenExhausted = errors.New("enrollment token has no uses left")
+
+// ErrEnrollmentTokenNotFound is returned by RevokeToken for a token that
+// does not exist, has expired or belongs to another node group.
+var ErrEnrollmentTokenNotFound = errors.New("enrollment token not found")
+
 // EnrollmentService manages enrollment tokens and agent certificate signing.
 type EnrollmentService struct {
 	mu     sync.Mutex
 	tokens map[string]*models.EnrollmentToken
 
-	caCert *x509.Certificate
-	caKey  crypto.Signer
+	caCert   *x509.Certificate
+	caKey    crypto.Signer
+	certOpts pki.CertOptions
 
 	nodeGroupSvc *NodeGroupService
 	nodeSvc      *NodeService
 	revokeRepo   *database.RevocationRepository
+	campaignRepo *database.EnrollmentCampaignRepository // nil disables campaigns
 }
 
 // NewEnrollmentService creates a new EnrollmentService.
@@ -46,25 +67,84 @@ func NewEnrollmentService(caCert *x509.Certificate, caKey crypto.Signer, nodeGro
 	}
 }
 
+// SetCertOptions sets the options for agent certificates signed at
+// enrollment and renewal. The zero value uses the pki defaults.
+func (s *EnrollmentService) SetCertOptions(opts pki.CertOptions) {
+	s.certOpts = opts
+}
+
+// SetCampaignRepository enables enrollment campaigns: tokens issued under a
+// campaign count their enrollments against its limit.
+func (s *EnrollmentService) SetCampaignRepository(repo *database.EnrollmentCampaignRepository) {
+	s.campaignRepo = repo
+}
+
 // CreateToken generates a short-lived, single-use enrollment token for a node group.
 func (s *EnrollmentService) CreateToken(nodeGroupID string) (*models.EnrollmentToken, error) {
+	return s.CreateMultiUseToken(nodeGroupID, 1, 0)
+}
+
+// CreateMultiUseToken generates an enrollment token for a node group that
+// enrolls up to maxUses machines, e.g. from one disk image. The token
+// expires after ttl, or after five minutes if ttl is zero, whether or not
+// its uses are consumed by then.
+func (s *EnrollmentService) CreateMultiUseToken(nodeGroupID string, maxUses int, ttl time.Duration) (*models.EnrollmentToken, error) {
 	if nodeGroupID == "" {
 		return nil, fmt.Errorf("node_group_id is required")
 	}
+	if maxUses < 1 || maxUses > MaxEnrollmentTokenUses {
+		return nil, fmt.Errorf("max_uses must be between 1 and %d", MaxEnrollmentTokenUses)
+	}
+	if ttl == 0 {
+		ttl = enrollmentTokenTTL
+	}
+	if ttl < time.Second || ttl > MaxEnrollmentTokenTTL {
+		return nil, fmt.Errorf("ttl_seconds must be between 1 and %d", int(MaxEnrollmentTokenTTL.Seconds()))
+	}
+	return s.issueToken(nodeGroupID, "", ttl, maxUses)
+}
+
+// CreateCampaignToken generates a single-use enrollment token under a
+// campaign. The token targets the campaign's node group and lives for the
+// campaign's token TTL. No token is issued once the campaign is full.
+func (s *EnrollmentService) CreateCampaignToken(campaign *models.EnrollmentCampaign) (*models.EnrollmentToken, error) {
+	if campaign.MaxEnrollments > 0 && campaign.EnrolledCount >= campaign.MaxEnrollments {
+		return nil, fmt.Errorf("enrollment campaign limit reached")
+	}
+	ttl := time.Duration(campaign.TokenTTLSeconds) * time.Second
+	if ttl <= 0 {
+		ttl = enrollmentTokenTTL
+	}
+	return s.issueToken(campaign.NodeGroupID, campaign.ID, ttl, 1)
+}
 
+func (s *EnrollmentService) issueToken(nodeGroupID, campaignID string, ttl time.Duration, maxUses int) (*models.EnrollmentToken, error) {
 	b := make([]byte, 32)
 	if _, err := rand.Read(b); err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate token ID: %w", err)
+	}
 
 	token := &models.EnrollmentToken{
+		ID:          hex.EncodeToString(id),
 		Token:       hex.EncodeToString(b),
 		NodeGroupID: nodeGroupID,
-		ExpiresAt:   time.Now().Add(enrollmentTokenTTL),
-		Used:        false,
+		CampaignID:  campaignID,
+		ExpiresAt:   time.Now().Add(ttl),
+		MaxUses:     maxUses,
 	}
 
 	s.mu.Lock()
+	// Exhausted tokens are kept until they expire; drop expired ones here.
+	now := time.Now()
+	for k, t := range s.tokens {
+		if now.After(t.ExpiresAt) {
+			delete(s.tokens, k)
+		}
+	}
 	s.tokens[token.Token] = token
 	s.mu.Unlock()
 
@@ -74,31 +154,96 @@ func (s *EnrollmentService) CreateToken(nodeGroupID string) (*models.EnrollmentT
 // ConsumeToken validates and consumes an enrollment token. Returns the
 // associated node group ID on success.
 func (s *EnrollmentService) ConsumeToken(tokenStr string) (string, error) {
+	token, err := s.RedeemToken(tokenStr)
+	if err != nil {
+		return "", err
+	}
+	return token.NodeGroupID, nil
+}
+
+// RedeemToken validates and consumes one use of an enrollment token and
+// returns a copy of it, including the campaign it was issued under, if any.
+// Once all uses are consumed it fails with ErrEnrollmentTokenExhausted
+// until the token expires.
+func (s *EnrollmentService) RedeemToken(tokenStr string) (*models.EnrollmentToken, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	token, ok := s.tokens[tokenStr]
 	if !ok {
-		return "", fmt.Errorf("invalid enrollment token")
-	}
-	if token.Used {
-		return "", fmt.Errorf("enrollment token already used")
+		return nil, fmt.Errorf("invalid enrollment token")
 	}
 	if time.Now().After(token.ExpiresAt) {
 		delete(s.tokens, tokenStr)
-		return "", fmt.Errorf("enrollment token expired")
+		return nil, fmt.Errorf("enrollment token expired")
+	}
+	if token.RevokedAt != nil {
+		return nil, fmt.Errorf("enrollment token revoked")
+	}
+	if token.UseCount >= token.MaxUses {
+		return nil, ErrEnrollmentTokenExhausted
 	}
 
-	token.Used = true
-	delete(s.tokens, tokenStr)
+	token.UseCount++
+	token.Used = token.UseCount >= token.MaxUses
 
-	return token.NodeGroupID, nil
+	redeemed := *token
+	return &redeemed, nil
+}
+
+// RevokeToken makes an unexpired enrollment token of a node group unusable
+// at once, e.g. when it leaked before use. The token is looked up by its ID
+// so the secret itself need not be sent again. It is kept until it expires
+// so that enrollment attempts with it are reported as revoked. Revoking a
+// token twice keeps the first revocation.
+func (s *EnrollmentService) RevokeToken(nodeGroupID, tokenID, revokedBy string) (*models.EnrollmentToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var token *models.EnrollmentToken
+	for _, t := range s.tokens {
+		if t.ID == tokenID && t.NodeGroupID == nodeGroupID {
+			token = t
+			break
+		}
+	}
+	if token == nil {
+		return nil, ErrEnrollmentTokenNotFound
+	}
+	now := time.Now()
+	if now.After(token.ExpiresAt) {
+		delete(s.tokens, token.Token)
+		return nil, ErrEnrollmentTokenNotFound
+	}
+	if token.RevokedAt == nil {
+		token.RevokedAt = &now
+		token.RevokedBy = revokedBy
+	}
+
+	revoked := *token
+	return &revoked, nil
+}
+
+// ReserveCampaignEnrollment counts one enrollment against a campaign and
+// fails if the campaign has reached its limit or no longer exists.
+func (s *EnrollmentService) ReserveCampaignEnrollment(ctx context.Context, campaignID string) error {
+	if s.campaignRepo == nil {
+		return fmt.Errorf("enrollment campaigns are not enabled")
+	}
+	ok, err := s.campaignRepo.ReserveEnrollment(ctx, campaignID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("enrollment campaign limit reached")
+	}
+	return nil
 }
 
 // SignCSR signs a PEM-encoded certificate signing request with the internal CA.
 // Returns the signed cert PEM, serial hex, and notAfter time.
 func (s *EnrollmentService) SignCSR(csrPEM []byte) (certPEM []byte, serial string, notAfter time.Time, err error) {
-	return pki.SignCSR(csrPEM, s.caCert, s.caKey)
+	return pki.SignCSR(csrPEM, s.caCert, s.caKey, s.certOpts)
 }
 
 // SetNodeCertificate persists the cert serial and notAfter for an enrolled node.
@@ -109,7 +254,7 @@ func (s *EnrollmentService) SetNodeCertificate(ctx context.Context, nodeID, seri
 // RenewCertificate signs a new CSR for an existing node (cert renewal).
 // It replaces the node's cert record and clears any prior revocation for that node.
 func (s *EnrollmentService) RenewCertificate(ctx context.Context, nodeID string, csrPEM []byte) ([]byte, error) {
-	certPEM, serial, notAfter, err := pki.SignCSR(csrPEM, s.caCert, s.caKey)
+	certPEM, serial, notAfter, err := pki.SignCSR(csrPEM, s.caCert, s.caKey, s.certOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign renewal CSR: %w", err)
 	}
@@ -120,20 +265,48 @@ func (s *EnrollmentService) RenewCertificate(ctx context.Context, nodeID string,
 	if err := s.revokeRepo.DeleteByNodeID(ctx, nodeID); err != nil {
 		return nil, fmt.Errorf("failed to clear revocations: %w", err)
 	}
+	s.nodeSvc.revoked()
 	return certPEM, nil
 }
 
 // RevokeCertificate revokes the certificate of a node by serial number.
 func (s *EnrollmentService) RevokeCertificate(ctx context.Context, nodeID, serial, reason string) error {
-	return s.revokeRepo.Revoke(ctx, nodeID, serial, reason)
+	if err := s.revokeRepo.Revoke(ctx, nodeID, serial, reason); err != nil {
+		return err
+	}
+	s.nodeSvc.revoked()
+	return nil
+}
+
+// CRL returns the PEM-encoded certificate revocation list of the internal
+// CA, listing every revoked agent certificate.
+func (s *EnrollmentService) CRL(ctx context.Context) ([]byte, error) {
+	serials, err := s.revokeRepo.ListSerials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	revoked := make([]*big.Int, 0, len(serials))
+	for _, serial := range serials {
+		n, ok := new(big.Int).SetString(serial, 16)
+		if !ok {
+			log.Printf("Skipping malformed revoked serial %q in CRL", serial)
+			continue
+		}
+		revoked = append(revoked, n)
+	}
+	return pki.GenerateCRL(s.caCert, s.caKey, revoked)
 }
 
 // CreateNodeOnEnroll creates a Node record in the database for a newly
-// enrolled agent.
-func (s *EnrollmentService) CreateNodeOnEnroll(ctx context.Context, nodeName, nodeGroupID string) (string, error) {
+// enrolled agent. campaignID records the enrollment campaign the agent
+// enrolled through and may be empty.
+func (s *EnrollmentService) CreateNodeOnEnroll(ctx context.Context, nodeName, nodeGroupID, campaignID string) (string, error) {
 	node := &models.Node{
 		Name: nodeName,
 	}
+	if campaignID != "" {
+		node.CampaignID = &campaignID
+	}
 	if err := s.nodeSvc.CreateNode(ctx, node); err != nil {
 		return "", fmt.Errorf("failed to create node: %w", err)
 	}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// maxCampaignTokenTTLSeconds bounds how long a campaign's enrollment tokens
+// may remain valid.
+const maxCampaignTokenTTLSeconds = int(MaxEnrollmentTokenTTL / time.Second)
+
+// EnrollmentCampaignService handles enrollment campaign business logic
+type EnrollmentCampaignService struct {
+	repo         *database.EnrollmentCampaignRepository
+	nodeGroupSvc *NodeGroupService
+	enrollSvc    *EnrollmentService
+}
+
+// NewEnrollmentCampaignService creates a new EnrollmentCampaignService
+func NewEnrollmentCampaignService(repo *database.EnrollmentCampaignRepository, nodeGroupSvc *NodeGroupService, enrollSvc *EnrollmentService) *EnrollmentCampaignService {
+	return &EnrollmentCampaignService{repo: repo, nodeGroupSvc: nodeGroupSvc, enrollSvc: enrollSvc}
+}
+
+// CreateCampaign creates a new enrollment campaign
+func (s *EnrollmentCampaignService) CreateCampaign(ctx context.Context, req *models.CreateEnrollmentCampaignRequest, createdBy string) (*models.EnrollmentCampaign, error) {
+	if req.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if req.NodeGroupID == "" {
+		return nil, fmt.Errorf("node_group_id is required")
+	}
+	ttl := req.TokenTTLSeconds
+	if ttl == 0 {
+		ttl = int(enrollmentTokenTTL.Seconds())
+	}
+	if err := validateCampaignLimits(ttl, req.MaxEnrollments); err != nil {
+		return nil, err
+	}
+
+	group, err := s.nodeGroupSvc.GetNodeGroup(ctx, req.NodeGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up node group: %w", err)
+	}
+	if group == nil {
+		return nil, fmt.Errorf("node group not found")
+	}
+
+	c := &models.EnrollmentCampaign{
+		Name:            req.Name,
+		Description:     req.Description,
+		NodeGroupID:     req.NodeGroupID,
+		TokenTTLSeconds: ttl,
+		MaxEnrollments:  req.MaxEnrollments,
+		CreatedBy:       createdBy,
+	}
+	if err := s.repo.Create(ctx, c); err != nil {
+		return nil, fmt.Errorf("failed to create enrollment campaign: %w", err)
+	}
+	return c, nil
+}
+
+// GetCampaign retrieves an enrollment campaign by ID
+func (s *EnrollmentCampaignService) GetCampaign(ctx context.Context, id string) (*models.EnrollmentCampaign, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListCampaigns returns all enrollment campaigns
+func (s *EnrollmentCampaignService) ListCampaigns(ctx context.Context) ([]*models.EnrollmentCampaign, error) {
+	return s.repo.ListAll(ctx)
+}
+
+// UpdateCampaign updates an enrollment campaign
+func (s *EnrollmentCampaignService) UpdateCampaign(ctx context.Context, id string, req *models.UpdateEnrollmentCampaignRequest) (*models.EnrollmentCampaign, error) {
+	if req.Name != nil && *req.Name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	ttl, limit := 1, 0
+	if req.TokenTTLSeconds != nil {
+		ttl = *req.TokenTTLSeconds
+	}
+	if req.MaxEnrollments != nil {
+		limit = *req.MaxEnrollments
+	}
+	if err := validateCampaignLimits(ttl, limit); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, id, req); err != nil {
+		return nil, fmt.Errorf("failed to update enrollment campaign: %w", err)
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// DeleteCampaign deletes an enrollment campaign
+func (s *EnrollmentCampaignService) DeleteCampaign(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// CreateToken issues an enrollment token under the campaign. It returns nil
+// if the campaign does not exist.
+func (s *EnrollmentCampaignService) CreateToken(ctx context.Context, id string) (*models.EnrollmentToken, error) {
+	c, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, nil
+	}
+	return s.enrollSvc.CreateCampaignToken(c)
+}
+
+func validateCampaignLimits(ttlSeconds, maxEnrollments int) error {
+	if ttlSeconds <= 0 || ttlSeconds > maxCampaignTokenTTLSeconds {
+		return fmt.Errorf("token_ttl_seconds must be between 1 and %d", maxCampaignTokenTTLSeconds)
+	}
+	if maxEnrollments < 0 {
+		return fmt.Errorf("max_enrollments cannot be negative")
+	}
+	return nil
+}
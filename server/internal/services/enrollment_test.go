@@ -12,16 +12,19 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/VuteTech/Bor/server/internal/models"
 	"github.com/VuteTech/Bor/server/internal/pki"
 )
 
 func newTestCA(t *testing.T) (*x509.Certificate, crypto.Signer) {
 	t.Helper()
 	dir := t.TempDir()
-	certPath, keyPath, err := pki.EnsureCA(dir)
+	certPath, keyPath, err := pki.EnsureCA(dir, pki.CertOptions{})
 	if err != nil {
 		t.Fatalf("EnsureCA() error = %v", err)
 	}
@@ -82,6 +85,148 @@ func TestEnrollmentService_ConsumeToken(t *testing.T) {
 	}
 }
 
+func TestEnrollmentService_CreateMultiUseToken(t *testing.T) {
+	caCert, caKey := newTestCA(t)
+	svc := NewEnrollmentService(caCert, caKey, nil, nil, nil)
+
+	token, err := svc.CreateMultiUseToken("group-1", 3, 0)
+	if err != nil {
+		t.Fatalf("CreateMultiUseToken() error = %v", err)
+	}
+	if token.MaxUses != 3 {
+		t.Errorf("MaxUses = %d, want 3", token.MaxUses)
+	}
+
+	for i := 1; i <= 3; i++ {
+		redeemed, err := svc.RedeemToken(token.Token)
+		if err != nil {
+			t.Fatalf("RedeemToken() #%d error = %v", i, err)
+		}
+		if redeemed.UseCount != i || redeemed.Used != (i == 3) {
+			t.Errorf("redeem #%d: UseCount = %d, Used = %v", i, redeemed.UseCount, redeemed.Used)
+		}
+	}
+
+	if _, err := svc.RedeemToken(token.Token); !errors.Is(err, ErrEnrollmentTokenExhausted) {
+		t.Errorf("RedeemToken() after last use error = %v, want ErrEnrollmentTokenExhausted", err)
+	}
+}
+
+func TestEnrollmentService_CreateMultiUseToken_InvalidUses(t *testing.T) {
+	caCert, caKey := newTestCA(t)
+	svc := NewEnrollmentService(caCert, caKey, nil, nil, nil)
+
+	for _, n := range []int{-1, 0, MaxEnrollmentTokenUses + 1} {
+		if _, err := svc.CreateMultiUseToken("group-1", n, 0); err == nil {
+			t.Errorf("CreateMultiUseToken(%d) should return error", n)
+		}
+	}
+}
+
+func TestEnrollmentService_CreateMultiUseToken_TTL(t *testing.T) {
+	caCert, caKey := newTestCA(t)
+	svc := NewEnrollmentService(caCert, caKey, nil, nil, nil)
+
+	for _, ttl := range []time.Duration{-time.Second, time.Millisecond, MaxEnrollmentTokenTTL + time.Second} {
+		if _, err := svc.CreateMultiUseToken("group-1", 3, ttl); err == nil {
+			t.Errorf("CreateMultiUseToken(ttl %v) should return error", ttl)
+		}
+	}
+
+	token, err := svc.CreateMultiUseToken("group-1", 3, time.Hour)
+	if err != nil {
+		t.Fatalf("CreateMultiUseToken() error = %v", err)
+	}
+	if d := time.Until(token.ExpiresAt); d <= 59*time.Minute || d > time.Hour {
+		t.Errorf("token expires in %v, want 1h", d)
+	}
+	if _, err := svc.RedeemToken(token.Token); err != nil {
+		t.Fatalf("RedeemToken() error = %v", err)
+	}
+
+	// The token expires by time even though uses are left.
+	svc.mu.Lock()
+	svc.tokens[token.Token].ExpiresAt = time.Now().Add(-time.Second)
+	svc.mu.Unlock()
+	if _, err := svc.RedeemToken(token.Token); err == nil || errors.Is(err, ErrEnrollmentTokenExhausted) {
+		t.Errorf("RedeemToken() after expiry error = %v, want expired", err)
+	}
+}
+
+func TestEnrollmentService_RedeemToken_Concurrent(t *testing.T) {
+	caCert, caKey := newTestCA(t)
+	svc := NewEnrollmentService(caCert, caKey, nil, nil, nil)
+
+	const maxUses = 5
+	token, _ := svc.CreateMultiUseToken("group-1", maxUses, 0)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for range 4 * maxUses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := svc.RedeemToken(token.Token); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != maxUses {
+		t.Errorf("%d redemptions succeeded, want %d", succeeded, maxUses)
+	}
+}
+
+func TestEnrollmentService_RevokeToken(t *testing.T) {
+	caCert, caKey := newTestCA(t)
+	svc := NewEnrollmentService(caCert, caKey, nil, nil, nil)
+
+	token, _ := svc.CreateMultiUseToken("group-1", 2, 0)
+	if _, err := svc.RedeemToken(token.Token); err != nil {
+		t.Fatalf("RedeemToken() error = %v", err)
+	}
+
+	// The secret itself does not identify the token for revocation.
+	if _, err := svc.RevokeToken("group-1", token.Token, "admin"); !errors.Is(err, ErrEnrollmentTokenNotFound) {
+		t.Errorf("RevokeToken() by secret error = %v, want ErrEnrollmentTokenNotFound", err)
+	}
+
+	if _, err := svc.RevokeToken("group-2", token.ID, "admin"); !errors.Is(err, ErrEnrollmentTokenNotFound) {
+		t.Errorf("RevokeToken() for another group error = %v, want ErrEnrollmentTokenNotFound", err)
+	}
+
+	revoked, err := svc.RevokeToken("group-1", token.ID, "admin")
+	if err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if revoked.RevokedAt == nil || revoked.RevokedBy != "admin" || revoked.UseCount != 1 {
+		t.Errorf("revoked token = %+v, want revoked by admin after 1 use", revoked)
+	}
+
+	// A second revocation keeps the first one.
+	again, err := svc.RevokeToken("group-1", token.ID, "other")
+	if err != nil {
+		t.Fatalf("second RevokeToken() error = %v", err)
+	}
+	if again.RevokedBy != "admin" {
+		t.Errorf("RevokedBy = %q after second revocation, want %q", again.RevokedBy, "admin")
+	}
+
+	if _, err := svc.RedeemToken(token.Token); err == nil {
+		t.Error("RedeemToken() should fail for a revoked token")
+	}
+
+	if _, err := svc.RevokeToken("group-1", "nonexistent-token", "admin"); !errors.Is(err, ErrEnrollmentTokenNotFound) {
+		t.Errorf("RevokeToken() for unknown token error = %v, want ErrEnrollmentTokenNotFound", err)
+	}
+}
+
 func TestEnrollmentService_ConsumeToken_Invalid(t *testing.T) {
 	caCert, caKey := newTestCA(t)
 	svc := NewEnrollmentService(caCert, caKey, nil, nil, nil)
@@ -92,6 +237,65 @@ func TestEnrollmentService_ConsumeToken_Invalid(t *testing.T) {
 	}
 }
 
+func TestEnrollmentService_CreateCampaignToken(t *testing.T) {
+	caCert, caKey := newTestCA(t)
+	svc := NewEnrollmentService(caCert, caKey, nil, nil, nil)
+
+	campaign := &models.EnrollmentCampaign{
+		ID:              "campaign-1",
+		NodeGroupID:     "group-1",
+		TokenTTLSeconds: 3600,
+		MaxEnrollments:  2,
+		EnrolledCount:   1,
+	}
+	token, err := svc.CreateCampaignToken(campaign)
+	if err != nil {
+		t.Fatalf("CreateCampaignToken() error = %v", err)
+	}
+	if token.CampaignID != "campaign-1" || token.NodeGroupID != "group-1" {
+		t.Errorf("token = %+v, want campaign-1/group-1", token)
+	}
+	if d := time.Until(token.ExpiresAt); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("token expires in %v, want ~1h", d)
+	}
+
+	redeemed, err := svc.RedeemToken(token.Token)
+	if err != nil {
+		t.Fatalf("RedeemToken() error = %v", err)
+	}
+	if redeemed.CampaignID != "campaign-1" {
+		t.Errorf("CampaignID = %q, want %q", redeemed.CampaignID, "campaign-1")
+	}
+
+	campaign.EnrolledCount = 2
+	if _, err := svc.CreateCampaignToken(campaign); err == nil {
+		t.Error("CreateCampaignToken() should fail once the campaign is full")
+	}
+}
+
+func TestValidateCampaignLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     int
+		max     int
+		wantErr bool
+	}{
+		{"defaults", 300, 0, false},
+		{"capped", 3600, 50, false},
+		{"zero ttl", 0, 0, true},
+		{"ttl too long", maxCampaignTokenTTLSeconds + 1, 0, true},
+		{"negative max", 300, -1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCampaignLimits(tt.ttl, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCampaignLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestEnrollmentService_SignCSR(t *testing.T) {
 	caCert, caKey := newTestCA(t)
 	svc := NewEnrollmentService(caCert, caKey, nil, nil, nil)
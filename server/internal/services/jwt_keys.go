@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported JWT signing algorithms.
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+)
+
+// JWTKeys holds the key material used to sign and verify JWTs.
+//
+// With HS256, tokens are signed with the current secret and verified
+// against it and any previous secrets, so the secret can be rotated without
+// logging every user out: move the old secret to the previous list, wait for
+// the refresh lifetime to pass, then drop it. RS256 works the same way with
+// a private key for signing and the public keys of earlier key pairs kept
+// for verification.
+type JWTKeys struct {
+	method     jwt.SigningMethod
+	signingKey interface{}
+	keyID      string // RS256 only
+	verifyKeys []jwt.VerificationKey
+	publicKeys []*rsa.PublicKey // RS256 only, current key first
+}
+
+// NewHMACJWTKeys returns HS256 keys that sign with secret and also accept
+// tokens signed with any of previous.
+func NewHMACJWTKeys(secret string, previous []string) *JWTKeys {
+	k := &JWTKeys{
+		method:     jwt.SigningMethodHS256,
+		signingKey: []byte(secret),
+		verifyKeys: []jwt.VerificationKey{[]byte(secret)},
+	}
+	for _, p := range previous {
+		if p != "" && p != secret {
+			k.verifyKeys = append(k.verifyKeys, []byte(p))
+		}
+	}
+	return k
+}
+
+// NewRSAJWTKeys returns RS256 keys that sign with the PEM-encoded private
+// key and also accept tokens signed by the private keys of the PEM-encoded
+// previous public keys.
+func NewRSAJWTKeys(privateKeyPEM []byte, previousPublicKeysPEM [][]byte) (*JWTKeys, error) {
+	priv, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT private key: %w", err)
+	}
+	keyID, err := rsaKeyID(&priv.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	k := &JWTKeys{
+		method:     jwt.SigningMethodRS256,
+		signingKey: priv,
+		keyID:      keyID,
+		verifyKeys: []jwt.VerificationKey{&priv.PublicKey},
+		publicKeys: []*rsa.PublicKey{&priv.PublicKey},
+	}
+	for i, p := range previousPublicKeysPEM {
+		pub, err := jwt.ParseRSAPublicKeyFromPEM(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse previous JWT public key %d: %w", i+1, err)
+		}
+		k.verifyKeys = append(k.verifyKeys, pub)
+		k.publicKeys = append(k.publicKeys, pub)
+	}
+	return k, nil
+}
+
+// Algorithm returns the JWT "alg" value tokens are signed with.
+func (k *JWTKeys) Algorithm() string {
+	return k.method.Alg()
+}
+
+// sign signs claims with the current key.
+func (k *JWTKeys) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	if k.keyID != "" {
+		token.Header["kid"] = k.keyID
+	}
+	return token.SignedString(k.signingKey)
+}
+
+// keyfunc returns the verification keys for a token, rejecting any token
+// not signed with the configured algorithm.
+func (k *JWTKeys) keyfunc(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return jwt.VerificationKeySet{Keys: k.verifyKeys}, nil
+}
+
+// JWK is a JSON Web Key (RFC 7517) describing an RSA public key.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JWKSet is a JSON Web Key Set (RFC 7517).
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKeys returns the RS256 verification keys as a JWK set, current key
+// first, or nil for HS256 where there is no public key to share.
+func (k *JWTKeys) PublicKeys() *JWKSet {
+	if len(k.publicKeys) == 0 {
+		return nil
+	}
+	set := &JWKSet{Keys: make([]JWK, 0, len(k.publicKeys))}
+	for _, pub := range k.publicKeys {
+		kid, err := rsaKeyID(pub)
+		if err != nil {
+			continue
+		}
+		set.Keys = append(set.Keys, JWK{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: JWTAlgorithmRS256,
+			KeyID:     kid,
+			Modulus:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		})
+	}
+	return set
+}
+
+// rsaKeyID derives a stable key ID from the SHA-256 of the public key.
+func rsaKeyID(pub *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:8]), nil
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func generateRSAPEM(t *testing.T) (privPEM, pubPEM []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	privPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	pubPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	return privPEM, pubPEM
+}
+
+func TestAuthService_ValidateToken_PreviousSecret(t *testing.T) {
+	user := &models.User{ID: "test-id", Username: "testuser"}
+
+	old := (&AuthService{tokenLifetime: time.Hour}).WithJWTKeys(NewHMACJWTKeys("secret-1", nil))
+	token, err := old.generateToken(user)
+	if err != nil {
+		t.Fatalf("generateToken() error = %v", err)
+	}
+
+	rotated := (&AuthService{tokenLifetime: time.Hour}).WithJWTKeys(NewHMACJWTKeys("secret-2", []string{"secret-1"}))
+	if _, err := rotated.ValidateToken(token); err != nil {
+		t.Fatalf("ValidateToken() with previous secret error = %v", err)
+	}
+
+	retired := (&AuthService{tokenLifetime: time.Hour}).WithJWTKeys(NewHMACJWTKeys("secret-2", nil))
+	if _, err := retired.ValidateToken(token); err == nil {
+		t.Fatal("ValidateToken() should reject a token signed with a retired secret")
+	}
+}
+
+func TestAuthService_RS256(t *testing.T) {
+	user := &models.User{ID: "test-id", Username: "testuser"}
+	oldPriv, oldPub := generateRSAPEM(t)
+	newPriv, _ := generateRSAPEM(t)
+
+	oldKeys, err := NewRSAJWTKeys(oldPriv, nil)
+	if err != nil {
+		t.Fatalf("NewRSAJWTKeys() error = %v", err)
+	}
+	oldSvc := (&AuthService{tokenLifetime: time.Hour}).WithJWTKeys(oldKeys)
+	token, err := oldSvc.generateToken(user)
+	if err != nil {
+		t.Fatalf("generateToken() error = %v", err)
+	}
+	if claims, err := oldSvc.ValidateToken(token); err != nil || claims.UserID != user.ID {
+		t.Fatalf("ValidateToken() = %v, %v; want claims for %s", claims, err, user.ID)
+	}
+
+	newKeys, err := NewRSAJWTKeys(newPriv, [][]byte{oldPub})
+	if err != nil {
+		t.Fatalf("NewRSAJWTKeys() error = %v", err)
+	}
+	newSvc := (&AuthService{tokenLifetime: time.Hour}).WithJWTKeys(newKeys)
+	if _, err := newSvc.ValidateToken(token); err != nil {
+		t.Fatalf("ValidateToken() with previous public key error = %v", err)
+	}
+
+	set := newSvc.JWTPublicKeys()
+	if set == nil || len(set.Keys) != 2 || set.Keys[0].Algorithm != JWTAlgorithmRS256 {
+		t.Fatalf("JWTPublicKeys() = %+v, want two RS256 keys", set)
+	}
+	if set.Keys[0].KeyID == set.Keys[1].KeyID {
+		t.Error("JWTPublicKeys() key IDs should differ between key pairs")
+	}
+}
+
+func TestAuthService_RejectsAlgorithmMismatch(t *testing.T) {
+	user := &models.User{ID: "test-id", Username: "testuser"}
+	priv, _ := generateRSAPEM(t)
+	rsaKeys, err := NewRSAJWTKeys(priv, nil)
+	if err != nil {
+		t.Fatalf("NewRSAJWTKeys() error = %v", err)
+	}
+
+	hmacSvc := &AuthService{jwtSecret: "test-secret-key", tokenLifetime: time.Hour}
+	token, err := hmacSvc.generateToken(user)
+	if err != nil {
+		t.Fatalf("generateToken() error = %v", err)
+	}
+
+	rsaSvc := (&AuthService{tokenLifetime: time.Hour}).WithJWTKeys(rsaKeys)
+	if _, err := rsaSvc.ValidateToken(token); err == nil {
+		t.Fatal("ValidateToken() should reject an HS256 token when RS256 is configured")
+	}
+	if hmacSvc.JWTPublicKeys() != nil {
+		t.Error("JWTPublicKeys() should be nil for HS256")
+	}
+}
+
+func TestAuthService_TokenIssuerAudience(t *testing.T) {
+	keys := NewHMACJWTKeys("secret", nil)
+	user := &models.User{ID: "u-1", Username: "alice"}
+
+	scoped := (&AuthService{tokenLifetime: time.Hour}).WithJWTKeys(keys).WithTokenIssuer("bor-a", "api")
+	token, err := scoped.generateToken(user)
+	if err != nil {
+		t.Fatalf("generateToken() error = %v", err)
+	}
+	claims, err := scoped.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.Issuer != "bor-a" || len(claims.Audience) != 1 || claims.Audience[0] != "api" {
+		t.Errorf("iss/aud = %q/%v, want bor-a/[api]", claims.Issuer, claims.Audience)
+	}
+
+	otherIssuer := (&AuthService{tokenLifetime: time.Hour}).WithJWTKeys(keys).WithTokenIssuer("bor-b", "api")
+	if _, err := otherIssuer.ValidateToken(token); err == nil {
+		t.Error("ValidateToken() accepted a token of another issuer")
+	}
+	otherAudience := (&AuthService{tokenLifetime: time.Hour}).WithJWTKeys(keys).WithTokenIssuer("bor-a", "ui")
+	if _, err := otherAudience.ValidateToken(token); err == nil {
+		t.Error("ValidateToken() accepted a token for another audience")
+	}
+
+	unscoped := (&AuthService{tokenLifetime: time.Hour}).WithJWTKeys(keys)
+	plain, err := unscoped.generateToken(user)
+	if err != nil {
+		t.Fatalf("generateToken() error = %v", err)
+	}
+	if _, err := scoped.ValidateToken(plain); err == nil {
+		t.Error("ValidateToken() accepted a token without iss and aud")
+	}
+	if _, err := unscoped.ValidateToken(token); err != nil {
+		t.Errorf("ValidateToken() without configured issuer: %v", err)
+	}
+}
@@ -6,8 +6,12 @@ package services
 
 import (
 	"fmt"
+	"path"
+	"sort"
 
+	"github.com/VuteTech/Bor/server/internal/models"
 	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+	"github.com/VuteTech/Bor/server/pkg/kconfig"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -37,11 +41,89 @@ func ValidateKConfigPolicy(content string) error {
 		}
 	}
 
+	if err := validateKConfigDeletes(&kcp); err != nil {
+		return err
+	}
+	return validateKConfigScopes(&kcp)
+}
+
+// kconfigScopeFieldExempt lists KConfigPolicy fields that are not settings
+// and therefore cannot carry a scope.
+var kconfigScopeFieldExempt = map[string]bool{
+	"enforcedFields": true,
+	"scope":          true,
+	"fieldScopes":    true,
+	"deletedFields":  true,
+}
+
+// validateKConfigDeletes checks that deletedFields names single-valued
+// settings that the policy does not also set.
+func validateKConfigDeletes(kcp *pb.KConfigPolicy) error {
+	fields := kcp.ProtoReflect().Descriptor().Fields()
+	for _, key := range kcp.DeletedFields {
+		fd := fields.ByJSONName(key)
+		if fd == nil || kconfigScopeFieldExempt[key] {
+			return fmt.Errorf("deletedFields: unknown setting %q", key)
+		}
+		if fd.IsList() {
+			return fmt.Errorf("deletedFields: %s cannot be deleted", key)
+		}
+		if kcp.ProtoReflect().Has(fd) {
+			return fmt.Errorf("%s cannot be both set and deleted", key)
+		}
+	}
+	return nil
+}
+
+// validateKConfigScopes checks the policy-wide scope and the per-field
+// scope overrides. Restrictions cannot be per-user seeds, and a field
+// cannot be both enforced and a per-user seed.
+func validateKConfigScopes(kcp *pb.KConfigPolicy) error {
+	if err := validateKConfigScope(kcp.Scope); err != nil {
+		return fmt.Errorf("scope: %w", err)
+	}
+
+	fields := kcp.ProtoReflect().Descriptor().Fields()
+
+	keys := make([]string, 0, len(kcp.FieldScopes))
+	for k := range kcp.FieldScopes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if fields.ByJSONName(key) == nil || kconfigScopeFieldExempt[key] {
+			return fmt.Errorf("fieldScopes: unknown setting %q", key)
+		}
+		if err := validateKConfigScope(kcp.FieldScopes[key]); err != nil {
+			return fmt.Errorf("fieldScopes[%s]: %w", key, err)
+		}
+	}
+
+	if len(kcp.UrlRestrictions) > 0 && kconfig.FieldScope(kcp, "urlRestrictions") == pb.KConfigScope_KCONFIG_SCOPE_USER {
+		return fmt.Errorf("urlRestrictions are always enforced and cannot be per-user seeds")
+	}
+	if len(kcp.KcmRestrictions) > 0 && kconfig.FieldScope(kcp, "kcmRestrictions") == pb.KConfigScope_KCONFIG_SCOPE_USER {
+		return fmt.Errorf("kcmRestrictions are always enforced and cannot be per-user seeds")
+	}
+	for _, key := range kcp.EnforcedFields {
+		if kconfig.FieldScope(kcp, key) == pb.KConfigScope_KCONFIG_SCOPE_USER {
+			return fmt.Errorf("%s cannot be both enforced and a per-user seed", key)
+		}
+	}
+	return nil
+}
+
+// validateKConfigScope rejects scope values outside the KConfigScope enum.
+func validateKConfigScope(sc pb.KConfigScope) error {
+	if _, ok := pb.KConfigScope_name[int32(sc)]; !ok {
+		return fmt.Errorf("invalid scope %d", sc)
+	}
 	return nil
 }
 
 // kconfigPolicyHasSettings reports whether the policy has at least one
-// setting configured (any non-nil optional field or non-empty repeated field).
+// setting configured (any non-nil optional field or non-empty repeated field)
+// or deleted.
 func kconfigPolicyHasSettings(kcp *pb.KConfigPolicy) bool {
 	return kcp.ShellAccess != nil ||
 		kcp.RunCommand != nil ||
@@ -66,7 +148,8 @@ func kconfigPolicyHasSettings(kcp *pb.KConfigPolicy) bool {
 		kcp.WallpaperFillMode != nil ||
 		kcp.WallpaperColor != nil ||
 		len(kcp.UrlRestrictions) > 0 ||
-		len(kcp.KcmRestrictions) > 0
+		len(kcp.KcmRestrictions) > 0 ||
+		len(kcp.DeletedFields) > 0
 }
 
 // ParseKConfigPolicyContent parses and validates a KConfig policy content string.
@@ -82,3 +165,52 @@ func ParseKConfigPolicyContent(content string) (*pb.KConfigPolicy, error) {
 
 	return &kcp, nil
 }
+
+// kcmRestrictionPaths are where agents write KCM restrictions; they bypass
+// the XDG overlay.
+var kcmRestrictionPaths = []string{"/etc/kde5rc", "/etc/kde6rc"}
+
+// userSeedPath is the agents' default directory for per-user seed files.
+const userSeedPath = "/etc/skel/.config"
+
+// RenderKConfigPolicy renders KConfig policy content to the INI files an
+// agent writes when it is the node's only KConfig policy, using the same
+// merge code as the agent. Overlay files are named relative to the agent's
+// KConfig directory; KCM restrictions are written to /etc/kde5rc and
+// /etc/kde6rc and per-user seeds to the default seed directory.
+func RenderKConfigPolicy(content string) ([]models.RenderedPolicyFile, error) {
+	var kcp pb.KConfigPolicy
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(content), &kcp); err != nil {
+		return nil, fmt.Errorf("invalid KConfig policy JSON: %w", err)
+	}
+
+	scoped := kconfig.SplitByScope(kconfig.PolicyToEntries(&kcp))
+	files, err := kconfig.Merge(scoped.Overlay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render KConfig policy: %w", err)
+	}
+	userFiles, err := kconfig.Merge(scoped.User)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render KConfig user seeds: %w", err)
+	}
+
+	rendered := make([]models.RenderedPolicyFile, 0, len(files)+len(userFiles)+len(kcmRestrictionPaths))
+	for name, data := range files {
+		rendered = append(rendered, models.RenderedPolicyFile{Path: name, Content: string(data)})
+	}
+	for name, data := range userFiles {
+		rendered = append(rendered, models.RenderedPolicyFile{Path: path.Join(userSeedPath, name), Content: string(data)})
+	}
+	sort.Slice(rendered, func(i, j int) bool { return rendered[i].Path < rendered[j].Path })
+
+	if len(scoped.KCM) > 0 {
+		kcmFiles, err := kconfig.Merge(scoped.KCM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render KCM restrictions: %w", err)
+		}
+		for _, kcmPath := range kcmRestrictionPaths {
+			rendered = append(rendered, models.RenderedPolicyFile{Path: kcmPath, Content: string(kcmFiles["kde5rc"])})
+		}
+	}
+	return rendered, nil
+}
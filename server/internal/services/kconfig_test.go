@@ -213,3 +213,136 @@ func TestParseKConfigPolicyContent_Invalid(t *testing.T) {
 		t.Fatal("expected validation error for empty content")
 	}
 }
+
+func TestRenderKConfigPolicy(t *testing.T) {
+	content := `{
+		"shellAccess": false,
+		"runCommand": true,
+		"iconTheme": "breeze",
+		"enforcedFields": ["shellAccess"],
+		"kcmRestrictions": ["kcm_clock"]
+	}`
+	files, err := RenderKConfigPolicy(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]string, len(files))
+	var order []string
+	for _, f := range files {
+		got[f.Path] = f.Content
+		order = append(order, f.Path)
+	}
+	if want := []string{"kdeglobals", "/etc/kde5rc", "/etc/kde6rc"}; strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Fatalf("files = %v, want %v", order, want)
+	}
+	if !strings.Contains(got["kdeglobals"], "[KDE Action Restrictions]\nrun_command=true\nshell_access[$i]=false\n") {
+		t.Errorf("kdeglobals missing key-level enforcement:\n%s", got["kdeglobals"])
+	}
+	if !strings.Contains(got["kdeglobals"], "[Icons]\nTheme=breeze\n") {
+		t.Errorf("kdeglobals missing icon theme:\n%s", got["kdeglobals"])
+	}
+	if got["/etc/kde6rc"] != "[KDE Control Module Restrictions][$i]\nkcm_clock=false\n" {
+		t.Errorf("kde6rc = %q", got["/etc/kde6rc"])
+	}
+}
+
+func TestValidateKConfigPolicy_Scopes(t *testing.T) {
+	valid := []string{
+		`{"iconTheme": "breeze", "scope": "KCONFIG_SCOPE_USER"}`,
+		`{"iconTheme": "breeze", "shellAccess": false, "enforcedFields": ["shellAccess"], "fieldScopes": {"iconTheme": "KCONFIG_SCOPE_USER"}}`,
+		`{"kcmRestrictions": ["kcm_clock"], "fieldScopes": {"kcmRestrictions": "KCONFIG_SCOPE_SYSTEM_OVERLAY"}}`,
+	}
+	for _, content := range valid {
+		if err := ValidateKConfigPolicy(content); err != nil {
+			t.Errorf("%s: unexpected error %v", content, err)
+		}
+	}
+
+	invalid := []string{
+		`{"iconTheme": "breeze", "fieldScopes": {"noSuchField": "KCONFIG_SCOPE_USER"}}`,
+		`{"iconTheme": "breeze", "fieldScopes": {"enforcedFields": "KCONFIG_SCOPE_USER"}}`,
+		`{"iconTheme": "breeze", "scope": 9}`,
+		`{"kcmRestrictions": ["kcm_clock"], "scope": "KCONFIG_SCOPE_USER"}`,
+		`{"shellAccess": false, "enforcedFields": ["shellAccess"], "fieldScopes": {"shellAccess": "KCONFIG_SCOPE_USER"}}`,
+	}
+	for _, content := range invalid {
+		if err := ValidateKConfigPolicy(content); err == nil {
+			t.Errorf("%s: expected error", content)
+		}
+	}
+}
+
+func TestRenderKConfigPolicy_Scopes(t *testing.T) {
+	content := `{
+		"runCommand": true,
+		"iconTheme": "breeze",
+		"autoLock": true,
+		"fieldScopes": {"iconTheme": "KCONFIG_SCOPE_USER", "autoLock": "KCONFIG_SCOPE_SYSTEM_IMMUTABLE"}
+	}`
+	files, err := RenderKConfigPolicy(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]string, len(files))
+	for _, f := range files {
+		got[f.Path] = f.Content
+	}
+	if got["/etc/skel/.config/kdeglobals"] != "[Icons]\nTheme=breeze\n" {
+		t.Errorf("user seed = %q", got["/etc/skel/.config/kdeglobals"])
+	}
+	if got["kdeglobals"] != "[KDE Action Restrictions]\nrun_command=true\n" {
+		t.Errorf("overlay kdeglobals = %q", got["kdeglobals"])
+	}
+	if got["kscreenlockerrc"] != "[Daemon][$i]\nAutoLock=true\n" {
+		t.Errorf("kscreenlockerrc = %q", got["kscreenlockerrc"])
+	}
+}
+
+func TestValidateKConfigPolicy_DeletedFields(t *testing.T) {
+	valid := []string{
+		`{"deletedFields": ["iconTheme"]}`,
+		`{"shellAccess": false, "deletedFields": ["lockTimeout"], "enforcedFields": ["lockTimeout"]}`,
+	}
+	for _, content := range valid {
+		if err := ValidateKConfigPolicy(content); err != nil {
+			t.Errorf("%s: unexpected error %v", content, err)
+		}
+	}
+
+	invalid := []string{
+		`{"deletedFields": ["noSuchField"]}`,
+		`{"deletedFields": ["enforcedFields"]}`,
+		`{"deletedFields": ["kcmRestrictions"]}`,
+		`{"iconTheme": "breeze", "deletedFields": ["iconTheme"]}`,
+	}
+	for _, content := range invalid {
+		if err := ValidateKConfigPolicy(content); err == nil {
+			t.Errorf("%s: expected error", content)
+		}
+	}
+}
+
+func TestRenderKConfigPolicy_DeletedFields(t *testing.T) {
+	content := `{
+		"autoLock": true,
+		"deletedFields": ["lockTimeout", "iconTheme"],
+		"enforcedFields": ["autoLock", "lockTimeout"]
+	}`
+	files, err := RenderKConfigPolicy(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]string, len(files))
+	for _, f := range files {
+		got[f.Path] = f.Content
+	}
+	if got["kscreenlockerrc"] != "[Daemon][$i]\nAutoLock=true\nTimeout[$d]\n" {
+		t.Errorf("kscreenlockerrc = %q", got["kscreenlockerrc"])
+	}
+	if got["kdeglobals"] != "[Icons]\nTheme[$d]\n" {
+		t.Errorf("kdeglobals = %q", got["kdeglobals"])
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/go-ldap/ldap/v3"
 )
@@ -53,10 +54,10 @@ type LDAPConfig struct {
 	AttrMemberOf string `json:"attr_member_of"`
 	// PageSize controls LDAP result paging (RFC 2696).  0 disables paging.
 	PageSize int `json:"page_size"`
-	// GroupRoleMap maps LDAP group CNs to Bor role names.
+	// GroupRoleMap maps LDAP groups, by CN or full DN, to Bor role names.
 	// Users are automatically granted / revoked these roles on every login
 	// based on their current LDAP group membership.
-	// Example: {"Domain Admins": "Super Admin", "IT Staff": "Org Admin"}
+	// Example: {"Domain Admins": "Super Admin", "CN=IT Staff,DC=example,DC=com": "Org Admin"}
 	GroupRoleMap map[string]string `json:"group_role_map"`
 }
 
@@ -67,10 +68,51 @@ type LDAPUser struct {
 	FullName string
 	// Groups contains the CNs of the groups the user is a member of.
 	Groups []string
+	// GroupDNs contains the DNs of the same groups.
+	GroupDNs []string
+}
+
+// MappedRoles returns the names of the roles groupRoleMap grants the user.
+// A key containing '=' is matched as a DN against GroupDNs, ignoring case
+// and insignificant whitespace; any other key is matched against the CNs
+// in Groups.
+func (u *LDAPUser) MappedRoles(groupRoleMap map[string]string) map[string]bool {
+	cns := make(map[string]bool, len(u.Groups))
+	for _, cn := range u.Groups {
+		cns[cn] = true
+	}
+	var dns []*ldap.DN
+	for _, s := range u.GroupDNs {
+		if dn, err := ldap.ParseDN(s); err == nil {
+			dns = append(dns, dn)
+		}
+	}
+
+	roles := make(map[string]bool)
+	for group, roleName := range groupRoleMap {
+		if !strings.Contains(group, "=") {
+			if cns[group] {
+				roles[roleName] = true
+			}
+			continue
+		}
+		want, err := ldap.ParseDN(group)
+		if err != nil {
+			continue
+		}
+		for _, dn := range dns {
+			if dn.EqualFold(want) {
+				roles[roleName] = true
+				break
+			}
+		}
+	}
+	return roles
 }
 
 // LDAPService handles LDAP authentication.
 type LDAPService struct {
+	mu     sync.RWMutex
 	config *LDAPConfig
 }
 
@@ -79,9 +121,24 @@ func NewLDAPService(config *LDAPConfig) *LDAPService {
 	return &LDAPService{config: config}
 }
 
+// Config returns the current LDAP configuration. It must not be modified.
+func (s *LDAPService) Config() *LDAPConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
+
+// SetConfig replaces the LDAP configuration, e.g. on a configuration
+// reload. Logins already in progress finish with the previous one.
+func (s *LDAPService) SetConfig(config *LDAPConfig) {
+	s.mu.Lock()
+	s.config = config
+	s.mu.Unlock()
+}
+
 // IsEnabled returns whether LDAP is enabled.
 func (s *LDAPService) IsEnabled() bool {
-	return s.config.Enabled
+	return s.Config().Enabled
 }
 
 // Authenticate verifies user credentials against LDAP and returns the user's
@@ -94,36 +151,37 @@ func (s *LDAPService) IsEnabled() bool {
 //  4. Re-bind as the user to verify the supplied password.
 //  5. Retrieve group memberships via AttrMemberOf or a separate search.
 func (s *LDAPService) Authenticate(username, password string) (*LDAPUser, error) {
-	if !s.config.Enabled {
+	cfg := s.Config()
+	if !cfg.Enabled {
 		return nil, fmt.Errorf("LDAP is not enabled")
 	}
 
-	conn, err := s.connect()
+	conn, err := cfg.connect()
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to LDAP: %w", err)
 	}
 	defer func() { _ = conn.Close() }()
 
 	// ── Step 1: service account bind ─────────────────────────────────────────
-	if bindErr := conn.Bind(s.config.BindDN, s.config.BindPassword); bindErr != nil {
+	if bindErr := conn.Bind(cfg.BindDN, cfg.BindPassword); bindErr != nil {
 		return nil, fmt.Errorf("failed to bind with service account: %w", bindErr)
 	}
 
 	// ── Step 2: search for the user ──────────────────────────────────────────
 	searchAttrs := []string{
 		"dn",
-		s.config.AttrUsername,
-		s.config.AttrEmail,
-		s.config.AttrFullName,
+		cfg.AttrUsername,
+		cfg.AttrEmail,
+		cfg.AttrFullName,
 	}
-	if s.config.AttrMemberOf != "" {
-		searchAttrs = append(searchAttrs, s.config.AttrMemberOf)
+	if cfg.AttrMemberOf != "" {
+		searchAttrs = append(searchAttrs, cfg.AttrMemberOf)
 	}
 
-	filter := fmt.Sprintf(s.config.UserFilter, ldap.EscapeFilter(username))
+	filter := fmt.Sprintf(cfg.UserFilter, ldap.EscapeFilter(username))
 
 	var entry *ldap.Entry
-	entry, err = s.searchUser(conn, filter, searchAttrs)
+	entry, err = cfg.searchUser(conn, filter, searchAttrs)
 	if err != nil {
 		return nil, err
 	}
@@ -132,18 +190,18 @@ func (s *LDAPService) Authenticate(username, password string) (*LDAPUser, error)
 	// When the service account search finds no entry but a UPN suffix is
 	// configured, try binding directly as username@SUFFIX.  AD allows this even
 	// when anonymous/bind-account search fails due to restrictive ACLs.
-	if entry == nil && s.config.UPNSuffix != "" {
-		upn := username + s.config.UPNSuffix
+	if entry == nil && cfg.UPNSuffix != "" {
+		upn := username + cfg.UPNSuffix
 		if upnErr := conn.Bind(upn, password); upnErr != nil {
 			return nil, fmt.Errorf("user not found and UPN bind failed: invalid credentials")
 		}
 		// Re-search now that we're bound as the user.
-		entry, err = s.searchUser(conn, filter, searchAttrs)
+		entry, err = cfg.searchUser(conn, filter, searchAttrs)
 		if err != nil || entry == nil {
 			return nil, fmt.Errorf("user not found in LDAP")
 		}
 		// Password already verified via the UPN bind above.
-		return s.buildUser(conn, entry, username), nil
+		return cfg.buildUser(conn, entry, username), nil
 	}
 
 	if entry == nil {
@@ -156,22 +214,22 @@ func (s *LDAPService) Authenticate(username, password string) (*LDAPUser, error)
 	}
 
 	// ── Step 5: build user with group info ───────────────────────────────────
-	return s.buildUser(conn, entry, username), nil
+	return cfg.buildUser(conn, entry, username), nil
 }
 
 // searchUser runs a paged (or non-paged) LDAP search and returns the first
 // matching entry, or nil when no entries match.
-func (s *LDAPService) searchUser(conn *ldap.Conn, filter string, attrs []string) (*ldap.Entry, error) {
-	base := s.config.BaseDN
+func (c *LDAPConfig) searchUser(conn *ldap.Conn, filter string, attrs []string) (*ldap.Entry, error) {
+	base := c.BaseDN
 
-	if s.config.PageSize > 0 {
-		paging := ldap.NewControlPaging(uint32(s.config.PageSize)) //nolint:gosec // page size is configured by admin
+	if c.PageSize > 0 {
+		paging := ldap.NewControlPaging(uint32(c.PageSize)) //nolint:gosec // page size is configured by admin
 		req := ldap.NewSearchRequest(
 			base,
 			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 			filter, attrs, []ldap.Control{paging},
 		)
-		result, err := conn.SearchWithPaging(req, uint32(s.config.PageSize)) //nolint:gosec // page size is admin-configured
+		result, err := conn.SearchWithPaging(req, uint32(c.PageSize)) //nolint:gosec // page size is admin-configured
 		if err != nil {
 			return nil, fmt.Errorf("LDAP search failed: %w", err)
 		}
@@ -197,34 +255,35 @@ func (s *LDAPService) searchUser(conn *ldap.Conn, filter string, attrs []string)
 }
 
 // buildUser constructs an LDAPUser from the search entry and resolves groups.
-func (s *LDAPService) buildUser(conn *ldap.Conn, entry *ldap.Entry, fallbackUsername string) *LDAPUser {
-	username := entry.GetAttributeValue(s.config.AttrUsername)
+func (c *LDAPConfig) buildUser(conn *ldap.Conn, entry *ldap.Entry, fallbackUsername string) *LDAPUser {
+	username := entry.GetAttributeValue(c.AttrUsername)
 	if username == "" {
 		username = fallbackUsername
 	}
 
 	user := &LDAPUser{
 		Username: username,
-		Email:    entry.GetAttributeValue(s.config.AttrEmail),
-		FullName: entry.GetAttributeValue(s.config.AttrFullName),
+		Email:    entry.GetAttributeValue(c.AttrEmail),
+		FullName: entry.GetAttributeValue(c.AttrFullName),
 	}
 
 	// ── Group resolution ─────────────────────────────────────────────────────
 	// Prefer the direct memberOf attribute when configured (one round-trip).
-	if s.config.AttrMemberOf != "" {
-		for _, dn := range entry.GetAttributeValues(s.config.AttrMemberOf) {
+	if c.AttrMemberOf != "" {
+		for _, dn := range entry.GetAttributeValues(c.AttrMemberOf) {
 			user.Groups = append(user.Groups, cnFromDN(dn))
+			user.GroupDNs = append(user.GroupDNs, dn)
 		}
 		return user
 	}
 
 	// Fall back to a separate group search using GroupFilter.
-	if s.config.GroupFilter != "" {
-		groupBase := s.config.GroupBaseDN
+	if c.GroupFilter != "" {
+		groupBase := c.GroupBaseDN
 		if groupBase == "" {
-			groupBase = s.config.BaseDN
+			groupBase = c.BaseDN
 		}
-		groupFilter := fmt.Sprintf(s.config.GroupFilter, ldap.EscapeFilter(entry.DN))
+		groupFilter := fmt.Sprintf(c.GroupFilter, ldap.EscapeFilter(entry.DN))
 		req := ldap.NewSearchRequest(
 			groupBase,
 			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
@@ -236,6 +295,7 @@ func (s *LDAPService) buildUser(conn *ldap.Conn, entry *ldap.Entry, fallbackUser
 				if cn := g.GetAttributeValue("cn"); cn != "" {
 					user.Groups = append(user.Groups, cn)
 				}
+				user.GroupDNs = append(user.GroupDNs, g.DN)
 			}
 		}
 	}
@@ -256,16 +316,16 @@ func cnFromDN(dn string) string {
 }
 
 // connect establishes a connection to the LDAP server, applying TLS settings.
-func (s *LDAPService) connect() (*ldap.Conn, error) {
-	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+func (c *LDAPConfig) connect() (*ldap.Conn, error) {
+	addr := fmt.Sprintf("%s:%d", c.Host, c.Port)
 
-	tlsCfg, err := s.tlsConfig()
+	tlsCfg, err := c.tlsConfig()
 	if err != nil {
 		return nil, err
 	}
 
 	// LDAPS: full TLS from connection start.
-	if s.config.UseTLS {
+	if c.UseTLS {
 		return ldap.DialURL(fmt.Sprintf("ldaps://%s", addr),
 			ldap.DialWithTLSConfig(tlsCfg))
 	}
@@ -276,7 +336,7 @@ func (s *LDAPService) connect() (*ldap.Conn, error) {
 	}
 
 	// StartTLS: upgrade a plain connection to TLS.
-	if s.config.StartTLS {
+	if c.StartTLS {
 		if err := conn.StartTLS(tlsCfg); err != nil {
 			_ = conn.Close()
 			return nil, fmt.Errorf("StartTLS failed: %w", err)
@@ -294,25 +354,25 @@ func (s *LDAPService) connect() (*ldap.Conn, error) {
 // provided we therefore verify the certificate chain ourselves (ensuring the
 // cert is signed by the trusted CA) while allowing CN-based hostname matching
 // via VerifyHostname on the parsed certificate.
-func (s *LDAPService) tlsConfig() (*tls.Config, error) {
+func (c *LDAPConfig) tlsConfig() (*tls.Config, error) {
 	cfg := &tls.Config{
 		MinVersion: tls.VersionTLS12,
-		ServerName: s.config.Host,
+		ServerName: c.Host,
 	}
 
-	if s.config.TLSSkipVerify {
+	if c.TLSSkipVerify {
 		cfg.InsecureSkipVerify = true //nolint:gosec // G402: admin-configured, dev/testing use
 		return cfg, nil
 	}
 
-	if s.config.TLSCAFile != "" {
-		pemData, err := os.ReadFile(s.config.TLSCAFile) //nolint:gosec // G304: path is admin-configured
+	if c.TLSCAFile != "" {
+		pemData, err := os.ReadFile(c.TLSCAFile) //nolint:gosec // G304: path is admin-configured
 		if err != nil {
-			return nil, fmt.Errorf("failed to read LDAP CA cert %s: %w", s.config.TLSCAFile, err)
+			return nil, fmt.Errorf("failed to read LDAP CA cert %s: %w", c.TLSCAFile, err)
 		}
 		pool := x509.NewCertPool()
 		if !pool.AppendCertsFromPEM(pemData) {
-			return nil, fmt.Errorf("no valid certificates found in %s", s.config.TLSCAFile)
+			return nil, fmt.Errorf("no valid certificates found in %s", c.TLSCAFile)
 		}
 		cfg.RootCAs = pool
 
@@ -353,10 +413,10 @@ func (s *LDAPService) tlsConfig() (*tls.Config, error) {
 			// Try standard SAN-based hostname check first.
 			// If it fails and the cert has no SANs, fall back to
 			// matching the Common Name (legacy Samba AD certs).
-			if hostErr := cert.VerifyHostname(s.config.Host); hostErr != nil {
+			if hostErr := cert.VerifyHostname(c.Host); hostErr != nil {
 				if len(cert.DNSNames) == 0 && len(cert.IPAddresses) == 0 {
-					if !strings.EqualFold(cert.Subject.CommonName, s.config.Host) {
-						return fmt.Errorf("certificate CN %q does not match host %q", cert.Subject.CommonName, s.config.Host)
+					if !strings.EqualFold(cert.Subject.CommonName, c.Host) {
+						return fmt.Errorf("certificate CN %q does not match host %q", cert.Subject.CommonName, c.Host)
 					}
 				} else {
 					return fmt.Errorf("hostname verification failed: %w", hostErr)
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLDAPUser_MappedRoles(t *testing.T) {
+	user := &LDAPUser{
+		Groups: []string{"Domain Admins", "IT Staff"},
+		GroupDNs: []string{
+			"CN=Domain Admins,CN=Users,DC=example,DC=com",
+			"cn=IT Staff,ou=Groups,dc=example,dc=com",
+		},
+	}
+	groupRoleMap := map[string]string{
+		"Domain Admins": "Super Admin",
+		"CN=IT Staff, OU=Groups, DC=example, DC=com": "Org Admin",
+		"CN=IT Staff,OU=Other,DC=example,DC=com":     "Auditor",
+		"Helpdesk":                                   "Compliance Viewer",
+		"domain admins":                              "Policy Editor",
+	}
+
+	got := user.MappedRoles(groupRoleMap)
+	want := map[string]bool{"Super Admin": true, "Org Admin": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MappedRoles() = %v, want %v", got, want)
+	}
+}
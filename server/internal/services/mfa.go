@@ -12,7 +12,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
-	"os"
 	"strings"
 	"time"
 
@@ -35,20 +34,24 @@ type MFAService struct {
 	mfaRepo      *database.MFARepository
 	settingsRepo *database.SettingsRepository
 	aesKey       []byte
-	legacyAESKey []byte // pre-HKDF key for migrating existing secrets
+	// fallbackKeys are tried when aesKey cannot decrypt a secret: keys from
+	// earlier passphrases and pre-HKDF keys for migrating existing secrets.
+	fallbackKeys [][]byte
 }
 
-// NewMFAService creates a new MFAService.
-func NewMFAService(mfaRepo *database.MFARepository, settingsRepo *database.SettingsRepository, jwtSecret string) *MFAService {
-	secret := os.Getenv("BOR_MFA_SECRET")
-	if secret == "" {
-		secret = jwtSecret
+// NewMFAService creates a new MFAService. TOTP secrets are encrypted at
+// rest with a key derived from key; secrets encrypted with one of the
+// previous keys can still be decrypted.
+func NewMFAService(mfaRepo *database.MFARepository, settingsRepo *database.SettingsRepository, key string, previous []string) *MFAService {
+	fallbackKeys := [][]byte{deriveLegacyAESKey(key)}
+	for _, p := range previous {
+		fallbackKeys = append(fallbackKeys, deriveAESKey(p, mfaKeyInfo), deriveLegacyAESKey(p))
 	}
 	return &MFAService{
 		mfaRepo:      mfaRepo,
 		settingsRepo: settingsRepo,
-		aesKey:       deriveAESKey(secret),
-		legacyAESKey: deriveLegacyAESKey(secret),
+		aesKey:       deriveAESKey(key, mfaKeyInfo),
+		fallbackKeys: fallbackKeys,
 	}
 }
 
@@ -299,13 +302,14 @@ func (s *MFAService) decryptSecret(encSecret string) (string, error) {
 	if err == nil {
 		return string(b), nil
 	}
-	// Fall back to the legacy SHA-256-derived key for secrets encrypted
-	// before the HKDF migration.
-	b, legacyErr := aesDecrypt(s.legacyAESKey, encSecret)
-	if legacyErr != nil {
-		return "", fmt.Errorf("decrypt totp secret: %w", err)
+	// Fall back to previous keys and to the legacy SHA-256-derived keys for
+	// secrets encrypted before the HKDF migration.
+	for _, key := range s.fallbackKeys {
+		if b, fallbackErr := aesDecrypt(key, encSecret); fallbackErr == nil {
+			return string(b), nil
+		}
 	}
-	return string(b), nil
+	return "", fmt.Errorf("decrypt totp secret: %w", err)
 }
 
 func (s *MFAService) getTOTPAlgorithm(ctx context.Context) otp.Algorithm {
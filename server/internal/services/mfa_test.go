@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import "testing"
+
+func TestMFAService_DecryptSecret_PreviousKeys(t *testing.T) {
+	const totpSecret = "JBSWY3DPEHPK3PXP"
+
+	oldHKDF, err := aesEncrypt(deriveAESKey("old-jwt-secret", mfaKeyInfo), []byte(totpSecret))
+	if err != nil {
+		t.Fatalf("aesEncrypt() error = %v", err)
+	}
+	oldLegacy, err := aesEncrypt(deriveLegacyAESKey("old-jwt-secret"), []byte(totpSecret))
+	if err != nil {
+		t.Fatalf("aesEncrypt() error = %v", err)
+	}
+
+	svc := NewMFAService(nil, nil, "data-key", []string{"old-jwt-secret"})
+	for name, enc := range map[string]string{"hkdf": oldHKDF, "legacy": oldLegacy} {
+		got, err := svc.decryptSecret(enc)
+		if err != nil || got != totpSecret {
+			t.Errorf("%s: decryptSecret() = %q, %v; want %q", name, got, err, totpSecret)
+		}
+	}
+
+	rotated := NewMFAService(nil, nil, "data-key", nil)
+	if _, err := rotated.decryptSecret(oldHKDF); err == nil {
+		t.Error("decryptSecret() without the previous key should fail")
+	}
+}
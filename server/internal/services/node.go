@@ -6,7 +6,9 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,7 +18,19 @@ import (
 
 // NodeService handles node business logic
 type NodeService struct {
-	nodeRepo *database.NodeRepository
+	nodeRepo   *database.NodeRepository
+	heartbeats *heartbeatCoalescer // nil writes every heartbeat
+
+	minAgentVersion    []int  // nil accepts every agent version
+	minAgentVersionStr string // minAgentVersion as configured
+
+	revocations RevocationListener // nil when revocations are not cached
+}
+
+// RevocationListener is told when certificates are revoked or revocations
+// are cleared, so that cached revocation lists can be reloaded.
+type RevocationListener interface {
+	Invalidate()
 }
 
 // NewNodeService creates a new NodeService
@@ -24,6 +38,36 @@ func NewNodeService(nodeRepo *database.NodeRepository) *NodeService {
 	return &NodeService{nodeRepo: nodeRepo}
 }
 
+// SetRevocationListener registers l to be told about certificates revoked
+// by node deletion.
+func (s *NodeService) SetRevocationListener(l RevocationListener) {
+	s.revocations = l
+}
+
+func (s *NodeService) revoked() {
+	if s.revocations != nil {
+		s.revocations.Invalidate()
+	}
+}
+
+// StartHeartbeatCoalescing makes ProcessHeartbeat buffer last_seen updates
+// of heartbeats whose facts did not change and write them every interval
+// in a single statement. It must be called before heartbeats are served.
+// The returned function stops the flusher after writing what is buffered.
+func (s *NodeService) StartHeartbeatCoalescing(interval time.Duration) (stop func()) {
+	s.heartbeats = newHeartbeatCoalescer(s.nodeRepo)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.heartbeats.run(ctx, interval)
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
 // CreateNode creates a new node
 func (s *NodeService) CreateNode(ctx context.Context, node *models.Node) error {
 	return s.nodeRepo.Create(ctx, node)
@@ -31,7 +75,9 @@ func (s *NodeService) CreateNode(ctx context.Context, node *models.Node) error {
 
 // ListAllNodes returns all nodes
 func (s *NodeService) ListAllNodes(ctx context.Context) ([]*models.Node, error) {
-	return s.nodeRepo.ListAll(ctx)
+	nodes, err := s.nodeRepo.ListAll(ctx)
+	s.markOutdatedAgents(nodes...)
+	return nodes, err
 }
 
 // ListNodesByStatus returns nodes filtered by status
@@ -39,12 +85,47 @@ func (s *NodeService) ListNodesByStatus(ctx context.Context, status string) ([]*
 	if !isValidNodeStatus(status) {
 		return nil, fmt.Errorf("invalid node status: %s", status)
 	}
-	return s.nodeRepo.ListByStatus(ctx, status)
+	nodes, err := s.nodeRepo.ListByStatus(ctx, status)
+	s.markOutdatedAgents(nodes...)
+	return nodes, err
+}
+
+// Node list page sizes.
+const (
+	defaultNodesPerPage = 50
+	maxNodesPerPage     = 500
+)
+
+// ListNodesPaginated returns one page of the nodes matching the filters
+// of req. Page and PerPage are clamped to valid values.
+func (s *NodeService) ListNodesPaginated(ctx context.Context, req *models.NodeListRequest) (*models.NodeListResponse, error) {
+	if req.Status != "" && !isValidNodeStatus(req.Status) {
+		return nil, fmt.Errorf("invalid node status: %s", req.Status)
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PerPage <= 0 {
+		req.PerPage = defaultNodesPerPage
+	}
+	if req.PerPage > maxNodesPerPage {
+		req.PerPage = maxNodesPerPage
+	}
+
+	nodes, total, err := s.nodeRepo.ListPaginated(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.markOutdatedAgents(nodes...)
+
+	return models.NewListResponse(nodes, total, models.Pagination{Page: req.Page, PerPage: req.PerPage}), nil
 }
 
 // GetNode retrieves a node by ID
 func (s *NodeService) GetNode(ctx context.Context, id string) (*models.Node, error) {
-	return s.nodeRepo.GetByID(ctx, id)
+	node, err := s.nodeRepo.GetByID(ctx, id)
+	s.markOutdatedAgents(node)
+	return node, err
 }
 
 // SearchNodes searches nodes by a term
@@ -52,7 +133,9 @@ func (s *NodeService) SearchNodes(ctx context.Context, term string) ([]*models.N
 	if term == "" {
 		return s.nodeRepo.ListAll(ctx)
 	}
-	return s.nodeRepo.Search(ctx, term)
+	nodes, err := s.nodeRepo.Search(ctx, term)
+	s.markOutdatedAgents(nodes...)
+	return nodes, err
 }
 
 // UpdateNode updates node fields
@@ -74,16 +157,21 @@ func (s *NodeService) CountByStatus(ctx context.Context) (map[string]int, error)
 }
 
 // ProcessHeartbeat records a node heartbeat, updating metadata facts.
-// It does not change node status — status is managed by stream connect/disconnect.
+// It sets node status only to bring a degraded node back online; otherwise
+// status is managed by stream connect/disconnect and the status reaper.
 func (s *NodeService) ProcessHeartbeat(ctx context.Context, nodeID string, info *models.NodeHeartbeatInfo) error {
 	facts := map[string]string{
-		"fqdn":          info.FQDN,
-		"ip_address":    info.IPAddress,
-		"os_name":       info.OSName,
-		"os_version":    info.OSVersion,
-		"desktop_env":   strings.Join(info.DesktopEnvs, ", "),
-		"agent_version": info.AgentVersion,
-		"machine_id":    info.MachineID,
+		"fqdn":            info.FQDN,
+		"ip_address":      info.IPAddress,
+		"os_name":         info.OSName,
+		"os_version":      info.OSVersion,
+		"desktop_env":     strings.Join(info.DesktopEnvs, ", "),
+		"agent_version":   info.AgentVersion,
+		"machine_id":      info.MachineID,
+		"firefox_version": info.FirefoxVersion,
+		"chrome_version":  info.ChromeVersion,
+		"kernel_version":  info.KernelVersion,
+		"hardware":        hardwareFact(info.Hardware),
 	}
 	// Omit empty values so we don't overwrite existing data with blanks.
 	for k, v := range facts {
@@ -91,12 +179,76 @@ func (s *NodeService) ProcessHeartbeat(ctx context.Context, nodeID string, info
 			delete(facts, k)
 		}
 	}
+	if s.heartbeats != nil {
+		if err := s.heartbeats.record(ctx, nodeID, facts); err != nil {
+			return fmt.Errorf("failed to update heartbeat: %w", err)
+		}
+		return nil
+	}
 	if err := s.nodeRepo.UpdateHeartbeat(ctx, nodeID, facts); err != nil {
 		return fmt.Errorf("failed to update heartbeat: %w", err)
 	}
 	return nil
 }
 
+// hardwareFact encodes reported hardware facts for storage, or returns ""
+// when there are none. Free memory and disk space are rounded down to 1%
+// of their totals so that small fluctuations don't defeat heartbeat
+// coalescing; the boot time is truncated to the minute for the same reason.
+func hardwareFact(hw *models.NodeHardware) string {
+	if hw == nil {
+		return ""
+	}
+	h := *hw
+	h.MemoryAvailableBytes = roundDownToPercent(h.MemoryAvailableBytes, h.MemoryTotalBytes)
+	h.RootDiskFreeBytes = roundDownToPercent(h.RootDiskFreeBytes, h.RootDiskTotalBytes)
+	if h.BootTime != nil {
+		bt := h.BootTime.Truncate(time.Minute)
+		h.BootTime = &bt
+	}
+	b, err := json.Marshal(&h)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// roundDownToPercent rounds v down to a multiple of 1% of total.
+func roundDownToPercent(v, total uint64) uint64 {
+	step := total / 100
+	if step == 0 {
+		return v
+	}
+	return v - v%step
+}
+
+// SetSessionUsers records the users with a login session on node, as
+// reported in its heartbeat, and returns whether they changed. The node's
+// user-group-bound policies depend on them.
+func (s *NodeService) SetSessionUsers(ctx context.Context, node *models.Node, users []string) (bool, error) {
+	users = normalizeSessionUsers(users)
+	if slices.Equal(users, normalizeSessionUsers(node.SessionUsers)) {
+		return false, nil
+	}
+	if err := s.nodeRepo.UpdateSessionUsers(ctx, node.ID, users); err != nil {
+		return false, err
+	}
+	node.SessionUsers = users
+	return true, nil
+}
+
+// normalizeSessionUsers returns users sorted, without blanks or duplicates.
+func normalizeSessionUsers(users []string) []string {
+	out := make([]string, 0, len(users))
+	for _, u := range users {
+		if u = strings.TrimSpace(u); u != "" {
+			out = append(out, u)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
 // UpdateNodeStatus sets the cached status of a node. Used by the gRPC
 // stream handler to mark nodes online on connect and offline on disconnect.
 func (s *NodeService) UpdateNodeStatus(ctx context.Context, nodeID, status string) error {
@@ -106,9 +258,93 @@ func (s *NodeService) UpdateNodeStatus(ctx context.Context, nodeID, status strin
 	return nil
 }
 
-// DeleteNode removes a node by ID.
-func (s *NodeService) DeleteNode(ctx context.Context, id string) error {
-	return s.nodeRepo.Delete(ctx, id)
+// DeleteNode removes a node and revokes its current certificate, so the
+// agent cannot reconnect with it.
+func (s *NodeService) DeleteNode(ctx context.Context, node *models.Node) error {
+	if err := s.nodeRepo.DeleteAndRevoke(ctx, []*models.Node{node}, "node deleted"); err != nil {
+		return err
+	}
+	s.revoked()
+	if s.heartbeats != nil {
+		s.heartbeats.forget(node.ID)
+	}
+	return nil
+}
+
+// MaxBulkDeleteNodes bounds the number of nodes one bulk deletion may remove.
+const MaxBulkDeleteNodes = 1000
+
+// ValidateBulkDeleteRequest checks that a bulk deletion names its nodes
+// either by ID or by filter, and states how many nodes it expects to delete.
+// Duplicate node IDs are removed.
+func ValidateBulkDeleteRequest(req *models.BulkDeleteNodesRequest) error {
+	if (len(req.NodeIDs) == 0) == (req.FilterID == "") {
+		return fmt.Errorf("exactly one of node_ids or filter_id is required")
+	}
+	if req.ExpectedCount < 1 {
+		return fmt.Errorf("expected_count must be at least 1")
+	}
+	if len(req.NodeIDs) > MaxBulkDeleteNodes {
+		return fmt.Errorf("at most %d nodes can be deleted at once", MaxBulkDeleteNodes)
+	}
+	seen := make(map[string]bool, len(req.NodeIDs))
+	ids := req.NodeIDs[:0]
+	for _, id := range req.NodeIDs {
+		if id == "" {
+			return fmt.Errorf("node_ids must not contain empty IDs")
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	req.NodeIDs = ids
+	return nil
+}
+
+// BulkDeleteNodes deletes nodes and revokes their certificates in one
+// transaction. If anything fails, no node is deleted.
+func (s *NodeService) BulkDeleteNodes(ctx context.Context, nodes []*models.Node, reason string) error {
+	if len(nodes) > MaxBulkDeleteNodes {
+		return fmt.Errorf("at most %d nodes can be deleted at once", MaxBulkDeleteNodes)
+	}
+	if reason == "" {
+		reason = "decommissioned"
+	}
+	if err := s.nodeRepo.DeleteAndRevoke(ctx, nodes, reason); err != nil {
+		return err
+	}
+	s.revoked()
+	if s.heartbeats != nil {
+		for _, n := range nodes {
+			s.heartbeats.forget(n.ID)
+		}
+	}
+	return nil
+}
+
+// MaxQuarantineReasonLength bounds the reason recorded with a quarantine.
+const MaxQuarantineReasonLength = 500
+
+// QuarantineNode quarantines a node: until released it receives only the
+// policies bound to quarantine node groups.
+func (s *NodeService) QuarantineNode(ctx context.Context, id, reason string) (*models.Node, error) {
+	if len(reason) > MaxQuarantineReasonLength {
+		return nil, fmt.Errorf("reason must be at most %d characters", MaxQuarantineReasonLength)
+	}
+	if err := s.nodeRepo.SetQuarantine(ctx, id, true, reason); err != nil {
+		return nil, err
+	}
+	return s.GetNode(ctx, id)
+}
+
+// UnquarantineNode releases a node from quarantine, restoring normal
+// policy resolution.
+func (s *NodeService) UnquarantineNode(ctx context.Context, id string) (*models.Node, error) {
+	if err := s.nodeRepo.SetQuarantine(ctx, id, false, ""); err != nil {
+		return nil, err
+	}
+	return s.GetNode(ctx, id)
 }
 
 // AddNodeToGroup adds a node to a node group.
@@ -139,7 +375,7 @@ func (s *NodeService) ListExpiringCerts(ctx context.Context, withinDays int) ([]
 // isValidNodeStatus checks if the given status is a valid node status
 func isValidNodeStatus(status string) bool {
 	switch status {
-	case models.NodeStatusOnline, models.NodeStatusOffline, models.NodeStatusUnknown:
+	case models.NodeStatusOnline, models.NodeStatusDegraded, models.NodeStatusOffline, models.NodeStatusUnknown:
 		return true
 	default:
 		return false
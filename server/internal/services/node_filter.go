@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// NodeFilterService handles saved node filters and evaluates them against
+// nodes. Evaluation results are cached per node: the policy resolver asks
+// for a node's matching filters on every snapshot, while node facts only
+// change on heartbeat and filters only change through the API.
+type NodeFilterService struct {
+	repo     *database.NodeFilterRepository
+	nodeRepo *database.NodeRepository
+
+	mu      sync.Mutex
+	gen     uint64               // bumped whenever filters change
+	filters []*models.NodeFilter // nil until loaded
+	matches map[string][]string  // node ID → sorted matching filter IDs
+}
+
+// NewNodeFilterService creates a new NodeFilterService
+func NewNodeFilterService(repo *database.NodeFilterRepository, nodeRepo *database.NodeRepository) *NodeFilterService {
+	return &NodeFilterService{
+		repo:     repo,
+		nodeRepo: nodeRepo,
+		matches:  make(map[string][]string),
+	}
+}
+
+// CreateFilter creates a new node filter
+func (s *NodeFilterService) CreateFilter(ctx context.Context, req *models.CreateNodeFilterRequest) (*models.NodeFilter, error) {
+	if req.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := validateNodeFilterCriteria(req.Criteria); err != nil {
+		return nil, err
+	}
+	f := &models.NodeFilter{
+		Name:        req.Name,
+		Description: req.Description,
+		Criteria:    req.Criteria,
+	}
+	if err := s.repo.Create(ctx, f); err != nil {
+		return nil, fmt.Errorf("failed to create node filter: %w", err)
+	}
+	s.invalidateAll()
+	return f, nil
+}
+
+// GetFilter retrieves a node filter by ID
+func (s *NodeFilterService) GetFilter(ctx context.Context, id string) (*models.NodeFilter, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListFilters returns all node filters
+func (s *NodeFilterService) ListFilters(ctx context.Context) ([]*models.NodeFilter, error) {
+	return s.repo.ListAll(ctx)
+}
+
+// UpdateFilter updates a node filter
+func (s *NodeFilterService) UpdateFilter(ctx context.Context, id string, req *models.UpdateNodeFilterRequest) (*models.NodeFilter, error) {
+	if req.Name != nil && *req.Name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	if req.Criteria != nil {
+		if err := validateNodeFilterCriteria(*req.Criteria); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.Update(ctx, id, req); err != nil {
+		return nil, fmt.Errorf("failed to update node filter: %w", err)
+	}
+	s.invalidateAll()
+	return s.repo.GetByID(ctx, id)
+}
+
+// DeleteFilter deletes a node filter together with the bindings targeting it
+func (s *NodeFilterService) DeleteFilter(ctx context.Context, id string) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateAll()
+	return nil
+}
+
+// ListMatchingNodes returns the nodes that currently match a filter, or nil
+// if the filter does not exist.
+func (s *NodeFilterService) ListMatchingNodes(ctx context.Context, id string) ([]*models.Node, error) {
+	f, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return nil, nil
+	}
+	nodes, err := s.nodeRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matched := []*models.Node{}
+	for _, n := range nodes {
+		if matchNodeFilter(f.Criteria, n) {
+			matched = append(matched, n)
+		}
+	}
+	return matched, nil
+}
+
+// CountMatchingNodes returns the number of matching nodes per filter ID.
+func (s *NodeFilterService) CountMatchingNodes(ctx context.Context) (map[string]int, error) {
+	filters, err := s.loadFilters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := s.nodeRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(filters))
+	for _, f := range filters {
+		for _, n := range nodes {
+			if matchNodeFilter(f.Criteria, n) {
+				counts[f.ID]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// MatchingFilterIDs returns the IDs of the filters the node currently
+// matches. The node is re-read on a cache miss so the evaluation always
+// uses its latest reported facts.
+func (s *NodeFilterService) MatchingFilterIDs(ctx context.Context, nodeID string) ([]string, error) {
+	s.mu.Lock()
+	ids, ok := s.matches[nodeID]
+	gen := s.gen
+	s.mu.Unlock()
+	if ok {
+		return ids, nil
+	}
+
+	filters, err := s.loadFilters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) > 0 {
+		node, err := s.nodeRepo.GetByID(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			ids = evaluateNodeFilters(filters, node)
+		}
+	}
+
+	s.mu.Lock()
+	if s.gen == gen {
+		s.matches[nodeID] = ids
+	}
+	s.mu.Unlock()
+	return ids, nil
+}
+
+// EvaluateNode returns the IDs of the filters node matches, evaluated
+// directly against the given attributes without consulting the cache.
+func (s *NodeFilterService) EvaluateNode(ctx context.Context, node *models.Node) ([]string, error) {
+	filters, err := s.loadFilters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return evaluateNodeFilters(filters, node), nil
+}
+
+// RefreshNode drops the cached evaluation for a node, typically after a
+// heartbeat updated its facts, and reports whether the set of matching
+// filters changed as a result.
+func (s *NodeFilterService) RefreshNode(ctx context.Context, nodeID string) (bool, error) {
+	s.mu.Lock()
+	before, cached := s.matches[nodeID]
+	delete(s.matches, nodeID)
+	s.mu.Unlock()
+
+	after, err := s.MatchingFilterIDs(ctx, nodeID)
+	if err != nil {
+		return false, err
+	}
+	return cached && !slices.Equal(before, after), nil
+}
+
+func (s *NodeFilterService) loadFilters(ctx context.Context) ([]*models.NodeFilter, error) {
+	s.mu.Lock()
+	filters := s.filters
+	gen := s.gen
+	s.mu.Unlock()
+	if filters != nil {
+		return filters, nil
+	}
+
+	filters, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if filters == nil {
+		filters = []*models.NodeFilter{}
+	}
+
+	s.mu.Lock()
+	if s.gen == gen {
+		s.filters = filters
+	}
+	s.mu.Unlock()
+	return filters, nil
+}
+
+func (s *NodeFilterService) invalidateAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gen++
+	s.filters = nil
+	s.matches = make(map[string][]string)
+}
+
+// evaluateNodeFilters returns the sorted IDs of the filters matching node.
+func evaluateNodeFilters(filters []*models.NodeFilter, node *models.Node) []string {
+	var ids []string
+	for _, f := range filters {
+		if matchNodeFilter(f.Criteria, node) {
+			ids = append(ids, f.ID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// matchNodeFilter reports whether node satisfies every criterion in c.
+func matchNodeFilter(c models.NodeFilterCriteria, node *models.Node) bool {
+	if !matchPattern(c.NamePattern, node.Name) ||
+		!matchPattern(c.OSName, deref(node.OSName)) ||
+		!matchPattern(c.OSVersion, deref(node.OSVersion)) ||
+		!matchPattern(c.AgentVersion, deref(node.AgentVersion)) {
+		return false
+	}
+	if c.DesktopEnv == "" {
+		return true
+	}
+	// desktop_env is stored as a comma-separated list of environments.
+	for _, de := range strings.Split(deref(node.DesktopEnv), ",") {
+		if matchPattern(c.DesktopEnv, strings.TrimSpace(de)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern reports whether value matches the case-insensitive glob
+// pattern. An empty pattern matches anything.
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(value))
+	return err == nil && ok
+}
+
+func validateNodeFilterCriteria(c models.NodeFilterCriteria) error {
+	fields := []struct{ name, pattern string }{
+		{"name_pattern", c.NamePattern},
+		{"os_name", c.OSName},
+		{"os_version", c.OSVersion},
+		{"desktop_env", c.DesktopEnv},
+		{"agent_version", c.AgentVersion},
+	}
+	empty := true
+	for _, f := range fields {
+		if f.pattern == "" {
+			continue
+		}
+		empty = false
+		if _, err := path.Match(f.pattern, ""); err != nil {
+			return fmt.Errorf("invalid %s pattern %q: %w", f.name, f.pattern, err)
+		}
+	}
+	if empty {
+		return fmt.Errorf("at least one filter criterion is required")
+	}
+	return nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestMatchNodeFilter(t *testing.T) {
+	node := &models.Node{
+		Name:         "lap-042.example.com",
+		OSName:       strPtr("Fedora Linux"),
+		OSVersion:    strPtr("41"),
+		DesktopEnv:   strPtr("KDE, GNOME"),
+		AgentVersion: strPtr("1.4.2"),
+	}
+
+	tests := []struct {
+		name     string
+		criteria models.NodeFilterCriteria
+		want     bool
+	}{
+		{"name glob", models.NodeFilterCriteria{NamePattern: "lap-*"}, true},
+		{"name mismatch", models.NodeFilterCriteria{NamePattern: "srv-*"}, false},
+		{"case insensitive", models.NodeFilterCriteria{OSName: "fedora*"}, true},
+		{"any desktop env", models.NodeFilterCriteria{DesktopEnv: "gnome"}, true},
+		{"desktop env mismatch", models.NodeFilterCriteria{DesktopEnv: "xfce"}, false},
+		{"all criteria", models.NodeFilterCriteria{NamePattern: "lap-*", OSVersion: "4?", AgentVersion: "1.4.*", DesktopEnv: "KDE"}, true},
+		{"one criterion fails", models.NodeFilterCriteria{NamePattern: "lap-*", OSVersion: "40"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchNodeFilter(tt.criteria, node); got != tt.want {
+				t.Errorf("matchNodeFilter(%+v) = %v, want %v", tt.criteria, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchNodeFilter_MissingFacts(t *testing.T) {
+	node := &models.Node{Name: "new-node"}
+	if matchNodeFilter(models.NodeFilterCriteria{DesktopEnv: "GNOME"}, node) {
+		t.Error("node without reported desktop environments must not match a desktop_env filter")
+	}
+	if matchNodeFilter(models.NodeFilterCriteria{OSName: "Fedora*"}, node) {
+		t.Error("node without reported OS must not match an os_name filter")
+	}
+}
+
+func TestEvaluateNodeFilters(t *testing.T) {
+	node := &models.Node{Name: "lap-1", DesktopEnv: strPtr("GNOME")}
+	filters := []*models.NodeFilter{
+		{ID: "b", Criteria: models.NodeFilterCriteria{DesktopEnv: "GNOME"}},
+		{ID: "c", Criteria: models.NodeFilterCriteria{DesktopEnv: "KDE"}},
+		{ID: "a", Criteria: models.NodeFilterCriteria{NamePattern: "lap-*"}},
+	}
+	got := evaluateNodeFilters(filters, node)
+	if want := []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("evaluateNodeFilters() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateNodeFilterCriteria(t *testing.T) {
+	if err := validateNodeFilterCriteria(models.NodeFilterCriteria{}); err == nil {
+		t.Error("expected error for empty criteria")
+	}
+	if err := validateNodeFilterCriteria(models.NodeFilterCriteria{NamePattern: "lap-["}); err == nil {
+		t.Error("expected error for malformed pattern")
+	}
+	if err := validateNodeFilterCriteria(models.NodeFilterCriteria{OSName: "Ubuntu"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -14,22 +14,36 @@ import (
 
 // NodeGroupService handles node group business logic
 type NodeGroupService struct {
-	repo *database.NodeGroupRepository
+	repo        *database.NodeGroupRepository
+	bindingRepo *database.PolicyBindingRepository
+	nodeRepo    *database.NodeRepository
 }
 
+// MaxGroupMembersPerRequest caps the number of nodes in one bulk node
+// group membership change.
+const MaxGroupMembersPerRequest = 1000
+
 // NewNodeGroupService creates a new NodeGroupService
 func NewNodeGroupService(repo *database.NodeGroupRepository) *NodeGroupService {
 	return &NodeGroupService{repo: repo}
 }
 
+// WithBindings enables GetWithDetails.
+func (s *NodeGroupService) WithBindings(bindingRepo *database.PolicyBindingRepository) *NodeGroupService {
+	s.bindingRepo = bindingRepo
+	return s
+}
+
 // CreateNodeGroup creates a new node group
 func (s *NodeGroupService) CreateNodeGroup(ctx context.Context, req *models.CreateNodeGroupRequest) (*models.NodeGroup, error) {
 	if req.Name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 	ng := &models.NodeGroup{
-		Name:        req.Name,
-		Description: req.Description,
+		Name:          req.Name,
+		Description:   req.Description,
+		InventoryOnly: req.InventoryOnly,
+		Quarantine:    req.Quarantine,
 	}
 	if err := s.repo.Create(ctx, ng); err != nil {
 		return nil, fmt.Errorf("failed to create node group: %w", err)
@@ -42,11 +56,50 @@ func (s *NodeGroupService) GetNodeGroup(ctx context.Context, id string) (*models
 	return s.repo.GetByID(ctx, id)
 }
 
+// WithMembers enables AddMembers and RemoveMembers.
+func (s *NodeGroupService) WithMembers(nodeRepo *database.NodeRepository) *NodeGroupService {
+	s.nodeRepo = nodeRepo
+	return s
+}
+
+// GetWithDetails retrieves a node group by ID with its member nodes and
+// the policy bindings targeting it, or nil if the group does not exist.
+func (s *NodeGroupService) GetWithDetails(ctx context.Context, id string) (*models.NodeGroupDetails, error) {
+	if s.bindingRepo == nil {
+		return nil, fmt.Errorf("binding repository not configured")
+	}
+	group, err := s.repo.GetByID(ctx, id)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	nodes, err := s.repo.ListMembers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := s.bindingRepo.ListByGroupID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if bindings == nil {
+		bindings = []*models.PolicyBindingWithDetails{}
+	}
+	return &models.NodeGroupDetails{NodeGroup: *group, Nodes: nodes, Bindings: bindings}, nil
+}
+
 // ListNodeGroups returns all node groups
 func (s *NodeGroupService) ListNodeGroups(ctx context.Context) ([]*models.NodeGroup, error) {
 	return s.repo.ListAll(ctx)
 }
 
+// ListNodeGroupsPaginated returns page p of all node groups.
+func (s *NodeGroupService) ListNodeGroupsPaginated(ctx context.Context, p models.Pagination) (*models.ListResponse[*models.NodeGroup], error) {
+	groups, total, err := s.repo.ListPaginated(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewListResponse(groups, total, p), nil
+}
+
 // UpdateNodeGroup updates a node group
 func (s *NodeGroupService) UpdateNodeGroup(ctx context.Context, id string, req *models.UpdateNodeGroupRequest) (*models.NodeGroup, error) {
 	if err := s.repo.Update(ctx, id, req); err != nil {
@@ -71,3 +124,80 @@ func (s *NodeGroupService) DeleteNodeGroup(ctx context.Context, id string) error
 func (s *NodeGroupService) CountNodesByGroupID(ctx context.Context, groupID string) (int, error) {
 	return s.repo.CountNodesByGroupID(ctx, groupID)
 }
+
+// IsNodeInventoryOnly reports whether policies must not be enforced on the
+// node because one of its groups is in inventory-only mode.
+func (s *NodeGroupService) IsNodeInventoryOnly(ctx context.Context, nodeID string) (bool, error) {
+	return s.repo.IsNodeInventoryOnly(ctx, nodeID)
+}
+
+// QuarantineGroupIDs returns the node groups whose bindings make up the
+// lockdown policy set of quarantined nodes.
+func (s *NodeGroupService) QuarantineGroupIDs(ctx context.Context) ([]string, error) {
+	return s.repo.ListQuarantineGroupIDs(ctx)
+}
+
+// AddMembers adds nodes to a node group at once. req must have passed
+// ValidateNodeGroupMembersRequest.
+func (s *NodeGroupService) AddMembers(ctx context.Context, groupID string, req *models.NodeGroupMembersRequest) (*models.NodeGroupMembersResponse, error) {
+	if err := s.checkGroupExists(ctx, groupID); err != nil {
+		return nil, err
+	}
+	added, err := s.nodeRepo.AddManyToGroup(ctx, groupID, req.NodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NodeGroupMembersResponse{Added: added, Skipped: len(req.NodeIDs) - added}, nil
+}
+
+// RemoveMembers removes nodes from a node group at once. req must have
+// passed ValidateNodeGroupMembersRequest.
+func (s *NodeGroupService) RemoveMembers(ctx context.Context, groupID string, req *models.NodeGroupMembersRequest) (*models.NodeGroupMembersResponse, error) {
+	if err := s.checkGroupExists(ctx, groupID); err != nil {
+		return nil, err
+	}
+	removed, err := s.nodeRepo.RemoveManyFromGroup(ctx, groupID, req.NodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &models.NodeGroupMembersResponse{Removed: removed, Skipped: len(req.NodeIDs) - removed}, nil
+}
+
+// checkGroupExists returns an error if the node group does not exist.
+func (s *NodeGroupService) checkGroupExists(ctx context.Context, groupID string) error {
+	if s.nodeRepo == nil {
+		return fmt.Errorf("node repository not configured")
+	}
+	group, err := s.repo.GetByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("node group not found")
+	}
+	return nil
+}
+
+// ValidateNodeGroupMembersRequest checks that req names between one and
+// MaxGroupMembersPerRequest nodes. Duplicate node IDs are removed.
+func ValidateNodeGroupMembersRequest(req *models.NodeGroupMembersRequest) error {
+	if len(req.NodeIDs) == 0 {
+		return fmt.Errorf("node_ids is required")
+	}
+	if len(req.NodeIDs) > MaxGroupMembersPerRequest {
+		return fmt.Errorf("at most %d nodes can be changed at once", MaxGroupMembersPerRequest)
+	}
+	seen := make(map[string]bool, len(req.NodeIDs))
+	ids := req.NodeIDs[:0]
+	for _, id := range req.NodeIDs {
+		if id == "" {
+			return fmt.Errorf("node_ids must not contain empty IDs")
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	req.NodeIDs = ids
+	return nil
+}
@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func TestValidateNodeGroupMembersRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     []string
+		want    []string
+		wantErr bool
+	}{
+		{"ids", []string{"a", "b"}, []string{"a", "b"}, false},
+		{"duplicates", []string{"a", "b", "a"}, []string{"a", "b"}, false},
+		{"none", nil, nil, true},
+		{"empty id", []string{"a", ""}, nil, true},
+		{"too many", make([]string, MaxGroupMembersPerRequest+1), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := models.NodeGroupMembersRequest{NodeIDs: tt.ids}
+			err := ValidateNodeGroupMembersRequest(&req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateNodeGroupMembersRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !slices.Equal(req.NodeIDs, tt.want) {
+				t.Errorf("NodeIDs = %v, want %v", req.NodeIDs, tt.want)
+			}
+		})
+	}
+}
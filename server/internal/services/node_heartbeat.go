@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"log"
+	"maps"
+	"sync"
+	"time"
+)
+
+// heartbeatStore is the part of the node repository heartbeats write to.
+type heartbeatStore interface {
+	UpdateHeartbeat(ctx context.Context, id string, facts map[string]string) error
+	UpdateLastSeen(ctx context.Context, seen map[string]time.Time) error
+}
+
+// heartbeatCoalescer cuts the database writes caused by heartbeats. A
+// heartbeat whose facts differ from the last ones written for the node is
+// written at once; otherwise only its time is kept in memory, and the
+// buffered last_seen times are written in one statement by flush.
+type heartbeatCoalescer struct {
+	store heartbeatStore
+
+	mu      sync.Mutex
+	facts   map[string]map[string]string // node ID → facts last written
+	pending map[string]time.Time         // node ID → unwritten last_seen
+}
+
+func newHeartbeatCoalescer(store heartbeatStore) *heartbeatCoalescer {
+	return &heartbeatCoalescer{
+		store:   store,
+		facts:   make(map[string]map[string]string),
+		pending: make(map[string]time.Time),
+	}
+}
+
+// record handles one heartbeat of nodeID reporting facts.
+func (c *heartbeatCoalescer) record(ctx context.Context, nodeID string, facts map[string]string) error {
+	c.mu.Lock()
+	if prev, ok := c.facts[nodeID]; ok && maps.Equal(prev, facts) {
+		c.pending[nodeID] = time.Now()
+		c.mu.Unlock()
+		return nil
+	}
+	c.mu.Unlock()
+
+	if err := c.store.UpdateHeartbeat(ctx, nodeID, facts); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.facts[nodeID] = facts
+	delete(c.pending, nodeID) // last_seen was written with the facts
+	c.mu.Unlock()
+	return nil
+}
+
+// forget drops everything buffered for nodeID, e.g. after it was deleted.
+func (c *heartbeatCoalescer) forget(nodeID string) {
+	c.mu.Lock()
+	delete(c.facts, nodeID)
+	delete(c.pending, nodeID)
+	c.mu.Unlock()
+}
+
+// flush writes the buffered last_seen times. On failure they are kept for
+// the next flush unless a newer heartbeat arrived in the meantime.
+func (c *heartbeatCoalescer) flush(ctx context.Context) error {
+	c.mu.Lock()
+	seen := c.pending
+	c.pending = make(map[string]time.Time)
+	c.mu.Unlock()
+
+	if len(seen) == 0 {
+		return nil
+	}
+	err := c.store.UpdateLastSeen(ctx, seen)
+	if err != nil {
+		c.mu.Lock()
+		for id, t := range seen {
+			if _, newer := c.pending[id]; !newer {
+				c.pending[id] = t
+			}
+		}
+		c.mu.Unlock()
+	}
+	return err
+}
+
+// run flushes every interval until ctx is done, then flushes once more.
+func (c *heartbeatCoalescer) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.flush(ctx); err != nil {
+				log.Printf("Failed to flush heartbeats: %v", err)
+			}
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := c.flush(flushCtx); err != nil {
+				log.Printf("Failed to flush heartbeats on shutdown: %v", err)
+			}
+			cancel()
+			return
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeHeartbeatStore struct {
+	factWrites int
+	flushes    []map[string]time.Time
+	flushErr   error
+}
+
+func (f *fakeHeartbeatStore) UpdateHeartbeat(context.Context, string, map[string]string) error {
+	f.factWrites++
+	return nil
+}
+
+func (f *fakeHeartbeatStore) UpdateLastSeen(_ context.Context, seen map[string]time.Time) error {
+	if f.flushErr != nil {
+		return f.flushErr
+	}
+	f.flushes = append(f.flushes, seen)
+	return nil
+}
+
+func TestHeartbeatCoalescer_BuffersUnchangedFacts(t *testing.T) {
+	store := &fakeHeartbeatStore{}
+	c := newHeartbeatCoalescer(store)
+	ctx := context.Background()
+	facts := map[string]string{"os_name": "Fedora"}
+
+	for i := 0; i < 5; i++ {
+		if err := c.record(ctx, "node-1", map[string]string{"os_name": "Fedora"}); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	if err := c.record(ctx, "node-2", facts); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if store.factWrites != 2 {
+		t.Errorf("fact writes = %d, want 2 (first heartbeat of each node)", store.factWrites)
+	}
+
+	if err := c.flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(store.flushes) != 1 || len(store.flushes[0]) != 1 {
+		t.Fatalf("flushes = %v, want one flush of node-1", store.flushes)
+	}
+	if _, ok := store.flushes[0]["node-1"]; !ok {
+		t.Errorf("flush = %v, want node-1", store.flushes[0])
+	}
+
+	if err := c.flush(ctx); err != nil || len(store.flushes) != 1 {
+		t.Errorf("empty flush wrote: err=%v flushes=%d", err, len(store.flushes))
+	}
+}
+
+func TestHeartbeatCoalescer_ChangedFactsWriteImmediately(t *testing.T) {
+	store := &fakeHeartbeatStore{}
+	c := newHeartbeatCoalescer(store)
+	ctx := context.Background()
+
+	_ = c.record(ctx, "node-1", map[string]string{"agent_version": "1.0"})
+	_ = c.record(ctx, "node-1", map[string]string{"agent_version": "1.0"})
+	_ = c.record(ctx, "node-1", map[string]string{"agent_version": "1.1"})
+
+	if store.factWrites != 2 {
+		t.Errorf("fact writes = %d, want 2", store.factWrites)
+	}
+	if err := c.flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(store.flushes) != 0 {
+		t.Errorf("flushes = %v, want none: the last heartbeat was written with its facts", store.flushes)
+	}
+}
+
+func TestHeartbeatCoalescer_FailedFlushIsRetried(t *testing.T) {
+	store := &fakeHeartbeatStore{flushErr: errors.New("db down")}
+	c := newHeartbeatCoalescer(store)
+	ctx := context.Background()
+
+	_ = c.record(ctx, "node-1", nil)
+	_ = c.record(ctx, "node-1", nil)
+	if err := c.flush(ctx); err == nil {
+		t.Fatal("expected flush error")
+	}
+
+	store.flushErr = nil
+	if err := c.flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(store.flushes) != 1 || len(store.flushes[0]) != 1 {
+		t.Errorf("flushes = %v, want node-1 retried", store.flushes)
+	}
+}
+
+func TestHeartbeatCoalescer_Forget(t *testing.T) {
+	store := &fakeHeartbeatStore{}
+	c := newHeartbeatCoalescer(store)
+	ctx := context.Background()
+
+	_ = c.record(ctx, "node-1", nil)
+	_ = c.record(ctx, "node-1", nil)
+	c.forget("node-1")
+
+	if err := c.flush(ctx); err != nil || len(store.flushes) != 0 {
+		t.Errorf("flush after forget: err=%v flushes=%v", err, store.flushes)
+	}
+	_ = c.record(ctx, "node-1", nil)
+	if store.factWrites != 2 {
+		t.Errorf("fact writes = %d, want 2: a forgotten node is written again", store.factWrites)
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// MaxLogCaptureBytes bounds the stored size of a single log capture. Larger
+// uploads keep only their most recent output.
+const MaxLogCaptureBytes = 1 << 20
+
+// NodeLogCaptureService handles on-demand captures of agent log buffers
+type NodeLogCaptureService struct {
+	repo *database.NodeLogCaptureRepository
+}
+
+// NewNodeLogCaptureService creates a new NodeLogCaptureService
+func NewNodeLogCaptureService(repo *database.NodeLogCaptureRepository) *NodeLogCaptureService {
+	return &NodeLogCaptureService{repo: repo}
+}
+
+// RequestCapture records a pending log capture for a node. The caller
+// delivers the returned capture ID to the agent.
+func (s *NodeLogCaptureService) RequestCapture(ctx context.Context, nodeID, requestedBy string) (*models.NodeLogCapture, error) {
+	c := &models.NodeLogCapture{NodeID: nodeID, RequestedBy: requestedBy}
+	if err := s.repo.Create(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CancelCapture removes a pending capture whose request could not be
+// delivered.
+func (s *NodeLogCaptureService) CancelCapture(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// CompleteCapture stores logs uploaded by a node for one of its pending
+// captures. Content beyond MaxLogCaptureBytes is cut from the start.
+func (s *NodeLogCaptureService) CompleteCapture(ctx context.Context, id, nodeID string, content []byte, truncated bool, receivedAt time.Time) error {
+	content, cut := truncateLogContent(content)
+	found, err := s.repo.Complete(ctx, id, nodeID, content, truncated || cut, receivedAt)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no pending log capture %s for node %s", id, nodeID)
+	}
+	return nil
+}
+
+// ListCaptures returns a node's log captures, newest first
+func (s *NodeLogCaptureService) ListCaptures(ctx context.Context, nodeID string) ([]*models.NodeLogCapture, error) {
+	captures, err := s.repo.ListByNode(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if captures == nil {
+		captures = []*models.NodeLogCapture{}
+	}
+	return captures, nil
+}
+
+// GetCapture returns a node's log capture and its content, or nil if it
+// does not exist.
+func (s *NodeLogCaptureService) GetCapture(ctx context.Context, id, nodeID string) (*models.NodeLogCapture, []byte, error) {
+	return s.repo.GetContent(ctx, id, nodeID)
+}
+
+// truncateLogContent keeps the last MaxLogCaptureBytes of content, starting
+// at a line boundary, and reports whether anything was dropped.
+func truncateLogContent(content []byte) ([]byte, bool) {
+	if len(content) <= MaxLogCaptureBytes {
+		return content, false
+	}
+	tail := content[len(content)-MaxLogCaptureBytes:]
+	for i, b := range tail {
+		if b == '\n' {
+			return tail[i+1:], true
+		}
+	}
+	return tail, true
+}
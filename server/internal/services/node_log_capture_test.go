@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import "testing"
+
+func TestTruncateLogContent(t *testing.T) {
+	short := []byte("line 1\nline 2\n")
+	if got, cut := truncateLogContent(short); cut || string(got) != string(short) {
+		t.Errorf("truncateLogContent(short) = %q, %v; want unchanged", got, cut)
+	}
+
+	long := make([]byte, 0, MaxLogCaptureBytes+64)
+	for len(long) <= MaxLogCaptureBytes {
+		long = append(long, "0123456789abcdef0123456789abcdef\n"...)
+	}
+	got, cut := truncateLogContent(long)
+	if !cut {
+		t.Fatal("truncateLogContent(long) should report truncation")
+	}
+	if len(got) > MaxLogCaptureBytes {
+		t.Errorf("len = %d, want <= %d", len(got), MaxLogCaptureBytes)
+	}
+	if got[0] != '0' {
+		t.Errorf("truncated content should start at a line boundary, got %q", got[:8])
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+	"github.com/VuteTech/Bor/server/internal/models"
+	"github.com/VuteTech/Bor/server/pkg/remediation"
+)
+
+// MaxRemediationOutput bounds the stored output of a single remediation.
+const MaxRemediationOutput = 16 << 10
+
+// NodeRemediationService handles one-time remediation actions on nodes.
+// Only actions from the predefined remediation list are accepted.
+type NodeRemediationService struct {
+	repo *database.NodeRemediationRepository
+}
+
+// NewNodeRemediationService creates a new NodeRemediationService
+func NewNodeRemediationService(repo *database.NodeRemediationRepository) *NodeRemediationService {
+	return &NodeRemediationService{repo: repo}
+}
+
+// Actions returns the remediation actions that may be requested
+func (s *NodeRemediationService) Actions() []remediation.Action {
+	return remediation.List()
+}
+
+// RequestRemediation records a pending remediation for a node. The caller
+// delivers the returned remediation ID and action to the agent.
+func (s *NodeRemediationService) RequestRemediation(ctx context.Context, nodeID, action, requestedBy string) (*models.NodeRemediation, error) {
+	if err := validateRemediationAction(action); err != nil {
+		return nil, err
+	}
+	rem := &models.NodeRemediation{NodeID: nodeID, Action: action, RequestedBy: requestedBy}
+	if err := s.repo.Create(ctx, rem); err != nil {
+		return nil, err
+	}
+	return rem, nil
+}
+
+// CancelRemediation removes a pending remediation whose request could not
+// be delivered.
+func (s *NodeRemediationService) CancelRemediation(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// CompleteRemediation stores the result a node reported for one of its
+// pending remediations. Output beyond MaxRemediationOutput is cut.
+func (s *NodeRemediationService) CompleteRemediation(ctx context.Context, id, nodeID, action string, success bool, exitCode int, output, errMsg string, completedAt time.Time) error {
+	if len(output) > MaxRemediationOutput {
+		output = strings.ToValidUTF8(output[:MaxRemediationOutput], "")
+	}
+	found, err := s.repo.Complete(ctx, id, nodeID, action, success, exitCode, output, errMsg, completedAt)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("no pending remediation %s (%s) for node %s", id, action, nodeID)
+	}
+	return nil
+}
+
+// ListRemediations returns a node's remediations, newest first
+func (s *NodeRemediationService) ListRemediations(ctx context.Context, nodeID string) ([]*models.NodeRemediation, error) {
+	list, err := s.repo.ListByNode(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = []*models.NodeRemediation{}
+	}
+	return list, nil
+}
+
+// validateRemediationAction rejects anything that is not the name of a
+// predefined remediation action.
+func validateRemediationAction(action string) error {
+	if action == "" {
+		return fmt.Errorf("action is required")
+	}
+	if _, ok := remediation.Lookup(action); !ok {
+		return fmt.Errorf("unknown remediation action %q", action)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidateRemediationAction(t *testing.T) {
+	if err := validateRemediationAction("dconf-update"); err != nil {
+		t.Errorf("dconf-update: unexpected error %v", err)
+	}
+	for _, action := range []string{"", "rm -rf /", "/usr/bin/dconf update", "DCONF-UPDATE"} {
+		if err := validateRemediationAction(action); err == nil {
+			t.Errorf("%q: expected error", action)
+		}
+	}
+}
+
+func TestRequestRemediationRejectsUnknownAction(t *testing.T) {
+	svc := NewNodeRemediationService(nil)
+	if _, err := svc.RequestRemediation(context.Background(), "node-1", "reboot; curl evil", "admin"); err == nil {
+		t.Fatal("expected unknown action to be rejected before touching the database")
+	}
+}
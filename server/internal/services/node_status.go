@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// nodeStatusStore is the part of the node repository the status reaper
+// writes to.
+type nodeStatusStore interface {
+	MarkStale(ctx context.Context, from []string, status, reason string, cutoff time.Time) ([]database.NodeStatusTransition, error)
+}
+
+// nodeStatusReaper marks nodes that stopped sending heartbeats degraded
+// and then offline, so that nodes whose stream died without being closed
+// (e.g. on power loss) do not stay online.
+type nodeStatusReaper struct {
+	store         nodeStatusStore
+	heartbeats    *heartbeatCoalescer // nil when heartbeats are written at once
+	degradedAfter time.Duration
+	offlineAfter  time.Duration
+}
+
+// reap applies the status transitions due at now and returns them.
+// Buffered heartbeats are written first so that they count.
+func (r *nodeStatusReaper) reap(ctx context.Context, now time.Time) ([]database.NodeStatusTransition, error) {
+	if r.heartbeats != nil {
+		if err := r.heartbeats.flush(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	offline, err := r.store.MarkStale(ctx,
+		[]string{models.NodeStatusOnline, models.NodeStatusDegraded},
+		models.NodeStatusOffline,
+		fmt.Sprintf("no heartbeat for %s", r.offlineAfter),
+		now.Add(-r.offlineAfter))
+	if err != nil {
+		return nil, err
+	}
+	degraded, err := r.store.MarkStale(ctx,
+		[]string{models.NodeStatusOnline},
+		models.NodeStatusDegraded,
+		fmt.Sprintf("no heartbeat for %s", r.degradedAfter),
+		now.Add(-r.degradedAfter))
+	if err != nil {
+		return offline, err
+	}
+	return append(offline, degraded...), nil
+}
+
+// run reaps every interval until ctx is done, logging each transition.
+func (r *nodeStatusReaper) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			transitions, err := r.reap(ctx, time.Now())
+			for _, t := range transitions {
+				log.Printf("Node %s (%s) status %s → %s: no heartbeat", t.Name, t.NodeID, t.From, t.To)
+			}
+			if err != nil && ctx.Err() == nil {
+				log.Printf("Failed to update stale node statuses: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// nodeStatusCheckInterval returns how often the reaper runs: often enough
+// that a transition is applied within a quarter of the degraded threshold,
+// but at most every second.
+func nodeStatusCheckInterval(degradedAfter time.Duration) time.Duration {
+	return max(degradedAfter/4, time.Second)
+}
+
+// StartStatusReaper marks nodes degraded once their last heartbeat is older
+// than degradedAfter and offline once it is older than offlineAfter. A
+// degraded node that sends a heartbeat is marked online again. It must be
+// called after StartHeartbeatCoalescing, if that is used. The returned
+// function stops the reaper.
+func (s *NodeService) StartStatusReaper(degradedAfter, offlineAfter time.Duration) (stop func()) {
+	r := &nodeStatusReaper{
+		store:         s.nodeRepo,
+		heartbeats:    s.heartbeats,
+		degradedAfter: degradedAfter,
+		offlineAfter:  offlineAfter,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.run(ctx, nodeStatusCheckInterval(degradedAfter))
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
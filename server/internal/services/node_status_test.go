@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+type markStaleCall struct {
+	from   []string
+	status string
+	cutoff time.Time
+}
+
+type fakeNodeStatusStore struct {
+	calls []markStaleCall
+}
+
+func (f *fakeNodeStatusStore) MarkStale(_ context.Context, from []string, status, _ string, cutoff time.Time) ([]database.NodeStatusTransition, error) {
+	f.calls = append(f.calls, markStaleCall{from: from, status: status, cutoff: cutoff})
+	return []database.NodeStatusTransition{{NodeID: status, From: from[0], To: status}}, nil
+}
+
+func TestNodeStatusReaper_Reap(t *testing.T) {
+	store := &fakeNodeStatusStore{}
+	hb := &fakeHeartbeatStore{}
+	r := &nodeStatusReaper{
+		store:         store,
+		heartbeats:    newHeartbeatCoalescer(hb),
+		degradedAfter: 2 * time.Minute,
+		offlineAfter:  4 * time.Minute,
+	}
+	r.heartbeats.pending["node-1"] = time.Now()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	transitions, err := r.reap(context.Background(), now)
+	if err != nil {
+		t.Fatalf("reap: %v", err)
+	}
+	if len(hb.flushes) != 1 {
+		t.Errorf("flushes = %d, want buffered heartbeats written before reaping", len(hb.flushes))
+	}
+	if len(store.calls) != 2 || len(transitions) != 2 {
+		t.Fatalf("calls = %+v, transitions = %+v", store.calls, transitions)
+	}
+
+	// Offline first, so a node silent past both thresholds goes straight
+	// to offline instead of passing through degraded.
+	off, deg := store.calls[0], store.calls[1]
+	if off.status != models.NodeStatusOffline || !off.cutoff.Equal(now.Add(-4*time.Minute)) ||
+		!slices.Equal(off.from, []string{models.NodeStatusOnline, models.NodeStatusDegraded}) {
+		t.Errorf("offline call = %+v", off)
+	}
+	if deg.status != models.NodeStatusDegraded || !deg.cutoff.Equal(now.Add(-2*time.Minute)) ||
+		!slices.Equal(deg.from, []string{models.NodeStatusOnline}) {
+		t.Errorf("degraded call = %+v", deg)
+	}
+}
+
+func TestNodeStatusCheckInterval(t *testing.T) {
+	if got := nodeStatusCheckInterval(2 * time.Minute); got != 30*time.Second {
+		t.Errorf("interval = %v, want 30s", got)
+	}
+	if got := nodeStatusCheckInterval(time.Second); got != time.Second {
+		t.Errorf("interval = %v, want 1s floor", got)
+	}
+}
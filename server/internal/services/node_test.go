@@ -5,7 +5,10 @@
 package services
 
 import (
+	"encoding/json"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/VuteTech/Bor/server/internal/models"
 )
@@ -16,7 +19,7 @@ func TestIsValidNodeStatus(t *testing.T) {
 		valid  bool
 	}{
 		{models.NodeStatusOnline, true},
-		{models.NodeStatusDegraded, false},
+		{models.NodeStatusDegraded, true},
 		{models.NodeStatusOffline, true},
 		{models.NodeStatusUnknown, true},
 		{"invalid", false},
@@ -33,3 +36,82 @@ func TestIsValidNodeStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBulkDeleteRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.BulkDeleteNodesRequest
+		wantErr bool
+	}{
+		{"ids", models.BulkDeleteNodesRequest{NodeIDs: []string{"a", "b"}, ExpectedCount: 2}, false},
+		{"filter", models.BulkDeleteNodesRequest{FilterID: "f", ExpectedCount: 5}, false},
+		{"neither", models.BulkDeleteNodesRequest{ExpectedCount: 1}, true},
+		{"both", models.BulkDeleteNodesRequest{NodeIDs: []string{"a"}, FilterID: "f", ExpectedCount: 1}, true},
+		{"no expected count", models.BulkDeleteNodesRequest{NodeIDs: []string{"a"}}, true},
+		{"empty id", models.BulkDeleteNodesRequest{NodeIDs: []string{"a", ""}, ExpectedCount: 2}, true},
+		{"too many", models.BulkDeleteNodesRequest{NodeIDs: make([]string, MaxBulkDeleteNodes+1), ExpectedCount: MaxBulkDeleteNodes + 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBulkDeleteRequest(&tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBulkDeleteRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateBulkDeleteRequest_Deduplicates(t *testing.T) {
+	req := models.BulkDeleteNodesRequest{NodeIDs: []string{"a", "b", "a"}, ExpectedCount: 2}
+	if err := ValidateBulkDeleteRequest(&req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(req.NodeIDs) != 2 || req.NodeIDs[0] != "a" || req.NodeIDs[1] != "b" {
+		t.Errorf("NodeIDs = %v, want [a b]", req.NodeIDs)
+	}
+}
+
+func TestNormalizeSessionUsers(t *testing.T) {
+	got := normalizeSessionUsers([]string{"bob", " alice", "", "bob"})
+	want := []string{"alice", "bob"}
+	if !slices.Equal(got, want) {
+		t.Errorf("normalizeSessionUsers() = %v, want %v", got, want)
+	}
+}
+
+func TestHardwareFact(t *testing.T) {
+	if got := hardwareFact(nil); got != "" {
+		t.Errorf("hardwareFact(nil) = %q, want empty", got)
+	}
+
+	boot := time.Date(2026, 10, 1, 8, 30, 42, 0, time.UTC)
+	hw := &models.NodeHardware{
+		MemoryTotalBytes:     16_000_000_000,
+		MemoryAvailableBytes: 9_281_232_000,
+		CPUModel:             "Intel(R) Core(TM) i7-1165G7 @ 2.80GHz",
+		CPUCores:             8,
+		RootDiskTotalBytes:   500_000_000_000,
+		RootDiskFreeBytes:    123_456_789_012,
+		BootTime:             &boot,
+	}
+	var got models.NodeHardware
+	if err := json.Unmarshal([]byte(hardwareFact(hw)), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.MemoryAvailableBytes != 9_280_000_000 || got.RootDiskFreeBytes != 120_000_000_000 {
+		t.Errorf("free memory/disk = %d/%d, want rounded down to 1%% of the totals", got.MemoryAvailableBytes, got.RootDiskFreeBytes)
+	}
+	if got.CPUCores != 8 || got.CPUModel != hw.CPUModel || !got.BootTime.Equal(boot.Truncate(time.Minute)) {
+		t.Errorf("hardware = %+v", got)
+	}
+	if hw.MemoryAvailableBytes != 9_281_232_000 {
+		t.Error("hardwareFact modified its argument")
+	}
+
+	// Small fluctuations encode identically, so heartbeats still coalesce.
+	again := *hw
+	again.MemoryAvailableBytes += 1_000_000
+	if hardwareFact(&again) != hardwareFact(hw) {
+		t.Error("a 1 MB change in free memory changed the stored fact")
+	}
+}
@@ -7,10 +7,15 @@ package services
 import (
 	"context"
 	"fmt"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/VuteTech/Bor/server/internal/database"
 	"github.com/VuteTech/Bor/server/internal/models"
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 // timeNow is a variable for testing
@@ -51,16 +56,77 @@ func (s *PolicyService) ListPoliciesForNodeGroups(ctx context.Context, groupIDs
 	return s.bindingRepo.ListPoliciesByGroupIDs(ctx, groupIDs)
 }
 
+// ListPoliciesForSessionUsers returns released policies with enabled
+// bindings to any user group that one of the given users belongs to. It is
+// the companion of ListPoliciesForNodeGroups for the users logged in on a
+// node; each policy's ScopeUsers lists the users it applies to.
+func (s *PolicyService) ListPoliciesForSessionUsers(ctx context.Context, usernames []string) ([]*models.Policy, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	if s.bindingRepo == nil {
+		return nil, fmt.Errorf("binding repository not configured")
+	}
+	return s.bindingRepo.ListPoliciesByUsernames(ctx, usernames)
+}
+
+// ListPoliciesForTargets returns released policies with enabled bindings for
+// any of the given node groups or saved node filters.
+func (s *PolicyService) ListPoliciesForTargets(ctx context.Context, groupIDs, filterIDs []string) ([]*models.Policy, error) {
+	if len(groupIDs) == 0 && len(filterIDs) == 0 {
+		return nil, nil
+	}
+	if s.bindingRepo == nil {
+		return nil, fmt.Errorf("binding repository not configured")
+	}
+	return s.bindingRepo.ListPoliciesByTargets(ctx, groupIDs, filterIDs)
+}
+
+// ListStaleBindings returns the enabled bindings of deprecated and archived
+// policies.
+func (s *PolicyService) ListStaleBindings(ctx context.Context) ([]*models.StaleBinding, error) {
+	if s.bindingRepo == nil {
+		return nil, fmt.Errorf("binding repository not configured")
+	}
+	return s.bindingRepo.ListStaleBindings(ctx)
+}
+
 // ListAllPolicies returns all policies
 func (s *PolicyService) ListAllPolicies(ctx context.Context) ([]*models.Policy, error) {
 	return s.policyRepo.ListAll(ctx)
 }
 
+// ListAllPoliciesPaginated returns page p of all policies regardless of
+// state.
+func (s *PolicyService) ListAllPoliciesPaginated(ctx context.Context, p models.Pagination) (*models.ListResponse[*models.Policy], error) {
+	policies, total, err := s.policyRepo.ListPaginated(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewListResponse(policies, total, p), nil
+}
+
 // GetPolicy retrieves a policy by ID
 func (s *PolicyService) GetPolicy(ctx context.Context, id string) (*models.Policy, error) {
 	return s.policyRepo.GetByID(ctx, id)
 }
 
+// RenderPolicy returns the files a policy renders to on an agent. Only
+// KConfig policies are supported.
+func (s *PolicyService) RenderPolicy(ctx context.Context, id string) ([]models.RenderedPolicyFile, error) {
+	policy, err := s.policyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy: %w", err)
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("policy not found")
+	}
+	if policy.Type != "Kconfig" {
+		return nil, fmt.Errorf("rendering is only supported for Kconfig policies (policy type: %s)", policy.Type)
+	}
+	return RenderKConfigPolicy(policy.Content)
+}
+
 // isValidState checks if the given state is a valid policy state
 func isValidState(state string) bool {
 	switch state {
@@ -71,13 +137,104 @@ func isValidState(state string) bool {
 	}
 }
 
-// CreatePolicy creates a new policy (always starts in DRAFT state)
-func (s *PolicyService) CreatePolicy(ctx context.Context, req *models.CreatePolicyRequest, createdBy string) (*models.Policy, error) {
+// validateContactLoss checks a policy's dead-man's-switch settings. Revert and
+// activate need a positive TTL to know when contact counts as lost.
+func validateContactLoss(action string, ttlSeconds int) error {
+	if ttlSeconds < 0 {
+		return fmt.Errorf("contact_loss_ttl_seconds must not be negative")
+	}
+	switch action {
+	case models.ContactLossKeep:
+		return nil
+	case models.ContactLossRevert, models.ContactLossActivate:
+		if ttlSeconds == 0 {
+			return fmt.Errorf("contact_loss_ttl_seconds is required for contact loss action %q", action)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid contact loss action: %s (valid actions: keep, revert, activate)", action)
+	}
+}
+
+// maxCheckTimeoutSeconds caps how long an agent may run a compliance check.
+const maxCheckTimeoutSeconds = 300
+
+// validateComplianceCheck checks a policy's optional compliance check
+// command. The agent executes it without a shell, so it must be an
+// absolute path.
+func validateComplianceCheck(command string, args []string, timeoutSeconds int) error {
+	if command == "" {
+		if len(args) > 0 {
+			return fmt.Errorf("check_args requires check_command")
+		}
+		return nil
+	}
+	if !path.IsAbs(command) {
+		return fmt.Errorf("check_command must be an absolute path")
+	}
+	if timeoutSeconds < 0 || timeoutSeconds > maxCheckTimeoutSeconds {
+		return fmt.Errorf("check_timeout_seconds must be between 0 and %d", maxCheckTimeoutSeconds)
+	}
+	return nil
+}
+
+// maxPreconditions caps how many preconditions a single policy may carry.
+const maxPreconditions = 16
+
+// validatePreconditions checks a policy's agent-evaluated preconditions.
+func validatePreconditions(preconditions []models.PolicyPrecondition) error {
+	if len(preconditions) > maxPreconditions {
+		return fmt.Errorf("a policy may have at most %d preconditions", maxPreconditions)
+	}
+	for i, p := range preconditions {
+		if p.Value == "" {
+			return fmt.Errorf("precondition %d: value is required", i+1)
+		}
+		switch p.Type {
+		case models.PreconditionFileExists:
+			if !path.IsAbs(p.Value) {
+				return fmt.Errorf("precondition %d: file_exists requires an absolute path", i+1)
+			}
+		case models.PreconditionCommandExists, models.PreconditionDesktopInstalled, models.PreconditionDesktopSession:
+		default:
+			return fmt.Errorf("precondition %d: invalid type %q (valid types: file_exists, command_exists, desktop_installed, desktop_session)", i+1, p.Type)
+		}
+	}
+	return nil
+}
+
+// validateCreatePolicyRequest checks a request to create a policy.
+func validateCreatePolicyRequest(req *models.CreatePolicyRequest) error {
 	if req.Name == "" {
-		return nil, fmt.Errorf("policy name is required")
+		return fmt.Errorf("policy name is required")
 	}
 	if req.Type == "" {
-		return nil, fmt.Errorf("policy type is required")
+		return fmt.Errorf("policy type is required")
+	}
+	contactLossAction := req.ContactLossAction
+	if contactLossAction == "" {
+		contactLossAction = models.ContactLossKeep
+	}
+	if err := validateContactLoss(contactLossAction, req.ContactLossTTLSeconds); err != nil {
+		return err
+	}
+	if err := validateComplianceCheck(req.CheckCommand, req.CheckArgs, req.CheckTimeoutSeconds); err != nil {
+		return err
+	}
+	if err := validatePreconditions(req.Preconditions); err != nil {
+		return err
+	}
+	return CheckPolicyContent(req.Type, req.Content)
+}
+
+// CreatePolicy creates a new policy (always starts in DRAFT state)
+func (s *PolicyService) CreatePolicy(ctx context.Context, req *models.CreatePolicyRequest, createdBy string) (*models.Policy, error) {
+	if err := validateCreatePolicyRequest(req); err != nil {
+		return nil, err
+	}
+	contactLossAction := req.ContactLossAction
+	if contactLossAction == "" {
+		contactLossAction = models.ContactLossKeep
 	}
 
 	policy := &models.Policy{
@@ -88,6 +245,15 @@ func (s *PolicyService) CreatePolicy(ctx context.Context, req *models.CreatePoli
 		Version:     1,
 		State:       models.PolicyStateDraft,
 		CreatedBy:   createdBy,
+
+		ContactLossAction:     contactLossAction,
+		ContactLossTTLSeconds: req.ContactLossTTLSeconds,
+
+		CheckCommand:        req.CheckCommand,
+		CheckArgs:           req.CheckArgs,
+		CheckTimeoutSeconds: req.CheckTimeoutSeconds,
+
+		Preconditions: req.Preconditions,
 	}
 
 	if err := s.policyRepo.Create(ctx, policy); err != nil {
@@ -123,6 +289,36 @@ func (s *PolicyService) UpdatePolicy(ctx context.Context, id string, req *models
 	if req.Content != nil {
 		policy.Content = *req.Content
 	}
+	if req.ContactLossAction != nil {
+		policy.ContactLossAction = *req.ContactLossAction
+	}
+	if req.ContactLossTTLSeconds != nil {
+		policy.ContactLossTTLSeconds = *req.ContactLossTTLSeconds
+	}
+	if req.CheckCommand != nil {
+		policy.CheckCommand = *req.CheckCommand
+	}
+	if req.CheckArgs != nil {
+		policy.CheckArgs = *req.CheckArgs
+	}
+	if req.CheckTimeoutSeconds != nil {
+		policy.CheckTimeoutSeconds = *req.CheckTimeoutSeconds
+	}
+	if req.Preconditions != nil {
+		policy.Preconditions = *req.Preconditions
+	}
+	if err := validateContactLoss(policy.ContactLossAction, policy.ContactLossTTLSeconds); err != nil {
+		return nil, err
+	}
+	if err := validateComplianceCheck(policy.CheckCommand, policy.CheckArgs, policy.CheckTimeoutSeconds); err != nil {
+		return nil, err
+	}
+	if err := validatePreconditions(policy.Preconditions); err != nil {
+		return nil, err
+	}
+	if err := CheckPolicyContent(policy.Type, policy.Content); err != nil {
+		return nil, err
+	}
 
 	if err := s.policyRepo.Update(ctx, policy); err != nil {
 		return nil, fmt.Errorf("failed to update policy: %w", err)
@@ -236,6 +432,40 @@ func (s *PolicyService) DeletePolicy(ctx context.Context, id string) error {
 	return nil
 }
 
+// CheckPolicyContent reports whether content decodes into the protobuf
+// message of policyType the same way it is decoded when the policy is sent
+// to agents. Unlike the validation on release it accepts incomplete drafts.
+// Empty content and unknown types are accepted.
+func CheckPolicyContent(policyType, content string) error {
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+	opts := protojson.UnmarshalOptions{DiscardUnknown: true}
+	var msg proto.Message
+	switch policyType {
+	case "Firefox":
+		msg = &pb.FirefoxPolicy{}
+	case "Kconfig":
+		// KConfig content is decoded strictly for agents.
+		opts.DiscardUnknown = false
+		msg = &pb.KConfigPolicy{}
+	case "Chrome":
+		msg = &pb.ChromePolicy{}
+	case "Dconf":
+		msg = &pb.DConfPolicy{}
+	case "Polkit":
+		msg = &pb.PolkitPolicy{}
+	case "Sysctl":
+		msg = &pb.SysctlPolicy{}
+	default:
+		return nil
+	}
+	if err := opts.Unmarshal([]byte(content), msg); err != nil {
+		return fmt.Errorf("invalid %s policy content: %w", policyType, err)
+	}
+	return nil
+}
+
 // validatePolicyContent dispatches to the type-specific validator.
 // Unknown types are accepted (no validator) to remain forward-compatible.
 func validatePolicyContent(policyType, content string) error {
@@ -248,6 +478,8 @@ func validatePolicyContent(policyType, content string) error {
 		return ValidateChromeContent(content)
 	case "Dconf":
 		return ValidateDConfPolicy(content)
+	case "Sysctl":
+		return ValidateSysctlPolicy(content)
 	}
 	return nil
 }
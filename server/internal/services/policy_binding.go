@@ -7,6 +7,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/VuteTech/Bor/server/internal/database"
 	"github.com/VuteTech/Bor/server/internal/models"
@@ -17,24 +18,73 @@ type PolicyBindingService struct {
 	repo          *database.PolicyBindingRepository
 	policyRepo    *database.PolicyRepository
 	nodeGroupRepo *database.NodeGroupRepository
+	nodeRepo      *database.NodeRepository
+	filterSvc     *NodeFilterService
+	userGroupRepo *database.UserGroupRepository
+}
+
+// userScopedPolicyTypes are the policy types that may be bound to a user
+// group. The agent applies them per logged-in user; the other types
+// configure the whole machine and can only be bound to nodes.
+var userScopedPolicyTypes = map[string]bool{
+	"Kconfig": true,
 }
 
 // NewPolicyBindingService creates a new PolicyBindingService
-func NewPolicyBindingService(repo *database.PolicyBindingRepository, policyRepo *database.PolicyRepository, nodeGroupRepo *database.NodeGroupRepository) *PolicyBindingService {
+func NewPolicyBindingService(repo *database.PolicyBindingRepository, policyRepo *database.PolicyRepository, nodeGroupRepo *database.NodeGroupRepository, nodeRepo *database.NodeRepository, filterSvc *NodeFilterService) *PolicyBindingService {
 	return &PolicyBindingService{
 		repo:          repo,
 		policyRepo:    policyRepo,
 		nodeGroupRepo: nodeGroupRepo,
+		nodeRepo:      nodeRepo,
+		filterSvc:     filterSvc,
+	}
+}
+
+// WithUserGroups enables bindings to user groups.
+func (s *PolicyBindingService) WithUserGroups(userGroupRepo *database.UserGroupRepository) *PolicyBindingService {
+	s.userGroupRepo = userGroupRepo
+	return s
+}
+
+// validateBindingTarget checks that req targets exactly one of a node
+// group, a saved node filter or a user group.
+func validateBindingTarget(req *models.CreatePolicyBindingRequest) error {
+	targets := 0
+	for _, id := range []string{req.GroupID, req.FilterID, req.UserGroupID} {
+		if id != "" {
+			targets++
+		}
+	}
+	switch {
+	case targets == 0:
+		return fmt.Errorf("group_id is required")
+	case targets > 1:
+		return fmt.Errorf("group_id, filter_id and user_group_id are mutually exclusive")
+	}
+	return nil
+}
+
+// checkUserScopedType returns an error if policies of policyType cannot be
+// bound to a user group.
+func checkUserScopedType(policyType string) error {
+	if !userScopedPolicyTypes[policyType] {
+		return fmt.Errorf("%s policies apply machine-wide and cannot be bound to a user group", policyType)
 	}
+	return nil
 }
 
-// CreateBinding creates a new policy binding (default state: DISABLED)
+// CreateBinding creates a new policy binding (default state: DISABLED).
+// The binding targets a node group, a saved node filter or a user group.
 func (s *PolicyBindingService) CreateBinding(ctx context.Context, req *models.CreatePolicyBindingRequest) (*models.PolicyBinding, error) {
 	if req.PolicyID == "" {
 		return nil, fmt.Errorf("policy_id is required")
 	}
-	if req.GroupID == "" {
-		return nil, fmt.Errorf("group_id is required")
+	if err := validateBindingTarget(req); err != nil {
+		return nil, err
+	}
+	if err := validateBindingWindow(req.Window); err != nil {
+		return nil, err
 	}
 
 	// Verify policy exists
@@ -46,20 +96,51 @@ func (s *PolicyBindingService) CreateBinding(ctx context.Context, req *models.Cr
 		return nil, fmt.Errorf("policy not found")
 	}
 
-	// Verify group exists
-	group, err := s.nodeGroupRepo.GetByID(ctx, req.GroupID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to verify group: %w", err)
-	}
-	if group == nil {
-		return nil, fmt.Errorf("node group not found")
-	}
-
 	b := &models.PolicyBinding{
 		PolicyID: req.PolicyID,
 		GroupID:  req.GroupID,
 		State:    models.BindingStateDisabled,
 		Priority: req.Priority,
+		Window:   req.Window,
+		InWindow: bindingInWindow(req.Window, timeNow()),
+	}
+
+	switch {
+	case req.UserGroupID != "":
+		if s.userGroupRepo == nil {
+			return nil, fmt.Errorf("user group bindings are not supported")
+		}
+		if err := checkUserScopedType(policy.Type); err != nil {
+			return nil, err
+		}
+		// Verify user group exists
+		group, err := s.userGroupRepo.GetByID(ctx, req.UserGroupID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify user group: %w", err)
+		}
+		if group == nil {
+			return nil, fmt.Errorf("user group not found")
+		}
+		b.UserGroupID = &group.ID
+	case req.FilterID != "":
+		// Verify filter exists
+		filter, err := s.filterSvc.GetFilter(ctx, req.FilterID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify node filter: %w", err)
+		}
+		if filter == nil {
+			return nil, fmt.Errorf("node filter not found")
+		}
+		b.FilterID = &filter.ID
+	default:
+		// Verify group exists
+		group, err := s.nodeGroupRepo.GetByID(ctx, req.GroupID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify group: %w", err)
+		}
+		if group == nil {
+			return nil, fmt.Errorf("node group not found")
+		}
 	}
 	if err := s.repo.Create(ctx, b); err != nil {
 		return nil, fmt.Errorf("failed to create binding: %w", err)
@@ -72,9 +153,132 @@ func (s *PolicyBindingService) GetBinding(ctx context.Context, id string) (*mode
 	return s.repo.GetByID(ctx, id)
 }
 
-// ListBindings returns all policy bindings with details
+// ListBindings returns all policy bindings with details. The node count of
+// filter bindings is the number of nodes currently matching the filter.
 func (s *PolicyBindingService) ListBindings(ctx context.Context) ([]*models.PolicyBindingWithDetails, error) {
-	return s.repo.ListAll(ctx)
+	bindings, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.countFilterNodes(ctx, bindings); err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
+
+// ListBindingsPaginated returns page p of all policy bindings, detailed
+// like ListBindings.
+func (s *PolicyBindingService) ListBindingsPaginated(ctx context.Context, p models.Pagination) (*models.ListResponse[*models.PolicyBindingWithDetails], error) {
+	bindings, total, err := s.repo.ListPaginated(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.countFilterNodes(ctx, bindings); err != nil {
+		return nil, err
+	}
+	return models.NewListResponse(bindings, total, p), nil
+}
+
+// countFilterNodes fills in the node count of filter bindings by
+// evaluating the filters.
+func (s *PolicyBindingService) countFilterNodes(ctx context.Context, bindings []*models.PolicyBindingWithDetails) error {
+	var counts map[string]int
+	var err error
+	for _, b := range bindings {
+		if b.FilterID == nil {
+			continue
+		}
+		if counts == nil {
+			if counts, err = s.filterSvc.CountMatchingNodes(ctx); err != nil {
+				return fmt.Errorf("failed to evaluate node filters: %w", err)
+			}
+		}
+		b.NodeCount = counts[*b.FilterID]
+	}
+	return nil
+}
+
+// ListBindingNodes returns the nodes a binding targets: the members of its
+// node group, the nodes matching its saved filter, or the nodes on which a
+// member of its user group is logged in. The binding's state and window are
+// not considered. It returns nil if the binding does not exist.
+func (s *PolicyBindingService) ListBindingNodes(ctx context.Context, id string) ([]*models.Node, error) {
+	b, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, nil
+	}
+	return s.resolveNodes(ctx, []*models.PolicyBinding{b})
+}
+
+// ListPolicyAffectedNodes returns the nodes reached by any enabled binding
+// of a policy, ordered by name, as a dry run of releasing or changing it.
+// Bindings out of their activity window are included. It returns nil if
+// the policy does not exist.
+func (s *PolicyBindingService) ListPolicyAffectedNodes(ctx context.Context, policyID string) ([]*models.Node, error) {
+	policy, err := s.policyRepo.GetByID(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, nil
+	}
+	bindings, err := s.repo.ListEnabledByPolicyID(ctx, policyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.resolveNodes(ctx, bindings)
+}
+
+// resolveNodes returns the nodes targeted by any of bindings, each once,
+// ordered by name.
+func (s *PolicyBindingService) resolveNodes(ctx context.Context, bindings []*models.PolicyBinding) ([]*models.Node, error) {
+	var groupIDs, userGroupIDs []string
+	var found [][]*models.Node
+	for _, b := range bindings {
+		switch b.ScopeKind {
+		case models.BindingScopeNodeFilter:
+			nodes, err := s.filterSvc.ListMatchingNodes(ctx, *b.FilterID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate node filter: %w", err)
+			}
+			found = append(found, nodes)
+		case models.BindingScopeUserGroup:
+			userGroupIDs = append(userGroupIDs, *b.UserGroupID)
+		default:
+			groupIDs = append(groupIDs, b.GroupID)
+		}
+	}
+
+	nodes, err := s.nodeRepo.ListByGroupIDs(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	found = append(found, nodes)
+	if nodes, err = s.nodeRepo.ListBySessionUserGroupIDs(ctx, userGroupIDs); err != nil {
+		return nil, err
+	}
+	found = append(found, nodes)
+
+	return mergeNodes(found...), nil
+}
+
+// mergeNodes returns the nodes of all lists, each once, ordered by name.
+func mergeNodes(lists ...[]*models.Node) []*models.Node {
+	seen := make(map[string]bool)
+	merged := []*models.Node{}
+	for _, nodes := range lists {
+		for _, n := range nodes {
+			if !seen[n.ID] {
+				seen[n.ID] = true
+				merged = append(merged, n)
+			}
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Name < merged[j].Name })
+	return merged
 }
 
 // UpdateBinding updates a policy binding with enforcement rules
@@ -99,6 +303,11 @@ func (s *PolicyBindingService) UpdateBinding(ctx context.Context, id string, req
 		if policy.State != models.PolicyStateReleased {
 			return nil, fmt.Errorf("binding can only be enabled when policy is released (current policy state: %s)", policy.State)
 		}
+		if binding.UserGroupID != nil {
+			if err := checkUserScopedType(policy.Type); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Validate state value if provided
@@ -108,9 +317,18 @@ func (s *PolicyBindingService) UpdateBinding(ctx context.Context, id string, req
 		}
 	}
 
+	if err := validateBindingWindow(req.Window); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.Update(ctx, id, req); err != nil {
 		return nil, fmt.Errorf("failed to update binding: %w", err)
 	}
+	if req.Window != nil {
+		if err := s.repo.SetInWindow(ctx, id, bindingInWindow(req.Window, timeNow())); err != nil {
+			return nil, err
+		}
+	}
 	return s.repo.GetByID(ctx, id)
 }
 
@@ -125,6 +343,18 @@ func (s *PolicyBindingService) GetEnabledGroupIDsForPolicy(ctx context.Context,
 	return s.repo.GetEnabledGroupIDsByPolicyID(ctx, policyID)
 }
 
+// HasEnabledDynamicBinding returns true if the policy is bound to at least
+// one saved node filter or user group with an enabled binding. Such
+// policies can reach any node, so changes to them cannot be scoped by node
+// group.
+func (s *PolicyBindingService) HasEnabledDynamicBinding(ctx context.Context, policyID string) (bool, error) {
+	count, err := s.repo.CountEnabledDynamicBindingsByPolicyID(ctx, policyID)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // HasEnabledBinding returns true if the policy has at least one enabled binding
 func (s *PolicyBindingService) HasEnabledBinding(ctx context.Context, policyID string) (bool, error) {
 	count, err := s.repo.CountEnabledByPolicyID(ctx, policyID)
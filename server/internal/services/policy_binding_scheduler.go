@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// bindingWindowStore is the part of the binding repository the window
+// scheduler reads and writes.
+type bindingWindowStore interface {
+	ListWindowed(ctx context.Context) ([]*models.PolicyBinding, error)
+	SetInWindow(ctx context.Context, id string, inWindow bool) error
+}
+
+// bindingWindowScheduler keeps the in_window flag of bindings with an
+// activity window current, so policy resolution only has to filter on it.
+type bindingWindowScheduler struct {
+	store bindingWindowStore
+	// last is whether each binding was in its window at the previous
+	// check, as seen by this server.
+	last map[string]bool
+}
+
+// check updates the window state of all windowed bindings at now and
+// returns the enabled bindings that entered or left their window since
+// the previous check.
+func (s *bindingWindowScheduler) check(ctx context.Context, now time.Time) ([]*models.PolicyBinding, error) {
+	bindings, err := s.store.ListWindowed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	last := make(map[string]bool, len(bindings))
+	var changed []*models.PolicyBinding
+	for _, b := range bindings {
+		in := bindingInWindow(b.Window, now)
+		prev, seen := s.last[b.ID]
+		if !seen {
+			prev = b.InWindow
+		}
+		last[b.ID] = in
+		if in != b.InWindow {
+			if err := s.store.SetInWindow(ctx, b.ID, in); err != nil {
+				return changed, err
+			}
+			b.InWindow = in
+		}
+		if in != prev && b.State == models.BindingStateEnabled {
+			changed = append(changed, b)
+		}
+	}
+	s.last = last
+	return changed, nil
+}
+
+// run checks at once and then every interval until ctx is done, calling
+// onChange for each binding that entered or left its window.
+func (s *bindingWindowScheduler) run(ctx context.Context, interval time.Duration, onChange func(*models.PolicyBinding)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		changed, err := s.check(ctx, time.Now())
+		for _, b := range changed {
+			if b.InWindow {
+				log.Printf("Policy binding %s entered its activity window", b.ID)
+			} else {
+				log.Printf("Policy binding %s left its activity window", b.ID)
+			}
+			onChange(b)
+		}
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to update policy binding windows: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// StartWindowScheduler applies binding activity windows: every interval
+// it marks bindings in or out of their window and calls onChange for each
+// enabled binding whose state changed, so agents can be told to resync.
+// Schedules have minute resolution, so an interval of a minute or less
+// applies window boundaries on time. The returned function stops the
+// scheduler.
+func (s *PolicyBindingService) StartWindowScheduler(interval time.Duration, onChange func(*models.PolicyBinding)) (stop func()) {
+	sched := &bindingWindowScheduler{store: s.repo}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		sched.run(ctx, interval, onChange)
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -6,6 +6,7 @@ package services
 
 import (
 	"context"
+	"slices"
 	"testing"
 
 	"github.com/VuteTech/Bor/server/internal/models"
@@ -29,6 +30,16 @@ func TestPolicyBindingService_CreateBinding_Validation(t *testing.T) {
 			req:     &models.CreatePolicyBindingRequest{PolicyID: "policy-1"},
 			wantErr: "group_id is required",
 		},
+		{
+			name:    "both group_id and filter_id",
+			req:     &models.CreatePolicyBindingRequest{PolicyID: "policy-1", GroupID: "group-1", FilterID: "filter-1"},
+			wantErr: "group_id, filter_id and user_group_id are mutually exclusive",
+		},
+		{
+			name:    "both group_id and user_group_id",
+			req:     &models.CreatePolicyBindingRequest{PolicyID: "policy-1", GroupID: "group-1", UserGroupID: "ugroup-1"},
+			wantErr: "group_id, filter_id and user_group_id are mutually exclusive",
+		},
 	}
 
 	for _, tt := range tests {
@@ -44,6 +55,17 @@ func TestPolicyBindingService_CreateBinding_Validation(t *testing.T) {
 	}
 }
 
+func TestCheckUserScopedType(t *testing.T) {
+	if err := checkUserScopedType("Kconfig"); err != nil {
+		t.Errorf("checkUserScopedType(Kconfig) = %v, want nil", err)
+	}
+	for _, typ := range []string{"Firefox", "Chrome", "Dconf", "Polkit", "Sysctl"} {
+		if err := checkUserScopedType(typ); err == nil {
+			t.Errorf("checkUserScopedType(%s) = nil, want error", typ)
+		}
+	}
+}
+
 func TestPolicyBindingService_UpdateBinding_InvalidState(t *testing.T) {
 	svc := &PolicyBindingService{}
 	badState := "invalid"
@@ -95,3 +117,21 @@ func TestPolicyBindingService_CreateBinding_DefaultsToDisabled(t *testing.T) {
 		t.Errorf("BindingStateEnabled = %q, want %q", models.BindingStateEnabled, "enabled")
 	}
 }
+
+func TestMergeNodes(t *testing.T) {
+	a := &models.Node{ID: "1", Name: "beta"}
+	b := &models.Node{ID: "2", Name: "alpha"}
+	c := &models.Node{ID: "3", Name: "gamma"}
+
+	got := mergeNodes([]*models.Node{a, c}, nil, []*models.Node{b, a})
+	var names []string
+	for _, n := range got {
+		names = append(names, n.Name)
+	}
+	if want := []string{"alpha", "beta", "gamma"}; !slices.Equal(names, want) {
+		t.Errorf("mergeNodes() = %v, want %v", names, want)
+	}
+	if got := mergeNodes(); got == nil || len(got) != 0 {
+		t.Errorf("mergeNodes() with no lists = %v, want empty non-nil slice", got)
+	}
+}
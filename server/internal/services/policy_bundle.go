@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// ErrPolicyNotFound is returned when a policy selected for export does not
+// exist.
+var ErrPolicyNotFound = errors.New("policy not found")
+
+// PolicyBundleService exports policies as a portable bundle and imports
+// bundles, e.g. to move policies from a staging to a production instance.
+// Bindings are not part of a bundle: node groups differ between instances.
+type PolicyBundleService struct {
+	policySvc *PolicyService
+}
+
+// NewPolicyBundleService creates a new PolicyBundleService
+func NewPolicyBundleService(policySvc *PolicyService) *PolicyBundleService {
+	return &PolicyBundleService{policySvc: policySvc}
+}
+
+// Export builds a bundle of the policies with the given IDs, or of all
+// released policies when ids is empty.
+func (s *PolicyBundleService) Export(ctx context.Context, ids []string) (*models.PolicyBundle, error) {
+	var policies []*models.Policy
+	if len(ids) == 0 {
+		all, err := s.policySvc.ListAllPolicies(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list policies: %w", err)
+		}
+		for _, p := range all {
+			if p.State == models.PolicyStateReleased {
+				policies = append(policies, p)
+			}
+		}
+	} else {
+		for _, id := range ids {
+			p, err := s.policySvc.GetPolicy(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get policy %s: %w", id, err)
+			}
+			if p == nil {
+				return nil, fmt.Errorf("%w: %s", ErrPolicyNotFound, id)
+			}
+			policies = append(policies, p)
+		}
+	}
+
+	bundle := &models.PolicyBundle{
+		Version:    models.PolicyBundleVersion,
+		ExportedAt: timeNow(),
+		Policies:   make([]models.PolicyBundleEntry, 0, len(policies)),
+	}
+	for _, p := range policies {
+		bundle.Policies = append(bundle.Policies, models.PolicyBundleEntry{
+			ID: p.ID,
+			CreatePolicyRequest: models.CreatePolicyRequest{
+				Name:                  p.Name,
+				Description:           p.Description,
+				Type:                  p.Type,
+				Content:               p.Content,
+				ContactLossAction:     p.ContactLossAction,
+				ContactLossTTLSeconds: p.ContactLossTTLSeconds,
+				CheckCommand:          p.CheckCommand,
+				CheckArgs:             p.CheckArgs,
+				CheckTimeoutSeconds:   p.CheckTimeoutSeconds,
+				Preconditions:         p.Preconditions,
+			},
+		})
+	}
+	return bundle, nil
+}
+
+// Import creates a draft policy for every policy in the bundle and reports
+// the ID each one was given. The whole bundle is validated before anything
+// is created. Policies whose name is already taken are skipped and
+// reported as collisions, so importing a bundle twice creates nothing the
+// second time.
+func (s *PolicyBundleService) Import(ctx context.Context, bundle *models.PolicyBundle, importedBy string) (*models.PolicyImportResult, error) {
+	if err := validatePolicyBundle(bundle); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.policySvc.ListAllPolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policies: %w", err)
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, p := range existing {
+		taken[p.Name] = true
+	}
+
+	result := &models.PolicyImportResult{
+		Imported:   []models.PolicyImportMapping{},
+		Collisions: []string{},
+	}
+	for i := range bundle.Policies {
+		entry := &bundle.Policies[i]
+		if taken[entry.Name] {
+			result.Collisions = append(result.Collisions, entry.Name)
+			continue
+		}
+		policy, err := s.policySvc.CreatePolicy(ctx, &entry.CreatePolicyRequest, importedBy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import policy %q: %w", entry.Name, err)
+		}
+		result.Imported = append(result.Imported, models.PolicyImportMapping{
+			SourceID: entry.ID,
+			ID:       policy.ID,
+			Name:     policy.Name,
+		})
+	}
+	return result, nil
+}
+
+// validatePolicyBundle checks a bundle before any of it is imported.
+func validatePolicyBundle(bundle *models.PolicyBundle) error {
+	if bundle == nil {
+		return fmt.Errorf("policy bundle is required")
+	}
+	if bundle.Version != models.PolicyBundleVersion {
+		return fmt.Errorf("unsupported bundle version %d (expected %d)", bundle.Version, models.PolicyBundleVersion)
+	}
+	seen := make(map[string]bool, len(bundle.Policies))
+	for i := range bundle.Policies {
+		entry := &bundle.Policies[i]
+		if err := validateCreatePolicyRequest(&entry.CreatePolicyRequest); err != nil {
+			return fmt.Errorf("policy %d: %w", i+1, err)
+		}
+		if seen[entry.Name] {
+			return fmt.Errorf("duplicate policy %q", entry.Name)
+		}
+		seen[entry.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func TestValidatePolicyBundle(t *testing.T) {
+	entry := func(name, typ, content string) models.PolicyBundleEntry {
+		return models.PolicyBundleEntry{
+			ID:                  "src-" + name,
+			CreatePolicyRequest: models.CreatePolicyRequest{Name: name, Type: typ, Content: content},
+		}
+	}
+	tests := []struct {
+		name    string
+		bundle  *models.PolicyBundle
+		wantErr string
+	}{
+		{
+			name:    "nil bundle",
+			wantErr: "policy bundle is required",
+		},
+		{
+			name:    "unsupported version",
+			bundle:  &models.PolicyBundle{Version: 99},
+			wantErr: "unsupported bundle version 99 (expected 1)",
+		},
+		{
+			name: "missing type",
+			bundle: &models.PolicyBundle{Version: models.PolicyBundleVersion, Policies: []models.PolicyBundleEntry{
+				entry("a", "Firefox", ""), entry("b", "", ""),
+			}},
+			wantErr: "policy 2: policy type is required",
+		},
+		{
+			name: "invalid content",
+			bundle: &models.PolicyBundle{Version: models.PolicyBundleVersion, Policies: []models.PolicyBundleEntry{
+				entry("a", "Firefox", "{"),
+			}},
+			wantErr: "policy 1: invalid Firefox policy content: ",
+		},
+		{
+			name: "duplicate name",
+			bundle: &models.PolicyBundle{Version: models.PolicyBundleVersion, Policies: []models.PolicyBundleEntry{
+				entry("a", "Firefox", ""), entry("a", "Chrome", ""),
+			}},
+			wantErr: `duplicate policy "a"`,
+		},
+		{
+			name: "valid",
+			bundle: &models.PolicyBundle{Version: models.PolicyBundleVersion, Policies: []models.PolicyBundleEntry{
+				entry("a", "Firefox", `{"DisableTelemetry":true}`), entry("b", "Chrome", ""),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePolicyBundle(tt.bundle)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validatePolicyBundle() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("validatePolicyBundle() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyBundleEntry_JSON(t *testing.T) {
+	data := []byte(`{"id":"p1","name":"Telemetry","type":"Firefox","content":"{}","check_args":["-q"]}`)
+	var e models.PolicyBundleEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if e.ID != "p1" || e.Name != "Telemetry" || e.Type != "Firefox" || e.Content != "{}" {
+		t.Errorf("entry = %+v", e)
+	}
+	if len(e.CheckArgs) != 1 || e.CheckArgs[0] != "-q" {
+		t.Errorf("CheckArgs = %v, want [-q]", e.CheckArgs)
+	}
+}
@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// PolicyResolver determines the effective policies of a node: those with an
+// enabled binding to one of its node groups or to a saved filter it
+// matches, plus those bound to the user groups of its logged-in users. The agent stream and the effective-policies view share it so a
+// dry run follows exactly the same resolution path as a real snapshot.
+type PolicyResolver struct {
+	policySvc *PolicyService
+	filterSvc *NodeFilterService // nil disables filter bindings
+	groupSvc  *NodeGroupService  // nil disables quarantine
+}
+
+// NewPolicyResolver creates a new PolicyResolver. filterSvc may be nil.
+func NewPolicyResolver(policySvc *PolicyService, filterSvc *NodeFilterService) *PolicyResolver {
+	return &PolicyResolver{policySvc: policySvc, filterSvc: filterSvc}
+}
+
+// WithQuarantine makes quarantined nodes resolve to the policies bound to
+// the quarantine node groups instead of their own groups and filters.
+// groupSvc may be nil.
+func (r *PolicyResolver) WithQuarantine(groupSvc *NodeGroupService) *PolicyResolver {
+	r.groupSvc = groupSvc
+	return r
+}
+
+// Resolve returns the policies that currently apply to node. Filter
+// matches come from the filter service's per-node cache.
+func (r *PolicyResolver) Resolve(ctx context.Context, node *models.Node) ([]*models.Policy, error) {
+	groupIDs, filterIDs, err := r.Targets(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := r.ResolveTargets(ctx, groupIDs, filterIDs)
+	if err != nil {
+		return nil, err
+	}
+	session, err := r.SessionPolicies(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	return append(policies, session...), nil
+}
+
+// SessionPolicies returns the policies bound to the user groups of the
+// users logged in on node. They are not part of the snapshot shared by
+// nodes with the same Targets. A quarantined node receives none.
+func (r *PolicyResolver) SessionPolicies(ctx context.Context, node *models.Node) ([]*models.Policy, error) {
+	if len(node.SessionUsers) == 0 {
+		return nil, nil
+	}
+	if _, quarantined, err := r.quarantineTargets(ctx, node); quarantined || err != nil {
+		return nil, err
+	}
+	return r.policySvc.ListPoliciesForSessionUsers(ctx, node.SessionUsers)
+}
+
+// Targets returns the node groups and saved filters whose bindings apply to
+// node. Nodes with the same targets receive the same policies, which lets
+// callers share one resolved snapshot between them. A quarantined node
+// targets only the quarantine groups.
+func (r *PolicyResolver) Targets(ctx context.Context, node *models.Node) (groupIDs, filterIDs []string, err error) {
+	if ids, ok, err := r.quarantineTargets(ctx, node); ok || err != nil {
+		return ids, nil, err
+	}
+	if r.filterSvc != nil {
+		if filterIDs, err = r.filterSvc.MatchingFilterIDs(ctx, node.ID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return node.NodeGroupIDs, filterIDs, nil
+}
+
+// ResolveTargets returns the policies bound to any of the given node groups
+// or saved filters.
+func (r *PolicyResolver) ResolveTargets(ctx context.Context, groupIDs, filterIDs []string) ([]*models.Policy, error) {
+	return r.policySvc.ListPoliciesForTargets(ctx, groupIDs, filterIDs)
+}
+
+// Effective reports the policies node receives. With non-nil overrides it
+// performs a dry run: the overrides are applied to a copy of node, filters
+// are evaluated against the hypothetical attributes, and the result is
+// compared with what the node receives today.
+func (r *PolicyResolver) Effective(ctx context.Context, node *models.Node, overrides *models.NodeOverrides) (*models.EffectivePolicies, error) {
+	current, currentFilters, err := r.resolveUncached(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	stale, err := r.policySvc.ListStaleBindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.EffectivePolicies{
+		NodeID:    node.ID,
+		GroupIDs:  nonNil(node.NodeGroupIDs),
+		FilterIDs: nonNil(currentFilters),
+		Policies:  current,
+		Stale:     matchStaleBindings(stale, node.NodeGroupIDs, currentFilters),
+	}
+	if overrides == nil {
+		return result, nil
+	}
+
+	hypothetical := ApplyNodeOverrides(node, overrides)
+	policies, filterIDs, err := r.resolveUncached(ctx, hypothetical)
+	if err != nil {
+		return nil, err
+	}
+	result.DryRun = true
+	result.Overrides = overrides
+	result.GroupIDs = nonNil(hypothetical.NodeGroupIDs)
+	result.FilterIDs = nonNil(filterIDs)
+	result.Policies = policies
+	result.Added, result.Removed = diffPolicyIDs(current, policies)
+	result.Stale = matchStaleBindings(stale, hypothetical.NodeGroupIDs, filterIDs)
+	return result, nil
+}
+
+// StaleNodes reports, for each of nodes that has any, the deprecated
+// policies it receives and the archived policies it is still bound to.
+// Filter matches come from the filter service's per-node cache.
+func (r *PolicyResolver) StaleNodes(ctx context.Context, nodes []*models.Node) ([]*models.NodeStalePolicies, error) {
+	result := []*models.NodeStalePolicies{}
+	stale, err := r.policySvc.ListStaleBindings(ctx)
+	if err != nil || len(stale) == 0 {
+		return result, err
+	}
+	needFilters := false
+	for _, b := range stale {
+		needFilters = needFilters || b.FilterID != nil
+	}
+
+	for _, node := range nodes {
+		var filterIDs []string
+		if needFilters && r.filterSvc != nil {
+			if filterIDs, err = r.filterSvc.MatchingFilterIDs(ctx, node.ID); err != nil {
+				return nil, err
+			}
+		}
+		if policies := matchStaleBindings(stale, node.NodeGroupIDs, filterIDs); len(policies) > 0 {
+			result = append(result, &models.NodeStalePolicies{NodeID: node.ID, NodeName: node.Name, Policies: policies})
+		}
+	}
+	return result, nil
+}
+
+// resolveUncached resolves the policies for node's attributes as given,
+// which may differ from what is stored.
+func (r *PolicyResolver) resolveUncached(ctx context.Context, node *models.Node) ([]*models.Policy, []string, error) {
+	groupIDs, quarantined, err := r.quarantineTargets(ctx, node)
+	if err != nil {
+		return nil, nil, err
+	}
+	var filterIDs []string
+	if !quarantined {
+		groupIDs = node.NodeGroupIDs
+		if r.filterSvc != nil {
+			if filterIDs, err = r.filterSvc.EvaluateNode(ctx, node); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	policies, err := r.policySvc.ListPoliciesForTargets(ctx, groupIDs, filterIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !quarantined && len(node.SessionUsers) > 0 {
+		session, err := r.policySvc.ListPoliciesForSessionUsers(ctx, node.SessionUsers)
+		if err != nil {
+			return nil, nil, err
+		}
+		policies = append(policies, session...)
+	}
+	if policies == nil {
+		policies = []*models.Policy{}
+	}
+	return policies, filterIDs, nil
+}
+
+// quarantineTargets returns the quarantine group IDs and true when node is
+// quarantined. A quarantined node with no quarantine groups configured
+// receives no policies at all rather than falling back to its own.
+func (r *PolicyResolver) quarantineTargets(ctx context.Context, node *models.Node) ([]string, bool, error) {
+	if node.QuarantinedAt == nil || r.groupSvc == nil {
+		return nil, false, nil
+	}
+	ids, err := r.groupSvc.QuarantineGroupIDs(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return ids, true, nil
+}
+
+// ApplyNodeOverrides returns a copy of node with the overrides applied.
+// Group replacement happens before additions and removals.
+func ApplyNodeOverrides(node *models.Node, o *models.NodeOverrides) *models.Node {
+	n := *node
+	groups := slices.Clone(node.NodeGroupIDs)
+	if o.GroupIDs != nil {
+		groups = slices.Clone(o.GroupIDs)
+	}
+	for _, id := range o.AddGroupIDs {
+		if !slices.Contains(groups, id) {
+			groups = append(groups, id)
+		}
+	}
+	groups = slices.DeleteFunc(groups, func(id string) bool {
+		return slices.Contains(o.RemoveGroupIDs, id)
+	})
+	n.NodeGroupIDs = groups
+	n.NodeGroupNames = nil
+
+	if o.OSName != nil {
+		n.OSName = o.OSName
+	}
+	if o.OSVersion != nil {
+		n.OSVersion = o.OSVersion
+	}
+	if o.DesktopEnv != nil {
+		n.DesktopEnv = o.DesktopEnv
+	}
+	if o.AgentVersion != nil {
+		n.AgentVersion = o.AgentVersion
+	}
+	return &n
+}
+
+// matchStaleBindings returns the stale policies bound to any of the given
+// groups or filters, each listed once.
+func matchStaleBindings(bindings []*models.StaleBinding, groupIDs, filterIDs []string) []*models.StalePolicy {
+	policies := []*models.StalePolicy{}
+	seen := make(map[string]bool)
+	for _, b := range bindings {
+		if seen[b.Policy.PolicyID] {
+			continue
+		}
+		if (b.GroupID != nil && slices.Contains(groupIDs, *b.GroupID)) ||
+			(b.FilterID != nil && slices.Contains(filterIDs, *b.FilterID)) {
+			seen[b.Policy.PolicyID] = true
+			p := b.Policy
+			policies = append(policies, &p)
+		}
+	}
+	return policies
+}
+
+// diffPolicyIDs returns the IDs of policies present only in after (added)
+// and only in before (removed).
+func diffPolicyIDs(before, after []*models.Policy) (added, removed []string) {
+	had := make(map[string]bool, len(before))
+	for _, p := range before {
+		had[p.ID] = true
+	}
+	has := make(map[string]bool, len(after))
+	for _, p := range after {
+		has[p.ID] = true
+		if !had[p.ID] {
+			added = append(added, p.ID)
+		}
+	}
+	for _, p := range before {
+		if !has[p.ID] {
+			removed = append(removed, p.ID)
+		}
+	}
+	return added, removed
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func TestApplyNodeOverrides(t *testing.T) {
+	node := &models.Node{
+		ID:           "n1",
+		NodeGroupIDs: []string{"g1", "g2"},
+		OSVersion:    strPtr("40"),
+		DesktopEnv:   strPtr("KDE"),
+	}
+
+	got := ApplyNodeOverrides(node, &models.NodeOverrides{
+		AddGroupIDs:    []string{"g3", "g1"},
+		RemoveGroupIDs: []string{"g2"},
+		OSVersion:      strPtr("42"),
+	})
+	if want := []string{"g1", "g3"}; !slices.Equal(got.NodeGroupIDs, want) {
+		t.Errorf("NodeGroupIDs = %v, want %v", got.NodeGroupIDs, want)
+	}
+	if *got.OSVersion != "42" || *got.DesktopEnv != "KDE" {
+		t.Errorf("facts = %s/%s, want 42/KDE", *got.OSVersion, *got.DesktopEnv)
+	}
+	if !slices.Equal(node.NodeGroupIDs, []string{"g1", "g2"}) || *node.OSVersion != "40" {
+		t.Error("the original node must not be modified")
+	}
+
+	got = ApplyNodeOverrides(node, &models.NodeOverrides{GroupIDs: []string{"g9"}, AddGroupIDs: []string{"g1"}})
+	if want := []string{"g9", "g1"}; !slices.Equal(got.NodeGroupIDs, want) {
+		t.Errorf("replace + add: NodeGroupIDs = %v, want %v", got.NodeGroupIDs, want)
+	}
+}
+
+func TestDiffPolicyIDs(t *testing.T) {
+	before := []*models.Policy{{ID: "a"}, {ID: "b"}}
+	after := []*models.Policy{{ID: "b"}, {ID: "c"}}
+	added, removed := diffPolicyIDs(before, after)
+	if !slices.Equal(added, []string{"c"}) || !slices.Equal(removed, []string{"a"}) {
+		t.Errorf("diffPolicyIDs() = %v, %v; want [c], [a]", added, removed)
+	}
+}
+
+func TestMatchStaleBindings(t *testing.T) {
+	bindings := []*models.StaleBinding{
+		{GroupID: strPtr("g1"), Policy: models.StalePolicy{PolicyID: "old", Reason: models.StaleReasonDeprecated}},
+		{FilterID: strPtr("f1"), Policy: models.StalePolicy{PolicyID: "old", Reason: models.StaleReasonDeprecated}},
+		{GroupID: strPtr("g2"), Policy: models.StalePolicy{PolicyID: "gone", Reason: models.StaleReasonArchived}},
+		{FilterID: strPtr("f2"), Policy: models.StalePolicy{PolicyID: "other", Reason: models.StaleReasonDeprecated}},
+	}
+
+	got := matchStaleBindings(bindings, []string{"g1", "g2"}, []string{"f1"})
+	var ids []string
+	for _, p := range got {
+		ids = append(ids, p.PolicyID)
+	}
+	if want := []string{"old", "gone"}; !slices.Equal(ids, want) {
+		t.Errorf("stale policies = %v, want %v", ids, want)
+	}
+
+	if got := matchStaleBindings(bindings, nil, nil); got == nil || len(got) != 0 {
+		t.Errorf("no targets: got %v, want empty non-nil slice", got)
+	}
+}
@@ -7,6 +7,7 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/VuteTech/Bor/server/internal/models"
@@ -52,6 +53,21 @@ func TestPolicyService_CreatePolicy_Validation(t *testing.T) {
 			req:     &models.CreatePolicyRequest{Name: "test"},
 			wantErr: "policy type is required",
 		},
+		{
+			name:    "invalid contact loss action",
+			req:     &models.CreatePolicyRequest{Name: "test", Type: "Firefox", ContactLossAction: "explode"},
+			wantErr: "invalid contact loss action: explode (valid actions: keep, revert, activate)",
+		},
+		{
+			name:    "revert without ttl",
+			req:     &models.CreatePolicyRequest{Name: "test", Type: "Firefox", ContactLossAction: models.ContactLossRevert},
+			wantErr: `contact_loss_ttl_seconds is required for contact loss action "revert"`,
+		},
+		{
+			name:    "negative ttl",
+			req:     &models.CreatePolicyRequest{Name: "test", Type: "Firefox", ContactLossTTLSeconds: -1},
+			wantErr: "contact_loss_ttl_seconds must not be negative",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -66,6 +82,97 @@ func TestPolicyService_CreatePolicy_Validation(t *testing.T) {
 	}
 }
 
+func TestCheckPolicyContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		content string
+		wantErr bool
+	}{
+		{"empty draft", "Chrome", "", false},
+		{"empty object", "Dconf", "{}", false},
+		{"unknown type", "Custom", "not json", false},
+		{"malformed firefox", "Firefox", `{"DisableTelemetry":`, true},
+		{"firefox wrong field type", "Firefox", `{"DisableTelemetry":"yes"}`, true},
+		{"chrome unknown policy", "Chrome", `{"SomeFuturePolicy":true}`, false},
+		{"malformed chrome", "Chrome", `[]`, true},
+		{"kconfig unknown field", "Kconfig", `{"noSuchField":1}`, true},
+		{"valid sysctl", "Sysctl", `{"entries":[{"key":"net.ipv4.ip_forward","value":"0"}]}`, false},
+		{"malformed polkit", "Polkit", `{"rules":{}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPolicyContent(tt.typ, tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckPolicyContent(%q, %q) error = %v, wantErr %v", tt.typ, tt.content, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyService_CreatePolicy_InvalidContent(t *testing.T) {
+	svc := &PolicyService{}
+	req := &models.CreatePolicyRequest{Name: "test", Type: "Kconfig", Content: `{"noSuchField":1}`}
+	_, err := svc.CreatePolicy(context.Background(), req, "admin")
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid Kconfig policy content: ") {
+		t.Errorf("CreatePolicy() error = %v, want invalid Kconfig policy content", err)
+	}
+}
+
+func TestValidateContactLoss(t *testing.T) {
+	tests := []struct {
+		action string
+		ttl    int
+		valid  bool
+	}{
+		{models.ContactLossKeep, 0, true},
+		{models.ContactLossKeep, 3600, true},
+		{models.ContactLossRevert, 3600, true},
+		{models.ContactLossActivate, 60, true},
+		{models.ContactLossRevert, 0, false},
+		{models.ContactLossActivate, 0, false},
+		{models.ContactLossKeep, -5, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		err := validateContactLoss(tt.action, tt.ttl)
+		if (err == nil) != tt.valid {
+			t.Errorf("validateContactLoss(%q, %d) error = %v, want valid=%v", tt.action, tt.ttl, err, tt.valid)
+		}
+	}
+}
+
+func TestValidateComplianceCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		args    []string
+		timeout int
+		wantErr string
+	}{
+		{name: "no check"},
+		{name: "absolute command", command: "/usr/bin/firewall-cmd", args: []string{"--state"}, timeout: 10},
+		{name: "relative command", command: "firewall-cmd", wantErr: "check_command must be an absolute path"},
+		{name: "args without command", args: []string{"--state"}, wantErr: "check_args requires check_command"},
+		{name: "timeout too long", command: "/bin/true", timeout: 301, wantErr: "check_timeout_seconds must be between 0 and 300"},
+		{name: "negative timeout", command: "/bin/true", timeout: -1, wantErr: "check_timeout_seconds must be between 0 and 300"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateComplianceCheck(tt.command, tt.args, tt.timeout)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestPolicyService_SetPolicyState_InvalidState(t *testing.T) {
 	svc := &PolicyService{}
 	_, err := svc.SetPolicyState(context.Background(), "some-id", "active")
@@ -159,3 +266,39 @@ func TestPolicyService_UpdatePolicyRequest_JSON(t *testing.T) {
 		t.Errorf("expected type=Firefox in JSON, got: %v", parsed["type"])
 	}
 }
+
+func TestValidatePreconditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		pcs     []models.PolicyPrecondition
+		wantErr string
+	}{
+		{name: "none"},
+		{name: "valid", pcs: []models.PolicyPrecondition{
+			{Type: models.PreconditionFileExists, Value: "/etc/xdg/kdeglobals"},
+			{Type: models.PreconditionCommandExists, Value: "plasmashell"},
+			{Type: models.PreconditionDesktopInstalled, Value: "KDE"},
+			{Type: models.PreconditionDesktopSession, Value: "GNOME", Negate: true},
+		}},
+		{name: "relative path", pcs: []models.PolicyPrecondition{{Type: models.PreconditionFileExists, Value: "etc/foo"}},
+			wantErr: "precondition 1: file_exists requires an absolute path"},
+		{name: "empty value", pcs: []models.PolicyPrecondition{{Type: models.PreconditionCommandExists}},
+			wantErr: "precondition 1: value is required"},
+		{name: "unknown type", pcs: []models.PolicyPrecondition{{Type: "kernel_version", Value: "6"}},
+			wantErr: `precondition 1: invalid type "kernel_version" (valid types: file_exists, command_exists, desktop_installed, desktop_session)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePreconditions(tt.pcs)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// ProfileService handles policy profiles: named bundles of policies that
+// are bound to node groups as a unit. Methods that change which policies
+// reach a group return the IDs of the affected groups so the caller can
+// publish one resync per group.
+type ProfileService struct {
+	repo          *database.ProfileRepository
+	policyRepo    *database.PolicyRepository
+	nodeGroupRepo *database.NodeGroupRepository
+}
+
+// NewProfileService creates a new ProfileService
+func NewProfileService(repo *database.ProfileRepository, policyRepo *database.PolicyRepository, nodeGroupRepo *database.NodeGroupRepository) *ProfileService {
+	return &ProfileService{repo: repo, policyRepo: policyRepo, nodeGroupRepo: nodeGroupRepo}
+}
+
+// CreateProfile creates a new profile
+func (s *ProfileService) CreateProfile(ctx context.Context, req *models.CreateProfileRequest) (*models.Profile, error) {
+	if req.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	policyIDs, err := s.validatePolicyIDs(ctx, req.PolicyIDs)
+	if err != nil {
+		return nil, err
+	}
+	p := &models.Profile{
+		Name:        req.Name,
+		Description: req.Description,
+		PolicyIDs:   policyIDs,
+	}
+	if err := s.repo.Create(ctx, p); err != nil {
+		return nil, fmt.Errorf("failed to create profile: %w", err)
+	}
+	return p, nil
+}
+
+// GetProfile retrieves a profile by ID
+func (s *ProfileService) GetProfile(ctx context.Context, id string) (*models.Profile, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListProfiles returns all profiles
+func (s *ProfileService) ListProfiles(ctx context.Context) ([]*models.Profile, error) {
+	return s.repo.ListAll(ctx)
+}
+
+// UpdateProfile updates a profile. Replacing the member policies reconciles
+// the policy bindings of every group the profile is bound to; the IDs of
+// those groups are returned.
+func (s *ProfileService) UpdateProfile(ctx context.Context, id string, req *models.UpdateProfileRequest) (*models.Profile, []string, error) {
+	if req.Name != nil && *req.Name == "" {
+		return nil, nil, fmt.Errorf("name cannot be empty")
+	}
+	if req.PolicyIDs != nil {
+		policyIDs, err := s.validatePolicyIDs(ctx, *req.PolicyIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.PolicyIDs = &policyIDs
+	}
+	groupIDs, err := s.repo.Update(ctx, id, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+	p, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, groupIDs, nil
+}
+
+// DeleteProfile deletes a profile and the policy bindings it created, and
+// returns the IDs of the groups it was bound to.
+func (s *ProfileService) DeleteProfile(ctx context.Context, id string) ([]string, error) {
+	return s.repo.Delete(ctx, id)
+}
+
+// ListBindings returns the node groups a profile is bound to, or nil if the
+// profile does not exist.
+func (s *ProfileService) ListBindings(ctx context.Context, profileID string) ([]*models.ProfileBinding, error) {
+	p, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, nil
+	}
+	bindings, err := s.repo.ListBindings(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if bindings == nil {
+		bindings = []*models.ProfileBinding{}
+	}
+	return bindings, nil
+}
+
+// BindProfile binds a profile to a node group, creating or enabling the
+// policy binding of every member policy in that group.
+func (s *ProfileService) BindProfile(ctx context.Context, profileID string, req *models.CreateProfileBindingRequest) (*models.ProfileBinding, error) {
+	if req.GroupID == "" {
+		return nil, fmt.Errorf("group_id is required")
+	}
+	p, err := s.repo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify profile: %w", err)
+	}
+	if p == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+	group, err := s.nodeGroupRepo.GetByID(ctx, req.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify group: %w", err)
+	}
+	if group == nil {
+		return nil, fmt.Errorf("node group not found")
+	}
+
+	b := &models.ProfileBinding{
+		ProfileID: profileID,
+		GroupID:   group.ID,
+		GroupName: group.Name,
+		Priority:  req.Priority,
+	}
+	if err := s.repo.Bind(ctx, b); err != nil {
+		return nil, fmt.Errorf("failed to bind profile: %w", err)
+	}
+	return b, nil
+}
+
+// UnbindProfile removes a profile's binding to a node group together with
+// the policy bindings only that profile required.
+func (s *ProfileService) UnbindProfile(ctx context.Context, profileID, groupID string) error {
+	found, err := s.repo.Unbind(ctx, profileID, groupID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("profile binding not found")
+	}
+	return nil
+}
+
+// validatePolicyIDs checks that every policy exists and returns the IDs
+// with duplicates removed.
+func (s *ProfileService) validatePolicyIDs(ctx context.Context, ids []string) ([]string, error) {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		policy, err := s.policyRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify policy: %w", err)
+		}
+		if policy == nil {
+			return nil, fmt.Errorf("policy not found: %s", id)
+		}
+		unique = append(unique, id)
+	}
+	return unique, nil
+}
@@ -0,0 +1,522 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+// RBACService exports and imports the authorization model (roles, their
+// permissions and, optionally, user and user-group role bindings) as a
+// portable document. Every reference in the document is by name, so IDs
+// are remapped when the document is applied to another instance.
+type RBACService struct {
+	roleRepo             *database.RoleRepository
+	permRepo             *database.PermissionRepository
+	userRepo             *database.UserRepository
+	userGroupRepo        *database.UserGroupRepository
+	nodeGroupRepo        *database.NodeGroupRepository
+	userBindingRepo      *database.UserRoleBindingRepository
+	userGroupBindingRepo *database.UserGroupRoleBindingRepository
+}
+
+// NewRBACService creates a new RBACService
+func NewRBACService(
+	roleRepo *database.RoleRepository,
+	permRepo *database.PermissionRepository,
+	userRepo *database.UserRepository,
+	userGroupRepo *database.UserGroupRepository,
+	nodeGroupRepo *database.NodeGroupRepository,
+	userBindingRepo *database.UserRoleBindingRepository,
+	userGroupBindingRepo *database.UserGroupRoleBindingRepository,
+) *RBACService {
+	return &RBACService{
+		roleRepo:             roleRepo,
+		permRepo:             permRepo,
+		userRepo:             userRepo,
+		userGroupRepo:        userGroupRepo,
+		nodeGroupRepo:        nodeGroupRepo,
+		userBindingRepo:      userBindingRepo,
+		userGroupBindingRepo: userGroupBindingRepo,
+	}
+}
+
+// Export builds an RBAC export document. When includeBindings is true the
+// user and user-group role bindings are included as well.
+func (s *RBACService) Export(ctx context.Context, includeBindings bool) (*models.RBACExport, error) {
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	export := &models.RBACExport{
+		Version:    models.RBACExportVersion,
+		ExportedAt: timeNow(),
+		Roles:      make([]models.RBACExportRole, 0, len(roles)),
+	}
+
+	roleNames := make(map[string]string, len(roles))
+	for _, role := range roles {
+		roleNames[role.ID] = role.Name
+
+		perms, err := s.roleRepo.GetPermissionsByRoleID(ctx, role.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get permissions for role %s: %w", role.Name, err)
+		}
+		keys := make([]string, 0, len(perms))
+		for _, p := range perms {
+			keys = append(keys, p.Resource+":"+p.Action)
+		}
+		sort.Strings(keys)
+
+		export.Roles = append(export.Roles, models.RBACExportRole{
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: keys,
+		})
+	}
+
+	if !includeBindings {
+		return export, nil
+	}
+
+	nodeGroups, err := s.nodeGroupRepo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node groups: %w", err)
+	}
+	nodeGroupNames := make(map[string]string, len(nodeGroups))
+	for _, ng := range nodeGroups {
+		nodeGroupNames[ng.ID] = ng.Name
+	}
+
+	userBindings, err := s.userBindingRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usernames := make(map[string]string)
+	for _, b := range userBindings {
+		username, ok := usernames[b.UserID]
+		if !ok {
+			user, err := s.userRepo.GetByID(ctx, b.UserID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get user %s: %w", b.UserID, err)
+			}
+			if user != nil {
+				username = user.Username
+			}
+			usernames[b.UserID] = username
+		}
+		roleName, ok := roleNames[b.RoleID]
+		if username == "" || !ok {
+			continue
+		}
+		export.UserBindings = append(export.UserBindings, models.RBACExportUserBinding{
+			Username:  username,
+			Role:      roleName,
+			ScopeType: b.ScopeType,
+			ScopeName: exportScopeName(b.ScopeType, b.ScopeID, nodeGroupNames),
+		})
+	}
+
+	userGroups, err := s.userGroupRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userGroupNames := make(map[string]string, len(userGroups))
+	for _, ug := range userGroups {
+		userGroupNames[ug.ID] = ug.Name
+	}
+
+	groupBindings, err := s.userGroupBindingRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range groupBindings {
+		groupName, okGroup := userGroupNames[b.GroupID]
+		roleName, okRole := roleNames[b.RoleID]
+		if !okGroup || !okRole {
+			continue
+		}
+		export.GroupBindings = append(export.GroupBindings, models.RBACExportGroupBinding{
+			Group:     groupName,
+			Role:      roleName,
+			ScopeType: b.ScopeType,
+			ScopeName: exportScopeName(b.ScopeType, b.ScopeID, nodeGroupNames),
+		})
+	}
+
+	return export, nil
+}
+
+// builtinRoles are the roles seeded by the database migrations.
+var builtinRoles = []string{ //nolint:gochecknoglobals // fixed list of seeded role names
+	models.RoleSuperAdmin, models.RoleOrgAdmin, models.RolePolicyEditor,
+	models.RolePolicyReviewer, models.RoleComplianceViewer, models.RoleAuditor,
+}
+
+// Import applies an RBAC export document. The operation is idempotent:
+// roles are matched by name and created or updated, each role's permission
+// set is replaced with the one in the document, and bindings are only
+// created when an identical binding does not already exist. References
+// that cannot be resolved on this instance (unknown permissions, users,
+// groups or node groups) are skipped and reported as warnings.
+//
+// The document is resolved first and then applied in one transaction, so
+// an error leaves the authorization model unchanged. Changing the
+// permissions of a built-in role such as Super Admin is refused unless
+// overwriteBuiltin is set.
+func (s *RBACService) Import(ctx context.Context, doc *models.RBACExport, overwriteBuiltin bool) (*models.RBACImportResult, error) {
+	if err := validateRBACExport(doc); err != nil {
+		return nil, err
+	}
+
+	result := &models.RBACImportResult{Warnings: []string{}}
+	plan := &database.RBACImportPlan{}
+
+	// roleIDs maps the roles of the document, and roles referenced by its
+	// bindings, to their local ID; roles created by the import map to "".
+	roleIDs := make(map[string]string, len(doc.Roles))
+	for _, r := range doc.Roles {
+		permIDs := make([]string, 0, len(r.Permissions))
+		for _, key := range r.Permissions {
+			resource, action, _ := strings.Cut(key, ":")
+			perm, err := s.permRepo.GetByResourceAction(ctx, resource, action)
+			if err != nil {
+				return nil, err
+			}
+			if perm == nil {
+				result.Warnings = append(result.Warnings,
+					fmt.Sprintf("role %q: unknown permission %q skipped", r.Name, key))
+				continue
+			}
+			permIDs = append(permIDs, perm.ID)
+		}
+
+		existing, err := s.roleRepo.GetByName(ctx, r.Name)
+		if err != nil {
+			return nil, err
+		}
+		role := database.RBACImportRole{Name: r.Name, Description: r.Description, PermissionIDs: permIDs}
+		if existing == nil {
+			result.RolesCreated++
+		} else {
+			if !overwriteBuiltin && slices.Contains(builtinRoles, r.Name) {
+				changed, err := s.permissionsChanged(ctx, existing.ID, permIDs)
+				if err != nil {
+					return nil, err
+				}
+				if changed {
+					return nil, fmt.Errorf("role %q is built in; replacing its permissions requires overwrite_builtin", r.Name)
+				}
+			}
+			role.ID = existing.ID
+			result.RolesUpdated++
+		}
+		plan.Roles = append(plan.Roles, role)
+		roleIDs[r.Name] = role.ID
+	}
+
+	if len(doc.UserBindings) > 0 || len(doc.GroupBindings) > 0 {
+		if err := s.planBindings(ctx, doc, roleIDs, plan, result); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.roleRepo.ApplyImport(ctx, plan); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// planBindings adds the bindings of doc that do not exist yet to plan.
+func (s *RBACService) planBindings(ctx context.Context, doc *models.RBACExport, roleIDs map[string]string, plan *database.RBACImportPlan, result *models.RBACImportResult) error {
+	nodeGroups, err := s.nodeGroupRepo.ListAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list node groups: %w", err)
+	}
+	nodeGroupIDs := make(map[string]string, len(nodeGroups))
+	for _, ng := range nodeGroups {
+		nodeGroupIDs[ng.Name] = ng.ID
+	}
+
+	// planned catches duplicates within the document, which the existing
+	// bindings cannot since nothing is written before the plan is applied.
+	planned := make(map[string]bool)
+	bindingKey := func(kind, subjectID, role, scopeType string, scopeID *string) string {
+		id := ""
+		if scopeID != nil {
+			id = *scopeID
+		}
+		return strings.Join([]string{kind, subjectID, role, scopeType, id}, "\x00")
+	}
+
+	for _, b := range doc.UserBindings {
+		roleID, ok, err := s.resolveRoleID(ctx, roleIDs, b.Role)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("user binding %s → %s: unknown role skipped", b.Username, b.Role))
+			continue
+		}
+		user, err := s.userRepo.GetByUsername(ctx, b.Username)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("user binding %s → %s: unknown user skipped", b.Username, b.Role))
+			continue
+		}
+		scopeID, ok := importScopeID(b.ScopeType, b.ScopeName, nodeGroupIDs)
+		if !ok {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("user binding %s → %s: unknown scope %s/%s skipped", b.Username, b.Role, b.ScopeType, b.ScopeName))
+			continue
+		}
+
+		key := bindingKey("user", user.ID, b.Role, b.ScopeType, scopeID)
+		if planned[key] {
+			continue
+		}
+		if roleID != "" {
+			existing, err := s.userBindingRepo.ListByUserID(ctx, user.ID)
+			if err != nil {
+				return err
+			}
+			if hasUserBinding(existing, roleID, b.ScopeType, scopeID) {
+				continue
+			}
+		}
+		planned[key] = true
+		plan.UserBindings = append(plan.UserBindings, database.RBACImportBinding{
+			SubjectID: user.ID,
+			RoleID:    roleID,
+			RoleName:  b.Role,
+			ScopeType: b.ScopeType,
+			ScopeID:   scopeID,
+		})
+		result.UserBindingsCreated++
+	}
+
+	var userGroupIDs map[string]string
+	if len(doc.GroupBindings) > 0 {
+		userGroups, err := s.userGroupRepo.ListAll(ctx)
+		if err != nil {
+			return err
+		}
+		userGroupIDs = make(map[string]string, len(userGroups))
+		for _, ug := range userGroups {
+			userGroupIDs[ug.Name] = ug.ID
+		}
+	}
+
+	for _, b := range doc.GroupBindings {
+		roleID, ok, err := s.resolveRoleID(ctx, roleIDs, b.Role)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("group binding %s → %s: unknown role skipped", b.Group, b.Role))
+			continue
+		}
+		groupID, ok := userGroupIDs[b.Group]
+		if !ok {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("group binding %s → %s: unknown user group skipped", b.Group, b.Role))
+			continue
+		}
+		scopeID, ok := importScopeID(b.ScopeType, b.ScopeName, nodeGroupIDs)
+		if !ok {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("group binding %s → %s: unknown scope %s/%s skipped", b.Group, b.Role, b.ScopeType, b.ScopeName))
+			continue
+		}
+
+		key := bindingKey("group", groupID, b.Role, b.ScopeType, scopeID)
+		if planned[key] {
+			continue
+		}
+		if roleID != "" {
+			existing, err := s.userGroupBindingRepo.ListByGroupID(ctx, groupID)
+			if err != nil {
+				return err
+			}
+			if hasGroupBinding(existing, roleID, b.ScopeType, scopeID) {
+				continue
+			}
+		}
+		planned[key] = true
+		plan.GroupBindings = append(plan.GroupBindings, database.RBACImportBinding{
+			SubjectID: groupID,
+			RoleID:    roleID,
+			RoleName:  b.Role,
+			ScopeType: b.ScopeType,
+			ScopeID:   scopeID,
+		})
+		result.GroupBindingsCreated++
+	}
+
+	return nil
+}
+
+// resolveRoleID returns the local ID of the named role, preferring roles
+// created or updated earlier in the same import. The ID is empty for a
+// role the import creates; ok is false when the role does not exist.
+func (s *RBACService) resolveRoleID(ctx context.Context, imported map[string]string, name string) (id string, ok bool, err error) {
+	if id, ok := imported[name]; ok {
+		return id, true, nil
+	}
+	role, err := s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		return "", false, err
+	}
+	if role == nil {
+		return "", false, nil
+	}
+	imported[name] = role.ID
+	return role.ID, true, nil
+}
+
+// permissionsChanged reports whether permIDs differs from the current
+// permission set of the role.
+func (s *RBACService) permissionsChanged(ctx context.Context, roleID string, permIDs []string) (bool, error) {
+	current, err := s.roleRepo.GetPermissionsByRoleID(ctx, roleID)
+	if err != nil {
+		return false, err
+	}
+	have := make([]string, 0, len(current))
+	for _, p := range current {
+		have = append(have, p.ID)
+	}
+	return !samePermissionIDs(have, permIDs), nil
+}
+
+// samePermissionIDs reports whether a and b hold the same permission IDs,
+// ignoring order and duplicates.
+func samePermissionIDs(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// validateRBACExport checks the structure of an RBAC export document
+// before any of it is applied.
+func validateRBACExport(doc *models.RBACExport) error {
+	if doc == nil {
+		return fmt.Errorf("import document is required")
+	}
+	if doc.Version != models.RBACExportVersion {
+		return fmt.Errorf("unsupported export version %d (expected %d)", doc.Version, models.RBACExportVersion)
+	}
+	seen := make(map[string]bool, len(doc.Roles))
+	for _, r := range doc.Roles {
+		if r.Name == "" {
+			return fmt.Errorf("role name is required")
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("duplicate role %q", r.Name)
+		}
+		seen[r.Name] = true
+		for _, key := range r.Permissions {
+			resource, action, ok := strings.Cut(key, ":")
+			if !ok || resource == "" || action == "" {
+				return fmt.Errorf("role %q: invalid permission %q (expected resource:action)", r.Name, key)
+			}
+		}
+	}
+	for _, b := range doc.UserBindings {
+		if b.Username == "" || b.Role == "" {
+			return fmt.Errorf("user binding requires username and role")
+		}
+		if !isValidScopeType(b.ScopeType) {
+			return fmt.Errorf("user binding %s: invalid scope type %q", b.Username, b.ScopeType)
+		}
+	}
+	for _, b := range doc.GroupBindings {
+		if b.Group == "" || b.Role == "" {
+			return fmt.Errorf("group binding requires group and role")
+		}
+		if !isValidScopeType(b.ScopeType) {
+			return fmt.Errorf("group binding %s: invalid scope type %q", b.Group, b.ScopeType)
+		}
+	}
+	return nil
+}
+
+func isValidScopeType(scopeType string) bool {
+	switch scopeType {
+	case models.ScopeGlobal, models.ScopeOrganization, models.ScopeGroup:
+		return true
+	}
+	return false
+}
+
+// exportScopeName converts a binding scope ID into its portable form.
+// Node group scopes are exported by group name; organization scopes have
+// no named entity and keep their raw ID.
+func exportScopeName(scopeType string, scopeID *string, nodeGroupNames map[string]string) string {
+	if scopeID == nil || scopeType == models.ScopeGlobal {
+		return ""
+	}
+	if scopeType == models.ScopeGroup {
+		return nodeGroupNames[*scopeID]
+	}
+	return *scopeID
+}
+
+// importScopeID is the inverse of exportScopeName. It returns false when a
+// node group scope refers to a group that does not exist on this instance.
+func importScopeID(scopeType, scopeName string, nodeGroupIDs map[string]string) (*string, bool) {
+	if scopeType == models.ScopeGlobal || scopeName == "" {
+		return nil, scopeType == models.ScopeGlobal
+	}
+	if scopeType == models.ScopeGroup {
+		id, ok := nodeGroupIDs[scopeName]
+		if !ok {
+			return nil, false
+		}
+		return &id, true
+	}
+	return &scopeName, true
+}
+
+func sameScope(scopeType string, scopeID *string, wantType string, wantID *string) bool {
+	if scopeType != wantType {
+		return false
+	}
+	if scopeID == nil || wantID == nil {
+		return scopeID == nil && wantID == nil
+	}
+	return *scopeID == *wantID
+}
+
+func hasUserBinding(bindings []*models.UserRoleBinding, roleID, scopeType string, scopeID *string) bool {
+	for _, b := range bindings {
+		if b.RoleID == roleID && sameScope(b.ScopeType, b.ScopeID, scopeType, scopeID) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasGroupBinding(bindings []*models.UserGroupRoleBinding, roleID, scopeType string, scopeID *string) bool {
+	for _, b := range bindings {
+		if b.RoleID == roleID && sameScope(b.ScopeType, b.ScopeID, scopeType, scopeID) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func TestRBACService_Import_Validation(t *testing.T) {
+	svc := &RBACService{}
+
+	tests := []struct {
+		name    string
+		doc     *models.RBACExport
+		wantErr string
+	}{
+		{
+			name:    "nil document",
+			doc:     nil,
+			wantErr: "import document is required",
+		},
+		{
+			name:    "unsupported version",
+			doc:     &models.RBACExport{Version: 99},
+			wantErr: "unsupported export version 99 (expected 1)",
+		},
+		{
+			name: "empty role name",
+			doc: &models.RBACExport{
+				Version: models.RBACExportVersion,
+				Roles:   []models.RBACExportRole{{Name: ""}},
+			},
+			wantErr: "role name is required",
+		},
+		{
+			name: "duplicate role",
+			doc: &models.RBACExport{
+				Version: models.RBACExportVersion,
+				Roles:   []models.RBACExportRole{{Name: "Ops"}, {Name: "Ops"}},
+			},
+			wantErr: `duplicate role "Ops"`,
+		},
+		{
+			name: "malformed permission",
+			doc: &models.RBACExport{
+				Version: models.RBACExportVersion,
+				Roles:   []models.RBACExportRole{{Name: "Ops", Permissions: []string{"policy"}}},
+			},
+			wantErr: `role "Ops": invalid permission "policy" (expected resource:action)`,
+		},
+		{
+			name: "invalid user binding scope",
+			doc: &models.RBACExport{
+				Version:      models.RBACExportVersion,
+				UserBindings: []models.RBACExportUserBinding{{Username: "alice", Role: "Ops", ScopeType: "planet"}},
+			},
+			wantErr: `user binding alice: invalid scope type "planet"`,
+		},
+		{
+			name: "group binding without group",
+			doc: &models.RBACExport{
+				Version:       models.RBACExportVersion,
+				GroupBindings: []models.RBACExportGroupBinding{{Role: "Ops", ScopeType: models.ScopeGlobal}},
+			},
+			wantErr: "group binding requires group and role",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Import(context.Background(), tt.doc, false)
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if err.Error() != tt.wantErr {
+				t.Errorf("error = %q, want %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRBACScopeRoundTrip(t *testing.T) {
+	nodeGroupID := "11111111-1111-1111-1111-111111111111"
+	names := map[string]string{nodeGroupID: "Lab"}
+	ids := map[string]string{"Lab": nodeGroupID}
+
+	if got := exportScopeName(models.ScopeGlobal, nil, names); got != "" {
+		t.Errorf("global scope name = %q, want empty", got)
+	}
+	if got := exportScopeName(models.ScopeGroup, &nodeGroupID, names); got != "Lab" {
+		t.Errorf("group scope name = %q, want Lab", got)
+	}
+
+	id, ok := importScopeID(models.ScopeGroup, "Lab", ids)
+	if !ok || id == nil || *id != nodeGroupID {
+		t.Errorf("importScopeID(group, Lab) = %v, %v; want %s, true", id, ok, nodeGroupID)
+	}
+	if _, ok := importScopeID(models.ScopeGroup, "Missing", ids); ok {
+		t.Error("expected unknown node group to be unresolvable")
+	}
+	if id, ok := importScopeID(models.ScopeGlobal, "", ids); !ok || id != nil {
+		t.Errorf("importScopeID(global) = %v, %v; want nil, true", id, ok)
+	}
+}
+
+func TestHasUserBinding(t *testing.T) {
+	scope := "g1"
+	other := "g2"
+	bindings := []*models.UserRoleBinding{
+		{RoleID: "r1", ScopeType: models.ScopeGlobal},
+		{RoleID: "r2", ScopeType: models.ScopeGroup, ScopeID: &scope},
+	}
+
+	if !hasUserBinding(bindings, "r1", models.ScopeGlobal, nil) {
+		t.Error("expected global binding for r1 to be found")
+	}
+	if !hasUserBinding(bindings, "r2", models.ScopeGroup, &scope) {
+		t.Error("expected group binding for r2 to be found")
+	}
+	if hasUserBinding(bindings, "r2", models.ScopeGroup, &other) {
+		t.Error("binding with a different scope ID must not match")
+	}
+	if hasUserBinding(bindings, "r3", models.ScopeGlobal, nil) {
+		t.Error("binding with a different role must not match")
+	}
+}
+
+func TestSamePermissionIDs(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want bool
+	}{
+		{nil, nil, true},
+		{[]string{"p1", "p2"}, []string{"p2", "p1"}, true},
+		{[]string{"p1", "p1"}, []string{"p1"}, true},
+		{[]string{"p1"}, []string{"p1", "p2"}, false},
+		{[]string{"p1"}, nil, false},
+	}
+	for _, tt := range tests {
+		if got := samePermissionIDs(tt.a, tt.b); got != tt.want {
+			t.Errorf("samePermissionIDs(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// sysctlKeyRe matches a kernel parameter name such as "net.ipv4.ip_forward"
+// or "net/ipv4/conf/eth0.100/rp_filter". Whitespace and "=" would corrupt
+// the generated sysctl.d line.
+var sysctlKeyRe = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_./-]*$`)
+
+// ValidateSysctlPolicy validates a Sysctl policy content JSON string.
+func ValidateSysctlPolicy(content string) error {
+	if content == "" || content == "{}" {
+		return fmt.Errorf("sysctl policy content is empty")
+	}
+
+	var sp pb.SysctlPolicy
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(content), &sp); err != nil {
+		return fmt.Errorf("invalid sysctl policy JSON: %w", err)
+	}
+
+	if len(sp.Entries) == 0 {
+		return fmt.Errorf("sysctl policy must contain at least one entry")
+	}
+
+	seen := make(map[string]bool, len(sp.Entries))
+	for i, e := range sp.Entries {
+		if !sysctlKeyRe.MatchString(e.GetKey()) || strings.Contains(e.GetKey(), "..") {
+			return fmt.Errorf("entry[%d]: invalid key %q", i, e.GetKey())
+		}
+		// As in sysctl(8), a key containing "/" uses it as the separator and
+		// keeps dots literal, so both spellings name the same parameter.
+		key := e.GetKey()
+		if !strings.Contains(key, "/") {
+			key = strings.ReplaceAll(key, ".", "/")
+		}
+		if seen[key] {
+			return fmt.Errorf("entry[%d]: duplicate key %q", i, e.GetKey())
+		}
+		seen[key] = true
+		if strings.TrimSpace(e.GetValue()) == "" {
+			return fmt.Errorf("entry[%d]: value is required", i)
+		}
+		if strings.ContainsAny(e.GetValue(), "\r\n") {
+			return fmt.Errorf("entry[%d]: value must be a single line", i)
+		}
+	}
+
+	return nil
+}
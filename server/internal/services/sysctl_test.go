@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSysctlPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"valid", `{"entries":[{"key":"net.ipv4.ip_forward","value":"0"},{"key":"net/ipv4/conf/eth0.100/rp_filter","value":"1"}]}`, ""},
+		{"empty", `{}`, "empty"},
+		{"invalid json", `{bad`, "invalid sysctl policy JSON"},
+		{"no entries", `{"entries":[]}`, "at least one entry"},
+		{"missing key", `{"entries":[{"value":"1"}]}`, "invalid key"},
+		{"key with equals", `{"entries":[{"key":"kernel.x=1","value":"1"}]}`, "invalid key"},
+		{"path traversal", `{"entries":[{"key":"net/../../etc/shadow","value":"1"}]}`, "invalid key"},
+		{"duplicate key", `{"entries":[{"key":"vm.swappiness","value":"10"},{"key":"vm/swappiness","value":"20"}]}`, "duplicate key"},
+		{"missing value", `{"entries":[{"key":"vm.swappiness","value":" "}]}`, "value is required"},
+		{"multi-line value", `{"entries":[{"key":"vm.swappiness","value":"10\nkernel.sysrq = 1"}]}`, "single line"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSysctlPolicy(tt.content)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// revokedTokenStore is the part of the revoked token repository the auth
+// service uses.
+type revokedTokenStore interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	DeleteExpired(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// newTokenID returns a random JWT ID (jti).
+func newTokenID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithTokenRevocation records revoked tokens in repo, so that a token can
+// be invalidated before it expires, e.g. on logout.
+func (s *AuthService) WithTokenRevocation(repo *database.RevokedTokenRepository) *AuthService {
+	if repo != nil {
+		s.revokedTokens = repo
+	}
+	return s
+}
+
+// revoke records the token with the registered claims rc as revoked until
+// it expires. Tokens without an ID, issued before IDs were added, and
+// expired tokens are skipped.
+func (s *AuthService) revoke(ctx context.Context, rc jwt.RegisteredClaims) error {
+	if s.revokedTokens == nil || rc.ID == "" || rc.ExpiresAt == nil || !rc.ExpiresAt.After(time.Now()) {
+		return nil
+	}
+	return s.revokedTokens.Revoke(ctx, rc.ID, rc.ExpiresAt.Time)
+}
+
+// Logout revokes the given access and refresh tokens. Either may be empty;
+// tokens that are invalid or already expired are ignored.
+func (s *AuthService) Logout(ctx context.Context, accessToken, refreshToken string) error {
+	if accessToken != "" {
+		if claims, err := s.ValidateToken(accessToken); err == nil {
+			if err := s.revoke(ctx, claims.RegisteredClaims); err != nil {
+				return err
+			}
+		}
+	}
+	if refreshToken != "" {
+		if claims, err := s.ValidateRefreshToken(refreshToken); err == nil {
+			if err := s.revoke(ctx, claims.RegisteredClaims); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IsTokenRevoked returns whether the token with the JWT ID jti has been
+// revoked. Tokens without an ID are never revoked.
+func (s *AuthService) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	if s.revokedTokens == nil || jti == "" {
+		return false, nil
+	}
+	return s.revokedTokens.IsRevoked(ctx, jti)
+}
+
+// StartRevokedTokenPurge removes revocations of expired tokens every
+// interval, since an expired token is rejected anyway. The returned
+// function stops the purge.
+func (s *AuthService) StartRevokedTokenPurge(interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if s.revokedTokens == nil {
+					continue
+				}
+				n, err := s.revokedTokens.DeleteExpired(ctx, time.Now())
+				if err != nil && ctx.Err() == nil {
+					log.Printf("Failed to purge expired token revocations: %v", err)
+				} else if n > 0 {
+					log.Printf("Purged %d expired token revocation(s)", n)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+type fakeRevokedTokenStore struct {
+	revoked map[string]time.Time
+}
+
+func (f *fakeRevokedTokenStore) Revoke(_ context.Context, jti string, expiresAt time.Time) error {
+	f.revoked[jti] = expiresAt
+	return nil
+}
+
+func (f *fakeRevokedTokenStore) IsRevoked(_ context.Context, jti string) (bool, error) {
+	_, ok := f.revoked[jti]
+	return ok, nil
+}
+
+func (f *fakeRevokedTokenStore) DeleteExpired(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestAuthService_Logout_RevokesTokens(t *testing.T) {
+	store := &fakeRevokedTokenStore{revoked: make(map[string]time.Time)}
+	authSvc := &AuthService{jwtSecret: "test-secret-key", tokenLifetime: time.Hour, refreshLifetime: 24 * time.Hour, revokedTokens: store}
+	user := &models.User{ID: "u-1", Username: "alice"}
+	ctx := context.Background()
+
+	access, err := authSvc.generateToken(user)
+	if err != nil {
+		t.Fatalf("generateToken() error = %v", err)
+	}
+	refresh, err := authSvc.GenerateRefreshToken(user)
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken() error = %v", err)
+	}
+	other, err := authSvc.generateToken(user)
+	if err != nil {
+		t.Fatalf("generateToken() error = %v", err)
+	}
+
+	accessClaims, _ := authSvc.ValidateToken(access)
+	refreshClaims, _ := authSvc.ValidateRefreshToken(refresh)
+	otherClaims, _ := authSvc.ValidateToken(other)
+	if accessClaims.ID == "" || accessClaims.ID == otherClaims.ID {
+		t.Fatalf("token IDs %q and %q, want distinct non-empty IDs", accessClaims.ID, otherClaims.ID)
+	}
+
+	if err := authSvc.Logout(ctx, access, refresh); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	for name, jti := range map[string]string{"access": accessClaims.ID, "refresh": refreshClaims.ID} {
+		if revoked, _ := authSvc.IsTokenRevoked(ctx, jti); !revoked {
+			t.Errorf("%s token not revoked after Logout()", name)
+		}
+	}
+	if revoked, _ := authSvc.IsTokenRevoked(ctx, otherClaims.ID); revoked {
+		t.Error("token of another session revoked by Logout()")
+	}
+
+	if err := authSvc.Logout(ctx, "not-a-jwt", ""); err != nil {
+		t.Errorf("Logout() with an invalid token: error = %v, want nil", err)
+	}
+
+}
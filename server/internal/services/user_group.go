@@ -47,6 +47,15 @@ func (s *UserGroupService) ListUserGroups(ctx context.Context) ([]*models.UserGr
 	return s.repo.ListAll(ctx)
 }
 
+// ListUserGroupsPaginated returns page p of all user groups.
+func (s *UserGroupService) ListUserGroupsPaginated(ctx context.Context, p models.Pagination) (*models.ListResponse[*models.UserGroup], error) {
+	groups, total, err := s.repo.ListPaginated(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewListResponse(groups, total, p), nil
+}
+
 // UpdateUserGroup updates a user group
 func (s *UserGroupService) UpdateUserGroup(ctx context.Context, id string, req *models.UpdateUserGroupRequest) (*models.UserGroup, error) {
 	if err := s.repo.Update(ctx, id, req); err != nil {
@@ -0,0 +1,370 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/database"
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+const (
+	// webhookMaxAttempts is how often a delivery is tried before it is
+	// given up.
+	webhookMaxAttempts = 5
+	// webhookMinSecretLen is the minimum length of a signing secret.
+	webhookMinSecretLen = 16
+)
+
+// webhookEventTypes are the event types a webhook may subscribe to.
+var webhookEventTypes = []string{models.WebhookEventPolicyChanged, models.WebhookEventBindingChanged} //nolint:gochecknoglobals // fixed list
+
+// WebhookService manages webhooks and delivers events to them. Each
+// payload is signed with the webhook's secret: the X-Bor-Signature header
+// carries "sha256=" and the hex HMAC-SHA256 of the request body. Failed
+// deliveries are retried with exponential backoff.
+type WebhookService struct {
+	repo   *database.WebhookRepository
+	aesKey []byte
+	// fallbackKeys are tried when aesKey cannot decrypt a secret: keys from
+	// previous passphrases and keys derived with the MFA label, which
+	// encrypted webhook secrets before they had their own.
+	fallbackKeys [][]byte
+	client       *http.Client
+	// backoff is the delay before the first retry; it doubles on each
+	// further attempt.
+	backoff time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWebhookService creates a new WebhookService. Webhook secrets are
+// encrypted at rest with a key derived from key; secrets encrypted with one
+// of the previous keys can still be decrypted.
+func NewWebhookService(repo *database.WebhookRepository, key string, previous []string) *WebhookService {
+	fallbackKeys := [][]byte{deriveAESKey(key, mfaKeyInfo)}
+	for _, p := range previous {
+		fallbackKeys = append(fallbackKeys, deriveAESKey(p, webhookKeyInfo), deriveAESKey(p, mfaKeyInfo))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebhookService{
+		repo:         repo,
+		aesKey:       deriveAESKey(key, webhookKeyInfo),
+		fallbackKeys: fallbackKeys,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		backoff:      2 * time.Second,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// decryptSecret decrypts a stored webhook secret, trying the fallback keys
+// when the current key does not fit.
+func (s *WebhookService) decryptSecret(encSecret string) ([]byte, error) {
+	secret, err := aesDecrypt(s.aesKey, encSecret)
+	if err == nil {
+		return secret, nil
+	}
+	for _, key := range s.fallbackKeys {
+		if secret, fallbackErr := aesDecrypt(key, encSecret); fallbackErr == nil {
+			return secret, nil
+		}
+	}
+	return nil, err
+}
+
+// Close cancels pending retries and waits for running deliveries.
+func (s *WebhookService) Close() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+// CreateWebhook creates a new webhook
+func (s *WebhookService) CreateWebhook(ctx context.Context, req *models.CreateWebhookRequest) (*models.Webhook, error) {
+	if req.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	if len(req.Secret) < webhookMinSecretLen {
+		return nil, fmt.Errorf("secret must be at least %d characters", webhookMinSecretLen)
+	}
+	if err := validateWebhookEvents(req.Events); err != nil {
+		return nil, err
+	}
+	encSecret, err := aesEncrypt(s.aesKey, []byte(req.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("encrypt webhook secret: %w", err)
+	}
+	wh := &models.Webhook{
+		Name:    req.Name,
+		URL:     req.URL,
+		Secret:  encSecret,
+		Events:  req.Events,
+		Enabled: req.Enabled == nil || *req.Enabled,
+	}
+	if wh.Events == nil {
+		wh.Events = []string{}
+	}
+	if err := s.repo.Create(ctx, wh); err != nil {
+		return nil, err
+	}
+	return wh, nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func (s *WebhookService) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListWebhooks returns all webhooks
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]*models.Webhook, error) {
+	return s.repo.ListAll(ctx)
+}
+
+// UpdateWebhook updates a webhook
+func (s *WebhookService) UpdateWebhook(ctx context.Context, id string, req *models.UpdateWebhookRequest) (*models.Webhook, error) {
+	if req.Name != nil && *req.Name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	if req.URL != nil {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+	}
+	if req.Events != nil {
+		if err := validateWebhookEvents(*req.Events); err != nil {
+			return nil, err
+		}
+	}
+	update := *req
+	if req.Secret != nil {
+		if len(*req.Secret) < webhookMinSecretLen {
+			return nil, fmt.Errorf("secret must be at least %d characters", webhookMinSecretLen)
+		}
+		encSecret, err := aesEncrypt(s.aesKey, []byte(*req.Secret))
+		if err != nil {
+			return nil, fmt.Errorf("encrypt webhook secret: %w", err)
+		}
+		update.Secret = &encSecret
+	}
+	if err := s.repo.Update(ctx, id, &update); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// DeleteWebhook deletes a webhook
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// PolicyChanged sends a policy.changed event for p to the subscribed
+// webhooks in the background.
+func (s *WebhookService) PolicyChanged(p *models.Policy, actor string) {
+	s.Publish(models.WebhookEventPolicyChanged, models.WebhookResource{
+		Type:  "policy",
+		ID:    p.ID,
+		Name:  p.Name,
+		State: p.State,
+	}, actor)
+}
+
+// BindingChanged sends a binding.changed event for b to the subscribed
+// webhooks in the background.
+func (s *WebhookService) BindingChanged(b *models.PolicyBinding, actor string) {
+	s.Publish(models.WebhookEventBindingChanged, models.WebhookResource{
+		Type:     "policy_binding",
+		ID:       b.ID,
+		State:    b.State,
+		PolicyID: b.PolicyID,
+	}, actor)
+}
+
+// Publish sends an event to every enabled webhook subscribed to its type.
+// It returns at once; delivery, including retries, happens in the
+// background. An empty actor means the server itself.
+func (s *WebhookService) Publish(eventType string, resource models.WebhookResource, actor string) {
+	event := newWebhookEvent(eventType, resource, actor)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		webhooks, err := s.repo.ListEnabled(s.ctx)
+		if err != nil {
+			log.Printf("Failed to list webhooks for %s event: %v", eventType, err)
+			return
+		}
+		for _, wh := range webhooks {
+			if len(wh.Events) > 0 && !slices.Contains(wh.Events, eventType) {
+				continue
+			}
+			secret, err := s.decryptSecret(wh.Secret)
+			if err != nil {
+				log.Printf("Failed to decrypt secret of webhook %s: %v", wh.Name, err)
+				continue
+			}
+			s.wg.Add(1)
+			go func(wh *models.Webhook, secret []byte) {
+				defer s.wg.Done()
+				if err := s.deliverWithRetry(s.ctx, wh.URL, secret, event); err != nil {
+					log.Printf("Webhook %s: giving up on %s event %s: %v", wh.Name, event.Type, event.ID, err)
+				}
+			}(wh, secret)
+		}
+	}()
+}
+
+// Test sends a test event to the webhook with the given ID, or to every
+// enabled webhook when id is empty, and reports the result of each
+// delivery. Test deliveries are not retried.
+func (s *WebhookService) Test(ctx context.Context, id, actor string) ([]models.WebhookDeliveryResult, error) {
+	var webhooks []*models.Webhook
+	if id != "" {
+		wh, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if wh == nil {
+			return nil, fmt.Errorf("webhook not found")
+		}
+		webhooks = []*models.Webhook{wh}
+	} else {
+		var err error
+		if webhooks, err = s.repo.ListEnabled(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	event := newWebhookEvent(models.WebhookEventTest, models.WebhookResource{Type: "webhook"}, actor)
+	results := make([]models.WebhookDeliveryResult, 0, len(webhooks))
+	for _, wh := range webhooks {
+		res := models.WebhookDeliveryResult{WebhookID: wh.ID, Name: wh.Name}
+		secret, err := s.decryptSecret(wh.Secret)
+		if err != nil {
+			res.Error = "failed to decrypt webhook secret"
+		} else {
+			ev := event
+			ev.Resource.ID, ev.Resource.Name = wh.ID, wh.Name
+			res.StatusCode, err = s.deliver(ctx, wh.URL, secret, ev)
+			if err != nil {
+				res.Error = err.Error()
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// deliverWithRetry delivers event, retrying with exponential backoff
+// while the failure may be temporary.
+func (s *WebhookService) deliverWithRetry(ctx context.Context, target string, secret []byte, event models.WebhookEvent) error {
+	delay := s.backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var code int
+		code, err = s.deliver(ctx, target, secret, event)
+		if err == nil || !webhookRetryable(code) || attempt == webhookMaxAttempts {
+			return err
+		}
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// deliver POSTs event to target once and returns the response status. A
+// non-2xx status is an error.
+func (s *WebhookService) deliver(ctx context.Context, target string, secret []byte, event models.WebhookEvent) (int, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return 0, fmt.Errorf("encode webhook event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Bor-Webhook")
+	req.Header.Set("X-Bor-Event", event.Type)
+	req.Header.Set("X-Bor-Delivery", event.ID)
+	req.Header.Set("X-Bor-Signature", signWebhookPayload(secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// webhookRetryable reports whether a delivery that got status code (0 for
+// no response) is worth retrying.
+func webhookRetryable(code int) bool {
+	return code == 0 || code == http.StatusTooManyRequests || code >= 500
+}
+
+// signWebhookPayload returns the X-Bor-Signature header value for body.
+func signWebhookPayload(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookEvent(eventType string, resource models.WebhookResource, actor string) models.WebhookEvent {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	if actor == "" {
+		actor = "system"
+	}
+	return models.WebhookEvent{
+		ID:        hex.EncodeToString(b),
+		Type:      eventType,
+		Resource:  resource,
+		Actor:     actor,
+		Timestamp: timeNow().UTC(),
+	}
+}
+
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+	return nil
+}
+
+func validateWebhookEvents(events []string) error {
+	for _, e := range events {
+		if !slices.Contains(webhookEventTypes, e) {
+			return fmt.Errorf("unknown webhook event %q", e)
+		}
+	}
+	return nil
+}
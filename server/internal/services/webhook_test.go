@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VuteTech/Bor/server/internal/models"
+)
+
+func newTestWebhookService() *WebhookService {
+	return &WebhookService{client: &http.Client{Timeout: 5 * time.Second}, backoff: time.Millisecond}
+}
+
+func TestWebhookDeliver_Signed(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	event := newWebhookEvent(models.WebhookEventPolicyChanged, models.WebhookResource{Type: "policy", ID: "p1", State: models.PolicyStateReleased}, "alice")
+
+	var got models.WebhookEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if sig := r.Header.Get("X-Bor-Signature"); !hmac.Equal([]byte(sig), []byte(signWebhookPayload(secret, body))) {
+			t.Errorf("X-Bor-Signature = %q does not match the body", sig)
+		}
+		if r.Header.Get("X-Bor-Event") != models.WebhookEventPolicyChanged || r.Header.Get("X-Bor-Delivery") != event.ID {
+			t.Errorf("headers = %v", r.Header)
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	if _, err := newTestWebhookService().deliver(context.Background(), srv.URL, secret, event); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if got.Type != models.WebhookEventPolicyChanged || got.Actor != "alice" || got.Resource.ID != "p1" || got.Timestamp.IsZero() {
+		t.Errorf("payload = %+v", got)
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// echo -n '{}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=77325902caca812dc259733aacd046b73817372c777b8d95b402647474516e13"
+	if got := signWebhookPayload([]byte("secret"), []byte("{}")); got != want {
+		t.Errorf("signWebhookPayload = %q, want %q", got, want)
+	}
+}
+
+func TestWebhookDeliverWithRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		codes    []int
+		wantErr  bool
+		attempts int32
+	}{
+		{"succeeds after server errors", []int{500, 503, 200}, false, 3},
+		{"retries rate limiting", []int{429, 204}, false, 2},
+		{"client error is final", []int{400, 200}, true, 1},
+		{"gives up", []int{500, 500, 500, 500, 500, 500}, true, webhookMaxAttempts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := calls.Add(1)
+				w.WriteHeader(tt.codes[n-1])
+			}))
+			defer srv.Close()
+
+			event := newWebhookEvent(models.WebhookEventTest, models.WebhookResource{Type: "webhook"}, "")
+			err := newTestWebhookService().deliverWithRetry(context.Background(), srv.URL, []byte("secret"), event)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("deliverWithRetry error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls.Load() != tt.attempts {
+				t.Errorf("attempts = %d, want %d", calls.Load(), tt.attempts)
+			}
+		})
+	}
+}
+
+func TestNewWebhookEvent_DefaultActor(t *testing.T) {
+	if e := newWebhookEvent(models.WebhookEventTest, models.WebhookResource{}, ""); e.Actor != "system" || e.ID == "" {
+		t.Errorf("event = %+v", e)
+	}
+}
+
+func TestValidateWebhook(t *testing.T) {
+	for _, u := range []string{"", "example.com/hook", "ftp://example.com/hook", "https://"} {
+		if err := validateWebhookURL(u); err == nil {
+			t.Errorf("validateWebhookURL(%q) = nil", u)
+		}
+	}
+	if err := validateWebhookURL("https://hooks.example.com/bor"); err != nil {
+		t.Errorf("validateWebhookURL() error = %v", err)
+	}
+	if err := validateWebhookEvents([]string{models.WebhookEventPolicyChanged, "node.deleted"}); err == nil {
+		t.Error("validateWebhookEvents accepted an unknown event")
+	}
+	if err := validateWebhookEvents([]string{models.WebhookEventBindingChanged}); err != nil {
+		t.Errorf("validateWebhookEvents() error = %v", err)
+	}
+}
+
+func TestWebhookService_DecryptSecret(t *testing.T) {
+	svc := NewWebhookService(nil, "data-key", []string{"old-jwt-secret"})
+	defer svc.Close()
+
+	if bytes.Equal(svc.aesKey, deriveAESKey("data-key", mfaKeyInfo)) {
+		t.Fatal("webhook secrets share the TOTP secret key")
+	}
+
+	for name, key := range map[string][]byte{
+		"current":      svc.aesKey,
+		"mfa label":    deriveAESKey("data-key", mfaKeyInfo),
+		"previous key": deriveAESKey("old-jwt-secret", mfaKeyInfo),
+	} {
+		enc, err := aesEncrypt(key, []byte("hook-secret"))
+		if err != nil {
+			t.Fatalf("aesEncrypt() error = %v", err)
+		}
+		got, err := svc.decryptSecret(enc)
+		if err != nil || string(got) != "hook-secret" {
+			t.Errorf("%s: decryptSecret() = %q, %v; want %q", name, got, err, "hook-secret")
+		}
+	}
+}
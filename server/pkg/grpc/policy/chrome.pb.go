@@ -499,8 +499,13 @@ type ChromePolicy struct {
 	ClipboardAllowedForUrls []string `protobuf:"bytes,852,rep,name=ClipboardAllowedForUrls,proto3" json:"ClipboardAllowedForUrls,omitempty"`
 	// Block clipboard for these origins.
 	ClipboardBlockedForUrls []string `protobuf:"bytes,853,rep,name=ClipboardBlockedForUrls,proto3" json:"ClipboardBlockedForUrls,omitempty"`
-	unknownFields           protoimpl.UnknownFields
-	sizeCache               protoimpl.SizeCache
+	// Policy names (e.g. "HomepageLocation") set in this policy that are
+	// written as recommended defaults users may override, to
+	// policies/recommended, instead of as mandatory policies to
+	// policies/managed.
+	BorRecommendedPolicies []string `protobuf:"bytes,1000,rep,name=BorRecommendedPolicies,proto3" json:"BorRecommendedPolicies,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *ChromePolicy) Reset() {
@@ -2164,13 +2169,20 @@ func (x *ChromePolicy) GetClipboardBlockedForUrls() []string {
 	return nil
 }
 
+func (x *ChromePolicy) GetBorRecommendedPolicies() []string {
+	if x != nil {
+		return x.BorRecommendedPolicies
+	}
+	return nil
+}
+
 var File_chrome_proto protoreflect.FileDescriptor
 
 var file_chrome_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x63, 0x68, 0x72, 0x6f, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d,
 	0x62, 0x6f, 0x72, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73,
-	0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfa, 0x9b, 0x01, 0x0a,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb3, 0x9c, 0x01, 0x0a,
 	0x0c, 0x43, 0x68, 0x72, 0x6f, 0x6d, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x2f, 0x0a,
 	0x10, 0x48, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x10, 0x48, 0x6f, 0x6d, 0x65, 0x70,
@@ -3119,310 +3131,314 @@ var file_chrome_proto_rawDesc = []byte{
 	0x0a, 0x17, 0x43, 0x6c, 0x69, 0x70, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
 	0x65, 0x64, 0x46, 0x6f, 0x72, 0x55, 0x72, 0x6c, 0x73, 0x18, 0xd5, 0x06, 0x20, 0x03, 0x28, 0x09,
 	0x52, 0x17, 0x43, 0x6c, 0x69, 0x70, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x65, 0x64, 0x46, 0x6f, 0x72, 0x55, 0x72, 0x6c, 0x73, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x48, 0x6f,
-	0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x17,
-	0x0a, 0x15, 0x5f, 0x48, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x49, 0x73, 0x4e, 0x65, 0x77,
-	0x54, 0x61, 0x62, 0x50, 0x61, 0x67, 0x65, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x4e, 0x65, 0x77, 0x54,
-	0x61, 0x62, 0x50, 0x61, 0x67, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x13,
-	0x0a, 0x11, 0x5f, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x4f, 0x6e, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x75, 0x70, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x53, 0x68, 0x6f, 0x77, 0x48, 0x6f, 0x6d, 0x65,
-	0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75,
-	0x6c, 0x74, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x42, 0x6f, 0x6f, 0x6b,
-	0x6d, 0x61, 0x72, 0x6b, 0x42, 0x61, 0x72, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x17,
-	0x0a, 0x15, 0x5f, 0x45, 0x64, 0x69, 0x74, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73,
-	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x4d, 0x61, 0x6e, 0x61,
-	0x67, 0x65, 0x64, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x42, 0x14, 0x0a, 0x12,
-	0x5f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
-	0x67, 0x73, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f, 0x77, 0x73,
-	0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76,
-	0x65, 0x6c, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f, 0x77, 0x73,
-	0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x27, 0x0a, 0x25, 0x5f, 0x53,
-	0x61, 0x66, 0x65, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x45, 0x78, 0x74, 0x65, 0x6e,
-	0x64, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x64, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42,
-	0x1d, 0x0a, 0x1b, 0x5f, 0x41, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x50, 0x61, 0x67, 0x65, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x19,
-	0x0a, 0x17, 0x5f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x68, 0x69, 0x72, 0x64, 0x50, 0x61, 0x72,
-	0x74, 0x79, 0x43, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x73, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x43, 0x6c,
-	0x65, 0x61, 0x72, 0x53, 0x69, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x4f, 0x6e, 0x45, 0x78, 0x69,
-	0x74, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x53, 0x61, 0x76, 0x69, 0x6e, 0x67, 0x42, 0x72, 0x6f, 0x77,
-	0x73, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c,
-	0x65, 0x64, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x69, 0x6e, 0x67, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f,
-	0x72, 0x79, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x33, 0x44,
-	0x41, 0x50, 0x49, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
-	0x53, 0x70, 0x64, 0x79, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x4a, 0x61, 0x76, 0x61, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x41,
-	0x6c, 0x6c, 0x6f, 0x77, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x73, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x44, 0x6e, 0x73,
-	0x50, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c,
-	0x65, 0x64, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x44, 0x6e, 0x73, 0x4f, 0x76, 0x65, 0x72, 0x48, 0x74,
-	0x74, 0x70, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x44, 0x6e, 0x73, 0x4f,
-	0x76, 0x65, 0x72, 0x48, 0x74, 0x74, 0x70, 0x73, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
-	0x73, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x65,
-	0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x10,
-	0x0a, 0x0e, 0x5f, 0x53, 0x53, 0x4c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6e,
-	0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x51, 0x75, 0x69, 0x63, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64,
-	0x42, 0x19, 0x0a, 0x17, 0x5f, 0x4d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x42, 0x21, 0x0a, 0x1f, 0x5f,
-	0x48, 0x74, 0x74, 0x70, 0x30, 0x39, 0x4f, 0x6e, 0x4e, 0x6f, 0x6e, 0x44, 0x65, 0x66, 0x61, 0x75,
-	0x6c, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1c,
-	0x0a, 0x1a, 0x5f, 0x49, 0x6e, 0x63, 0x6f, 0x67, 0x6e, 0x69, 0x74, 0x6f, 0x4d, 0x6f, 0x64, 0x65,
-	0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x42, 0x13, 0x0a, 0x11,
-	0x5f, 0x49, 0x6e, 0x63, 0x6f, 0x67, 0x6e, 0x69, 0x74, 0x6f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x44, 0x69, 0x6e, 0x6f, 0x73,
-	0x61, 0x75, 0x72, 0x45, 0x61, 0x73, 0x74, 0x65, 0x72, 0x45, 0x67, 0x67, 0x42, 0x17, 0x0a, 0x15,
-	0x5f, 0x48, 0x53, 0x54, 0x53, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x42, 0x79, 0x70, 0x61, 0x73,
-	0x73, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x2f, 0x0a, 0x2d, 0x5f, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72,
-	0x65, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x46, 0x6f, 0x72, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x41,
-	0x6e, 0x63, 0x68, 0x6f, 0x72, 0x73, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x50, 0x6f, 0x6c, 0x69, 0x63,
-	0x79, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x50, 0x72, 0x6f, 0x78,
-	0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x50, 0x72,
-	0x6f, 0x78, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x50, 0x72, 0x6f, 0x78,
-	0x79, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
-	0x50, 0x72, 0x6f, 0x78, 0x79, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
-	0x50, 0x72, 0x6f, 0x78, 0x79, 0x50, 0x61, 0x63, 0x55, 0x72, 0x6c, 0x42, 0x12, 0x0a, 0x10, 0x5f,
-	0x50, 0x72, 0x6f, 0x78, 0x79, 0x42, 0x79, 0x70, 0x61, 0x73, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x42,
-	0x0e, 0x0a, 0x0c, 0x5f, 0x41, 0x75, 0x74, 0x68, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x73, 0x42,
-	0x22, 0x0a, 0x20, 0x5f, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x75, 0x74, 0x68, 0x4e,
-	0x65, 0x67, 0x6f, 0x74, 0x69, 0x61, 0x74, 0x65, 0x43, 0x6e, 0x61, 0x6d, 0x65, 0x4c, 0x6f, 0x6f,
-	0x6b, 0x75, 0x70, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x75,
-	0x74, 0x68, 0x4e, 0x65, 0x67, 0x6f, 0x74, 0x69, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x42,
-	0x16, 0x0a, 0x14, 0x5f, 0x41, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x6c,
-	0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x21, 0x0a, 0x1f, 0x5f, 0x41, 0x75, 0x74, 0x68,
-	0x4e, 0x65, 0x67, 0x6f, 0x74, 0x69, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
-	0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x41,
-	0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x57, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69,
-	0x73, 0x74, 0x42, 0x21, 0x0a, 0x1f, 0x5f, 0x41, 0x75, 0x74, 0x68, 0x4e, 0x65, 0x67, 0x6f, 0x74,
-	0x69, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x57, 0x68, 0x69, 0x74,
-	0x65, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x47, 0x53, 0x53, 0x41, 0x50, 0x49,
-	0x4c, 0x69, 0x62, 0x72, 0x61, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x1d, 0x0a, 0x1b, 0x5f,
-	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x43, 0x72, 0x6f, 0x73, 0x73, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e,
-	0x41, 0x75, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x57,
-	0x50, 0x41, 0x44, 0x51, 0x75, 0x69, 0x63, 0x6b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x45, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x48, 0x74, 0x74, 0x70, 0x73, 0x4f, 0x6e,
-	0x6c, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x55, 0x70, 0x67, 0x72, 0x61,
-	0x64, 0x65, 0x48, 0x74, 0x74, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x42, 0x18,
-	0x0a, 0x16, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6f, 0x6b, 0x69, 0x65,
-	0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x44, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
-	0x67, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4a, 0x61, 0x76,
-	0x61, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x17,
-	0x0a, 0x15, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x6f, 0x70, 0x75, 0x70, 0x73,
-	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x44, 0x65, 0x66, 0x61,
-	0x75, 0x6c, 0x74, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x44, 0x65, 0x66, 0x61,
-	0x75, 0x6c, 0x74, 0x47, 0x65, 0x6f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
-	0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
-	0x74, 0x57, 0x65, 0x62, 0x55, 0x73, 0x62, 0x47, 0x75, 0x61, 0x72, 0x64, 0x53, 0x65, 0x74, 0x74,
-	0x69, 0x6e, 0x67, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x46,
-	0x69, 0x6c, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x61, 0x64, 0x47, 0x75, 0x61,
-	0x72, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x25, 0x0a, 0x23, 0x5f, 0x44, 0x65,
-	0x66, 0x61, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x57,
-	0x72, 0x69, 0x74, 0x65, 0x47, 0x75, 0x61, 0x72, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x42, 0x18, 0x0a, 0x16, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x6e, 0x73,
-	0x6f, 0x72, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x44,
-	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x47, 0x75, 0x61, 0x72,
-	0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x26, 0x0a, 0x24, 0x5f, 0x4c, 0x65, 0x67,
-	0x61, 0x63, 0x79, 0x53, 0x61, 0x6d, 0x65, 0x53, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6f, 0x6b, 0x69,
-	0x65, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
-	0x42, 0x22, 0x0a, 0x20, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x57, 0x65, 0x62, 0x42,
-	0x6c, 0x75, 0x65, 0x74, 0x6f, 0x6f, 0x74, 0x68, 0x47, 0x75, 0x61, 0x72, 0x64, 0x53, 0x65, 0x74,
-	0x74, 0x69, 0x6e, 0x67, 0x42, 0x27, 0x0a, 0x25, 0x5f, 0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f,
-	0x77, 0x73, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x54, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x53,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x25, 0x0a,
-	0x23, 0x5f, 0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x52, 0x65,
-	0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f,
-	0x77, 0x73, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x65, 0x70, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x69, 0x6e,
-	0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x50, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x64, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
-	0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x68, 0x6f, 0x77,
-	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x41, 0x75,
-	0x74, 0x6f, 0x66, 0x69, 0x6c, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x41, 0x75, 0x74, 0x6f, 0x66, 0x69, 0x6c,
-	0x6c, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x43, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x61, 0x62, 0x6c,
-	0x65, 0x64, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x41, 0x75, 0x74, 0x6f, 0x46, 0x69, 0x6c, 0x6c, 0x45,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x49, 0x6d, 0x70, 0x6f, 0x72,
-	0x74, 0x41, 0x75, 0x74, 0x6f, 0x66, 0x69, 0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x44, 0x61, 0x74,
-	0x61, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x44, 0x69,
-	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x50, 0x72, 0x6f, 0x6d,
-	0x70, 0x74, 0x46, 0x6f, 0x72, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x6f, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x44, 0x69, 0x73, 0x6b, 0x43, 0x61,
-	0x63, 0x68, 0x65, 0x44, 0x69, 0x72, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x44, 0x69, 0x73, 0x6b, 0x43,
-	0x61, 0x63, 0x68, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x4d, 0x65, 0x64,
-	0x69, 0x61, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f,
-	0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42,
-	0x1c, 0x0a, 0x1a, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f,
-	0x77, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x1d, 0x0a,
-	0x1b, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
-	0x64, 0x44, 0x75, 0x70, 0x6c, 0x65, 0x78, 0x4d, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x29, 0x0a, 0x27,
-	0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64,
+	0x65, 0x64, 0x46, 0x6f, 0x72, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x37, 0x0a, 0x16, 0x42, 0x6f, 0x72,
+	0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x69, 0x65, 0x73, 0x18, 0xe8, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x42, 0x6f, 0x72, 0x52,
+	0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69,
+	0x65, 0x73, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x48, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x48, 0x6f, 0x6d, 0x65,
+	0x70, 0x61, 0x67, 0x65, 0x49, 0x73, 0x4e, 0x65, 0x77, 0x54, 0x61, 0x62, 0x50, 0x61, 0x67, 0x65,
+	0x42, 0x15, 0x0a, 0x13, 0x5f, 0x4e, 0x65, 0x77, 0x54, 0x61, 0x62, 0x50, 0x61, 0x67, 0x65, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x4f, 0x6e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x42, 0x11, 0x0a, 0x0f,
+	0x5f, 0x53, 0x68, 0x6f, 0x77, 0x48, 0x6f, 0x6d, 0x65, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x42,
+	0x1f, 0x0a, 0x1d, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x72, 0x6f, 0x77, 0x73,
+	0x65, 0x72, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x42, 0x15, 0x0a, 0x13, 0x5f, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x42, 0x61, 0x72,
+	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x45, 0x64, 0x69, 0x74,
+	0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x42, 0x13, 0x0a, 0x11, 0x5f, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x42, 0x6f, 0x6f, 0x6b,
+	0x6d, 0x61, 0x72, 0x6b, 0x73, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73,
+	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x42, 0x1e, 0x0a, 0x1c, 0x5f,
+	0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x74,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x42, 0x16, 0x0a, 0x14, 0x5f,
+	0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x42, 0x27, 0x0a, 0x25, 0x5f, 0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f, 0x77,
+	0x73, 0x69, 0x6e, 0x67, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1a, 0x0a, 0x18,
+	0x5f, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1d, 0x0a, 0x1b, 0x5f, 0x41, 0x6c, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x61, 0x67, 0x65, 0x73,
+	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x54, 0x68, 0x69, 0x72, 0x64, 0x50, 0x61, 0x72, 0x74, 0x79, 0x43, 0x6f, 0x6f, 0x6b, 0x69,
+	0x65, 0x73, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x53, 0x69, 0x74, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x4f, 0x6e, 0x45, 0x78, 0x69, 0x74, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x53,
+	0x61, 0x76, 0x69, 0x6e, 0x67, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1e, 0x0a, 0x1c, 0x5f,
+	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x72, 0x6f,
+	0x77, 0x73, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x42, 0x10, 0x0a, 0x0e, 0x5f,
+	0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x33, 0x44, 0x41, 0x50, 0x49, 0x73, 0x42, 0x0e, 0x0a,
+	0x0c, 0x5f, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x70, 0x64, 0x79, 0x42, 0x14, 0x0a,
+	0x12, 0x5f, 0x4a, 0x61, 0x76, 0x61, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x45, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x46, 0x69, 0x6c,
+	0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67,
+	0x73, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x44, 0x6e, 0x73, 0x50, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63,
+	0x68, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x13, 0x0a, 0x11, 0x5f,
+	0x44, 0x6e, 0x73, 0x4f, 0x76, 0x65, 0x72, 0x48, 0x74, 0x74, 0x70, 0x73, 0x4d, 0x6f, 0x64, 0x65,
+	0x42, 0x18, 0x0a, 0x16, 0x5f, 0x44, 0x6e, 0x73, 0x4f, 0x76, 0x65, 0x72, 0x48, 0x74, 0x74, 0x70,
+	0x73, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x53, 0x53, 0x4c, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x69, 0x6e, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x51, 0x75,
+	0x69, 0x63, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x4d, 0x61,
+	0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x50,
+	0x72, 0x6f, 0x78, 0x79, 0x42, 0x21, 0x0a, 0x1f, 0x5f, 0x48, 0x74, 0x74, 0x70, 0x30, 0x39, 0x4f,
+	0x6e, 0x4e, 0x6f, 0x6e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x73,
+	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x49, 0x6e, 0x63, 0x6f,
+	0x67, 0x6e, 0x69, 0x74, 0x6f, 0x4d, 0x6f, 0x64, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x49, 0x6e, 0x63, 0x6f, 0x67, 0x6e,
+	0x69, 0x74, 0x6f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x45, 0x61, 0x73, 0x74,
+	0x65, 0x72, 0x45, 0x67, 0x67, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x48, 0x53, 0x54, 0x53, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x42, 0x79, 0x70, 0x61, 0x73, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x2f,
+	0x0a, 0x2d, 0x5f, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65,
+	0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x46, 0x6f, 0x72, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x73, 0x42,
+	0x1f, 0x0a, 0x1d, 0x5f, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x4d, 0x6f, 0x64, 0x65,
+	0x42, 0x12, 0x0a, 0x10, 0x5f, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x4d, 0x6f, 0x64, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x50, 0x61,
+	0x63, 0x55, 0x72, 0x6c, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x42, 0x79,
+	0x70, 0x61, 0x73, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x41, 0x75, 0x74,
+	0x68, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x73, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x44, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x41, 0x75, 0x74, 0x68, 0x4e, 0x65, 0x67, 0x6f, 0x74, 0x69, 0x61, 0x74,
+	0x65, 0x43, 0x6e, 0x61, 0x6d, 0x65, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x42, 0x1a, 0x0a, 0x18,
+	0x5f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x75, 0x74, 0x68, 0x4e, 0x65, 0x67, 0x6f, 0x74,
+	0x69, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x41, 0x75, 0x74,
+	0x68, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74,
+	0x42, 0x21, 0x0a, 0x1f, 0x5f, 0x41, 0x75, 0x74, 0x68, 0x4e, 0x65, 0x67, 0x6f, 0x74, 0x69, 0x61,
+	0x74, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c,
+	0x69, 0x73, 0x74, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x41, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x57, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x21, 0x0a, 0x1f, 0x5f,
+	0x41, 0x75, 0x74, 0x68, 0x4e, 0x65, 0x67, 0x6f, 0x74, 0x69, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6c,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x57, 0x68, 0x69, 0x74, 0x65, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x14,
+	0x0a, 0x12, 0x5f, 0x47, 0x53, 0x53, 0x41, 0x50, 0x49, 0x4c, 0x69, 0x62, 0x72, 0x61, 0x72, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x42, 0x1d, 0x0a, 0x1b, 0x5f, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x43, 0x72,
+	0x6f, 0x73, 0x73, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x41, 0x75, 0x74, 0x68, 0x50, 0x72, 0x6f,
+	0x6d, 0x70, 0x74, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x57, 0x50, 0x41, 0x44, 0x51, 0x75, 0x69, 0x63,
+	0x6b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x10, 0x0a,
+	0x0e, 0x5f, 0x48, 0x74, 0x74, 0x70, 0x73, 0x4f, 0x6e, 0x6c, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x42,
+	0x16, 0x0a, 0x14, 0x5f, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x48, 0x74, 0x74, 0x70, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x44,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4a, 0x61, 0x76, 0x61, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x50, 0x6f, 0x70, 0x75, 0x70, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4e, 0x6f, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x47, 0x65, 0x6f, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x1c,
+	0x0a, 0x1a, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x57, 0x65, 0x62, 0x55, 0x73, 0x62,
+	0x47, 0x75, 0x61, 0x72, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x24, 0x0a, 0x22,
+	0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x52, 0x65, 0x61, 0x64, 0x47, 0x75, 0x61, 0x72, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x42, 0x25, 0x0a, 0x23, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x46, 0x69,
+	0x6c, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x57, 0x72, 0x69, 0x74, 0x65, 0x47, 0x75, 0x61,
+	0x72, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x44, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x6e, 0x73, 0x6f, 0x72, 0x73, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x47, 0x75, 0x61, 0x72, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x42, 0x26, 0x0a, 0x24, 0x5f, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x53, 0x61, 0x6d, 0x65,
+	0x53, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69,
+	0x6f, 0x72, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x44, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x57, 0x65, 0x62, 0x42, 0x6c, 0x75, 0x65, 0x74, 0x6f, 0x6f, 0x74,
+	0x68, 0x47, 0x75, 0x61, 0x72, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x27, 0x0a,
+	0x25, 0x5f, 0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x46, 0x6f,
+	0x72, 0x54, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x45,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x25, 0x0a, 0x23, 0x5f, 0x53, 0x61, 0x66, 0x65, 0x42,
+	0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x4c,
+	0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x22, 0x0a,
+	0x20, 0x5f, 0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x44, 0x65,
+	0x65, 0x70, 0x53, 0x63, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x24, 0x0a, 0x22,
+	0x5f, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x68, 0x6f, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x73, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x41, 0x75, 0x74, 0x6f, 0x66, 0x69, 0x6c, 0x6c, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1c, 0x0a,
+	0x1a, 0x5f, 0x41, 0x75, 0x74, 0x6f, 0x66, 0x69, 0x6c, 0x6c, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74,
+	0x43, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x12, 0x0a, 0x10, 0x5f,
+	0x41, 0x75, 0x74, 0x6f, 0x46, 0x69, 0x6c, 0x6c, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42,
+	0x19, 0x0a, 0x17, 0x5f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x75, 0x74, 0x6f, 0x66, 0x69,
+	0x6c, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x44,
+	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
+	0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x46, 0x6f, 0x72, 0x44, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0f,
+	0x0a, 0x0d, 0x5f, 0x44, 0x69, 0x73, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x44, 0x69, 0x72, 0x42,
+	0x10, 0x0a, 0x0e, 0x5f, 0x44, 0x69, 0x73, 0x6b, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e,
+	0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x50, 0x72, 0x69,
+	0x6e, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x4d, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x1d, 0x0a, 0x1b, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74,
+	0x69, 0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x44, 0x75, 0x70, 0x6c, 0x65, 0x78,
+	0x4d, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x29, 0x0a, 0x27, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69,
+	0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x47, 0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x73,
+	0x42, 0x17, 0x0a, 0x15, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6c,
+	0x6f, 0x72, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x50, 0x72,
+	0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x44, 0x75, 0x70, 0x6c, 0x65, 0x78, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67,
 	0x42, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x47, 0x72, 0x61, 0x70, 0x68, 0x69,
-	0x63, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x50, 0x72, 0x69, 0x6e,
-	0x74, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
-	0x42, 0x18, 0x0a, 0x16, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x44, 0x75, 0x70,
-	0x6c, 0x65, 0x78, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x50,
-	0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e,
-	0x64, 0x47, 0x72, 0x61, 0x70, 0x68, 0x69, 0x63, 0x73, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
-	0x42, 0x16, 0x0a, 0x14, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a,
-	0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x50, 0x72, 0x69,
-	0x6e, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x78, 0x53, 0x68, 0x65, 0x65, 0x74, 0x73, 0x41, 0x6c,
-	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69,
-	0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
-	0x65, 0x73, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x72,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x19,
-	0x0a, 0x17, 0x5f, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x50, 0x72, 0x6f,
-	0x78, 0x79, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x44, 0x65,
-	0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x72, 0x54, 0x6f, 0x6f, 0x6c, 0x73, 0x44, 0x69, 0x73, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x42, 0x1d, 0x0a, 0x1b, 0x5f, 0x44, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70,
-	0x65, 0x72, 0x54, 0x6f, 0x6f, 0x6c, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c,
-	0x69, 0x74, 0x79, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74,
-	0x65, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x41, 0x6c, 0x6c,
-	0x6f, 0x77, 0x4f, 0x75, 0x74, 0x64, 0x61, 0x74, 0x65, 0x64, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x73, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x41, 0x6c, 0x77, 0x61, 0x79, 0x73, 0x41, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x69, 0x7a, 0x65, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x42, 0x11, 0x0a, 0x0f,
-	0x5f, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42,
-	0x16, 0x0a, 0x14, 0x5f, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x46, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x44, 0x69, 0x73, 0x61,
-	0x62, 0x6c, 0x65, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x42, 0x14,
-	0x0a, 0x12, 0x5f, 0x46, 0x75, 0x6c, 0x6c, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x41, 0x6c, 0x6c,
-	0x6f, 0x77, 0x65, 0x64, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x4b, 0x69, 0x6f, 0x73, 0x6b, 0x4d, 0x6f,
-	0x64, 0x65, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x53, 0x75,
-	0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x55, 0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65,
-	0x64, 0x4f, 0x53, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x54,
-	0x61, 0x73, 0x6b, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x6e, 0x64, 0x50, 0x72, 0x6f,
-	0x63, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x22, 0x0a, 0x20, 0x5f,
-	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x49, 0x6d, 0x61,
-	0x67, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42,
-	0x20, 0x0a, 0x1e, 0x5f, 0x53, 0x68, 0x6f, 0x77, 0x41, 0x70, 0x70, 0x73, 0x53, 0x68, 0x6f, 0x72,
-	0x74, 0x63, 0x75, 0x74, 0x49, 0x6e, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x42, 0x61,
-	0x72, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x53, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x4c, 0x6f,
-	0x63, 0x61, 0x6c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x44, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65,
-	0x64, 0x54, 0x61, 0x73, 0x6b, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x53, 0x79, 0x6e, 0x63, 0x44, 0x69,
-	0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x42, 0x72, 0x6f, 0x77, 0x73,
-	0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x46, 0x6f, 0x72,
-	0x63, 0x65, 0x47, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x53, 0x61, 0x66, 0x65, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x59, 0x6f, 0x75, 0x54,
-	0x75, 0x62, 0x65, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x42, 0x1f, 0x0a, 0x1d, 0x5f,
+	0x63, 0x73, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x50, 0x72,
+	0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x61,
+	0x78, 0x53, 0x68, 0x65, 0x65, 0x74, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x1b,
+	0x0a, 0x19, 0x5f, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x64, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x73, 0x42, 0x1a, 0x0a, 0x18, 0x5f,
+	0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x43, 0x6c, 0x6f, 0x75,
+	0x64, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x44, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x72,
+	0x54, 0x6f, 0x6f, 0x6c, 0x73, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1d, 0x0a,
+	0x1b, 0x5f, 0x44, 0x65, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x72, 0x54, 0x6f, 0x6f, 0x6c, 0x73,
+	0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x42, 0x13, 0x0a, 0x11,
+	0x5f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x4f, 0x75, 0x74, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x41,
+	0x6c, 0x77, 0x61, 0x79, 0x73, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x50, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x73, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x44, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x46, 0x69, 0x6e, 0x64, 0x65, 0x72,
+	0x42, 0x15, 0x0a, 0x13, 0x5f, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x63, 0x72, 0x65,
+	0x65, 0x6e, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x46, 0x75, 0x6c, 0x6c,
+	0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x13, 0x0a,
+	0x11, 0x5f, 0x4b, 0x69, 0x6f, 0x73, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x45, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x53, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x55,
+	0x6e, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x4f, 0x53, 0x57, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x54, 0x61, 0x73, 0x6b, 0x4d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x45, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x69,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x20, 0x0a, 0x1e, 0x5f, 0x53, 0x68, 0x6f,
+	0x77, 0x41, 0x70, 0x70, 0x73, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x63, 0x75, 0x74, 0x49, 0x6e, 0x42,
+	0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x42, 0x61, 0x72, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x53,
+	0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x44, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x42, 0x0f,
+	0x0a, 0x0d, 0x5f, 0x53, 0x79, 0x6e, 0x63, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42,
+	0x10, 0x0a, 0x0e, 0x5f, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x69,
+	0x6e, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x47, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x53, 0x61, 0x66, 0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x42, 0x17, 0x0a, 0x15, 0x5f,
+	0x46, 0x6f, 0x72, 0x63, 0x65, 0x59, 0x6f, 0x75, 0x54, 0x75, 0x62, 0x65, 0x52, 0x65, 0x73, 0x74,
+	0x72, 0x69, 0x63, 0x74, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x45, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4e,
+	0x61, 0x6d, 0x65, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4b, 0x65, 0x79,
+	0x77, 0x6f, 0x72, 0x64, 0x42, 0x21, 0x0a, 0x1f, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x55, 0x52, 0x4c, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x55, 0x52, 0x4c, 0x42, 0x1f, 0x0a, 0x1d, 0x5f,
 	0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f,
-	0x76, 0x69, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1c, 0x0a, 0x1a,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x63, 0x6f, 0x6e, 0x55, 0x52, 0x4c, 0x42, 0x21, 0x0a, 0x1f,
 	0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x44,
-	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x42, 0x21, 0x0a, 0x1f, 0x5f,
-	0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f,
-	0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x55, 0x52, 0x4c, 0x42, 0x22,
-	0x0a, 0x20, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x55,
-	0x52, 0x4c, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x63, 0x6f, 0x6e,
-	0x55, 0x52, 0x4c, 0x42, 0x21, 0x0a, 0x1f, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4e, 0x65, 0x77,
-	0x54, 0x61, 0x62, 0x55, 0x52, 0x4c, 0x42, 0x20, 0x0a, 0x1e, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75,
-	0x6c, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x52, 0x4c, 0x42, 0x2a, 0x0a, 0x28, 0x5f, 0x44, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
-	0x65, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x52, 0x4c, 0x50, 0x6f, 0x73, 0x74, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53,
-	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x21, 0x0a,
-	0x1f, 0x5f, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x42, 0x25, 0x0a, 0x23, 0x5f, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x61,
-	0x6e, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x79, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c,
-	0x65, 0x64, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x4b, 0x65, 0x72, 0x62, 0x65, 0x72, 0x6f, 0x73, 0x41,
-	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x4b, 0x65, 0x72, 0x62,
-	0x65, 0x72, 0x6f, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x22, 0x0a, 0x20, 0x5f,
-	0x4b, 0x65, 0x72, 0x62, 0x65, 0x72, 0x6f, 0x73, 0x52, 0x65, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42,
-	0x14, 0x0a, 0x12, 0x5f, 0x4b, 0x65, 0x72, 0x62, 0x65, 0x72, 0x6f, 0x73, 0x50, 0x72, 0x69, 0x6e,
-	0x63, 0x69, 0x70, 0x61, 0x6c, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x55, 0x72, 0x6c, 0x73, 0x42,
-	0x19, 0x0a, 0x17, 0x5f, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c,
-	0x6f, 0x63, 0x61, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x53,
-	0x70, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x50, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x52, 0x61, 0x74, 0x65, 0x42, 0x0e, 0x0a,
-	0x0c, 0x5f, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x42, 0x14, 0x0a,
-	0x12, 0x5f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x6f, 0x75,
-	0x74, 0x65, 0x72, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x53, 0x68, 0x6f, 0x77, 0x43, 0x61, 0x73, 0x74,
-	0x49, 0x63, 0x6f, 0x6e, 0x49, 0x6e, 0x54, 0x6f, 0x6f, 0x6c, 0x62, 0x61, 0x72, 0x42, 0x1a, 0x0a,
-	0x18, 0x5f, 0x53, 0x53, 0x4c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69,
-	0x64, 0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x26, 0x0a, 0x24, 0x5f, 0x52, 0x65,
-	0x6d, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x54, 0x72, 0x61, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x6c, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x48, 0x6f, 0x73, 0x74, 0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x54, 0x72,
-	0x61, 0x76, 0x65, 0x72, 0x73, 0x61, 0x6c, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x52, 0x65, 0x6d, 0x6f,
-	0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x6f, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x46, 0x69, 0x6c, 0x65,
-	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x42, 0x29, 0x0a, 0x27, 0x5f, 0x52, 0x65, 0x6d,
-	0x6f, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x6c, 0x6c,
-	0x6f, 0x77, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x63,
-	0x63, 0x65, 0x73, 0x73, 0x48, 0x6f, 0x73, 0x74, 0x55, 0x64, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x52,
-	0x61, 0x6e, 0x67, 0x65, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
-	0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
-	0x42, 0x18, 0x0a, 0x16, 0x5f, 0x42, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x4d,
-	0x6f, 0x64, 0x65, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x48,
-	0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x23,
-	0x0a, 0x21, 0x5f, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x61, 0x66, 0x65, 0x42, 0x72,
-	0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x65, 0x64, 0x41, 0x6e, 0x79,
-	0x77, 0x61, 0x79, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x41, 0x6c,
-	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x55, 0x73, 0x65, 0x72,
-	0x46, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42,
-	0x19, 0x0a, 0x17, 0x5f, 0x41, 0x75, 0x74, 0x6f, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x6c, 0x6c, 0x6f,
-	0x77, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x55, 0x52, 0x4c, 0x73, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x41,
-	0x75, 0x74, 0x6f, 0x4f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73,
-	0x42, 0x12, 0x0a, 0x10, 0x5f, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72,
-	0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x65, 0x72, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42,
-	0x1f, 0x0a, 0x1d, 0x5f, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
-	0x42, 0x28, 0x0a, 0x26, 0x5f, 0x49, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x50, 0x72, 0x69,
-	0x76, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x2a, 0x0a, 0x28, 0x5f, 0x50,
-	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1c, 0x0a, 0x1a, 0x5f, 0x45, 0x78, 0x74, 0x65, 0x6e,
-	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x42, 0x23, 0x0a, 0x21, 0x5f, 0x4c, 0x6f, 0x6f, 0x6b, 0x61, 0x6c, 0x69,
-	0x6b, 0x65, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69,
-	0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x53, 0x75,
-	0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x46, 0x69, 0x72, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x42, 0x75,
-	0x62, 0x62, 0x6c, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x42,
-	0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x49, 0x6d, 0x70,
-	0x6f, 0x72, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x49,
-	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x42, 0x17, 0x0a,
-	0x15, 0x5f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x61, 0x76, 0x65, 0x64, 0x50, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x49, 0x6d, 0x70, 0x6f, 0x72,
-	0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x42, 0x1f, 0x0a,
-	0x1d, 0x5f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65,
-	0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x42, 0x2d,
-	0x0a, 0x2b, 0x5f, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x54, 0x72,
-	0x61, 0x6e, 0x73, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x45, 0x6e, 0x66, 0x6f, 0x72, 0x63,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1e, 0x0a,
-	0x1c, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x43,
-	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x16, 0x0a,
-	0x14, 0x5f, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x41, 0x6c,
-	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x43,
-	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x1a, 0x0a,
-	0x18, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6c, 0x69, 0x70, 0x62, 0x6f, 0x61,
-	0x72, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x56, 0x75, 0x74, 0x65, 0x54, 0x65, 0x63, 0x68,
-	0x2f, 0x42, 0x6f, 0x72, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
-	0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x3b, 0x70, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4e, 0x65, 0x77, 0x54, 0x61, 0x62, 0x55, 0x52, 0x4c, 0x42,
+	0x20, 0x0a, 0x1e, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x52,
+	0x4c, 0x42, 0x2a, 0x0a, 0x28, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x55, 0x52, 0x4c, 0x50, 0x6f, 0x73, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x42, 0x17, 0x0a,
+	0x15, 0x5f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x45,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x21, 0x0a, 0x1f, 0x5f, 0x43, 0x6c, 0x6f, 0x75, 0x64,
+	0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c,
+	0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x25, 0x0a, 0x23, 0x5f, 0x43, 0x6c,
+	0x6f, 0x75, 0x64, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x72,
+	0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x61, 0x6e, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x79,
+	0x42, 0x19, 0x0a, 0x17, 0x5f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x13, 0x0a, 0x11, 0x5f,
+	0x4b, 0x65, 0x72, 0x62, 0x65, 0x72, 0x6f, 0x73, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x42, 0x12, 0x0a, 0x10, 0x5f, 0x4b, 0x65, 0x72, 0x62, 0x65, 0x72, 0x6f, 0x73, 0x45, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x4b, 0x65, 0x72, 0x62, 0x65, 0x72, 0x6f,
+	0x73, 0x52, 0x65, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x4b, 0x65, 0x72,
+	0x62, 0x65, 0x72, 0x6f, 0x73, 0x50, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x42, 0x18,
+	0x0a, 0x16, 0x5f, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x46, 0x6f, 0x72, 0x55, 0x72, 0x6c, 0x73, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x41, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x53, 0x70, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x42, 0x14, 0x0a, 0x12, 0x5f, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x66, 0x72, 0x65,
+	0x73, 0x68, 0x52, 0x61, 0x74, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x55, 0x73, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x45, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x42, 0x18, 0x0a, 0x16,
+	0x5f, 0x53, 0x68, 0x6f, 0x77, 0x43, 0x61, 0x73, 0x74, 0x49, 0x63, 0x6f, 0x6e, 0x49, 0x6e, 0x54,
+	0x6f, 0x6f, 0x6c, 0x62, 0x61, 0x72, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x53, 0x53, 0x4c, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x64, 0x42, 0x26, 0x0a, 0x24, 0x5f, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x72, 0x65, 0x77, 0x61, 0x6c,
+	0x6c, 0x54, 0x72, 0x61, 0x76, 0x65, 0x72, 0x73, 0x61, 0x6c, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x6f, 0x73, 0x74, 0x46,
+	0x69, 0x72, 0x65, 0x77, 0x61, 0x6c, 0x6c, 0x54, 0x72, 0x61, 0x76, 0x65, 0x72, 0x73, 0x61, 0x6c,
+	0x42, 0x19, 0x0a, 0x17, 0x5f, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x48, 0x6f, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x42, 0x24, 0x0a, 0x22, 0x5f,
+	0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x6f, 0x73, 0x74,
+	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x42, 0x29, 0x0a, 0x27, 0x5f, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x48, 0x6f, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x6c, 0x61, 0x79,
+	0x65, 0x64, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x1f, 0x0a, 0x1d,
+	0x5f, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x6f, 0x73,
+	0x74, 0x55, 0x64, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x1a, 0x0a,
+	0x18, 0x5f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x42, 0x61,
+	0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x4d, 0x6f, 0x64, 0x65, 0x45, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x42, 0x22, 0x0a, 0x20, 0x5f, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65,
+	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x23, 0x0a, 0x21, 0x5f, 0x44, 0x69, 0x73, 0x61,
+	0x62, 0x6c, 0x65, 0x53, 0x61, 0x66, 0x65, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x65, 0x64, 0x41, 0x6e, 0x79, 0x77, 0x61, 0x79, 0x42, 0x10, 0x0a, 0x0e,
+	0x5f, 0x53, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x10,
+	0x0a, 0x0e, 0x5f, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x42, 0x16, 0x0a, 0x14, 0x5f, 0x55, 0x73, 0x65, 0x72, 0x46, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63,
+	0x6b, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x41, 0x75, 0x74,
+	0x6f, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x55,
+	0x52, 0x4c, 0x73, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x41, 0x75, 0x74, 0x6f, 0x4f, 0x70, 0x65, 0x6e,
+	0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x45, 0x6e,
+	0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x19, 0x0a,
+	0x17, 0x5f, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x65,
+	0x72, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x61, 0x6e, 0x64, 0x62,
+	0x6f, 0x78, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x28, 0x0a, 0x26, 0x5f, 0x49, 0x6e,
+	0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x41, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x64, 0x42, 0x2a, 0x0a, 0x28, 0x5f, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x74,
+	0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42,
+	0x1c, 0x0a, 0x1a, 0x5f, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x23, 0x0a,
+	0x21, 0x5f, 0x4c, 0x6f, 0x6f, 0x6b, 0x61, 0x6c, 0x69, 0x6b, 0x65, 0x57, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x73, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x53, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x46,
+	0x69, 0x72, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x42, 0x75, 0x62, 0x62, 0x6c, 0x65, 0x42, 0x12, 0x0a,
+	0x10, 0x5f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x6f, 0x6f, 0x6b, 0x6d, 0x61, 0x72, 0x6b,
+	0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x6f,
+	0x6d, 0x65, 0x70, 0x61, 0x67, 0x65, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x61, 0x76, 0x65, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x42,
+	0x15, 0x0a, 0x13, 0x5f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x45, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x42, 0x2d, 0x0a, 0x2b, 0x5f, 0x43, 0x65, 0x72, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x45, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x69,
+	0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x41, 0x75, 0x64, 0x69, 0x6f,
+	0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x16,
+	0x0a, 0x14, 0x5f, 0x56, 0x69, 0x64, 0x65, 0x6f, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x44, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x43, 0x6c, 0x69, 0x70, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x56, 0x75, 0x74, 0x65, 0x54, 0x65, 0x63, 0x68, 0x2f, 0x42, 0x6f, 0x72, 0x2f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x3b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
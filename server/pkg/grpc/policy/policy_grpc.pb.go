@@ -19,16 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PolicyService_GetPolicy_FullMethodName              = "/bor.policy.v1.PolicyService/GetPolicy"
-	PolicyService_ListPolicies_FullMethodName           = "/bor.policy.v1.PolicyService/ListPolicies"
-	PolicyService_SubscribePolicyUpdates_FullMethodName = "/bor.policy.v1.PolicyService/SubscribePolicyUpdates"
-	PolicyService_ReportCompliance_FullMethodName       = "/bor.policy.v1.PolicyService/ReportCompliance"
-	PolicyService_GetAgentConfig_FullMethodName         = "/bor.policy.v1.PolicyService/GetAgentConfig"
-	PolicyService_Heartbeat_FullMethodName              = "/bor.policy.v1.PolicyService/Heartbeat"
-	PolicyService_ReportTamperEvent_FullMethodName      = "/bor.policy.v1.PolicyService/ReportTamperEvent"
-	PolicyService_RenewCertificate_FullMethodName       = "/bor.policy.v1.PolicyService/RenewCertificate"
-	PolicyService_ReportSchemaCatalogue_FullMethodName  = "/bor.policy.v1.PolicyService/ReportSchemaCatalogue"
-	PolicyService_ReportPolkitCatalogue_FullMethodName  = "/bor.policy.v1.PolicyService/ReportPolkitCatalogue"
+	PolicyService_GetPolicy_FullMethodName               = "/bor.policy.v1.PolicyService/GetPolicy"
+	PolicyService_ListPolicies_FullMethodName            = "/bor.policy.v1.PolicyService/ListPolicies"
+	PolicyService_SubscribePolicyUpdates_FullMethodName  = "/bor.policy.v1.PolicyService/SubscribePolicyUpdates"
+	PolicyService_ReportCompliance_FullMethodName        = "/bor.policy.v1.PolicyService/ReportCompliance"
+	PolicyService_ReportComplianceBatch_FullMethodName   = "/bor.policy.v1.PolicyService/ReportComplianceBatch"
+	PolicyService_GetAgentConfig_FullMethodName          = "/bor.policy.v1.PolicyService/GetAgentConfig"
+	PolicyService_Heartbeat_FullMethodName               = "/bor.policy.v1.PolicyService/Heartbeat"
+	PolicyService_ReportTamperEvent_FullMethodName       = "/bor.policy.v1.PolicyService/ReportTamperEvent"
+	PolicyService_RenewCertificate_FullMethodName        = "/bor.policy.v1.PolicyService/RenewCertificate"
+	PolicyService_ReportSchemaCatalogue_FullMethodName   = "/bor.policy.v1.PolicyService/ReportSchemaCatalogue"
+	PolicyService_ReportPolkitCatalogue_FullMethodName   = "/bor.policy.v1.PolicyService/ReportPolkitCatalogue"
+	PolicyService_ReportCheckResult_FullMethodName       = "/bor.policy.v1.PolicyService/ReportCheckResult"
+	PolicyService_UploadLogs_FullMethodName              = "/bor.policy.v1.PolicyService/UploadLogs"
+	PolicyService_ReportRemediationResult_FullMethodName = "/bor.policy.v1.PolicyService/ReportRemediationResult"
 )
 
 // PolicyServiceClient is the client API for PolicyService service.
@@ -45,6 +49,8 @@ type PolicyServiceClient interface {
 	SubscribePolicyUpdates(ctx context.Context, in *SubscribePolicyUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PolicyUpdate], error)
 	// Report policy compliance status
 	ReportCompliance(ctx context.Context, in *ReportComplianceRequest, opts ...grpc.CallOption) (*ReportComplianceResponse, error)
+	// Report the compliance status of several policies in one call
+	ReportComplianceBatch(ctx context.Context, in *ReportComplianceBatchRequest, opts ...grpc.CallOption) (*ReportComplianceBatchResponse, error)
 	// Get agent configuration (notification settings, etc.)
 	GetAgentConfig(ctx context.Context, in *GetAgentConfigRequest, opts ...grpc.CallOption) (*GetAgentConfigResponse, error)
 	// Send a heartbeat with node metadata
@@ -59,6 +65,15 @@ type PolicyServiceClient interface {
 	// ReportPolkitCatalogue is called by agents at startup to publish
 	// the polkit actions installed on their node.
 	ReportPolkitCatalogue(ctx context.Context, in *ReportPolkitCatalogueRequest, opts ...grpc.CallOption) (*ReportPolkitCatalogueResponse, error)
+	// ReportCheckResult reports the outcome of a policy's compliance check
+	// command. Stored separately from the deployment compliance status.
+	ReportCheckResult(ctx context.Context, in *ReportCheckResultRequest, opts ...grpc.CallOption) (*ReportCheckResultResponse, error)
+	// UploadLogs delivers the agent's recent log buffer in answer to a
+	// LOG_REQUEST event.
+	UploadLogs(ctx context.Context, in *UploadLogsRequest, opts ...grpc.CallOption) (*UploadLogsResponse, error)
+	// ReportRemediationResult reports the outcome of a remediation action
+	// run in answer to a REMEDIATION_REQUEST event.
+	ReportRemediationResult(ctx context.Context, in *ReportRemediationResultRequest, opts ...grpc.CallOption) (*ReportRemediationResultResponse, error)
 }
 
 type policyServiceClient struct {
@@ -118,6 +133,16 @@ func (c *policyServiceClient) ReportCompliance(ctx context.Context, in *ReportCo
 	return out, nil
 }
 
+func (c *policyServiceClient) ReportComplianceBatch(ctx context.Context, in *ReportComplianceBatchRequest, opts ...grpc.CallOption) (*ReportComplianceBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportComplianceBatchResponse)
+	err := c.cc.Invoke(ctx, PolicyService_ReportComplianceBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *policyServiceClient) GetAgentConfig(ctx context.Context, in *GetAgentConfigRequest, opts ...grpc.CallOption) (*GetAgentConfigResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetAgentConfigResponse)
@@ -178,6 +203,36 @@ func (c *policyServiceClient) ReportPolkitCatalogue(ctx context.Context, in *Rep
 	return out, nil
 }
 
+func (c *policyServiceClient) ReportCheckResult(ctx context.Context, in *ReportCheckResultRequest, opts ...grpc.CallOption) (*ReportCheckResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportCheckResultResponse)
+	err := c.cc.Invoke(ctx, PolicyService_ReportCheckResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *policyServiceClient) UploadLogs(ctx context.Context, in *UploadLogsRequest, opts ...grpc.CallOption) (*UploadLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadLogsResponse)
+	err := c.cc.Invoke(ctx, PolicyService_UploadLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *policyServiceClient) ReportRemediationResult(ctx context.Context, in *ReportRemediationResultRequest, opts ...grpc.CallOption) (*ReportRemediationResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportRemediationResultResponse)
+	err := c.cc.Invoke(ctx, PolicyService_ReportRemediationResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PolicyServiceServer is the server API for PolicyService service.
 // All implementations must embed UnimplementedPolicyServiceServer
 // for forward compatibility.
@@ -192,6 +247,8 @@ type PolicyServiceServer interface {
 	SubscribePolicyUpdates(*SubscribePolicyUpdatesRequest, grpc.ServerStreamingServer[PolicyUpdate]) error
 	// Report policy compliance status
 	ReportCompliance(context.Context, *ReportComplianceRequest) (*ReportComplianceResponse, error)
+	// Report the compliance status of several policies in one call
+	ReportComplianceBatch(context.Context, *ReportComplianceBatchRequest) (*ReportComplianceBatchResponse, error)
 	// Get agent configuration (notification settings, etc.)
 	GetAgentConfig(context.Context, *GetAgentConfigRequest) (*GetAgentConfigResponse, error)
 	// Send a heartbeat with node metadata
@@ -206,6 +263,15 @@ type PolicyServiceServer interface {
 	// ReportPolkitCatalogue is called by agents at startup to publish
 	// the polkit actions installed on their node.
 	ReportPolkitCatalogue(context.Context, *ReportPolkitCatalogueRequest) (*ReportPolkitCatalogueResponse, error)
+	// ReportCheckResult reports the outcome of a policy's compliance check
+	// command. Stored separately from the deployment compliance status.
+	ReportCheckResult(context.Context, *ReportCheckResultRequest) (*ReportCheckResultResponse, error)
+	// UploadLogs delivers the agent's recent log buffer in answer to a
+	// LOG_REQUEST event.
+	UploadLogs(context.Context, *UploadLogsRequest) (*UploadLogsResponse, error)
+	// ReportRemediationResult reports the outcome of a remediation action
+	// run in answer to a REMEDIATION_REQUEST event.
+	ReportRemediationResult(context.Context, *ReportRemediationResultRequest) (*ReportRemediationResultResponse, error)
 	mustEmbedUnimplementedPolicyServiceServer()
 }
 
@@ -228,6 +294,9 @@ func (UnimplementedPolicyServiceServer) SubscribePolicyUpdates(*SubscribePolicyU
 func (UnimplementedPolicyServiceServer) ReportCompliance(context.Context, *ReportComplianceRequest) (*ReportComplianceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReportCompliance not implemented")
 }
+func (UnimplementedPolicyServiceServer) ReportComplianceBatch(context.Context, *ReportComplianceBatchRequest) (*ReportComplianceBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportComplianceBatch not implemented")
+}
 func (UnimplementedPolicyServiceServer) GetAgentConfig(context.Context, *GetAgentConfigRequest) (*GetAgentConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAgentConfig not implemented")
 }
@@ -246,6 +315,15 @@ func (UnimplementedPolicyServiceServer) ReportSchemaCatalogue(context.Context, *
 func (UnimplementedPolicyServiceServer) ReportPolkitCatalogue(context.Context, *ReportPolkitCatalogueRequest) (*ReportPolkitCatalogueResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReportPolkitCatalogue not implemented")
 }
+func (UnimplementedPolicyServiceServer) ReportCheckResult(context.Context, *ReportCheckResultRequest) (*ReportCheckResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportCheckResult not implemented")
+}
+func (UnimplementedPolicyServiceServer) UploadLogs(context.Context, *UploadLogsRequest) (*UploadLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadLogs not implemented")
+}
+func (UnimplementedPolicyServiceServer) ReportRemediationResult(context.Context, *ReportRemediationResultRequest) (*ReportRemediationResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportRemediationResult not implemented")
+}
 func (UnimplementedPolicyServiceServer) mustEmbedUnimplementedPolicyServiceServer() {}
 func (UnimplementedPolicyServiceServer) testEmbeddedByValue()                       {}
 
@@ -332,6 +410,24 @@ func _PolicyService_ReportCompliance_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PolicyService_ReportComplianceBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportComplianceBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyServiceServer).ReportComplianceBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyService_ReportComplianceBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyServiceServer).ReportComplianceBatch(ctx, req.(*ReportComplianceBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PolicyService_GetAgentConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAgentConfigRequest)
 	if err := dec(in); err != nil {
@@ -440,6 +536,60 @@ func _PolicyService_ReportPolkitCatalogue_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PolicyService_ReportCheckResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportCheckResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyServiceServer).ReportCheckResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyService_ReportCheckResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyServiceServer).ReportCheckResult(ctx, req.(*ReportCheckResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolicyService_UploadLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyServiceServer).UploadLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyService_UploadLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyServiceServer).UploadLogs(ctx, req.(*UploadLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PolicyService_ReportRemediationResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRemediationResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicyServiceServer).ReportRemediationResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PolicyService_ReportRemediationResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicyServiceServer).ReportRemediationResult(ctx, req.(*ReportRemediationResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PolicyService_ServiceDesc is the grpc.ServiceDesc for PolicyService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -459,6 +609,10 @@ var PolicyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReportCompliance",
 			Handler:    _PolicyService_ReportCompliance_Handler,
 		},
+		{
+			MethodName: "ReportComplianceBatch",
+			Handler:    _PolicyService_ReportComplianceBatch_Handler,
+		},
 		{
 			MethodName: "GetAgentConfig",
 			Handler:    _PolicyService_GetAgentConfig_Handler,
@@ -483,6 +637,18 @@ var PolicyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReportPolkitCatalogue",
 			Handler:    _PolicyService_ReportPolkitCatalogue_Handler,
 		},
+		{
+			MethodName: "ReportCheckResult",
+			Handler:    _PolicyService_ReportCheckResult_Handler,
+		},
+		{
+			MethodName: "UploadLogs",
+			Handler:    _PolicyService_UploadLogs_Handler,
+		},
+		{
+			MethodName: "ReportRemediationResult",
+			Handler:    _PolicyService_ReportRemediationResult_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        v7.34.1
+// source: sysctl.proto
+
+package policy
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SysctlPolicy sets kernel tunables. The agent merges every Sysctl policy
+// assigned to the node into /etc/sysctl.d/99-bor.conf and runs
+// `sysctl --system` to apply it.
+type SysctlPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*SysctlEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SysctlPolicy) Reset() {
+	*x = SysctlPolicy{}
+	mi := &file_sysctl_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SysctlPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SysctlPolicy) ProtoMessage() {}
+
+func (x *SysctlPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_sysctl_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SysctlPolicy.ProtoReflect.Descriptor instead.
+func (*SysctlPolicy) Descriptor() ([]byte, []int) {
+	return file_sysctl_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SysctlPolicy) GetEntries() []*SysctlEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// SysctlEntry is a single kernel parameter, e.g. key "net.ipv4.ip_forward"
+// with value "0". Keys may use "." or "/" as the separator.
+type SysctlEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Binding priority of the policy the entry came from. Set by the agent
+	// before merging; when two policies set the same key the higher priority
+	// wins.
+	Priority      int32 `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SysctlEntry) Reset() {
+	*x = SysctlEntry{}
+	mi := &file_sysctl_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SysctlEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SysctlEntry) ProtoMessage() {}
+
+func (x *SysctlEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_sysctl_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SysctlEntry.ProtoReflect.Descriptor instead.
+func (*SysctlEntry) Descriptor() ([]byte, []int) {
+	return file_sysctl_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SysctlEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SysctlEntry) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SysctlEntry) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+var File_sysctl_proto protoreflect.FileDescriptor
+
+var file_sysctl_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x73, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d,
+	0x62, 0x6f, 0x72, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x76, 0x31, 0x22, 0x44, 0x0a,
+	0x0c, 0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x34, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x62, 0x6f, 0x72, 0x2e, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x79, 0x73, 0x63, 0x74, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x22, 0x51, 0x0a, 0x0b, 0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x56, 0x75, 0x74, 0x65, 0x54, 0x65, 0x63, 0x68, 0x2f, 0x42, 0x6f,
+	0x72, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x72, 0x70,
+	0x63, 0x2f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x3b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sysctl_proto_rawDescOnce sync.Once
+	file_sysctl_proto_rawDescData = file_sysctl_proto_rawDesc
+)
+
+func file_sysctl_proto_rawDescGZIP() []byte {
+	file_sysctl_proto_rawDescOnce.Do(func() {
+		file_sysctl_proto_rawDescData = protoimpl.X.CompressGZIP(file_sysctl_proto_rawDescData)
+	})
+	return file_sysctl_proto_rawDescData
+}
+
+var file_sysctl_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_sysctl_proto_goTypes = []any{
+	(*SysctlPolicy)(nil), // 0: bor.policy.v1.SysctlPolicy
+	(*SysctlEntry)(nil),  // 1: bor.policy.v1.SysctlEntry
+}
+var file_sysctl_proto_depIdxs = []int32{
+	1, // 0: bor.policy.v1.SysctlPolicy.entries:type_name -> bor.policy.v1.SysctlEntry
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_sysctl_proto_init() }
+func file_sysctl_proto_init() {
+	if File_sysctl_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_sysctl_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_sysctl_proto_goTypes,
+		DependencyIndexes: file_sysctl_proto_depIdxs,
+		MessageInfos:      file_sysctl_proto_msgTypes,
+	}.Build()
+	File_sysctl_proto = out.File
+	file_sysctl_proto_rawDesc = nil
+	file_sysctl_proto_goTypes = nil
+	file_sysctl_proto_depIdxs = nil
+}
@@ -0,0 +1,579 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package kconfig renders KDE Kiosk (KConfig) policies as INI files. It is
+// shared by the agent, which writes the files, and the server, which
+// previews them for policy authors.
+package kconfig
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	pb "github.com/VuteTech/Bor/server/pkg/grpc/policy"
+	"google.golang.org/protobuf/proto"
+)
+
+// group holds entries for a single INI [Group] within a file.
+type group struct {
+	name    string
+	entries []*pb.KConfigEntry
+}
+
+// enforcedSet builds a fast-lookup set from the KConfigPolicy.EnforcedFields list.
+func enforcedSet(pol *pb.KConfigPolicy) map[string]bool {
+	s := make(map[string]bool, len(pol.EnforcedFields))
+	for _, f := range pol.EnforcedFields {
+		s[f] = true
+	}
+	return s
+}
+
+// deletedSet builds a fast-lookup set from the KConfigPolicy.DeletedFields list.
+func deletedSet(pol *pb.KConfigPolicy) map[string]bool {
+	s := make(map[string]bool, len(pol.DeletedFields))
+	for _, f := range pol.DeletedFields {
+		s[f] = true
+	}
+	return s
+}
+
+// FieldScope returns the scope configured for a policy field: its
+// field_scopes override when set, otherwise the policy-wide scope.
+func FieldScope(pol *pb.KConfigPolicy, jsonKey string) pb.KConfigScope {
+	if sc := pol.FieldScopes[jsonKey]; sc != pb.KConfigScope_KCONFIG_SCOPE_UNSPECIFIED {
+		return sc
+	}
+	return pol.Scope
+}
+
+// boolVal converts an optional bool proto pointer to an INI "true"/"false" string.
+func boolVal(v *bool) string {
+	if v != nil && *v {
+		return "true"
+	}
+	return "false"
+}
+
+// PolicyToEntries converts a typed KConfigPolicy to the flat
+// []*KConfigEntry slice expected by Merge and SplitByScope.
+// Absent optional fields (nil pointers, empty repeated) are skipped.
+// Fields listed in DeletedFields become delete entries whatever their value.
+// Each entry carries the scope configured for its field.
+func PolicyToEntries(pol *pb.KConfigPolicy) []*pb.KConfigEntry {
+	if pol == nil {
+		return nil
+	}
+
+	enforced := enforcedSet(pol)
+	deleted := deletedSet(pol)
+
+	var entries []*pb.KConfigEntry
+	add := func(e *pb.KConfigEntry) {
+		if e != nil {
+			entries = append(entries, e)
+		}
+	}
+
+	deleteE := func(file, group, key, jsonKey string) *pb.KConfigEntry {
+		return &pb.KConfigEntry{File: file, Group: group, Key: key, Operation: pb.KConfigOperation_KCONFIG_OPERATION_DELETE, Enforced: enforced[jsonKey], Scope: FieldScope(pol, jsonKey)}
+	}
+
+	boolE := func(file, group, key, jsonKey string, val *bool) *pb.KConfigEntry {
+		if deleted[jsonKey] {
+			return deleteE(file, group, key, jsonKey)
+		}
+		if val == nil {
+			return nil
+		}
+		return &pb.KConfigEntry{File: file, Group: group, Key: key, Value: boolVal(val), Type: "bool", Enforced: enforced[jsonKey], Scope: FieldScope(pol, jsonKey)}
+	}
+
+	strE := func(file, group, key, jsonKey string, val *string) *pb.KConfigEntry {
+		if deleted[jsonKey] {
+			return deleteE(file, group, key, jsonKey)
+		}
+		if val == nil {
+			return nil
+		}
+		return &pb.KConfigEntry{File: file, Group: group, Key: key, Value: *val, Type: "string", Enforced: enforced[jsonKey], Scope: FieldScope(pol, jsonKey)}
+	}
+
+	intE := func(file, group, key, jsonKey string, val *int32) *pb.KConfigEntry {
+		if deleted[jsonKey] {
+			return deleteE(file, group, key, jsonKey)
+		}
+		if val == nil {
+			return nil
+		}
+		return &pb.KConfigEntry{File: file, Group: group, Key: key, Value: strconv.Itoa(int(*val)), Type: "int", Enforced: enforced[jsonKey], Scope: FieldScope(pol, jsonKey)}
+	}
+
+	// Action Restrictions (kdeglobals, [KDE Action Restrictions])
+	add(boolE("kdeglobals", "KDE Action Restrictions", "shell_access", "shellAccess", pol.ShellAccess))
+	add(boolE("kdeglobals", "KDE Action Restrictions", "run_command", "runCommand", pol.RunCommand))
+	add(boolE("kdeglobals", "KDE Action Restrictions", "action/logout", "actionLogout", pol.ActionLogout))
+	add(boolE("kdeglobals", "KDE Action Restrictions", "action/file_new", "actionFileNew", pol.ActionFileNew))
+	add(boolE("kdeglobals", "KDE Action Restrictions", "action/file_open", "actionFileOpen", pol.ActionFileOpen))
+	add(boolE("kdeglobals", "KDE Action Restrictions", "action/file_save", "actionFileSave", pol.ActionFileSave))
+
+	// Resource Restrictions (kdeglobals, [KDE Resource Restrictions])
+	add(boolE("kdeglobals", "KDE Resource Restrictions", "wallpaper", "restrictWallpaper", pol.RestrictWallpaper))
+	add(boolE("kdeglobals", "KDE Resource Restrictions", "icons", "restrictIcons", pol.RestrictIcons))
+	add(boolE("kdeglobals", "KDE Resource Restrictions", "autostart", "restrictAutostart", pol.RestrictAutostart))
+	add(boolE("kdeglobals", "KDE Resource Restrictions", "colors", "restrictColors", pol.RestrictColors))
+	add(boolE("kdeglobals", "KDE Resource Restrictions", "cursors", "restrictCursors", pol.RestrictCursors))
+
+	// Window Manager (kwinrc, [Windows])
+	add(boolE("kwinrc", "Windows", "BorderlessMaximizedWindows", "borderlessMaximizedWindows", pol.BorderlessMaximizedWindows))
+
+	// Desktop (plasmarc, [General])
+	add(boolE("plasmarc", "General", "plasmoidUnlockedDesktop", "plasmoidUnlockedDesktop", pol.PlasmoidUnlockedDesktop))
+	add(boolE("plasmarc", "General", "allow_configure_when_locked", "allowConfigureWhenLocked", pol.AllowConfigureWhenLocked))
+
+	// Screen Lock (kscreenlockerrc, [Daemon])
+	add(boolE("kscreenlockerrc", "Daemon", "AutoLock", "autoLock", pol.AutoLock))
+	add(boolE("kscreenlockerrc", "Daemon", "LockOnResume", "lockOnResume", pol.LockOnResume))
+	add(intE("kscreenlockerrc", "Daemon", "Timeout", "lockTimeout", pol.LockTimeout))
+
+	// Appearance
+	add(strE("kdeglobals", "Icons", "Theme", "iconTheme", pol.IconTheme))
+	add(strE("plasma-org.kde.plasma.desktop-appletsrc", "Containments][1", "wallpaperplugin", "wallpaperPlugin", pol.WallpaperPlugin))
+	add(strE("plasma-org.kde.plasma.desktop-appletsrc", "Containments][1][Wallpaper][org.kde.image][General", "Image", "wallpaperImage", pol.WallpaperImage))
+	add(strE("plasma-org.kde.plasma.desktop-appletsrc", "Containments][1][Wallpaper][org.kde.image][General", "FillMode", "wallpaperFillMode", pol.WallpaperFillMode))
+	add(strE("plasma-org.kde.plasma.desktop-appletsrc", "Containments][1][Wallpaper][org.kde.image][General", "Color", "wallpaperColor", pol.WallpaperColor))
+
+	// URL Restrictions (kdeglobals, [KDE URL Restrictions]) — always enforced
+	urlScope := FieldScope(pol, "urlRestrictions")
+	for i, r := range pol.UrlRestrictions {
+		val := fmt.Sprintf("%s,%s,%s,%s,%s,%s,%s,%v",
+			r.GetAction(), r.GetReferrerProtocol(), r.GetReferrerHost(), r.GetReferrerPath(),
+			r.GetProtocol(), r.GetHost(), r.GetPath(), r.GetEnabled())
+		entries = append(entries, &pb.KConfigEntry{
+			File:     "kdeglobals",
+			Group:    "KDE URL Restrictions",
+			Key:      fmt.Sprintf("rule_%d", i+1),
+			Value:    val,
+			Type:     "string",
+			Enforced: true,
+			Scope:    urlScope,
+		})
+	}
+	if len(pol.UrlRestrictions) > 0 {
+		entries = append(entries, &pb.KConfigEntry{
+			File:     "kdeglobals",
+			Group:    "KDE URL Restrictions",
+			Key:      "rule_count",
+			Value:    strconv.Itoa(len(pol.UrlRestrictions)),
+			Type:     "string",
+			Enforced: true,
+			Scope:    urlScope,
+		})
+	}
+
+	// KCM Restrictions (kde5rc, [KDE Control Module Restrictions]) — always enforced
+	kcmScope := FieldScope(pol, "kcmRestrictions")
+	for _, mod := range pol.KcmRestrictions {
+		entries = append(entries, &pb.KConfigEntry{
+			File:     "kde5rc",
+			Group:    "KDE Control Module Restrictions",
+			Key:      mod,
+			Value:    "false",
+			Type:     "bool",
+			Enforced: true,
+			Scope:    kcmScope,
+		})
+	}
+
+	return entries
+}
+
+// Conflict describes a key that several policies set to different values.
+// Values and PolicyIDs are parallel and ordered winner first: the entry
+// with the highest priority, and at equal priority the one that came last
+// in the merge input.
+type Conflict struct {
+	File      string
+	Group     string
+	Key       string
+	Values    []string
+	PolicyIDs []string
+}
+
+// Merge takes already-parsed proto entries (flattened from
+// all policies), groups them by target file and INI group, renders INI
+// content with [$i] enforcement suffixes, and returns a map of file→INI bytes.
+// See MergeWithConflicts for how duplicate keys are resolved.
+func Merge(entries []*pb.KConfigEntry) (map[string][]byte, error) {
+	files, _, err := MergeWithConflicts(entries)
+	return files, err
+}
+
+// MergeWithConflicts is Merge that also returns the keys set to different
+// values by more than one policy; a key one policy deletes and another sets
+// is a conflict too. Each file/group/key is written once with the value of
+// the highest-priority entry; at equal priority the entry that comes last in
+// entries wins, so callers pass policies in a stable order. The key is
+// enforced when any entry with the same effect as the winner is.
+// URL restriction rules are not keys in this sense: the rules of all
+// policies are kept and renumbered.
+func MergeWithConflicts(entries []*pb.KConfigEntry) (map[string][]byte, []Conflict, error) {
+	if len(entries) == 0 {
+		return nil, nil, nil
+	}
+	entries, conflicts := resolveDuplicates(entries)
+
+	// Group entries by file, then by group name within each file.
+	// Use ordered maps to produce deterministic output.
+	type fileData struct {
+		groups map[string]*group
+		order  []string // insertion order of group names
+	}
+	files := make(map[string]*fileData)
+	var fileOrder []string
+
+	for _, e := range entries {
+		fd, ok := files[e.File]
+		if !ok {
+			fd = &fileData{groups: make(map[string]*group)}
+			files[e.File] = fd
+			fileOrder = append(fileOrder, e.File)
+		}
+		g, ok := fd.groups[e.Group]
+		if !ok {
+			g = &group{name: e.Group}
+			fd.groups[e.Group] = g
+			fd.order = append(fd.order, e.Group)
+		}
+		g.entries = append(g.entries, e)
+	}
+
+	sort.Strings(fileOrder)
+
+	// Renumber URL restriction rules when multiple policies contribute
+	// rule_N entries to the same [KDE URL Restrictions] group.
+	for _, fd := range files {
+		for _, g := range fd.groups {
+			if g.name == "KDE URL Restrictions" {
+				renumberURLRestrictions(g)
+			}
+		}
+	}
+
+	result := make(map[string][]byte, len(files))
+	for _, fileName := range fileOrder {
+		fd := files[fileName]
+		var buf strings.Builder
+
+		sortedGroups := make([]string, len(fd.order))
+		copy(sortedGroups, fd.order)
+		sort.Strings(sortedGroups)
+
+		for i, groupName := range sortedGroups {
+			g := fd.groups[groupName]
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			renderINIGroup(&buf, g)
+		}
+
+		result[fileName] = []byte(buf.String())
+	}
+
+	return result, conflicts, nil
+}
+
+// resolveDuplicates keeps one entry per file/group/key as described in
+// MergeWithConflicts and reports the keys whose values disagree, in the
+// order the keys first appear. URL restriction rules pass through in input
+// order.
+func resolveDuplicates(entries []*pb.KConfigEntry) ([]*pb.KConfigEntry, []Conflict) {
+	type entryKey struct{ file, group, key string }
+	byKey := make(map[entryKey][]*pb.KConfigEntry, len(entries))
+	var order []entryKey
+	var out []*pb.KConfigEntry
+	for _, e := range entries {
+		if e.Group == "KDE URL Restrictions" && (e.Key == "rule_count" || parseRuleNum(e.Key) > 0) {
+			out = append(out, e)
+			continue
+		}
+		k := entryKey{e.File, e.Group, e.Key}
+		if _, ok := byKey[k]; !ok {
+			order = append(order, k)
+		}
+		byKey[k] = append(byKey[k], e)
+	}
+
+	var conflicts []Conflict
+	for _, k := range order {
+		dups := byKey[k]
+		if len(dups) == 1 {
+			out = append(out, dups[0])
+			continue
+		}
+		// Highest priority first; at equal priority later input first.
+		ranked := make([]*pb.KConfigEntry, len(dups))
+		for i, e := range dups {
+			ranked[len(dups)-1-i] = e
+		}
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].Priority > ranked[j].Priority
+		})
+		winner := ranked[0]
+
+		enforced := false
+		differ := false
+		for _, e := range ranked {
+			if sameEffect(e, winner) {
+				enforced = enforced || e.Enforced
+			} else {
+				differ = true
+			}
+		}
+		out = append(out, withEnforced(winner, enforced))
+
+		if differ {
+			c := Conflict{File: k.file, Group: k.group, Key: k.key}
+			for _, e := range ranked {
+				c.Values = append(c.Values, DisplayValue(e))
+				c.PolicyIDs = append(c.PolicyIDs, e.PolicyId)
+			}
+			conflicts = append(conflicts, c)
+		}
+	}
+	return out, conflicts
+}
+
+// sameEffect reports whether a and b write the same thing for their key.
+func sameEffect(a, b *pb.KConfigEntry) bool {
+	if a.Operation != b.Operation {
+		return false
+	}
+	return a.Operation == pb.KConfigOperation_KCONFIG_OPERATION_DELETE || a.Value == b.Value
+}
+
+// DisplayValue returns the entry's value for logs and compliance messages,
+// or "(deleted)" for a delete entry.
+func DisplayValue(e *pb.KConfigEntry) string {
+	if e.Operation == pb.KConfigOperation_KCONFIG_OPERATION_DELETE {
+		return "(deleted)"
+	}
+	return e.Value
+}
+
+// renderINIGroup writes a single INI group to the builder.
+// If all entries in the group are enforced, the group header uses [$i].
+// If only some entries are enforced, key-level [$i] suffixes are used.
+// Delete entries are written as key[$d] (key[$di] when enforced at key
+// level) and count towards the enforcement decision like any other key.
+func renderINIGroup(buf *strings.Builder, g *group) {
+	allEnforced := true
+	anyEnforced := false
+	for _, e := range g.entries {
+		if e.Enforced {
+			anyEnforced = true
+		} else {
+			allEnforced = false
+		}
+	}
+
+	// Write group header.
+	if allEnforced && anyEnforced {
+		fmt.Fprintf(buf, "[%s][$i]\n", g.name)
+	} else {
+		fmt.Fprintf(buf, "[%s]\n", g.name)
+	}
+
+	// Sort entries by key for deterministic output.
+	sorted := make([]*pb.KConfigEntry, len(g.entries))
+	copy(sorted, g.entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Key < sorted[j].Key
+	})
+
+	for _, e := range sorted {
+		if e.Operation == pb.KConfigOperation_KCONFIG_OPERATION_DELETE {
+			if !allEnforced && e.Enforced {
+				fmt.Fprintf(buf, "%s[$di]\n", e.Key)
+			} else {
+				fmt.Fprintf(buf, "%s[$d]\n", e.Key)
+			}
+			continue
+		}
+		if !allEnforced && e.Enforced {
+			// Key-level enforcement.
+			fmt.Fprintf(buf, "%s[$i]=%s\n", e.Key, e.Value)
+		} else {
+			fmt.Fprintf(buf, "%s=%s\n", e.Key, e.Value)
+		}
+	}
+}
+
+// parseRuleNum extracts the numeric index from a "rule_N" key.
+// Returns -1 if the key does not match the pattern.
+func parseRuleNum(key string) int {
+	if !strings.HasPrefix(key, "rule_") {
+		return -1
+	}
+	n, err := strconv.Atoi(key[len("rule_"):])
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// renumberURLRestrictions collects all rule_N entries in a
+// [KDE URL Restrictions] group, renumbers them sequentially starting
+// from rule_1, and sets a single rule_count entry with the total.
+// Rules of higher-priority policies come first; within a priority rules
+// keep their original index order. Identical rules contributed by several
+// policies are written once, at the position of the highest-priority one.
+// Non-rule entries (other than rule_count) are preserved.
+func renumberURLRestrictions(g *group) {
+	type indexedRule struct {
+		origNum int
+		entry   *pb.KConfigEntry
+	}
+
+	var rules []indexedRule
+	var other []*pb.KConfigEntry
+
+	for _, e := range g.entries {
+		if e.Key == "rule_count" {
+			continue // drop old rule_count — we'll regenerate it
+		}
+		n := parseRuleNum(e.Key)
+		if n > 0 {
+			rules = append(rules, indexedRule{origNum: n, entry: e})
+		} else {
+			other = append(other, e)
+		}
+	}
+
+	// Stable sort so that rules from different policies with the same
+	// priority and index maintain insertion order.
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].entry.Priority != rules[j].entry.Priority {
+			return rules[i].entry.Priority > rules[j].entry.Priority
+		}
+		return rules[i].origNum < rules[j].origNum
+	})
+
+	seen := make(map[string]bool, len(rules))
+	unique := rules[:0]
+	for _, r := range rules {
+		if seen[r.entry.Value] {
+			continue
+		}
+		seen[r.entry.Value] = true
+		unique = append(unique, r)
+	}
+	rules = unique
+
+	// Renumber sequentially.
+	result := make([]*pb.KConfigEntry, 0, len(other)+len(rules)+1)
+	result = append(result, other...)
+	for i, r := range rules {
+		r.entry.Key = fmt.Sprintf("rule_%d", i+1)
+		result = append(result, r.entry)
+	}
+
+	// Add rule_count if there are any rules.
+	if len(rules) > 0 {
+		result = append(result, &pb.KConfigEntry{
+			File:     g.entries[0].File,
+			Group:    g.name,
+			Key:      "rule_count",
+			Value:    strconv.Itoa(len(rules)),
+			Enforced: g.entries[0].Enforced,
+		})
+	}
+
+	g.entries = result
+}
+
+// isKCMRestriction reports whether e is a KDE Control Module restriction.
+func isKCMRestriction(e *pb.KConfigEntry) bool {
+	return e.File == "kde5rc" && e.Group == "KDE Control Module Restrictions"
+}
+
+// EntryScope returns the scope e is written to. Entries without an
+// explicit scope keep the inferred behaviour: KCM restrictions are system
+// immutable and everything else goes to the system overlay.
+func EntryScope(e *pb.KConfigEntry) pb.KConfigScope {
+	switch e.Scope {
+	case pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_OVERLAY,
+		pb.KConfigScope_KCONFIG_SCOPE_USER,
+		pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_IMMUTABLE:
+		return e.Scope
+	}
+	if isKCMRestriction(e) {
+		return pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_IMMUTABLE
+	}
+	return pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_OVERLAY
+}
+
+// routesToKCM reports whether e belongs in /etc/kde5rc and /etc/kde6rc.
+func routesToKCM(e *pb.KConfigEntry) bool {
+	return e.File == "kde5rc" && EntryScope(e) == pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_IMMUTABLE
+}
+
+// ScopedEntries holds KConfig entries grouped by the sync path that
+// writes them.
+type ScopedEntries struct {
+	Overlay []*pb.KConfigEntry // system XDG overlay
+	KCM     []*pb.KConfigEntry // /etc/kde5rc and /etc/kde6rc
+	User    []*pb.KConfigEntry // per-user seeds
+}
+
+// SplitByScope routes entries to their sync paths by EntryScope.
+// System immutable entries are always marked enforced and user entries
+// never are; such entries are copied so the input is left untouched.
+// System immutable kde5rc entries go to KCM, other system immutable
+// entries to the overlay.
+func SplitByScope(entries []*pb.KConfigEntry) ScopedEntries {
+	var s ScopedEntries
+	for _, e := range entries {
+		switch EntryScope(e) {
+		case pb.KConfigScope_KCONFIG_SCOPE_USER:
+			s.User = append(s.User, withEnforced(e, false))
+		case pb.KConfigScope_KCONFIG_SCOPE_SYSTEM_IMMUTABLE:
+			if routesToKCM(e) {
+				s.KCM = append(s.KCM, withEnforced(e, true))
+			} else {
+				s.Overlay = append(s.Overlay, withEnforced(e, true))
+			}
+		default:
+			s.Overlay = append(s.Overlay, e)
+		}
+	}
+	return s
+}
+
+// withEnforced returns e, or a copy of it with Enforced set to enforced.
+func withEnforced(e *pb.KConfigEntry, enforced bool) *pb.KConfigEntry {
+	if e.Enforced == enforced {
+		return e
+	}
+	c := proto.Clone(e).(*pb.KConfigEntry)
+	c.Enforced = enforced
+	return c
+}
+
+// SplitKCMRestrictions separates the entries written to /etc/kde5rc and
+// /etc/kde6rc from all other KConfig entries. Without an explicit scope
+// these are the entries with file="kde5rc" and group="KDE Control Module
+// Restrictions". See SplitByScope for the full routing.
+func SplitKCMRestrictions(entries []*pb.KConfigEntry) (kcm, other []*pb.KConfigEntry) {
+	for _, e := range entries {
+		if routesToKCM(e) {
+			kcm = append(kcm, e)
+		} else {
+			other = append(other, e)
+		}
+	}
+	return
+}
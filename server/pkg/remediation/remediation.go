@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+// Package remediation defines the fixed set of named remediation actions an
+// administrator may run on a node. It is shared by the server, which only
+// accepts these names, and the agent, which maps a name to the command
+// defined here. Commands never travel over the wire and are never run
+// through a shell, so adding an action requires a code change and review.
+package remediation
+
+import "sort"
+
+// Action is a predefined remediation action.
+type Action struct {
+	// Name identifies the action in API requests and stream events.
+	Name string `json:"name"`
+	// Description explains what the action does.
+	Description string `json:"description"`
+	// Argv is the command the agent runs. Argv[0] is an absolute path.
+	Argv []string `json:"-"`
+}
+
+var actions = map[string]Action{
+	"dconf-update": {
+		Name:        "dconf-update",
+		Description: "Recompile the system dconf databases",
+		Argv:        []string{"/usr/bin/dconf", "update"},
+	},
+	"restart-polkit": {
+		Name:        "restart-polkit",
+		Description: "Restart polkit so changed rules take effect",
+		Argv:        []string{"/usr/bin/systemctl", "try-restart", "polkit.service"},
+	},
+	"systemd-daemon-reload": {
+		Name:        "systemd-daemon-reload",
+		Description: "Reload systemd unit files",
+		Argv:        []string{"/usr/bin/systemctl", "daemon-reload"},
+	},
+	"rebuild-font-cache": {
+		Name:        "rebuild-font-cache",
+		Description: "Clear and rebuild the system fontconfig cache",
+		Argv:        []string{"/usr/bin/fc-cache", "--really-force", "--system-only"},
+	},
+}
+
+// Lookup returns the action with the given name.
+func Lookup(name string) (Action, bool) {
+	a, ok := actions[name]
+	return a, ok
+}
+
+// List returns all actions sorted by name.
+func List() []Action {
+	list := make([]Action, 0, len(actions))
+	for _, a := range actions {
+		list = append(list, a)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
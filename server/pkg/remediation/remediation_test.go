@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+package remediation
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestActionsAreWellFormed(t *testing.T) {
+	list := List()
+	if len(list) == 0 {
+		t.Fatal("expected at least one action")
+	}
+	for i, a := range list {
+		if i > 0 && list[i-1].Name >= a.Name {
+			t.Errorf("List not sorted at %q", a.Name)
+		}
+		if a.Description == "" {
+			t.Errorf("%s: missing description", a.Name)
+		}
+		if len(a.Argv) == 0 || !filepath.IsAbs(a.Argv[0]) {
+			t.Errorf("%s: command must be an absolute path, got %v", a.Name, a.Argv)
+		}
+		if got, ok := Lookup(a.Name); !ok || got.Name != a.Name {
+			t.Errorf("Lookup(%q) = %v, %v", a.Name, got, ok)
+		}
+	}
+	if _, ok := Lookup("sh -c reboot"); ok {
+		t.Error("unknown action must not be found")
+	}
+}
@@ -42,6 +42,7 @@ import { setPermissions, clearPermissions, hasPermission } from "./apiClient/per
 import { LoginPage } from "./views/LoginPage";
 import { AccountModal } from "./views/Settings/AccountModal";
 import { MFARequiredGate } from "./views/MFARequiredGate";
+import { PasswordChangeRequiredGate } from "./views/PasswordChangeRequiredGate";
 import { DashboardPage } from "./views/Dashboard";
 import { PoliciesPage } from "./views/Policies";
 import { NodesPage } from "./views/Nodes";
@@ -121,6 +122,7 @@ export const Shell: React.FC = () => {
   /* ── Auth state ── */
   const [isLoggedIn, setIsLoggedIn] = useState(false);
   const [currentUser, setCurrentUser] = useState<string>("");
+  const [currentUserSource, setCurrentUserSource] = useState<string | undefined>(undefined);
   const [authChecked, setAuthChecked] = useState(false);
 
   const [activeScreen, setActiveScreen] = useState<ScreenKey>("dashboard");
@@ -135,11 +137,14 @@ export const Shell: React.FC = () => {
   // Tracks the element that opened AccountModal so focus returns on close (WCAG 2.1.1)
   const accountModalTriggerRef = React.useRef<HTMLElement | null>(null);
   const [mfaGateActive, setMfaGateActive] = useState(false);
+  const [passwordGateActive, setPasswordGateActive] = useState(false);
 
   /* ── After session is established, check if MFA setup is required ── */
   const applySession = useCallback(async (user: UserInfo) => {
     setPermissions(user.permissions || []);
     setCurrentUser(user.full_name || user.username);
+    setCurrentUserSource(user.source);
+    setPasswordGateActive(!!user.must_change_password);
     setIsLoggedIn(true);
     // Check whether MFA is enforced but not yet set up for this user.
     // A failure here is non-fatal — we simply don't show the gate.
@@ -182,6 +187,7 @@ export const Shell: React.FC = () => {
     setCurrentUser("");
     setActiveScreen("dashboard");
     setMfaGateActive(false);
+    setPasswordGateActive(false);
   }, []);
 
   /* ── Show login page if not signed in ── */
@@ -197,6 +203,15 @@ export const Shell: React.FC = () => {
     return <LoginPage onLoggedIn={handleLoggedIn} />;
   }
 
+  if (passwordGateActive) {
+    return (
+      <PasswordChangeRequiredGate
+        onPasswordChanged={() => setPasswordGateActive(false)}
+        onLogout={performLogout}
+      />
+    );
+  }
+
   if (mfaGateActive) {
     return (
       <MFARequiredGate
@@ -513,6 +528,7 @@ export const Shell: React.FC = () => {
       </Page>
       <AccountModal
         isOpen={isAccountModalOpen}
+        userSource={currentUserSource}
         onClose={() => {
           setIsAccountModalOpen(false);
           setTimeout(() => accountModalTriggerRef.current?.focus(), 0);
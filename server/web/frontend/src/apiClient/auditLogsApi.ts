@@ -10,7 +10,7 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -31,6 +31,7 @@ export interface AuditLog {
   details: string;
   ip_address: string;
   created_at: string;
+  correlation_id?: string;
 }
 
 export interface AuditLogListResponse {
@@ -47,6 +48,23 @@ export interface AuditLogListParams {
   resource_type?: string[];
   action?: string[];
   username?: string;
+  correlation_id?: string;
+  /** Free text matched against details and resource ID. */
+  q?: string;
+  /** Inclusive lower bound: RFC 3339 timestamp or YYYY-MM-DD. */
+  from?: string;
+  /** Exclusive upper bound; a YYYY-MM-DD date includes that day. */
+  to?: string;
+}
+
+function appendFilterParams(qp: URLSearchParams, params?: AuditLogListParams) {
+  params?.resource_type?.forEach((v) => qp.append("resource_type", v));
+  params?.action?.forEach((v) => qp.append("action", v));
+  if (params?.username) qp.set("username", params.username);
+  if (params?.correlation_id) qp.set("correlation_id", params.correlation_id);
+  if (params?.q) qp.set("q", params.q);
+  if (params?.from) qp.set("from", params.from);
+  if (params?.to) qp.set("to", params.to);
 }
 
 /* ── API methods ── */
@@ -57,9 +75,7 @@ export async function fetchAuditLogs(
   const qp = new URLSearchParams();
   if (params?.page) qp.set("page", String(params.page));
   if (params?.per_page) qp.set("per_page", String(params.per_page));
-  params?.resource_type?.forEach((v) => qp.append("resource_type", v));
-  params?.action?.forEach((v) => qp.append("action", v));
-  if (params?.username) qp.set("username", params.username);
+  appendFilterParams(qp, params);
 
   const qs = qp.toString();
   const url = `/api/v1/audit-logs${qs ? "?" + qs : ""}`;
@@ -72,9 +88,7 @@ export async function exportAuditLogs(
 ): Promise<void> {
   const qp = new URLSearchParams();
   qp.set("format", format);
-  params?.resource_type?.forEach((v) => qp.append("resource_type", v));
-  params?.action?.forEach((v) => qp.append("action", v));
-  if (params?.username) qp.set("username", params.username);
+  appendFilterParams(qp, params);
 
   const res = await fetch(`/api/v1/audit-logs/export?${qp.toString()}`, {
     credentials: "same-origin",
@@ -84,7 +98,7 @@ export async function exportAuditLogs(
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
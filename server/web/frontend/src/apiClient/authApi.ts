@@ -62,7 +62,7 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -80,6 +80,11 @@ export async function refreshSession(): Promise<boolean> {
 export interface LoginResult {
   token: string;
   user: UserInfo;
+  /** Set, without token and user, when a second factor must be verified
+   *  by passing session_token to authStep. */
+  mfa_required?: boolean;
+  session_token?: string;
+  mfa_methods?: string[];
 }
 
 export interface UserInfo {
@@ -89,6 +94,7 @@ export interface UserInfo {
   full_name: string;
   permissions?: string[];
   source?: string;
+  must_change_password?: boolean;
 }
 
 export async function login(
@@ -180,6 +186,34 @@ export async function mfaSetupFinish(code: string): Promise<MFASetupFinishResult
   });
 }
 
+/** Minimum password length enforced by the server. */
+export const MIN_PASSWORD_LENGTH = 12;
+
+export async function changePassword(
+  currentPassword: string,
+  newPassword: string
+): Promise<void> {
+  const res = await fetch("/api/v1/auth/change-password", {
+    method: "POST",
+    headers: authHeaders(),
+    credentials: "same-origin",
+    body: JSON.stringify({
+      current_password: currentPassword,
+      new_password: newPassword,
+    }),
+  });
+  if (!res.ok) {
+    let detail = res.statusText;
+    try {
+      const b = await res.json();
+      if (b.error?.message) detail = b.error.message;
+    } catch {
+      /* swallow */
+    }
+    throw new Error(detail);
+  }
+}
+
 export async function mfaDisable(password: string): Promise<void> {
   const res = await fetch("/api/v1/users/me/mfa/disable", {
     method: "POST",
@@ -191,7 +225,7 @@ export async function mfaDisable(password: string): Promise<void> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -291,7 +325,7 @@ export async function renameWebAuthnCredential(
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -309,7 +343,7 @@ export async function deleteWebAuthnCredential(id: string): Promise<void> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -10,7 +10,7 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -27,25 +27,85 @@ export interface PolicyBinding {
   id: string;
   policy_id: string;
   group_id: string;
+  filter_id?: string;
+  user_group_id?: string;
+  scope_kind: "node_group" | "node_filter" | "user_group";
   state: "enabled" | "disabled";
   priority: number;
+  window?: BindingWindow;
+  /** False while the binding is outside its window and delivers nothing. */
+  in_window: boolean;
+  profile_managed: boolean;
   policy_name: string;
   policy_state: string;
   group_name: string;
+  filter_name?: string;
+  user_group_name?: string;
   node_count: number;
   created_at: string;
   updated_at: string;
 }
 
+/**
+ * Limits when a binding is in effect. schedule is a list of weekly windows
+ * such as "Mon-Fri 08:00-16:00; Sat 09:00-12:00" in the IANA timezone
+ * (default UTC).
+ */
+export interface BindingWindow {
+  active_from?: string;
+  active_to?: string;
+  schedule?: string;
+  timezone?: string;
+}
+
+/**
+ * Exactly one of group_id, filter_id and user_group_id must be set. Only
+ * KConfig policies can be bound to a user group.
+ */
 export interface CreatePolicyBindingRequest {
   policy_id: string;
-  group_id: string;
+  group_id?: string;
+  filter_id?: string;
+  user_group_id?: string;
   priority: number;
+  window?: BindingWindow;
 }
 
 export interface UpdatePolicyBindingRequest {
   state?: string;
   priority?: number;
+  /** Replaces the binding's window; an empty object removes it. */
+  window?: BindingWindow;
+}
+
+export interface Profile {
+  id: string;
+  name: string;
+  description: string;
+  policy_ids: string[];
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateProfileRequest {
+  name: string;
+  description: string;
+  policy_ids: string[];
+}
+
+export interface UpdateProfileRequest {
+  name?: string;
+  description?: string;
+  policy_ids?: string[];
+}
+
+export interface ProfileBinding {
+  id: string;
+  profile_id: string;
+  group_id: string;
+  group_name: string;
+  priority: number;
+  created_at: string;
 }
 
 /* ── API calls ── */
@@ -89,3 +149,67 @@ export async function deleteBinding(id: string): Promise<void> {
     headers: authHeaders(),
   });
 }
+
+export async function fetchProfiles(): Promise<Profile[]> {
+  return apiRequest<Profile[]>("/api/v1/profiles", {
+    headers: authHeaders(),
+  });
+}
+
+export async function createProfile(
+  req: CreateProfileRequest
+): Promise<Profile> {
+  return apiRequest<Profile>("/api/v1/profiles", {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify(req),
+  });
+}
+
+export async function updateProfile(
+  id: string,
+  req: UpdateProfileRequest
+): Promise<Profile> {
+  return apiRequest<Profile>(`/api/v1/profiles/${id}`, {
+    method: "PUT",
+    headers: authHeaders(),
+    body: JSON.stringify(req),
+  });
+}
+
+export async function deleteProfile(id: string): Promise<void> {
+  return apiRequest<void>(`/api/v1/profiles/${id}`, {
+    method: "DELETE",
+    headers: authHeaders(),
+  });
+}
+
+export async function fetchProfileBindings(
+  id: string
+): Promise<ProfileBinding[]> {
+  return apiRequest<ProfileBinding[]>(`/api/v1/profiles/${id}/bindings`, {
+    headers: authHeaders(),
+  });
+}
+
+export async function bindProfile(
+  id: string,
+  groupId: string,
+  priority: number
+): Promise<ProfileBinding> {
+  return apiRequest<ProfileBinding>(`/api/v1/profiles/${id}/bindings`, {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify({ group_id: groupId, priority }),
+  });
+}
+
+export async function unbindProfile(
+  id: string,
+  groupId: string
+): Promise<void> {
+  return apiRequest<void>(`/api/v1/profiles/${id}/bindings/${groupId}`, {
+    method: "DELETE",
+    headers: authHeaders(),
+  });
+}
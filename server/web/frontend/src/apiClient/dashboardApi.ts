@@ -10,7 +10,7 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -77,6 +77,7 @@ export interface CertExpiryEntry {
 export interface FleetOverview {
   totalNodes: number;
   online: number;
+  degraded: number;
   offline: number;
   unknown: number;
   agentVersions: Record<string, number>;
@@ -157,6 +158,7 @@ export async function fetchDashboardData(): Promise<DashboardData> {
   /* Fleet */
   const totalNodes = rawNodes.length;
   const online = rawNodes.filter((n) => n.status === "online").length;
+  const degraded = rawNodes.filter((n) => n.status === "degraded").length;
   const offline = rawNodes.filter((n) => n.status === "offline").length;
   const unknown = rawNodes.filter((n) => n.status === "unknown").length;
 
@@ -268,7 +270,7 @@ export async function fetchDashboardData(): Promise<DashboardData> {
     }));
 
   return {
-    fleet: { totalNodes, online, offline, unknown, agentVersions, osDistribution, desktopEnvironment, certsExpiringSoon, certsExpired },
+    fleet: { totalNodes, online, degraded, offline, unknown, agentVersions, osDistribution, desktopEnvironment, certsExpiringSoon, certsExpired },
     nodesGroups: { totalGroups: rawGroups.length, nodesWithoutGroup, groups },
     policies: { totalPolicies: rawPolicies.length, released, draft, archived, byType },
     bindings: {
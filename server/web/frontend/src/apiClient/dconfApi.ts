@@ -10,7 +10,7 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch { /* swallow */ }
     throw new Error(detail);
   }
@@ -64,6 +64,27 @@ export interface ComplianceResult {
   message?: string;
   items?: ComplianceItem[];
   reported_at: string;
+  /** Result of the policy's compliance check command, if any. */
+  check_status?: ComplianceStatus;
+  check_exit_code?: number;
+  check_message?: string;
+  checked_at?: string;
+  /** Set after repeated consecutive failures (debounced alerting). */
+  unhealthy: boolean;
+  consecutive_failures: number;
+}
+
+/** Content hash of a managed file as last reported by a node. */
+export interface AppliedFile {
+  node_id: string;
+  node_name: string;
+  policy_id: string;
+  policy_name: string;
+  path: string;
+  sha256: string;
+  reported_at: string;
+  /** True when the hash differs from the most common one for this file. */
+  divergent: boolean;
 }
 
 /* ── DConf policy content types (stored as JSON in policy.content) ── */
@@ -90,8 +111,124 @@ export async function fetchDConfSchemas(nodeId?: string): Promise<DConfSchema[]>
   });
 }
 
+export interface ComplianceListParams {
+  page?: number;
+  per_page?: number;
+  policy_id?: string;
+  /** true for passing results, false for non-compliant and errored ones. */
+  compliant?: boolean;
+}
+
+export interface ComplianceListResponse {
+  items: ComplianceResult[];
+  total: number;
+  page: number;
+  per_page: number;
+  total_pages: number;
+}
+
+export interface NodeComplianceResponse extends ComplianceListResponse {
+  compliant_count: number;
+  noncompliant_count: number;
+}
+
+function complianceQuery(params: ComplianceListParams): string {
+  const qp = new URLSearchParams();
+  if (params.page) qp.set("page", String(params.page));
+  if (params.per_page) qp.set("per_page", String(params.per_page));
+  if (params.policy_id) qp.set("policy_id", params.policy_id);
+  if (params.compliant !== undefined) qp.set("compliant", String(params.compliant));
+  const qs = qp.toString();
+  return qs ? `?${qs}` : "";
+}
+
+export async function listComplianceResults(
+  params: ComplianceListParams = {}
+): Promise<ComplianceListResponse> {
+  return apiRequest<ComplianceListResponse>(`/api/v1/compliance${complianceQuery(params)}`, {
+    headers: authHeaders(),
+  });
+}
+
+/** Fetches every page of compliance results. */
 export async function fetchComplianceResults(): Promise<ComplianceResult[]> {
-  return apiRequest<ComplianceResult[]>("/api/v1/compliance", {
+  const results: ComplianceResult[] = [];
+  for (let page = 1; ; page++) {
+    const resp = await listComplianceResults({ page, per_page: 100 });
+    results.push(...resp.items);
+    if (page >= resp.total_pages) return results;
+  }
+}
+
+/** Latest report per policy for one node, with a compliant/non-compliant rollup. */
+export async function fetchNodeCompliance(
+  nodeId: string,
+  params: ComplianceListParams = {}
+): Promise<NodeComplianceResponse> {
+  return apiRequest<NodeComplianceResponse>(
+    `/api/v1/nodes/${encodeURIComponent(nodeId)}/compliance${complianceQuery(params)}`,
+    { headers: authHeaders() }
+  );
+}
+
+export async function fetchAppliedFiles(
+  filter: { nodeId?: string; nodeGroupId?: string } = {}
+): Promise<AppliedFile[]> {
+  const params = new URLSearchParams();
+  if (filter.nodeId) params.set("node_id", filter.nodeId);
+  if (filter.nodeGroupId) params.set("node_group_id", filter.nodeGroupId);
+  const qs = params.toString() ? `?${params}` : "";
+  return apiRequest<AppliedFile[]>(`/api/v1/compliance/applied-files${qs}`, {
     headers: authHeaders(),
   });
 }
+
+export interface ComplianceReportFilter {
+  nodeGroupId?: string;
+  policyId?: string;
+  /** RFC 3339 timestamp or YYYY-MM-DD date. */
+  from?: string;
+  /** RFC 3339 timestamp or YYYY-MM-DD date (inclusive). */
+  to?: string;
+}
+
+/**
+ * Downloads the compliance evidence report for GRC tools: one row per
+ * (node, policy) with state, last check time and applied content hash.
+ */
+export async function exportComplianceReport(
+  format: "csv" | "json",
+  filter: ComplianceReportFilter = {}
+): Promise<void> {
+  const qp = new URLSearchParams();
+  qp.set("format", format);
+  if (filter.nodeGroupId) qp.set("node_group_id", filter.nodeGroupId);
+  if (filter.policyId) qp.set("policy_id", filter.policyId);
+  if (filter.from) qp.set("from", filter.from);
+  if (filter.to) qp.set("to", filter.to);
+
+  const res = await fetch(`/api/v1/compliance/export?${qp.toString()}`, {
+    credentials: "same-origin",
+  });
+
+  if (!res.ok) {
+    let detail = res.statusText;
+    try {
+      const b = await res.json();
+      if (b.error?.message) detail = b.error.message;
+    } catch {
+      /* swallow */
+    }
+    throw new Error(detail);
+  }
+
+  const blob = await res.blob();
+  const url = window.URL.createObjectURL(blob);
+  const a = document.createElement("a");
+  a.href = url;
+  a.download = `compliance_report.${format}`;
+  document.body.appendChild(a);
+  a.click();
+  document.body.removeChild(a);
+  window.URL.revokeObjectURL(url);
+}
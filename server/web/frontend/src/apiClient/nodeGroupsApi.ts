@@ -3,6 +3,7 @@
 // Copyright (C) 2026 Bor contributors
 
 import { authHeaders } from "./authApi";
+import type { PolicyBinding } from "./bindingsApi";
 
 async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
   const res = await fetch(url, { credentials: "same-origin", ...init });
@@ -10,7 +11,7 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -27,25 +28,82 @@ export interface NodeGroup {
   id: string;
   name: string;
   description: string;
+  inventory_only: boolean;
+  /** Bindings of quarantine groups form the policy set of quarantined nodes. */
+  quarantine: boolean;
   node_count: number;
   created_at: string;
   updated_at: string;
 }
 
+export interface NodeGroupMember {
+  id: string;
+  name: string;
+  status: string;
+}
+
+/** A node group with its member nodes and the policy bindings targeting it. */
+export interface NodeGroupDetails extends Omit<NodeGroup, "node_count"> {
+  nodes: NodeGroupMember[];
+  bindings: PolicyBinding[];
+}
+
+/** Outcome of a bulk membership change; skipped nodes needed no change or do not exist. */
+export interface NodeGroupMembersResponse {
+  added: number;
+  removed: number;
+  skipped: number;
+}
+
 export interface CreateNodeGroupRequest {
   name: string;
   description: string;
+  inventory_only?: boolean;
+  quarantine?: boolean;
 }
 
 export interface UpdateNodeGroupRequest {
   name?: string;
   description?: string;
+  inventory_only?: boolean;
+  quarantine?: boolean;
 }
 
 export interface EnrollmentToken {
+  id: string;
   token: string;
   node_group_id: string;
+  campaign_id?: string;
   expires_at: string;
+  max_uses: number;
+  use_count: number;
+  revoked_at?: string;
+  revoked_by?: string;
+}
+
+/** Upper bound on the number of enrollments per token, enforced by the server. */
+export const MAX_ENROLLMENT_TOKEN_USES = 10000;
+
+export interface EnrollmentCampaign {
+  id: string;
+  name: string;
+  description: string;
+  node_group_id: string;
+  token_ttl_seconds: number;
+  /** 0 means unlimited. */
+  max_enrollments: number;
+  enrolled_count: number;
+  created_by: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateEnrollmentCampaignRequest {
+  name: string;
+  description: string;
+  node_group_id: string;
+  token_ttl_seconds?: number;
+  max_enrollments?: number;
 }
 
 /* ── API calls ── */
@@ -56,8 +114,8 @@ export async function fetchNodeGroups(): Promise<NodeGroup[]> {
   });
 }
 
-export async function fetchNodeGroup(id: string): Promise<NodeGroup> {
-  return apiRequest<NodeGroup>(`/api/v1/node-groups/${id}`, {
+export async function fetchNodeGroup(id: string): Promise<NodeGroupDetails> {
+  return apiRequest<NodeGroupDetails>(`/api/v1/node-groups/${id}`, {
     headers: authHeaders(),
   });
 }
@@ -88,11 +146,74 @@ export async function deleteNodeGroup(id: string): Promise<void> {
   });
 }
 
+export async function addNodeGroupMembers(
+  groupId: string,
+  nodeIds: string[]
+): Promise<NodeGroupMembersResponse> {
+  return apiRequest<NodeGroupMembersResponse>(`/api/v1/node-groups/${groupId}/members`, {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify({ node_ids: nodeIds }),
+  });
+}
+
+export async function removeNodeGroupMembers(
+  groupId: string,
+  nodeIds: string[]
+): Promise<NodeGroupMembersResponse> {
+  return apiRequest<NodeGroupMembersResponse>(`/api/v1/node-groups/${groupId}/members`, {
+    method: "DELETE",
+    headers: authHeaders(),
+    body: JSON.stringify({ node_ids: nodeIds }),
+  });
+}
+
 export async function generateEnrollmentToken(
-  groupId: string
+  groupId: string,
+  maxUses = 1,
+  ttlSeconds?: number
 ): Promise<EnrollmentToken> {
   return apiRequest<EnrollmentToken>(`/api/v1/node-groups/${groupId}/tokens`, {
     method: "POST",
     headers: authHeaders(),
+    body: JSON.stringify({ max_uses: maxUses, ttl_seconds: ttlSeconds }),
+  });
+}
+
+export async function revokeEnrollmentToken(
+  groupId: string,
+  tokenId: string
+): Promise<void> {
+  return apiRequest<void>(
+    `/api/v1/node-groups/${groupId}/tokens/${encodeURIComponent(tokenId)}`,
+    {
+      method: "DELETE",
+      headers: authHeaders(),
+    }
+  );
+}
+
+export async function fetchEnrollmentCampaigns(): Promise<EnrollmentCampaign[]> {
+  return apiRequest<EnrollmentCampaign[]>("/api/v1/enrollment-campaigns", {
+    headers: authHeaders(),
+  });
+}
+
+export async function createEnrollmentCampaign(
+  req: CreateEnrollmentCampaignRequest
+): Promise<EnrollmentCampaign> {
+  return apiRequest<EnrollmentCampaign>("/api/v1/enrollment-campaigns", {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify(req),
+  });
+}
+
+export async function generateCampaignEnrollmentToken(
+  campaignId: string
+): Promise<EnrollmentToken> {
+  return apiRequest<EnrollmentToken>(`/api/v1/enrollment-campaigns/${campaignId}/tokens`, {
+    method: "POST",
+    headers: authHeaders(),
   });
 }
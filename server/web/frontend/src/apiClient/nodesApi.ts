@@ -3,6 +3,7 @@
 // Copyright (C) 2026 Bor contributors
 
 import { authHeaders } from "./authApi";
+import type { Policy } from "./policiesApi";
 
 async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
   const res = await fetch(url, { credentials: "same-origin", ...init });
@@ -10,7 +11,7 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -21,7 +22,18 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
 
 /* ── Node types ── */
 
-export type NodeStatus = "online" | "offline" | "unknown";
+export type NodeStatus = "online" | "degraded" | "offline" | "unknown";
+
+/** Hardware and resource facts; absent fields could not be determined. */
+export interface NodeHardware {
+  memory_total_bytes?: number;
+  memory_available_bytes?: number;
+  cpu_model?: string;
+  cpu_cores?: number;
+  root_disk_total_bytes?: number;
+  root_disk_free_bytes?: number;
+  boot_time?: string;
+}
 
 export interface Node {
   id: string;
@@ -33,6 +45,14 @@ export interface Node {
   os_version?: string;
   desktop_env?: string;
   agent_version?: string;
+  firefox_version?: string;
+  chrome_version?: string;
+  kernel_version?: string;
+  hardware?: NodeHardware;
+  /** Set when less than 10% of the root filesystem is free. */
+  low_disk?: boolean;
+  /** Set when the agent is older than the server's minimum agent version. */
+  agent_outdated?: boolean;
   status: NodeStatus;
   status_reason?: string;
   groups?: string;
@@ -42,6 +62,11 @@ export interface Node {
   last_seen?: string;
   cert_serial?: string;
   cert_not_after?: string;
+  /** Set while the node is quarantined and receives only lockdown policies. */
+  quarantined_at?: string;
+  quarantine_reason?: string;
+  /** Users with a login session on the node, as of its last heartbeat. */
+  session_users: string[];
   created_at: string;
   updated_at: string;
 }
@@ -54,10 +79,79 @@ export interface UpdateNodeRequest {
 
 export interface NodeStatusCounts {
   online: number;
+  degraded?: number;
   offline: number;
   unknown: number;
 }
 
+/** Hypothetical node attributes for an effective-policies dry run. */
+export interface NodeOverrides {
+  group_ids?: string[];
+  add_group_ids?: string[];
+  remove_group_ids?: string[];
+  os_name?: string;
+  os_version?: string;
+  desktop_env?: string;
+  agent_version?: string;
+}
+
+export interface EffectivePolicies {
+  node_id: string;
+  dry_run: boolean;
+  overrides?: NodeOverrides;
+  group_ids: string[];
+  filter_ids: string[];
+  policies: Policy[];
+  added?: string[];
+  removed?: string[];
+  stale_policies: StalePolicy[];
+}
+
+/** A deprecated policy a node receives, or an archived one it is still bound to. */
+export interface StalePolicy {
+  policy_id: string;
+  policy_name: string;
+  reason: "deprecated" | "archived";
+  deprecation_message?: string;
+  replacement_policy_id?: string;
+}
+
+export interface NodeStalePolicies {
+  node_id: string;
+  node_name: string;
+  policies: StalePolicy[];
+}
+
+/** An on-demand capture of an agent's recent log output. */
+export interface NodeLogCapture {
+  id: string;
+  node_id: string;
+  status: "pending" | "received";
+  requested_by: string;
+  requested_at: string;
+  received_at?: string;
+  size: number;
+  truncated: boolean;
+}
+
+export interface RemediationAction {
+  name: string;
+  description: string;
+}
+
+export interface NodeRemediation {
+  id: string;
+  node_id: string;
+  action: string;
+  status: "pending" | "succeeded" | "failed";
+  requested_by: string;
+  requested_at: string;
+  completed_at?: string;
+  exit_code?: number;
+  output: string;
+  error?: string;
+}
+
 /* ── API calls ── */
 
 export async function fetchNodes(params?: {
@@ -72,6 +166,43 @@ export async function fetchNodes(params?: {
   return apiRequest<Node[]>(url, { headers: authHeaders() });
 }
 
+export interface NodeListParams {
+  page?: number;
+  per_page?: number;
+  search?: string;
+  status?: NodeStatus;
+  os_name?: string;
+  desktop_env?: string;
+  group_id?: string;
+}
+
+export interface NodeListResponse {
+  items: Node[];
+  total: number;
+  page: number;
+  per_page: number;
+  total_pages: number;
+}
+
+/** Fetches one page of the nodes matching all given filters. */
+export async function fetchNodesPage(params: NodeListParams = {}): Promise<NodeListResponse> {
+  const qs = new URLSearchParams();
+  qs.set("page", String(params.page ?? 1));
+  if (params.per_page) qs.set("per_page", String(params.per_page));
+  if (params.search) qs.set("search", params.search);
+  if (params.status) qs.set("status", params.status);
+  if (params.os_name) qs.set("os_name", params.os_name);
+  if (params.desktop_env) qs.set("desktop_env", params.desktop_env);
+  if (params.group_id) qs.set("group_id", params.group_id);
+  return apiRequest<NodeListResponse>(`/api/v1/nodes?${qs.toString()}`, { headers: authHeaders() });
+}
+
+export async function fetchStalePolicyNodes(): Promise<NodeStalePolicies[]> {
+  return apiRequest<NodeStalePolicies[]>("/api/v1/nodes/stale-policies", {
+    headers: authHeaders(),
+  });
+}
+
 export async function fetchNode(id: string): Promise<Node> {
   return apiRequest<Node>(`/api/v1/nodes/${id}`, { headers: authHeaders() });
 }
@@ -97,6 +228,117 @@ export async function refreshNodeMetadata(id: string): Promise<void> {
   });
 }
 
+export async function collectNodeLogs(id: string): Promise<NodeLogCapture> {
+  return apiRequest<NodeLogCapture>(`/api/v1/nodes/${id}/collect-logs`, {
+    method: "POST",
+    headers: authHeaders(),
+  });
+}
+
+export async function fetchNodeLogCaptures(
+  id: string
+): Promise<NodeLogCapture[]> {
+  return apiRequest<NodeLogCapture[]>(`/api/v1/nodes/${id}/logs`, {
+    headers: authHeaders(),
+  });
+}
+
+export async function fetchRemediationActions(): Promise<RemediationAction[]> {
+  return apiRequest<RemediationAction[]>("/api/v1/remediation-actions", {
+    headers: authHeaders(),
+  });
+}
+
+export async function runNodeRemediation(
+  id: string,
+  action: string
+): Promise<NodeRemediation> {
+  return apiRequest<NodeRemediation>(`/api/v1/nodes/${id}/run-command`, {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify({ action }),
+  });
+}
+
+export async function quarantineNode(
+  id: string,
+  reason?: string
+): Promise<Node> {
+  return apiRequest<Node>(`/api/v1/nodes/${id}/quarantine`, {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify({ reason }),
+  });
+}
+
+export async function unquarantineNode(id: string): Promise<Node> {
+  return apiRequest<Node>(`/api/v1/nodes/${id}/unquarantine`, {
+    method: "POST",
+    headers: authHeaders(),
+  });
+}
+
+export async function fetchNodeRemediations(
+  id: string
+): Promise<NodeRemediation[]> {
+  return apiRequest<NodeRemediation[]>(`/api/v1/nodes/${id}/remediations`, {
+    headers: authHeaders(),
+  });
+}
+
+export async function downloadNodeLogCapture(
+  id: string,
+  captureId: string,
+  filename: string
+): Promise<void> {
+  const res = await fetch(`/api/v1/nodes/${id}/logs/${captureId}`, {
+    credentials: "same-origin",
+    headers: authHeaders(),
+  });
+  if (!res.ok) {
+    let detail = res.statusText;
+    try {
+      const b = await res.json();
+      if (b.error?.message) detail = b.error.message;
+    } catch {
+      /* swallow */
+    }
+    throw new Error(detail);
+  }
+
+  const blob = await res.blob();
+  const url = window.URL.createObjectURL(blob);
+  const a = document.createElement("a");
+  a.href = url;
+  a.download = filename;
+  document.body.appendChild(a);
+  a.click();
+  document.body.removeChild(a);
+  window.URL.revokeObjectURL(url);
+}
+
+export async function fetchEffectivePolicies(
+  id: string,
+  overrides?: NodeOverrides
+): Promise<EffectivePolicies> {
+  const qs = new URLSearchParams();
+  if (overrides) {
+    overrides.group_ids?.forEach((g) => qs.append("group_id", g));
+    if (overrides.group_ids?.length === 0) qs.set("group_id", "");
+    overrides.add_group_ids?.forEach((g) => qs.append("add_group_id", g));
+    overrides.remove_group_ids?.forEach((g) => qs.append("remove_group_id", g));
+    if (overrides.os_name !== undefined) qs.set("os_name", overrides.os_name);
+    if (overrides.os_version !== undefined) qs.set("os_version", overrides.os_version);
+    if (overrides.desktop_env !== undefined) qs.set("desktop_env", overrides.desktop_env);
+    if (overrides.agent_version !== undefined) qs.set("agent_version", overrides.agent_version);
+  }
+  const query = qs.toString();
+  return apiRequest<EffectivePolicies>(
+    `/api/v1/nodes/${id}/effective-policies${query ? `?${query}` : ""}`,
+    { headers: authHeaders() }
+  );
+}
+
 export async function addNodeToGroup(nodeId: string, groupId: string): Promise<Node> {
   return apiRequest<Node>(`/api/v1/nodes/${nodeId}/groups`, {
     method: "POST",
@@ -115,7 +357,7 @@ export async function removeNodeFromGroup(nodeId: string, groupId: string): Prom
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -133,7 +375,7 @@ export async function deleteNode(id: string): Promise<void> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -141,6 +383,36 @@ export async function deleteNode(id: string): Promise<void> {
   }
 }
 
+export interface BulkDeleteNodesRequest {
+  node_ids?: string[];
+  filter_id?: string;
+  expected_count: number;
+  reason?: string;
+}
+
+export interface BulkDeleteNodeResult {
+  node_id: string;
+  name?: string;
+  status: "deleted" | "not_found";
+  cert_revoked: boolean;
+  disconnected: boolean;
+}
+
+export interface BulkDeleteNodesResponse {
+  deleted: number;
+  results: BulkDeleteNodeResult[];
+}
+
+export async function bulkDeleteNodes(
+  req: BulkDeleteNodesRequest
+): Promise<BulkDeleteNodesResponse> {
+  return apiRequest<BulkDeleteNodesResponse>("/api/v1/nodes:bulk-delete", {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify(req),
+  });
+}
+
 export async function revokeNodeCertificate(id: string, reason?: string): Promise<void> {
   await apiRequest<{ status: string }>(`/api/v1/nodes/${id}/revoke`, {
     method: "POST",
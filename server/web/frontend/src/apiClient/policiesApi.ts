@@ -10,7 +10,7 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -32,16 +32,43 @@ export interface Policy {
   deprecated_at?: string | null;
   deprecation_message?: string | null;
   replacement_policy_id?: string | null;
+  contact_loss_action: ContactLossAction;
+  contact_loss_ttl_seconds: number;
+  check_command: string;
+  check_args: string[];
+  check_timeout_seconds: number;
+  preconditions: PolicyPrecondition[];
   created_by: string;
   created_at: string;
   updated_at: string;
 }
 
+export type ContactLossAction = "keep" | "revert" | "activate";
+
+export type PreconditionType =
+  | "file_exists"
+  | "command_exists"
+  | "desktop_installed"
+  | "desktop_session";
+
+/** A check on live node state the agent evaluates before applying a policy. */
+export interface PolicyPrecondition {
+  type: PreconditionType;
+  value: string;
+  negate?: boolean;
+}
+
 export interface CreatePolicyRequest {
   name: string;
   description: string;
   type: string;
   content: string;
+  contact_loss_action?: ContactLossAction;
+  contact_loss_ttl_seconds?: number;
+  check_command?: string;
+  check_args?: string[];
+  check_timeout_seconds?: number;
+  preconditions?: PolicyPrecondition[];
 }
 
 export interface UpdatePolicyRequest {
@@ -49,6 +76,12 @@ export interface UpdatePolicyRequest {
   description?: string;
   type?: string;
   content?: string;
+  contact_loss_action?: ContactLossAction;
+  contact_loss_ttl_seconds?: number;
+  check_command?: string;
+  check_args?: string[];
+  check_timeout_seconds?: number;
+  preconditions?: PolicyPrecondition[];
 }
 
 export interface SetPolicyStateRequest {
@@ -60,6 +93,22 @@ export interface DeprecatePolicyRequest {
   replacement_policy_id?: string;
 }
 
+/** A file as an agent would write it for a policy. */
+export interface RenderedPolicyFile {
+  path: string;
+  content: string;
+}
+
+export interface RenderedPolicy {
+  policy_id: string;
+  files: RenderedPolicyFile[];
+}
+
+export interface ValidatePolicyResult {
+  valid: boolean;
+  error?: string;
+}
+
 /* ── API methods ── */
 
 export async function fetchAllPolicies(): Promise<Policy[]> {
@@ -74,6 +123,12 @@ export async function fetchPolicy(id: string): Promise<Policy> {
   });
 }
 
+export async function fetchRenderedPolicy(id: string): Promise<RenderedPolicy> {
+  return apiRequest<RenderedPolicy>(`/api/v1/policies/all/${encodeURIComponent(id)}/rendered`, {
+    headers: authHeaders(),
+  });
+}
+
 export async function createPolicy(req: CreatePolicyRequest): Promise<Policy> {
   return apiRequest<Policy>("/api/v1/policies/all", {
     method: "POST",
@@ -82,6 +137,14 @@ export async function createPolicy(req: CreatePolicyRequest): Promise<Policy> {
   });
 }
 
+export async function validatePolicy(type: string, content: string): Promise<ValidatePolicyResult> {
+  return apiRequest<ValidatePolicyResult>("/api/v1/policies/validate", {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify({ type, content }),
+  });
+}
+
 export async function updatePolicy(id: string, req: UpdatePolicyRequest): Promise<Policy> {
   return apiRequest<Policy>(`/api/v1/policies/all/${encodeURIComponent(id)}`, {
     method: "PUT",
@@ -116,7 +179,7 @@ export async function deletePolicy(id: string): Promise<void> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
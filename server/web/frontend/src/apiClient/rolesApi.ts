@@ -10,7 +10,7 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -25,7 +25,7 @@ async function apiRequestNoBody(url: string, init?: RequestInit): Promise<void>
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -60,6 +60,19 @@ export interface UpdateRoleRequest {
   description?: string;
 }
 
+/** A user holding a role through one binding, direct or via a user group. */
+export interface RoleHolder {
+  user_id: string;
+  username: string;
+  full_name: string;
+  enabled: boolean;
+  binding_id: string;
+  scope_type: string;
+  scope_id?: string;
+  via_group_id?: string;
+  via_group_name?: string;
+}
+
 /* ── API methods ── */
 
 export async function fetchRoles(): Promise<Role[]> {
@@ -80,6 +93,15 @@ export async function createRole(req: CreateRoleRequest): Promise<Role> {
   });
 }
 
+/** Copies a role and its permissions; without a name the server derives "<name> (copy)". */
+export async function cloneRole(id: string, name?: string): Promise<Role> {
+  return apiRequest<Role>(`/api/v1/roles/${encodeURIComponent(id)}/clone`, {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify(name ? { name } : {}),
+  });
+}
+
 export async function updateRole(
   id: string,
   req: UpdateRoleRequest
@@ -113,6 +135,13 @@ export async function fetchRolePermissions(
   );
 }
 
+export async function fetchRoleUsers(roleId: string): Promise<RoleHolder[]> {
+  return apiRequest<RoleHolder[]>(
+    `/api/v1/roles/${encodeURIComponent(roleId)}/users`,
+    { headers: authHeaders() }
+  );
+}
+
 export async function setRolePermissions(
   roleId: string,
   permissionIds: string[]
@@ -10,12 +10,13 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
     throw new Error(detail);
   }
+  if (res.status === 204) return undefined as unknown as T;
   return res.json();
 }
 
@@ -42,3 +43,77 @@ export async function updateAgentNotificationSettings(
     body: JSON.stringify(settings),
   });
 }
+
+/* ── Webhooks ── */
+
+export type WebhookEventType = "policy.changed" | "binding.changed";
+
+export interface Webhook {
+  id: string;
+  name: string;
+  url: string;
+  events: WebhookEventType[];
+  enabled: boolean;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateWebhookRequest {
+  name: string;
+  url: string;
+  secret: string;
+  events: WebhookEventType[];
+  enabled?: boolean;
+}
+
+export interface UpdateWebhookRequest {
+  name?: string;
+  url?: string;
+  secret?: string;
+  events?: WebhookEventType[];
+  enabled?: boolean;
+}
+
+export interface WebhookDeliveryResult {
+  webhook_id: string;
+  name: string;
+  status_code?: number;
+  error?: string;
+}
+
+export async function fetchWebhooks(): Promise<Webhook[]> {
+  return apiRequest<Webhook[]>("/api/v1/settings/webhooks", {
+    headers: authHeaders(),
+  });
+}
+
+export async function createWebhook(req: CreateWebhookRequest): Promise<Webhook> {
+  return apiRequest<Webhook>("/api/v1/settings/webhooks", {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify(req),
+  });
+}
+
+export async function updateWebhook(id: string, req: UpdateWebhookRequest): Promise<Webhook> {
+  return apiRequest<Webhook>(`/api/v1/settings/webhooks/${id}`, {
+    method: "PUT",
+    headers: authHeaders(),
+    body: JSON.stringify(req),
+  });
+}
+
+export async function deleteWebhook(id: string): Promise<void> {
+  return apiRequest<void>(`/api/v1/settings/webhooks/${id}`, {
+    method: "DELETE",
+    headers: authHeaders(),
+  });
+}
+
+export async function testWebhooks(webhookId?: string): Promise<WebhookDeliveryResult[]> {
+  return apiRequest<WebhookDeliveryResult[]>("/api/v1/settings/webhooks/test", {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify(webhookId ? { webhook_id: webhookId } : {}),
+  });
+}
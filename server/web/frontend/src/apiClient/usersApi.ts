@@ -10,7 +10,7 @@ async function apiRequest<T>(url: string, init?: RequestInit): Promise<T> {
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -25,7 +25,7 @@ async function apiRequestNoBody(url: string, init?: RequestInit): Promise<void>
     let detail = res.statusText;
     try {
       const b = await res.json();
-      if (b.error) detail = b.error;
+      if (b.error?.message) detail = b.error.message;
     } catch {
       /* swallow */
     }
@@ -42,6 +42,9 @@ export interface User {
   full_name: string;
   source: string;
   enabled: boolean;
+  failed_attempts?: number;
+  locked_until?: string;
+  must_change_password?: boolean;
   created_at: string;
   updated_at: string;
 }
@@ -65,6 +68,7 @@ export interface UserRoleBinding {
   role_id: string;
   scope_type: string;
   scope_id?: string;
+  source?: "manual" | "ldap";
   created_at: string;
 }
 
@@ -75,6 +79,25 @@ export interface CreateBindingRequest {
   scope_id?: string;
 }
 
+/** A role binding granting a permission, direct or via a user group. */
+export interface PermissionGrant {
+  binding_id: string;
+  role_id: string;
+  role_name: string;
+  via_group_id?: string;
+  via_group_name?: string;
+}
+
+export interface EffectivePermissions {
+  user_id: string;
+  username: string;
+  scopes: {
+    scope_type: string;
+    scope_id?: string;
+    permissions: { permission: string; grants: PermissionGrant[] }[];
+  }[];
+}
+
 /* ── API methods ── */
 
 export async function fetchUsers(): Promise<User[]> {
@@ -113,6 +136,27 @@ export async function deleteUser(id: string): Promise<void> {
   });
 }
 
+export async function resetUserPassword(
+  id: string,
+  newPassword: string,
+  mustChange: boolean
+): Promise<void> {
+  return apiRequestNoBody(`/api/v1/users/${encodeURIComponent(id)}/reset-password`, {
+    method: "POST",
+    headers: authHeaders(),
+    body: JSON.stringify({ new_password: newPassword, must_change: mustChange }),
+  });
+}
+
+export async function fetchEffectivePermissions(
+  userId: string
+): Promise<EffectivePermissions> {
+  return apiRequest<EffectivePermissions>(
+    `/api/v1/users/${encodeURIComponent(userId)}/effective-permissions`,
+    { headers: authHeaders() }
+  );
+}
+
 export async function fetchUserBindings(
   userId: string
 ): Promise<UserRoleBinding[]> {
@@ -10,6 +10,7 @@ import type { DConfPolicy } from "./dconf";
 import type { FirefoxPolicy } from "./firefox";
 import type { KConfigPolicy } from "./kconfig";
 import type { PolkitPolicy } from "./polkit";
+import type { SysctlPolicy } from "./sysctl";
 
 export const protobufPackage = "bor.policy.v1";
 
@@ -56,8 +57,9 @@ export interface Policy {
   kconfig_policy?: KConfigPolicy | undefined;
   chrome_policy?: ChromePolicy | undefined;
   dconf_policy?: DConfPolicy | undefined;
-  polkit_policy?:
-    | PolkitPolicy
+  polkit_policy?: PolkitPolicy | undefined;
+  sysctl_policy?:
+    | SysctlPolicy
     | undefined;
   /**
    * Binding priority delivered to the agent. Equals the maximum priority
@@ -0,0 +1,33 @@
+// Code generated by protoc-gen-ts_proto. DO NOT EDIT.
+// versions:
+//   protoc-gen-ts_proto  v2.11.5
+//   protoc               v7.34.1
+// source: sysctl.proto
+
+/* eslint-disable */
+
+export const protobufPackage = "bor.policy.v1";
+
+/**
+ * SysctlPolicy sets kernel tunables. The agent merges every Sysctl policy
+ * assigned to the node into /etc/sysctl.d/99-bor.conf and runs
+ * `sysctl --system` to apply it.
+ */
+export interface SysctlPolicy {
+  entries: SysctlEntry[];
+}
+
+/**
+ * SysctlEntry is a single kernel parameter, e.g. key "net.ipv4.ip_forward"
+ * with value "0". Keys may use "." or "/" as the separator.
+ */
+export interface SysctlEntry {
+  key: string;
+  value: string;
+  /**
+   * Binding priority of the policy the entry came from. Set by the agent
+   * before merging; when two policies set the same key the higher priority
+   * wins.
+   */
+  priority: number;
+}
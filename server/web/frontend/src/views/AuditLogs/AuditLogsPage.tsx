@@ -50,10 +50,10 @@ import {
 
 // ─── Known filter values ──────────────────────────────────────────────────────
 
-const KNOWN_ACTIONS = ["create", "update", "delete", "tamper_detected"];
+const KNOWN_ACTIONS = ["create", "update", "delete", "purge", "enroll", "deliver", "tamper_detected"];
 const KNOWN_RESOURCE_TYPES = [
   "policies", "nodes", "node-groups", "users", "roles",
-  "user-groups", "policy-bindings", "managed_file", "settings",
+  "user-groups", "policy-bindings", "managed_file", "settings", "audit-logs",
 ];
 
 // ─── Color definitions ────────────────────────────────────────────────────────
@@ -72,6 +72,16 @@ const FILTER_TYPE_COLORS: Record<FilterType, ColorStyle> = {
   action:        { bg: "#6753ac", color: "#fff" },
   resource_type: { bg: "#009596", color: "#fff" },
   username:      { bg: "#4f5d75", color: "#fff" },
+  q:             { bg: "#8f4700", color: "#fff" },
+  from:          { bg: "#3c3f42", color: "#fff" },
+  to:            { bg: "#3c3f42", color: "#fff" },
+};
+
+// Chips of these types are shown with a prefix, e.g. "from 2026-01-01".
+const FILTER_TYPE_PREFIX: Partial<Record<FilterType, string>> = {
+  q: "contains ",
+  from: "from ",
+  to: "to ",
 };
 
 function chipColor(type: FilterType, value: string): ColorStyle {
@@ -81,7 +91,7 @@ function chipColor(type: FilterType, value: string): ColorStyle {
 
 // ─── Filter chip types ────────────────────────────────────────────────────────
 
-type FilterType = "action" | "resource_type" | "username";
+type FilterType = "action" | "resource_type" | "username" | "q" | "from" | "to";
 
 interface FilterChip { id: string; type: FilterType; value: string }
 
@@ -174,7 +184,7 @@ const FilterPill: React.FC<{ chip: FilterChip; onRemove: () => void }> = ({ chip
       background: bg, color, borderRadius: 9999,
       padding: "2px 6px 2px 10px", fontSize: "0.8rem", fontWeight: 500,
     }}>
-      {chip.value}
+      {(FILTER_TYPE_PREFIX[chip.type] ?? "") + chip.value}
       <button
         aria-label={`Remove filter ${chip.value}`}
         onClick={onRemove}
@@ -455,6 +465,7 @@ export const AuditLogsPage: React.FC = () => {
   const [total, setTotal] = useState(0);
   const [filters, setFilters] = useState<FilterChip[]>([]);
   const [usernameInput, setUsernameInput] = useState("");
+  const [searchInput, setSearchInput] = useState("");
   const [exporting, setExporting] = useState(false);
   const [selectedEntry, setSelectedEntry] = useState<AuditLog | null>(null);
 
@@ -463,6 +474,9 @@ export const AuditLogsPage: React.FC = () => {
   const activeActions = filters.filter((f) => f.type === "action").map((f) => f.value);
   const activeResourceTypes = filters.filter((f) => f.type === "resource_type").map((f) => f.value);
   const activeUsernames = filters.filter((f) => f.type === "username").map((f) => f.value);
+  const activeSearch = filters.find((f) => f.type === "q")?.value;
+  const activeFrom = filters.find((f) => f.type === "from")?.value;
+  const activeTo = filters.find((f) => f.type === "to")?.value;
 
   const addFilter = (type: FilterType, value: string) => {
     if (filters.some((f) => f.type === type && f.value === value)) return;
@@ -475,8 +489,34 @@ export const AuditLogsPage: React.FC = () => {
     setPage(1);
   };
 
+  // Search text and date bounds take a single value: a new one replaces
+  // the old chip, an empty one removes it.
+  const setSingleFilter = (type: FilterType, value: string) => {
+    setFilters((prev) => [
+      ...prev.filter((f) => f.type !== type),
+      ...(value ? [{ id: nextChipId(), type, value }] : []),
+    ]);
+    setPage(1);
+  };
+
   const clearAllFilters = () => { setFilters([]); setPage(1); };
 
+  const commitSearch = () => {
+    const v = searchInput.trim();
+    if (v) { setSingleFilter("q", v); setSearchInput(""); }
+  };
+
+  const filterParams = (): AuditLogListParams => {
+    const params: AuditLogListParams = {};
+    if (activeActions.length > 0) params.action = activeActions;
+    if (activeResourceTypes.length > 0) params.resource_type = activeResourceTypes;
+    if (activeUsernames.length > 0) params.username = activeUsernames[0];
+    if (activeSearch) params.q = activeSearch;
+    if (activeFrom) params.from = activeFrom;
+    if (activeTo) params.to = activeTo;
+    return params;
+  };
+
   const commitUsername = () => {
     const v = usernameInput.trim();
     if (v) { addFilter("username", v); setUsernameInput(""); }
@@ -485,11 +525,7 @@ export const AuditLogsPage: React.FC = () => {
   const reload = useCallback(() => {
     setLoading(true);
     setError(null);
-    const params: AuditLogListParams = { page, per_page: perPage };
-    if (activeActions.length > 0) params.action = activeActions;
-    if (activeResourceTypes.length > 0) params.resource_type = activeResourceTypes;
-    if (activeUsernames.length > 0) params.username = activeUsernames[0];
-    fetchAuditLogs(params)
+    fetchAuditLogs({ ...filterParams(), page, per_page: perPage })
       .then((resp) => { setLogs(resp.items || []); setTotal(resp.total); })
       .catch((e) => setError(e.message))
       .finally(() => setLoading(false));
@@ -502,11 +538,7 @@ export const AuditLogsPage: React.FC = () => {
     setExporting(true);
     setError(null);
     try {
-      const params: AuditLogListParams = {};
-      if (activeActions.length > 0) params.action = activeActions;
-      if (activeResourceTypes.length > 0) params.resource_type = activeResourceTypes;
-      if (activeUsernames.length > 0) params.username = activeUsernames[0];
-      await exportAuditLogs(format, params);
+      await exportAuditLogs(format, filterParams());
     } catch (e: unknown) {
       setError(e instanceof Error ? e.message : "Export failed");
     } finally {
@@ -550,6 +582,26 @@ export const AuditLogsPage: React.FC = () => {
                       </FlexItem>
                     </Flex>
                   </ToolbarItem>
+                  <ToolbarItem>
+                    <TextInput type="search" aria-label="Search details and resource IDs" placeholder="Search…" value={searchInput}
+                      onChange={(_ev, v) => setSearchInput(v)}
+                      onKeyUp={(e) => { if (e.key === "Enter") commitSearch(); }}
+                      style={{ width: 180 }}
+                    />
+                  </ToolbarItem>
+                  <ToolbarItem>
+                    <Flex spaceItems={{ default: "spaceItemsSm" }} alignItems={{ default: "alignItemsCenter" }}>
+                      <FlexItem>
+                        <TextInput type="date" aria-label="From date" value={activeFrom ?? ""}
+                          onChange={(_ev, v) => setSingleFilter("from", v)} style={{ width: 150 }} />
+                      </FlexItem>
+                      <FlexItem>–</FlexItem>
+                      <FlexItem>
+                        <TextInput type="date" aria-label="To date" value={activeTo ?? ""}
+                          onChange={(_ev, v) => setSingleFilter("to", v)} style={{ width: 150 }} />
+                      </FlexItem>
+                    </Flex>
+                  </ToolbarItem>
                   <ToolbarItem>
                     <Button variant="plain" aria-label="Refresh" onClick={reload} isDisabled={loading}>
                       <SyncIcon />
@@ -23,16 +23,19 @@ import {
 } from "@patternfly/react-core";
 import { Table, Thead, Tr, Th, Tbody, Td, ExpandableRowContent } from "@patternfly/react-table";
 import SyncAltIcon from "@patternfly/react-icons/dist/esm/icons/sync-alt-icon";
+import DownloadIcon from "@patternfly/react-icons/dist/esm/icons/download-icon";
 
 import { LiveAlert } from "../../components/LiveAlert";
 import {
   fetchComplianceResults,
   fetchDConfSchemas,
+  exportComplianceReport,
   ComplianceResult,
   ComplianceStatus,
   ComplianceItem,
   DConfSchema,
 } from "../../apiClient/dconfApi";
+import { hasPermission } from "../../apiClient/permissions";
 
 /* ── helpers ── */
 
@@ -141,6 +144,20 @@ export const CompliancePage: React.FC = () => {
   const [searchText, setSearchText] = useState("");
   const [statusFilter, setStatusFilter] = useState<string>("All");
   const [statusOpen, setStatusOpen] = useState(false);
+  const [exporting, setExporting] = useState(false);
+  const canExport = hasPermission("compliance:export");
+
+  const handleExport = async (format: "csv" | "json") => {
+    setExporting(true);
+    setError(null);
+    try {
+      await exportComplianceReport(format);
+    } catch (e: unknown) {
+      setError(e instanceof Error ? e.message : "Export failed");
+    } finally {
+      setExporting(false);
+    }
+  };
 
   const load = useCallback(async (silent = false) => {
     try {
@@ -213,6 +230,16 @@ export const CompliancePage: React.FC = () => {
           <Title headingLevel="h1" size="xl">Compliance</Title>
         </FlexItem>
         <FlexItem>
+          {canExport && (
+            <>
+              <Button variant="secondary" icon={<DownloadIcon />} onClick={() => handleExport("csv")} isDisabled={exporting} isLoading={exporting} style={{ marginRight: "0.5rem" }}>
+                Export CSV
+              </Button>
+              <Button variant="secondary" icon={<DownloadIcon />} onClick={() => handleExport("json")} isDisabled={exporting} isLoading={exporting} style={{ marginRight: "0.5rem" }}>
+                Export JSON
+              </Button>
+            </>
+          )}
           <Button
             variant="plain"
             onClick={() => load(true)}
@@ -34,10 +34,11 @@ const StatCard: React.FC<{
   title: string;
   value: number | string;
   icon?: React.ReactNode;
-  color?: "green" | "red" | "blue" | "grey";
+  color?: "green" | "orange" | "red" | "blue" | "grey";
 }> = ({ title, value, icon, color }) => {
   const colorMap: Record<string, string> = {
     green: "var(--pf-v5-global--success-color--100)",
+    orange: "var(--pf-v5-global--warning-color--100)",
     red: "var(--pf-v5-global--danger-color--100)",
     blue: "var(--pf-v5-global--info-color--100)",
     grey: "var(--pf-v5-global--Color--200)",
@@ -118,7 +119,7 @@ export const FleetOverviewSection: React.FC<FleetOverviewSectionProps> = ({ data
         Fleet Overview
       </Title>
       <Grid hasGutter>
-        <GridItem span={3}>
+        <GridItem span={2}>
           <StatCard title="Total Nodes" value={data.totalNodes} color="blue" />
         </GridItem>
         <GridItem span={3}>
@@ -129,6 +130,14 @@ export const FleetOverviewSection: React.FC<FleetOverviewSectionProps> = ({ data
             color="green"
           />
         </GridItem>
+        <GridItem span={2}>
+          <StatCard
+            title="Degraded"
+            value={data.degraded}
+            icon={<ExclamationTriangleIcon />}
+            color="orange"
+          />
+        </GridItem>
         <GridItem span={3}>
           <StatCard
             title="Offline"
@@ -137,7 +146,7 @@ export const FleetOverviewSection: React.FC<FleetOverviewSectionProps> = ({ data
             color="red"
           />
         </GridItem>
-        <GridItem span={3}>
+        <GridItem span={2}>
           <StatCard
             title="Unknown"
             value={data.unknown}
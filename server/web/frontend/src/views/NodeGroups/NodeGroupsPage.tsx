@@ -21,6 +21,7 @@ import {
   FormGroup,
   TextInput,
   TextArea,
+  NumberInput,
   ActionGroup,
   EmptyState,
   EmptyStateBody,
@@ -45,6 +46,8 @@ import {
   updateNodeGroup,
   deleteNodeGroup,
   generateEnrollmentToken,
+  revokeEnrollmentToken,
+  MAX_ENROLLMENT_TOKEN_USES,
   NodeGroup,
   EnrollmentToken,
 } from "../../apiClient/nodeGroupsApi";
@@ -84,6 +87,8 @@ export const NodeGroupsPage: React.FC = () => {
   const [generatedToken, setGeneratedToken] = useState<EnrollmentToken | null>(null);
   const [tokenLoading, setTokenLoading] = useState(false);
   const [tokenError, setTokenError] = useState<string | null>(null);
+  const [tokenMaxUses, setTokenMaxUses] = useState(1);
+  const [tokenRevoked, setTokenRevoked] = useState(false);
 
   /* ── Load data ── */
   const loadGroups = useCallback(async () => {
@@ -207,6 +212,8 @@ export const NodeGroupsPage: React.FC = () => {
     setGeneratedToken(null);
     setTokenError(null);
     setTokenLoading(false);
+    setTokenMaxUses(1);
+    setTokenRevoked(false);
   };
 
   const handleGenerateToken = async () => {
@@ -214,7 +221,7 @@ export const NodeGroupsPage: React.FC = () => {
     try {
       setTokenLoading(true);
       setTokenError(null);
-      const token = await generateEnrollmentToken(tokenGroup.id);
+      const token = await generateEnrollmentToken(tokenGroup.id, tokenMaxUses);
       setGeneratedToken(token);
     } catch (err) {
       setTokenError(err instanceof Error ? err.message : "Failed to generate token");
@@ -223,6 +230,20 @@ export const NodeGroupsPage: React.FC = () => {
     }
   };
 
+  const handleRevokeToken = async () => {
+    if (!tokenGroup || !generatedToken) return;
+    try {
+      setTokenLoading(true);
+      setTokenError(null);
+      await revokeEnrollmentToken(tokenGroup.id, generatedToken.id);
+      setTokenRevoked(true);
+    } catch (err) {
+      setTokenError(err instanceof Error ? err.message : "Failed to revoke token");
+    } finally {
+      setTokenLoading(false);
+    }
+  };
+
   /* ── Render ── */
   if (loading) {
     return (
@@ -523,13 +544,35 @@ export const NodeGroupsPage: React.FC = () => {
           {!generatedToken ? (
             <div>
               <p>
-                Generate a one-time enrollment token for the node group{" "}
+                Generate an enrollment token for the node group{" "}
                 <strong>{tokenGroup?.name}</strong>.
               </p>
+              <FormGroup label="Number of uses" fieldId="token-max-uses" style={{ marginTop: "1rem" }}>
+                <NumberInput
+                  id="token-max-uses"
+                  value={tokenMaxUses}
+                  min={1}
+                  max={MAX_ENROLLMENT_TOKEN_USES}
+                  onMinus={() => setTokenMaxUses((n) => Math.max(1, n - 1))}
+                  onPlus={() => setTokenMaxUses((n) => Math.min(MAX_ENROLLMENT_TOKEN_USES, n + 1))}
+                  onChange={(e) => {
+                    const n = Number((e.target as HTMLInputElement).value);
+                    setTokenMaxUses(Number.isNaN(n) ? 1 : Math.min(MAX_ENROLLMENT_TOKEN_USES, Math.max(1, Math.floor(n))));
+                  }}
+                  inputName="token-max-uses"
+                  inputAriaLabel="Number of machines that may enroll with the token"
+                  minusBtnAriaLabel="Decrease uses"
+                  plusBtnAriaLabel="Increase uses"
+                />
+              </FormGroup>
               <Alert variant="info" title="Token details" isInline style={{ marginTop: "1rem" }}>
                 <ul style={{ margin: 0, paddingLeft: "1.25rem" }}>
                   <li>The token expires in <strong>5 minutes</strong></li>
-                  <li>The token is <strong>single-use</strong> — it can only be used once</li>
+                  <li>
+                    The token can enroll up to <strong>{tokenMaxUses}</strong>{" "}
+                    {tokenMaxUses === 1 ? "machine" : "machines"} — e.g. several machines
+                    deployed from one image
+                  </li>
                   <li>Copy the token immediately — it will not be shown again</li>
                 </ul>
               </Alert>
@@ -544,8 +587,10 @@ export const NodeGroupsPage: React.FC = () => {
                   style={{ marginBottom: "1rem" }}
                 >
                   Copy the token below. It will <strong>expire at{" "}
-                  {formatDate(generatedToken.expires_at)}</strong> and can only be used{" "}
-                  <strong>once</strong>.
+                  {formatDate(generatedToken.expires_at)}</strong> and can be used{" "}
+                  <strong>
+                    {generatedToken.max_uses === 1 ? "once" : `${generatedToken.max_uses} times`}
+                  </strong>.
                 </Alert>
               </div>
               <FormGroup label="Enrollment Command" fieldId="enroll-command">
@@ -566,23 +611,40 @@ export const NodeGroupsPage: React.FC = () => {
                   {generatedToken.token}
                 </ClipboardCopy>
               </FormGroup>
-              <Alert variant="warning" title="Save this token now" isInline style={{ marginTop: "1rem" }}>
-                This token will not be shown again after closing this dialog. Generate a new
-                token if needed.
-              </Alert>
+              {tokenRevoked ? (
+                <Alert variant="danger" title="Token revoked" isInline style={{ marginTop: "1rem" }}>
+                  This token can no longer be used to enroll agents.
+                </Alert>
+              ) : (
+                <Alert variant="warning" title="Save this token now" isInline style={{ marginTop: "1rem" }}>
+                  This token will not be shown again after closing this dialog. Generate a new
+                  token if needed, and revoke this one if it was exposed.
+                </Alert>
+              )}
             </div>
           )}
         </ModalBody>
         <ModalFooter>
           {generatedToken
             ? (
-                <Button
-                  key="close"
-                  variant="primary"
-                  onClick={() => { setTokenGroup(null); setGeneratedToken(null); }}
-                >
-                  Done
-                </Button>
+                <>
+                  <Button
+                    key="close"
+                    variant="primary"
+                    onClick={() => { setTokenGroup(null); setGeneratedToken(null); }}
+                  >
+                    Done
+                  </Button>
+                  <Button
+                    key="revoke"
+                    variant="danger"
+                    onClick={handleRevokeToken}
+                    isLoading={tokenLoading}
+                    isDisabled={tokenLoading || tokenRevoked}
+                  >
+                    Revoke Token
+                  </Button>
+                </>
               )
             : (
                 <>
@@ -69,12 +69,13 @@ import { fetchNodeGroups, NodeGroup } from "../../apiClient/nodeGroupsApi";
 
 /* ── Helpers ── */
 
-const STATUS_OPTIONS: NodeStatus[] = ["online", "offline", "unknown"];
+const STATUS_OPTIONS: NodeStatus[] = ["online", "degraded", "offline", "unknown"];
 const MAX_NOTES_DISPLAY_LENGTH = 30;
 
-const statusColor = (status: NodeStatus): "green" | "red" | "grey" => {
+const statusColor = (status: NodeStatus): "green" | "orange" | "red" | "grey" => {
   switch (status) {
     case "online":  return "green";
+    case "degraded": return "orange";
     case "offline": return "red";
     default:        return "grey";
   }
@@ -84,6 +85,7 @@ const statusTooltip = (status: NodeStatus, reason?: string): string => {
   if (reason) return reason;
   switch (status) {
     case "online":  return "Agent stream connected";
+    case "degraded": return "No recent heartbeat from the agent";
     case "offline": return "Agent stream disconnected";
     case "unknown": return "Never connected or enrollment pending";
     default:        return "";
@@ -105,6 +107,27 @@ const timeAgo = (dateStr?: string): string => {
   return `${days}d ago`;
 };
 
+const formatBytes = (bytes?: number): string => {
+  if (!bytes) return "—";
+  const units = ["B", "KiB", "MiB", "GiB", "TiB"];
+  let i = 0;
+  let v = bytes;
+  while (v >= 1024 && i < units.length - 1) {
+    v /= 1024;
+    i++;
+  }
+  return `${v.toFixed(i === 0 ? 0 : 1)} ${units[i]}`;
+};
+
+const uptimeDisplay = (bootTime?: string): string => {
+  if (!bootTime) return "—";
+  const mins = Math.floor((Date.now() - new Date(bootTime).getTime()) / 60000);
+  if (mins < 60) return `${Math.max(mins, 0)}m`;
+  const hours = Math.floor(mins / 60);
+  if (hours < 24) return `${hours}h ${mins % 60}m`;
+  return `${Math.floor(hours / 24)}d ${hours % 24}h`;
+};
+
 type SortField = "last_seen" | "name";
 
 /* ── Component ── */
@@ -500,9 +523,62 @@ export const NodesPage: React.FC = () => {
               <DescriptionListTerm>Desktop</DescriptionListTerm>
               <DescriptionListDescription>{selectedNode.desktop_env || "—"}</DescriptionListDescription>
             </DescriptionListGroup>
+            <DescriptionListGroup>
+              <DescriptionListTerm>Kernel</DescriptionListTerm>
+              <DescriptionListDescription>{selectedNode.kernel_version || "—"}</DescriptionListDescription>
+            </DescriptionListGroup>
+            <DescriptionListGroup>
+              <DescriptionListTerm>Firefox</DescriptionListTerm>
+              <DescriptionListDescription>{selectedNode.firefox_version || "—"}</DescriptionListDescription>
+            </DescriptionListGroup>
+            <DescriptionListGroup>
+              <DescriptionListTerm>Chrome</DescriptionListTerm>
+              <DescriptionListDescription>{selectedNode.chrome_version || "—"}</DescriptionListDescription>
+            </DescriptionListGroup>
+            <DescriptionListGroup>
+              <DescriptionListTerm>CPU</DescriptionListTerm>
+              <DescriptionListDescription>
+                {[selectedNode.hardware?.cpu_model, selectedNode.hardware?.cpu_cores && `${selectedNode.hardware.cpu_cores} cores`]
+                  .filter(Boolean).join(", ") || "—"}
+              </DescriptionListDescription>
+            </DescriptionListGroup>
+            <DescriptionListGroup>
+              <DescriptionListTerm>Memory</DescriptionListTerm>
+              <DescriptionListDescription>
+                {selectedNode.hardware?.memory_total_bytes
+                  ? `${formatBytes(selectedNode.hardware.memory_available_bytes)} available of ${formatBytes(selectedNode.hardware.memory_total_bytes)}`
+                  : "—"}
+              </DescriptionListDescription>
+            </DescriptionListGroup>
+            <DescriptionListGroup>
+              <DescriptionListTerm>Root Disk</DescriptionListTerm>
+              <DescriptionListDescription>
+                {selectedNode.hardware?.root_disk_total_bytes
+                  ? `${formatBytes(selectedNode.hardware.root_disk_free_bytes)} free of ${formatBytes(selectedNode.hardware.root_disk_total_bytes)}`
+                  : "—"}
+                {selectedNode.low_disk && (
+                  <>
+                    {" "}
+                    <Label color="orange" isCompact>Low disk</Label>
+                  </>
+                )}
+              </DescriptionListDescription>
+            </DescriptionListGroup>
+            <DescriptionListGroup>
+              <DescriptionListTerm>Uptime</DescriptionListTerm>
+              <DescriptionListDescription>{uptimeDisplay(selectedNode.hardware?.boot_time)}</DescriptionListDescription>
+            </DescriptionListGroup>
             <DescriptionListGroup>
               <DescriptionListTerm>Agent Version</DescriptionListTerm>
-              <DescriptionListDescription>{selectedNode.agent_version || "—"}</DescriptionListDescription>
+              <DescriptionListDescription>
+                {selectedNode.agent_version || "—"}
+                {selectedNode.agent_outdated && (
+                  <>
+                    {" "}
+                    <Label color="red" isCompact>Upgrade required</Label>
+                  </>
+                )}
+              </DescriptionListDescription>
             </DescriptionListGroup>
             <DescriptionListGroup>
               <DescriptionListTerm>Node Groups</DescriptionListTerm>
@@ -923,7 +999,14 @@ export const NodesPage: React.FC = () => {
                             isSelected: selectedIds.has(node.id),
                           }}
                         />
-                        <Td dataLabel="Node">{node.name}</Td>
+                        <Td dataLabel="Node">
+                          {node.name}
+                          {node.low_disk && (
+                            <Tooltip content="Less than 10% of the root filesystem is free.">
+                              <Label color="orange" isCompact style={{ marginLeft: "0.5rem" }}>Low disk</Label>
+                            </Tooltip>
+                          )}
+                        </Td>
                         <Td dataLabel="Status">
                           <Tooltip content={statusTooltip(node.status, node.status_reason)}>
                             <Label color={statusColor(node.status)}>{node.status}</Label>
@@ -932,7 +1015,14 @@ export const NodesPage: React.FC = () => {
                         <Td dataLabel="Groups">{node.node_group_names?.join(", ") || "—"}</Td>
                         <Td dataLabel="OS">{osDisplay(node) || "—"}</Td>
                         <Td dataLabel="Last Seen">{timeAgo(node.last_seen)}</Td>
-                        <Td dataLabel="Agent">{node.agent_version || "—"}</Td>
+                        <Td dataLabel="Agent">
+                          {node.agent_version || "—"}
+                          {node.agent_outdated && (
+                            <Tooltip content="This agent is older than the minimum version the server accepts and receives no policies until it is upgraded.">
+                              <Label color="red" isCompact style={{ marginLeft: "0.5rem" }}>Upgrade required</Label>
+                            </Tooltip>
+                          )}
+                        </Td>
                         <Td dataLabel="Notes">
                           {node.notes
                             ? node.notes.length > MAX_NOTES_DISPLAY_LENGTH
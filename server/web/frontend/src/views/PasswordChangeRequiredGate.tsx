@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+import React from "react";
+import { Page, PageSection, Card, CardBody, Title, Button } from "@patternfly/react-core";
+import { ChangePasswordSection } from "./Settings/ChangePasswordSection";
+
+interface PasswordChangeRequiredGateProps {
+  onPasswordChanged: () => void;
+  onLogout: () => void;
+}
+
+/** Shown after an admin reset the user's password and asked them to choose a new one. */
+export const PasswordChangeRequiredGate: React.FC<PasswordChangeRequiredGateProps> = ({
+  onPasswordChanged,
+  onLogout,
+}) => {
+  return (
+    <Page>
+      <PageSection
+        isFilled
+        style={{
+          display: "flex",
+          alignItems: "center",
+          justifyContent: "center",
+          minHeight: "100vh",
+        }}
+      >
+        <Card style={{ maxWidth: 520, width: "100%" }}>
+          <CardBody>
+            <Title headingLevel="h1" size="xl" style={{ marginBottom: 8 }}>
+              Choose a new password
+            </Title>
+            <p style={{ marginBottom: 16 }}>
+              An administrator reset your password. Please choose a new password before
+              accessing the application.
+            </p>
+            <ChangePasswordSection onChanged={onPasswordChanged} />
+            <Button variant="link" isInline onClick={onLogout}>
+              Log out
+            </Button>
+          </CardBody>
+        </Card>
+      </PageSection>
+    </Page>
+  );
+};
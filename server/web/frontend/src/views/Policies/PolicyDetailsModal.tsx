@@ -40,10 +40,11 @@ import {
 import { Table, Thead, Tbody, Tr, Th, Td } from "@patternfly/react-table";
 
 import type { Policy, CreatePolicyRequest, UpdatePolicyRequest } from "../../apiClient/policiesApi";
-import { createPolicy, updatePolicy, setPolicyState, deletePolicy } from "../../apiClient/policiesApi";
+import { createPolicy, updatePolicy, setPolicyState, deletePolicy, validatePolicy } from "../../apiClient/policiesApi";
 import type { FirefoxPolicy } from "../../generated/proto/firefox";
 import { DConfPolicyEditor } from "./DConfPolicyEditor";
 import { PolkitPolicyEditor } from "./PolkitPolicyEditor";
+import { SysctlPolicyEditor } from "./SysctlPolicyEditor";
 
 /* ── Known policy types and their config schemas ── */
 
@@ -53,6 +54,7 @@ const POLICY_TYPES: { value: string; label: string; isDisabled?: boolean }[] = [
   { value: "Firefox", label: "Firefox" },
   { value: "Polkit", label: "Polkit" },
   { value: "Chrome", label: "Chrome" },
+  { value: "Sysctl", label: "Sysctl" },
 ];
 
 interface PolicyTypeConfig {
@@ -970,9 +972,19 @@ function buildKConfigContent(policyDef: KConfigPolicyDef, value: string, enforce
     delete parsed.enforcedFields;
   }
 
+  // A field that is set can no longer be deleted.
+  removeFromDeletedFields(parsed, policyDef.key);
+
   return JSON.stringify(parsed, null, 2);
 }
 
+// Drop defKey from the deletedFields list of parsed KConfig content.
+function removeFromDeletedFields(parsed: Record<string, unknown>, defKey: string): void {
+  if (!Array.isArray(parsed.deletedFields)) return;
+  const filtered = (parsed.deletedFields as string[]).filter(f => f !== defKey);
+  if (filtered.length > 0) { parsed.deletedFields = filtered; } else { delete parsed.deletedFields; }
+}
+
 // Remove a KConfig field from the content JSON by its camelCase key.
 function removeKConfigContentKey(defKey: string, existingContent: string): string {
   const parsed: Record<string, unknown> = {};
@@ -985,6 +997,14 @@ function removeKConfigContentKey(defKey: string, existingContent: string): strin
     if (filtered.length > 0) { parsed.enforcedFields = filtered; } else { delete parsed.enforcedFields; }
   }
 
+  if (parsed.fieldScopes && typeof parsed.fieldScopes === "object") {
+    const scopes = { ...(parsed.fieldScopes as Record<string, string>) };
+    delete scopes[defKey];
+    if (Object.keys(scopes).length > 0) { parsed.fieldScopes = scopes; } else { delete parsed.fieldScopes; }
+  }
+
+  removeFromDeletedFields(parsed, defKey);
+
   return JSON.stringify(parsed, null, 2);
 }
 
@@ -1202,6 +1222,20 @@ function buildSettingsRows(policyType: string, content: string): SettingsRow[] {
     return rows;
   }
 
+  if (policyType === "Sysctl") {
+    // Sysctl content shape: { entries: [{key, value},...] }
+    const sysctlContent = raw as { entries?: Record<string, unknown>[] };
+    const entries = Array.isArray(sysctlContent?.entries) ? sysctlContent.entries : [];
+    for (const [idx, entry] of entries.entries()) {
+      rows.push({
+        setting: String(entry["key"] ?? "") || `Entry ${idx + 1}`,
+        value: formatDisplayValue(entry["value"]),
+        locked: null,
+      });
+    }
+    return rows;
+  }
+
   // Known structured types (Polkit, Chrome)
   const config = TYPE_CONFIGS[policyType];
   if (config) {
@@ -1411,6 +1445,8 @@ export const PolicyDetailsModal: React.FC<PolicyDetailsModalProps> = ({
         // Dconf editor reads contentRaw directly — nothing extra to initialise.
       } else if (policy.type === "Polkit") {
         // Polkit editor reads contentRaw directly — nothing extra to initialise.
+      } else if (policy.type === "Sysctl") {
+        // Sysctl editor reads contentRaw directly — nothing extra to initialise.
       } else {
         try {
           const parsed = JSON.parse(policy.content || "{}");
@@ -1478,6 +1514,8 @@ export const PolicyDetailsModal: React.FC<PolicyDetailsModalProps> = ({
       setChromeExpandedGroups(new Set());
     } else if (newType === "Dconf") {
       setContentRaw(JSON.stringify({ entries: [], db_name: "local" }, null, 2));
+    } else if (newType === "Sysctl") {
+      setContentRaw(JSON.stringify({ entries: [] }, null, 2));
     } else {
       setStructuredFieldsList([{}]);
       setContentRaw(JSON.stringify([{}], null, 2));
@@ -1806,6 +1844,24 @@ export const PolicyDetailsModal: React.FC<PolicyDetailsModalProps> = ({
           setSaving(false);
           return;
         }
+      } else if (policyType === "Sysctl") {
+        try {
+          const parsed = JSON.parse(finalContent) as { entries?: { key?: string; value?: string }[] };
+          if (!Array.isArray(parsed.entries) || parsed.entries.length === 0) {
+            setError("At least one sysctl entry must be configured before saving");
+            setSaving(false);
+            return;
+          }
+          if (parsed.entries.some((e) => !e.key || !e.value?.trim())) {
+            setError("Every sysctl entry needs a key and a value");
+            setSaving(false);
+            return;
+          }
+        } catch {
+          setError("Sysctl policy content is not valid JSON");
+          setSaving(false);
+          return;
+        }
       } else {
         try {
           JSON.parse(contentRaw);
@@ -1816,6 +1872,13 @@ export const PolicyDetailsModal: React.FC<PolicyDetailsModalProps> = ({
         }
       }
 
+      const validation = await validatePolicy(policyType, finalContent);
+      if (!validation.valid) {
+        setError(validation.error ?? "Policy content is not valid");
+        setSaving(false);
+        return;
+      }
+
       if (isEditMode && policy) {
         const req: UpdatePolicyRequest = {
           name,
@@ -3118,6 +3181,17 @@ export const PolicyDetailsModal: React.FC<PolicyDetailsModalProps> = ({
         </div>
       );
     }
+    if (policyType === "Sysctl") {
+      return (
+        <div style={{ padding: "1rem 0" }}>
+          <SysctlPolicyEditor
+            contentRaw={contentRaw}
+            onChange={(newRaw) => { setContentRaw(newRaw); }}
+            isDisabled={!isEditable}
+          />
+        </div>
+      );
+    }
 
     return (
     <div style={{ padding: "1rem 0" }}>
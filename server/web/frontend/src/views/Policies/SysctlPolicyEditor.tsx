@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+/**
+ * SysctlPolicyEditor — structured editor for a Sysctl policy.
+ *
+ * Renders a list of kernel parameter rows, each containing:
+ *  - Key   (e.g. net.ipv4.ip_forward)
+ *  - Value (written verbatim after "key = ")
+ *  - Remove button
+ *
+ * The parent passes contentRaw (JSON string) and an onChange callback.
+ * On every change the new JSON is pushed up via onChange.
+ */
+
+import React, { useCallback, useId } from "react";
+import {
+  Button,
+  FormGroup,
+  TextInput,
+} from "@patternfly/react-core";
+import TrashIcon from "@patternfly/react-icons/dist/esm/icons/trash-icon";
+import PlusCircleIcon from "@patternfly/react-icons/dist/esm/icons/plus-circle-icon";
+
+export interface SysctlEntry {
+  key: string;
+  value: string;
+}
+
+export interface SysctlPolicyContent {
+  entries: SysctlEntry[];
+}
+
+/* ── helpers ── */
+
+function parseSysctlContent(raw: string): SysctlPolicyContent {
+  try {
+    const parsed = JSON.parse(raw || "{}") as Partial<SysctlPolicyContent>;
+    return { entries: Array.isArray(parsed.entries) ? parsed.entries : [] };
+  } catch {
+    return { entries: [] };
+  }
+}
+
+/* ── main component ── */
+
+interface SysctlPolicyEditorProps {
+  contentRaw: string;
+  onChange: (newContentRaw: string) => void;
+  isDisabled?: boolean;
+}
+
+export const SysctlPolicyEditor: React.FC<SysctlPolicyEditorProps> = ({
+  contentRaw,
+  onChange,
+  isDisabled,
+}) => {
+  const idPrefix = useId();
+  const content = parseSysctlContent(contentRaw);
+
+  const pushChange = useCallback((updated: SysctlPolicyContent) => {
+    onChange(JSON.stringify(updated, null, 2));
+  }, [onChange]);
+
+  const addEntry = () => {
+    pushChange({ entries: [...content.entries, { key: "", value: "" }] });
+  };
+
+  const removeEntry = (idx: number) => {
+    pushChange({ entries: content.entries.filter((_, i) => i !== idx) });
+  };
+
+  const updateEntry = (idx: number, patch: Partial<SysctlEntry>) => {
+    pushChange({ entries: content.entries.map((e, i) => i === idx ? { ...e, ...patch } : e) });
+  };
+
+  return (
+    <div>
+      <p style={{ color: "var(--pf-t--global--text--color--subtle)", marginBottom: "1rem" }}>
+        Entries are written to /etc/sysctl.d/99-bor.conf and applied with <code>sysctl --system</code>.
+      </p>
+
+      {content.entries.length === 0 && (
+        <p style={{ color: "var(--pf-t--global--text--color--subtle)", marginBottom: "1rem" }}>
+          No entries yet. Click "Add entry" to begin.
+        </p>
+      )}
+
+      {content.entries.map((entry, idx) => {
+        const rowId = `${idPrefix}-row-${idx}`;
+        return (
+          <div
+            key={idx}
+            style={{
+              border: "1px solid var(--pf-t--global--border--color--default)",
+              borderRadius: "6px",
+              padding: "1rem",
+              marginBottom: "0.75rem",
+              background: "var(--pf-t--global--background--color--secondary--default)",
+            }}
+          >
+            <div style={{ display: "grid", gridTemplateColumns: "1fr 1fr auto", gap: "0.75rem", alignItems: "end" }}>
+              <FormGroup label="Key" fieldId={`${rowId}-key`} isRequired>
+                <TextInput
+                  id={`${rowId}-key`}
+                  value={entry.key}
+                  onChange={(_ev, v) => updateEntry(idx, { key: v.trim() })}
+                  placeholder="net.ipv4.ip_forward"
+                  isDisabled={isDisabled}
+                  aria-label="Kernel parameter name"
+                />
+              </FormGroup>
+
+              <FormGroup label="Value" fieldId={`${rowId}-value`} isRequired>
+                <TextInput
+                  id={`${rowId}-value`}
+                  value={entry.value}
+                  onChange={(_ev, v) => updateEntry(idx, { value: v })}
+                  placeholder="0"
+                  isDisabled={isDisabled}
+                  aria-label="Kernel parameter value"
+                />
+              </FormGroup>
+
+              <div>
+                <Button
+                  variant="plain"
+                  onClick={() => removeEntry(idx)}
+                  isDisabled={isDisabled}
+                  aria-label={`Remove entry ${idx + 1}`}
+                  style={{ color: "var(--pf-t--global--color--status--danger--100)" }}
+                >
+                  <TrashIcon />
+                </Button>
+              </div>
+            </div>
+          </div>
+        );
+      })}
+
+      <Button
+        variant="secondary"
+        icon={<PlusCircleIcon />}
+        onClick={addEntry}
+        isDisabled={isDisabled}
+      >
+        Add entry
+      </Button>
+    </div>
+  );
+};
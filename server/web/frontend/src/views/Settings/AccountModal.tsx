@@ -5,13 +5,16 @@
 import React from "react";
 import { Modal, ModalVariant, ModalHeader, ModalBody } from "@patternfly/react-core";
 import { MFATab } from "./MFATab";
+import { ChangePasswordSection } from "./ChangePasswordSection";
 
 interface AccountModalProps {
   isOpen: boolean;
   onClose: () => void;
+  /** Source of the signed-in user; only local users can change their password here. */
+  userSource?: string;
 }
 
-export const AccountModal: React.FC<AccountModalProps> = ({ isOpen, onClose }) => {
+export const AccountModal: React.FC<AccountModalProps> = ({ isOpen, onClose, userSource }) => {
   return (
     <Modal
       variant={ModalVariant.medium}
@@ -20,6 +23,7 @@ export const AccountModal: React.FC<AccountModalProps> = ({ isOpen, onClose }) =
     >
       <ModalHeader title="Account Security" />
       <ModalBody>
+        {userSource === "local" && <ChangePasswordSection />}
         <MFATab />
       </ModalBody>
     </Modal>
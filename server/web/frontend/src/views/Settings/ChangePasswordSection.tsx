@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Copyright (C) 2026 Vute Tech LTD
+// Copyright (C) 2026 Bor contributors
+
+import React, { useState } from "react";
+import {
+  ActionGroup,
+  Alert,
+  Button,
+  Form,
+  FormGroup,
+  FormHelperText,
+  HelperText,
+  HelperTextItem,
+  TextInput,
+  Title,
+} from "@patternfly/react-core";
+import { LiveAlert } from "../../components/LiveAlert";
+import { changePassword, MIN_PASSWORD_LENGTH } from "../../apiClient/authApi";
+
+interface ChangePasswordSectionProps {
+  /** Called after the password was changed. */
+  onChanged?: () => void;
+}
+
+/** Lets a local user change their own password. */
+export const ChangePasswordSection: React.FC<ChangePasswordSectionProps> = ({ onChanged }) => {
+  const [currentPassword, setCurrentPassword] = useState("");
+  const [newPassword, setNewPassword] = useState("");
+  const [confirmPassword, setConfirmPassword] = useState("");
+  const [saving, setSaving] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+  const [changed, setChanged] = useState(false);
+
+  const mismatch = confirmPassword !== "" && confirmPassword !== newPassword;
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (mismatch) return;
+    try {
+      setSaving(true);
+      setError(null);
+      setChanged(false);
+      await changePassword(currentPassword, newPassword);
+      setCurrentPassword("");
+      setNewPassword("");
+      setConfirmPassword("");
+      setChanged(true);
+      onChanged?.();
+    } catch (err) {
+      setError(err instanceof Error ? err.message : "Failed to change password");
+    } finally {
+      setSaving(false);
+    }
+  };
+
+  return (
+    <div style={{ marginBottom: 24 }}>
+      <Title headingLevel="h3" size="md" style={{ marginBottom: 12 }}>
+        Password
+      </Title>
+      <Form onSubmit={handleSubmit} style={{ maxWidth: 420 }}>
+        <LiveAlert message={error} isInline />
+        {changed && (
+          <div aria-live="polite" aria-atomic="true">
+            <Alert variant="success" title="Password changed" isInline />
+          </div>
+        )}
+        <FormGroup label="Current password" isRequired fieldId="current-password">
+          <TextInput
+            id="current-password"
+            type="password"
+            value={currentPassword}
+            onChange={(_ev, v) => setCurrentPassword(v)}
+            autoComplete="current-password"
+            isRequired
+          />
+        </FormGroup>
+        <FormGroup label="New password" isRequired fieldId="new-password">
+          <TextInput
+            id="new-password"
+            type="password"
+            value={newPassword}
+            onChange={(_ev, v) => setNewPassword(v)}
+            autoComplete="new-password"
+            isRequired
+          />
+          <FormHelperText>
+            <HelperText>
+              <HelperTextItem>
+                At least {MIN_PASSWORD_LENGTH} characters, mixing at least three of lowercase
+                letters, uppercase letters, digits and symbols
+              </HelperTextItem>
+            </HelperText>
+          </FormHelperText>
+        </FormGroup>
+        <FormGroup label="Confirm new password" isRequired fieldId="confirm-password">
+          <TextInput
+            id="confirm-password"
+            type="password"
+            value={confirmPassword}
+            onChange={(_ev, v) => setConfirmPassword(v)}
+            autoComplete="new-password"
+            validated={mismatch ? "error" : "default"}
+            isRequired
+          />
+          {mismatch && (
+            <FormHelperText>
+              <HelperText>
+                <HelperTextItem variant="error">Passwords do not match</HelperTextItem>
+              </HelperText>
+            </FormHelperText>
+          )}
+        </FormGroup>
+        <ActionGroup>
+          <Button
+            variant="primary"
+            type="submit"
+            isLoading={saving}
+            isDisabled={saving || !currentPassword || !newPassword || newPassword !== confirmPassword}
+          >
+            Change password
+          </Button>
+        </ActionGroup>
+      </Form>
+    </div>
+  );
+};
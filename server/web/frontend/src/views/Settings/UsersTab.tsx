@@ -16,6 +16,8 @@ import {
   FormGroup,
   TextInput,
   Switch,
+  Checkbox,
+  Alert,
   Tabs,
   Tab,
   TabTitleText,
@@ -33,6 +35,7 @@ import {
   createUser,
   updateUser,
   deleteUser,
+  resetUserPassword,
   fetchUserBindings,
   createBinding,
   deleteBinding,
@@ -41,6 +44,7 @@ import {
   CreateUserRequest,
 } from "../../apiClient/usersApi";
 import { fetchRoles, Role } from "../../apiClient/rolesApi";
+import { MIN_PASSWORD_LENGTH } from "../../apiClient/authApi";
 
 /* ── Users Tab ── */
 
@@ -319,6 +323,16 @@ const EditUserModal: React.FC<{
               <RoleAssignmentsTab userId={user.id} />
             </div>
           </Tab>
+          {user.source === "local" && (
+            <Tab
+              eventKey="password"
+              title={<TabTitleText>Password</TabTitleText>}
+            >
+              <div style={{ padding: "16px 0" }}>
+                <ResetPasswordTab user={user} />
+              </div>
+            </Tab>
+          )}
         </Tabs>
       </ModalBody>
     </Modal>
@@ -389,6 +403,92 @@ const ProfileTab: React.FC<{
   );
 };
 
+/* ── Reset Password Tab ── */
+
+const ResetPasswordTab: React.FC<{ user: User }> = ({ user }) => {
+  const [password, setPassword] = useState("");
+  const [confirmPassword, setConfirmPassword] = useState("");
+  const [mustChange, setMustChange] = useState(true);
+  const [saving, setSaving] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+  const [done, setDone] = useState(false);
+
+  const mismatch = confirmPassword !== "" && confirmPassword !== password;
+
+  const handleReset = async () => {
+    setSaving(true);
+    setError(null);
+    setDone(false);
+    try {
+      await resetUserPassword(user.id, password, mustChange);
+      setPassword("");
+      setConfirmPassword("");
+      setDone(true);
+    } catch (e: unknown) {
+      setError(e instanceof Error ? e.message : "Failed to reset password");
+    } finally {
+      setSaving(false);
+    }
+  };
+
+  return (
+    <>
+      <LiveAlert message={error} isInline style={{ marginBottom: 16 }} />
+      {done && (
+        <div aria-live="polite" aria-atomic="true">
+          <Alert
+            variant="success"
+            title="Password reset"
+            isInline
+            style={{ marginBottom: 16 }}
+          >
+            Give the new password to {user.username} over a secure channel.
+          </Alert>
+        </div>
+      )}
+      <Form>
+        <FormGroup label="New password" isRequired fieldId="rp-password">
+          <TextInput
+            id="rp-password"
+            type="password"
+            value={password}
+            onChange={(_ev, v) => setPassword(v)}
+            autoComplete="new-password"
+          />
+          <p style={{ fontSize: "0.875rem", color: "var(--pf-v5-global--Color--200)", marginTop: 4 }}>
+            At least {MIN_PASSWORD_LENGTH} characters, mixing at least three of lowercase
+            letters, uppercase letters, digits and symbols
+          </p>
+        </FormGroup>
+        <FormGroup label="Confirm new password" isRequired fieldId="rp-confirm">
+          <TextInput
+            id="rp-confirm"
+            type="password"
+            value={confirmPassword}
+            onChange={(_ev, v) => setConfirmPassword(v)}
+            autoComplete="new-password"
+            validated={mismatch ? "error" : "default"}
+          />
+        </FormGroup>
+        <Checkbox
+          id="rp-must-change"
+          label="Require the user to choose a new password after logging in"
+          isChecked={mustChange}
+          onChange={(_ev, v) => setMustChange(v)}
+        />
+        <Button
+          variant="primary"
+          onClick={handleReset}
+          isDisabled={saving || !password || password !== confirmPassword}
+          isLoading={saving}
+        >
+          Reset password
+        </Button>
+      </Form>
+    </>
+  );
+};
+
 /* ── Role Assignments Tab ── */
 
 const RoleAssignmentsTab: React.FC<{ userId: string }> = ({ userId }) => {
@@ -487,7 +587,14 @@ const RoleAssignmentsTab: React.FC<{ userId: string }> = ({ userId }) => {
         <Tbody>
           {bindings.map((b) => (
             <Tr key={b.id}>
-              <Td>{roleName(b.role_id)}</Td>
+              <Td>
+                {roleName(b.role_id)}
+                {b.source === "ldap" && (
+                  <Label color="blue" isCompact style={{ marginLeft: 8 }}>
+                    LDAP group
+                  </Label>
+                )}
+              </Td>
               <Td>
                 <Label>{b.scope_type}</Label>
               </Td>